@@ -50,6 +50,12 @@ Use --with-note for partial acceptance (accept with a recorded note):
 Notes are recorded in the ledger for the audit trail but do not
 block acceptance. This allows verifiers to express nuanced feedback.
 
+A node can only be accepted once every node it depends on is validated
+or admitted. Workflows that deliberately validate bottom-up can skip
+this check with --allow-unvalidated-deps. Validation dependencies (set
+with 'af refine --requires-validated') must always be validated; an
+admitted validation dependency blocks acceptance.
+
 If you provide --agent, the tool will check if you have raised any
 challenges for the node. Accepting without having raised any challenges
 requires --confirm to ensure thorough verification.
@@ -81,6 +87,7 @@ Workflow:
 	cmd.Flags().StringVar(&withNote, "with-note", "", "Optional acceptance note for partial acceptance")
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm acceptance without having raised challenges")
 	cmd.Flags().StringVar(&agent, "agent", "", "Agent ID (verifier identity for challenge verification)")
+	cmd.Flags().Bool("allow-unvalidated-deps", false, "Accept even if reference dependencies are not yet validated")
 
 	return cmd
 }
@@ -305,10 +312,13 @@ func runAccept(cmd *cobra.Command, args []string, acceptAll bool, withNote strin
 		return err
 	}
 
-	svc, err := service.NewProofService(dir)
+	svc, err := service.NewProofService(dir, service.WithAutoTaint(isAutoTaint(cmd)))
 	if err != nil {
 		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
+	if cli.MustBool(cmd, "allow-unvalidated-deps") {
+		svc.SetRequireValidatedDependencies(false)
+	}
 
 	nodeIDs, err := getNodeIDsToAccept(cmd, svc, params)
 	if err != nil {
@@ -338,7 +348,11 @@ func handleBlockingChallengesError(cmd *cobra.Command, svc *service.ProofService
 		return fmt.Errorf("error accepting node: %w", origErr)
 	}
 
-	blockingChallenges := st.GetBlockingChallengesForNode(nodeID)
+	severities, err := svc.BlockingSeverities()
+	if err != nil {
+		return fmt.Errorf("error accepting node: %w", origErr)
+	}
+	blockingChallenges := st.GetBlockingChallengesForNodeWithSeverities(nodeID, severities)
 
 	switch strings.ToLower(format) {
 	case "json":
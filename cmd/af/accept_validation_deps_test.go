@@ -362,3 +362,42 @@ func TestAcceptCmd_ShowsBlockingDepsInError(t *testing.T) {
 		t.Errorf("expected error to list blocking deps 1.1 and 1.2, got: %q", errStr)
 	}
 }
+
+// TestAcceptCmd_BlockedByPendingReferenceDep tests that a node cannot be
+// accepted while a reference dependency is pending, unless the check is
+// explicitly disabled.
+func TestAcceptCmd_BlockedByPendingReferenceDep(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootID, _ := service.ParseNodeID("1")
+	if err := svc.ClaimNode(rootID, "test-agent", time.Hour); err != nil {
+		t.Fatalf("failed to claim node 1: %v", err)
+	}
+	lemmaID, _ := service.ParseNodeID("1.1")
+	if err := svc.RefineNode(rootID, "test-agent", lemmaID, service.NodeTypeClaim, "Lemma", service.InferenceAssumption); err != nil {
+		t.Fatalf("failed to create node 1.1: %v", err)
+	}
+	stepID, _ := service.ParseNodeID("1.2")
+	if err := svc.RefineNodeWithDeps(rootID, "test-agent", stepID, service.NodeTypeClaim, "By the lemma", service.InferenceModusPonens, []service.NodeID{lemmaID}); err != nil {
+		t.Fatalf("failed to create node 1.2: %v", err)
+	}
+
+	output, err := executeAcceptCommand(t, "1.2", "-d", tmpDir)
+	if err == nil {
+		t.Fatalf("expected error when accepting node with pending dependency, got nil. Output: %s", output)
+	}
+	if !strings.Contains(err.Error(), "depends on 1.1") {
+		t.Errorf("expected error naming dependency 1.1, got: %q", err.Error())
+	}
+
+	output, err = executeAcceptCommand(t, "1.2", "-d", tmpDir, "--allow-unvalidated-deps")
+	if err != nil {
+		t.Fatalf("expected --allow-unvalidated-deps to accept node, got: %v. Output: %s", err, output)
+	}
+}
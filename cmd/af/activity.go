@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newActivityCmd creates the activity command for summarizing work per agent.
+func newActivityCmd() *cobra.Command {
+	var dir string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "activity",
+		GroupID: GroupQuery,
+		Short:   "Summarize ledger activity per agent",
+		Long: `Scan the ledger and summarize what each agent has done.
+
+For every agent the command counts:
+  - claims:      nodes claimed (including nodes reassigned to the agent)
+  - refinements: child nodes created while the agent held the parent's claim
+  - challenges:  challenges raised by the agent
+  - acceptances: nodes accepted while the agent held the node's claim
+
+It also shows the time of each agent's first and last counted action.
+Agents are listed by total activity, most active first. Events that
+cannot be attributed to an agent are not counted.
+
+Below the agents, every node that was ever claimed is listed with the
+number of claims on it and how long it was held in total and at most,
+most claimed first. Claims still held count up to now. Nodes near the top
+with little time held are ones agents keep picking up and dropping.
+
+Use 'af agents' to see current claims and the raw claim history.
+
+Examples:
+  af activity                 Show per-agent activity
+  af activity -d ./proof      Use a specific proof directory
+  af activity -f json         Output in JSON format`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runActivity(cmd, dir, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+
+	return cmd
+}
+
+func runActivity(cmd *cobra.Command, dir, format string) error {
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	// Check if proof is initialized
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	activities, err := svc.Activity()
+	if err != nil {
+		return fmt.Errorf("error scanning ledger: %w", err)
+	}
+
+	durations, err := svc.ClaimDurations()
+	if err != nil {
+		return fmt.Errorf("error scanning claim history: %w", err)
+	}
+
+	view := render.ActivityToView(activities)
+	view.Nodes = render.ClaimDurationsToView(durations)
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderActivity(view))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newActivityCmd())
+}
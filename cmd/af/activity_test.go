@@ -0,0 +1,114 @@
+//go:build integration
+
+// Package main contains tests for the af activity command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeActivityCommand creates and executes an activity command with the given arguments.
+func executeActivityCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newActivityCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// setupActivityTest creates a proof where "prover" claims and refines node 1
+// and "verifier" challenges the new child.
+func setupActivityTest(t *testing.T) (string, func()) {
+	t.Helper()
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	rootID := mustParseNodeID(t, "1")
+	childID := mustParseNodeID(t, "1.1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	if err := svc.RefineNode(rootID, "prover", childID, service.NodeTypeClaim, "Step one", service.InferenceAssumption); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	if _, err := svc.RaiseChallengeWithAgent(childID, "statement", "Unclear", "minor", "verifier"); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return tmpDir, cleanup
+}
+
+// TestActivityCommand_TextOutput tests that activity lists agents by total activity.
+func TestActivityCommand_TextOutput(t *testing.T) {
+	tmpDir, cleanup := setupActivityTest(t)
+	defer cleanup()
+
+	output, err := executeActivityCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("activity failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Agent activity (2 agents)") {
+		t.Errorf("output missing header:\n%s", output)
+	}
+	proverAt, verifierAt := strings.Index(output, "prover"), strings.Index(output, "verifier")
+	if proverAt < 0 || verifierAt < 0 || proverAt > verifierAt {
+		t.Errorf("expected prover (2 actions) listed before verifier (1 action):\n%s", output)
+	}
+}
+
+// TestActivityCommand_JSONOutput tests that activity emits the activity view as JSON.
+func TestActivityCommand_JSONOutput(t *testing.T) {
+	tmpDir, cleanup := setupActivityTest(t)
+	defer cleanup()
+
+	output, err := executeActivityCommand(t, "-d", tmpDir, "-f", "json")
+	if err != nil {
+		t.Fatalf("activity failed: %v\noutput: %s", err, output)
+	}
+
+	var view render.ActivityView
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(view.Agents) != 2 {
+		t.Fatalf("got %d agents, want 2: %+v", len(view.Agents), view.Agents)
+	}
+	prover, verifier := view.Agents[0], view.Agents[1]
+	if prover.Agent != "prover" || prover.Claims != 1 || prover.Refinements != 1 || prover.Total != 2 {
+		t.Errorf("prover = %+v", prover)
+	}
+	if verifier.Agent != "verifier" || verifier.ChallengesRaised != 1 || verifier.Total != 1 {
+		t.Errorf("verifier = %+v", verifier)
+	}
+	if prover.FirstAction == "" || prover.LastAction == "" {
+		t.Errorf("prover missing action timestamps: %+v", prover)
+	}
+}
+
+// TestActivityCommand_InvalidFormat tests that an unknown format is rejected.
+func TestActivityCommand_InvalidFormat(t *testing.T) {
+	tmpDir, cleanup := setupActivityTest(t)
+	defer cleanup()
+
+	if _, err := executeActivityCommand(t, "-d", tmpDir, "-f", "xml"); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
@@ -55,7 +55,7 @@ func runAdmit(cmd *cobra.Command, args []string) error {
 	format := cli.MustString(cmd, "format")
 
 	// Create proof service
-	svc, err := service.NewProofService(dir)
+	svc, err := service.NewProofService(dir, service.WithAutoTaint(isAutoTaint(cmd)))
 	if err != nil {
 		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
@@ -19,6 +19,7 @@ type AgentEntry struct {
 	NodeID    string          `json:"node_id"`
 	Owner     string          `json:"owner"`
 	ClaimedAt service.Timestamp `json:"claimed_at,omitempty"`
+	ExpiresAt service.Timestamp `json:"expires_at,omitempty"`
 }
 
 // ActivityEntry represents a historical claim/release activity.
@@ -107,6 +108,7 @@ func runAgents(cmd *cobra.Command, args []string) error {
 				NodeID:    n.ID.String(),
 				Owner:     n.ClaimedBy,
 				ClaimedAt: n.ClaimedAt,
+				ExpiresAt: n.ClaimExpiry(),
 			})
 		}
 	}
@@ -133,7 +135,7 @@ func runAgents(cmd *cobra.Command, args []string) error {
 
 		// Check if this is a claim or release event
 		eventType, _ := eventData["type"].(string)
-		if eventType != "nodes_claimed" && eventType != "nodes_released" && eventType != "lock_reaped" {
+		if eventType != "nodes_claimed" && eventType != "nodes_released" && eventType != "nodes_reassigned" && eventType != "lock_reaped" {
 			return nil // Not an agent activity event
 		}
 
@@ -165,10 +167,13 @@ func runAgents(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		// Extract owner
+		// Extract owner; a reassignment is attributed to the agent taking over
 		if owner, ok := eventData["owner"].(string); ok {
 			entry.Owner = owner
 		}
+		if toOwner, ok := eventData["to_owner"].(string); ok {
+			entry.Owner = toOwner
+		}
 
 		activity = append(activity, entry)
 		return nil
@@ -226,8 +231,8 @@ func formatAgentsText(output AgentsOutput) string {
 	} else {
 		for _, entry := range output.ClaimedNodes {
 			sb.WriteString(fmt.Sprintf("  [%s] claimed by %s", entry.NodeID, entry.Owner))
-			if entry.ClaimedAt.String() != "" {
-				sb.WriteString(fmt.Sprintf(" (expires: %s)", formatAgentTimestamp(entry.ClaimedAt)))
+			if !entry.ExpiresAt.IsZero() {
+				sb.WriteString(fmt.Sprintf(" (expires: %s)", formatAgentTimestamp(entry.ExpiresAt)))
 			}
 			sb.WriteString("\n")
 		}
@@ -306,6 +311,8 @@ func formatActivityType(eventType string) string {
 		return "Claimed"
 	case "nodes_released":
 		return "Released"
+	case "nodes_reassigned":
+		return "Reassigned"
 	case "lock_reaped":
 		return "Lock Reaped"
 	default:
@@ -46,7 +46,7 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Agent/owner name (required)")
+	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Agent/owner name (default $AF_OWNER or the default-owner setting)")
 	cmd.Flags().StringVarP(&statement, "statement", "s", "", "New statement text (required)")
 	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
 	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
@@ -57,9 +57,9 @@ Examples:
 func runAmend(cmd *cobra.Command, nodeIDStr, owner, statement, dir, format string) error {
 	examples := render.GetExamples("af amend")
 
-	// Validate owner is not empty
-	if strings.TrimSpace(owner) == "" {
-		return render.MissingFlagError("af amend", "owner", examples)
+	owner, err := resolveOwner(cmd, "af amend", dir, examples)
+	if err != nil {
+		return err
 	}
 
 	// Validate statement is not empty
@@ -24,6 +24,11 @@ The node's epistemic state changes from pending to archived.
 Use this when you want to abandon a proof path without marking it as
 incorrect. Archived nodes are preserved in the ledger history.
 
+Use --subtree to archive the node together with all of its descendants.
+Validated nodes cannot be archived: if the subtree contains one, the command
+fails unless --force is given, in which case validated nodes are skipped.
+Admitted, refuted, and already archived nodes are left unchanged.
+
 This is a DESTRUCTIVE action. You will be prompted for confirmation unless
 the --yes flag is provided. In non-interactive environments (when stdin is
 not a terminal), the --yes flag is required.
@@ -33,7 +38,9 @@ Examples:
   af archive 1 -y       Archive without confirmation
   af archive 1.2.3      Archive a specific child node
   af archive 1 -d ./proof  Archive using specific directory
-  af archive 1 --reason "Taking different approach"  Archive with explanation`,
+  af archive 1 --reason "Taking different approach"  Archive with explanation
+  af archive 1.2 --subtree -y          Archive node 1.2 and all descendants
+  af archive 1.2 --subtree --force -y  Same, skipping validated descendants`,
 		Args: cobra.ExactArgs(1),
 		RunE: runArchive,
 	}
@@ -42,6 +49,8 @@ Examples:
 	cmd.Flags().StringP("format", "f", "text", "Output format (text/json)")
 	cmd.Flags().String("reason", "", "Reason for archiving")
 	cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().Bool("subtree", false, "Archive the node and all of its descendants")
+	cmd.Flags().Bool("force", false, "With --subtree, skip validated descendants instead of failing")
 
 	return cmd
 }
@@ -59,9 +68,18 @@ func runArchive(cmd *cobra.Command, args []string) error {
 	format := cli.MustString(cmd, "format")
 	reason := cli.MustString(cmd, "reason")
 	skipConfirm := cli.MustBool(cmd, "yes")
+	subtree := cli.MustBool(cmd, "subtree")
+	force := cli.MustBool(cmd, "force")
+
+	if force && !subtree {
+		return fmt.Errorf("--force can only be used with --subtree")
+	}
 
 	// Handle confirmation for destructive action
 	action := fmt.Sprintf("archive node %s", nodeIDStr)
+	if subtree {
+		action = fmt.Sprintf("archive node %s and all of its descendants", nodeIDStr)
+	}
 	confirmed, err := cli.ConfirmAction(cmd.OutOrStdout(), action, skipConfirm)
 	if err != nil {
 		return fmt.Errorf("stdin is not a terminal; use --yes flag to confirm archive in non-interactive mode")
@@ -72,13 +90,19 @@ func runArchive(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create proof service
-	svc, err := service.NewProofService(dir)
+	svc, err := service.NewProofService(dir, service.WithAutoTaint(isAutoTaint(cmd)))
 	if err != nil {
 		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
 
-	// Archive the node
-	if err := svc.ArchiveNode(nodeID); err != nil {
+	// Archive the node, or the whole subtree
+	archivedIDs := []service.NodeID{nodeID}
+	if subtree {
+		archivedIDs, err = svc.ArchiveSubtree(nodeID, force)
+		if err != nil {
+			return fmt.Errorf("error archiving subtree: %w", err)
+		}
+	} else if err := svc.ArchiveNode(nodeID); err != nil {
 		return fmt.Errorf("error archiving node: %w", err)
 	}
 
@@ -90,6 +114,9 @@ func runArchive(cmd *cobra.Command, args []string) error {
 			"status":   "archived",
 			"archived": true,
 		}
+		if subtree {
+			result["archived_ids"] = service.ToStringSlice(archivedIDs)
+		}
 		if reason != "" {
 			result["reason"] = reason
 		}
@@ -100,7 +127,12 @@ func runArchive(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(cmd.OutOrStdout(), string(output))
 	default:
 		// Text format
-		fmt.Fprintf(cmd.OutOrStdout(), "Node %s archived.\n", nodeID.String())
+		if subtree {
+			fmt.Fprintf(cmd.OutOrStdout(), "Archived %d node(s): %s\n",
+				len(archivedIDs), strings.Join(service.ToStringSlice(archivedIDs), ", "))
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Node %s archived.\n", nodeID.String())
+		}
 	}
 
 	return nil
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tobias/vibefeld/internal/service"
 )
@@ -1114,3 +1115,46 @@ func TestArchiveCmd_JSONOutputWithReason(t *testing.T) {
 	// The JSON may or may not include the reason - depends on implementation
 	t.Logf("JSON output with reason: %s", output)
 }
+
+// TestArchiveCmd_Subtree tests archiving a node together with its descendants.
+func TestArchiveCmd_Subtree(t *testing.T) {
+	tmpDir, cleanup := setupArchiveTestWithNode(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID := mustParseArchiveNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNode(rootID, "prover", mustParseArchiveNodeID(t, "1.1"), service.NodeTypeClaim, "Child", service.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ReleaseNode(rootID, "prover"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := executeArchiveCommand(t, "1", "-d", tmpDir, "-y", "--force"); err == nil {
+		t.Error("expected error for --force without --subtree")
+	}
+
+	output, err := executeArchiveCommand(t, "1", "-d", tmpDir, "-y", "--subtree")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Archived 2 node(s): 1, 1.1") {
+		t.Errorf("unexpected output: %q", output)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "1.1"} {
+		if n := st.GetNode(mustParseArchiveNodeID(t, id)); n == nil || n.EpistemicState != service.EpistemicArchived {
+			t.Errorf("node %s not archived", id)
+		}
+	}
+}
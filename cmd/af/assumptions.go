@@ -4,6 +4,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -45,6 +46,8 @@ func newAssumptionCmd() *cobra.Command {
 		Long: `Show detailed information about a specific assumption.
 
 The assumption can be identified by its full ID or a unique prefix.
+The nodes that reference it in their context are listed, with references
+from archived nodes shown separately.
 
 Examples:
   af assumption abc123              Show assumption with ID abc123
@@ -164,12 +167,17 @@ func runAssumption(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	usage, err := svc.AssumptionUsageDetail()
+	if err != nil {
+		return fmt.Errorf("error loading assumption usage: %w", err)
+	}
+
 	// Output based on format
 	if format == "json" {
-		return outputAssumptionJSON(cmd, assumption)
+		return outputAssumptionJSON(cmd, assumption, usage[assumption.ID])
 	}
 
-	return outputAssumptionText(cmd, assumption)
+	return outputAssumptionText(cmd, assumption, usage[assumption.ID])
 }
 
 // getAllAssumptions returns all assumptions from the proof directory.
@@ -273,8 +281,9 @@ func outputAssumptionsText(cmd *cobra.Command, assumptions []*node.Assumption) e
 }
 
 // outputAssumptionJSON outputs a single assumption in JSON format.
-func outputAssumptionJSON(cmd *cobra.Command, asm *node.Assumption) error {
+func outputAssumptionJSON(cmd *cobra.Command, asm *node.Assumption, usage service.ContextUsage) error {
 	output := assumptionToJSON(asm)
+	addContextUsageJSON(output, usage)
 
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -286,7 +295,7 @@ func outputAssumptionJSON(cmd *cobra.Command, asm *node.Assumption) error {
 }
 
 // outputAssumptionText outputs a single assumption in text format.
-func outputAssumptionText(cmd *cobra.Command, asm *node.Assumption) error {
+func outputAssumptionText(cmd *cobra.Command, asm *node.Assumption, usage service.ContextUsage) error {
 	fmt.Fprintf(cmd.OutOrStdout(), "Assumption: %s\n\n", asm.ID)
 	fmt.Fprintf(cmd.OutOrStdout(), "  Statement:    %s\n", asm.Statement)
 	fmt.Fprintf(cmd.OutOrStdout(), "  Content Hash: %s\n", asm.ContentHash)
@@ -295,6 +304,7 @@ func outputAssumptionText(cmd *cobra.Command, asm *node.Assumption) error {
 	if asm.Justification != "" {
 		fmt.Fprintf(cmd.OutOrStdout(), "  Justification: %s\n", asm.Justification)
 	}
+	writeContextUsage(cmd.OutOrStdout(), usage)
 
 	fmt.Fprintln(cmd.OutOrStdout())
 	fmt.Fprintln(cmd.OutOrStdout(), "Next steps:")
@@ -320,6 +330,28 @@ func assumptionToJSON(asm *node.Assumption) map[string]interface{} {
 	return result
 }
 
+// writeContextUsage writes the nodes that reference an assumption or
+// external, aligned with the other detail fields.
+func writeContextUsage(out io.Writer, usage service.ContextUsage) {
+	if len(usage.Live) == 0 {
+		fmt.Fprintln(out, "  Used by:      (no live nodes)")
+	} else {
+		fmt.Fprintf(out, "  Used by:      %s\n", strings.Join(service.ToStringSlice(usage.Live), ", "))
+	}
+	if len(usage.Archived) > 0 {
+		fmt.Fprintf(out, "  Archived use: %s\n", strings.Join(service.ToStringSlice(usage.Archived), ", "))
+	}
+}
+
+// addContextUsageJSON adds the nodes that reference an assumption or
+// external to its JSON representation.
+func addContextUsageJSON(result map[string]interface{}, usage service.ContextUsage) {
+	result["used_by"] = service.ToStringSlice(usage.Live)
+	if len(usage.Archived) > 0 {
+		result["archived_used_by"] = service.ToStringSlice(usage.Archived)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(newAssumptionsCmd())
 	rootCmd.AddCommand(newAssumptionCmd())
@@ -0,0 +1,119 @@
+// Package main contains the af batch command implementation.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/cli"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+func newBatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "batch <file.json>",
+		GroupID: GroupWorkflow,
+		Short:   "Apply a scripted sequence of operations from a JSON file",
+		Long: `Batch applies a JSON array of operations in order, e.g. a whole
+refinement plan submitted by a planner agent.
+
+The entire batch is first validated in dry-run mode, where each operation
+sees the effects of the ones before it. If any operation would fail, nothing
+is written and the index of the first failing operation is reported.
+
+Each operation is an object with an "op" field and the fields it needs:
+
+  create     node, type, statement, inference
+  claim      node, owner, timeout (e.g. "30m"; defaults to the lock timeout)
+  release    node, owner
+  refine     node (the parent), owner, type, statement, inference,
+             child (optional; the next free child ID is used when omitted)
+  accept, admit, refute, archive, reopen
+             node
+  challenge  node, target, reason, severity
+  resolve, withdraw
+             challenge
+  tag, untag node, tag
+
+Example file:
+  [
+    {"op": "claim", "node": "1", "owner": "prover-1"},
+    {"op": "refine", "node": "1", "owner": "prover-1",
+     "type": "claim", "statement": "Base case", "inference": "assumption"},
+    {"op": "refine", "node": "1", "owner": "prover-1",
+     "type": "claim", "statement": "Inductive step", "inference": "assumption"},
+    {"op": "release", "node": "1", "owner": "prover-1"}
+  ]
+
+Examples:
+  af batch plan.json              Apply the operations in plan.json
+  af batch plan.json --dry-run    Validate only and list the events
+  af batch plan.json -f json      Report the result as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBatch,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text/json)")
+
+	return cmd
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	dir := cli.MustString(cmd, "dir")
+	format := cli.MustString(cmd, "format")
+
+	svc, err := service.NewProofService(dir,
+		service.WithDryRun(isDryRun(cmd)),
+		service.WithAutoTaint(isAutoTaint(cmd)))
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	result, err := svc.BatchFromFile(args[0])
+	if err != nil {
+		if result.FailedIndex < 0 {
+			return fmt.Errorf("error running batch: %w", err)
+		}
+		if result.Applied == 0 || result.DryRun {
+			return fmt.Errorf("batch rejected, nothing was written: %w", err)
+		}
+		return fmt.Errorf("batch stopped after applying %d of %d operations: %w", result.Applied, result.Total, err)
+	}
+
+	if result.DryRun {
+		return writeDryRunPreview(cmd, format, svc.PreviewEvents())
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, result)
+	}
+	if strings.ToLower(format) == "json" {
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Applied %d operation(s):\n", result.Applied)
+	for _, step := range result.Steps {
+		line := fmt.Sprintf("  [%d] %s", step.Index, step.Op)
+		if step.NodeID != "" {
+			line += " " + step.NodeID
+		}
+		if step.ChallengeID != "" {
+			line += " " + step.ChallengeID
+		}
+		fmt.Fprintln(out, line)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newBatchCmd())
+}
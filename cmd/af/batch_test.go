@@ -0,0 +1,107 @@
+//go:build integration
+
+// Package main contains tests for the af batch command.
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeBatchCommand creates and executes a batch command with the given arguments.
+func executeBatchCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newBatchCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// writeBatchPlan writes a batch file into dir and returns its path.
+func writeBatchPlan(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "plan.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestBatchCommand_Applies tests that a refinement plan is applied in order.
+func TestBatchCommand_Applies(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	plan := writeBatchPlan(t, tmpDir, `[
+		{"op": "claim", "node": "1", "owner": "prover"},
+		{"op": "refine", "node": "1", "owner": "prover", "type": "claim", "statement": "Base case", "inference": "assumption"},
+		{"op": "refine", "node": "1", "owner": "prover", "type": "claim", "statement": "Inductive step", "inference": "assumption"},
+		{"op": "release", "node": "1", "owner": "prover"}
+	]`)
+
+	output, err := executeBatchCommand(t, plan, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("batch failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{"Applied 4 operation(s):", "[1] refine 1.1", "[2] refine 1.2", "[3] release"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := service.ParseNodeID("1.2")
+	if n := st.GetNode(id); n == nil || n.Statement != "Inductive step" {
+		t.Errorf("node 1.2 = %v, want the inductive step", n)
+	}
+}
+
+// TestBatchCommand_RejectsFailingPlan tests that a plan with a failing step
+// reports its index and writes nothing.
+func TestBatchCommand_RejectsFailingPlan(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	plan := writeBatchPlan(t, tmpDir, `[
+		{"op": "claim", "node": "1", "owner": "prover"},
+		{"op": "accept", "node": "1.9"}
+	]`)
+
+	output, err := executeBatchCommand(t, plan, "-d", tmpDir)
+	if err == nil {
+		t.Fatalf("batch succeeded, want an error\noutput: %s", output)
+	}
+	if !strings.Contains(err.Error(), "nothing was written") || !strings.Contains(err.Error(), "batch operation 1 (accept)") {
+		t.Errorf("error = %q, want the failing index and a note that nothing was written", err)
+	}
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := service.ParseNodeID("1")
+	if n := st.GetNode(id); n.ClaimedBy != "" {
+		t.Errorf("node 1 claimed by %q after rejected batch", n.ClaimedBy)
+	}
+}
@@ -2,17 +2,14 @@
 package main
 
 import (
-	"crypto/rand"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/cli"
-	"github.com/tobias/vibefeld/internal/ledger"
 	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/service"
 )
@@ -138,41 +135,21 @@ func runChallenge(cmd *cobra.Command, args []string) error {
 		return render.InvalidValueError("af challenge", "severity", severity, []string{"critical", "major", "minor", "note"}, examples)
 	}
 
-	// Create proof service to check state
+	// Create proof service
 	svc, err := service.NewProofService(dir)
 	if err != nil {
 		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
 
-	// Load state to check if node exists
-	st, err := svc.LoadState()
-	if err != nil {
-		return fmt.Errorf("error loading proof state: %w", err)
-	}
-
-	// Check if node exists
-	n := st.GetNode(nodeID)
-	if n == nil {
-		return fmt.Errorf("node %s does not exist", nodeID.String())
-	}
-
-	// Generate a unique challenge ID
-	challengeID := generateChallengeID()
-
-	// Create ledger from proof path
-	ledgerDir := filepath.Join(svc.Path(), "ledger")
-	ldg, err := ledger.NewLedger(ledgerDir)
-	if err != nil {
-		return fmt.Errorf("error accessing ledger: %w", err)
-	}
-
 	// Get agent ID from environment variable (if set)
 	agentID := os.Getenv("AF_AGENT_ID")
 
-	// Append challenge raised event with severity and agent ID
-	event := ledger.NewChallengeRaisedWithSeverity(challengeID, nodeID, target, reason, severity, agentID)
-	_, err = ldg.Append(event)
+	// Raise the challenge (validates that the node exists)
+	challengeID, err := svc.RaiseChallengeWithAgent(nodeID, target, reason, severity, agentID)
 	if err != nil {
+		if errors.Is(err, service.ErrNodeNotFound) {
+			return fmt.Errorf("node %s does not exist", nodeID.String())
+		}
 		return fmt.Errorf("error raising challenge: %w", err)
 	}
 
@@ -228,18 +205,6 @@ func outputChallengeText(cmd *cobra.Command, nodeID service.NodeID, challengeID,
 	return nil
 }
 
-// generateChallengeID generates a unique identifier for a challenge.
-// Uses random bytes for uniqueness.
-func generateChallengeID() string {
-	b := make([]byte, 8)
-	if _, err := rand.Read(b); err != nil {
-		// If crypto/rand fails, this indicates a critical system issue
-		// Use timestamp-based fallback
-		return fmt.Sprintf("ch-%v", service.Now())
-	}
-	return "ch-" + hex.EncodeToString(b)
-}
-
 func init() {
 	rootCmd.AddCommand(newChallengeCmd())
 }
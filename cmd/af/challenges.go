@@ -4,7 +4,6 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -24,13 +23,20 @@ identifies an issue that the prover must address before a node can
 be validated.
 
 Filter options:
-  --node     Show only challenges targeting a specific node
-  --status   Filter by challenge status (open, resolved, withdrawn)
+  --node      Show only challenges targeting a specific node
+  --status    Filter by challenge status (open, resolved, withdrawn)
+  --severity  Filter by severity (critical, major, minor, note)
+  --open      Shorthand for --status open
+
+Challenges are listed most severe first (critical, major, minor, note),
+then by node ID.
 
 Examples:
   af challenges                    List all challenges
   af challenges --node 1.1.1       Challenges on specific node
   af challenges --status open      Only open challenges
+  af challenges --open --severity critical
+                                   Open critical challenges
   af challenges --format json      Machine-readable output`,
 		RunE: runChallenges,
 	}
@@ -39,6 +45,8 @@ Examples:
 	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
 	cmd.Flags().StringP("node", "n", "", "Filter by target node ID")
 	cmd.Flags().StringP("status", "s", "", "Filter by status (open, resolved, withdrawn)")
+	cmd.Flags().String("severity", "", "Filter by severity (critical, major, minor, note)")
+	cmd.Flags().Bool("open", false, "Show only open challenges (same as --status open)")
 
 	return cmd
 }
@@ -50,6 +58,8 @@ func runChallenges(cmd *cobra.Command, args []string) error {
 	format, _ := cmd.Flags().GetString("format")
 	nodeFilter, _ := cmd.Flags().GetString("node")
 	statusFilter, _ := cmd.Flags().GetString("status")
+	severityFilter, _ := cmd.Flags().GetString("severity")
+	openOnly, _ := cmd.Flags().GetBool("open")
 
 	// Validate format
 	format = strings.ToLower(format)
@@ -62,6 +72,20 @@ func runChallenges(cmd *cobra.Command, args []string) error {
 	if statusFilter != "" && statusFilter != "open" && statusFilter != "resolved" && statusFilter != "withdrawn" {
 		return fmt.Errorf("invalid status %q: must be 'open', 'resolved', or 'withdrawn'", statusFilter)
 	}
+	if openOnly {
+		if statusFilter != "" && statusFilter != service.ChallengeStatusOpen {
+			return fmt.Errorf("--open conflicts with --status %s", statusFilter)
+		}
+		statusFilter = service.ChallengeStatusOpen
+	}
+
+	// Validate severity if provided
+	severityFilter = strings.ToLower(severityFilter)
+	if severityFilter != "" {
+		if err := service.ValidateChallengeSeverity(severityFilter); err != nil {
+			return err
+		}
+	}
 
 	// Parse node filter if provided
 	var nodeID service.NodeID
@@ -88,21 +112,16 @@ func runChallenges(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("proof not initialized")
 	}
 
-	// Load current state
-	st, err := svc.LoadState()
+	// List matching challenges, most severe first, then by node ID
+	filtered, err := svc.ListChallenges(service.ChallengeFilter{
+		Status:   statusFilter,
+		Severity: severityFilter,
+		NodeID:   nodeID,
+	})
 	if err != nil {
-		return fmt.Errorf("error loading proof state: %w", err)
+		return fmt.Errorf("error listing challenges: %w", err)
 	}
 
-	// Get all challenges
-	challenges := st.AllChallenges()
-
-	// Apply filters
-	filtered := filterChallenges(challenges, nodeID, nodeFilter != "", statusFilter)
-
-	// Sort challenges by node ID then by challenge ID
-	sortChallenges(filtered)
-
 	// Output based on format
 	if format == "json" {
 		output := renderChallengesJSON(filtered)
@@ -129,41 +148,6 @@ func runChallenges(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// filterChallenges filters challenges based on node ID and status.
-func filterChallenges(challenges []*service.Challenge, nodeID service.NodeID, filterByNode bool, statusFilter string) []*service.Challenge {
-	var result []*service.Challenge
-
-	for _, c := range challenges {
-		// Apply node filter
-		if filterByNode && c.NodeID.String() != nodeID.String() {
-			continue
-		}
-
-		// Apply status filter
-		if statusFilter != "" && c.Status != statusFilter {
-			continue
-		}
-
-		result = append(result, c)
-	}
-
-	return result
-}
-
-// sortChallenges sorts challenges by node ID (string comparison) then by challenge ID.
-func sortChallenges(challenges []*service.Challenge) {
-	sort.Slice(challenges, func(i, j int) bool {
-		// First compare node IDs
-		nodeI := challenges[i].NodeID.String()
-		nodeJ := challenges[j].NodeID.String()
-		if nodeI != nodeJ {
-			return nodeI < nodeJ
-		}
-		// Then compare challenge IDs
-		return challenges[i].ID < challenges[j].ID
-	})
-}
-
 // renderChallengesText renders challenges as a text table.
 func renderChallengesText(challenges []*service.Challenge) string {
 	if len(challenges) == 0 {
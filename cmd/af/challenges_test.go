@@ -587,7 +587,7 @@ func TestChallengesCmd_ExpectedFlags(t *testing.T) {
 	cmd := newChallengesCmd()
 
 	// Check expected flags exist
-	expectedFlags := []string{"status", "node", "format", "dir"}
+	expectedFlags := []string{"status", "severity", "open", "node", "format", "dir"}
 	for _, flagName := range expectedFlags {
 		if cmd.Flags().Lookup(flagName) == nil && cmd.PersistentFlags().Lookup(flagName) == nil {
 			t.Errorf("expected challenges command to have flag %q", flagName)
@@ -770,6 +770,64 @@ func TestChallengesCmd_CombinedFilters(t *testing.T) {
 	}
 }
 
+// TestChallengesCmd_FilterBySeverityAndOpen verifies --severity combined with --open.
+func TestChallengesCmd_FilterBySeverityAndOpen(t *testing.T) {
+	proofDir, cleanup := setupChallengesTestWithMultipleNodes(t)
+	defer cleanup()
+
+	ldg, err := ledger.NewLedger(filepath.Join(proofDir, "ledger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeID1, _ := service.ParseNodeID("1")
+	if _, err := ldg.Append(ledger.NewChallengeRaisedWithSeverity("ch-crit01", nodeID1, "gap", "Case n=1 fails", "critical", "")); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newTestChallengesCmd()
+	output, err := executeChallengesCommand(cmd, "challenges", "--open", "--severity", "critical", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "ch-crit01") {
+		t.Errorf("expected critical challenge ch-crit01, got: %q", output)
+	}
+	// ch-def456 is open but major; ch-abc123 is resolved
+	for _, id := range []string{"ch-def456", "ch-abc123"} {
+		if strings.Contains(output, id) {
+			t.Errorf("expected %s to be filtered out, got: %q", id, output)
+		}
+	}
+
+	// Challenges without a recorded severity count as major
+	cmd = newTestChallengesCmd()
+	output, err = executeChallengesCommand(cmd, "challenges", "--open", "--severity", "major", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "ch-def456") || strings.Contains(output, "ch-crit01") {
+		t.Errorf("expected only ch-def456 for open major challenges, got: %q", output)
+	}
+}
+
+// TestChallengesCmd_InvalidSeverityOrOpenConflict verifies flag validation.
+func TestChallengesCmd_InvalidSeverityOrOpenConflict(t *testing.T) {
+	proofDir, cleanup := setupChallengesTest(t)
+	defer cleanup()
+
+	tests := [][]string{
+		{"--severity", "blocker"},
+		{"--open", "--status", "resolved"},
+	}
+	for _, args := range tests {
+		cmd := newTestChallengesCmd()
+		_, err := executeChallengesCommand(cmd, append([]string{"challenges", "--dir", proofDir}, args...)...)
+		if err == nil {
+			t.Errorf("expected error for args %v, got nil", args)
+		}
+	}
+}
+
 // TestChallengesCmd_OutputShowsSummary verifies summary line in text output.
 func TestChallengesCmd_OutputShowsSummary(t *testing.T) {
 	proofDir, cleanup := setupChallengesTestWithChallenges(t)
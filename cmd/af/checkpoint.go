@@ -0,0 +1,188 @@
+// Package main contains the af checkpoint command for named ledger checkpoints.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newCheckpointCmd creates the checkpoint command and its subcommands.
+func newCheckpointCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "checkpoint",
+		GroupID: GroupAdmin,
+		Short:   "Create, list, and restore named checkpoints",
+		Long: `Name points in the proof's history so that an experiment can be rolled back.
+
+A checkpoint records the ledger's latest sequence number under a unique
+name in .af/checkpoints.json. The ledger is append-only, so restoring a
+checkpoint never truncates the proof: it writes a new proof directory
+whose ledger ends at the checkpoint.
+
+Examples:
+  af checkpoint create before-induction        Name the current state
+  af checkpoint list                           Show all checkpoints
+  af checkpoint restore before-induction ../p  Restore into a new directory`,
+	}
+
+	cmd.AddCommand(newCheckpointCreateCmd())
+	cmd.AddCommand(newCheckpointListCmd())
+	cmd.AddCommand(newCheckpointRestoreCmd())
+
+	return cmd
+}
+
+func newCheckpointCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Record the current ledger position under a name",
+		Long: `Record the proof's latest ledger sequence number under a name.
+
+Names must be unique within the proof.
+
+Examples:
+  af checkpoint create before-induction
+  af checkpoint create v1 -d ./proof`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCheckpointCreate,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+
+	return cmd
+}
+
+func newCheckpointListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the proof's checkpoints",
+		Long: `List the proof's checkpoints, oldest first.
+
+Examples:
+  af checkpoint list
+  af checkpoint list -f json`,
+		Args: cobra.NoArgs,
+		RunE: runCheckpointList,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+func newCheckpointRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <name> <dest>",
+		Short: "Write the proof as of a checkpoint into a new directory",
+		Long: `Write a copy of the proof whose ledger ends at the named checkpoint.
+
+The destination must not exist or must be an empty directory. meta.json
+and the files in .af are copied as for 'af clone', keeping only the
+checkpoints at or before the restored one. The source proof is unchanged.
+
+Examples:
+  af checkpoint restore before-induction ../proof-rollback`,
+		Args: cobra.ExactArgs(2),
+		RunE: runCheckpointRestore,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+
+	return cmd
+}
+
+// loadCheckpointService opens the proof in the command's --dir and checks
+// that it is initialized.
+func loadCheckpointService(cmd *cobra.Command) (*service.ProofService, error) {
+	svc, err := service.NewProofService(service.MustString(cmd, "dir"))
+	if err != nil {
+		return nil, fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return nil, fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return nil, fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+	return svc, nil
+}
+
+// runCheckpointCreate executes the checkpoint create command.
+func runCheckpointCreate(cmd *cobra.Command, args []string) error {
+	svc, err := loadCheckpointService(cmd)
+	if err != nil {
+		return err
+	}
+
+	seq, err := svc.Checkpoint(args[0])
+	if err != nil {
+		return fmt.Errorf("error creating checkpoint: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Created checkpoint %s at sequence %d\n", args[0], seq)
+	return nil
+}
+
+// runCheckpointList executes the checkpoint list command.
+func runCheckpointList(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(service.MustString(cmd, "format"))
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	svc, err := loadCheckpointService(cmd)
+	if err != nil {
+		return err
+	}
+	checkpoints, err := svc.ListCheckpoints()
+	if err != nil {
+		return fmt.Errorf("error listing checkpoints: %w", err)
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, checkpoints)
+	}
+	if format == "json" {
+		data, err := json.MarshalIndent(checkpoints, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	if len(checkpoints) == 0 {
+		fmt.Fprintln(out, "No checkpoints.")
+		return nil
+	}
+	for _, cp := range checkpoints {
+		fmt.Fprintf(out, "%-20s seq %-6d %s\n", cp.Name, cp.Seq, cp.Created)
+	}
+	return nil
+}
+
+// runCheckpointRestore executes the checkpoint restore command.
+func runCheckpointRestore(cmd *cobra.Command, args []string) error {
+	name, dest := args[0], args[1]
+
+	svc, err := loadCheckpointService(cmd)
+	if err != nil {
+		return err
+	}
+	if err := svc.RestoreCheckpoint(name, dest); err != nil {
+		return fmt.Errorf("error restoring checkpoint: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Restored checkpoint %s into %s\n", name, dest)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newCheckpointCmd())
+}
@@ -0,0 +1,75 @@
+//go:build !integration
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// TestCheckpoint_CreateListRestore verifies the checkpoint subcommands end
+// to end: a created checkpoint is listed and can be restored.
+func TestCheckpoint_CreateListRestore(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "src")
+	dstDir := filepath.Join(tmpDir, "dst")
+	if err := service.Init(srcDir, "Checkpoint conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		root := newTestRootCmd()
+		root.AddCommand(newCheckpointCmd())
+		out, err := executeCommand(root, args...)
+		if err != nil {
+			t.Fatalf("af %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return out
+	}
+
+	if out := run("checkpoint", "list", "-d", srcDir); !strings.Contains(out, "No checkpoints.") {
+		t.Errorf("unexpected list output before any checkpoint: %q", out)
+	}
+	if out := run("checkpoint", "create", "start", "-d", srcDir); !strings.Contains(out, "Created checkpoint start at sequence 2") {
+		t.Errorf("unexpected create output: %q", out)
+	}
+	if out := run("checkpoint", "list", "-d", srcDir); !strings.Contains(out, "start") || !strings.Contains(out, "seq 2") {
+		t.Errorf("list should show the start checkpoint: %q", out)
+	}
+	if out := run("checkpoint", "restore", "start", dstDir, "-d", srcDir); !strings.Contains(out, "Restored checkpoint start") {
+		t.Errorf("unexpected restore output: %q", out)
+	}
+
+	svc, err := service.NewProofService(dstDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("restored proof does not load: %v", err)
+	}
+	if st.LatestSeq() != 2 {
+		t.Errorf("restored proof is at sequence %d, want 2", st.LatestSeq())
+	}
+}
+
+// TestCheckpoint_DuplicateName verifies that a name can only be used once.
+func TestCheckpoint_DuplicateName(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Checkpoint conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	for i, wantErr := range []bool{false, true} {
+		root := newTestRootCmd()
+		root.AddCommand(newCheckpointCmd())
+		_, err := executeCommand(root, "checkpoint", "create", "v1", "-d", dir)
+		if (err != nil) != wantErr {
+			t.Errorf("create #%d: error = %v, want error %v", i+1, err, wantErr)
+		}
+	}
+}
@@ -14,7 +14,7 @@ import (
 // newClaimCmd creates the claim command for claiming a node for work.
 func newClaimCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "claim <node-id>",
+		Use:     "claim [node-id]",
 		GroupID: GroupWorkflow,
 		Short:   "Claim a job for work",
 		Long: `Claim a proof node to work on as a prover or verifier.
@@ -25,30 +25,41 @@ modifications. Claims have a timeout to automatically release abandoned work.
 Use --refresh to extend the claim timeout on a node you already own,
 without releasing and reclaiming (which would risk another agent claiming it).
 
+Use --next instead of a node ID to claim the deepest available open node in
+one step, optionally restricted with --type and --tag. Unlike listing jobs
+and then claiming one, this cannot lose a race to another agent halfway: if
+the proof changes in between, the claim fails and can simply be retried.
+
 Examples:
   af claim 1 --owner prover-001 --role prover
   af claim 1.2 --owner verifier-alpha --timeout 30m --role verifier
   af claim 1 -o prover-001 -r prover -t 2h --format json
   af claim 1 --owner prover-001 --refresh --timeout 2h
+  af claim --next --owner prover-001 --tag needs-expert
 
 Workflow:
   After claiming a node as a prover, use 'af refine' to develop the proof.
   As a verifier, use 'af challenge' to raise objections or 'af accept' to validate.
   Use 'af release' if you cannot complete the work.`,
-		Args: cobra.ExactArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if next, _ := cmd.Flags().GetBool("next"); next {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: runClaim,
 	}
 
 	// Add flags
-	cmd.Flags().StringP("owner", "o", "", "Owner identity for the claim (required)")
+	cmd.Flags().StringP("owner", "o", "", "Owner identity for the claim (default $AF_OWNER or the default-owner setting)")
 	cmd.Flags().StringP("timeout", "t", service.DefaultClaimTimeout, "Claim timeout duration (e.g., 30m, 1h, 2h30m)")
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory")
 	cmd.Flags().StringP("format", "f", "text", "Output format: text or json")
 	cmd.Flags().StringP("role", "r", "prover", "Agent role: prover or verifier")
 	cmd.Flags().Bool("refresh", false, "Refresh an existing claim (extend timeout without releasing)")
-
-	// Mark owner as required
-	cmd.MarkFlagRequired("owner")
+	cmd.Flags().Bool("next", false, "Claim the deepest available open node instead of a given one")
+	cmd.Flags().String("type", "", "With --next, only claim nodes of this type")
+	cmd.Flags().String("tag", "", "With --next, only claim nodes carrying this tag")
 
 	return cmd
 }
@@ -57,23 +68,38 @@ Workflow:
 func runClaim(cmd *cobra.Command, args []string) error {
 	examples := render.GetExamples("af claim")
 
-	// Parse node ID from positional argument
-	nodeID, err := service.ParseNodeID(args[0])
-	if err != nil {
-		return render.InvalidNodeIDError("af claim", args[0], examples)
-	}
-
 	// Get flags
-	owner := service.MustString(cmd, "owner")
 	timeoutStr := service.MustString(cmd, "timeout")
 	dir := service.MustString(cmd, "dir")
 	format := service.MustString(cmd, "format")
 	role := service.MustString(cmd, "role")
 	refresh := service.MustBool(cmd, "refresh")
+	next := service.MustBool(cmd, "next")
+	opts := service.ClaimNextOptions{
+		NodeType: service.NodeType(service.MustString(cmd, "type")),
+		Tag:      service.MustString(cmd, "tag"),
+	}
+
+	// Parse node ID from positional argument
+	var nodeID service.NodeID
+	if next {
+		if refresh {
+			return render.NewUsageError("af claim", "--next cannot be combined with --refresh", examples)
+		}
+	} else {
+		if opts.NodeType != "" || opts.Tag != "" {
+			return render.NewUsageError("af claim", "--type and --tag require --next", examples)
+		}
+		var err error
+		nodeID, err = service.ParseNodeID(args[0])
+		if err != nil {
+			return render.InvalidNodeIDError("af claim", args[0], examples)
+		}
+	}
 
-	// Validate owner is not empty or whitespace
-	if strings.TrimSpace(owner) == "" {
-		return render.EmptyValueError("af claim", "owner", examples)
+	owner, err := resolveOwner(cmd, "af claim", dir, examples)
+	if err != nil {
+		return err
 	}
 
 	// Parse timeout duration
@@ -99,8 +125,14 @@ func runClaim(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to open proof directory: %w", err)
 	}
 
-	// Either refresh an existing claim or claim a new node
-	if refresh {
+	// Refresh an existing claim, claim the next node, or claim a given one
+	if next {
+		n, err := svc.ClaimNext(owner, timeout, opts)
+		if err != nil {
+			return fmt.Errorf("failed to claim next node: %w", err)
+		}
+		nodeID = n.ID
+	} else if refresh {
 		err = svc.RefreshClaim(nodeID, owner, timeout)
 		if err != nil {
 			return fmt.Errorf("failed to refresh claim on node %s: %w", nodeID.String(), err)
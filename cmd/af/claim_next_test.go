@@ -0,0 +1,59 @@
+//go:build !integration
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+func executeClaimNext(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newClaimCmd())
+	return executeCommand(root, args...)
+}
+
+func TestClaimCmd_Next(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeClaimNext("claim", "--next", "--owner", "prover-1", "-d", dir)
+	if err != nil {
+		t.Fatalf("claim --next failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "1") || !strings.Contains(output, "prover-1") {
+		t.Errorf("unexpected output: %q", output)
+	}
+
+	if _, err := executeClaimNext("claim", "--next", "--owner", "prover-2", "-d", dir); err == nil || !strings.Contains(err.Error(), "no available nodes") {
+		t.Errorf("second claim --next: error = %v, want no available nodes", err)
+	}
+}
+
+func TestClaimCmd_NextFlagCombinations(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"next with node ID", []string{"claim", "1", "--next", "--owner", "p", "-d", dir}},
+		{"next with refresh", []string{"claim", "--next", "--refresh", "--owner", "p", "-d", dir}},
+		{"tag without next", []string{"claim", "1", "--tag", "x", "--owner", "p", "-d", dir}},
+		{"no node ID", []string{"claim", "--owner", "p", "-d", dir}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := executeClaimNext(tt.args...); err == nil {
+				t.Errorf("%v succeeded, want an error", tt.args)
+			}
+		})
+	}
+}
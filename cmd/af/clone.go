@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newCloneCmd creates the clone command.
+func newCloneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clone <dest>",
+		GroupID: GroupAdmin,
+		Short:   "Copy the proof into a new directory",
+		Long: `Copy the proof's ledger and configuration into a new proof directory.
+
+The destination must not exist or must be an empty directory. Every event
+is copied byte for byte under its original sequence number, and the
+prev_hash chain and node content hashes are re-verified before anything
+is written. meta.json and the files in .af (hooks, patterns) are copied
+too. The clone is independent: later changes to either proof do not
+affect the other.
+
+Unlike 'af dump' and 'af restore', no intermediate file is written.
+
+Examples:
+  af clone ../proof-copy                 Clone the proof in the current directory
+  af clone ../proof-copy -d ./proof      Clone a specific proof directory`,
+		Args: cobra.ExactArgs(1),
+		RunE: runClone,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+
+	return cmd
+}
+
+// runClone executes the clone command.
+func runClone(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+	dest := args[0]
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+
+	if err := svc.CloneProof(dest); err != nil {
+		return fmt.Errorf("error cloning proof: %w", err)
+	}
+
+	count, err := ledger.Count(filepath.Join(dest, "ledger"))
+	if err != nil {
+		return fmt.Errorf("error counting cloned events: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Cloned %d events into %s\n", count, dest)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newCloneCmd())
+}
@@ -0,0 +1,70 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeCloneCommand executes args against a fresh command tree with the
+// clone subcommand and returns the combined output.
+func executeCloneCommand(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newCloneCmd())
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.String(), err
+}
+
+// TestClone_CopiesProof verifies that a cloned proof has the same state as
+// the source and that cloning into a non-empty directory is refused.
+func TestClone_CopiesProof(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "src")
+	dstDir := filepath.Join(tmpDir, "dst")
+
+	if err := service.Init(srcDir, "Clone conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	out, err := executeCloneCommand("clone", dstDir, "-d", srcDir)
+	if err != nil {
+		t.Fatalf("clone failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Cloned 2 events") {
+		t.Errorf("unexpected clone output: %q", out)
+	}
+
+	svc, err := service.NewProofService(dstDir)
+	if err != nil {
+		t.Fatalf("NewProofService failed: %v", err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState on cloned proof failed: %v", err)
+	}
+	root, _ := service.ParseNodeID("1")
+	if n := st.GetNode(root); n == nil || n.Statement != "Clone conjecture" {
+		t.Errorf("cloned root = %+v, want statement %q", n, "Clone conjecture")
+	}
+
+	if _, err := executeCloneCommand("clone", dstDir, "-d", srcDir); err == nil {
+		t.Error("expected clone into a non-empty directory to fail")
+	}
+}
+
+// TestClone_NoProof verifies that cloning a directory without a proof fails.
+func TestClone_NoProof(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, err := executeCloneCommand("clone", filepath.Join(tmpDir, "dst"), "-d", tmpDir); err == nil {
+		t.Error("expected clone without a proof to fail")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+)
+
+// colorMode returns the mode selected by the global --color flag.
+// Commands built without the root command (e.g. in tests) default to auto.
+func colorMode(cmd *cobra.Command) render.ColorMode {
+	value, err := cmd.Flags().GetString("color")
+	if err != nil {
+		return render.ColorAuto
+	}
+	mode, err := render.ParseColorMode(value)
+	if err != nil {
+		return render.ColorAuto
+	}
+	return mode
+}
+
+// renderOptions returns the render options for the command's text output.
+// Color follows --color, where auto colors only when writing to a terminal
+// and NO_COLOR is unset. Statements are wrapped to the terminal width, or 80
+// columns when not writing to a terminal. --json output is never colored.
+// Commands with an --absolute flag render event timestamps relative to the
+// current time unless it is set.
+func renderOptions(cmd *cobra.Command) render.RenderOptions {
+	if isJSON(cmd) {
+		return render.RenderOptions{}
+	}
+	opts := render.RenderOptions{
+		Color: render.ShouldColor(colorMode(cmd), cmd.OutOrStdout()),
+		Width: render.TerminalWidth(cmd.OutOrStdout()),
+	}
+	if absolute, err := cmd.Flags().GetBool("absolute"); err == nil && !absolute {
+		opts.Now = time.Now()
+	}
+	return opts
+}
+
+// applyColorMode validates --color and applies it to the render package so
+// renderers without explicit options follow the same setting.
+func applyColorMode(cmd *cobra.Command) error {
+	if flag := cmd.Flags().Lookup("color"); flag != nil {
+		if _, err := render.ParseColorMode(flag.Value.String()); err != nil {
+			return err
+		}
+	}
+	if renderOptions(cmd).Color {
+		render.EnableColor()
+	} else {
+		render.DisableColor()
+	}
+	return nil
+}
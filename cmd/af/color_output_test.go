@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newColorTestCmd returns a command carrying the same --color and --json
+// flags the root command provides.
+func newColorTestCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.Flags().String("color", "auto", "")
+	cmd.Flags().Bool("json", false, "")
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	_ = cmd.Execute()
+	return cmd
+}
+
+func TestRenderOptions_ColorFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"auto when piped", nil, false},
+		{"always", []string{"--color", "always"}, true},
+		{"never", []string{"--color", "never"}, false},
+		{"json disables color", []string{"--color", "always", "--json"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newColorTestCmd(tt.args...)
+			if got := renderOptions(cmd).Color; got != tt.want {
+				t.Errorf("renderOptions().Color = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderOptions_NoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	if renderOptions(newColorTestCmd()).Color {
+		t.Error("auto should not color when NO_COLOR is set")
+	}
+	if !renderOptions(newColorTestCmd("--color", "always")).Color {
+		t.Error("--color=always should override NO_COLOR")
+	}
+}
+
+func TestApplyColorMode_InvalidValue(t *testing.T) {
+	cmd := newColorTestCmd("--color", "sometimes")
+	if err := applyColorMode(cmd); err == nil {
+		t.Error("expected error for invalid --color value")
+	}
+}
@@ -294,7 +294,7 @@ func init() {
 		// Find and register completions for commands that take node IDs
 		nodeIDCmds := []string{
 			"claim", "refine", "accept", "release", "challenge",
-			"get", "deps", "history", "scope", "amend", "archive",
+			"get", "show", "deps", "history", "scope", "amend", "archive",
 			"admit", "refute",
 		}
 
@@ -0,0 +1,282 @@
+// Package main contains the af config command for reading and changing proof limits.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// configKey describes a setting that af config can read and change.
+type configKey struct {
+	get func(cfg *service.Config) string
+	set func(cfg *service.Config, value string) error
+}
+
+// configKeys maps the setting names accepted by af config to their fields.
+var configKeys = map[string]configKey{
+	"max-depth": {
+		get: func(cfg *service.Config) string { return strconv.Itoa(cfg.MaxDepth) },
+		set: func(cfg *service.Config, value string) (err error) {
+			cfg.MaxDepth, err = strconv.Atoi(value)
+			return err
+		},
+	},
+	"max-children": {
+		get: func(cfg *service.Config) string { return strconv.Itoa(cfg.MaxChildren) },
+		set: func(cfg *service.Config, value string) (err error) {
+			cfg.MaxChildren, err = strconv.Atoi(value)
+			return err
+		},
+	},
+	"lock-timeout": {
+		get: func(cfg *service.Config) string { return cfg.LockTimeout.String() },
+		set: func(cfg *service.Config, value string) (err error) {
+			cfg.LockTimeout, err = time.ParseDuration(value)
+			return err
+		},
+	},
+	"blocking-severities": {
+		get: func(cfg *service.Config) string { return strings.Join(cfg.BlockingSeverities, ",") },
+		set: func(cfg *service.Config, value string) error {
+			cfg.BlockingSeverities = nil
+			for _, sev := range strings.Split(value, ",") {
+				if sev = strings.TrimSpace(sev); sev != "" {
+					cfg.BlockingSeverities = append(cfg.BlockingSeverities, sev)
+				}
+			}
+			if len(cfg.BlockingSeverities) == 0 {
+				return fmt.Errorf("at least one severity is required")
+			}
+			return nil
+		},
+	},
+	"default-owner": {
+		get: func(cfg *service.Config) string { return cfg.DefaultOwner },
+		set: func(cfg *service.Config, value string) error {
+			cfg.DefaultOwner = value
+			return nil
+		},
+	},
+}
+
+// configKeyNames returns the names of the settings af config accepts, sorted.
+func configKeyNames() []string {
+	names := make([]string, 0, len(configKeys))
+	for name := range configKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupConfigKey returns the setting called name, or an error listing the
+// valid names.
+func lookupConfigKey(name string) (configKey, error) {
+	key, ok := configKeys[name]
+	if !ok {
+		return configKey{}, fmt.Errorf("unknown setting %q: must be one of %s", name, strings.Join(configKeyNames(), ", "))
+	}
+	return key, nil
+}
+
+// newConfigCmd creates the config command and its get and set subcommands.
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config",
+		GroupID: GroupAdmin,
+		Short:   "Show or change the proof's limits",
+		Long: `Show or change the runtime-tunable limits stored in the proof's meta.json.
+
+Settings:
+  max-depth            Maximum depth of the proof tree (1-100)
+  max-children         Maximum number of children per node (1-100)
+  lock-timeout         Maximum duration a lock can be held (1s-1h)
+  blocking-severities  Comma-separated challenge severities that block acceptance
+  default-owner        Owner used when neither --owner nor $AF_OWNER is given
+                       (an empty value clears it)
+
+Lowering a limit only constrains later operations: nodes that already
+exceed it are kept.
+
+Examples:
+  af config get                                   Show all settings
+  af config get max-depth                         Show one setting
+  af config set max-depth 30                      Allow deeper proof trees
+  af config set blocking-severities critical      Only critical challenges block
+  af config set default-owner prover-1            Make --owner optional`,
+	}
+
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+
+	return cmd
+}
+
+func newConfigGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get [setting]",
+		Short: "Show the proof's limits",
+		Long: `Show all runtime-tunable settings, or the value of a single setting.
+
+Examples:
+  af config get                  Show all settings
+  af config get max-children     Show one setting
+  af config get -f json          Output in JSON format`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runConfigGet,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+func newConfigSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <setting> <value>",
+		Short: "Change one of the proof's limits",
+		Long: `Change a runtime-tunable setting and save it to meta.json.
+
+The new value is validated before anything is written. Lowering
+max-depth or max-children below what existing nodes already use is
+allowed; the command reports it, and the limit applies to new nodes only.
+
+Examples:
+  af config set max-depth 30
+  af config set max-children 5
+  af config set lock-timeout 10m
+  af config set blocking-severities critical,major
+  af config set default-owner prover-1`,
+		Args: cobra.ExactArgs(2),
+		RunE: runConfigSet,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+
+	return cmd
+}
+
+// loadConfigService opens the proof in the command's --dir and checks that
+// it is initialized.
+func loadConfigService(cmd *cobra.Command) (*service.ProofService, error) {
+	svc, err := service.NewProofService(service.MustString(cmd, "dir"))
+	if err != nil {
+		return nil, fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return nil, fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return nil, fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+	return svc, nil
+}
+
+// runConfigGet executes the config get command.
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(service.MustString(cmd, "format"))
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	names := configKeyNames()
+	if len(args) == 1 {
+		if _, err := lookupConfigKey(args[0]); err != nil {
+			return err
+		}
+		names = args
+	}
+
+	svc, err := loadConfigService(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := svc.Config()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		values[name] = configKeys[name].get(cfg)
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, values)
+	}
+	if format == "json" {
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	if len(args) == 1 {
+		fmt.Fprintln(out, values[args[0]])
+		return nil
+	}
+	for _, name := range names {
+		fmt.Fprintf(out, "%-20s %s\n", name, values[name])
+	}
+	return nil
+}
+
+// runConfigSet executes the config set command.
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	name, value := args[0], args[1]
+	key, err := lookupConfigKey(name)
+	if err != nil {
+		return err
+	}
+
+	svc, err := loadConfigService(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := svc.Config()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	updated := *cfg
+	if err := key.set(&updated, value); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, name, err)
+	}
+	if err := svc.SetConfig(&updated); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Set %s = %s\n", name, key.get(&updated))
+
+	stats, err := svc.Stats()
+	if err != nil {
+		return fmt.Errorf("error computing stats: %w", err)
+	}
+	switch {
+	case name == "max-depth" && stats.MaxDepth > updated.MaxDepth:
+		fmt.Fprintf(out, "Note: existing nodes reach depth %d; they are kept, but no new node may exceed depth %d.\n",
+			stats.MaxDepth, updated.MaxDepth)
+	case name == "max-children" && stats.MaxChildren > updated.MaxChildren:
+		fmt.Fprintf(out, "Note: a node already has %d children; they are kept, but nodes with %d or more children accept no new ones.\n",
+			stats.MaxChildren, updated.MaxChildren)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newConfigCmd())
+}
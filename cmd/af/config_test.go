@@ -0,0 +1,116 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeConfigCommand executes args against a fresh command tree with the
+// config subcommand and returns the combined output.
+func executeConfigCommand(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newConfigCmd())
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.String(), err
+}
+
+// TestConfig_SetAndGet verifies that a setting changed with config set is
+// saved and shown by config get.
+func TestConfig_SetAndGet(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Config conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	out, err := executeConfigCommand("config", "set", "max-depth", "30", "-d", dir)
+	if err != nil {
+		t.Fatalf("config set failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Set max-depth = 30") {
+		t.Errorf("unexpected config set output: %q", out)
+	}
+
+	out, err = executeConfigCommand("config", "get", "max-depth", "-d", dir)
+	if err != nil {
+		t.Fatalf("config get failed: %v\n%s", err, out)
+	}
+	if out != "30\n" {
+		t.Errorf("config get max-depth = %q, want %q", out, "30\n")
+	}
+
+	out, err = executeConfigCommand("config", "get", "-d", dir, "-f", "json")
+	if err != nil {
+		t.Fatalf("config get failed: %v\n%s", err, out)
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(out), &values); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	if values["max-depth"] != "30" || values["lock-timeout"] != "5m0s" || values["blocking-severities"] != "critical,major" {
+		t.Errorf("config get values = %v", values)
+	}
+}
+
+// TestConfig_SetMaxChildrenBelowExisting verifies that max-children can be
+// lowered below an existing node's child count and that the output says so.
+func TestConfig_SetMaxChildrenBelowExisting(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Config conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1.1", "1.2"} {
+		nodeID, _ := service.ParseNodeID(id)
+		if err := svc.CreateNode(nodeID, service.NodeTypeClaim, "Claim "+id, service.InferenceAssumption); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := executeConfigCommand("config", "set", "max-children", "1", "-d", dir)
+	if err != nil {
+		t.Fatalf("config set failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "a node already has 2 children") {
+		t.Errorf("config set should note the existing children, got: %q", out)
+	}
+}
+
+// TestConfig_Invalid verifies that unknown settings and invalid values are
+// rejected.
+func TestConfig_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Config conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"config", "set", "max-width", "3"},
+		{"config", "set", "max-depth", "0"},
+		{"config", "set", "max-depth", "deep"},
+		{"config", "set", "lock-timeout", "-1s"},
+		{"config", "set", "blocking-severities", "critical,urgent"},
+		{"config", "get", "max-width"},
+	} {
+		if out, err := executeConfigCommand(append(args, "-d", dir)...); err == nil {
+			t.Errorf("%v succeeded, output: %s", args, out)
+		}
+	}
+
+	out, err := executeConfigCommand("config", "get", "max-depth", "-d", dir)
+	if err != nil || out != "20\n" {
+		t.Errorf("max-depth after rejected updates = %q, %v; want 20", out, err)
+	}
+}
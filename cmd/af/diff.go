@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newDiffCmd creates the diff command for comparing state between two ledger sequences.
+func newDiffCmd() *cobra.Command {
+	var dir string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "diff <from-seq> <to-seq>",
+		GroupID: GroupQuery,
+		Short:   "Show node changes between two points in ledger history",
+		Long: `Show how the proof state changed between two ledger sequence numbers.
+
+The state is rebuilt as it was after event <from-seq> and after event
+<to-seq>, and the differences are listed: nodes that were added, nodes
+that were archived, and changes to the workflow, epistemic, or taint
+state of existing nodes.
+
+Use 'af log' to find sequence numbers. A <from-seq> of 0 compares
+against the empty state before the first event.
+
+Examples:
+  af diff 10 25               Show what changed between events 10 and 25
+  af diff 0 5                 Show the state built by the first 5 events
+  af diff 10 25 -f json       Output the diff in JSON format`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, args[0], args[1], dir, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, fromStr, toStr, dir, format string) error {
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	fromSeq, err := strconv.Atoi(fromStr)
+	if err != nil || fromSeq < 0 {
+		return fmt.Errorf("invalid from sequence %q: must be a non-negative integer", fromStr)
+	}
+	toSeq, err := strconv.Atoi(toStr)
+	if err != nil || toSeq < 0 {
+		return fmt.Errorf("invalid to sequence %q: must be a non-negative integer", toStr)
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	// Check if proof is initialized
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	diff, err := svc.Diff(fromSeq, toSeq)
+	if err != nil {
+		return err
+	}
+
+	view := render.StateDiffToView(diff)
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderStateDiff(view))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newDiffCmd())
+}
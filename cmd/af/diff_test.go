@@ -0,0 +1,120 @@
+//go:build integration
+
+// Package main contains tests for the af diff command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeDiffCommand creates and executes a diff command with the given arguments.
+func executeDiffCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newDiffCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// setupDiffTest creates a proof, accepts node 1, and returns the directory
+// together with the sequence numbers before and after the acceptance.
+func setupDiffTest(t *testing.T) (string, int, int, func()) {
+	t.Helper()
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	before := st.LatestSeq()
+
+	if err := svc.AcceptNode(mustParseNodeID(t, "1")); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	st, err = svc.LoadState()
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return tmpDir, before, st.LatestSeq(), cleanup
+}
+
+// TestDiffCommand_TextOutput tests that diff lists the epistemic change.
+func TestDiffCommand_TextOutput(t *testing.T) {
+	tmpDir, before, after, cleanup := setupDiffTest(t)
+	defer cleanup()
+
+	output, err := executeDiffCommand(t, fmt.Sprint(before), fmt.Sprint(after), "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("diff failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "1 epistemic_state: pending -> validated") {
+		t.Errorf("output missing epistemic change:\n%s", output)
+	}
+}
+
+// TestDiffCommand_JSONOutput tests that diff emits the diff view as JSON.
+func TestDiffCommand_JSONOutput(t *testing.T) {
+	tmpDir, _, after, cleanup := setupDiffTest(t)
+	defer cleanup()
+
+	output, err := executeDiffCommand(t, "0", fmt.Sprint(after), "-d", tmpDir, "-f", "json")
+	if err != nil {
+		t.Fatalf("diff failed: %v\noutput: %s", err, output)
+	}
+
+	var view render.StateDiffView
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if view.FromSeq != 0 || view.ToSeq != after {
+		t.Errorf("range = %d..%d, want 0..%d", view.FromSeq, view.ToSeq, after)
+	}
+	if len(view.Added) != 1 || view.Added[0].ID != "1" {
+		t.Errorf("Added = %+v, want node 1", view.Added)
+	}
+}
+
+// TestDiffCommand_InvalidRange tests the error cases for sequence arguments.
+func TestDiffCommand_InvalidRange(t *testing.T) {
+	tmpDir, _, after, cleanup := setupDiffTest(t)
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr string
+	}{
+		{"from after to", "3", "1", "is after"},
+		{"past end", "1", fmt.Sprint(after + 5), "exceeds latest ledger sequence"},
+		{"not a number", "abc", "1", "invalid from sequence"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeDiffCommand(t, tt.from, tt.to, "-d", tmpDir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("diff %s %s error = %v, want error containing %q", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
+)
+
+// dryRunResultJSON is the JSON shape of a dry-run preview.
+type dryRunResultJSON struct {
+	DryRun bool              `json:"dry_run"`
+	Valid  bool              `json:"valid"`
+	Events []json.RawMessage `json:"events"`
+}
+
+// writeDryRunPreview reports that a dry-run operation passed validation and
+// lists the events it would have appended to the ledger.
+func writeDryRunPreview(cmd *cobra.Command, format string, events []ledger.Event) error {
+	if format == "json" {
+		result := dryRunResultJSON{DryRun: true, Valid: true, Events: make([]json.RawMessage, 0, len(events))}
+		for _, e := range events {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("failed to marshal event: %w", err)
+			}
+			result.Events = append(result.Events, data)
+		}
+		jsonBytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		cmd.Println(string(jsonBytes))
+		return nil
+	}
+
+	cmd.Println("Dry run: operation is valid; no changes were written.")
+	cmd.Printf("Would append %d event(s):\n", len(events))
+	for _, e := range events {
+		cmd.Printf("  %s\n", describeEvent(e))
+	}
+	return nil
+}
+
+// describeEvent returns a one-line summary of an event: its type followed
+// by the node or challenge it concerns, when it has one.
+func describeEvent(e ledger.Event) string {
+	var fields struct {
+		Node struct {
+			ID        string `json:"id"`
+			Statement string `json:"statement"`
+		} `json:"node"`
+		NodeID      string   `json:"node_id"`
+		NodeIDs     []string `json:"node_ids"`
+		ChallengeID string   `json:"challenge_id"`
+	}
+	data, err := json.Marshal(e)
+	if err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+
+	var subject string
+	switch {
+	case fields.Node.ID != "":
+		subject = fmt.Sprintf("%s: %s", fields.Node.ID, fields.Node.Statement)
+	case fields.ChallengeID != "":
+		subject = fields.ChallengeID
+	case fields.NodeID != "":
+		subject = fields.NodeID
+	case len(fields.NodeIDs) > 0:
+		subject = strings.Join(fields.NodeIDs, ", ")
+	}
+
+	if subject == "" {
+		return string(e.Type())
+	}
+	return fmt.Sprintf("%-20s %s", e.Type(), subject)
+}
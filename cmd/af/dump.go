@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newDumpCmd creates the dump command.
+func newDumpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dump",
+		GroupID: GroupAdmin,
+		Short:   "Dump the event ledger as a JSON array",
+		Long: `Dump every event in the ledger as a JSON array, in sequence order.
+
+Events are written exactly as stored, so the dump can be loaded into an
+empty proof directory with 'af restore' to back up a proof or move it
+between machines.
+
+Examples:
+  af dump                          Write the ledger to stdout
+  af dump -o backup.json           Write the ledger to a file
+  af dump --dir /path/to/proof     Dump a specific proof directory`,
+		Args: cobra.NoArgs,
+		RunE: runDump,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// runDump executes the dump command.
+func runDump(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+	output := service.MustString(cmd, "output")
+
+	data, err := ledger.Export(filepath.Join(dir, "ledger"))
+	if err != nil {
+		return fmt.Errorf("error dumping ledger: %w", err)
+	}
+
+	if output == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := service.WriteFileAtomic(output, data, 0644); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Ledger dumped to %s\n", output)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newDumpCmd())
+}
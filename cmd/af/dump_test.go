@@ -0,0 +1,93 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newTestDumpRestoreCmd creates a fresh root command with the dump and restore
+// subcommands for testing.
+func newTestDumpRestoreCmd() *cobra.Command {
+	cmd := newTestRootCmd()
+	cmd.AddCommand(newDumpCmd())
+	cmd.AddCommand(newRestoreCmd())
+	return cmd
+}
+
+// executeDumpRestoreCommand executes args against a fresh command tree and
+// returns the combined output.
+func executeDumpRestoreCommand(args ...string) (string, error) {
+	root := newTestDumpRestoreCmd()
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.String(), err
+}
+
+// TestDumpRestore_RoundTrip verifies that a dumped ledger restores into a new
+// directory with the same proof state.
+func TestDumpRestore_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "src")
+	dstDir := filepath.Join(tmpDir, "dst")
+	dumpFile := filepath.Join(tmpDir, "backup.json")
+
+	if err := service.Init(srcDir, "Dump conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if out, err := executeDumpRestoreCommand("dump", "-d", srcDir, "-o", dumpFile); err != nil {
+		t.Fatalf("dump failed: %v\n%s", err, out)
+	}
+
+	out, err := executeDumpRestoreCommand("restore", dumpFile, "-d", dstDir)
+	if err != nil {
+		t.Fatalf("restore failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Restored") {
+		t.Errorf("unexpected restore output: %q", out)
+	}
+
+	svc, err := service.NewProofService(dstDir)
+	if err != nil {
+		t.Fatalf("NewProofService failed: %v", err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState on restored proof failed: %v", err)
+	}
+	root, _ := service.ParseNodeID("1")
+	if n := st.GetNode(root); n == nil || n.Statement != "Dump conjecture" {
+		t.Errorf("restored root = %+v, want statement %q", n, "Dump conjecture")
+	}
+
+	// Restoring over a non-empty ledger is refused.
+	if _, err := executeDumpRestoreCommand("restore", dumpFile, "-d", dstDir); err == nil {
+		t.Error("expected restore into non-empty ledger to fail")
+	}
+}
+
+// TestDump_Stdout verifies that dump writes a JSON array to stdout by default.
+func TestDump_Stdout(t *testing.T) {
+	proofDir := filepath.Join(t.TempDir(), "proof")
+	if err := service.Init(proofDir, "Dump conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	out, err := executeDumpRestoreCommand("dump", "-d", proofDir)
+	if err != nil {
+		t.Fatalf("dump failed: %v", err)
+	}
+	if !strings.HasPrefix(out, "[") || !strings.Contains(out, `"proof_initialized"`) {
+		t.Errorf("dump output is not the event array:\n%s", out)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/cli"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newEscalateChallengeCmd creates the escalate-challenge command.
+func newEscalateChallengeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "escalate-challenge CHALLENGE_ID SEVERITY",
+		GroupID: GroupVerifier,
+		Short:   "Change the severity of an open challenge",
+		Long: `Change the severity of an open challenge to critical, major, minor, or note.
+
+Escalating a challenge to a blocking severity (critical or major by default)
+prevents its node from being accepted until the challenge is resolved.
+De-escalating it to a non-blocking severity lifts that block. The change is
+recorded in the ledger, so 'af log' shows the challenge's severity history.
+
+Examples:
+  af escalate-challenge chal-001 critical     A minor issue turned out to be serious
+  af escalate-challenge chal-001 note         Downgrade to an informational note
+  af escalate-challenge chal-001 major -f json`,
+		Args: cobra.ExactArgs(2),
+		RunE: runEscalateChallenge,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+func runEscalateChallenge(cmd *cobra.Command, args []string) error {
+	challengeID := args[0]
+	if strings.TrimSpace(challengeID) == "" {
+		return errors.New("challenge ID cannot be empty")
+	}
+	severity := strings.ToLower(strings.TrimSpace(args[1]))
+
+	dir := cli.MustString(cmd, "dir")
+	format := cli.MustString(cmd, "format")
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return errors.New("proof not initialized")
+	}
+
+	// Escalate the challenge (validates severity and that it is open)
+	if err := svc.EscalateChallenge(challengeID, severity); err != nil {
+		return fmt.Errorf("error changing challenge severity: %w", err)
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		result := map[string]interface{}{
+			"challenge_id": challengeID,
+			"severity":     severity,
+		}
+		output, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	default:
+		fmt.Fprintf(cmd.OutOrStdout(), "Challenge %s severity changed to %s.\n", challengeID, severity)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newEscalateChallengeCmd())
+}
@@ -0,0 +1,74 @@
+//go:build integration
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// setupEscalateChallengeTest creates a proof with a minor challenge on the
+// root node and returns the directory and challenge ID.
+func setupEscalateChallengeTest(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := service.ParseNodeID("1")
+	challengeID, err := svc.RaiseChallenge(rootID, "statement", "Typo", "minor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, challengeID
+}
+
+func executeEscalateChallenge(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newEscalateChallengeCmd())
+	return executeCommand(root, append([]string{"escalate-challenge"}, args...)...)
+}
+
+func TestEscalateChallengeCmd_Success(t *testing.T) {
+	dir, challengeID := setupEscalateChallengeTest(t)
+
+	output, err := executeEscalateChallenge(challengeID, "critical", "-d", dir)
+	if err != nil {
+		t.Fatalf("escalate-challenge failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "severity changed to critical") {
+		t.Errorf("unexpected output: %q", output)
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := service.ParseNodeID("1")
+	if err := svc.AcceptNode(rootID); err == nil {
+		t.Error("AcceptNode succeeded despite a critical challenge")
+	}
+}
+
+func TestEscalateChallengeCmd_InvalidSeverity(t *testing.T) {
+	dir, challengeID := setupEscalateChallengeTest(t)
+
+	if _, err := executeEscalateChallenge(challengeID, "blocker", "-d", dir); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestEscalateChallengeCmd_ChallengeNotFound(t *testing.T) {
+	dir, _ := setupEscalateChallengeTest(t)
+
+	_, err := executeEscalateChallenge("chal-missing", "major", "-d", dir)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("error = %v, want challenge not found", err)
+	}
+}
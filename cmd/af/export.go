@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -18,8 +17,26 @@ func newExportCmd() *cobra.Command {
 		Long: `Export the proof tree to various document formats.
 
 Supported formats:
-  - markdown, md: Export to Markdown format (default)
-  - latex, tex: Export to LaTeX format
+  - markdown, md: Export a Markdown document with a linked table of
+    contents and one section per node (default)
+  - latex, tex: Export to LaTeX, with the root as a theorem and each
+    node's children as a proof nested inside its parent's proof
+  - dot: Export the dependency graph in Graphviz DOT format
+  - mermaid: Export a Mermaid flowchart for embedding in Markdown docs
+  - csv: Export one row of status columns per node for spreadsheets
+
+In LaTeX output, admitted nodes are marked [admitted] and refuted nodes
+are omitted together with their subtrees; use --include-refuted to keep
+them, marked [refuted]. Markdown output likewise omits refuted and archived
+nodes and their subtrees unless --include-refuted is given.
+
+CSV output has a header row followed by one row per node, sorted by ID,
+with columns id, depth, type, inference, workflow_state, epistemic_state,
+taint_state, num_dependencies, num_children, claimed_by, and
+open_challenges. Use --include-statements to add a statement column.
+
+Output goes to stdout unless --output is given. Files are written atomically
+(temp file + rename), so a failed export never clobbers an existing file.
 
 The export includes:
   - Hierarchical node tree structure
@@ -32,13 +49,19 @@ Examples:
   af export --format latex            Export to stdout in LaTeX format
   af export -o proof.md               Export to file in Markdown format
   af export --format latex -o proof.tex  Export to LaTeX file
+  af export -f latex --include-refuted  Keep refuted nodes in LaTeX output
+  af export --format dot | dot -Tsvg > proof.svg  Render dependency graph
+  af export --format mermaid -o proof.mmd  Export Mermaid flowchart
+  af export -f csv --include-statements -o nodes.csv  Export node status
   af export --dir /path/to/proof      Export proof from specific directory`,
 		RunE: runExport,
 	}
 
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
-	cmd.Flags().StringP("format", "f", "markdown", "Output format (markdown, md, latex, tex)")
+	cmd.Flags().StringP("format", "f", "markdown", "Output format (markdown, md, latex, tex, dot, mermaid, csv)")
 	cmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().Bool("include-refuted", false, "Include refuted nodes in LaTeX output, and refuted and archived nodes in Markdown output")
+	cmd.Flags().Bool("include-statements", false, "Include node statements in CSV output")
 
 	return cmd
 }
@@ -49,11 +72,19 @@ func runExport(cmd *cobra.Command, args []string) error {
 	dir := service.MustString(cmd, "dir")
 	format := service.MustString(cmd, "format")
 	outputPath := service.MustString(cmd, "output")
+	includeRefuted, _ := cmd.Flags().GetBool("include-refuted")
+	includeStatements, _ := cmd.Flags().GetBool("include-statements")
 
 	// Validate format first (before checking directory)
 	format = strings.ToLower(format)
 	if err := service.ValidateExportFormat(format); err != nil {
-		return err
+		return invalidExportFormatError(format)
+	}
+	if includeRefuted && format != "latex" && format != "tex" && format != "markdown" && format != "md" {
+		return fmt.Errorf("--include-refuted applies only to the latex and markdown formats")
+	}
+	if includeStatements && format != "csv" {
+		return fmt.Errorf("--include-statements applies only to the csv format")
 	}
 
 	// Create proof service
@@ -78,14 +109,18 @@ func runExport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Export to the specified format
-	output, err := service.ExportProof(st, format)
+	output, err := service.ExportProofWithOptions(st, format, service.ExportOptions{
+		IncludeRefuted:    includeRefuted,
+		IncludeStatements: includeStatements,
+	})
 	if err != nil {
 		return fmt.Errorf("error exporting proof: %w", err)
 	}
 
-	// Output to file or stdout
+	// Output to file or stdout. File output is written atomically so a
+	// failed export never clobbers an existing file.
 	if outputPath != "" {
-		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+		if err := service.WriteFileAtomic(outputPath, []byte(output), 0644); err != nil {
 			return fmt.Errorf("error writing to file %q: %w", outputPath, err)
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Proof exported to %s\n", outputPath)
@@ -96,6 +131,25 @@ func runExport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// invalidExportFormatError creates an error for an unsupported export format,
+// with a fuzzy suggestion for likely typos and the list of valid formats.
+func invalidExportFormatError(format string) error {
+	formats := service.ExportFormats()
+
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("invalid export format %q", format))
+
+	if result := service.SuggestFlag(format, formats); result.AutoCorrect {
+		msg.WriteString(fmt.Sprintf("\n\nDid you mean: %s", result.Match))
+	}
+
+	msg.WriteString("\n\nValid formats:\n  ")
+	msg.WriteString(strings.Join(formats, ", "))
+	msg.WriteString("\n\nUsage:\n  af export --format <format> [--output FILE]")
+
+	return fmt.Errorf("%s", msg.String())
+}
+
 func init() {
 	rootCmd.AddCommand(newExportCmd())
 }
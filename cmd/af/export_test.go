@@ -4,10 +4,13 @@ package main
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
 )
 
 // =============================================================================
@@ -45,7 +48,7 @@ func TestExportCmd_ExpectedFlags(t *testing.T) {
 	cmd := newExportCmd()
 
 	// Check expected flags exist
-	expectedFlags := []string{"format", "dir", "output"}
+	expectedFlags := []string{"format", "dir", "output", "include-refuted", "include-statements"}
 	for _, flagName := range expectedFlags {
 		if cmd.Flags().Lookup(flagName) == nil && cmd.PersistentFlags().Lookup(flagName) == nil {
 			t.Errorf("expected export command to have flag %q", flagName)
@@ -183,6 +186,41 @@ func TestExportCmd_InvalidFormat(t *testing.T) {
 	}
 }
 
+// TestExportCmd_InvalidFormatListsValidFormats verifies the invalid format error
+// suggests a correction and lists the supported formats.
+func TestExportCmd_InvalidFormatListsValidFormats(t *testing.T) {
+	cmd := newTestExportCmd()
+	_, err := executeExportCommand(cmd, "export", "--format", "mermiad", "--dir", "/nonexistent/path")
+	if err == nil {
+		t.Fatal("expected error for invalid format, got nil")
+	}
+
+	for _, want := range []string{
+		`invalid export format "mermiad"`,
+		"Did you mean: mermaid",
+		"Valid formats:\n  markdown, md, latex, tex, dot, mermaid",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should contain %q, got:\n%s", want, err)
+		}
+	}
+}
+
+// TestExportCmd_InvalidFormatNoSuggestion verifies unrelated input gets no guess.
+func TestExportCmd_InvalidFormatNoSuggestion(t *testing.T) {
+	cmd := newTestExportCmd()
+	_, err := executeExportCommand(cmd, "export", "--format", "pdf", "--dir", "/nonexistent/path")
+	if err == nil {
+		t.Fatal("expected error for invalid format, got nil")
+	}
+	if strings.Contains(err.Error(), "Did you mean") {
+		t.Errorf("error should not suggest a format for %q, got:\n%s", "pdf", err)
+	}
+	if !strings.Contains(err.Error(), "Valid formats:") {
+		t.Errorf("error should list valid formats, got:\n%s", err)
+	}
+}
+
 // =============================================================================
 // Table-Driven Format Validation Tests
 // =============================================================================
@@ -200,6 +238,9 @@ func TestExportCmd_FormatValidation(t *testing.T) {
 		{"valid tex format", "tex", false},
 		{"valid MARKDOWN uppercase", "MARKDOWN", false},
 		{"valid LATEX uppercase", "LATEX", false},
+		{"valid dot format", "dot", false},
+		{"valid mermaid format", "mermaid", false},
+		{"valid csv format", "csv", false},
 		{"invalid pdf format", "pdf", true},
 		{"invalid xml format", "xml", true},
 		{"invalid json format", "json", true},
@@ -250,3 +291,93 @@ func TestExportCmd_HelpMentionsFormats(t *testing.T) {
 		}
 	}
 }
+
+// TestExportCmd_OutputFileReplacesExisting verifies --output writes the export
+// to a file, replacing any previous export.
+func TestExportCmd_OutputFileReplacesExisting(t *testing.T) {
+	proofDir := t.TempDir()
+	if err := service.Init(proofDir, "Export conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "proof.mmd")
+	if err := os.WriteFile(outPath, []byte("stale export"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newTestExportCmd()
+	output, err := executeExportCommand(cmd, "export", "--format", "mermaid", "--output", outPath, "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.Contains(output, "Proof exported to "+outPath) {
+		t.Errorf("expected confirmation message, got: %q", output)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "flowchart TD\n") || !strings.Contains(string(data), "Export conjecture") {
+		t.Errorf("unexpected export contents:\n%s", data)
+	}
+}
+
+// TestExportCmd_IncludeRefutedFormats verifies --include-refuted is
+// rejected for formats other than LaTeX and Markdown and accepted for them.
+func TestExportCmd_IncludeRefutedFormats(t *testing.T) {
+	proofDir := t.TempDir()
+	if err := service.Init(proofDir, "Export conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	cmd := newTestExportCmd()
+	_, err := executeExportCommand(cmd, "export", "--format", "csv", "--include-refuted", "--dir", proofDir)
+	if err == nil || !strings.Contains(err.Error(), "--include-refuted") {
+		t.Errorf("expected --include-refuted error for csv, got: %v", err)
+	}
+
+	cmd = newTestExportCmd()
+	output, err := executeExportCommand(cmd, "export", "--format", "markdown", "--include-refuted", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("markdown export failed: %v", err)
+	}
+	if !strings.Contains(output, "[1](#node-1)") {
+		t.Errorf("expected a contents link to node 1, got:\n%s", output)
+	}
+
+	cmd = newTestExportCmd()
+	output, err = executeExportCommand(cmd, "export", "--format", "latex", "--include-refuted", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("latex export failed: %v", err)
+	}
+	if !strings.Contains(output, "\\begin{theorem}\nExport conjecture") {
+		t.Errorf("expected root stated as a theorem, got:\n%s", output)
+	}
+}
+
+// TestExportCmd_IncludeStatementsCSVOnly verifies --include-statements is
+// rejected for formats other than CSV and adds a statement column for CSV.
+func TestExportCmd_IncludeStatementsCSVOnly(t *testing.T) {
+	proofDir := t.TempDir()
+	if err := service.Init(proofDir, "Export, \"quoted\" conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	cmd := newTestExportCmd()
+	_, err := executeExportCommand(cmd, "export", "--format", "latex", "--include-statements", "--dir", proofDir)
+	if err == nil || !strings.Contains(err.Error(), "--include-statements") {
+		t.Errorf("expected --include-statements error for latex, got: %v", err)
+	}
+
+	cmd = newTestExportCmd()
+	output, err := executeExportCommand(cmd, "export", "--format", "csv", "--include-statements", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("csv export failed: %v", err)
+	}
+	want := "id,depth,type,inference,workflow_state,epistemic_state,taint_state,num_dependencies,num_children,claimed_by,open_challenges,statement\n" +
+		"1,1,claim,assumption,available,pending,unresolved,0,0,,0,\"Export, \"\"quoted\"\" conjecture\"\n"
+	if output != want {
+		t.Errorf("csv export =\n%s\nwant:\n%s", output, want)
+	}
+}
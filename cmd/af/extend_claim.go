@@ -3,7 +3,6 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -33,7 +32,7 @@ Examples:
 	}
 
 	// Add flags
-	cmd.Flags().StringP("owner", "o", "", "Owner identity for the claim (required)")
+	cmd.Flags().StringP("owner", "o", "", "Owner identity for the claim (default $AF_OWNER or the default-owner setting)")
 	cmd.Flags().String("duration", service.DefaultClaimTimeout, "New claim duration from now (e.g., 30m, 1h, 2h30m)")
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory")
 	cmd.Flags().StringP("format", "f", "text", "Output format: text or json")
@@ -55,14 +54,13 @@ func runExtendClaim(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get flags
-	owner := service.MustString(cmd, "owner")
 	durationStr := service.MustString(cmd, "duration")
 	dir := service.MustString(cmd, "dir")
 	format := service.MustString(cmd, "format")
 
-	// Validate owner is not empty or whitespace
-	if strings.TrimSpace(owner) == "" {
-		return render.EmptyValueError("af extend-claim", "owner", examples)
+	owner, err := resolveOwner(cmd, "af extend-claim", dir, examples)
+	if err != nil {
+		return err
 	}
 
 	// Parse duration
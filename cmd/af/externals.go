@@ -46,7 +46,8 @@ func newExternalCmd() *cobra.Command {
 		Long: `Show details of a specific external reference.
 
 Retrieves and displays the external reference with the given name, including
-its source and metadata.
+its source and metadata, and the nodes that reference it in their context.
+References from archived nodes are shown separately.
 
 Examples:
   af external "Fermat's Last Theorem"    Show the external reference by name
@@ -159,12 +160,17 @@ func runExternal(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("external %q not found", name)
 	}
 
+	usage, err := svc.ExternalUsageDetail()
+	if err != nil {
+		return fmt.Errorf("error loading external usage: %w", err)
+	}
+
 	// Output based on format
 	if format == "json" {
-		return outputExternalJSON(cmd, ext, full)
+		return outputExternalJSON(cmd, ext, usage[ext.ID], full)
 	}
 
-	return outputExternalText(cmd, ext, full)
+	return outputExternalText(cmd, ext, usage[ext.ID], full)
 }
 
 // getAllExternals returns all externals from the proof directory.
@@ -234,8 +240,9 @@ func outputExternalsText(cmd *cobra.Command, externals []*node.External) error {
 }
 
 // outputExternalJSON outputs a single external in JSON format.
-func outputExternalJSON(cmd *cobra.Command, ext *node.External, full bool) error {
+func outputExternalJSON(cmd *cobra.Command, ext *node.External, usage service.ContextUsage, full bool) error {
 	output := externalToJSON(ext)
+	addContextUsageJSON(output, usage)
 
 	// If not full, we still include all basic fields
 	// The full flag mainly affects text output
@@ -250,9 +257,10 @@ func outputExternalJSON(cmd *cobra.Command, ext *node.External, full bool) error
 }
 
 // outputExternalText outputs a single external in text format.
-func outputExternalText(cmd *cobra.Command, ext *node.External, full bool) error {
+func outputExternalText(cmd *cobra.Command, ext *node.External, usage service.ContextUsage, full bool) error {
 	fmt.Fprintf(cmd.OutOrStdout(), "External: %s\n\n", ext.Name)
 	fmt.Fprintf(cmd.OutOrStdout(), "  Source: %s\n", ext.Source)
+	writeContextUsage(cmd.OutOrStdout(), usage)
 
 	if full {
 		fmt.Fprintln(cmd.OutOrStdout())
@@ -3,6 +3,9 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -86,6 +89,23 @@ func TestIsDryRun(t *testing.T) {
 	}
 }
 
+func TestIsAutoTaint(t *testing.T) {
+	cmd := &cobra.Command{Use: "test", Run: func(cmd *cobra.Command, args []string) {}}
+	cmd.PersistentFlags().Bool("no-auto-taint", false, "Don't record taint changes")
+
+	// Auto-taint is on by default
+	if !isAutoTaint(cmd) {
+		t.Error("isAutoTaint() should return true by default")
+	}
+
+	cmd.SetArgs([]string{"--no-auto-taint"})
+	_ = cmd.Execute()
+
+	if isAutoTaint(cmd) {
+		t.Error("isAutoTaint() should return false after parsing with --no-auto-taint")
+	}
+}
+
 func TestFlagsInheritedBySubcommands(t *testing.T) {
 	// Create root command with persistent flags
 	root := newTestRootCmd()
@@ -120,3 +140,84 @@ func TestFlagsInheritedBySubcommands(t *testing.T) {
 		t.Error("subcommand should inherit --dry-run flag")
 	}
 }
+
+func TestIsJSON(t *testing.T) {
+	cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+	cmd.PersistentFlags().Bool("json", false, "Emit JSON")
+
+	if isJSON(cmd) {
+		t.Error("isJSON() should return false by default")
+	}
+
+	cmd.SetArgs([]string{"--json"})
+	_ = cmd.Execute()
+
+	if !isJSON(cmd) {
+		t.Error("isJSON() should return true after parsing with --json")
+	}
+
+	// Commands without the flag registered report false rather than erroring
+	if isJSON(&cobra.Command{Use: "bare"}) {
+		t.Error("isJSON() should return false when the flag is not registered")
+	}
+}
+
+func TestApplyJSONFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		defFormat  string
+		wantFormat string
+	}{
+		{"json implies format json", []string{"--json"}, "text", "json"},
+		{"no json leaves default", []string{}, "text", "text"},
+		{"explicit format wins", []string{"--json", "--format", "text"}, "text", "text"},
+		{"non text/json command untouched", []string{"--json"}, "markdown", "markdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test", RunE: func(c *cobra.Command, _ []string) error {
+				return applyJSONFormat(c)
+			}}
+			cmd.Flags().Bool("json", false, "Emit JSON")
+			cmd.Flags().String("format", tt.defFormat, "Output format")
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() failed: %v", err)
+			}
+			if got, _ := cmd.Flags().GetString("format"); got != tt.wantFormat {
+				t.Errorf("format = %q, want %q", got, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestRenderErrorJSON(t *testing.T) {
+	cmd := &cobra.Command{Use: "claim"}
+	out := renderErrorJSON(cmd, fmt.Errorf("node not found"), "NODE_NOT_FOUND", 3)
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("renderErrorJSON output is not valid JSON: %v\n%s", err, out)
+	}
+	if got["command"] != "claim" {
+		t.Errorf("command = %v, want claim", got["command"])
+	}
+	if got["error"] != "node not found" {
+		t.Errorf("error = %v, want %q", got["error"], "node not found")
+	}
+	if got["exit_code"] != float64(3) {
+		t.Errorf("exit_code = %v, want 3", got["exit_code"])
+	}
+	if got["code"] != "NODE_NOT_FOUND" {
+		t.Errorf("code = %v, want NODE_NOT_FOUND", got["code"])
+	}
+
+	// A nil command (failure before dispatch) still renders
+	if out := renderErrorJSON(nil, fmt.Errorf("boom"), "", 1); !strings.Contains(out, `"error":"boom"`) {
+		t.Errorf("renderErrorJSON(nil, ...) = %s", out)
+	}
+}
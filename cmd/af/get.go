@@ -320,6 +320,7 @@ func nodeToJSONFull(n *node.Node, challenges []*service.Challenge, amendments []
 				"previous_statement": a.PreviousStatement,
 				"new_statement":      a.NewStatement,
 				"owner":              a.Owner,
+				"fields":             a.Fields,
 			}
 		}
 		result["amendment_history"] = amendmentList
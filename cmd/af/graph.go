@@ -0,0 +1,90 @@
+// Package main contains the af graph command for printing the raw dependency graph.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newGraphCmd creates the graph command for printing the raw dependency graph.
+func newGraphCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "graph",
+		GroupID: GroupQuery,
+		Short:   "Show the dependency graph as nodes, edges, and cycles",
+		Long: `Show the proof's dependency graph as raw data, for tools that lay out or
+analyze the graph themselves.
+
+The graph has every node with its metadata, an edge for every dependency
+and validation dependency, tagged "dependency" or "validation" and pointing
+from the dependent node to the node it depends on, and the dependency
+cycles, if any. It is the data 'af export --format dot' and
+'af export --format mermaid' are drawn from.
+
+Text output lists the edges and cycles; use --json for the full graph.
+
+Examples:
+  af graph                  List the edges and cycles
+  af graph --json           Output the full graph as JSON
+  af graph -d ./proof       Use a specific proof directory`,
+		Args: cobra.NoArgs,
+		RunE: runGraph,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+// runGraph executes the graph command.
+func runGraph(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+	format := strings.ToLower(service.MustString(cmd, "format"))
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+
+	graph, err := svc.DependencyGraph()
+	if err != nil {
+		return fmt.Errorf("error building dependency graph: %w", err)
+	}
+	view := render.GraphToView(graph)
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderDependencyGraph(view))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newGraphCmd())
+}
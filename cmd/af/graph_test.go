@@ -0,0 +1,108 @@
+//go:build !integration
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/service"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func executeGraph(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newGraphCmd())
+	return executeCommand(root, args...)
+}
+
+// setupGraphTest creates a proof where 1.2 depends on 1.1 and requires it
+// validated.
+func setupGraphTest(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := types.Parse("1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := types.Parse("1.1")
+	second, _ := types.Parse("1.2")
+	for _, spec := range []service.RefineSpec{
+		{ChildID: first, Statement: "First"},
+		{ChildID: second, Statement: "Second", Dependencies: []types.NodeID{first}, ValidationDeps: []types.NodeID{first}},
+	} {
+		spec.ParentID, spec.Owner = rootID, "prover"
+		spec.NodeType, spec.Inference = schema.NodeTypeClaim, schema.InferenceModusPonens
+		if err := svc.Refine(spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGraphCmd_Text(t *testing.T) {
+	dir := setupGraphTest(t)
+
+	output, err := executeGraph("graph", "-d", dir)
+	if err != nil {
+		t.Fatalf("graph failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{"3 node(s), 2 edge(s)", "1.2 -> 1.1 (dependency)", "1.2 -> 1.1 (validation)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Cycles") {
+		t.Errorf("output lists cycles for an acyclic graph:\n%s", output)
+	}
+}
+
+func TestGraphCmd_JSON(t *testing.T) {
+	dir := setupGraphTest(t)
+
+	output, err := executeGraph("graph", "-d", dir, "-f", "json")
+	if err != nil {
+		t.Fatalf("graph failed: %v\noutput: %s", err, output)
+	}
+	var got struct {
+		Nodes []struct {
+			ID string `json:"id"`
+		} `json:"nodes"`
+		Edges []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+			Kind string `json:"kind"`
+		} `json:"edges"`
+		Cycles [][]string `json:"cycles"`
+	}
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(got.Nodes) != 3 {
+		t.Errorf("got %d nodes, want 3", len(got.Nodes))
+	}
+	if len(got.Edges) != 2 || got.Edges[0].Kind != "dependency" || got.Edges[1].Kind != "validation" {
+		t.Errorf("edges = %+v, want a dependency and a validation edge", got.Edges)
+	}
+	if got.Cycles == nil {
+		t.Error("cycles serialized as null, want []")
+	}
+}
+
+func TestGraphCmd_InvalidFormat(t *testing.T) {
+	dir := setupGraphTest(t)
+
+	if _, err := executeGraph("graph", "-d", dir, "-f", "dot"); err == nil || !strings.Contains(err.Error(), "invalid format") {
+		t.Errorf("expected invalid format error, got %v", err)
+	}
+}
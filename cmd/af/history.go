@@ -116,7 +116,7 @@ func affectsNode(event map[string]interface{}, nodeID service.NodeID) bool {
 			}
 		}
 
-	case "nodes_claimed", "nodes_released":
+	case "nodes_claimed", "nodes_released", "nodes_reassigned":
 		// Check if node is in the list
 		if ids, ok := event["node_ids"].([]interface{}); ok {
 			for _, id := range ids {
@@ -126,7 +126,8 @@ func affectsNode(event map[string]interface{}, nodeID service.NodeID) bool {
 			}
 		}
 
-	case "node_validated", "node_admitted", "node_refuted", "node_archived", "taint_recomputed", "lock_reaped":
+	case "node_validated", "node_admitted", "node_refuted", "node_archived", "node_reopened", "taint_recomputed", "lock_reaped", "lemma_applied",
+		"node_tagged", "node_untagged", "note_added", "node_latex_set":
 		// Check node_id field
 		if id, ok := event["node_id"].(string); ok {
 			return id == nodeIDStr
@@ -185,6 +186,17 @@ func buildHistoryEntry(seq int, event map[string]interface{}) render.HistoryEntr
 			entry.Details["timeout"] = timeout
 		}
 
+	case "nodes_reassigned":
+		if toOwner, ok := event["to_owner"].(string); ok {
+			entry.Actor = toOwner
+		}
+		if fromOwner, ok := event["from_owner"].(string); ok {
+			entry.Details["from_owner"] = fromOwner
+		}
+		if timeout, ok := event["timeout"].(string); ok {
+			entry.Details["timeout"] = timeout
+		}
+
 	case "nodes_released":
 		// No actor info typically
 		if ids, ok := event["node_ids"].([]interface{}); ok {
@@ -212,6 +224,17 @@ func buildHistoryEntry(seq int, event map[string]interface{}) render.HistoryEntr
 			entry.Details["challenge_id"] = challengeID
 		}
 
+	case "challenge_escalated":
+		if challengeID, ok := event["challenge_id"].(string); ok {
+			entry.Details["challenge_id"] = challengeID
+		}
+		if severity, ok := event["severity"].(string); ok {
+			entry.Details["severity"] = severity
+		}
+		if previous, ok := event["previous_severity"].(string); ok {
+			entry.Details["previous_severity"] = previous
+		}
+
 	case "taint_recomputed":
 		if newTaint, ok := event["new_taint"].(string); ok {
 			entry.Details["new_taint"] = newTaint
@@ -222,6 +245,38 @@ func buildHistoryEntry(seq int, event map[string]interface{}) render.HistoryEntr
 			entry.Details["lemma"] = lemma
 		}
 
+	case "node_tagged", "node_untagged":
+		if tag, ok := event["tag"].(string); ok {
+			entry.Details["tag"] = tag
+		}
+
+	case "note_added":
+		if author, ok := event["author"].(string); ok {
+			entry.Actor = author
+		}
+		if noteID, ok := event["note_id"].(string); ok {
+			entry.Details["note_id"] = noteID
+		}
+		if text, ok := event["text"].(string); ok {
+			entry.Details["text"] = text
+		}
+
+	case "node_latex_set":
+		if owner, ok := event["owner"].(string); ok {
+			entry.Actor = owner
+		}
+		if latex, ok := event["latex"].(string); ok {
+			entry.Details["latex"] = latex
+		}
+
+	case "lemma_applied":
+		if owner, ok := event["owner"].(string); ok {
+			entry.Actor = owner
+		}
+		if lemmaID, ok := event["lemma_id"].(string); ok {
+			entry.Details["lemma_id"] = lemmaID
+		}
+
 	case "lock_reaped":
 		if owner, ok := event["owner"].(string); ok {
 			entry.Actor = owner
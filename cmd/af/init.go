@@ -29,10 +29,7 @@ The conjecture is the mathematical statement to be proven through
 adversarial collaboration between prover and verifier agents.
 
 Use --template to start with a predefined proof structure:
-  - contradiction: Proof by contradiction (assume negation, derive contradiction)
-  - induction: Proof by induction (base case, inductive step)
-  - cases: Proof by case analysis (multiple cases)
-
+` + templateSummary() + `
 Use --list-templates to see all available templates.
 
 Example:
@@ -55,12 +52,22 @@ Workflow:
 	cmd.Flags().StringVarP(&conjecture, "conjecture", "c", "", "The mathematical conjecture to prove (required unless --list-templates)")
 	cmd.Flags().StringVarP(&author, "author", "a", "", "The author initiating the proof (required unless --list-templates)")
 	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "The directory to initialize the proof in")
-	cmd.Flags().StringVarP(&template, "template", "t", "", "Use a proof template (contradiction, induction, cases)")
+	cmd.Flags().StringVarP(&template, "template", "t", "", "Use a proof template ("+strings.Join(service.TemplateNames(), ", ")+")")
 	cmd.Flags().BoolVar(&listTemplates, "list-templates", false, "List available proof templates")
 
 	return cmd
 }
 
+// templateSummary returns one "  - name: description" line per template,
+// for the init command's help text.
+func templateSummary() string {
+	var b strings.Builder
+	for _, tmpl := range service.ListTemplates() {
+		fmt.Fprintf(&b, "  - %s: %s\n", tmpl.Name, tmpl.Description)
+	}
+	return b.String()
+}
+
 // runListTemplates displays all available proof templates.
 func runListTemplates(cmd *cobra.Command) error {
 	cmd.Println("Available proof templates:")
@@ -96,7 +103,7 @@ func runInit(cmd *cobra.Command, conjecture, author, dir, templateName string) e
 		var ok bool
 		tmpl, ok = service.GetTemplate(templateName)
 		if !ok {
-			return fmt.Errorf("unknown template: %q (use --list-templates to see available templates)", templateName)
+			return fmt.Errorf("unknown template: %q (available: %s)", templateName, strings.Join(service.TemplateNames(), ", "))
 		}
 		hasTemplate = true
 	}
@@ -588,6 +588,9 @@ func TestInitCmd_InductionTemplate(t *testing.T) {
 	if !strings.Contains(strings.ToLower(node11.Statement), "base") {
 		t.Errorf("expected node 1.1 statement to contain 'base', got %q", node11.Statement)
 	}
+	if node11.Inference != service.InferenceAssumption {
+		t.Errorf("expected base case inference %q, got %q", service.InferenceAssumption, node11.Inference)
+	}
 
 	// Verify node 1.2 exists (inductive step)
 	node12 := st.GetNode(mustParseNodeID(t, "1.2"))
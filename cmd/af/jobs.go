@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/service"
 )
 
@@ -38,19 +39,59 @@ Examples:
   af jobs --role prover       List only prover jobs
   af jobs --role verifier     List only verifier jobs
   af jobs --format json       Output in JSON format
+  af jobs --format plain      One tab-separated line per job, for scripts
+  af jobs --sort id --limit 5 List the first five jobs by node ID
+  af jobs --blocking-first    List jobs with blocking challenges first
+  af jobs --ready-only        List only jobs whose dependencies are proven
+  af jobs --reap              Release expired claims before listing
+
+Output formats:
+  text   Human-readable sections with the recommended job marked.
+  json   An object with "prover_jobs" and "verifier_jobs" arrays (and
+         "reclaimable" when claims have expired). Each job has node_id,
+         type, statement, depth, the states of its dependencies and
+         validation_deps, and its open challenge counts by severity.
+  plain  role, node ID, type, depth, and statement, separated by tabs,
+         with no headers or summary.
+
+Jobs are listed in priority order by default: prover jobs with critical
+or major challenges first, then shallower nodes first. Use --sort depth or
+--sort id to order by depth or node ID instead. --limit N lists at most N
+jobs in total, prover jobs first.
+
+Each job in text output is marked ✓ when every node it depends on is
+validated or admitted (or it has no dependencies) and ✗ when it still
+waits on an unproven prerequisite; JSON output has "deps_satisfied".
+--ready-only lists only the ✓ jobs, so provers don't start on steps that
+are blocked by unproven dependencies.
+
+Jobs with open challenges whose severity blocks acceptance (critical and
+major unless 'af config set blocking-severities' says otherwise) are
+flagged "blocking". --blocking-first lists them ahead of the other jobs of
+their role, most severe challenge first, so the proof gets unblocked first.
 
 Workflow:
   To start working on a job, use 'af claim <node-id>' to claim it first.
   This prevents other agents from working on the same node. Once claimed,
   use the appropriate command for your role:
     Prover:   af refine <id>, af amend <id>, af resolve-challenge <id>
-    Verifier: af accept <id>, af challenge <id>`,
+    Verifier: af accept <id>, af challenge <id>
+
+  Claims expire after their timeout. Expired claims are listed in a
+  separate "reclaimable" section but are not released. Use --reap to
+  release them first, so nodes held by crashed agents are listed as
+  available again, or 'af reclaim <node-id>' to take one over directly.`,
 		RunE: runJobs,
 	}
 
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
-	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, or plain)")
 	cmd.Flags().StringP("role", "r", "", "Filter by role (prover or verifier)")
+	cmd.Flags().Int("limit", 0, "Maximum number of jobs to list (0 for no limit)")
+	cmd.Flags().String("sort", "", "Order jobs by depth or id instead of priority")
+	cmd.Flags().Bool("reap", false, "Release expired claims before listing jobs")
+	cmd.Flags().Bool("blocking-first", false, "List jobs with blocking challenges first")
+	cmd.Flags().Bool("ready-only", false, "List only jobs whose dependencies are validated or admitted")
 
 	return cmd
 }
@@ -61,11 +102,22 @@ func runJobs(cmd *cobra.Command, args []string) error {
 	dir := service.MustString(cmd, "dir")
 	format := service.MustString(cmd, "format")
 	role := service.MustString(cmd, "role")
+	reap := service.MustBool(cmd, "reap")
+	limit := service.MustInt(cmd, "limit")
+	sortBy := strings.ToLower(service.MustString(cmd, "sort"))
+	blockingFirst := service.MustBool(cmd, "blocking-first")
+	readyOnly := service.MustBool(cmd, "ready-only")
 
 	// Validate format
 	format = strings.ToLower(format)
-	if format != "" && format != "text" && format != "json" {
-		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	if format != "" && format != "text" && format != "json" && format != "plain" {
+		return fmt.Errorf("invalid format %q: must be 'text', 'json', or 'plain'", format)
+	}
+	if sortBy != "" && sortBy != jobSortDepth && sortBy != jobSortID {
+		return fmt.Errorf("invalid sort %q: must be 'depth' or 'id'", sortBy)
+	}
+	if limit < 0 {
+		return fmt.Errorf("invalid limit %d: must not be negative", limit)
 	}
 
 	// Validate role if provided (check if flag was explicitly set)
@@ -90,6 +142,15 @@ func runJobs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("proof not initialized")
 	}
 
+	// Free stale claims first so their nodes show up as jobs
+	var reaped []service.NodeID
+	if reap {
+		reaped, err = svc.ReapExpiredClaims()
+		if err != nil {
+			return fmt.Errorf("error reaping expired claims: %w", err)
+		}
+	}
+
 	// Load current state
 	st, err := svc.LoadState()
 	if err != nil {
@@ -109,9 +170,19 @@ func runJobs(cmd *cobra.Command, args []string) error {
 	// Build severity map for challenge severity counts
 	severityMap := buildSeverityMap(st.AllChallenges())
 
+	// Flag jobs whose open challenges block acceptance
+	blockingSeverities, err := svc.BlockingSeverities()
+	if err != nil {
+		return fmt.Errorf("error loading blocking severities: %w", err)
+	}
+	blocking := buildBlockingMap(st.AllChallenges(), blockingSeverities)
+
 	// Find jobs
 	jobResult := service.FindJobs(nodes, nodeMap, challengeMap)
 
+	// Expired claims are surfaced, not released (that's what --reap is for)
+	reclaimable := render.ReclaimableClaimsToViews(nodes)
+
 	// Apply role filter if specified
 	if roleSet && role == "prover" {
 		jobResult = &service.JobResult{
@@ -125,21 +196,57 @@ func runJobs(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Mark jobs whose dependencies are proven, dropping the rest with --ready-only
+	ready := buildReadyMap(st, jobResult)
+	if readyOnly {
+		jobResult = &service.JobResult{
+			ProverJobs:   readyJobs(jobResult.ProverJobs, ready),
+			VerifierJobs: readyJobs(jobResult.VerifierJobs, ready),
+		}
+	}
+
+	totalJobs := len(jobResult.ProverJobs) + len(jobResult.VerifierJobs)
+	jobResult = orderJobs(jobResult, severityMap, blocking, sortBy, blockingFirst, limit)
+
+	// Global --json: emit the jobs view model in the standard envelope
+	if isJSON(cmd) {
+		view := render.JobResultToView(jobResult)
+		view.Reclaimable = reclaimable
+		return writeJSONEnvelope(cmd, view)
+	}
+
 	// Output based on format
 	if format == "json" {
-		output := renderJobsJSONWithSeverity(jobResult, severityMap)
+		output := renderJobsJSONWithSeverity(st, jobResult, severityMap, blocking, reclaimable, sortBy)
 		fmt.Fprintln(cmd.OutOrStdout(), output)
 		return nil
 	}
+	if format == "plain" {
+		fmt.Fprint(cmd.OutOrStdout(), renderJobsPlain(jobResult))
+		return nil
+	}
 
 	// Text format
-	output := renderJobsWithSeverity(jobResult, severityMap)
+	if len(reaped) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Released %d expired claim(s): %s\n\n",
+			len(reaped), strings.Join(service.ToStringSlice(reaped), ", "))
+	}
+	output := renderJobsWithSeverity(jobResult, severityMap, blocking, ready, sortBy, renderOptions(cmd))
 	fmt.Fprint(cmd.OutOrStdout(), output)
+	if len(reclaimable) > 0 {
+		if !strings.HasSuffix(output, "\n") {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+		fmt.Fprint(cmd.OutOrStdout(), "\n"+render.RenderReclaimableViews(reclaimable, renderOptions(cmd)))
+	}
 
 	// Add summary line showing both job type counts
 	proverCount := len(jobResult.ProverJobs)
 	verifierCount := len(jobResult.VerifierJobs)
 	fmt.Fprintf(cmd.OutOrStdout(), "\nSummary: %d prover job(s), %d verifier job(s)\n", proverCount, verifierCount)
+	if listed := proverCount + verifierCount; listed < totalJobs {
+		fmt.Fprintf(cmd.OutOrStdout(), "Showing %d of %d jobs (--limit %d)\n", listed, totalJobs, limit)
+	}
 
 	return nil
 }
@@ -188,25 +295,173 @@ func buildSeverityMap(challenges []*service.Challenge) map[string]*severityCount
 	return result
 }
 
+// buildBlockingMap maps the ID of every node with an open challenge whose
+// severity is in blocking to the rank of its most severe such challenge
+// (see service.SeverityRank). Nodes without blocking challenges are absent.
+func buildBlockingMap(challenges []*service.Challenge, blocking []string) map[string]int {
+	result := make(map[string]int)
+	for _, c := range challenges {
+		severity := service.ChallengeSeverity(c.Severity)
+		if c.Status != service.ChallengeStatusOpen || !service.SeverityBlocksAcceptanceWith(severity, blocking) {
+			continue
+		}
+		rank := service.SeverityRank(severity)
+		if prev, ok := result[c.NodeID.String()]; !ok || rank < prev {
+			result[c.NodeID.String()] = rank
+		}
+	}
+	return result
+}
+
+// buildReadyMap returns, for every job in jobResult, whether its
+// dependencies are satisfied (see render.DependenciesSatisfied).
+func buildReadyMap(st *service.State, jobResult *service.JobResult) map[string]bool {
+	ready := make(map[string]bool)
+	for _, jobs := range [][]*node.Node{jobResult.ProverJobs, jobResult.VerifierJobs} {
+		for _, n := range jobs {
+			ready[n.ID.String()] = render.DependenciesSatisfied(st, n)
+		}
+	}
+	return ready
+}
+
+// readyJobs returns the nodes whose dependencies are satisfied in ready,
+// keeping their order.
+func readyJobs(nodes []*node.Node, ready map[string]bool) []*node.Node {
+	var kept []*node.Node
+	for _, n := range nodes {
+		if ready[n.ID.String()] {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// depsMarker returns the text marker for a job whose dependencies are
+// satisfied or not.
+func depsMarker(satisfied bool) string {
+	if satisfied {
+		return "✓"
+	}
+	return "✗"
+}
+
+// Orderings accepted by af jobs --sort. The default, "", is priority order.
+const (
+	jobSortDepth = "depth"
+	jobSortID    = "id"
+)
+
+// orderJobs returns the jobs of jobResult in display order, keeping at most
+// limit jobs in total (prover jobs first) when limit is positive.
+//
+// sortBy is "" for priority order (see proverJobPriority and
+// verifierJobPriority), jobSortDepth for shallowest first, or jobSortID for
+// node ID order. With blockingFirst, jobs in blocking come before the rest,
+// those with the most severe blocking challenge first, each group keeping
+// the sortBy order.
+func orderJobs(jobResult *service.JobResult, severityMap map[string]*severityCounts, blocking map[string]int, sortBy string, blockingFirst bool, limit int) *service.JobResult {
+	if jobResult == nil {
+		return &service.JobResult{}
+	}
+
+	prover := sortJobNodes(jobResult.ProverJobs, sortBy, func(n *node.Node) int {
+		return proverJobPriority(n, severityMap[n.ID.String()])
+	})
+	verifier := sortJobNodes(jobResult.VerifierJobs, sortBy, verifierJobPriority)
+	if blockingFirst {
+		prover = blockingJobsFirst(prover, blocking)
+		verifier = blockingJobsFirst(verifier, blocking)
+	}
+
+	if limit > 0 {
+		if len(prover) > limit {
+			prover = prover[:limit]
+		}
+		if rest := limit - len(prover); len(verifier) > rest {
+			verifier = verifier[:rest]
+		}
+	}
+	return &service.JobResult{ProverJobs: prover, VerifierJobs: verifier}
+}
+
+// sortJobNodes returns a sorted copy of nodes. In priority order, lower
+// priority scores come first; ties are broken by ID.
+func sortJobNodes(nodes []*node.Node, sortBy string, priority func(*node.Node) int) []*node.Node {
+	sorted := make([]*node.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch sortBy {
+		case jobSortID:
+			return a.ID.Less(b.ID)
+		case jobSortDepth:
+			if a.Depth() != b.Depth() {
+				return a.Depth() < b.Depth()
+			}
+			return a.ID.Less(b.ID)
+		default:
+			if pa, pb := priority(a), priority(b); pa != pb {
+				return pa < pb
+			}
+			// Tiebreaker: ID for stable sort
+			return a.ID.String() < b.ID.String()
+		}
+	})
+	return sorted
+}
+
+// blockingJobsFirst stably reorders nodes so that those in blocking come
+// first, lowest blocking rank first.
+func blockingJobsFirst(nodes []*node.Node, blocking map[string]int) []*node.Node {
+	// Non-blocking jobs rank after every severity
+	rank := func(n *node.Node) int {
+		if r, ok := blocking[n.ID.String()]; ok {
+			return r
+		}
+		return len(service.AllChallengeSeverities())
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return rank(nodes[i]) < rank(nodes[j]) })
+	return nodes
+}
+
+// depsLegend explains the dependency markers in the text output.
+const depsLegend = "✓ dependencies proven, ✗ waiting on unproven dependencies."
+
+// jobOrderDescription describes an ordering for the text output headers.
+func jobOrderDescription(sortBy string, prover bool) string {
+	switch sortBy {
+	case jobSortID:
+		return "Sorted by node ID."
+	case jobSortDepth:
+		return "Sorted by depth: shallower nodes first."
+	}
+	if prover {
+		return "Sorted by urgency: critical challenges first, then by depth."
+	}
+	return "Sorted by depth: breadth-first review (shallower nodes first)."
+}
+
 // formatSeverityCounts returns a human-readable string of severity counts.
 // Only shows non-zero counts. Example: "[1 critical, 2 minor challenges]"
-func formatSeverityCounts(counts *severityCounts) string {
+// Each count is colored by severity when opts.Color is set.
+func formatSeverityCounts(counts *severityCounts, opts render.RenderOptions) string {
 	if counts == nil {
 		return ""
 	}
 
 	var parts []string
 	if counts.Critical > 0 {
-		parts = append(parts, fmt.Sprintf("%d critical", counts.Critical))
+		parts = append(parts, opts.ColorSeverity("critical", fmt.Sprintf("%d critical", counts.Critical)))
 	}
 	if counts.Major > 0 {
-		parts = append(parts, fmt.Sprintf("%d major", counts.Major))
+		parts = append(parts, opts.ColorSeverity("major", fmt.Sprintf("%d major", counts.Major)))
 	}
 	if counts.Minor > 0 {
-		parts = append(parts, fmt.Sprintf("%d minor", counts.Minor))
+		parts = append(parts, opts.ColorSeverity("minor", fmt.Sprintf("%d minor", counts.Minor)))
 	}
 	if counts.Note > 0 {
-		parts = append(parts, fmt.Sprintf("%d note", counts.Note))
+		parts = append(parts, opts.ColorSeverity("note", fmt.Sprintf("%d note", counts.Note)))
 	}
 
 	if len(parts) == 0 {
@@ -222,50 +477,33 @@ func formatSeverityCounts(counts *severityCounts) string {
 	return fmt.Sprintf("[%s %s]", strings.Join(parts, ", "), suffix)
 }
 
-// renderJobsWithSeverity renders jobs with severity counts included.
-func renderJobsWithSeverity(jobResult *service.JobResult, severityMap map[string]*severityCounts) string {
+// renderJobsWithSeverity renders jobs, already in display order (see
+// orderJobs), with severity counts included, jobs in blocking flagged, and
+// each job marked by whether its dependencies are satisfied in ready.
+// The first job of each role is recommended only in the default priority
+// order.
+func renderJobsWithSeverity(jobResult *service.JobResult, severityMap map[string]*severityCounts, blocking map[string]int, ready map[string]bool, sortBy string, opts render.RenderOptions) string {
 	if jobResult == nil || jobResult.IsEmpty() {
 		return "No jobs available.\n\nProver jobs: 0 nodes awaiting refinement\nVerifier jobs: 0 nodes ready for review"
 	}
 
 	var sb strings.Builder
-
-	// Sort prover jobs by priority (most critical first, then by depth)
-	proverJobs := make([]*node.Node, len(jobResult.ProverJobs))
-	copy(proverJobs, jobResult.ProverJobs)
-	sort.Slice(proverJobs, func(i, j int) bool {
-		pi := proverJobPriority(proverJobs[i], severityMap[proverJobs[i].ID.String()])
-		pj := proverJobPriority(proverJobs[j], severityMap[proverJobs[j].ID.String()])
-		if pi != pj {
-			return pi < pj
-		}
-		// Tiebreaker: ID for stable sort
-		return proverJobs[i].ID.String() < proverJobs[j].ID.String()
-	})
-
-	// Sort verifier jobs by depth (breadth-first: shallower first)
-	verifierJobs := make([]*node.Node, len(jobResult.VerifierJobs))
-	copy(verifierJobs, jobResult.VerifierJobs)
-	sort.Slice(verifierJobs, func(i, j int) bool {
-		di := verifierJobPriority(verifierJobs[i])
-		dj := verifierJobPriority(verifierJobs[j])
-		if di != dj {
-			return di < dj
-		}
-		// Tiebreaker: ID for stable sort
-		return verifierJobs[i].ID.String() < verifierJobs[j].ID.String()
-	})
+	proverJobs := jobResult.ProverJobs
+	verifierJobs := jobResult.VerifierJobs
+	byPriority := sortBy == ""
 
 	// Render prover jobs section
 	if len(proverJobs) > 0 {
 		sb.WriteString(fmt.Sprintf("=== Prover Jobs (%d available) ===\n", len(proverJobs)))
 		sb.WriteString("Nodes awaiting refinement. Claim one and refine the proof.\n")
-		sb.WriteString("Sorted by urgency: critical challenges first, then by depth.\n\n")
+		sb.WriteString(jobOrderDescription(sortBy, true) + "\n")
+		sb.WriteString(depsLegend + "\n\n")
 		for i, n := range proverJobs {
-			isRecommended := i == 0
-			renderJobNodeWithPriority(&sb, n, severityMap[n.ID.String()], isRecommended, true)
+			isRecommended := byPriority && i == 0
+			_, isBlocking := blocking[n.ID.String()]
+			renderJobNodeWithPriority(&sb, n, severityMap[n.ID.String()], isRecommended, isBlocking, ready[n.ID.String()], opts)
 		}
-		if len(proverJobs) > 0 {
+		if byPriority {
 			recommended := proverJobs[0]
 			reason := proverPriorityReason(severityMap[recommended.ID.String()])
 			sb.WriteString(fmt.Sprintf("\nRecommended: Start with [%s] (%s)\n", recommended.ID.String(), reason))
@@ -282,12 +520,14 @@ func renderJobsWithSeverity(jobResult *service.JobResult, severityMap map[string
 	if len(verifierJobs) > 0 {
 		sb.WriteString(fmt.Sprintf("=== Verifier Jobs (%d available) ===\n", len(verifierJobs)))
 		sb.WriteString("Nodes ready for review. Verify or challenge the proof.\n")
-		sb.WriteString("Sorted by depth: breadth-first review (shallower nodes first).\n\n")
+		sb.WriteString(jobOrderDescription(sortBy, false) + "\n")
+		sb.WriteString(depsLegend + "\n\n")
 		for i, n := range verifierJobs {
-			isRecommended := i == 0
-			renderJobNodeWithPriority(&sb, n, severityMap[n.ID.String()], isRecommended, false)
+			isRecommended := byPriority && i == 0
+			_, isBlocking := blocking[n.ID.String()]
+			renderJobNodeWithPriority(&sb, n, severityMap[n.ID.String()], isRecommended, isBlocking, ready[n.ID.String()], opts)
 		}
-		if len(verifierJobs) > 0 {
+		if byPriority {
 			recommended := verifierJobs[0]
 			reason := verifierPriorityReason(recommended)
 			sb.WriteString(fmt.Sprintf("\nRecommended: Start with [%s] (%s)\n", recommended.ID.String(), reason))
@@ -299,12 +539,12 @@ func renderJobsWithSeverity(jobResult *service.JobResult, severityMap map[string
 }
 
 // renderJobNodeWithSeverity renders a single job node entry with severity counts.
-func renderJobNodeWithSeverity(sb *strings.Builder, n *node.Node, counts *severityCounts) {
+func renderJobNodeWithSeverity(sb *strings.Builder, n *node.Node, counts *severityCounts, opts render.RenderOptions) {
 	// Sanitize statement (remove control chars, normalize whitespace) but do NOT truncate
 	stmt := sanitizeJobStatement(n.Statement)
 
 	// Build the line with severity counts if present
-	severityStr := formatSeverityCounts(counts)
+	severityStr := formatSeverityCounts(counts, opts)
 	if severityStr != "" {
 		sb.WriteString(fmt.Sprintf("  [%s] %s: %q %s\n", n.ID.String(), string(n.Type), stmt, severityStr))
 	} else {
@@ -319,8 +559,9 @@ func renderJobNodeWithSeverity(sb *strings.Builder, n *node.Node, counts *severi
 
 // renderJobNodeWithPriority renders a single job node entry with priority indicator.
 // isRecommended marks the recommended starting job with a star.
-// isProver determines whether to show prover-specific or verifier-specific info.
-func renderJobNodeWithPriority(sb *strings.Builder, n *node.Node, counts *severityCounts, isRecommended bool, isProver bool) {
+// isBlocking flags a node with open challenges that block acceptance.
+// depsSatisfied selects the ✓ or ✗ dependency marker.
+func renderJobNodeWithPriority(sb *strings.Builder, n *node.Node, counts *severityCounts, isRecommended bool, isBlocking bool, depsSatisfied bool, opts render.RenderOptions) {
 	// Sanitize statement (remove control chars, normalize whitespace) but do NOT truncate
 	stmt := sanitizeJobStatement(n.Statement)
 
@@ -331,11 +572,14 @@ func renderJobNodeWithPriority(sb *strings.Builder, n *node.Node, counts *severi
 	}
 
 	// Build the line with severity counts if present
-	severityStr := formatSeverityCounts(counts)
+	severityStr := formatSeverityCounts(counts, opts)
+	if isBlocking {
+		severityStr = strings.TrimSpace(severityStr + " (blocking)")
+	}
 	if severityStr != "" {
-		sb.WriteString(fmt.Sprintf("%s[%s] %s: %q %s\n", prefix, n.ID.String(), string(n.Type), stmt, severityStr))
+		sb.WriteString(fmt.Sprintf("%s[%s] %s %s: %q %s\n", prefix, n.ID.String(), depsMarker(depsSatisfied), string(n.Type), stmt, severityStr))
 	} else {
-		sb.WriteString(fmt.Sprintf("%s[%s] %s: %q\n", prefix, n.ID.String(), string(n.Type), stmt))
+		sb.WriteString(fmt.Sprintf("%s[%s] %s %s: %q\n", prefix, n.ID.String(), depsMarker(depsSatisfied), string(n.Type), stmt))
 	}
 
 	// Show claimed-by info
@@ -409,93 +653,61 @@ func sanitizeJobStatement(s string) string {
 	return strings.TrimSpace(result)
 }
 
-// jobsJSONJobEntry represents a single job entry with severity info in JSON format.
-type jobsJSONJobEntry struct {
-	NodeID         string          `json:"node_id"`
-	Statement      string          `json:"statement"`
-	Type           string          `json:"type"`
-	Depth          int             `json:"depth"`
-	SeverityCounts *severityCounts `json:"severity_counts,omitempty"`
-	Recommended    bool            `json:"recommended,omitempty"`
-	PriorityReason string          `json:"priority_reason,omitempty"`
-}
-
-// jobsJSONOutput represents the JSON output for jobs command with severity info.
-type jobsJSONOutput struct {
-	ProverJobs   []jobsJSONJobEntry `json:"prover_jobs"`
-	VerifierJobs []jobsJSONJobEntry `json:"verifier_jobs"`
-}
-
-// renderJobsJSONWithSeverity renders jobs as JSON with severity counts included.
-// Jobs are sorted by priority and include recommended flags.
-func renderJobsJSONWithSeverity(jobResult *service.JobResult, severityMap map[string]*severityCounts) string {
+// renderJobsJSONWithSeverity renders jobs, already in display order (see
+// orderJobs), as a render.JobsView. Each job carries its severity counts and
+// dependency states, and those in blocking are marked blocking; in the default
+// priority order the first job of each role is marked recommended. Expired
+// claims are listed under "reclaimable".
+func renderJobsJSONWithSeverity(st *service.State, jobResult *service.JobResult, severityMap map[string]*severityCounts, blocking map[string]int, reclaimable []render.NodeView, sortBy string) string {
+	output := render.JobsView{
+		ProverJobs:   []render.JobView{},
+		VerifierJobs: []render.JobView{},
+	}
 	if jobResult == nil {
-		return `{"prover_jobs":[],"verifier_jobs":[]}`
+		jobResult = &service.JobResult{}
 	}
 
-	// Sort prover jobs by priority
-	proverJobs := make([]*node.Node, len(jobResult.ProverJobs))
-	copy(proverJobs, jobResult.ProverJobs)
-	sort.Slice(proverJobs, func(i, j int) bool {
-		pi := proverJobPriority(proverJobs[i], severityMap[proverJobs[i].ID.String()])
-		pj := proverJobPriority(proverJobs[j], severityMap[proverJobs[j].ID.String()])
-		if pi != pj {
-			return pi < pj
+	jobView := func(n *node.Node, recommended bool, reason func() string) render.JobView {
+		view := render.JobNodeToView(st, n)
+		if counts := severityMap[n.ID.String()]; counts != nil {
+			sc := render.SeverityCountsView(*counts)
+			view.SeverityCounts = &sc
 		}
-		return proverJobs[i].ID.String() < proverJobs[j].ID.String()
-	})
-
-	// Sort verifier jobs by depth
-	verifierJobs := make([]*node.Node, len(jobResult.VerifierJobs))
-	copy(verifierJobs, jobResult.VerifierJobs)
-	sort.Slice(verifierJobs, func(i, j int) bool {
-		di := verifierJobPriority(verifierJobs[i])
-		dj := verifierJobPriority(verifierJobs[j])
-		if di != dj {
-			return di < dj
+		_, view.Blocking = blocking[n.ID.String()]
+		if recommended {
+			view.Recommended = true
+			view.PriorityReason = reason()
 		}
-		return verifierJobs[i].ID.String() < verifierJobs[j].ID.String()
-	})
-
-	output := jobsJSONOutput{
-		ProverJobs:   make([]jobsJSONJobEntry, 0, len(proverJobs)),
-		VerifierJobs: make([]jobsJSONJobEntry, 0, len(verifierJobs)),
+		return view
 	}
+	byPriority := sortBy == ""
 
-	for i, job := range proverJobs {
-		counts := severityMap[job.ID.String()]
-		entry := jobsJSONJobEntry{
-			NodeID:    job.ID.String(),
-			Statement: job.Statement,
-			Type:      string(job.Type),
-			Depth:     job.Depth(),
-		}
-		if counts != nil {
-			entry.SeverityCounts = counts
-		}
-		if i == 0 {
-			entry.Recommended = true
-			entry.PriorityReason = proverPriorityReason(counts)
-		}
-		output.ProverJobs = append(output.ProverJobs, entry)
+	for i, job := range jobResult.ProverJobs {
+		output.ProverJobs = append(output.ProverJobs, jobView(job, byPriority && i == 0, func() string {
+			return proverPriorityReason(severityMap[job.ID.String()])
+		}))
+	}
+	for i, job := range jobResult.VerifierJobs {
+		output.VerifierJobs = append(output.VerifierJobs, jobView(job, byPriority && i == 0, func() string {
+			return verifierPriorityReason(job)
+		}))
 	}
 
-	for i, job := range verifierJobs {
-		counts := severityMap[job.ID.String()]
-		entry := jobsJSONJobEntry{
-			NodeID:    job.ID.String(),
-			Statement: job.Statement,
-			Type:      string(job.Type),
-			Depth:     job.Depth(),
-		}
-		if counts != nil {
-			entry.SeverityCounts = counts
+	for _, v := range reclaimable {
+		view := render.JobView{
+			NodeID:    v.ID,
+			Statement: v.Statement,
+			Type:      v.Type,
+			Depth:     v.Depth,
+			ClaimedBy: v.ClaimedBy,
+			Expired:   v.Expired,
 		}
-		if i == 0 {
-			entry.Recommended = true
-			entry.PriorityReason = verifierPriorityReason(job)
+		if id, err := service.ParseNodeID(v.ID); err == nil {
+			if n := st.GetNode(id); n != nil {
+				view.DepsSatisfied = render.DependenciesSatisfied(st, n)
+			}
 		}
-		output.VerifierJobs = append(output.VerifierJobs, entry)
+		output.Reclaimable = append(output.Reclaimable, view)
 	}
 
 	data, err := json.Marshal(output)
@@ -505,3 +717,19 @@ func renderJobsJSONWithSeverity(jobResult *service.JobResult, severityMap map[st
 
 	return string(data)
 }
+
+// renderJobsPlain renders jobs, already in display order, one per line as
+// tab-separated role, node ID, type, depth, and statement. Statements are
+// sanitized so that each job stays on one line.
+func renderJobsPlain(jobResult *service.JobResult) string {
+	var sb strings.Builder
+	write := func(role string, jobs []*node.Node) {
+		for _, n := range jobs {
+			stmt := strings.Join(strings.Fields(sanitizeJobStatement(n.Statement)), " ")
+			fmt.Fprintf(&sb, "%s\t%s\t%s\t%d\t%s\n", role, n.ID.String(), string(n.Type), n.Depth(), stmt)
+		}
+	}
+	write("prover", jobResult.ProverJobs)
+	write("verifier", jobResult.VerifierJobs)
+	return sb.String()
+}
@@ -0,0 +1,117 @@
+//go:build !integration
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/service"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func executeJobs(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newJobsCmd())
+	return executeCommand(root, args...)
+}
+
+// setupBlockingJobsTest creates a proof whose children 1.1, 1.2, and 1.3
+// have an open minor, major, and critical challenge respectively.
+func setupBlockingJobsTest(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := types.Parse("1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for i, severity := range []string{"minor", "major", "critical"} {
+		childID, _ := types.Parse("1." + string(rune('1'+i)))
+		if err := svc.RefineNode(rootID, "prover", childID, schema.NodeTypeClaim, "Step", schema.InferenceModusPonens); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.RaiseChallenge(childID, "", "Objection", severity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// proverJobIDs returns the node IDs of the prover jobs in plain output.
+func proverJobIDs(output string) []string {
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if fields := strings.Split(line, "\t"); len(fields) > 1 && fields[0] == "prover" {
+			ids = append(ids, fields[1])
+		}
+	}
+	return ids
+}
+
+func TestJobsCmd_BlockingFirst(t *testing.T) {
+	dir := setupBlockingJobsTest(t)
+
+	output, err := executeJobs("jobs", "-d", dir, "-f", "plain", "--sort", "id")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	if got := strings.Join(proverJobIDs(output), " "); got != "1.1 1.2 1.3" {
+		t.Errorf("--sort id prover jobs = %q, want %q", got, "1.1 1.2 1.3")
+	}
+
+	output, err = executeJobs("jobs", "-d", dir, "-f", "plain", "--sort", "id", "--blocking-first")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	if got := strings.Join(proverJobIDs(output), " "); got != "1.3 1.2 1.1" {
+		t.Errorf("--blocking-first prover jobs = %q, want %q", got, "1.3 1.2 1.1")
+	}
+}
+
+func TestJobsCmd_FlagsBlocking(t *testing.T) {
+	dir := setupBlockingJobsTest(t)
+
+	output, err := executeJobs("jobs", "-d", dir, "--sort", "id")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "[1.1]") && strings.Contains(line, "(blocking)") {
+			t.Errorf("minor challenge flagged as blocking: %q", line)
+		}
+		if strings.Contains(line, "[1.3]") && !strings.Contains(line, "(blocking)") {
+			t.Errorf("critical challenge not flagged as blocking: %q", line)
+		}
+	}
+
+	output, err = executeJobs("jobs", "-d", dir, "-f", "json")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	var got struct {
+		ProverJobs []struct {
+			NodeID   string `json:"node_id"`
+			Blocking bool   `json:"blocking"`
+		} `json:"prover_jobs"`
+	}
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	blocking := make(map[string]bool)
+	for _, j := range got.ProverJobs {
+		blocking[j.NodeID] = j.Blocking
+	}
+	if !blocking["1.2"] || !blocking["1.3"] || blocking["1.1"] {
+		t.Errorf("blocking = %v, want 1.2 and 1.3 only", blocking)
+	}
+}
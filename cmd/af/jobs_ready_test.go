@@ -0,0 +1,118 @@
+//go:build !integration
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/service"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// setupReadyJobsTest creates a proof with children 1.1 and 1.2, where 1.2
+// depends on the unproven 1.1.
+func setupReadyJobsTest(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := types.Parse("1")
+	childID, _ := types.Parse("1.1")
+	dependentID, _ := types.Parse("1.2")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNode(rootID, "prover", childID, schema.NodeTypeClaim, "Lemma", schema.InferenceModusPonens); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNodeWithDeps(rootID, "prover", dependentID, schema.NodeTypeClaim, "Uses the lemma",
+		schema.InferenceModusPonens, []types.NodeID{childID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ReleaseNode(rootID, "prover"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestJobsCmd_DepsMarkers(t *testing.T) {
+	dir := setupReadyJobsTest(t)
+
+	output, err := executeJobs("jobs", "-d", dir)
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{"[1] ✓ claim:", "[1.1] ✓ claim:", "[1.2] ✗ claim:"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = executeJobs("jobs", "-d", dir, "-f", "json")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	var result struct {
+		VerifierJobs []struct {
+			NodeID        string `json:"node_id"`
+			DepsSatisfied bool   `json:"deps_satisfied"`
+		} `json:"verifier_jobs"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	got := make(map[string]bool)
+	for _, j := range result.VerifierJobs {
+		got[j.NodeID] = j.DepsSatisfied
+	}
+	want := map[string]bool{"1": true, "1.1": true, "1.2": false}
+	for id, satisfied := range want {
+		if v, ok := got[id]; !ok || v != satisfied {
+			t.Errorf("deps_satisfied[%s] = %v (listed %v), want %v", id, v, ok, satisfied)
+		}
+	}
+}
+
+func TestJobsCmd_ReadyOnly(t *testing.T) {
+	dir := setupReadyJobsTest(t)
+
+	output, err := executeJobs("jobs", "-d", dir, "-f", "plain", "--sort", "id", "--ready-only")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if fields := strings.Split(line, "\t"); len(fields) > 1 {
+			ids = append(ids, fields[1])
+		}
+	}
+	if got := strings.Join(ids, " "); got != "1 1.1" {
+		t.Errorf("--ready-only jobs = %q, want %q", got, "1 1.1")
+	}
+
+	// Once its dependency is validated, 1.2 is ready too
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	childID, _ := types.Parse("1.1")
+	if err := svc.AcceptNode(childID); err != nil {
+		t.Fatal(err)
+	}
+	output, err = executeJobs("jobs", "-d", dir, "-f", "plain", "--sort", "id", "--ready-only")
+	if err != nil {
+		t.Fatalf("jobs failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "\t1.2\t") {
+		t.Errorf("--ready-only output missing 1.2 after its dependency was validated:\n%s", output)
+	}
+}
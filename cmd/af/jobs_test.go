@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/service"
 )
 
@@ -953,3 +955,243 @@ func TestJobsCommand_SeverityCountsSingularChallenge(t *testing.T) {
 		t.Errorf("expected '[... challenge]' in output, got: %q", output)
 	}
 }
+
+// =============================================================================
+// Reap Tests
+// =============================================================================
+
+// TestJobsCmd_ReapReleasesExpiredClaims verifies that --reap frees stale claims
+// before listing jobs.
+func TestJobsCmd_ReapReleasesExpiredClaims(t *testing.T) {
+	proofDir, cleanup := setupJobsTestWithNodes(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child1ID, _ := service.ParseNodeID("1.1")
+	if err := svc.ClaimNode(child1ID, "crashed-agent", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	cmd := newTestJobsCmd()
+	output, err := executeCommand(cmd, "jobs", "--dir", proofDir, "--reap")
+	if err != nil {
+		t.Fatalf("jobs --reap failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Released 1 expired claim(s): 1.1") {
+		t.Errorf("expected reap summary in output, got: %q", output)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetNode(child1ID).WorkflowState; got != service.WorkflowAvailable {
+		t.Errorf("node 1.1 WorkflowState = %q, want %q", got, service.WorkflowAvailable)
+	}
+}
+
+// TestJobsCmd_WithoutReapKeepsExpiredClaims verifies that claims are only
+// released when --reap is given.
+func TestJobsCmd_WithoutReapKeepsExpiredClaims(t *testing.T) {
+	proofDir, cleanup := setupJobsTestWithNodes(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child1ID, _ := service.ParseNodeID("1.1")
+	if err := svc.ClaimNode(child1ID, "crashed-agent", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	cmd := newTestJobsCmd()
+	if _, err := executeCommand(cmd, "jobs", "--dir", proofDir); err != nil {
+		t.Fatalf("jobs failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetNode(child1ID).WorkflowState; got != service.WorkflowClaimed {
+		t.Errorf("node 1.1 WorkflowState = %q, want %q", got, service.WorkflowClaimed)
+	}
+}
+
+// TestJobsCmd_ShowsReclaimableClaims verifies that expired claims are listed
+// in their own section, in both text and JSON output, without being released.
+func TestJobsCmd_ShowsReclaimableClaims(t *testing.T) {
+	proofDir, cleanup := setupJobsTestWithNodes(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child1ID, _ := service.ParseNodeID("1.1")
+	if err := svc.ClaimNode(child1ID, "crashed-agent", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	output, err := executeCommand(newTestJobsCmd(), "jobs", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("jobs failed: %v", err)
+	}
+	if !strings.Contains(output, "Reclaimable Claims (1 expired)") ||
+		!strings.Contains(output, "claimed by: crashed-agent (expired)") {
+		t.Errorf("expected reclaimable section for 1.1, got: %q", output)
+	}
+
+	output, err = executeCommand(newTestJobsCmd(), "jobs", "--dir", proofDir, "--format", "json")
+	if err != nil {
+		t.Fatalf("jobs --format json failed: %v", err)
+	}
+	var result struct {
+		Reclaimable []struct {
+			NodeID    string `json:"node_id"`
+			ClaimedBy string `json:"claimed_by"`
+			Expired   bool   `json:"expired"`
+		} `json:"reclaimable"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+	if len(result.Reclaimable) != 1 || result.Reclaimable[0].NodeID != "1.1" ||
+		result.Reclaimable[0].ClaimedBy != "crashed-agent" || !result.Reclaimable[0].Expired {
+		t.Errorf("reclaimable = %+v, want expired claim on 1.1 by crashed-agent", result.Reclaimable)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetNode(child1ID).WorkflowState; got != service.WorkflowClaimed {
+		t.Errorf("node 1.1 WorkflowState = %q, want %q", got, service.WorkflowClaimed)
+	}
+}
+
+// TestJobsCmd_PlainFormat verifies one tab-separated line per job.
+func TestJobsCmd_PlainFormat(t *testing.T) {
+	proofDir, cleanup := setupJobsTestWithNodes(t)
+	defer cleanup()
+
+	output, err := executeCommand(newTestJobsCmd(), "jobs", "--format", "plain", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("jobs --format plain failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	want := []string{
+		"verifier\t1\tclaim\t1\tComplex conjecture",
+		"verifier\t1.1\tclaim\t2\tFirst child",
+		"verifier\t1.2\tclaim\t2\tSecond child",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("plain output = %q, want %q", lines, want)
+	}
+}
+
+// TestJobsCmd_SortAndLimit verifies --sort and --limit.
+func TestJobsCmd_SortAndLimit(t *testing.T) {
+	proofDir, cleanup := setupJobsTestWithNodes(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child10ID, _ := service.ParseNodeID("1.10")
+	if err := svc.CreateNode(child10ID, service.NodeTypeClaim, "Tenth child", service.InferenceModusPonens); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(newTestJobsCmd(), "jobs", "--format", "plain", "--sort", "id", "--limit", "3", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("jobs --sort id --limit 3 failed: %v", err)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		ids = append(ids, strings.Split(line, "\t")[1])
+	}
+	if got := strings.Join(ids, ","); got != "1,1.1,1.2" {
+		t.Errorf("--sort id --limit 3 listed %s, want 1,1.1,1.2", got)
+	}
+
+	output, err = executeCommand(newTestJobsCmd(), "jobs", "--sort", "depth", "--limit", "2", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("jobs --sort depth failed: %v", err)
+	}
+	if !strings.Contains(output, "Showing 2 of 4 jobs") || strings.Contains(output, "Recommended:") {
+		t.Errorf("expected a limited listing without a recommendation, got: %q", output)
+	}
+
+	for _, args := range [][]string{{"--sort", "priority"}, {"--limit", "-1"}, {"--format", "csv"}} {
+		if _, err := executeCommand(newTestJobsCmd(), append([]string{"jobs", "--dir", proofDir}, args...)...); err == nil {
+			t.Errorf("jobs %v succeeded, want an error", args)
+		}
+	}
+}
+
+// TestJobsCmd_JSONDependencyStates verifies that JSON jobs include the
+// states of their dependencies.
+func TestJobsCmd_JSONDependencyStates(t *testing.T) {
+	proofDir, cleanup := setupJobsTestWithNodes(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := service.ParseNodeID("1")
+	child1ID, _ := service.ParseNodeID("1.1")
+	child3ID, _ := service.ParseNodeID("1.3")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Refine(service.RefineSpec{
+		ParentID:     rootID,
+		Owner:        "prover",
+		ChildID:      child3ID,
+		NodeType:     service.NodeTypeClaim,
+		Statement:    "Uses the first child",
+		Inference:    service.InferenceModusPonens,
+		Dependencies: []service.NodeID{child1ID},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ReleaseNode(rootID, "prover"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(newTestJobsCmd(), "jobs", "--format", "json", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("jobs --format json failed: %v", err)
+	}
+	var result render.JobsView
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %s", err, output)
+	}
+
+	var job *render.JobView
+	for i := range result.VerifierJobs {
+		if result.VerifierJobs[i].NodeID == "1.3" {
+			job = &result.VerifierJobs[i]
+		}
+	}
+	if job == nil {
+		t.Fatalf("verifier jobs %+v do not include 1.3", result.VerifierJobs)
+	}
+	if len(job.Dependencies) != 1 || job.Dependencies[0].ID != "1.1" ||
+		!job.Dependencies[0].Exists || job.Dependencies[0].EpistemicState != "pending" {
+		t.Errorf("1.3 dependencies = %+v, want pending 1.1", job.Dependencies)
+	}
+	if !result.VerifierJobs[0].Recommended || result.VerifierJobs[0].PriorityReason == "" {
+		t.Errorf("first verifier job %+v is not recommended", result.VerifierJobs[0])
+	}
+}
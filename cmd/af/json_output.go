@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+)
+
+// writeJSONEnvelope writes data to the command's output wrapped in the
+// standard --json envelope ({"command", "schema_version", "data"}).
+// Data should be a render view model so the output shape is stable.
+func writeJSONEnvelope(cmd *cobra.Command, data interface{}) error {
+	if c := capturedJSON(cmd); c != nil {
+		c.data = data
+		c.hasData = true
+		return nil
+	}
+	output, err := render.RenderJSONEnvelope(cmd.Name(), data)
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
+
+// applyJSONFormat makes the global --json flag imply --format json for
+// commands with a text/json format flag. An explicitly set --format takes
+// precedence, and commands whose format flag selects something other than
+// text/json (e.g. export) are left alone.
+func applyJSONFormat(cmd *cobra.Command) error {
+	if !isJSON(cmd) {
+		return nil
+	}
+	format := cmd.Flags().Lookup("format")
+	if format == nil || format.Changed || format.DefValue != "text" {
+		return nil
+	}
+	return format.Value.Set("json")
+}
+
+// renderErrorJSON renders an already-sanitized error as a JSON error envelope.
+// code is the error's stable code, taken from the unsanitized error since
+// sanitizing may drop its type. cmd may be nil if the failure happened
+// before a command was resolved.
+func renderErrorJSON(cmd *cobra.Command, err error, code string, exitCode int) string {
+	name := ""
+	if cmd != nil {
+		name = cmd.Name()
+	}
+	return render.RenderJSONError(name, code, err.Error(), exitCode)
+}
+
+// streamingJSONCommands lists the commands whose --json output is a stream of
+// JSON lines, written as it is produced. They are not wrapped in the envelope.
+var streamingJSONCommands = map[string]bool{
+	"log":   true,
+	"watch": true,
+}
+
+// jsonCaptureKey is the context key of a command's jsonCapture.
+type jsonCaptureKey struct{}
+
+// jsonCapture holds the output of a command run with --json until the
+// command finishes, so that it can be written as a single envelope.
+type jsonCapture struct {
+	out     io.Writer
+	buf     bytes.Buffer
+	data    interface{}
+	hasData bool
+}
+
+// captureJSONOutput redirects the output of a command run with --json into a
+// buffer, to be wrapped in the envelope by flushJSONOutput. Streaming output
+// (see streamingJSONCommands and --watch) is left alone.
+func captureJSONOutput(cmd *cobra.Command) {
+	if !isJSON(cmd) || streamingJSONCommands[cmd.Name()] {
+		return
+	}
+	if watch := cmd.Flags().Lookup("watch"); watch != nil && watch.Value.String() == "true" {
+		return
+	}
+	c := &jsonCapture{out: cmd.OutOrStdout()}
+	cmd.SetOut(&c.buf)
+	cmd.SetContext(context.WithValue(cmd.Context(), jsonCaptureKey{}, c))
+}
+
+// capturedJSON returns the pending capture of cmd, or nil if its output is
+// not being captured.
+func capturedJSON(cmd *cobra.Command) *jsonCapture {
+	if cmd == nil || cmd.Context() == nil {
+		return nil
+	}
+	c, _ := cmd.Context().Value(jsonCaptureKey{}).(*jsonCapture)
+	return c
+}
+
+// flushJSONOutput writes the captured output of cmd wrapped in the envelope
+// and restores its output. Data passed to writeJSONEnvelope is used as is;
+// otherwise JSON output is embedded unchanged and any other output becomes a
+// string. A failed command that printed nothing writes no envelope, since its
+// error envelope is all there is to report.
+func flushJSONOutput(cmd *cobra.Command, failed bool) error {
+	c := capturedJSON(cmd)
+	if c == nil {
+		return nil
+	}
+	cmd.SetOut(c.out)
+	cmd.SetContext(context.WithValue(cmd.Context(), jsonCaptureKey{}, nil))
+
+	data := c.data
+	if !c.hasData {
+		captured := bytes.TrimSpace(c.buf.Bytes())
+		switch {
+		case len(captured) == 0 && failed:
+			return nil
+		case len(captured) == 0:
+			data = nil
+		case json.Valid(captured):
+			data = json.RawMessage(captured)
+		default:
+			data = string(captured)
+		}
+	}
+	output, err := render.RenderJSONEnvelope(cmd.Name(), data)
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	fmt.Fprintln(c.out, output)
+	return nil
+}
+
+// jsonRequested returns true if the global --json flag is set. An unknown
+// command fails before flags are parsed, so the raw arguments are checked
+// as well.
+func jsonRequested(cmd *cobra.Command, args []string) bool {
+	if cmd != nil {
+		if flag := cmd.Flags().Lookup("json"); flag != nil && flag.Changed {
+			return flag.Value.String() == "true"
+		}
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--json" || arg == "--json=true" {
+			return true
+		}
+	}
+	return false
+}
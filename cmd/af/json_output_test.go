@@ -0,0 +1,191 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newJSONTestRootCmd returns a test root with the global --json flag and the
+// hooks that wrap command output in the envelope, plus a few real commands.
+func newJSONTestRootCmd() *cobra.Command {
+	root := newTestRootCmd()
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+	root.PersistentFlags().Bool("json", false, "")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyJSONFormat(cmd); err != nil {
+			return err
+		}
+		captureJSONOutput(cmd)
+		return nil
+	}
+	root.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+		return flushJSONOutput(cmd, false)
+	}
+	root.AddCommand(newClaimCmd())
+	root.AddCommand(newChallengesCmd())
+	root.AddCommand(newJobsCmd())
+	root.AddCommand(&cobra.Command{
+		Use: "hello",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(cmd.OutOrStdout(), "Hello, world")
+			return nil
+		},
+	})
+	return root
+}
+
+// decodeEnvelope parses output as a single JSON envelope and checks its command.
+func decodeEnvelope(t *testing.T, output, command string) map[string]json.RawMessage {
+	t.Helper()
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(output), &envelope); err != nil {
+		t.Fatalf("output is not a single JSON object: %v\n%s", err, output)
+	}
+	var got string
+	if err := json.Unmarshal(envelope["command"], &got); err != nil || got != command {
+		t.Errorf("command = %s, want %q", envelope["command"], command)
+	}
+	if string(envelope["schema_version"]) != "1" {
+		t.Errorf("schema_version = %s, want 1", envelope["schema_version"])
+	}
+	if _, ok := envelope["data"]; !ok {
+		t.Errorf("envelope has no data field: %s", output)
+	}
+	return envelope
+}
+
+func TestJSONEnvelope_Commands(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		command string
+		args    []string
+	}{
+		{"claim", []string{"claim", "1", "--owner", "prover", "--json", "-d", dir}},
+		{"challenges", []string{"challenges", "--json", "-d", dir}},
+		{"jobs", []string{"jobs", "--json", "-d", dir}},
+		{"hello", []string{"hello", "--json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			output, err := executeCommand(newJSONTestRootCmd(), tt.args...)
+			if err != nil {
+				t.Fatalf("%s failed: %v\n%s", tt.command, err, output)
+			}
+			decodeEnvelope(t, output, tt.command)
+		})
+	}
+}
+
+func TestJSONEnvelope_TextOutputBecomesString(t *testing.T) {
+	output, err := executeCommand(newJSONTestRootCmd(), "hello", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := decodeEnvelope(t, output, "hello")
+	var data string
+	if err := json.Unmarshal(envelope["data"], &data); err != nil || data != "Hello, world" {
+		t.Errorf("data = %s, want \"Hello, world\"", envelope["data"])
+	}
+}
+
+func TestJSONEnvelope_WithoutJSONFlag(t *testing.T) {
+	output, err := executeCommand(newJSONTestRootCmd(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != "Hello, world\n" {
+		t.Errorf("output = %q, want the plain text", output)
+	}
+}
+
+func TestJSONEnvelope_JobsEmptyLists(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(newJSONTestRootCmd(), "jobs", "--json", "--role", "prover", "-d", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := decodeEnvelope(t, output, "jobs")
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(envelope["data"], &data); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"prover_jobs", "verifier_jobs", "reclaimable"} {
+		if !strings.HasPrefix(string(data[field]), "[") {
+			t.Errorf("%s = %s, want an array", field, data[field])
+		}
+	}
+	if string(data["verifier_jobs"]) != "[]" {
+		t.Errorf("verifier_jobs = %s, want [] with --role prover", data["verifier_jobs"])
+	}
+	if string(data["reclaimable"]) != "[]" {
+		t.Errorf("reclaimable = %s, want []", data["reclaimable"])
+	}
+}
+
+func TestFlushJSONOutput_FailedWithoutOutput(t *testing.T) {
+	root := newJSONTestRootCmd()
+	root.AddCommand(&cobra.Command{
+		Use: "fail",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("failed")
+		},
+	})
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetArgs([]string{"fail", "--json"})
+	cmd, err := root.ExecuteC()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := flushJSONOutput(cmd, true); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want nothing besides the error envelope", buf.String())
+	}
+}
+
+func TestJSONRequested(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{"flag", []string{"--json", "bogus"}, true},
+		{"flag after command", []string{"bogus", "--json"}, true},
+		{"explicit true", []string{"bogus", "--json=true"}, true},
+		{"explicit false", []string{"bogus", "--json=false"}, false},
+		{"absent", []string{"bogus"}, false},
+		{"after terminator", []string{"bogus", "--", "--json"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newJSONTestRootCmd()
+			root.SetArgs(tt.args)
+			cmd, err := root.ExecuteC()
+			if err == nil {
+				t.Fatal("expected an unknown command error")
+			}
+			if got := jsonRequested(cmd, tt.args); got != tt.want {
+				t.Errorf("jsonRequested(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package main contains the af lint command for reporting proof-quality warnings.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newLintCmd creates the lint command for reporting proof-quality warnings.
+func newLintCmd() *cobra.Command {
+	defaults := service.DefaultLintOptions()
+
+	cmd := &cobra.Command{
+		Use:     "lint",
+		GroupID: GroupAdmin,
+		Short:   "Report proof-quality warnings",
+		Long: `Check the proof for quality problems that are not structural errors.
+Use 'af validate' for structural invariants.
+
+Rules:
+  long_statement    (info)     statement longer than --max-statement-length
+  unsupported_leaf  (warning)  claim validated without any supporting children
+  admitted_debt     (info)     node admitted without proof
+  linear_chain      (info)     at least --min-chain-length nodes without
+                               branching, which might be collapsible
+  expired_claim     (warning)  node still claimed past its timeout
+  unused_assumption (warning)  assumption no live node references in its
+                               context
+
+Archived nodes are skipped. Rule IDs are stable, so specific rules can be
+suppressed with --disable. Warnings never make the command fail.
+
+Examples:
+  af lint                                 Lint the proof in the current directory
+  af lint --disable admitted_debt         Skip the admitted_debt rule
+  af lint --max-statement-length 200      Flag statements over 200 characters
+  af lint -f json                         Output warnings in JSON format`,
+		Args: cobra.NoArgs,
+		RunE: runLint,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+	cmd.Flags().StringSlice("disable", nil, "Rule IDs to skip (comma-separated)")
+	cmd.Flags().Int("max-statement-length", defaults.MaxStatementLength, "Longest statement, in characters, before long_statement applies")
+	cmd.Flags().Int("min-chain-length", defaults.MinChainLength, "Fewest nodes without branching that linear_chain reports")
+
+	return cmd
+}
+
+// runLint executes the lint command.
+func runLint(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+	format := strings.ToLower(service.MustString(cmd, "format"))
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	opts := service.DefaultLintOptions()
+	var err error
+	if opts.Disabled, err = cmd.Flags().GetStringSlice("disable"); err != nil {
+		return err
+	}
+	if opts.MaxStatementLength, err = cmd.Flags().GetInt("max-statement-length"); err != nil {
+		return err
+	}
+	if opts.MinChainLength, err = cmd.Flags().GetInt("min-chain-length"); err != nil {
+		return err
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+
+	warnings, err := svc.LintWithOptions(opts)
+	if err != nil {
+		return fmt.Errorf("error linting proof: %w", err)
+	}
+	if warnings == nil {
+		warnings = []service.LintWarning{}
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, warnings)
+	}
+	if format == "json" {
+		data, err := json.MarshalIndent(warnings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	if len(warnings) == 0 {
+		fmt.Fprintln(out, "No lint warnings.")
+		return nil
+	}
+	for _, w := range warnings {
+		// Warnings about assumptions carry no node
+		first := "-"
+		if len(w.NodeIDs) > 0 {
+			first = w.NodeIDs[0].String()
+		}
+		fmt.Fprintf(out, "%-8s %-10s %-17s %s\n", first, w.Severity, w.Rule, w.Message)
+	}
+	fmt.Fprintf(out, "\n%d warning(s)\n", len(warnings))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newLintCmd())
+}
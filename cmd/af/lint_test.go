@@ -0,0 +1,109 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeLintCommand executes args against a fresh command tree with the
+// lint subcommand and returns the combined output.
+func executeLintCommand(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newLintCmd())
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.String(), err
+}
+
+// setupLintTest creates a proof whose root child 1.1 is admitted.
+func setupLintTest(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := service.Init(dir, "Lint conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	childID, _ := service.ParseNodeID("1.1")
+	if err := svc.CreateNode(childID, service.NodeTypeClaim, "A short claim", service.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AdmitNode(childID); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// TestLint_Text verifies that warnings are listed with their rule and
+// severity, and that --disable and the thresholds change what is reported.
+func TestLint_Text(t *testing.T) {
+	dir := setupLintTest(t)
+
+	out, err := executeLintCommand("lint", "-d", dir)
+	if err != nil {
+		t.Fatalf("lint failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "1.1") || !strings.Contains(out, "admitted_debt") || !strings.Contains(out, "1 warning(s)") {
+		t.Errorf("unexpected lint output: %q", out)
+	}
+
+	out, err = executeLintCommand("lint", "-d", dir, "--disable", "admitted_debt")
+	if err != nil {
+		t.Fatalf("lint failed: %v\n%s", err, out)
+	}
+	if out != "No lint warnings.\n" {
+		t.Errorf("lint with admitted_debt disabled = %q", out)
+	}
+
+	out, err = executeLintCommand("lint", "-d", dir, "--disable", "admitted_debt", "--max-statement-length", "5")
+	if err != nil {
+		t.Fatalf("lint failed: %v\n%s", err, out)
+	}
+	if strings.Count(out, "long_statement") != 2 {
+		t.Errorf("expected both statements to be flagged as long, got: %q", out)
+	}
+}
+
+// TestLint_JSON verifies the JSON output.
+func TestLint_JSON(t *testing.T) {
+	dir := setupLintTest(t)
+
+	out, err := executeLintCommand("lint", "-d", dir, "-f", "json")
+	if err != nil {
+		t.Fatalf("lint failed: %v\n%s", err, out)
+	}
+	var warnings []service.LintWarning
+	if err := json.Unmarshal([]byte(out), &warnings); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	if len(warnings) != 1 || warnings[0].Rule != service.LintAdmittedDebt || warnings[0].NodeIDs[0].String() != "1.1" {
+		t.Errorf("warnings = %+v, want admitted_debt on 1.1", warnings)
+	}
+}
+
+// TestLint_InvalidOptions verifies that unknown rules and bad thresholds
+// are rejected.
+func TestLint_InvalidOptions(t *testing.T) {
+	dir := setupLintTest(t)
+
+	for _, args := range [][]string{
+		{"lint", "--disable", "no_such_rule"},
+		{"lint", "--min-chain-length", "1"},
+		{"lint", "-f", "xml"},
+	} {
+		if out, err := executeLintCommand(append(args, "-d", dir)...); err == nil {
+			t.Errorf("%v succeeded, output: %s", args, out)
+		}
+	}
+}
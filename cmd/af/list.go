@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newListCmd creates the list command for showing one line per node.
+func newListCmd() *cobra.Command {
+	var dir string
+	var format string
+	var filter string
+	var sortBy string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		GroupID: GroupQuery,
+		Short:   "List nodes one per line",
+		Long: `List proof nodes one per line, with their claim and epistemic state:
+
+  1.2.1  [claimed by alice]  [pending]  "Statement"
+
+This sits between 'af status' (aggregate counts) and 'af tree' (the full
+tree). Columns are aligned, and long statements are wrapped to the terminal
+width ($COLUMNS, the terminal's width, or 80 columns when piped).
+
+Filters (--filter):
+  pending, validated, refuted  Nodes in that epistemic state
+  claimed                      Nodes currently claimed
+  tainted                      Nodes that are tainted or self-admitted
+
+Sort orders (--sort):
+  id     Node ID (default)
+  depth  Shallowest nodes first
+  state  Epistemic state: pending, needs_refinement, validated, admitted,
+         refuted, archived
+
+Examples:
+  af list                       List every node
+  af list --filter pending      List pending nodes
+  af list --filter claimed      List claimed nodes and their owners
+  af list --sort depth          List nodes level by level
+  af list -f json               Output the list in JSON format`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, dir, format, filter, sortBy)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+	cmd.Flags().StringVar(&filter, "filter", "", "Only list nodes that are pending/claimed/validated/refuted/tainted")
+	cmd.Flags().StringVar(&sortBy, "sort", "id", "Sort order (id/depth/state)")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, dir, format, filter, sortBy string) error {
+	examples := render.GetExamples("af list")
+
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	// Validate filter and sort order
+	filter = strings.ToLower(filter)
+	if filter != "" && !containsString(render.ValidListFilters, filter) {
+		return render.InvalidValueError("af list", "filter", filter, render.ValidListFilters, examples)
+	}
+	sortBy = strings.ToLower(sortBy)
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	if !containsString(render.ValidListSorts, sortBy) {
+		return render.InvalidValueError("af list", "sort", sortBy, render.ValidListSorts, examples)
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	// Check if proof is initialized
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	// Load state
+	st, err := svc.LoadState()
+	if err != nil {
+		return fmt.Errorf("error loading proof state: %w", err)
+	}
+
+	view := render.StateToNodeListView(st, filter, sortBy)
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderNodeList(view, renderOptions(cmd)))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newListCmd())
+}
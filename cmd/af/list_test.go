@@ -0,0 +1,119 @@
+//go:build integration
+
+// Package main contains tests for the af list command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeListCommand creates and executes a list command with the given arguments.
+func executeListCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newListCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// setupListTest creates a proof whose root is claimed by alice and has two
+// pending children.
+func setupListTest(t *testing.T) string {
+	t.Helper()
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	t.Cleanup(cleanup)
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := service.ParseNodeID("1")
+	if err := svc.ClaimNode(rootID, "alice", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1.1", "1.2"} {
+		childID, _ := service.ParseNodeID(id)
+		if err := svc.RefineNode(rootID, "alice", childID, service.NodeTypeClaim, "Step "+id, service.InferenceAssumption); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+// TestListCmd_Text tests the aligned one-line-per-node output.
+func TestListCmd_Text(t *testing.T) {
+	tmpDir := setupListTest(t)
+	t.Setenv("COLUMNS", "")
+
+	output, err := executeListCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("list failed: %v\noutput: %s", err, output)
+	}
+	want := "1    [claimed by alice]  [pending]  \"Test conjecture\"\n" +
+		"1.1  [available]         [pending]  \"Step 1.1\"\n" +
+		"1.2  [available]         [pending]  \"Step 1.2\"\n"
+	if output != want {
+		t.Errorf("output =\n%s\nwant\n%s", output, want)
+	}
+}
+
+// TestListCmd_FilterAndWidth tests --filter and wrapping to $COLUMNS.
+func TestListCmd_FilterAndWidth(t *testing.T) {
+	tmpDir := setupListTest(t)
+	t.Setenv("COLUMNS", "48")
+
+	output, err := executeListCommand(t, "-d", tmpDir, "--filter", "claimed")
+	if err != nil {
+		t.Fatalf("list failed: %v\noutput: %s", err, output)
+	}
+	want := "1  [claimed by alice]  [pending]  \"Test\n" +
+		strings.Repeat(" ", 35) + "conjecture\"\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+// TestListCmd_JSON tests JSON output with a sort order.
+func TestListCmd_JSON(t *testing.T) {
+	tmpDir := setupListTest(t)
+
+	output, err := executeListCommand(t, "-d", tmpDir, "--sort", "depth", "-f", "json")
+	if err != nil {
+		t.Fatalf("list failed: %v\noutput: %s", err, output)
+	}
+	var view render.NodeListView
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(view.Nodes) != 3 || view.Nodes[0].ClaimedBy != "alice" || view.Nodes[2].ID != "1.2" {
+		t.Errorf("unexpected list: %+v", view.Nodes)
+	}
+}
+
+// TestListCmd_InvalidFlags tests that unknown filters and sort orders are rejected.
+func TestListCmd_InvalidFlags(t *testing.T) {
+	tmpDir := setupListTest(t)
+
+	for _, args := range [][]string{{"--filter", "bogus"}, {"--sort", "bogus"}} {
+		output, err := executeListCommand(t, append([]string{"-d", tmpDir}, args...)...)
+		if err == nil {
+			t.Errorf("list %v: expected error, output: %s", args, output)
+			continue
+		}
+		if !strings.Contains(err.Error(), "bogus") {
+			t.Errorf("list %v: error %q does not mention the bad value", args, err)
+		}
+	}
+}
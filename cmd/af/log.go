@@ -7,10 +7,11 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
 )
 
 func newLogCmd() *cobra.Command {
@@ -25,24 +26,41 @@ proof initialization, node creation, claims, releases, validations,
 and other state changes.
 
 Each event includes its sequence number, type, timestamp, and a
-summary of the event details.
+summary of the event details. Timestamps are shown relative to now
+("3m ago", "yesterday"), falling back to the full date and time for
+events older than a week; --absolute always shows the full timestamp.
+
+With --node, only events that reference the given node are shown.
+Challenge resolutions and withdrawals count as referencing the node the
+challenge was raised on.
+
+With --json (or -f jsonl) events are streamed as JSON lines: one object
+per event, carrying seq, type, timestamp and the event's own fields,
+written as the ledger is read. An event that cannot be read or parsed is
+written as {"seq": N, "error": "..."} on its own line and the command
+exits non-zero. -f json prints a single JSON array instead.
 
 Examples:
   af log                      Show all events
   af log --since 10           Show events after sequence 10
+  af log --node 1.2           Show events that touch node 1.2
   af log -n 5                 Show only the first 5 events
   af log --reverse            Show newest events first
   af log --reverse -n 10      Show the 10 newest events
-  af log -f json              Output in JSON format
+  af log --absolute           Show full timestamps
+  af log -f json              Output a JSON array
+  af log --json               Stream JSON lines
   af log -d ./proof           Use specific proof directory`,
 		RunE: runLog,
 	}
 
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
-	cmd.Flags().StringP("format", "f", "text", "Output format (text/json)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text/json/jsonl)")
 	cmd.Flags().Int("since", 0, "Show events since sequence number N")
+	cmd.Flags().String("node", "", "Show only events that reference this node ID")
 	cmd.Flags().IntP("limit", "n", 0, "Limit output to N events (0 = unlimited)")
 	cmd.Flags().Bool("reverse", false, "Show newest events first")
+	cmd.Flags().Bool("absolute", false, "Show full timestamps instead of relative times")
 
 	return cmd
 }
@@ -52,6 +70,7 @@ type logEntry struct {
 	Seq       int                    `json:"seq"`
 	Type      string                 `json:"type"`
 	Timestamp string                 `json:"timestamp"`
+	NodeIDs   []string               `json:"node_ids,omitempty"`
 	Data      map[string]interface{} `json:"-"`
 	RawData   json.RawMessage        `json:"data,omitempty"`
 }
@@ -78,11 +97,28 @@ func runLog(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	nodeFilter, err := cmd.Flags().GetString("node")
+	if err != nil {
+		return err
+	}
 
-	// Validate format
+	// Validate format. The global --json flag streams JSON lines unless a
+	// format was asked for explicitly.
 	format = strings.ToLower(format)
-	if format != "text" && format != "json" {
-		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	if format == "json" && isJSON(cmd) && !cmd.Flags().Changed("format") {
+		format = "jsonl"
+	}
+	if format != "text" && format != "json" && format != "jsonl" {
+		return fmt.Errorf("invalid format %q: must be 'text', 'json' or 'jsonl'", format)
+	}
+
+	// Validate node filter
+	if nodeFilter != "" {
+		nodeID, err := service.ParseNodeID(nodeFilter)
+		if err != nil {
+			return fmt.Errorf("invalid node ID %q: %w", nodeFilter, err)
+		}
+		nodeFilter = nodeID.String()
 	}
 
 	// Create ledger instance
@@ -92,17 +128,32 @@ func runLog(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error accessing ledger: %w", err)
 	}
 
-	// Collect events
+	// Text and JSON lines are written as the ledger is scanned. --reverse
+	// needs every event before the first can be written, and -f json is a
+	// single array, so those are collected first.
+	var out render.EventLogWriter
+	switch format {
+	case "text":
+		out = render.NewTextEventLogWriter(cmd.OutOrStdout(), renderOptions(cmd))
+	case "jsonl":
+		out = render.NewJSONLEventLogWriter(cmd.OutOrStdout())
+	}
+	stream := out != nil && !reverse
+
+	// Scan events. Challenge targets are tracked from the start of the
+	// ledger so resolutions after --since can still be attributed to a node.
 	var entries []logEntry
+	written := 0
+	lastSeq, badSeq := 0, 0
+	var writeErr error
+	challengeNodes := make(map[string]string)
 	err = ldg.Scan(func(seq int, data []byte) error {
-		// Apply since filter
-		if since > 0 && seq <= since {
-			return nil
-		}
+		lastSeq = seq
 
 		// Parse the event
 		var eventData map[string]interface{}
 		if err := json.Unmarshal(data, &eventData); err != nil {
+			badSeq = seq
 			return fmt.Errorf("failed to parse event %d: %w", seq, err)
 		}
 
@@ -116,20 +167,55 @@ func runLog(cmd *cobra.Command, args []string) error {
 		if t, ok := eventData["type"].(string); ok {
 			entry.Type = t
 		}
+		entry.NodeIDs = eventNodeIDs(entry.Type, eventData, challengeNodes)
+
+		// Apply since and node filters
+		if since > 0 && seq <= since {
+			return nil
+		}
+		if nodeFilter != "" && !containsString(entry.NodeIDs, nodeFilter) {
+			return nil
+		}
 
 		// Extract timestamp
 		if ts, ok := eventData["timestamp"].(string); ok {
 			entry.Timestamp = ts
 		}
 
-		entries = append(entries, entry)
+		if !stream {
+			entries = append(entries, entry)
+			return nil
+		}
+		if writeErr = writeLogEntry(out, entry); writeErr != nil {
+			return writeErr
+		}
+		written++
+		if limit > 0 && written >= limit {
+			return ledger.ErrStopScan
+		}
 		return nil
 	})
 
+	if writeErr != nil {
+		return writeErr
+	}
 	if err != nil {
+		// Report the broken event in the output itself, then fail so the
+		// exit status is non-zero. An event that could not be read at all
+		// is the one after the last event handed to the callback.
+		if out != nil {
+			if badSeq == 0 {
+				badSeq = lastSeq + 1
+			}
+			out.WriteError(badSeq, err)
+		}
 		return fmt.Errorf("error scanning ledger: %w", err)
 	}
 
+	if stream {
+		return out.Close()
+	}
+
 	// Apply reverse if requested
 	if reverse {
 		sort.Slice(entries, func(i, j int) bool {
@@ -147,7 +233,17 @@ func runLog(cmd *cobra.Command, args []string) error {
 		return outputLogJSON(cmd, entries)
 	}
 
-	return outputLogText(cmd, entries)
+	for _, entry := range entries {
+		if err := writeLogEntry(out, entry); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// writeLogEntry writes one parsed event to an event log writer.
+func writeLogEntry(out render.EventLogWriter, entry logEntry) error {
+	return out.WriteEvent(logEntryView(entry.Seq, entry.Type, entry.Timestamp, entry.NodeIDs, entry.Data), entry.Data)
 }
 
 func outputLogJSON(cmd *cobra.Command, entries []logEntry) error {
@@ -180,64 +276,64 @@ func outputLogJSON(cmd *cobra.Command, entries []logEntry) error {
 	return nil
 }
 
-func outputLogText(cmd *cobra.Command, entries []logEntry) error {
-	if len(entries) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "No events found.")
-		return nil
+// logEntryView builds the event log view model for a parsed event.
+func logEntryView(seq int, eventType, timestamp string, nodeIDs []string, data map[string]interface{}) render.EventLogEntryView {
+	return render.EventLogEntryView{
+		Seq:       seq,
+		Type:      eventType,
+		Timestamp: timestamp,
+		Summary:   generateSummary(eventType, data),
+		NodeIDs:   nodeIDs,
 	}
+}
 
-	for _, entry := range entries {
-		// Format timestamp for display
-		displayTime := formatTimestamp(entry.Timestamp)
-
-		// Format event type for display (convert snake_case to PascalCase)
-		displayType := formatEventType(entry.Type)
-
-		// Generate summary based on event type
-		summary := generateSummary(entry.Type, entry.Data)
-
-		// Output: #seq  Type  Timestamp  Summary
-		fmt.Fprintf(cmd.OutOrStdout(), "#%-3d  %-20s  %s  %s\n",
-			entry.Seq, displayType, displayTime, summary)
+// eventNodeIDs returns the IDs of the nodes an event references.
+// challengeNodes maps challenge IDs to their target node; it is updated from
+// challenge_raised events and used to attribute resolutions and withdrawals,
+// which only carry the challenge ID.
+func eventNodeIDs(eventType string, data map[string]interface{}, challengeNodes map[string]string) []string {
+	var ids []string
+	add := func(v interface{}) {
+		if s, ok := v.(string); ok && s != "" && !containsString(ids, s) {
+			ids = append(ids, s)
+		}
 	}
 
-	return nil
-}
-
-// formatTimestamp formats an ISO8601 timestamp for display.
-func formatTimestamp(ts string) string {
-	if ts == "" {
-		return "                   "
+	if n, ok := data["node"].(map[string]interface{}); ok {
+		add(n["id"])
+	}
+	if lemma, ok := data["lemma"].(map[string]interface{}); ok {
+		add(lemma["node_id"])
+	}
+	add(data["node_id"])
+	add(data["discharge_node_id"])
+	if list, ok := data["node_ids"].([]interface{}); ok {
+		for _, id := range list {
+			add(id)
+		}
 	}
 
-	// Parse the timestamp
-	t, err := time.Parse(time.RFC3339, ts)
-	if err != nil {
-		// Try parsing with nanoseconds
-		t, err = time.Parse(time.RFC3339Nano, ts)
-		if err != nil {
-			return ts[:min(19, len(ts))] // Return truncated original
+	challengeID, _ := data["challenge_id"].(string)
+	switch eventType {
+	case "challenge_raised":
+		if nodeID, ok := data["node_id"].(string); ok && challengeID != "" {
+			challengeNodes[challengeID] = nodeID
 		}
+	case "challenge_resolved", "challenge_withdrawn", "challenge_escalated":
+		add(challengeNodes[challengeID])
 	}
 
-	return t.Format("2006-01-02 15:04:05")
+	return ids
 }
 
-// formatEventType converts snake_case event type to PascalCase for display.
-func formatEventType(eventType string) string {
-	if eventType == "" {
-		return "Unknown"
-	}
-
-	// Split by underscore and capitalize each part
-	parts := strings.Split(eventType, "_")
-	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
 		}
 	}
-
-	return strings.Join(parts, "")
+	return false
 }
 
 // generateSummary creates a human-readable summary for an event.
@@ -275,6 +371,9 @@ func generateSummary(eventType string, data map[string]interface{}) string {
 					idStrs = append(idStrs, s)
 				}
 			}
+			if owner, ok := data["owner"].(string); ok && owner != "" {
+				return fmt.Sprintf("Claimed nodes: %s by %s", strings.Join(idStrs, ", "), owner)
+			}
 			return fmt.Sprintf("Claimed nodes: %s", strings.Join(idStrs, ", "))
 		}
 		return "Claimed nodes"
@@ -291,6 +390,20 @@ func generateSummary(eventType string, data map[string]interface{}) string {
 		}
 		return "Released nodes"
 
+	case "nodes_reassigned":
+		if ids, ok := data["node_ids"].([]interface{}); ok {
+			idStrs := make([]string, 0, len(ids))
+			for _, id := range ids {
+				if s, ok := id.(string); ok {
+					idStrs = append(idStrs, s)
+				}
+			}
+			from, _ := data["from_owner"].(string)
+			to, _ := data["to_owner"].(string)
+			return fmt.Sprintf("Reassigned nodes: %s from %s to %s", strings.Join(idStrs, ", "), from, to)
+		}
+		return "Reassigned nodes"
+
 	case "node_validated":
 		if id, ok := data["node_id"].(string); ok {
 			return fmt.Sprintf("Validated node %s", id)
@@ -315,6 +428,42 @@ func generateSummary(eventType string, data map[string]interface{}) string {
 		}
 		return "Archived node"
 
+	case "node_reopened":
+		if id, ok := data["node_id"].(string); ok {
+			return fmt.Sprintf("Reopened node %s", id)
+		}
+		return "Reopened node"
+
+	case "node_tagged":
+		if id, ok := data["node_id"].(string); ok {
+			tag, _ := data["tag"].(string)
+			return fmt.Sprintf("Tagged node %s with %s", id, tag)
+		}
+		return "Tagged node"
+
+	case "node_untagged":
+		if id, ok := data["node_id"].(string); ok {
+			tag, _ := data["tag"].(string)
+			return fmt.Sprintf("Removed tag %s from node %s", tag, id)
+		}
+		return "Untagged node"
+
+	case "note_added":
+		if id, ok := data["node_id"].(string); ok {
+			author, _ := data["author"].(string)
+			return fmt.Sprintf("Note on node %s by %s", id, author)
+		}
+		return "Added note"
+
+	case "node_latex_set":
+		if id, ok := data["node_id"].(string); ok {
+			if latex, _ := data["latex"].(string); latex == "" {
+				return fmt.Sprintf("Cleared LaTeX of node %s", id)
+			}
+			return fmt.Sprintf("Set LaTeX of node %s", id)
+		}
+		return "Set LaTeX"
+
 	case "challenge_raised":
 		if id, ok := data["challenge_id"].(string); ok {
 			nodeID := ""
@@ -337,6 +486,14 @@ func generateSummary(eventType string, data map[string]interface{}) string {
 		}
 		return "Challenge withdrawn"
 
+	case "challenge_escalated":
+		if id, ok := data["challenge_id"].(string); ok {
+			from, _ := data["previous_severity"].(string)
+			to, _ := data["severity"].(string)
+			return fmt.Sprintf("Challenge %s severity changed from %s to %s", id, from, to)
+		}
+		return "Challenge severity changed"
+
 	case "def_added":
 		if def, ok := data["definition"].(map[string]interface{}); ok {
 			if name, ok := def["name"].(string); ok {
@@ -353,6 +510,16 @@ func generateSummary(eventType string, data map[string]interface{}) string {
 		}
 		return "Extracted lemma"
 
+	case "lemma_applied":
+		if lemmaID, ok := data["lemma_id"].(string); ok {
+			nodeID := ""
+			if nid, ok := data["node_id"].(string); ok {
+				nodeID = nid
+			}
+			return fmt.Sprintf("Applied lemma %s to node %s", lemmaID, nodeID)
+		}
+		return "Applied lemma"
+
 	case "taint_recomputed":
 		if id, ok := data["node_id"].(string); ok {
 			newTaint := ""
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -797,12 +798,13 @@ func TestLogCmd_TimestampDisplayed(t *testing.T) {
 	}
 }
 
-// TestLogCmd_TextFormatContainsTimestamp tests text format includes timestamp.
+// TestLogCmd_TextFormatContainsTimestamp tests text format with --absolute
+// includes the full timestamp.
 func TestLogCmd_TextFormatContainsTimestamp(t *testing.T) {
 	tmpDir, cleanup := setupLogTest(t)
 	defer cleanup()
 
-	output, err := executeLogCommand(t, "-d", tmpDir)
+	output, err := executeLogCommand(t, "-d", tmpDir, "--absolute")
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -813,6 +815,22 @@ func TestLogCmd_TextFormatContainsTimestamp(t *testing.T) {
 	}
 }
 
+// TestLogCmd_TextFormatRelativeTimestamp tests text format shows recent
+// events relative to now by default.
+func TestLogCmd_TextFormatRelativeTimestamp(t *testing.T) {
+	tmpDir, cleanup := setupLogTest(t)
+	defer cleanup()
+
+	output, err := executeLogCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "just now") {
+		t.Errorf("text format should show a relative timestamp, got: %q", output)
+	}
+}
+
 // =============================================================================
 // Combined Filter Tests
 // =============================================================================
@@ -921,3 +939,178 @@ func TestLogCmd_AllFiltersCombined(t *testing.T) {
 		}
 	}
 }
+
+// TestLogCmd_NodeFilter tests that --node shows only events referencing the node.
+func TestLogCmd_NodeFilter(t *testing.T) {
+	tmpDir, cleanup := setupLogTest(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1.1", "1.2"} {
+		nodeID, _ := service.ParseNodeID(id)
+		if err := svc.CreateNode(nodeID, service.NodeTypeClaim, "Child "+id, service.InferenceModusPonens); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nodeID, _ := service.ParseNodeID("1.2")
+	if err := svc.ClaimNode(nodeID, "prover-1", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	challengeID, err := svc.RaiseChallenge(nodeID, "statement", "unclear step", "minor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ResolveChallenge(challengeID); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeLogCommand(t, "-d", tmpDir, "--node", "1.2")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{
+		"Created node 1.2",
+		"Claimed nodes: 1.2 by prover-1",
+		fmt.Sprintf("Challenge %s raised on node 1.2", challengeID),
+		fmt.Sprintf("Challenge %s resolved", challengeID),
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"Initialized proof", "Created node 1.1", "Created node 1:"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output should not contain %q, got:\n%s", unwanted, output)
+		}
+	}
+}
+
+// TestLogCmd_NodeFilterInvalidID tests that --node rejects malformed IDs.
+func TestLogCmd_NodeFilterInvalidID(t *testing.T) {
+	tmpDir, cleanup := setupLogTest(t)
+	defer cleanup()
+
+	if _, err := executeLogCommand(t, "-d", tmpDir, "--node", "not-a-node"); err == nil {
+		t.Fatal("expected error for invalid node ID")
+	}
+}
+
+// =============================================================================
+// JSON Lines Tests
+// =============================================================================
+
+// setupLogTestWithNodes returns a proof with two child nodes created after
+// initialization.
+func setupLogTestWithNodes(t *testing.T) (string, func()) {
+	t.Helper()
+	tmpDir, cleanup := setupLogTest(t)
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1.1", "1.2"} {
+		nodeID, err := service.ParseNodeID(id)
+		if err != nil {
+			cleanup()
+			t.Fatal(err)
+		}
+		if err := svc.CreateNode(nodeID, service.NodeTypeClaim, "Claim "+id, service.InferenceModusPonens); err != nil {
+			cleanup()
+			t.Fatal(err)
+		}
+	}
+	return tmpDir, cleanup
+}
+
+// decodeLogLines decodes each line of JSON lines output.
+func decodeLogLines(t *testing.T, output string) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			t.Fatalf("line %q is not a JSON object: %v", line, err)
+		}
+		lines = append(lines, obj)
+	}
+	return lines
+}
+
+// TestLogCmd_JSONLines tests that -f jsonl writes one object per event.
+func TestLogCmd_JSONLines(t *testing.T) {
+	tmpDir, cleanup := setupLogTestWithNodes(t)
+	defer cleanup()
+
+	output, err := executeLogCommand(t, "-d", tmpDir, "-f", "jsonl")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	lines := decodeLogLines(t, output)
+	if len(lines) < 3 {
+		t.Fatalf("expected at least 3 lines, got %d:\n%s", len(lines), output)
+	}
+	for i, obj := range lines {
+		if seq, _ := obj["seq"].(float64); int(seq) != i+1 {
+			t.Errorf("line %d has seq %v, want %d", i, obj["seq"], i+1)
+		}
+		if _, ok := obj["timestamp"].(string); !ok {
+			t.Errorf("line %d has no timestamp: %v", i, obj)
+		}
+	}
+	if lines[0]["type"] != "proof_initialized" || lines[0]["conjecture"] != "Test conjecture for log" {
+		t.Errorf("first line should carry the proof_initialized fields, got %v", lines[0])
+	}
+
+	// --limit stops the stream early
+	output, err = executeLogCommand(t, "-d", tmpDir, "-f", "jsonl", "-n", "2")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := len(decodeLogLines(t, output)); got != 2 {
+		t.Errorf("-n 2 wrote %d lines, want 2", got)
+	}
+}
+
+// TestLogCmd_JSONLinesCorruptEvent tests that a corrupt event mid-stream is
+// reported on its own line and fails the command.
+func TestLogCmd_JSONLinesCorruptEvent(t *testing.T) {
+	tmpDir, cleanup := setupLogTestWithNodes(t)
+	defer cleanup()
+
+	path := filepath.Join(tmpDir, "ledger", "000002.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeLogCommand(t, "-d", tmpDir, "-f", "jsonl")
+	if err == nil {
+		t.Fatal("expected an error for a corrupt event")
+	}
+
+	// Cobra prints the error after the stream; keep only the JSON lines
+	var jsonLines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "{") {
+			jsonLines = append(jsonLines, line)
+		}
+	}
+	lines := decodeLogLines(t, strings.Join(jsonLines, "\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected the first event and an error line, got:\n%s", output)
+	}
+	if lines[0]["type"] != "proof_initialized" {
+		t.Errorf("first line = %v, want the proof_initialized event", lines[0])
+	}
+	if seq, _ := lines[1]["seq"].(float64); int(seq) != 2 {
+		t.Errorf("error line seq = %v, want 2", lines[1]["seq"])
+	}
+	if msg, _ := lines[1]["error"].(string); !strings.Contains(msg, "event 2") {
+		t.Errorf("error line = %v, want an error naming event 2", lines[1])
+	}
+}
@@ -20,15 +20,60 @@ import (
 const Version = "0.1.0"
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
-		// Enhance error with usage examples if this is an unknown command error
-		enhanced := enhanceUnknownCommandError(rootCmd, err)
+	if cmd, err := rootCmd.ExecuteC(); err != nil {
+		// Add usage examples to unknown command errors unless plain errors were requested
+		enhanced := reportedError(rootCmd, cmd, os.Args[1:], err)
 		// Sanitize error messages to prevent leaking filesystem paths
 		sanitized := service.SanitizeError(enhanced)
-		fmt.Fprintln(os.Stderr, sanitized)
 		// Use structured exit code from AFError if available, otherwise default to 1
-		os.Exit(service.ExitCode(enhanced))
+		exitCode := service.ExitCode(enhanced)
+		if jsonRequested(cmd, os.Args[1:]) {
+			// Output the command wrote before failing still goes out in its envelope
+			if flushErr := flushJSONOutput(cmd, true); flushErr != nil {
+				fmt.Fprintln(os.Stderr, service.SanitizeError(flushErr))
+			}
+			fmt.Fprintln(os.Stderr, renderErrorJSON(cmd, sanitized, service.ErrorCodeName(enhanced), exitCode))
+		} else {
+			fmt.Fprintln(os.Stderr, sanitized)
+		}
+		os.Exit(exitCode)
+	}
+}
+
+// plainErrorsEnv is the environment variable that, set to 1, disables error
+// enhancement like the global --no-enhance flag.
+const plainErrorsEnv = "AF_PLAIN_ERRORS"
+
+// reportedError returns err as it should be reported: with usage examples
+// appended to unknown command errors, unless plain errors were requested.
+func reportedError(root, cmd *cobra.Command, args []string, err error) error {
+	if plainErrors(cmd, args) {
+		return err
+	}
+	return enhanceUnknownCommandError(root, err)
+}
+
+// plainErrors returns true if the global --no-enhance flag is set or
+// AF_PLAIN_ERRORS is 1. An unknown command fails before flags are parsed,
+// so the raw arguments are checked as well.
+func plainErrors(cmd *cobra.Command, args []string) bool {
+	if os.Getenv(plainErrorsEnv) == "1" {
+		return true
+	}
+	if cmd != nil {
+		if flag := cmd.Flags().Lookup("no-enhance"); flag != nil && flag.Changed {
+			return flag.Value.String() == "true"
+		}
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--no-enhance" || arg == "--no-enhance=true" {
+			return true
+		}
 	}
+	return false
 }
 
 // suggestionPattern matches cobra's "Did you mean" suggestions
@@ -127,7 +172,19 @@ Typical Workflow:
 
 Global flags:
   --verbose       Enable verbose output for debugging
-  --dry-run       Preview changes without making them`,
+  --dry-run       Preview changes without making them
+  --no-auto-taint Don't record taint changes after accept, admit, refute
+                  or archive (faster batch work; see 'af recompute-taint')
+  --json          Emit machine-readable JSON output (including errors)
+  --quiet         Print nothing when a command that changes the proof
+                  succeeds; errors are still reported. Query commands
+                  such as status still print their output.
+  --color MODE    Colorize text output: auto (default), always, or never.
+                  auto disables color when output is not a terminal or
+                  NO_COLOR is set.
+  --no-enhance    Report errors as they are, without the usage examples
+                  appended to unknown command errors. Setting
+                  AF_PLAIN_ERRORS=1 has the same effect.`,
 	Version: Version,
 }
 
@@ -166,6 +223,28 @@ func init() {
 	// Note: -v is already used by Cobra for --version, so verbose has no shorthand
 	rootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output for debugging")
 	rootCmd.PersistentFlags().Bool("dry-run", false, "Preview changes without making them")
+	rootCmd.PersistentFlags().Bool("no-auto-taint", false, "Don't record taint changes after epistemic state changes")
+	rootCmd.PersistentFlags().Bool("json", false, "Emit machine-readable JSON output")
+	rootCmd.PersistentFlags().Bool("quiet", false, "Print nothing on success for commands that change the proof")
+	rootCmd.PersistentFlags().String("color", "auto", "Colorize text output: auto, always, or never")
+	rootCmd.PersistentFlags().Bool("no-enhance", false, "Report errors without appended usage examples (or set AF_PLAIN_ERRORS=1)")
+
+	// --json implies --format json for every subcommand that has a format flag,
+	// and wraps whatever the command prints in the envelope
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyColorMode(cmd); err != nil {
+			return err
+		}
+		applyQuietMode(cmd)
+		if err := applyJSONFormat(cmd); err != nil {
+			return err
+		}
+		captureJSONOutput(cmd)
+		return nil
+	}
+	rootCmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+		return flushJSONOutput(cmd, false)
+	}
 }
 
 // isVerbose returns true if verbose mode is enabled.
@@ -174,8 +253,26 @@ func isVerbose(cmd *cobra.Command) bool {
 	return v
 }
 
+// isJSON returns true if the global --json flag is set.
+func isJSON(cmd *cobra.Command) bool {
+	j, _ := cmd.Flags().GetBool("json")
+	return j
+}
+
+// isQuiet returns true if the global --quiet flag is set.
+func isQuiet(cmd *cobra.Command) bool {
+	q, _ := cmd.Flags().GetBool("quiet")
+	return q
+}
+
 // isDryRun returns true if dry-run mode is enabled.
 func isDryRun(cmd *cobra.Command) bool {
 	d, _ := cmd.Flags().GetBool("dry-run")
 	return d
 }
+
+// isAutoTaint returns false if the global --no-auto-taint flag is set.
+func isAutoTaint(cmd *cobra.Command) bool {
+	n, _ := cmd.Flags().GetBool("no-auto-taint")
+	return !n
+}
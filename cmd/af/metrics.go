@@ -21,14 +21,24 @@ The metrics command calculates:
   - Refinement depth: Maximum depth of the proof tree
   - Challenge density: Number of challenges per node
   - Definition coverage: Percentage of referenced terms with definitions
+  - Taint: Nodes that are tainted or self-admitted
+  - Assumptions: Assumption references per node
+  - Verbosity: Nodes with overly long statements
   - Quality score: Composite score (0-100) based on all metrics
+  - Node scores: Per-node score (0-100) from validation, open challenges
+    weighted by severity, taint, and satisfied dependencies
 
 Use --node to focus metrics on a specific subtree.
+Use --breakdown to show how each factor contributes to the quality score.
+Use --weakest to list the lowest-scoring nodes. JSON output always
+includes every node's score.
 
 Examples:
   af metrics                     Show metrics for entire proof
   af metrics --dir /path/to/proof  Show metrics for specific proof
   af metrics --node 1.2           Show metrics for subtree rooted at node 1.2
+  af metrics --breakdown          Show the weighted score components
+  af metrics --weakest 10         Show the 10 lowest-scoring nodes
   af metrics --format json        Output in JSON format`,
 		RunE: runMetrics,
 	}
@@ -36,6 +46,8 @@ Examples:
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
 	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
 	cmd.Flags().StringP("node", "n", "", "Node ID to focus metrics on (subtree)")
+	cmd.Flags().Bool("breakdown", false, "Show per-factor quality score breakdown")
+	cmd.Flags().Int("weakest", 0, "Show the N lowest-scoring nodes (0 = none)")
 
 	return cmd
 }
@@ -46,12 +58,17 @@ func runMetrics(cmd *cobra.Command, args []string) error {
 	dir, _ := cmd.Flags().GetString("dir")
 	format, _ := cmd.Flags().GetString("format")
 	nodeIDStr, _ := cmd.Flags().GetString("node")
+	breakdown, _ := cmd.Flags().GetBool("breakdown")
+	weakest, _ := cmd.Flags().GetInt("weakest")
 
 	// Validate format
 	format = strings.ToLower(format)
 	if format != "" && format != "text" && format != "json" {
 		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
 	}
+	if weakest < 0 {
+		return fmt.Errorf("--weakest must not be negative, got %d", weakest)
+	}
 
 	// Parse node ID if provided
 	var nodeID *service.NodeID
@@ -106,6 +123,10 @@ func runMetrics(cmd *cobra.Command, args []string) error {
 		report = service.OverallQuality(st)
 	}
 
+	if breakdown {
+		report.WithBreakdown()
+	}
+
 	// Output based on format
 	if format == "json" {
 		output, err := json.MarshalIndent(report, "", "  ")
@@ -119,6 +140,9 @@ func runMetrics(cmd *cobra.Command, args []string) error {
 	// Text format
 	output := renderMetricsText(report, nodeIDStr)
 	fmt.Fprint(cmd.OutOrStdout(), output)
+	if weakest > 0 {
+		fmt.Fprint(cmd.OutOrStdout(), renderWeakestNodes(report, weakest))
+	}
 
 	return nil
 }
@@ -165,12 +189,28 @@ func renderMetricsText(report *service.QualityReport, nodeID string) string {
 	sb.WriteString(fmt.Sprintf("  Coverage:         %.1f%%\n", report.DefinitionCoverage*100))
 	sb.WriteString("\n")
 
+	// Taint, Assumptions, Verbosity
+	sb.WriteString("Epistemic Debt:\n")
+	sb.WriteString(fmt.Sprintf("  Tainted nodes:    %d\n", report.TaintedNodes))
+	sb.WriteString(fmt.Sprintf("  Assumption refs:  %d (%.2f per node)\n", report.AssumptionRefs, report.AssumptionDensity))
+	sb.WriteString(fmt.Sprintf("  Verbose nodes:    %d\n", report.VerboseNodes))
+	sb.WriteString("\n")
+
 	// Quality Score
 	sb.WriteString("Quality Score:\n")
 	scoreIcon := getScoreIcon(report.QualityScore)
 	sb.WriteString(fmt.Sprintf("  Overall score:    %.1f/100 %s\n", report.QualityScore, scoreIcon))
 	sb.WriteString("\n")
 
+	// Score Breakdown (only when requested)
+	if report.Breakdown != nil {
+		sb.WriteString("Score Breakdown:\n")
+		for _, c := range report.Breakdown.Components {
+			sb.WriteString(fmt.Sprintf("  %-12s %5.1f/%-4.0f (%.0f%%)\n", c.Name+":", c.Points, c.Weight, c.Ratio*100))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Interpretation
 	sb.WriteString("Score Interpretation:\n")
 	sb.WriteString(getScoreInterpretation(report.QualityScore))
@@ -183,6 +223,22 @@ func renderMetricsText(report *service.QualityReport, nodeID string) string {
 	return sb.String()
 }
 
+// renderWeakestNodes renders the limit lowest-scoring nodes of the report.
+func renderWeakestNodes(report *service.QualityReport, limit int) string {
+	var sb strings.Builder
+	sb.WriteString("\nWeakest Nodes:\n")
+	nodes := report.WeakestNodes()
+	if len(nodes) == 0 {
+		sb.WriteString("  (none)\n")
+		return sb.String()
+	}
+	for _, n := range nodes[:min(limit, len(nodes))] {
+		sb.WriteString(fmt.Sprintf("  %-10s %5.1f  %-10s taint=%-13s challenges=%d deps=%d/%d\n",
+			n.NodeID.String(), n.Score, n.EpistemicState, n.TaintState, n.OpenChallenges, n.SatisfiedDependencies, n.Dependencies))
+	}
+	return sb.String()
+}
+
 // getScoreIcon returns an icon based on the quality score.
 func getScoreIcon(score float64) string {
 	switch {
@@ -236,6 +292,16 @@ func getMetricsNextSteps(report *service.QualityReport) string {
 		sb.WriteString(fmt.Sprintf("  - Add definitions for %d undefined term(s)\n", missing))
 	}
 
+	// Check for epistemic debt
+	if report.TaintedNodes > 0 {
+		sb.WriteString(fmt.Sprintf("  - Replace admissions behind %d tainted node(s) with full proofs\n", report.TaintedNodes))
+	}
+
+	// Check for verbose statements
+	if report.VerboseNodes > 0 {
+		sb.WriteString(fmt.Sprintf("  - Split %d overly long statement(s) into smaller steps\n", report.VerboseNodes))
+	}
+
 	// If everything looks good
 	if sb.Len() == 0 {
 		sb.WriteString("  - Proof is in good shape! Continue refining or review with 'af status'\n")
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
 )
 
 // =============================================================================
@@ -381,3 +382,46 @@ func TestMetricsCmd_NodeFlagAcceptsValidID(t *testing.T) {
 		})
 	}
 }
+
+// TestRenderMetricsText_Breakdown verifies the score breakdown section is shown
+// only when the report carries a breakdown.
+func TestRenderMetricsText_Breakdown(t *testing.T) {
+	report := &service.QualityReport{NodeCount: 1, PendingNodes: 1, DefinitionCoverage: 1.0}
+
+	if out := renderMetricsText(report, ""); strings.Contains(out, "Score Breakdown") {
+		t.Errorf("expected no breakdown section without --breakdown, got:\n%s", out)
+	}
+
+	out := renderMetricsText(report.WithBreakdown(), "")
+	for _, want := range []string{"Score Breakdown", "validation:", "taint:", "verbosity:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected breakdown output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderWeakestNodes(t *testing.T) {
+	report := &service.QualityReport{Nodes: []service.NodeScore{
+		{NodeID: mustParseNodeIDForMetrics(t, "1"), EpistemicState: "validated", TaintState: "clean", Score: 100},
+		{NodeID: mustParseNodeIDForMetrics(t, "1.1"), EpistemicState: "pending", TaintState: "unresolved", OpenChallenges: 2, Score: 35},
+		{NodeID: mustParseNodeIDForMetrics(t, "1.2"), EpistemicState: "admitted", TaintState: "self_admitted", Score: 60},
+	}}
+
+	out := renderWeakestNodes(report, 2)
+	first, second := strings.Index(out, "1.1"), strings.Index(out, "1.2")
+	if first < 0 || second < 0 || first > second {
+		t.Errorf("expected 1.1 then 1.2, got:\n%s", out)
+	}
+	if strings.Contains(out, "validated") {
+		t.Errorf("expected only the 2 weakest nodes, got:\n%s", out)
+	}
+}
+
+func mustParseNodeIDForMetrics(t *testing.T, s string) service.NodeID {
+	t.Helper()
+	id, err := service.ParseNodeID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
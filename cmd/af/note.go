@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/cli"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newNoteCmd creates the note command.
+func newNoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "note NODE_ID TEXT",
+		GroupID: GroupVerifier,
+		Short:   "Leave an informational note on a node",
+		Long: `Leave a non-blocking remark on a node.
+
+Notes are for observations that don't warrant a challenge, such as a
+suggested simplification or a pointer to related work. Unlike challenges,
+notes never block acceptance and cannot be resolved or withdrawn. They are
+shown by 'af show'.
+
+Examples:
+  af note 1.2 "Could cite Lemma 3 here" --author verifier-1
+  af note 1 "Nice argument" -a verifier-1 -f json`,
+		Args: cobra.ExactArgs(2),
+		RunE: runNote,
+	}
+
+	cmd.Flags().StringP("author", "a", "", "Agent leaving the note (required)")
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	nodeID, err := service.ParseNodeID(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid node ID %q: %v", args[0], err)
+	}
+	text := args[1]
+
+	author := cli.MustString(cmd, "author")
+	if strings.TrimSpace(author) == "" {
+		return errors.New("--author is required")
+	}
+	dir := cli.MustString(cmd, "dir")
+	format := cli.MustString(cmd, "format")
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return errors.New("proof not initialized")
+	}
+
+	noteID, err := svc.AddNote(nodeID, author, text)
+	if err != nil {
+		return fmt.Errorf("error adding note: %w", err)
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		result := map[string]interface{}{
+			"note_id": noteID,
+			"node_id": nodeID.String(),
+			"author":  author,
+		}
+		output, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	default:
+		fmt.Fprintf(cmd.OutOrStdout(), "Note %s added to node %s.\n", noteID, nodeID)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newNoteCmd())
+}
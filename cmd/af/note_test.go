@@ -0,0 +1,53 @@
+//go:build !integration
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+func executeNote(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newNoteCmd())
+	root.AddCommand(newShowCmd())
+	return executeCommand(root, args...)
+}
+
+func TestNoteCmd_AddsNoteShownByShow(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeNote("note", "1", "Could cite Lemma 3", "--author", "verifier-1", "-d", dir)
+	if err != nil {
+		t.Fatalf("note failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "added to node 1") {
+		t.Errorf("unexpected output: %q", output)
+	}
+
+	output, err = executeNote("show", "1", "-d", dir)
+	if err != nil {
+		t.Fatalf("show failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{"Notes (1):", "verifier-1: Could cite Lemma 3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("show output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestNoteCmd_RequiresAuthor(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := executeNote("note", "1", "text", "-d", dir); err == nil || !strings.Contains(err.Error(), "--author") {
+		t.Errorf("note without --author: error = %v, want --author is required", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Package main contains the owner resolution shared by owner-taking commands.
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// ownerEnvVar names the environment variable that supplies a default owner.
+const ownerEnvVar = "AF_OWNER"
+
+// resolveOwner returns the owner for an owner-taking command, taken from the
+// first of these that is set:
+//
+//  1. the --owner flag, if given explicitly
+//  2. the AF_OWNER environment variable, if non-empty
+//  3. the default_owner setting of the proof in dir
+//
+// The resolved owner must not be empty or only whitespace, matching the
+// service's owner checks. An owner that cannot be resolved at all is
+// reported as a missing --owner flag.
+func resolveOwner(cmd *cobra.Command, command, dir string, examples []string) (string, error) {
+	if cmd.Flags().Changed("owner") {
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(owner) == "" {
+			return "", render.EmptyValueError(command, "owner", examples)
+		}
+		return owner, nil
+	}
+
+	if owner := os.Getenv(ownerEnvVar); owner != "" {
+		if strings.TrimSpace(owner) == "" {
+			return "", render.NewUsageError(command, "$"+ownerEnvVar+" cannot be only whitespace", examples)
+		}
+		return owner, nil
+	}
+
+	// A proof that cannot be opened has no default; the command reports
+	// the directory problem itself once the owner is settled
+	if svc, err := service.NewProofService(dir); err == nil {
+		if cfg, err := svc.Config(); err == nil && strings.TrimSpace(cfg.DefaultOwner) != "" {
+			return cfg.DefaultOwner, nil
+		}
+	}
+
+	return "", render.NewUsageError(command,
+		"missing required flag: --owner (or set $"+ownerEnvVar+" or 'af config set default-owner')", examples)
+}
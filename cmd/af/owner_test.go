@@ -0,0 +1,136 @@
+//go:build !integration
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newOwnerTestCmd returns a command with an --owner flag, parsed from args.
+func newOwnerTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringP("owner", "o", "", "Owner")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatalf("parsing flags: %v", err)
+	}
+	return cmd
+}
+
+// newOwnerTestProof initializes a proof whose default-owner setting is
+// defaultOwner, or unset if it is empty.
+func newOwnerTestProof(t *testing.T, defaultOwner string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := service.Init(dir, "Owner conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if defaultOwner == "" {
+		return dir
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := svc.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := *cfg
+	updated.DefaultOwner = defaultOwner
+	if err := svc.SetConfig(&updated); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	return dir
+}
+
+// TestResolveOwner_Precedence verifies that an explicit --owner beats
+// $AF_OWNER, which beats the proof's default-owner setting.
+func TestResolveOwner_Precedence(t *testing.T) {
+	dir := newOwnerTestProof(t, "config-owner")
+
+	tests := []struct {
+		name string
+		env  string
+		args []string
+		want string
+	}{
+		{"flag wins", "env-owner", []string{"--owner", "flag-owner"}, "flag-owner"},
+		{"env over config", "env-owner", nil, "env-owner"},
+		{"config fallback", "", nil, "config-owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ownerEnvVar, tt.env)
+			got, err := resolveOwner(newOwnerTestCmd(t, tt.args...), "af test", dir, nil)
+			if err != nil {
+				t.Fatalf("resolveOwner failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveOwner() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResolveOwner_Errors verifies that an owner that is missing or only
+// whitespace is rejected.
+func TestResolveOwner_Errors(t *testing.T) {
+	dir := newOwnerTestProof(t, "")
+
+	tests := []struct {
+		name    string
+		env     string
+		args    []string
+		wantErr string
+	}{
+		{"nothing set", "", nil, "missing required flag: --owner"},
+		{"empty flag", "env-owner", []string{"--owner", ""}, "--owner cannot be empty"},
+		{"whitespace flag", "", []string{"--owner", "  "}, "--owner cannot be empty"},
+		{"whitespace env", "  ", nil, "$AF_OWNER cannot be only whitespace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ownerEnvVar, tt.env)
+			_, err := resolveOwner(newOwnerTestCmd(t, tt.args...), "af test", dir, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveOwner() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestClaim_UsesEnvOwner verifies that claim works without --owner when
+// $AF_OWNER is set.
+func TestClaim_UsesEnvOwner(t *testing.T) {
+	dir := newOwnerTestProof(t, "")
+	t.Setenv(ownerEnvVar, "env-prover")
+
+	root := newTestRootCmd()
+	root.AddCommand(newClaimCmd())
+	if out, err := executeCommand(root, "claim", "1", "-d", dir); err != nil {
+		t.Fatalf("claim failed: %v\n%s", err, out)
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, err := service.ParseNodeID("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetNode(rootID).ClaimedBy; got != "env-prover" {
+		t.Errorf("node 1 claimed by %q, want env-prover", got)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newPruneArchivedCmd creates the prune-archived command.
+func newPruneArchivedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "prune-archived <dest>",
+		GroupID: GroupAdmin,
+		Short:   "Copy the proof without its archived subtrees",
+		Long: `Write a copy of the proof to a new directory whose ledger leaves out the
+events of archived nodes, so it replays faster.
+
+The source proof is never modified. The destination must not exist or must
+be an empty directory. An archived node is kept if a kept node depends on
+it, descends from it, or a lemma was extracted from it. The remaining
+events are renumbered from 1 and their hash chain is recomputed, and the
+new ledger is checked to replay to the original state minus the pruned
+nodes before the command succeeds.
+
+Examples:
+  af prune-archived ../proof-pruned              Prune the proof in the current directory
+  af prune-archived ../proof-pruned -d ./proof   Prune a specific proof directory`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPruneArchived,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+
+	return cmd
+}
+
+// runPruneArchived executes the prune-archived command.
+func runPruneArchived(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+	dest := args[0]
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+
+	dropped, err := svc.PruneArchived(dest)
+	if err != nil {
+		return fmt.Errorf("error pruning archived nodes: %w", err)
+	}
+
+	count, err := ledger.Count(filepath.Join(dest, "ledger"))
+	if err != nil {
+		return fmt.Errorf("error counting pruned events: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d events into %s (%d archived events dropped)\n", count, dest, dropped)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newPruneArchivedCmd())
+}
@@ -0,0 +1,68 @@
+//go:build !integration
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executePruneArchivedCommand executes args against a fresh command tree with
+// the prune-archived subcommand and returns the combined output.
+func executePruneArchivedCommand(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newPruneArchivedCmd())
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(buf)
+	root.SetArgs(args)
+	err := root.Execute()
+	return buf.String(), err
+}
+
+// TestPruneArchived_DropsArchivedNode verifies that an archived leaf is left
+// out of the pruned copy.
+func TestPruneArchived_DropsArchivedNode(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "src")
+	dstDir := filepath.Join(tmpDir, "dst")
+
+	if err := service.Init(srcDir, "Prune conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	svc, err := service.NewProofService(srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	childID, _ := service.ParseNodeID("1.1")
+	if err := svc.CreateNode(childID, service.NodeTypeClaim, "Dead end", service.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ArchiveNode(childID); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := executePruneArchivedCommand("prune-archived", dstDir, "-d", srcDir)
+	if err != nil {
+		t.Fatalf("prune-archived failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Wrote 2 events") || !strings.Contains(out, "2 archived events dropped") {
+		t.Errorf("unexpected output: %q", out)
+	}
+
+	pruned, err := service.NewProofService(dstDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := pruned.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState on pruned proof failed: %v", err)
+	}
+	if st.GetNode(childID) != nil {
+		t.Error("archived node 1.1 is still in the pruned proof")
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// quietCommands lists, by command path below the root, the commands whose
+// output only reports that a change succeeded. The global --quiet flag
+// discards their output. Query commands are deliberately absent: their
+// output is the payload that was asked for, so --quiet leaves it alone.
+var quietCommands = map[string]bool{
+	// Setup
+	"init": true,
+
+	// Claims
+	"claim":        true,
+	"release":      true,
+	"extend-claim": true,
+	"reclaim":      true,
+	"reap":         true,
+
+	// Prover commands
+	"refine":            true,
+	"refine-sibling":    true,
+	"amend":             true,
+	"request-def":       true,
+	"resolve-challenge": true,
+	"extract-lemma":     true,
+	"batch":             true,
+
+	// Verifier commands
+	"accept":             true,
+	"challenge":          true,
+	"escalate-challenge": true,
+	"withdraw-challenge": true,
+	"request-refinement": true,
+	"note":               true,
+
+	// Escape hatches
+	"admit":   true,
+	"refute":  true,
+	"archive": true,
+
+	// Administration
+	"def-add":            true,
+	"def-reject":         true,
+	"add-external":       true,
+	"verify-external":    true,
+	"recompute-taint":    true,
+	"config set":         true,
+	"hooks add":          true,
+	"hooks remove":       true,
+	"clone":              true,
+	"prune-archived":     true,
+	"restore":            true,
+	"checkpoint create":  true,
+	"checkpoint restore": true,
+}
+
+// applyQuietMode discards the output of cmd if the global --quiet flag is
+// set and cmd is one of quietCommands. Dry runs keep their output, since
+// the preview is what was asked for. Errors are unaffected: main prints
+// them to stderr itself, as JSON under --json.
+func applyQuietMode(cmd *cobra.Command) {
+	if !isQuiet(cmd) || isDryRun(cmd) {
+		return
+	}
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if !quietCommands[path] {
+		return
+	}
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+}
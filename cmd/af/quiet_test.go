@@ -0,0 +1,61 @@
+//go:build !integration
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newQuietTestRootCmd returns a test root with the global flags and the
+// pre-run hook that --quiet relies on, plus the claim and status commands.
+func newQuietTestRootCmd() *cobra.Command {
+	root := newTestRootCmd()
+	root.PersistentFlags().Bool("quiet", false, "")
+	root.PersistentFlags().Bool("dry-run", false, "")
+	root.PersistentFlags().Bool("json", false, "")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		applyQuietMode(cmd)
+		return applyJSONFormat(cmd)
+	}
+	root.AddCommand(newClaimCmd())
+	root.AddCommand(newStatusCmd())
+	return root
+}
+
+func TestQuietMode(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"claim", "1", "--owner", "prover", "--quiet", "-d", dir},
+		{"claim", "1", "--owner", "prover", "--refresh", "--quiet", "--json", "-d", dir},
+	} {
+		output, err := executeCommand(newQuietTestRootCmd(), args...)
+		if err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		if output != "" {
+			t.Errorf("%v printed %q, want nothing", args, output)
+		}
+	}
+
+	// Errors are still returned for main to report
+	if _, err := executeCommand(newQuietTestRootCmd(), "claim", "1", "--owner", "other", "--quiet", "-d", dir); err == nil {
+		t.Error("claiming a claimed node under --quiet succeeded, want an error")
+	}
+
+	// Query commands keep their payload
+	output, err := executeCommand(newQuietTestRootCmd(), "status", "--quiet", "-d", dir)
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if !strings.Contains(output, "Test conjecture") {
+		t.Errorf("status --quiet printed %q, want the status", output)
+	}
+}
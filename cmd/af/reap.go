@@ -112,12 +112,8 @@ func runReap(cmd *cobra.Command, args []string) error {
 		// Check if lock is expired or if --all flag is set
 		if all {
 			toReap = append(toReap, n.ID)
-		} else {
-			// Check if the claim has expired
-			// ClaimedAt stores the timeout timestamp (when the claim expires)
-			if n.ClaimedAt.Before(now) {
-				toReap = append(toReap, n.ID)
-			}
+		} else if n.IsClaimExpired(now) {
+			toReap = append(toReap, n.ID)
 		}
 	}
 
@@ -0,0 +1,48 @@
+//go:build !integration
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/service"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func executeReclaim(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newReclaimCmd())
+	return executeCommand(root, args...)
+}
+
+func TestReclaimCmd(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, _ := types.Parse("1")
+	if err := svc.ClaimNode(root, "stale", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	output, err := executeReclaim("reclaim", "1", "--owner", "fresh", "-d", dir)
+	if err != nil {
+		t.Fatalf("reclaim failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Reclaimed node 1 from stale") || !strings.Contains(output, "fresh") {
+		t.Errorf("unexpected output: %q", output)
+	}
+
+	// The new claim is live, so it cannot be reclaimed again
+	_, err = executeReclaim("reclaim", "1", "--owner", "other", "-d", dir)
+	if err == nil || !strings.Contains(err.Error(), "fresh") {
+		t.Errorf("reclaiming a live claim: error = %v, want one naming fresh", err)
+	}
+}
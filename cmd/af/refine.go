@@ -14,9 +14,11 @@ import (
 
 // childSpec represents a child node specification in the --children JSON input.
 type childSpec struct {
-	Statement string `json:"statement"`
-	Type      string `json:"type"`
-	Inference string `json:"inference"`
+	Statement string   `json:"statement"`
+	Type      string   `json:"type"`
+	Inference string   `json:"inference"`
+	Depends   []string `json:"depends"`    // IDs of existing nodes
+	BatchDeps []int    `json:"batch_deps"` // Indexes of earlier children in the array
 }
 
 // validateNodeTypeAndInference validates node type and inference strings,
@@ -82,25 +84,13 @@ func parseValidationDependencies(requiresValidated string, st *service.State) ([
 // findNextChildIDResult holds the result of finding the next available child ID.
 type findNextChildIDResult struct {
 	ChildID   service.NodeID
-	ChildNum  int
 	WarnDepth bool // true if child depth exceeds WarnDepth
 }
 
-// findNextChildID finds the next available child ID for a parent and validates depth constraints.
-func findNextChildID(parentID service.NodeID, st *service.State, svc *service.ProofService) (findNextChildIDResult, error) {
-	childNum := 1
-	for {
-		candidateID, err := parentID.Child(childNum)
-		if err != nil {
-			return findNextChildIDResult{}, fmt.Errorf("failed to generate child ID: %v", err)
-		}
-		if st.GetNode(candidateID) == nil {
-			break
-		}
-		childNum++
-	}
-
-	childID, err := parentID.Child(childNum)
+// findNextChildID finds the next child ID for a parent under the configured
+// child ID strategy and validates depth constraints.
+func findNextChildID(parentID service.NodeID, svc *service.ProofService) (findNextChildIDResult, error) {
+	childID, err := svc.AllocateChildID(parentID)
 	if err != nil {
 		return findNextChildIDResult{}, fmt.Errorf("failed to generate child ID: %v", err)
 	}
@@ -116,7 +106,6 @@ func findNextChildID(parentID service.NodeID, st *service.State, svc *service.Pr
 
 	return findNextChildIDResult{
 		ChildID:   childID,
-		ChildNum:  childNum,
 		WarnDepth: childDepth > cfg.WarnDepth,
 	}, nil
 }
@@ -269,8 +258,13 @@ Provide statements as positional arguments:
 For complex cases with different types per child, use --children with JSON:
   af refine 1 --children '[{"statement":"Case 1","type":"case"},{"statement":"Case 2","type":"case"}]' -o agent1
 
+A child in --children may list "depends" (IDs of existing nodes) and
+"batch_deps" (0-based indexes of earlier children in the same array), so
+a small DAG of steps can be created in one atomic call.
+
 Use --depends to declare logical dependencies on other nodes.
 Use --requires-validated for validation dependencies.
+Use --dry-run to check that a refinement is valid without writing it.
 
 Examples:
   af refine 1 "First subgoal" -o agent1
@@ -280,6 +274,8 @@ Examples:
   af refine 1 "By step 1.1, we have..." -o agent1 --depends 1.1
   af refine 1.5 "Step 1.5" -o agent1 --requires-validated 1.1,1.2,1.3,1.4
   af refine 1 --children '[{"statement":"Child 1"},{"statement":"Child 2","type":"case"}]' -o agent1
+  af refine 1 --children '[{"statement":"Lemma"},{"statement":"By the lemma","batch_deps":[0]}]' -o agent1
+  af refine 1 "Step A" -o agent1 --dry-run
 
 Workflow:
   Use 'af refine' to add depth (children) to a node.
@@ -296,7 +292,7 @@ Workflow:
 		},
 	}
 
-	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Agent/owner name (required, must match claim owner)")
+	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Agent/owner name, must match claim owner (default $AF_OWNER or the default-owner setting)")
 	cmd.Flags().StringVarP(&nodeType, "type", "t", "claim", "Child node type (claim/local_assume/local_discharge/case/qed)")
 	cmd.Flags().StringVarP(&inference, "justification", "j", "assumption",
 		"Justification/inference type\n"+
@@ -316,9 +312,9 @@ Workflow:
 func runRefine(cmd *cobra.Command, nodeIDStr, owner, nodeTypeStr, inferenceStr, dir, format, childrenJSON, depends, requiresValidated string, statements []string) error {
 	examples := render.GetExamples("af refine")
 
-	// Validate owner is not empty
-	if strings.TrimSpace(owner) == "" {
-		return render.MissingFlagError("af refine", "owner", examples)
+	owner, err := resolveOwner(cmd, "af refine", dir, examples)
+	if err != nil {
+		return err
 	}
 
 	// Check input methods: positional statements vs --children JSON
@@ -344,7 +340,7 @@ func runRefine(cmd *cobra.Command, nodeIDStr, owner, nodeTypeStr, inferenceStr,
 	}
 
 	// Create service
-	svc, err := service.NewProofService(dir)
+	svc, err := service.NewProofService(dir, service.WithDryRun(isDryRun(cmd)))
 	if err != nil {
 		return fmt.Errorf("failed to open proof: %w", err)
 	}
@@ -378,7 +374,7 @@ func runRefine(cmd *cobra.Command, nodeIDStr, owner, nodeTypeStr, inferenceStr,
 }
 
 // runRefineMulti handles the --children flag for creating multiple child nodes at once.
-// This uses the atomic RefineNodeBulk method to create all children in a single operation,
+// This uses the atomic RefineNodeBulkWithDeps method to create all children in a single operation,
 // preventing race conditions where other agents could grab the node between individual refines.
 func runRefineMulti(cmd *cobra.Command, parentID service.NodeID, parentIDStr, owner, childrenJSON, dir, format string, svc *service.ProofService, st *service.State) error {
 	examples := render.GetExamples("af refine")
@@ -398,8 +394,9 @@ func runRefineMulti(cmd *cobra.Command, parentID service.NodeID, parentIDStr, ow
 			examples)
 	}
 
-	// Convert to service.ChildSpec and validate each child specification
+	// Convert to service.ChildSpecWithDeps and validate each child specification
 	specs := make([]service.ChildSpec, len(children))
+	depSpecs := make([]service.ChildSpecWithDeps, len(children))
 	for i, child := range children {
 		if strings.TrimSpace(child.Statement) == "" {
 			return render.NewUsageError("af refine",
@@ -428,18 +425,37 @@ func runRefineMulti(cmd *cobra.Command, parentID service.NodeID, parentIDStr, ow
 			return fmt.Errorf("child %d: invalid definition citation: %v", i+1, err)
 		}
 
+		// Parse dependencies on existing nodes
+		deps := make([]service.NodeID, len(child.Depends))
+		for j, depStr := range child.Depends {
+			deps[j], err = service.ParseNodeID(depStr)
+			if err != nil {
+				return render.NewUsageError("af refine",
+					fmt.Sprintf("child %d: invalid dependency ID %q: %v", i+1, depStr, err),
+					examples)
+			}
+		}
+
 		specs[i] = service.ChildSpec{
 			NodeType:  nodeType,
 			Statement: child.Statement,
 			Inference: inferenceType,
 		}
+		depSpecs[i] = service.ChildSpecWithDeps{
+			ChildSpec:    specs[i],
+			Dependencies: deps,
+			BatchDeps:    child.BatchDeps,
+		}
 	}
 
-	// Use RefineNodeBulk for atomic multi-child creation
-	childIDs, err := svc.RefineNodeBulk(parentID, owner, specs)
+	// Use RefineNodeBulkWithDeps for atomic multi-child creation
+	childIDs, err := svc.RefineNodeBulkWithDeps(parentID, owner, depSpecs)
 	if err != nil {
 		return handleRefineError(err, parentIDStr, owner)
 	}
+	if svc.DryRun() {
+		return writeDryRunPreview(cmd, format, svc.PreviewEvents())
+	}
 
 	return formatMultiChildOutput(cmd, format, parentIDStr, specs, childIDs)
 }
@@ -487,7 +503,7 @@ func runRefinePositional(cmd *cobra.Command, parentID service.NodeID, parentIDSt
 			return err
 		}
 
-		childResult, err := findNextChildID(parentID, st, svc)
+		childResult, err := findNextChildID(parentID, svc)
 		if err != nil {
 			return err
 		}
@@ -499,6 +515,9 @@ func runRefinePositional(cmd *cobra.Command, parentID service.NodeID, parentIDSt
 		if err != nil {
 			return handleRefineError(err, parentIDStr, owner)
 		}
+		if svc.DryRun() {
+			return writeDryRunPreview(cmd, format, svc.PreviewEvents())
+		}
 
 		return formatRefineOutput(cmd, format, refineOutputParams{
 			ParentIDStr:    parentIDStr,
@@ -524,6 +543,9 @@ func runRefinePositional(cmd *cobra.Command, parentID service.NodeID, parentIDSt
 	if err != nil {
 		return handleRefineError(err, parentIDStr, owner)
 	}
+	if svc.DryRun() {
+		return writeDryRunPreview(cmd, format, svc.PreviewEvents())
+	}
 
 	return formatMultiChildOutput(cmd, format, parentIDStr, specs, childIDs)
 }
@@ -60,7 +60,7 @@ Workflow:
 		},
 	}
 
-	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Agent/owner name (required, must match claim owner)")
+	cmd.Flags().StringVarP(&owner, "owner", "o", "", "Agent/owner name, must match claim owner (default $AF_OWNER or the default-owner setting)")
 	cmd.Flags().StringVarP(&nodeType, "type", "t", "claim", "Node type (claim/local_assume/local_discharge/case/qed)")
 	cmd.Flags().StringVarP(&inference, "justification", "j", "assumption",
 		"Justification/inference type\n"+
@@ -80,9 +80,9 @@ Workflow:
 func runRefineSibling(cmd *cobra.Command, nodeIDStr, owner, nodeTypeStr, inferenceStr, dir, format, childrenJSON, depends, requiresValidated string, statements []string) error {
 	examples := render.GetExamples("af refine-sibling")
 
-	// Validate owner is not empty
-	if strings.TrimSpace(owner) == "" {
-		return render.MissingFlagError("af refine-sibling", "owner", examples)
+	owner, err := resolveOwner(cmd, "af refine-sibling", dir, examples)
+	if err != nil {
+		return err
 	}
 
 	// Check input methods: positional statements vs --children JSON
@@ -444,6 +444,46 @@ func TestRefineCmd_JSONOutput(t *testing.T) {
 	}
 }
 
+func TestRefineCmd_DryRun(t *testing.T) {
+	tmpDir, cleanup := setupRefineTest(t)
+	defer cleanup()
+
+	newDryRunCmd := func() *cobra.Command {
+		cmd := newRefineTestCmd()
+		cmd.PersistentFlags().Bool("dry-run", false, "Preview changes without making them")
+		return cmd
+	}
+
+	output, err := executeCommand(newDryRunCmd(), "refine", "1", "Step A", "Step B",
+		"--owner", "test-agent", "--dry-run", "--dir", tmpDir)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Would append 2 event(s)") || !strings.Contains(output, "1.2: Step B") {
+		t.Errorf("expected preview of two node_created events, got: %q", output)
+	}
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	childID, _ := service.ParseNodeID("1.1")
+	if st.GetNode(childID) != nil {
+		t.Error("dry run created node 1.1")
+	}
+
+	// Validation still runs: the wrong owner is rejected
+	_, err = executeCommand(newDryRunCmd(), "refine", "1", "Step A",
+		"--owner", "other-agent", "--dry-run", "--dir", tmpDir)
+	if err == nil {
+		t.Error("expected error for wrong owner in dry-run mode")
+	}
+}
+
 func TestRefineCmd_Help(t *testing.T) {
 	cmd := newRefineTestCmd()
 	output, err := executeCommand(cmd, "refine", "--help")
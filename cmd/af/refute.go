@@ -19,7 +19,8 @@ func newRefuteCmd() *cobra.Command {
 		Long: `Refute marks a proof node as disproven or incorrect.
 
 This is a verifier action that indicates the node's claim is false.
-The node's epistemic state changes from pending to refuted.
+The node's epistemic state changes from pending to refuted. If other nodes
+depend on it, a warning lists them, since they may become tainted.
 
 This is a DESTRUCTIVE action. You will be prompted for confirmation unless
 the --yes flag is provided. In non-interactive environments (when stdin is
@@ -69,7 +70,7 @@ func runRefute(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create proof service
-	svc, err := service.NewProofService(dir)
+	svc, err := service.NewProofService(dir, service.WithAutoTaint(isAutoTaint(cmd)))
 	if err != nil {
 		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
@@ -79,6 +80,10 @@ func runRefute(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error refuting node: %w", err)
 	}
 
+	// Nodes that rely on the refuted one are only warned about; failing to
+	// list them must not fail a refutation that already happened
+	dependents, _ := svc.DependentsOf(nodeID)
+
 	// Output result based on format
 	switch strings.ToLower(format) {
 	case "json":
@@ -90,6 +95,9 @@ func runRefute(cmd *cobra.Command, args []string) error {
 		if reason != "" {
 			result["reason"] = reason
 		}
+		if len(dependents) > 0 {
+			result["dependents"] = service.ToStringSlice(dependents)
+		}
 		output, err := json.Marshal(result)
 		if err != nil {
 			return fmt.Errorf("error marshaling JSON: %w", err)
@@ -98,6 +106,10 @@ func runRefute(cmd *cobra.Command, args []string) error {
 	default:
 		// Text format
 		fmt.Fprintf(cmd.OutOrStdout(), "Node %s refuted.\n", nodeID.String())
+		if len(dependents) > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %d node(s) depend on %s and may become tainted: %s\n",
+				len(dependents), nodeID.String(), strings.Join(service.ToStringSlice(dependents), ", "))
+		}
 	}
 
 	return nil
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tobias/vibefeld/internal/service"
 )
@@ -1141,3 +1142,55 @@ func TestRefuteCmd_JSONOutputWithReason(t *testing.T) {
 	// The reason may or may not appear in the output depending on implementation
 	t.Logf("JSON output with reason: %+v", result)
 }
+
+// TestRefuteCmd_WarnsAboutDependents tests that refuting a node lists the
+// nodes that depend on it, without failing the refutation.
+func TestRefuteCmd_WarnsAboutDependents(t *testing.T) {
+	tmpDir, cleanup := setupRefuteTestWithNode(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID := mustParseRefuteNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1.1", "1.2", "1.3"} {
+		spec := service.RefineSpec{
+			ParentID:  rootID,
+			Owner:     "prover",
+			ChildID:   mustParseRefuteNodeID(t, id),
+			NodeType:  service.NodeTypeClaim,
+			Statement: "Step " + id,
+			Inference: service.InferenceAssumption,
+		}
+		if id != "1.1" {
+			spec.Dependencies = []service.NodeID{mustParseRefuteNodeID(t, "1.1")}
+		}
+		if err := svc.Refine(spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := executeRefuteCommand(t, "1.1", "-d", tmpDir, "-y")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Warning: 2 node(s) depend on 1.1 and may become tainted: 1.2, 1.3") {
+		t.Errorf("expected dependents warning, got: %q", output)
+	}
+
+	output, err = executeRefuteCommand(t, "1.2", "-d", tmpDir, "-y", "-f", "json")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v\nOutput: %s", err, output)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nOutput: %q", err, output)
+	}
+	if _, ok := result["dependents"]; ok {
+		t.Errorf("expected no dependents for 1.2, got %v", result["dependents"])
+	}
+}
@@ -36,7 +36,7 @@ Workflow:
 		RunE: runRelease,
 	}
 
-	cmd.Flags().StringP("owner", "o", "", "Agent ID that owns the claim (required)")
+	cmd.Flags().StringP("owner", "o", "", "Agent ID that owns the claim (default $AF_OWNER or the default-owner setting)")
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
 	cmd.Flags().StringP("format", "f", "text", "Output format (text/json)")
 
@@ -55,16 +55,12 @@ func runRelease(cmd *cobra.Command, args []string) error {
 	examples := render.GetExamples("af release")
 
 	// Get flags
-	owner := service.MustString(cmd, "owner")
 	dir := service.MustString(cmd, "dir")
 	format := service.MustString(cmd, "format")
 
-	// Validate owner is provided and not empty
-	if owner == "" {
-		return render.MissingFlagError("af release", "owner", examples)
-	}
-	if strings.TrimSpace(owner) == "" {
-		return render.EmptyValueError("af release", "owner", examples)
+	owner, err := resolveOwner(cmd, "af release", dir, examples)
+	if err != nil {
+		return err
 	}
 
 	// Parse node ID
@@ -3,14 +3,24 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/service"
 )
 
+// replayProgressThreshold is the number of events above which replay
+// reports progress, so that long replays do not appear to hang.
+const replayProgressThreshold = 2000
+
+// replayProgressInterval is the minimum time between progress updates.
+const replayProgressInterval = 250 * time.Millisecond
+
 // ReplayStats holds statistics gathered during ledger replay.
 type ReplayStats struct {
 	EventsProcessed int `json:"events_processed"`
@@ -40,13 +50,23 @@ func newReplayCmd() *cobra.Command {
 The replay command processes all events in sequence order and shows statistics
 about the proof including nodes, challenges, and definitions.
 
-With --verify, the command also validates content hashes for all nodes to
-ensure data integrity.
+With --verify, the command also validates content hashes for all nodes and
+checks the prev_hash chain linking each event to the one before it, reporting
+the first sequence where the chain is broken.
+
+Replays of more than 2000 events print a progress line to stderr when it is
+a terminal; -v prints progress even when stderr is not a terminal.
+
+With --diff-against, the ledger is compared event-for-event with the ledger
+of another proof directory (e.g. a backup). The first divergent sequence and
+any extra events on either side are reported. The command fails if the
+ledgers have diverged, in text and JSON output alike.
 
 Examples:
   af replay                         Replay ledger in current directory
   af replay --dir /path/to/proof    Replay for specific proof directory
   af replay --verify                Verify content hashes during replay
+  af replay --diff-against ../backup  Compare ledger with a backup copy
   af replay --format json           Output in JSON format
   af replay -v                      Show detailed replay progress`,
 		RunE: runReplay,
@@ -56,6 +76,7 @@ Examples:
 	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
 	cmd.Flags().Bool("verify", false, "Verify content hashes during replay")
 	cmd.Flags().BoolP("verbose", "v", false, "Show detailed replay progress")
+	cmd.Flags().String("diff-against", "", "Compare ledger with another proof directory")
 
 	return cmd
 }
@@ -67,6 +88,7 @@ func runReplay(cmd *cobra.Command, args []string) error {
 	format := service.MustString(cmd, "format")
 	verify := service.MustBool(cmd, "verify")
 	verbose := service.MustBool(cmd, "verbose")
+	diffAgainst := service.MustString(cmd, "diff-against")
 
 	// Validate format
 	format = strings.ToLower(format)
@@ -76,6 +98,10 @@ func runReplay(cmd *cobra.Command, args []string) error {
 
 	// Open ledger
 	ledgerDir := filepath.Join(dir, "ledger")
+
+	if diffAgainst != "" {
+		return runReplayDiff(cmd, ledgerDir, filepath.Join(diffAgainst, "ledger"), format)
+	}
 	ldg, err := ledger.NewLedger(ledgerDir)
 	if err != nil {
 		return fmt.Errorf("error accessing ledger: %w", err)
@@ -88,12 +114,18 @@ func runReplay(cmd *cobra.Command, args []string) error {
 	}
 
 	// Perform replay
+	progress := newReplayProgress(cmd.ErrOrStderr(), eventCount, verbose)
+	var onProgress service.ReplayProgressFunc
+	if progress != nil {
+		onProgress = progress.report
+	}
 	var st *service.State
 	if verify {
-		st, err = service.ReplayWithVerify(ldg)
+		st, err = service.ReplayWithVerifyProgress(ldg, onProgress)
 	} else {
-		st, err = service.Replay(ldg)
+		st, err = service.ReplayWithProgress(ldg, onProgress)
 	}
+	progress.finish()
 
 	// Build stats
 	stats := ReplayStats{
@@ -162,6 +194,55 @@ func runReplay(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// replayProgress writes a throttled progress line while a ledger is replayed.
+type replayProgress struct {
+	w    io.Writer
+	tty  bool // Rewrite one line in place rather than printing a line per update
+	now  func() time.Time
+	last time.Time
+}
+
+// newReplayProgress returns a progress reporter for replaying total events
+// to w, or nil if no progress should be shown. Progress is shown only for
+// replays of more than replayProgressThreshold events, and only when w is a
+// terminal or verbose is set.
+func newReplayProgress(w io.Writer, total int, verbose bool) *replayProgress {
+	if total <= replayProgressThreshold {
+		return nil
+	}
+	tty := render.IsTerminal(w)
+	if !tty && !verbose {
+		return nil
+	}
+	return &replayProgress{w: w, tty: tty, now: time.Now}
+}
+
+// report is a service.ReplayProgressFunc. It prints the first event, then
+// at most one update per replayProgressInterval, and always the last event.
+func (p *replayProgress) report(seq, total int) {
+	now := p.now()
+	if seq != 1 && seq != total && now.Sub(p.last) < replayProgressInterval {
+		return
+	}
+	p.last = now
+
+	line := fmt.Sprintf("Replaying events: %d/%d (%d%%)", seq, total, seq*100/total)
+	if p.tty {
+		fmt.Fprintf(p.w, "\r%s", line)
+	} else {
+		fmt.Fprintln(p.w, line)
+	}
+}
+
+// finish clears an in-place progress line so later output starts on a
+// clean line. It is safe to call on a nil reporter.
+func (p *replayProgress) finish() {
+	if p == nil || !p.tty {
+		return
+	}
+	fmt.Fprint(p.w, "\r\033[K")
+}
+
 // formatReplayText formats replay statistics as human-readable text.
 func formatReplayText(stats ReplayStats, verify bool, verbose bool) string {
 	var sb strings.Builder
@@ -220,6 +301,63 @@ func formatReplayText(stats ReplayStats, verify bool, verbose bool) string {
 	return sb.String()
 }
 
+// runReplayDiff compares two ledgers and reports where they diverge.
+// Returns an error if the ledgers are not prefix-compatible, in either format.
+func runReplayDiff(cmd *cobra.Command, ledgerDir, otherDir, format string) error {
+	cmp, err := ledger.Compare(ledgerDir, otherDir)
+	if err != nil {
+		return fmt.Errorf("error comparing ledgers: %w", err)
+	}
+
+	if format == "json" {
+		output, err := json.MarshalIndent(cmp, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error formatting JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatLedgerComparison(cmp))
+	}
+
+	if !cmp.PrefixCompatible {
+		return fmt.Errorf("ledgers diverge at sequence %d", cmp.FirstDivergence)
+	}
+	return nil
+}
+
+// formatLedgerComparison formats a ledger comparison as human-readable text.
+func formatLedgerComparison(cmp *ledger.LedgerComparison) string {
+	var sb strings.Builder
+
+	sb.WriteString("Comparing ledgers...\n")
+	sb.WriteString(fmt.Sprintf("  Events (this):  %d\n", cmp.CountA))
+	sb.WriteString(fmt.Sprintf("  Events (other): %d\n", cmp.CountB))
+	sb.WriteString(fmt.Sprintf("  Common prefix:  %d\n", cmp.CommonPrefix))
+
+	if cmp.FirstDivergence > 0 {
+		sb.WriteString(fmt.Sprintf("  First divergence at sequence %d\n", cmp.FirstDivergence))
+	}
+	if len(cmp.ExtraInA) > 0 {
+		sb.WriteString(fmt.Sprintf("  Extra events in this ledger: %d (seq %d-%d)\n",
+			len(cmp.ExtraInA), cmp.ExtraInA[0], cmp.ExtraInA[len(cmp.ExtraInA)-1]))
+	}
+	if len(cmp.ExtraInB) > 0 {
+		sb.WriteString(fmt.Sprintf("  Extra events in other ledger: %d (seq %d-%d)\n",
+			len(cmp.ExtraInB), cmp.ExtraInB[0], cmp.ExtraInB[len(cmp.ExtraInB)-1]))
+	}
+
+	switch {
+	case cmp.Identical():
+		sb.WriteString("  Ledgers are identical.\n")
+	case cmp.PrefixCompatible:
+		sb.WriteString("  Ledgers are prefix-compatible (one extends the other).\n")
+	default:
+		sb.WriteString("  Ledgers have diverged.\n")
+	}
+
+	return sb.String()
+}
+
 // countDefinitions counts the number of DefAdded events in the ledger.
 func countDefinitions(ldg *ledger.Ledger) (int, error) {
 	count := 0
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -45,7 +46,7 @@ func TestReplayCmd_ExpectedFlags(t *testing.T) {
 	cmd := newReplayCmd()
 
 	// Check expected flags exist
-	expectedFlags := []string{"format", "dir", "verify", "verbose"}
+	expectedFlags := []string{"format", "dir", "verify", "verbose", "diff-against"}
 	for _, flagName := range expectedFlags {
 		if cmd.Flags().Lookup(flagName) == nil && cmd.PersistentFlags().Lookup(flagName) == nil {
 			t.Errorf("expected replay command to have flag %q", flagName)
@@ -327,3 +328,39 @@ func TestReplayCmd_FormatValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestReplayProgress_Throttled verifies that replay progress is shown only for
+// long replays when verbose or on a terminal, and that updates are throttled
+// while the first and last events are always reported.
+func TestReplayProgress_Throttled(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if p := newReplayProgress(buf, replayProgressThreshold, true); p != nil {
+		t.Error("progress shown for a replay at the threshold")
+	}
+	if p := newReplayProgress(buf, replayProgressThreshold+1, false); p != nil {
+		t.Error("progress shown without --verbose when not writing to a terminal")
+	}
+
+	p := newReplayProgress(buf, replayProgressThreshold+1, true)
+	if p == nil {
+		t.Fatal("no progress for a long verbose replay")
+	}
+	clock := time.Unix(0, 0)
+	p.now = func() time.Time { return clock }
+
+	total := 10
+	for seq := 1; seq <= total; seq++ {
+		if seq == 5 {
+			clock = clock.Add(replayProgressInterval)
+		}
+		p.report(seq, total)
+	}
+	p.finish()
+
+	want := "Replaying events: 1/10 (10%)\n" +
+		"Replaying events: 5/10 (50%)\n" +
+		"Replaying events: 10/10 (100%)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("progress output = %q, want %q", got, want)
+	}
+}
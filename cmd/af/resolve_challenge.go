@@ -6,12 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/cli"
-	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/service"
 )
 
 // newResolveChallengeCmd creates the resolve-challenge command.
@@ -109,79 +108,23 @@ func runResolveChallenge(cmd *cobra.Command, args []string) error {
 		return errors.New("path is not a directory")
 	}
 
-	// Get ledger
-	ledgerDir := filepath.Join(dir, "ledger")
-	ldg, err := ledger.NewLedger(ledgerDir)
+	// Create service
+	svc, err := service.NewProofService(dir)
 	if err != nil {
-		return fmt.Errorf("error accessing ledger: %w", err)
+		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
 
 	// Check if proof is initialized
-	count, err := ldg.Count()
+	status, err := svc.Status()
 	if err != nil {
-		return fmt.Errorf("error reading ledger: %w", err)
+		return fmt.Errorf("error checking proof status: %w", err)
 	}
-	if count == 0 {
+	if !status.Initialized {
 		return errors.New("proof not initialized")
 	}
 
-	// Scan ledger to find challenge state
-	state := &resolveChallengeState{
-		id:     challengeID,
-		exists: false,
-		status: "",
-	}
-
-	err = ldg.Scan(func(seq int, data []byte) error {
-		// Parse base event to get type
-		var base struct {
-			Type        string `json:"type"`
-			ChallengeID string `json:"challenge_id"`
-		}
-		if err := json.Unmarshal(data, &base); err != nil {
-			return nil // Skip unparseable events
-		}
-
-		// Track challenge state changes
-		switch base.Type {
-		case string(ledger.EventChallengeRaised):
-			if base.ChallengeID == challengeID {
-				state.exists = true
-				state.status = "open"
-			}
-		case string(ledger.EventChallengeResolved):
-			if base.ChallengeID == challengeID {
-				state.status = "resolved"
-			}
-		case string(ledger.EventChallengeWithdrawn):
-			if base.ChallengeID == challengeID {
-				state.status = "withdrawn"
-			}
-		}
-
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("error scanning ledger: %w", err)
-	}
-
-	// Validate challenge state
-	if !state.exists {
-		return fmt.Errorf("challenge %q does not exist", challengeID)
-	}
-
-	if state.status == "resolved" {
-		return fmt.Errorf("challenge %q is not open (already resolved)", challengeID)
-	}
-
-	if state.status == "withdrawn" {
-		return fmt.Errorf("challenge %q is not open (already withdrawn)", challengeID)
-	}
-
-	// Append ChallengeResolved event
-	event := ledger.NewChallengeResolved(challengeID)
-	_, err = ldg.Append(event)
-	if err != nil {
+	// Resolve the challenge (validates that it exists and is open)
+	if err := svc.ResolveChallenge(challengeID); err != nil {
 		return fmt.Errorf("error resolving challenge: %w", err)
 	}
 
@@ -207,13 +150,6 @@ func runResolveChallenge(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// resolveChallengeState tracks the state of a challenge as we replay events.
-type resolveChallengeState struct {
-	id     string
-	exists bool
-	status string // "open", "resolved", "withdrawn"
-}
-
 func init() {
 	rootCmd.AddCommand(newResolveChallengeCmd())
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newRestoreCmd creates the restore command.
+func newRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restore <file>",
+		GroupID: GroupAdmin,
+		Short:   "Restore a ledger from an 'af dump' file",
+		Long: `Restore a proof ledger from a JSON event array written by 'af dump'.
+
+The target proof directory is created if needed, but its ledger must be
+empty: restore never overwrites existing events. Before anything is
+written, the dump is checked for a continuous prev_hash chain and for
+node content hashes that match their content.
+
+Examples:
+  af restore backup.json                Restore into the current directory
+  af restore backup.json -d ./proof     Restore into a specific directory`,
+		Args: cobra.ExactArgs(1),
+		RunE: runRestore,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+
+	return cmd
+}
+
+// runRestore executes the restore command.
+func runRestore(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("error reading dump file: %w", err)
+	}
+
+	if err := service.InitProofDir(dir); err != nil {
+		return fmt.Errorf("error creating proof directory: %w", err)
+	}
+
+	if err := ledger.Import(filepath.Join(dir, "ledger"), data); err != nil {
+		return fmt.Errorf("error restoring ledger: %w", err)
+	}
+
+	count, err := ledger.Count(filepath.Join(dir, "ledger"))
+	if err != nil {
+		return fmt.Errorf("error counting restored events: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Restored %d events into %s\n", count, dir)
+	fmt.Fprintln(cmd.OutOrStdout(), "Run 'af replay --verify' to check the restored proof.")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newRestoreCmd())
+}
@@ -39,7 +39,9 @@ var commandRoles = map[string]string{
 	"claim":        RoleShared,
 	"release":      RoleShared,
 	"extend-claim": RoleShared,
+	"reclaim":      RoleShared,
 	"jobs":         RoleShared,
+	"who":          RoleShared,
 
 	// Escape hatches (typically operator, but sometimes agent-used)
 	"admit":   RoleOperator,
@@ -49,8 +51,10 @@ var commandRoles = map[string]string{
 	// Information/reference commands
 	"status":       RoleInfo,
 	"get":          RoleInfo,
+	"show":         RoleInfo,
 	"scope":        RoleInfo,
 	"deps":         RoleInfo,
+	"graph":        RoleInfo,
 	"challenges":   RoleInfo,
 	"defs":         RoleInfo,
 	"def":          RoleInfo,
@@ -72,6 +76,7 @@ var commandRoles = map[string]string{
 	"history":      RoleInfo,
 	"search":       RoleInfo,
 	"log":          RoleInfo,
+	"diff":         RoleInfo,
 	"metrics":      RoleInfo,
 	"strategy":     RoleInfo,
 	"patterns":     RoleInfo,
@@ -90,6 +95,7 @@ var commandRoles = map[string]string{
 	"export":          RoleOperator,
 	"hooks":           RoleOperator,
 	"watch":           RoleOperator,
+	"checkpoint":      RoleOperator,
 
 	// Interactive/guidance commands
 	"shell":    RoleShared,
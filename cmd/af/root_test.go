@@ -258,6 +258,48 @@ func TestRootCmd_FuzzyMatching(t *testing.T) {
 	}
 }
 
+func TestRootCmd_NoEnhance(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		env       string
+		wantUsage bool
+	}{
+		{name: "enhanced by default", args: []string{"clam"}, wantUsage: true},
+		{name: "no-enhance flag", args: []string{"--no-enhance", "clam"}},
+		{name: "no-enhance flag after command", args: []string{"clam", "--no-enhance"}},
+		{name: "AF_PLAIN_ERRORS", args: []string{"clam"}, env: "1"},
+		{name: "AF_PLAIN_ERRORS other value", args: []string{"clam"}, env: "0", wantUsage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(plainErrorsEnv, tt.env)
+			root := newTestRootCmdWithStubs()
+			root.PersistentFlags().Bool("no-enhance", false, "Report errors without appended usage examples")
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs(tt.args)
+
+			cmd, err := root.ExecuteC()
+			if err == nil {
+				t.Fatal("expected error for misspelled command, got nil")
+			}
+			got := reportedError(root, cmd, tt.args, err).Error()
+
+			if !strings.Contains(got, "unknown command \"clam\"") {
+				t.Errorf("expected the cobra error, got: %q", got)
+			}
+			if hasUsage := strings.Contains(got, "Usage:"); hasUsage != tt.wantUsage {
+				t.Errorf("Usage block present = %v, want %v; error: %q", hasUsage, tt.wantUsage, got)
+			}
+			if tt.wantUsage && !strings.Contains(got, "af claim") {
+				t.Errorf("expected usage example for claim, got: %q", got)
+			}
+		})
+	}
+}
+
 func TestRootCmd_ShortFlag(t *testing.T) {
 	// Test that -h works as short form of --help
 	cmd := newTestRootCmd()
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/jsonschema"
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/service"
 )
@@ -73,6 +74,13 @@ The schema command shows valid values for:
   - Taint states (clean, self_admitted, tainted, unresolved)
   - Challenge targets (statement, inference, gap, etc.)
 
+With --json-schema, the command instead prints a JSON Schema document
+(draft 2020-12) describing a machine-readable output format, for tools
+that validate AF output:
+  events  Ledger events, as emitted by af log -f json
+  views   The --json envelope and the view models in its data field,
+          as emitted by e.g. af status --json
+
 This command works without an initialized proof directory because
 the schema is static configuration data.
 
@@ -80,13 +88,16 @@ Examples:
   af schema                           Show all schema information
   af schema --format json             Output in JSON format
   af schema --section inference-types Show only inference types
-  af schema -s states                 Show only state information`,
+  af schema -s states                 Show only state information
+  af schema --json-schema events      JSON Schema for ledger events
+  af schema --json-schema views       JSON Schema for --json output`,
 		RunE: runSchema,
 	}
 
 	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
 	cmd.Flags().StringP("section", "s", "", "Filter to specific section")
 	cmd.Flags().StringP("dir", "d", ".", "Proof directory path (ignored for schema)")
+	cmd.Flags().String("json-schema", "", "Print the JSON Schema for a format (events or views)")
 
 	return cmd
 }
@@ -95,6 +106,11 @@ Examples:
 func runSchema(cmd *cobra.Command, args []string) error {
 	format, _ := cmd.Flags().GetString("format")
 	section, _ := cmd.Flags().GetString("section")
+	jsonSchema, _ := cmd.Flags().GetString("json-schema")
+
+	if jsonSchema != "" {
+		return outputJSONSchema(cmd, strings.ToLower(jsonSchema))
+	}
 
 	// Validate format (case-insensitive)
 	format = strings.ToLower(format)
@@ -120,6 +136,20 @@ func runSchema(cmd *cobra.Command, args []string) error {
 	return outputSchemaText(cmd, normalizedSection)
 }
 
+// outputJSONSchema prints the named JSON Schema document.
+func outputJSONSchema(cmd *cobra.Command, name string) error {
+	doc, ok := jsonschema.Document(name)
+	if !ok {
+		return fmt.Errorf("unknown JSON schema %q: must be '%s' or '%s'", name, jsonschema.DocumentEvents, jsonschema.DocumentViews)
+	}
+	output, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	return nil
+}
+
 // schemaJSON represents the JSON output structure.
 type schemaJSON struct {
 	InferenceTypes   []inferenceTypeJSON   `json:"inference_types,omitempty"`
@@ -1,11 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/service"
 )
@@ -17,6 +17,8 @@ func newSearchCmd() *cobra.Command {
 	var stateFilter string
 	var workflowFilter string
 	var defFilter string
+	var includeLatex bool
+	var caseSensitive bool
 	var jsonOutput bool
 
 	cmd := &cobra.Command{
@@ -27,6 +29,7 @@ func newSearchCmd() *cobra.Command {
 
 Supports multiple filter criteria that can be combined:
   - Text search: Match nodes containing text in their statement
+    (case-insensitive; add --latex to also search LaTeX)
   - Epistemic state: Filter by pending, validated, admitted, refuted, or archived
   - Workflow state: Filter by available, claimed, or blocked
   - Definition reference: Find nodes referencing a specific definition
@@ -37,14 +40,16 @@ Examples:
   af search --workflow available       Show all available nodes
   af search --def "continuity"         Find nodes referencing definition "continuity"
   af search --state validated --json   Show validated nodes in JSON format
-  af search -t "limit" -s pending      Combine text and state filters`,
+  af search -t "limit" -s pending      Combine text and state filters
+  af search induction -s refuted       Find refuted nodes mentioning "induction"
+  af search "\sum" --latex             Also match against node LaTeX`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Use positional argument as text query if provided and --text not specified
 			query := textQuery
 			if query == "" && len(args) > 0 {
 				query = args[0]
 			}
-			return runSearch(cmd, dir, query, stateFilter, workflowFilter, defFilter, jsonOutput)
+			return runSearch(cmd, dir, query, stateFilter, workflowFilter, defFilter, includeLatex, caseSensitive, jsonOutput)
 		},
 	}
 
@@ -53,12 +58,14 @@ Examples:
 	cmd.Flags().StringVarP(&stateFilter, "state", "s", "", "Filter by epistemic state (pending/validated/admitted/refuted/archived)")
 	cmd.Flags().StringVarP(&workflowFilter, "workflow", "w", "", "Filter by workflow state (available/claimed/blocked)")
 	cmd.Flags().StringVar(&defFilter, "def", "", "Search nodes referencing a definition")
+	cmd.Flags().BoolVar(&includeLatex, "latex", false, "Also match the text query against node LaTeX")
+	cmd.Flags().BoolVar(&caseSensitive, "case-sensitive", false, "Match the text query case-sensitively")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 
 	return cmd
 }
 
-func runSearch(cmd *cobra.Command, dir, textQuery, stateFilter, workflowFilter, defFilter string, jsonOutput bool) error {
+func runSearch(cmd *cobra.Command, dir, textQuery, stateFilter, workflowFilter, defFilter string, includeLatex, caseSensitive, jsonOutput bool) error {
 	examples := render.GetExamples("af search")
 
 	// Validate filters
@@ -94,90 +101,59 @@ func runSearch(cmd *cobra.Command, dir, textQuery, stateFilter, workflowFilter,
 		return fmt.Errorf("proof not initialized")
 	}
 
-	// Load state
-	st, err := svc.LoadState()
+	nodes, err := svc.SearchNodes(textQuery, service.SearchOptions{
+		IncludeLatex:   includeLatex,
+		CaseSensitive:  caseSensitive,
+		EpistemicState: service.EpistemicState(stateFilter),
+		WorkflowState:  service.WorkflowState(workflowFilter),
+		Definition:     defFilter,
+	})
 	if err != nil {
-		return fmt.Errorf("error loading proof state: %w", err)
+		return fmt.Errorf("error searching nodes: %w", err)
 	}
 
-	// Get all nodes
-	allNodes := st.AllNodes()
-
-	// Filter nodes based on criteria
-	var results []render.SearchResult
-	for _, n := range allNodes {
-		if n == nil {
-			continue
-		}
-
-		match, reason := matchesFilters(n, textQuery, stateFilter, workflowFilter, defFilter)
-		if match {
-			results = append(results, render.SearchResult{
-				Node:        n,
-				MatchReason: reason,
-			})
-		}
+	// Every result passed all filters, so they share a match reason
+	reason := searchMatchReason(textQuery, stateFilter, workflowFilter, defFilter)
+	view := render.SearchResultsView{
+		Results: make([]render.SearchMatchView, 0, len(nodes)),
+		Total:   len(nodes),
+	}
+	for _, n := range nodes {
+		m := render.NodeToSearchMatchView(n, textQuery, caseSensitive)
+		m.MatchReason = reason
+		view.Results = append(view.Results, m)
 	}
 
 	// Output results
 	if jsonOutput {
-		fmt.Fprintln(cmd.OutOrStdout(), render.FormatSearchResultsJSON(results))
-	} else {
-		fmt.Fprint(cmd.OutOrStdout(), render.FormatSearchResults(results))
+		data, err := json.Marshal(view)
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
 	}
 
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderSearchMatches(view))
 	return nil
 }
 
-// matchesFilters checks if a node matches all specified filters.
-// Returns (matches bool, reason string).
-func matchesFilters(n *node.Node, textQuery, stateFilter, workflowFilter, defFilter string) (bool, string) {
+// searchMatchReason describes which of the given criteria a result satisfied.
+func searchMatchReason(textQuery, stateFilter, workflowFilter, defFilter string) string {
 	var reasons []string
-
-	// Text filter - case-insensitive search in statement
 	if textQuery != "" {
-		if !strings.Contains(strings.ToLower(n.Statement), strings.ToLower(textQuery)) {
-			return false, ""
-		}
 		reasons = append(reasons, "text match")
 	}
-
-	// Epistemic state filter
 	if stateFilter != "" {
-		if string(n.EpistemicState) != stateFilter {
-			return false, ""
-		}
 		reasons = append(reasons, "state: "+stateFilter)
 	}
-
-	// Workflow state filter
 	if workflowFilter != "" {
-		if string(n.WorkflowState) != workflowFilter {
-			return false, ""
-		}
 		reasons = append(reasons, "workflow: "+workflowFilter)
 	}
-
-	// Definition reference filter - search in Context field
 	if defFilter != "" {
-		found := false
-		lowerDef := strings.ToLower(defFilter)
-		for _, ctx := range n.Context {
-			// Context entries may be "def:name" or just "name"
-			ctxLower := strings.ToLower(ctx)
-			if strings.Contains(ctxLower, lowerDef) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false, ""
-		}
 		reasons = append(reasons, "refs def: "+defFilter)
 	}
-
-	// If we get here, all specified filters matched
-	return true, strings.Join(reasons, ", ")
+	return strings.Join(reasons, ", ")
 }
 
 func init() {
@@ -295,6 +295,44 @@ func TestSearchCmd_JSONOutput(t *testing.T) {
 	}
 }
 
+func TestSearchCmd_CaseSensitiveAndSnippet(t *testing.T) {
+	dir := t.TempDir()
+
+	initCmd := newInitCmd()
+	initCmd.SetArgs([]string{"--dir", dir, "--conjecture", "The limit of convergent sequences is unique", "--author", "test"})
+	initCmd.SetOut(&bytes.Buffer{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("Failed to initialize proof: %v", err)
+	}
+
+	// Case-insensitive by default, with the match reported in the snippet
+	cmd := newSearchCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dir", dir, "CONVERGENT", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"snippet":"The limit of convergent sequences is unique"`) {
+		t.Errorf("Expected snippet in JSON output, got: %s", out.String())
+	}
+	if !strings.Contains(out.String(), `"match_start":13,"match_end":23`) {
+		t.Errorf("Expected match offsets in JSON output, got: %s", out.String())
+	}
+
+	// --case-sensitive excludes the differently-cased match
+	cmd = newSearchCmd()
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dir", dir, "CONVERGENT", "--case-sensitive"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "No matching nodes found") {
+		t.Errorf("Expected no results for case-sensitive search, got: %s", out.String())
+	}
+}
+
 func TestSearchCmd_CombinedFilters(t *testing.T) {
 	dir := t.TempDir()
 
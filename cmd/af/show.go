@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newShowCmd creates the show command for displaying a single node's full record.
+func newShowCmd() *cobra.Command {
+	var dir string
+	var format string
+	var history bool
+
+	cmd := &cobra.Command{
+		Use:     "show <node-id>",
+		GroupID: GroupQuery,
+		Short:   "Show the complete record of a single node",
+		Long: `Show the complete record of a single proof node.
+
+Displays the statement, LaTeX, inference type, context, and workflow,
+epistemic and taint state of the node. Dependencies and validation
+dependencies are listed with their current epistemic state (unsatisfied
+prerequisites are marked with '!'). When the node has dependencies, every
+node it rests on transitively is listed the same way, with a warning if the
+dependencies form a cycle. Open challenges are grouped by severity, and
+notes left on the node follow.
+
+With --history, the node's full event timeline follows: every ledger event
+that references it (creation, claims, releases, challenges, validation,
+taint changes, amendments) in order, with a one-line summary of each.
+Event times are shown relative to now ("2h ago"), or in full with
+--absolute.
+
+Examples:
+  af show 1                   Show node 1
+  af show 1.2 --history       Show node 1.2 and every event that touched it
+  af show 1.2 -f json         Show node 1.2 in JSON format
+  af show 1.2 -d ./proof      Show node 1.2 from a specific directory`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(cmd, args[0], dir, format, history)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+	cmd.Flags().BoolVar(&history, "history", false, "Include every ledger event that references the node")
+	cmd.Flags().Bool("absolute", false, "Show full timestamps in --history instead of relative times")
+
+	return cmd
+}
+
+func runShow(cmd *cobra.Command, nodeIDStr, dir, format string, history bool) error {
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	// Parse node ID
+	nodeID, err := service.ParseNodeID(nodeIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid node ID %q: %v", nodeIDStr, err)
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	// Check if proof is initialized
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	// Load state
+	st, err := svc.LoadState()
+	if err != nil {
+		return fmt.Errorf("error loading proof state: %w", err)
+	}
+
+	if st.GetNode(nodeID) == nil {
+		return fmt.Errorf("node %q does not exist", nodeIDStr)
+	}
+
+	view := render.BuildNodeDetailView(st, nodeID)
+	deps, err := svc.TransitiveDependencies(nodeID)
+	if err != nil && !errors.Is(err, service.ErrCircularDependency) {
+		return fmt.Errorf("error resolving dependencies: %w", err)
+	}
+	view.TransitiveDeps = render.DependencyStatusViews(st, deps)
+	view.DependencyCycle = err != nil
+	if history {
+		entries, err := svc.NodeHistory(nodeID)
+		if err != nil {
+			return fmt.Errorf("error reading node history: %w", err)
+		}
+		view.History = render.NodeHistoryToView(entries)
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderNodeDetailWithOptions(view, renderOptions(cmd)))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newShowCmd())
+}
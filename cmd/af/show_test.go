@@ -0,0 +1,114 @@
+//go:build integration
+
+// Package main contains tests for the af show command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/render"
+)
+
+// executeShowCommand creates and executes a show command with the given arguments.
+func executeShowCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newShowCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestShowCommand_TextOutput tests that show renders the node with grouped challenges.
+func TestShowCommand_TextOutput(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	nodeID := mustParseNodeID(t, "1")
+	addChallengeToNode(t, tmpDir, nodeID, "ch-crit", "inference", "Invalid inference", "critical")
+	addChallengeToNode(t, tmpDir, nodeID, "ch-note", "statement", "Consider rewording", "note")
+
+	output, err := executeShowCommand(t, "1", "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("show failed: %v\noutput: %s", err, output)
+	}
+
+	for _, want := range []string{"Test conjecture", "Open challenges (2 of 2 total)", "critical (1) - blocking", "ch-note"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "critical") > strings.Index(output, "note (1)") {
+		t.Errorf("expected critical challenges before notes:\n%s", output)
+	}
+}
+
+// TestShowCommand_JSONOutput tests that show emits the node detail view as JSON.
+func TestShowCommand_JSONOutput(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	output, err := executeShowCommand(t, "1", "-d", tmpDir, "-f", "json")
+	if err != nil {
+		t.Fatalf("show failed: %v\noutput: %s", err, output)
+	}
+
+	var view render.NodeDetailView
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if view.Node.ID != "1" || view.Node.Statement != "Test conjecture" {
+		t.Errorf("unexpected node in JSON output: %+v", view.Node)
+	}
+}
+
+// TestShowCommand_History tests that --history appends the node's event timeline.
+func TestShowCommand_History(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	addChallengeToNode(t, tmpDir, mustParseNodeID(t, "1"), "ch-hist", "statement", "Unclear", "major")
+
+	output, err := executeShowCommand(t, "1", "-d", tmpDir, "--history")
+	if err != nil {
+		t.Fatalf("show failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{"History (", "node_created", "created as claim: Test conjecture", "challenge ch-hist raised on statement: Unclear"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = executeShowCommand(t, "1", "-d", tmpDir, "--history", "-f", "json")
+	if err != nil {
+		t.Fatalf("show failed: %v\noutput: %s", err, output)
+	}
+	var view render.NodeDetailView
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(view.History) == 0 || view.History[0].Type != "node_created" {
+		t.Errorf("JSON history = %+v, want node_created first", view.History)
+	}
+}
+
+// TestShowCommand_NodeNotFound tests that show errors cleanly for a missing node.
+func TestShowCommand_NodeNotFound(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	_, err := executeShowCommand(t, "1.7", "-d", tmpDir)
+	if err == nil {
+		t.Fatal("expected error for missing node")
+	}
+	if !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("error = %q, want mention of missing node", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Package main contains the af stats command for showing structural proof metrics.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newStatsCmd creates the stats command for showing structural proof metrics.
+func newStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "stats",
+		GroupID: GroupQuery,
+		Short:   "Show structural metrics of the proof tree",
+		Long: `Show metrics describing the shape of the proof, for tracking a proof's
+progress over time.
+
+The stats command displays:
+  - Maximum depth of the proof tree
+  - Number of leaves and the average branching factor of non-leaf nodes
+  - Largest number of children of any node
+  - Number of admitted nodes (proof debt)
+  - Length of the longest dependency chain, in edges
+  - Node counts by taint state
+
+Dependency cycles are followed once, so the chain length is always finite.
+
+Examples:
+  af stats                        Show stats for the proof in the current directory
+  af stats --dir /path/to/proof   Show stats for a specific proof directory
+  af stats --format json          Output in JSON format`,
+		RunE: runStats,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory path")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text or json)")
+
+	return cmd
+}
+
+// runStats executes the stats command.
+func runStats(cmd *cobra.Command, args []string) error {
+	dir := service.MustString(cmd, "dir")
+	format := strings.ToLower(service.MustString(cmd, "format"))
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized. Run 'af init' to start a new proof")
+	}
+
+	stats, err := svc.Stats()
+	if err != nil {
+		return fmt.Errorf("error computing stats: %w", err)
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, stats)
+	}
+	if format == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "=== Proof Stats ===")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Nodes:                    %d\n", stats.TotalNodes)
+	fmt.Fprintf(out, "Max depth:                %d\n", stats.MaxDepth)
+	fmt.Fprintf(out, "Leaves:                   %d\n", stats.Leaves)
+	fmt.Fprintf(out, "Average branching:        %.2f\n", stats.AverageBranching)
+	fmt.Fprintf(out, "Most children of a node:  %d\n", stats.MaxChildren)
+	fmt.Fprintf(out, "Admitted (proof debt):    %d\n", stats.AdmittedNodes)
+	fmt.Fprintf(out, "Longest dependency chain: %d\n", stats.LongestDependencyChain)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "By Taint:")
+	for _, taint := range []string{"clean", "self_admitted", "tainted", "unresolved"} {
+		fmt.Fprintf(out, "  %-14s %d\n", taint+":", stats.TaintCounts[taint])
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newStatsCmd())
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+// Package main contains tests for the af stats command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeStatsCommand creates and executes a stats command with the given arguments.
+func executeStatsCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newStatsCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// setupStatsTest creates a proof with two children under the root, the
+// second depending on the first.
+func setupStatsTest(t *testing.T) (string, func()) {
+	t.Helper()
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	rootID, _ := service.ParseNodeID("1")
+	childID, _ := service.ParseNodeID("1.1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	for _, child := range []struct {
+		id   string
+		deps []service.NodeID
+	}{{"1.1", nil}, {"1.2", []service.NodeID{childID}}} {
+		id, _ := service.ParseNodeID(child.id)
+		if err := svc.Refine(service.RefineSpec{
+			ParentID:     rootID,
+			Owner:        "prover",
+			ChildID:      id,
+			NodeType:     service.NodeTypeClaim,
+			Statement:    "Step " + id.String(),
+			Inference:    service.InferenceAssumption,
+			Dependencies: child.deps,
+		}); err != nil {
+			cleanup()
+			t.Fatal(err)
+		}
+	}
+	return tmpDir, cleanup
+}
+
+// TestStatsCmd_Text tests the text output.
+func TestStatsCmd_Text(t *testing.T) {
+	tmpDir, cleanup := setupStatsTest(t)
+	defer cleanup()
+
+	output, err := executeStatsCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("stats failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{
+		"Nodes:                    3",
+		"Max depth:                2",
+		"Leaves:                   2",
+		"Average branching:        2.00",
+		"Most children of a node:  2",
+		"Longest dependency chain: 1",
+		"By Taint:",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+// TestStatsCmd_JSON tests the JSON output.
+func TestStatsCmd_JSON(t *testing.T) {
+	tmpDir, cleanup := setupStatsTest(t)
+	defer cleanup()
+
+	output, err := executeStatsCommand(t, "-d", tmpDir, "-f", "json")
+	if err != nil {
+		t.Fatalf("stats failed: %v\noutput: %s", err, output)
+	}
+	var stats service.ProofStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if stats.TotalNodes != 3 || stats.LongestDependencyChain != 1 {
+		t.Errorf("total, chain = %d, %d; want 3, 1", stats.TotalNodes, stats.LongestDependencyChain)
+	}
+	if len(stats.TaintCounts) != 4 {
+		t.Errorf("taint_counts = %v, want all four taint states", stats.TaintCounts)
+	}
+}
+
+// TestStatsCmd_NoProof tests that a directory without a proof is an error.
+func TestStatsCmd_NoProof(t *testing.T) {
+	if output, err := executeStatsCommand(t, "-d", t.TempDir()); err == nil {
+		t.Errorf("stats succeeded without a proof, output: %s", output)
+	}
+}
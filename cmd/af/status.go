@@ -1,10 +1,14 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
 	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/service"
 )
@@ -29,19 +33,39 @@ Pagination:
   --limit controls how many nodes to show (0 = unlimited)
   --offset skips the first N nodes before displaying
 
+Delta mode:
+  Use --since-seq N to report only what changed after ledger sequence N:
+  nodes created, nodes validated, and challenges raised. If N is at or past
+  the latest sequence, "No changes" is reported.
+
 Urgent mode:
   Use --urgent to show only items needing immediate attention:
   - Nodes with blocking challenges (critical or major severity)
   - Available prover jobs (nodes needing refinement)
   - Ready verifier jobs (nodes ready for review)
 
+Detailed mode:
+  Use --detailed to follow the status with the node IDs grouped into
+  Validated, Admitted, Refuted, Pending and Claimed sections, each with its
+  count and sorted by node ID. Archived nodes are not listed. With JSON
+  output only the groups are printed.
+
+Watch mode:
+  Use --watch to keep the status on screen and re-render it whenever new
+  events are appended to the ledger. The ledger is polled every --interval
+  (default 1s) and the screen is cleared between frames. Press Ctrl+C to exit.
+
 Examples:
   af status                        Show proof status in current directory
   af status --dir /path/to/proof   Show status for specific proof directory
   af status --format json          Output in JSON format
   af status --limit 10             Show only the first 10 nodes
   af status --limit 10 --offset 5  Show 10 nodes, starting from the 6th
-  af status --urgent               Show only urgent items needing attention`,
+  af status --urgent               Show only urgent items needing attention
+  af status --since-seq 42         Show what changed after ledger sequence 42
+  af status --detailed             Also list node IDs grouped by state
+  af status --watch                Re-render status as the ledger changes
+  af status --watch --interval 5s  Poll the ledger every 5 seconds`,
 		RunE: runStatus,
 	}
 
@@ -50,6 +74,10 @@ Examples:
 	cmd.Flags().IntP("limit", "l", 0, "Maximum nodes to display (0 = unlimited)")
 	cmd.Flags().IntP("offset", "o", 0, "Number of nodes to skip")
 	cmd.Flags().BoolP("urgent", "u", false, "Show only urgent items (blocking challenges, available jobs)")
+	cmd.Flags().Int("since-seq", -1, "Show only nodes created, nodes validated, and challenges raised after this ledger sequence")
+	cmd.Flags().Bool("detailed", false, "Also list node IDs grouped by state (validated, admitted, refuted, pending, claimed)")
+	cmd.Flags().BoolP("watch", "w", false, "Re-render status whenever the ledger changes")
+	cmd.Flags().Duration("interval", 1*time.Second, "Poll interval for --watch (e.g., 1s, 500ms)")
 
 	return cmd
 }
@@ -62,6 +90,13 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	limit := service.MustInt(cmd, "limit")
 	offset := service.MustInt(cmd, "offset")
 	urgent := service.MustBool(cmd, "urgent")
+	sinceSeq := service.MustInt(cmd, "since-seq")
+	detailed := service.MustBool(cmd, "detailed")
+	watch := service.MustBool(cmd, "watch")
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return fmt.Errorf("invalid interval: %w", err)
+	}
 
 	// Validate pagination flags
 	if limit < 0 {
@@ -70,6 +105,23 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	if offset < 0 {
 		return fmt.Errorf("invalid offset %d: must be non-negative", offset)
 	}
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval %s: must be positive", interval)
+	}
+	if cmd.Flags().Changed("since-seq") {
+		if sinceSeq < 0 {
+			return fmt.Errorf("invalid since-seq %d: must be non-negative", sinceSeq)
+		}
+		if urgent {
+			return fmt.Errorf("--since-seq cannot be combined with --urgent")
+		}
+		if detailed {
+			return fmt.Errorf("--since-seq cannot be combined with --detailed")
+		}
+	}
+	if detailed && urgent {
+		return fmt.Errorf("--detailed cannot be combined with --urgent")
+	}
 
 	// Validate format
 	format = strings.ToLower(format)
@@ -83,12 +135,28 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error accessing proof directory: %w", err)
 	}
 
+	frame := func() error {
+		return writeStatus(cmd, svc, format, limit, offset, urgent, detailed, sinceSeq)
+	}
+	if watch {
+		return watchStatus(cmd, filepath.Join(dir, "ledger"), interval, frame)
+	}
+	return frame()
+}
+
+// writeStatus renders one status frame to the command's output. A
+// non-negative sinceSeq renders the delta after that sequence instead, and
+// detailed adds the node IDs grouped by state.
+func writeStatus(cmd *cobra.Command, svc *service.ProofService, format string, limit, offset int, urgent, detailed bool, sinceSeq int) error {
 	// Check if proof is initialized
 	status, err := svc.Status()
 	if err != nil {
 		return fmt.Errorf("error checking proof status: %w", err)
 	}
 	if !status.Initialized {
+		if isJSON(cmd) {
+			return fmt.Errorf("proof not initialized")
+		}
 		if format == "json" {
 			fmt.Fprintln(cmd.OutOrStdout(), `{"error":"proof not initialized"}`)
 			return nil
@@ -103,6 +171,22 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error loading proof state: %w", err)
 	}
 
+	if sinceSeq >= 0 {
+		return writeStatusDelta(cmd, svc, st, format, sinceSeq)
+	}
+
+	if detailed && (isJSON(cmd) || format == "json") {
+		return writeStatusGroups(cmd, st, format)
+	}
+
+	// Global --json: emit view models in the standard envelope
+	if isJSON(cmd) {
+		if urgent {
+			return writeJSONEnvelope(cmd, render.FilterUrgentNodes(st))
+		}
+		return writeJSONEnvelope(cmd, render.StateToStatusView(st))
+	}
+
 	// Urgent mode: show only urgent items
 	if urgent {
 		if format == "json" {
@@ -123,12 +207,106 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Text format with pagination support
-	output := render.RenderStatus(st, limit, offset)
+	output := render.RenderStatusWithOptions(st, limit, offset, renderOptions(cmd))
 	fmt.Fprint(cmd.OutOrStdout(), output)
 
+	if detailed {
+		fmt.Fprintln(cmd.OutOrStdout())
+		fmt.Fprint(cmd.OutOrStdout(), render.RenderStatusGroups(render.StateToStatusGroupsView(st)))
+	}
+
+	return nil
+}
+
+// writeStatusGroups writes the node IDs grouped by state as JSON, in the
+// standard envelope under the global --json flag.
+func writeStatusGroups(cmd *cobra.Command, st *service.State, format string) error {
+	view := render.StateToStatusGroupsView(st)
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	data, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
+}
+
+// writeStatusDelta renders the nodes created, nodes validated, and challenges
+// raised after sinceSeq. A sinceSeq at or past the latest sequence is
+// reported as no changes rather than an error.
+func writeStatusDelta(cmd *cobra.Command, svc *service.ProofService, st *service.State, format string, sinceSeq int) error {
+	view := render.StatusDeltaToView(sinceSeq, nil, st)
+	if sinceSeq < st.LatestSeq() {
+		diff, err := svc.Diff(sinceSeq, st.LatestSeq())
+		if err != nil {
+			return fmt.Errorf("error computing changes since seq %d: %w", sinceSeq, err)
+		}
+		view = render.StatusDeltaToView(sinceSeq, diff, st)
+	}
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderStatusDelta(view, renderOptions(cmd)))
 	return nil
 }
 
+// clearScreen is the ANSI sequence that moves the cursor home and clears the
+// terminal, used between --watch frames.
+const clearScreen = "\033[H\033[2J"
+
+// watchStatus renders the status once, then polls the ledger event count
+// every interval and re-renders whenever it changes. It returns nil when
+// the command is interrupted.
+func watchStatus(cmd *cobra.Command, ledgerDir string, interval time.Duration, frame func() error) error {
+	ctx, cancel := interruptContext(cmd)
+	defer cancel()
+
+	if err := frame(); err != nil {
+		return err
+	}
+	lastCount, _ := ledger.Count(ledgerDir)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			count, err := ledger.Count(ledgerDir)
+			if err != nil {
+				// Log error but continue watching
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: error reading ledger: %v\n", err)
+				continue
+			}
+			if count == lastCount {
+				continue
+			}
+			lastCount = count
+
+			fmt.Fprint(cmd.OutOrStdout(), clearScreen)
+			if err := frame(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(newStatusCmd())
 }
@@ -4,11 +4,18 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/service"
 )
 
 // =============================================================================
@@ -283,3 +290,251 @@ func TestStatusCmd_FormatValidation(t *testing.T) {
 		})
 	}
 }
+
+// =============================================================================
+// Watch Mode Tests
+// =============================================================================
+
+// TestStatusCmd_InvalidInterval verifies that --interval must be positive.
+func TestStatusCmd_InvalidInterval(t *testing.T) {
+	cmd := newTestStatusCmd()
+	_, err := executeStatusCommand(cmd, "status", "--watch", "--interval", "0s", "--dir", t.TempDir())
+
+	if err == nil || !strings.Contains(err.Error(), "invalid interval") {
+		t.Errorf("expected invalid interval error, got %v", err)
+	}
+}
+
+// waitFor polls cond until it returns true or the deadline passes.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestWatchStatus_RerendersOnLedgerChange verifies that watch mode renders
+// once up front, re-renders after a new event is appended, and returns nil
+// when its context is canceled.
+func TestWatchStatus_RerendersOnLedgerChange(t *testing.T) {
+	proofDir := t.TempDir()
+	if err := service.Init(proofDir, "Watch conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	ledgerDir := filepath.Join(proofDir, "ledger")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := &cobra.Command{}
+	cmd.SetContext(ctx)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	var frames atomic.Int32
+	frame := func() error {
+		frames.Add(1)
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watchStatus(cmd, ledgerDir, 10*time.Millisecond, frame)
+	}()
+
+	waitFor(t, func() bool { return frames.Load() == 1 })
+	if _, err := ledger.Append(ledgerDir, ledger.NewChallengeResolved("chal-1")); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	waitFor(t, func() bool { return frames.Load() == 2 })
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("watchStatus returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchStatus did not exit after cancel")
+	}
+
+	if !strings.Contains(buf.String(), clearScreen) {
+		t.Error("expected screen to be cleared between frames")
+	}
+}
+
+// =============================================================================
+// Delta Mode Tests
+// =============================================================================
+
+// TestStatusCmd_SinceSeq verifies that --since-seq reports nodes created,
+// nodes validated, and challenges raised after the given sequence.
+func TestStatusCmd_SinceSeq(t *testing.T) {
+	proofDir := t.TempDir()
+	if err := service.Init(proofDir, "Delta conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := st.LatestSeq()
+
+	rootID, _ := service.ParseNodeID("1")
+	childID, _ := service.ParseNodeID("1.1")
+	if err := svc.CreateNode(childID, "claim", "New step", "assumption"); err != nil {
+		t.Fatalf("CreateNode failed: %v", err)
+	}
+	if err := svc.AcceptNode(childID); err != nil {
+		t.Fatalf("AcceptNode failed: %v", err)
+	}
+	if _, err := svc.RaiseChallenge(rootID, "statement", "Needs a citation", "minor"); err != nil {
+		t.Fatalf("RaiseChallenge failed: %v", err)
+	}
+
+	output, err := executeStatusCommand(newTestStatusCmd(), "status", "--dir", proofDir, "--since-seq", strconv.Itoa(since))
+	if err != nil {
+		t.Fatalf("status --since-seq failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{
+		"Changes since seq " + strconv.Itoa(since),
+		"Created (1):\n  + 1.1 [validated] New step",
+		"Validated (1):\n  1.1 New step",
+		"Challenges raised (1):",
+		"on 1 [statement, minor] Needs a citation",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	// A sequence past the end is not an error
+	output, err = executeStatusCommand(newTestStatusCmd(), "status", "--dir", proofDir, "--since-seq", "1000")
+	if err != nil {
+		t.Fatalf("status --since-seq 1000 failed: %v", err)
+	}
+	if output != "No changes since seq 1000.\n" {
+		t.Errorf("output = %q, want no changes", output)
+	}
+
+	output, err = executeStatusCommand(newTestStatusCmd(), "status", "--dir", proofDir, "--since-seq", strconv.Itoa(since), "-f", "json")
+	if err != nil {
+		t.Fatalf("status --since-seq -f json failed: %v", err)
+	}
+	var view struct {
+		Created          []map[string]interface{} `json:"created"`
+		Validated        []map[string]interface{} `json:"validated"`
+		ChallengesRaised []map[string]interface{} `json:"challenges_raised"`
+	}
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(view.Created) != 1 || len(view.Validated) != 1 || len(view.ChallengesRaised) != 1 {
+		t.Errorf("JSON delta = %+v, want one of each", view)
+	}
+}
+
+// TestStatusCmd_SinceSeqInvalid verifies that --since-seq must be
+// non-negative and cannot be combined with --urgent.
+func TestStatusCmd_SinceSeqInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"--since-seq", "-1"},
+		{"--since-seq", "3", "--urgent"},
+	} {
+		_, err := executeStatusCommand(newTestStatusCmd(), append([]string{"status", "--dir", t.TempDir()}, args...)...)
+		if err == nil || !strings.Contains(err.Error(), "since-seq") {
+			t.Errorf("status %v: expected since-seq error, got %v", args, err)
+		}
+	}
+}
+
+// =============================================================================
+// Detailed Mode Tests
+// =============================================================================
+
+// TestStatusCmd_Detailed verifies that --detailed follows the usual status
+// with node IDs grouped by state, and that JSON output lists the groups.
+func TestStatusCmd_Detailed(t *testing.T) {
+	proofDir := t.TempDir()
+	if err := service.Init(proofDir, "Grouped conjecture", "author"); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	svc, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID, _ := service.ParseNodeID("1")
+	childID, _ := service.ParseNodeID("1.1")
+	if err := svc.CreateNode(childID, "claim", "New step", "assumption"); err != nil {
+		t.Fatalf("CreateNode failed: %v", err)
+	}
+	if err := svc.AcceptNode(childID); err != nil {
+		t.Fatalf("AcceptNode failed: %v", err)
+	}
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatalf("ClaimNode failed: %v", err)
+	}
+
+	plain, err := executeStatusCommand(newTestStatusCmd(), "status", "--dir", proofDir)
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if strings.Contains(plain, "Nodes by state:") {
+		t.Errorf("default status should not list groups:\n%s", plain)
+	}
+
+	output, err := executeStatusCommand(newTestStatusCmd(), "status", "--dir", proofDir, "--detailed")
+	if err != nil {
+		t.Fatalf("status --detailed failed: %v\noutput: %s", err, output)
+	}
+	for _, want := range []string{
+		"Nodes by state:",
+		"Validated (1):\n  1.1\n",
+		"Pending (0):\n  (none)\n",
+		"Claimed (1):\n  1\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = executeStatusCommand(newTestStatusCmd(), "status", "--dir", proofDir, "--detailed", "-f", "json")
+	if err != nil {
+		t.Fatalf("status --detailed -f json failed: %v", err)
+	}
+	var view struct {
+		Groups []struct {
+			Name    string   `json:"name"`
+			Count   int      `json:"count"`
+			NodeIDs []string `json:"node_ids"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(view.Groups) != 5 || view.Groups[0].Name != "Validated" || view.Groups[0].Count != 1 {
+		t.Errorf("JSON groups = %+v, want five groups starting with one validated node", view.Groups)
+	}
+}
+
+// TestStatusCmd_DetailedInvalid verifies that --detailed cannot be combined
+// with --urgent or --since-seq.
+func TestStatusCmd_DetailedInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"--detailed", "--urgent"},
+		{"--detailed", "--since-seq", "3"},
+	} {
+		_, err := executeStatusCommand(newTestStatusCmd(), append([]string{"status", "--dir", t.TempDir()}, args...)...)
+		if err == nil || !strings.Contains(err.Error(), "--detailed") {
+			t.Errorf("status %v: expected --detailed error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newTreeCmd creates the tree command for displaying the proof tree.
+func newTreeCmd() *cobra.Command {
+	var dir string
+	var format string
+	var prune bool
+	var depth int
+	var fullIDs bool
+
+	cmd := &cobra.Command{
+		Use:     "tree [node-id]",
+		GroupID: GroupQuery,
+		Short:   "Show the proof tree",
+		Long: `Show the proof tree with the epistemic and taint state of each node.
+
+If a node ID is given, only the subtree rooted at that node is shown.
+
+Use --prune to collapse every subtree whose root and descendants are all
+validated or admitted into a single "[✓ n nodes]" summary line. Subtrees
+containing pending, claimed, refuted, or tainted nodes are always expanded.
+
+Use --depth N to show at most N levels below the root of the displayed tree
+(--depth 0 shows the root only). Deeper descendants are replaced by a
+"… (m more levels, k nodes)" line.
+
+Below the root of the displayed tree, nodes are labeled with the last
+segment of their ID (1.2.3 shows as 3 under 1.2), since the indentation
+already conveys the rest. Use --full-ids to show complete IDs. JSON output
+always carries full IDs.
+
+Examples:
+  af tree                     Show the whole proof tree
+  af tree 1.2                 Show the subtree rooted at node 1.2
+  af tree --prune             Collapse fully validated subtrees
+  af tree --depth 2           Show the root and two levels below it
+  af tree 1.3 --depth 1       Show node 1.3 and its children
+  af tree --full-ids          Label every node with its complete ID
+  af tree -f json             Show the tree in JSON format`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodeIDStr := ""
+			if len(args) == 1 {
+				nodeIDStr = args[0]
+			}
+			return runTree(cmd, nodeIDStr, dir, format, prune, depth, fullIDs)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+	cmd.Flags().BoolVarP(&prune, "prune", "p", false, "Collapse fully validated subtrees into a summary line")
+	cmd.Flags().IntVar(&depth, "depth", -1, "Maximum levels to show below the root (0 = root only, -1 = unlimited)")
+	cmd.Flags().BoolVar(&fullIDs, "full-ids", false, "Show complete node IDs instead of the last segment below the root")
+
+	return cmd
+}
+
+func runTree(cmd *cobra.Command, nodeIDStr, dir, format string, prune bool, depth int, fullIDs bool) error {
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	if depth < -1 {
+		return fmt.Errorf("invalid depth %d: must be -1 (unlimited) or non-negative", depth)
+	}
+
+	// Parse optional subtree root
+	var root *service.NodeID
+	if nodeIDStr != "" {
+		nodeID, err := service.ParseNodeID(nodeIDStr)
+		if err != nil {
+			return fmt.Errorf("invalid node ID %q: %v", nodeIDStr, err)
+		}
+		root = &nodeID
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	// Check if proof is initialized
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Errorf("error checking proof status: %w", err)
+	}
+	if !status.Initialized {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	// Load state
+	st, err := svc.LoadState()
+	if err != nil {
+		return fmt.Errorf("error loading proof state: %w", err)
+	}
+
+	if root != nil && st.GetNode(*root) == nil {
+		return fmt.Errorf("node %q does not exist", nodeIDStr)
+	}
+
+	view := render.StateToTreeViewWithDepth(st, root, depth)
+
+	if isJSON(cmd) {
+		return writeJSONEnvelope(cmd, view)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	opts := renderOptions(cmd)
+	opts.CollapseValidated = prune
+	opts.AbbreviateIDs = !fullIDs
+	fmt.Fprint(cmd.OutOrStdout(), render.RenderTreeViewWithOptions(view, opts))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newTreeCmd())
+}
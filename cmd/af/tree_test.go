@@ -0,0 +1,144 @@
+//go:build integration
+
+// Package main contains tests for the af tree command.
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeTreeCommand creates and executes a tree command with the given arguments.
+func executeTreeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newTreeCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestTreeCommand_Prune tests that --prune collapses a validated proof while
+// the default output keeps every node expanded.
+func TestTreeCommand_Prune(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatalf("NewProofService failed: %v", err)
+	}
+	if err := svc.AcceptNode(mustParseNodeID(t, "1")); err != nil {
+		t.Fatalf("AcceptNode failed: %v", err)
+	}
+
+	output, err := executeTreeCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("tree failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Test conjecture") {
+		t.Errorf("expected full tree to show the root statement:\n%s", output)
+	}
+
+	output, err = executeTreeCommand(t, "-d", tmpDir, "--prune")
+	if err != nil {
+		t.Fatalf("tree --prune failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "1 [✓ 1 node]") || strings.Contains(output, "Test conjecture") {
+		t.Errorf("expected pruned tree to collapse the validated root:\n%s", output)
+	}
+}
+
+// TestTreeCommand_UnknownNode tests that an unknown subtree root is an error.
+func TestTreeCommand_UnknownNode(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	if _, err := executeTreeCommand(t, "1.9", "-d", tmpDir); err == nil {
+		t.Error("expected error for unknown node")
+	}
+}
+
+// TestTreeCommand_Depth tests that --depth trims descendants behind an indicator.
+func TestTreeCommand_Depth(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatalf("NewProofService failed: %v", err)
+	}
+	rootID := mustParseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for _, child := range []string{"1.1", "1.2"} {
+		if err := svc.RefineNode(rootID, "prover", mustParseNodeID(t, child), service.NodeTypeClaim, "Child "+child, service.InferenceAssumption); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := executeTreeCommand(t, "-d", tmpDir, "--depth", "0")
+	if err != nil {
+		t.Fatalf("tree --depth 0 failed: %v\noutput: %s", err, output)
+	}
+	if strings.Contains(output, "Child") || !strings.Contains(output, "(1 more level, 2 nodes)") {
+		t.Errorf("expected root only with an indicator for 2 hidden nodes:\n%s", output)
+	}
+
+	if _, err := executeTreeCommand(t, "-d", tmpDir, "--depth", "-2"); err == nil {
+		t.Error("expected error for negative depth")
+	}
+}
+
+// TestTreeCommand_FullIDs tests that children are labeled by the last
+// segment of their ID unless --full-ids is given, and that JSON output
+// keeps full IDs either way.
+func TestTreeCommand_FullIDs(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	svc, err := service.NewProofService(tmpDir)
+	if err != nil {
+		t.Fatalf("NewProofService failed: %v", err)
+	}
+	rootID := mustParseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNode(rootID, "prover", mustParseNodeID(t, "1.1"), service.NodeTypeClaim, "Child", service.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeTreeCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("tree failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "└── 1 [pending/unresolved] Child") {
+		t.Errorf("expected the child labeled by its last ID segment:\n%s", output)
+	}
+
+	output, err = executeTreeCommand(t, "-d", tmpDir, "--full-ids")
+	if err != nil {
+		t.Fatalf("tree --full-ids failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "└── 1.1 [pending/unresolved] Child") {
+		t.Errorf("expected the child labeled by its full ID:\n%s", output)
+	}
+
+	output, err = executeTreeCommand(t, "-d", tmpDir, "-f", "json")
+	if err != nil {
+		t.Fatalf("tree -f json failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, `"id": "1.1"`) {
+		t.Errorf("expected JSON output to carry the full child ID:\n%s", output)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newValidateCmd creates the validate command for checking proof invariants.
+func newValidateCmd() *cobra.Command {
+	var dir string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "validate",
+		GroupID: GroupAdmin,
+		Short:   "Check structural invariants of the whole proof",
+		Long: `Load the proof and check its structural invariants in one pass.
+
+Critical violations (the proof is corrupt or inconsistent):
+  - ledger_gap:            the ledger is missing event sequence numbers
+  - missing_dependency:    a node depends on a node that does not exist
+  - dependency_cycle:      nodes depend on each other in a cycle
+  - content_hash_mismatch: a node's content hash does not match its content
+
+Warnings (suspicious, but possible through normal use):
+  - unvalidated_dependency: a validated node depends on a node that is
+                            neither validated nor admitted
+
+A ledger with gaps cannot be replayed, so only the gaps are reported.
+
+The command exits non-zero if any critical invariant is violated: 7 for
+corruption (ledger gaps, hash mismatches), 6 for dependency cycles and 3 for
+missing dependencies. Warnings alone exit 0, so the command can be used as a
+CI gate against a proof directory.
+
+Examples:
+  af validate                 Check the proof in the current directory
+  af validate -d ./proof      Check a specific proof directory
+  af validate -f json         Output the report in JSON format`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(cmd, dir, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, dir, format string) error {
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	// Check invariants before anything that replays the ledger, since a
+	// ledger with gaps cannot be replayed
+	report, err := svc.CheckInvariants()
+	if err != nil {
+		return fmt.Errorf("error checking invariants: %w", err)
+	}
+	if report.EventsChecked == 0 {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	view := render.ValidationReportToView(report)
+
+	// The report is always written; critical violations are then surfaced
+	// as an exit-coded error
+	if isJSON(cmd) {
+		if err := writeJSONEnvelope(cmd, view); err != nil {
+			return err
+		}
+	} else if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), render.RenderValidationReport(view))
+	}
+
+	return service.InvariantReportError(report)
+}
+
+func init() {
+	rootCmd.AddCommand(newValidateCmd())
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+// Package main contains tests for the af validate command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeValidateCommand creates and executes a validate command with the given arguments.
+func executeValidateCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newValidateCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestValidateCommand_Valid tests that a healthy proof passes validation.
+func TestValidateCommand_Valid(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	output, err := executeValidateCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("validate failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "All invariants hold.") {
+		t.Errorf("output missing success line:\n%s", output)
+	}
+}
+
+// TestValidateCommand_LedgerGap tests that a ledger gap is reported and
+// exits with the corruption exit code.
+func TestValidateCommand_LedgerGap(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	if err := os.Remove(filepath.Join(tmpDir, "ledger", "000001.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeValidateCommand(t, "-d", tmpDir, "-f", "json")
+	if err == nil {
+		t.Fatalf("expected validate to fail, output: %s", output)
+	}
+	if code := service.ExitCode(err); code != 7 {
+		t.Errorf("exit code = %d, want 7 (err: %v)", code, err)
+	}
+
+	// The report precedes cobra's error output
+	var view render.ValidationReportView
+	if err := json.NewDecoder(strings.NewReader(output)).Decode(&view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if view.Valid || len(view.Violations) != 1 || view.Violations[0].Kind != "ledger_gap" {
+		t.Errorf("view = %+v, want one ledger_gap violation", view)
+	}
+}
+
+// TestValidateCommand_NotInitialized tests that an empty ledger is rejected.
+func TestValidateCommand_NotInitialized(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "af-validate-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := service.InitProofDir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = executeValidateCommand(t, "-d", tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "not initialized") {
+		t.Errorf("error = %v, want 'not initialized'", err)
+	}
+}
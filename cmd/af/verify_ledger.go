@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newVerifyLedgerCmd creates the verify-ledger command for checking the
+// integrity of the event ledger.
+func newVerifyLedgerCmd() *cobra.Command {
+	var dir string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "verify-ledger",
+		GroupID: GroupAdmin,
+		Short:   "Verify the integrity of the event ledger",
+		Long: `Verify the event ledger event by event and report every problem found:
+
+  - sequence_gap:          the ledger is missing event sequence numbers
+  - chain_broken:          an event's prev_hash does not match the hash of
+                           the event before it
+  - content_hash_mismatch: a created node's content hash does not match its
+                           content (reported with the node ID)
+  - invalid_event:         an event cannot be parsed or applied to the state
+                           built from the events before it
+
+These are the checks 'af replay --verify' performs, but verification does not
+stop at the first problem. Ledgers written before hash chaining was introduced
+have no prev_hash chain; for them the chain check is skipped.
+
+On success the command prints "ledger OK, N events verified". Any issue
+exits with code 7, so the command can be used as a CI gate.
+
+Examples:
+  af verify-ledger               Verify the ledger in the current directory
+  af verify-ledger -d ./proof    Verify a specific proof directory
+  af verify-ledger -f json       Output the report in JSON format`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerifyLedger(cmd, dir, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", ".", "Proof directory")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text/json)")
+
+	return cmd
+}
+
+func runVerifyLedger(cmd *cobra.Command, dir, format string) error {
+	// Validate format
+	format = strings.ToLower(format)
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	// Create service
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("error accessing proof directory: %w", err)
+	}
+
+	report, err := svc.VerifyLedger()
+	if err != nil {
+		return fmt.Errorf("error verifying ledger: %w", err)
+	}
+	if report.EventsChecked == 0 {
+		return fmt.Errorf("proof not initialized")
+	}
+
+	view := render.LedgerVerificationToView(report)
+
+	// The report is always written; issues are then surfaced as an
+	// exit-coded error
+	if isJSON(cmd) {
+		if err := writeJSONEnvelope(cmd, view); err != nil {
+			return err
+		}
+	} else if format == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), render.RenderLedgerVerification(view))
+	}
+
+	return service.LedgerVerificationError(report)
+}
+
+func init() {
+	rootCmd.AddCommand(newVerifyLedgerCmd())
+}
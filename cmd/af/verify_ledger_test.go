@@ -0,0 +1,122 @@
+//go:build integration
+
+// Package main contains tests for the af verify-ledger command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// executeVerifyLedgerCommand creates and executes a verify-ledger command with the given arguments.
+func executeVerifyLedgerCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := newVerifyLedgerCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestVerifyLedgerCommand_OK tests that an untouched ledger verifies cleanly.
+func TestVerifyLedgerCommand_OK(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	output, err := executeVerifyLedgerCommand(t, "-d", tmpDir)
+	if err != nil {
+		t.Fatalf("verify-ledger failed: %v\noutput: %s", err, output)
+	}
+	if !strings.HasPrefix(output, "ledger OK, ") || !strings.Contains(output, " events verified") {
+		t.Errorf("output missing success line:\n%s", output)
+	}
+}
+
+// TestVerifyLedgerCommand_HashMismatch tests that a tampered node is reported
+// with its sequence and node ID, and exits with the corruption exit code.
+func TestVerifyLedgerCommand_HashMismatch(t *testing.T) {
+	tmpDir, cleanup := setupAcceptTestWithNode(t)
+	defer cleanup()
+
+	// Find the event that created node 1 and change its statement
+	ledgerDir := filepath.Join(tmpDir, "ledger")
+	entries, err := os.ReadDir(ledgerDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := false
+	for _, e := range entries {
+		path := filepath.Join(ledgerDir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var event map[string]interface{}
+		if err := json.Unmarshal(data, &event); err != nil || event["type"] != "node_created" {
+			continue
+		}
+		n := event["node"].(map[string]interface{})
+		if n["id"] != "1" {
+			continue
+		}
+		out := strings.Replace(string(data), "Test conjecture", "Tampered statement", 1)
+		if err := os.WriteFile(path, []byte(out), 0644); err != nil {
+			t.Fatal(err)
+		}
+		tampered = true
+		break
+	}
+	if !tampered {
+		t.Fatal("node_created event for node 1 not found")
+	}
+
+	output, err := executeVerifyLedgerCommand(t, "-d", tmpDir, "-f", "json")
+	if err == nil {
+		t.Fatalf("expected verify-ledger to fail, output: %s", output)
+	}
+	if code := service.ExitCode(err); code != 7 {
+		t.Errorf("exit code = %d, want 7 (err: %v)", code, err)
+	}
+
+	// The report precedes cobra's error output
+	var view render.LedgerVerificationView
+	if err := json.NewDecoder(strings.NewReader(output)).Decode(&view); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if view.OK {
+		t.Error("view.OK = true, want false")
+	}
+	found := false
+	for _, issue := range view.Issues {
+		if issue.Kind == "content_hash_mismatch" && issue.NodeID == "1" && issue.Seq > 0 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("issues = %+v, want a content_hash_mismatch for node 1", view.Issues)
+	}
+}
+
+// TestVerifyLedgerCommand_NotInitialized tests that an empty ledger is rejected.
+func TestVerifyLedgerCommand_NotInitialized(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := service.InitProofDir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := executeVerifyLedgerCommand(t, "-d", tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "not initialized") {
+		t.Errorf("err = %v, want proof not initialized", err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/render"
 )
 
 func newWatchCmd() *cobra.Command {
@@ -88,25 +89,9 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	lastSeq := since
 
 	// Set up signal handling for graceful shutdown
-	ctx := cmd.Context()
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
-	// Create a context that's canceled on SIGINT/SIGTERM
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := interruptContext(cmd)
 	defer cancel()
 
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		select {
-		case <-sigCh:
-			cancel()
-		case <-ctx.Done():
-		}
-	}()
-
 	// If --once mode, just show current events and exit
 	if once {
 		return showCurrentEvents(cmd, ldg, lastSeq, filter, jsonOutput)
@@ -140,6 +125,31 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// interruptContext returns the command's context wrapped so that it is
+// canceled on SIGINT/SIGTERM. The caller must call the returned cancel
+// function to release the signal handler.
+func interruptContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(sigCh)
+	}()
+
+	return ctx, cancel
+}
+
 // showCurrentEvents displays all current events and exits.
 func showCurrentEvents(cmd *cobra.Command, ldg *ledger.Ledger, since int, filter string, jsonOutput bool) error {
 	_, err := scanAndDisplayEvents(cmd, ldg, since, filter, jsonOutput)
@@ -222,18 +232,9 @@ func formatWatchEvent(seq int, data map[string]interface{}) string {
 	}
 
 	// Extract timestamp
-	timestamp := ""
-	if ts, ok := data["timestamp"].(string); ok {
-		timestamp = formatTimestamp(ts)
-	}
-
-	// Format type for display
-	displayType := formatEventType(eventType)
-
-	// Generate summary
-	summary := generateSummary(eventType, data)
+	timestamp, _ := data["timestamp"].(string)
 
-	return fmt.Sprintf("#%-3d  %-20s  %s  %s", seq, displayType, timestamp, summary)
+	return render.RenderEventLogEntry(logEntryView(seq, eventType, timestamp, nil, data))
 }
 
 // formatWatchEventJSON formats an event for JSON output (NDJSON format).
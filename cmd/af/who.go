@@ -0,0 +1,112 @@
+// Package main contains the af who command for showing a node's claimant.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/service"
+)
+
+// newWhoCmd creates the who command for showing who holds a node's claim.
+func newWhoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "who <node-id>",
+		GroupID: GroupQuery,
+		Short:   "Show who holds the claim on a node",
+		Long: `Show whether a node is free and, if it is claimed, who holds the claim
+and when it lapses.
+
+An expired claim is still reported until it is reaped or reclaimed; the
+output marks it as expired so it can be taken over with 'af reclaim'.
+
+Examples:
+  af who 1.2
+  af who 1 -f json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runWho,
+	}
+
+	cmd.Flags().StringP("dir", "d", ".", "Proof directory")
+	cmd.Flags().StringP("format", "f", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// runWho executes the who command.
+func runWho(cmd *cobra.Command, args []string) error {
+	examples := render.GetExamples("af who")
+
+	nodeID, err := service.ParseNodeID(args[0])
+	if err != nil {
+		return render.InvalidNodeIDError("af who", args[0], examples)
+	}
+
+	dir := service.MustString(cmd, "dir")
+	format := strings.ToLower(service.MustString(cmd, "format"))
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: must be 'text' or 'json'", format)
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open proof directory: %w", err)
+	}
+
+	owner, since, expires, err := svc.WhoHolds(nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to look up claim: %w", err)
+	}
+
+	now := time.Now()
+	status := "available"
+	if owner != "" {
+		status = "claimed"
+		if expires.Before(now) {
+			status = "expired"
+		}
+	}
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"node_id": nodeID.String(),
+			"status":  status,
+			"owner":   owner,
+		}
+		if owner != "" {
+			result["claimed_at"] = service.FromTime(since).String()
+			result["expires_at"] = service.FromTime(expires).String()
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	if owner == "" {
+		fmt.Fprintf(out, "Node %s is available\n", nodeID.String())
+		return nil
+	}
+	fmt.Fprintf(out, "Node %s is held by %s\n", nodeID.String(), owner)
+	fmt.Fprintf(out, "  Claimed at: %s\n", since.Local().Format("2006-01-02 15:04:05"))
+	if status == "expired" {
+		fmt.Fprintf(out, "  Expired:    %s (%s ago)\n", expires.Local().Format("2006-01-02 15:04:05"),
+			now.Sub(expires).Round(time.Second))
+		fmt.Fprintf(out, "Take it over with 'af reclaim %s'\n", nodeID.String())
+		return nil
+	}
+	fmt.Fprintf(out, "  Expires at: %s (in %s)\n", expires.Local().Format("2006-01-02 15:04:05"),
+		expires.Sub(now).Round(time.Second))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newWhoCmd())
+}
@@ -0,0 +1,97 @@
+//go:build !integration
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/service"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func executeWho(args ...string) (string, error) {
+	root := newTestRootCmd()
+	root.AddCommand(newWhoCmd())
+	return executeCommand(root, args...)
+}
+
+func TestWhoCmd(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeWho("who", "1", "-d", dir)
+	if err != nil {
+		t.Fatalf("who failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Node 1 is available") {
+		t.Errorf("unexpected output for a free node: %q", output)
+	}
+
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, _ := types.Parse("1")
+	if err := svc.ClaimNode(root, "prover-1", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err = executeWho("who", "1", "-d", dir)
+	if err != nil {
+		t.Fatalf("who failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Node 1 is held by prover-1") || !strings.Contains(output, "Expires at:") {
+		t.Errorf("unexpected output for a claimed node: %q", output)
+	}
+
+	output, err = executeWho("who", "1", "-d", dir, "-f", "json")
+	if err != nil {
+		t.Fatalf("who -f json failed: %v\noutput: %s", err, output)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if result["owner"] != "prover-1" || result["status"] != "claimed" || result["expires_at"] == nil {
+		t.Errorf("unexpected JSON: %v", result)
+	}
+}
+
+func TestWhoCmd_Expired(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := service.NewProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, _ := types.Parse("1")
+	if err := svc.ClaimNode(root, "stale", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	output, err := executeWho("who", "1", "-d", dir)
+	if err != nil {
+		t.Fatalf("who failed: %v\noutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Expired:") || !strings.Contains(output, "af reclaim 1") {
+		t.Errorf("unexpected output for an expired claim: %q", output)
+	}
+}
+
+func TestWhoCmd_NodeNotFound(t *testing.T) {
+	dir := t.TempDir()
+	if err := service.Init(dir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeWho("who", "1.5", "-d", dir); err == nil {
+		t.Error("expected an error for a missing node")
+	}
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package e2e
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/service"
+	"github.com/tobias/vibefeld/internal/state"
+)
+
+// TestReopen_RefuteReopenAccept tests the full round-trip of refuting a node,
+// reopening it, and then accepting it, including a fresh replay of the ledger.
+func TestReopen_RefuteReopenAccept(t *testing.T) {
+	proofDir, cleanup := setupTaintTest(t)
+	defer cleanup()
+
+	svc := initProof(t, proofDir, "Test conjecture for reopen")
+	rootID := mustParseID(t, "1")
+
+	// 1. Raise a challenge, then refute the node (supersedes the challenge)
+	challengeID, err := svc.RaiseChallenge(rootID, "inference", "Step is wrong", "major")
+	if err != nil {
+		t.Fatalf("RaiseChallenge failed: %v", err)
+	}
+	if err := svc.RefuteNode(rootID); err != nil {
+		t.Fatalf("RefuteNode failed: %v", err)
+	}
+
+	// Refuted is terminal for the normal transitions
+	if err := svc.AcceptNode(rootID); err == nil {
+		t.Fatal("expected AcceptNode to fail on refuted node")
+	}
+
+	// 2. Reopen the node
+	if err := svc.ReopenNode(rootID); err != nil {
+		t.Fatalf("ReopenNode failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := st.GetNode(rootID).EpistemicState; got != schema.EpistemicPending {
+		t.Fatalf("after reopen, epistemic state = %q, want %q", got, schema.EpistemicPending)
+	}
+	if got := st.GetChallenge(challengeID).Status; got != state.ChallengeStatusSuperseded {
+		t.Errorf("challenge status after reopen = %q, want %q", got, state.ChallengeStatusSuperseded)
+	}
+
+	// Reopening a pending node is not allowed
+	if err := svc.ReopenNode(rootID); !errors.Is(err, service.ErrInvalidState) {
+		t.Errorf("ReopenNode on pending node: got %v, want ErrInvalidState", err)
+	}
+
+	// 3. Accept the reopened node
+	if err := svc.AcceptNode(rootID); err != nil {
+		t.Fatalf("AcceptNode after reopen failed: %v", err)
+	}
+
+	// A fresh service replays the whole ledger, including the reopen event
+	fresh, err := service.NewProofService(proofDir)
+	if err != nil {
+		t.Fatalf("NewProofService failed: %v", err)
+	}
+	st, err = fresh.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState after replay failed: %v", err)
+	}
+	if got := st.GetNode(rootID).EpistemicState; got != schema.EpistemicValidated {
+		t.Errorf("after replay, epistemic state = %q, want %q", got, schema.EpistemicValidated)
+	}
+
+	// Validated nodes cannot be reopened
+	if err := fresh.ReopenNode(rootID); !errors.Is(err, service.ErrInvalidState) {
+		t.Errorf("ReopenNode on validated node: got %v, want ErrInvalidState", err)
+	}
+}
+
+// TestReopen_ArchivedNode tests that archived nodes can also be reopened.
+func TestReopen_ArchivedNode(t *testing.T) {
+	proofDir, cleanup := setupTaintTest(t)
+	defer cleanup()
+
+	svc := initProof(t, proofDir, "Test conjecture for reopening archived node")
+	childID := mustParseID(t, "1.1")
+	if err := svc.CreateNode(childID, schema.NodeTypeClaim, "Abandoned branch", schema.InferenceModusPonens); err != nil {
+		t.Fatalf("CreateNode failed: %v", err)
+	}
+	if err := svc.ArchiveNode(childID); err != nil {
+		t.Fatalf("ArchiveNode failed: %v", err)
+	}
+	if err := svc.ReopenNode(childID); err != nil {
+		t.Fatalf("ReopenNode failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if got := st.GetNode(childID).EpistemicState; got != schema.EpistemicPending {
+		t.Errorf("epistemic state = %q, want %q", got, schema.EpistemicPending)
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
 )
 
 // MaxDepthLimit is the maximum allowed value for MaxDepth configuration.
@@ -17,6 +20,18 @@ const MaxDepthLimit = 100
 // Used by claim and extend-claim commands when no explicit timeout is specified.
 const DefaultClaimTimeout = "1h"
 
+// Child ID allocation strategies for Config.ChildIDStrategy.
+const (
+	// ChildIDLowestFree gives a new child the lowest unused number under
+	// its parent, filling gaps.
+	ChildIDLowestFree = "lowest-free"
+
+	// ChildIDMonotonic gives a new child one more than the highest number
+	// ever used under its parent, archived children included, so gaps are
+	// never filled and no ID is reissued.
+	ChildIDMonotonic = "monotonic"
+)
+
 // Config holds the configuration for an AF proof.
 // It is stored in meta.json in the proof directory.
 type Config struct {
@@ -41,6 +56,18 @@ type Config struct {
 	// AutoCorrectThreshold is the fuzzy match threshold for auto-correction (default: 0.8)
 	AutoCorrectThreshold float64 `json:"auto_correct_threshold"`
 
+	// BlockingSeverities lists the challenge severities whose open challenges
+	// block node acceptance (default: critical and major)
+	BlockingSeverities []string `json:"blocking_severities,omitempty"`
+
+	// ChildIDStrategy selects how new child IDs are numbered: ChildIDLowestFree
+	// or ChildIDMonotonic (default: lowest-free)
+	ChildIDStrategy string `json:"child_id_strategy,omitempty"`
+
+	// DefaultOwner is the agent identity that owner-taking commands use
+	// when neither --owner nor $AF_OWNER is given (optional)
+	DefaultOwner string `json:"default_owner,omitempty"`
+
 	// SchemaPath is an optional custom schema path
 	SchemaPath string `json:"schema_path,omitempty"`
 
@@ -85,6 +112,18 @@ func Load(path string) (*Config, error) {
 	if cfg.AutoCorrectThreshold == 0 {
 		cfg.AutoCorrectThreshold = 0.8
 	}
+	if len(cfg.BlockingSeverities) == 0 {
+		cfg.BlockingSeverities = schema.DefaultBlockingSeverities()
+	}
+	if err := validateBlockingSeverities(cfg.BlockingSeverities); err != nil {
+		return nil, err
+	}
+	if cfg.ChildIDStrategy == "" {
+		cfg.ChildIDStrategy = ChildIDLowestFree
+	}
+	if err := validateChildIDStrategy(cfg.ChildIDStrategy); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
@@ -99,6 +138,8 @@ func Default() *Config {
 		MaxChildren:          20,
 		WarnDepth:            3,
 		AutoCorrectThreshold: 0.8,
+		BlockingSeverities:   schema.DefaultBlockingSeverities(),
+		ChildIDStrategy:      ChildIDLowestFree,
 		Version:              "1.0",
 		Created:              time.Now(),
 	}
@@ -114,6 +155,8 @@ func Default() *Config {
 // - MaxDepth must be between 1 and MaxDepthLimit (100)
 // - MaxChildren must be between 1 and 50
 // - AutoCorrectThreshold must be between 0.0 and 1.0
+// - BlockingSeverities must contain only known challenge severities
+// - ChildIDStrategy must be empty, "lowest-free", or "monotonic"
 // - Version must be "1.0"
 func Validate(c *Config) error {
 	if c == nil {
@@ -128,6 +171,27 @@ func Validate(c *Config) error {
 		return fmt.Errorf("conjecture must not be empty")
 	}
 
+	if err := ValidateLimits(c); err != nil {
+		return err
+	}
+
+	if c.Version != "1.0" {
+		return fmt.Errorf("version must be \"1.0\", got %q", c.Version)
+	}
+
+	return nil
+}
+
+// ValidateLimits checks the runtime-tunable settings of c: LockTimeout,
+// MaxDepth, MaxChildren, AutoCorrectThreshold, BlockingSeverities and
+// ChildIDStrategy, with the bounds listed for Validate. Unlike Validate it
+// does not require Title, Conjecture or Version, which meta.json of a proof
+// created by af init does not record.
+func ValidateLimits(c *Config) error {
+	if c == nil {
+		return fmt.Errorf("config cannot be nil")
+	}
+
 	if c.LockTimeout < time.Second || c.LockTimeout > time.Hour {
 		return fmt.Errorf("lock_timeout must be between 1s and 1h, got %v", c.LockTimeout)
 	}
@@ -144,13 +208,45 @@ func Validate(c *Config) error {
 		return fmt.Errorf("auto_correct_threshold must be between 0.0 and 1.0, got %f", c.AutoCorrectThreshold)
 	}
 
-	if c.Version != "1.0" {
-		return fmt.Errorf("version must be \"1.0\", got %q", c.Version)
+	if err := validateBlockingSeverities(c.BlockingSeverities); err != nil {
+		return err
+	}
+
+	if c.ChildIDStrategy != "" {
+		if err := validateChildIDStrategy(c.ChildIDStrategy); err != nil {
+			return err
+		}
+	}
+
+	if c.DefaultOwner != "" && strings.TrimSpace(c.DefaultOwner) == "" {
+		return fmt.Errorf("default_owner must not be only whitespace")
 	}
 
 	return nil
 }
 
+// validateBlockingSeverities checks that every configured blocking severity
+// is a known challenge severity.
+func validateBlockingSeverities(severities []string) error {
+	for _, sev := range severities {
+		if err := schema.ValidateChallengeSeverity(sev); err != nil {
+			return fmt.Errorf("blocking_severities: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateChildIDStrategy checks that strategy is a known child ID
+// allocation strategy.
+func validateChildIDStrategy(strategy string) error {
+	switch strategy {
+	case ChildIDLowestFree, ChildIDMonotonic:
+		return nil
+	default:
+		return fmt.Errorf("child_id_strategy must be %q or %q, got %q", ChildIDLowestFree, ChildIDMonotonic, strategy)
+	}
+}
+
 // Save writes the config to the given path as formatted JSON.
 // Returns an error if the file cannot be written.
 func Save(c *Config, path string) error {
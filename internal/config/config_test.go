@@ -236,6 +236,99 @@ func TestConfig_WarnDepthCustom(t *testing.T) {
 	}
 }
 
+func TestLoad_BlockingSeverities(t *testing.T) {
+	write := func(t *testing.T, fields map[string]interface{}) string {
+		t.Helper()
+		cfg := map[string]interface{}{
+			"title":      "Blocking Test",
+			"conjecture": "Test conjecture",
+			"version":    "1.0",
+		}
+		for k, v := range fields {
+			cfg[k] = v
+		}
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metaPath := filepath.Join(t.TempDir(), "meta.json")
+		if err := os.WriteFile(metaPath, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return metaPath
+	}
+
+	loaded, err := Load(write(t, nil))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.BlockingSeverities; len(got) != 2 || got[0] != "critical" || got[1] != "major" {
+		t.Errorf("default BlockingSeverities = %v, want [critical major]", got)
+	}
+
+	loaded, err = Load(write(t, map[string]interface{}{"blocking_severities": []string{"critical", "major", "minor"}}))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.BlockingSeverities; len(got) != 3 || got[2] != "minor" {
+		t.Errorf("custom BlockingSeverities = %v, want [critical major minor]", got)
+	}
+
+	if _, err := Load(write(t, map[string]interface{}{"blocking_severities": []string{"critical", "severe"}})); err == nil {
+		t.Error("Load() with unknown blocking severity should fail")
+	}
+}
+
+func TestLoad_ChildIDStrategy(t *testing.T) {
+	write := func(t *testing.T, fields map[string]interface{}) string {
+		t.Helper()
+		cfg := map[string]interface{}{
+			"title":      "Strategy Test",
+			"conjecture": "Test conjecture",
+			"version":    "1.0",
+		}
+		for k, v := range fields {
+			cfg[k] = v
+		}
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metaPath := filepath.Join(t.TempDir(), "meta.json")
+		if err := os.WriteFile(metaPath, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return metaPath
+	}
+
+	loaded, err := Load(write(t, nil))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ChildIDStrategy != ChildIDLowestFree {
+		t.Errorf("default ChildIDStrategy = %q, want %q", loaded.ChildIDStrategy, ChildIDLowestFree)
+	}
+
+	loaded, err = Load(write(t, map[string]interface{}{"child_id_strategy": "monotonic"}))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ChildIDStrategy != ChildIDMonotonic {
+		t.Errorf("ChildIDStrategy = %q, want %q", loaded.ChildIDStrategy, ChildIDMonotonic)
+	}
+
+	if _, err := Load(write(t, map[string]interface{}{"child_id_strategy": "random"})); err == nil {
+		t.Error("Load() with unknown child ID strategy should fail")
+	}
+
+	cfg := Default()
+	cfg.Title, cfg.Conjecture = "T", "C"
+	cfg.ChildIDStrategy = "random"
+	if err := Validate(cfg); err == nil {
+		t.Error("Validate() with unknown child ID strategy should fail")
+	}
+}
+
 func TestValidate_ValidConfig(t *testing.T) {
 	validConfigs := []struct {
 		name   string
@@ -615,6 +708,39 @@ func TestValidate_NilConfig(t *testing.T) {
 	}
 }
 
+func TestValidateLimits(t *testing.T) {
+	// Title, Conjecture and Version are not required
+	cfg := Default()
+	cfg.Version = ""
+	if err := ValidateLimits(cfg); err != nil {
+		t.Errorf("ValidateLimits() on default limits = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"zero depth", func(c *Config) { c.MaxDepth = 0 }},
+		{"negative children", func(c *Config) { c.MaxChildren = -1 }},
+		{"zero timeout", func(c *Config) { c.LockTimeout = 0 }},
+		{"unknown severity", func(c *Config) { c.BlockingSeverities = []string{"critical", "urgent"} }},
+		{"whitespace default owner", func(c *Config) { c.DefaultOwner = "   " }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			if err := ValidateLimits(cfg); err == nil {
+				t.Error("ValidateLimits() should return error")
+			}
+		})
+	}
+
+	if err := ValidateLimits(nil); err == nil {
+		t.Error("ValidateLimits() with nil config should return error")
+	}
+}
+
 func TestLoad_EmptyPath(t *testing.T) {
 	_, err := Load("")
 	if err == nil {
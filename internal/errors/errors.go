@@ -1,9 +1,11 @@
 // Package errors provides structured error types for the AF framework.
-// All errors carry an ErrorCode that maps to exit codes:
-// - Exit 1: retriable errors (race conditions, transient failures)
-// - Exit 2: blocked errors (work cannot proceed)
-// - Exit 3: logic errors (invalid input, not found, scope violations)
-// - Exit 4: corruption errors (data integrity failures)
+// All errors carry an ErrorCode that maps to an exit code by category:
+// - Exit 1: generic errors (errors without an ErrorCode)
+// - Exit 3: not found errors (missing nodes, challenges, definitions, ...)
+// - Exit 4: conflict errors (claims held by others, concurrent modification)
+// - Exit 5: blocked errors (work cannot proceed yet)
+// - Exit 6: validation errors (invalid input, scope violations, limits)
+// - Exit 7: corruption errors (data integrity failures)
 package errors
 
 import (
@@ -14,16 +16,17 @@ import (
 // ErrorCode represents a specific error condition in the AF framework.
 type ErrorCode int
 
-// Error codes grouped by category.
+// Error codes grouped by category. The numeric values are internal and
+// shift when codes are regrouped; the String form is the stable identifier.
 const (
-	// Claim-related errors (retriable = exit 1)
+	// Claim-related errors (conflict = exit 4)
 	ALREADY_CLAIMED ErrorCode = iota + 1
 	NOT_CLAIM_HOLDER
 
-	// Blocked errors (exit 2)
+	// Blocked errors (exit 5)
 	NODE_BLOCKED
 
-	// Validation errors (logic = exit 3)
+	// Validation errors (exit 6)
 	INVALID_PARENT
 	INVALID_TYPE
 	INVALID_INFERENCE
@@ -33,33 +36,53 @@ const (
 	ALREADY_EXISTS
 	INVALID_TIMEOUT
 
-	// Not found errors (logic = exit 3)
+	// Not found errors (exit 3)
 	NODE_NOT_FOUND
 	PARENT_NOT_FOUND
 	CHALLENGE_NOT_FOUND
 	DEF_NOT_FOUND
 	ASSUMPTION_NOT_FOUND
 	EXTERNAL_NOT_FOUND
+	LEMMA_NOT_FOUND
+	CHECKPOINT_NOT_FOUND
 
-	// Scope errors (logic = exit 3)
+	// Scope errors (validation = exit 6)
 	SCOPE_VIOLATION
 	SCOPE_UNCLOSED
 	DEPENDENCY_CYCLE
 
-	// Corruption/integrity errors (exit 4)
+	// Corruption/integrity errors (exit 7)
 	CONTENT_HASH_MISMATCH
 	LEDGER_INCONSISTENT
 
-	// Retriable validation error (exit 1)
+	// Validation invariant violated (exit 6)
 	VALIDATION_INVARIANT_FAILED
 
-	// Limit errors (logic = exit 3)
+	// Limit errors (validation = exit 6)
 	DEPTH_EXCEEDED
 	CHALLENGE_LIMIT_EXCEEDED
 	REFINEMENT_LIMIT_EXCEEDED
 
-	// Extraction errors (logic = exit 3)
+	// Extraction errors (validation = exit 6)
 	EXTRACTION_INVALID
+
+	// Blocked by dependencies that are not yet validated (exit 5)
+	DEPENDENCIES_UNVALIDATED
+
+	// Proof modified by another process since state was loaded (conflict = exit 4)
+	CONCURRENT_MODIFICATION
+
+	// Blocked by unresolved blocking challenges (exit 5)
+	BLOCKING_CHALLENGES
+
+	// No node is available to claim right now (conflict = exit 4)
+	NO_AVAILABLE_NODES
+
+	// Mutation attempted through a read-only service (validation = exit 6)
+	READ_ONLY
+
+	// Reclaim attempted before the current claim expired (conflict = exit 4)
+	CLAIM_NOT_EXPIRED
 )
 
 // errorCodeNames maps error codes to their string representations.
@@ -81,6 +104,8 @@ var errorCodeNames = map[ErrorCode]string{
 	DEF_NOT_FOUND:               "DEF_NOT_FOUND",
 	ASSUMPTION_NOT_FOUND:        "ASSUMPTION_NOT_FOUND",
 	EXTERNAL_NOT_FOUND:          "EXTERNAL_NOT_FOUND",
+	LEMMA_NOT_FOUND:             "LEMMA_NOT_FOUND",
+	CHECKPOINT_NOT_FOUND:        "CHECKPOINT_NOT_FOUND",
 	SCOPE_VIOLATION:             "SCOPE_VIOLATION",
 	SCOPE_UNCLOSED:              "SCOPE_UNCLOSED",
 	DEPENDENCY_CYCLE:            "DEPENDENCY_CYCLE",
@@ -91,6 +116,12 @@ var errorCodeNames = map[ErrorCode]string{
 	CHALLENGE_LIMIT_EXCEEDED:    "CHALLENGE_LIMIT_EXCEEDED",
 	REFINEMENT_LIMIT_EXCEEDED:   "REFINEMENT_LIMIT_EXCEEDED",
 	EXTRACTION_INVALID:          "EXTRACTION_INVALID",
+	DEPENDENCIES_UNVALIDATED:    "DEPENDENCIES_UNVALIDATED",
+	CONCURRENT_MODIFICATION:     "CONCURRENT_MODIFICATION",
+	BLOCKING_CHALLENGES:         "BLOCKING_CHALLENGES",
+	NO_AVAILABLE_NODES:          "NO_AVAILABLE_NODES",
+	READ_ONLY:                   "READ_ONLY",
+	CLAIM_NOT_EXPIRED:           "CLAIM_NOT_EXPIRED",
 }
 
 // String returns the string representation of an ErrorCode.
@@ -101,29 +132,66 @@ func (c ErrorCode) String() string {
 	return ""
 }
 
-// ExitCode returns the exit code for this error code.
-// Exit codes follow the spec:
-// - 1 = retriable errors
-// - 2 = blocked errors
-// - 3 = logic errors
-// - 4 = corruption errors
+// Process exit codes, one per error category. Scripts branch on these
+// values via $?, so changing one is a breaking change that needs a
+// migration note, as the move from the earlier scheme (1 retriable, 2
+// blocked, 3 logic, 4 corruption) to these categories got in
+// docs/cli-reference.md. Exit code 2 is not used, so that it cannot be
+// mistaken for the blocked code of earlier releases.
+const (
+	// ExitOK is returned when a command succeeds.
+	ExitOK = 0
+
+	// ExitGeneric is returned for errors that carry no ErrorCode, such as a
+	// malformed flag or a failed file read.
+	ExitGeneric = 1
+
+	// ExitNotFound is returned when a node, challenge, definition or other
+	// entity named by the command does not exist.
+	ExitNotFound = 3
+
+	// ExitConflict is returned when another agent got there first: the node
+	// is claimed by someone else, the claim has not expired, or the proof
+	// changed since it was loaded. Retrying after reloading may succeed.
+	ExitConflict = 4
+
+	// ExitBlocked is returned when work cannot proceed until something else
+	// changes, such as open challenges or unvalidated dependencies.
+	ExitBlocked = 5
+
+	// ExitValidation is returned for invalid input, scope violations and
+	// exceeded limits. Retrying the same command will fail again.
+	ExitValidation = 6
+
+	// ExitCorruption is returned when stored data fails an integrity check.
+	ExitCorruption = 7
+)
+
+// ExitCode returns the exit code of this error code's category.
+// Codes outside every category return ExitGeneric.
 func (c ErrorCode) ExitCode() int {
 	switch c {
-	// Exit 1: retriable
-	case ALREADY_CLAIMED, NOT_CLAIM_HOLDER, VALIDATION_INVARIANT_FAILED:
-		return 1
+	case NODE_NOT_FOUND, PARENT_NOT_FOUND, CHALLENGE_NOT_FOUND, DEF_NOT_FOUND, ASSUMPTION_NOT_FOUND,
+		EXTERNAL_NOT_FOUND, LEMMA_NOT_FOUND, CHECKPOINT_NOT_FOUND:
+		return ExitNotFound
+
+	case ALREADY_CLAIMED, NOT_CLAIM_HOLDER, CLAIM_NOT_EXPIRED, CONCURRENT_MODIFICATION, NO_AVAILABLE_NODES:
+		return ExitConflict
 
-	// Exit 2: blocked
-	case NODE_BLOCKED:
-		return 2
+	case NODE_BLOCKED, DEPENDENCIES_UNVALIDATED, BLOCKING_CHALLENGES:
+		return ExitBlocked
+
+	case INVALID_PARENT, INVALID_TYPE, INVALID_INFERENCE, INVALID_TARGET, EMPTY_INPUT, INVALID_STATE,
+		ALREADY_EXISTS, INVALID_TIMEOUT, SCOPE_VIOLATION, SCOPE_UNCLOSED, DEPENDENCY_CYCLE,
+		VALIDATION_INVARIANT_FAILED, DEPTH_EXCEEDED, CHALLENGE_LIMIT_EXCEEDED, REFINEMENT_LIMIT_EXCEEDED,
+		EXTRACTION_INVALID, READ_ONLY:
+		return ExitValidation
 
-	// Exit 4: corruption
 	case CONTENT_HASH_MISMATCH, LEDGER_INCONSISTENT:
-		return 4
+		return ExitCorruption
 
-	// Exit 3: logic errors (all others)
 	default:
-		return 3
+		return ExitGeneric
 	}
 }
 
@@ -175,6 +243,13 @@ func (e *AFError) Error() string {
 	return fmt.Sprintf("%s: %s", e.code.String(), e.message)
 }
 
+// Code returns the stable string form of the error's code (e.g.
+// "NODE_NOT_FOUND"), for callers that need to branch on the error class
+// without matching the message.
+func (e *AFError) Code() string {
+	return e.code.String()
+}
+
 // Is implements errors.Is comparison.
 // Two AFErrors are considered equal if they have the same error code.
 func (e *AFError) Is(target error) bool {
@@ -205,7 +280,14 @@ func Code(err error) ErrorCode {
 	return ErrorCode(0)
 }
 
-// IsRetriable returns true if the error is retriable (exit code 1).
+// CodeName returns the stable string code of the AFError in err's chain
+// (e.g. "NODE_NOT_FOUND"), or "" if err is nil or not an AFError.
+func CodeName(err error) string {
+	return Code(err).String()
+}
+
+// IsRetriable returns true if the error is a conflict (ExitConflict) that
+// retrying after reloading may resolve.
 func IsRetriable(err error) bool {
 	if err == nil {
 		return false
@@ -214,10 +296,10 @@ func IsRetriable(err error) bool {
 	if code == ErrorCode(0) {
 		return false
 	}
-	return code.ExitCode() == 1
+	return code.ExitCode() == ExitConflict
 }
 
-// IsBlocked returns true if the error indicates a blocked state (exit code 2).
+// IsBlocked returns true if the error indicates a blocked state (ExitBlocked).
 func IsBlocked(err error) bool {
 	if err == nil {
 		return false
@@ -226,10 +308,10 @@ func IsBlocked(err error) bool {
 	if code == ErrorCode(0) {
 		return false
 	}
-	return code.ExitCode() == 2
+	return code.ExitCode() == ExitBlocked
 }
 
-// IsCorruption returns true if the error indicates data corruption (exit code 4).
+// IsCorruption returns true if the error indicates data corruption (ExitCorruption).
 func IsCorruption(err error) bool {
 	if err == nil {
 		return false
@@ -238,20 +320,21 @@ func IsCorruption(err error) bool {
 	if code == ErrorCode(0) {
 		return false
 	}
-	return code.ExitCode() == 4
+	return code.ExitCode() == ExitCorruption
 }
 
 // ExitCode returns the appropriate exit code for an error.
-// Returns 0 for nil, 1 for non-AFError errors, or the error code's exit code.
+// Returns ExitOK for nil, ExitGeneric for errors without an ErrorCode, or the
+// exit code of the error code's category.
 func ExitCode(err error) int {
 	if err == nil {
-		return 0
+		return ExitOK
 	}
 
 	code := Code(err)
 	if code == ErrorCode(0) {
-		// Non-AFError defaults to exit code 1 (retriable)
-		return 1
+		// Errors without a code default to ExitGeneric
+		return ExitGeneric
 	}
 
 	return code.ExitCode()
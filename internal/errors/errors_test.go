@@ -48,6 +48,17 @@ func TestErrorCodesExist(t *testing.T) {
 
 		// Extraction errors
 		{"EXTRACTION_INVALID", EXTRACTION_INVALID, "EXTRACTION_INVALID"},
+
+		// Dependency errors
+		{"DEPENDENCIES_UNVALIDATED", DEPENDENCIES_UNVALIDATED, "DEPENDENCIES_UNVALIDATED"},
+
+		// Service errors
+		{"CONCURRENT_MODIFICATION", CONCURRENT_MODIFICATION, "CONCURRENT_MODIFICATION"},
+		{"BLOCKING_CHALLENGES", BLOCKING_CHALLENGES, "BLOCKING_CHALLENGES"},
+		{"LEMMA_NOT_FOUND", LEMMA_NOT_FOUND, "LEMMA_NOT_FOUND"},
+		{"NO_AVAILABLE_NODES", NO_AVAILABLE_NODES, "NO_AVAILABLE_NODES"},
+		{"READ_ONLY", READ_ONLY, "READ_ONLY"},
+		{"CHECKPOINT_NOT_FOUND", CHECKPOINT_NOT_FOUND, "CHECKPOINT_NOT_FOUND"},
 	}
 
 	for _, tt := range tests {
@@ -59,45 +70,75 @@ func TestErrorCodesExist(t *testing.T) {
 	}
 }
 
-// TestExitCodes verifies exit codes are correctly mapped per the spec:
-// - Exit code 1 = retriable errors
-// - Exit code 2 = blocked errors
-// - Exit code 3 = logic errors
-// - Exit code 4 = corruption errors
+// TestExitCodes verifies every error code maps to the exit code of its
+// category:
+// - Exit code 3 = not found errors
+// - Exit code 4 = conflict errors
+// - Exit code 5 = blocked errors
+// - Exit code 6 = validation errors
+// - Exit code 7 = corruption errors
 func TestExitCodes(t *testing.T) {
 	tests := []struct {
 		name     string
 		code     ErrorCode
 		exitCode int
 	}{
-		// Exit code 1 = retriable errors
-		{"ALREADY_CLAIMED is retriable", ALREADY_CLAIMED, 1},
-		{"NOT_CLAIM_HOLDER is retriable", NOT_CLAIM_HOLDER, 1},
-		{"VALIDATION_INVARIANT_FAILED is retriable", VALIDATION_INVARIANT_FAILED, 1},
-
-		// Exit code 2 = blocked errors
-		{"NODE_BLOCKED is blocked", NODE_BLOCKED, 2},
-
-		// Exit code 3 = logic errors
-		{"INVALID_PARENT is logic error", INVALID_PARENT, 3},
-		{"INVALID_TYPE is logic error", INVALID_TYPE, 3},
-		{"INVALID_INFERENCE is logic error", INVALID_INFERENCE, 3},
-		{"INVALID_TARGET is logic error", INVALID_TARGET, 3},
-		{"CHALLENGE_NOT_FOUND is logic error", CHALLENGE_NOT_FOUND, 3},
-		{"DEF_NOT_FOUND is logic error", DEF_NOT_FOUND, 3},
-		{"ASSUMPTION_NOT_FOUND is logic error", ASSUMPTION_NOT_FOUND, 3},
-		{"EXTERNAL_NOT_FOUND is logic error", EXTERNAL_NOT_FOUND, 3},
-		{"SCOPE_VIOLATION is logic error", SCOPE_VIOLATION, 3},
-		{"SCOPE_UNCLOSED is logic error", SCOPE_UNCLOSED, 3},
-		{"DEPENDENCY_CYCLE is logic error", DEPENDENCY_CYCLE, 3},
-		{"DEPTH_EXCEEDED is logic error", DEPTH_EXCEEDED, 3},
-		{"CHALLENGE_LIMIT_EXCEEDED is logic error", CHALLENGE_LIMIT_EXCEEDED, 3},
-		{"REFINEMENT_LIMIT_EXCEEDED is logic error", REFINEMENT_LIMIT_EXCEEDED, 3},
-		{"EXTRACTION_INVALID is logic error", EXTRACTION_INVALID, 3},
-
-		// Exit code 4 = corruption errors
-		{"CONTENT_HASH_MISMATCH is corruption", CONTENT_HASH_MISMATCH, 4},
-		{"LEDGER_INCONSISTENT is corruption", LEDGER_INCONSISTENT, 4},
+		// Exit code 3 = not found errors
+		{"NODE_NOT_FOUND is not found", NODE_NOT_FOUND, 3},
+		{"PARENT_NOT_FOUND is not found", PARENT_NOT_FOUND, 3},
+		{"CHALLENGE_NOT_FOUND is not found", CHALLENGE_NOT_FOUND, 3},
+		{"DEF_NOT_FOUND is not found", DEF_NOT_FOUND, 3},
+		{"ASSUMPTION_NOT_FOUND is not found", ASSUMPTION_NOT_FOUND, 3},
+		{"EXTERNAL_NOT_FOUND is not found", EXTERNAL_NOT_FOUND, 3},
+		{"LEMMA_NOT_FOUND is not found", LEMMA_NOT_FOUND, 3},
+		{"CHECKPOINT_NOT_FOUND is not found", CHECKPOINT_NOT_FOUND, 3},
+
+		// Exit code 4 = conflict errors
+		{"ALREADY_CLAIMED is conflict", ALREADY_CLAIMED, 4},
+		{"NOT_CLAIM_HOLDER is conflict", NOT_CLAIM_HOLDER, 4},
+		{"CLAIM_NOT_EXPIRED is conflict", CLAIM_NOT_EXPIRED, 4},
+		{"CONCURRENT_MODIFICATION is conflict", CONCURRENT_MODIFICATION, 4},
+		{"NO_AVAILABLE_NODES is conflict", NO_AVAILABLE_NODES, 4},
+
+		// Exit code 5 = blocked errors
+		{"NODE_BLOCKED is blocked", NODE_BLOCKED, 5},
+		{"DEPENDENCIES_UNVALIDATED is blocked", DEPENDENCIES_UNVALIDATED, 5},
+		{"BLOCKING_CHALLENGES is blocked", BLOCKING_CHALLENGES, 5},
+
+		// Exit code 6 = validation errors
+		{"INVALID_PARENT is validation", INVALID_PARENT, 6},
+		{"INVALID_TYPE is validation", INVALID_TYPE, 6},
+		{"INVALID_INFERENCE is validation", INVALID_INFERENCE, 6},
+		{"INVALID_TARGET is validation", INVALID_TARGET, 6},
+		{"EMPTY_INPUT is validation", EMPTY_INPUT, 6},
+		{"INVALID_STATE is validation", INVALID_STATE, 6},
+		{"ALREADY_EXISTS is validation", ALREADY_EXISTS, 6},
+		{"INVALID_TIMEOUT is validation", INVALID_TIMEOUT, 6},
+		{"SCOPE_VIOLATION is validation", SCOPE_VIOLATION, 6},
+		{"SCOPE_UNCLOSED is validation", SCOPE_UNCLOSED, 6},
+		{"DEPENDENCY_CYCLE is validation", DEPENDENCY_CYCLE, 6},
+		{"VALIDATION_INVARIANT_FAILED is validation", VALIDATION_INVARIANT_FAILED, 6},
+		{"DEPTH_EXCEEDED is validation", DEPTH_EXCEEDED, 6},
+		{"CHALLENGE_LIMIT_EXCEEDED is validation", CHALLENGE_LIMIT_EXCEEDED, 6},
+		{"REFINEMENT_LIMIT_EXCEEDED is validation", REFINEMENT_LIMIT_EXCEEDED, 6},
+		{"EXTRACTION_INVALID is validation", EXTRACTION_INVALID, 6},
+		{"READ_ONLY is validation", READ_ONLY, 6},
+
+		// Exit code 7 = corruption errors
+		{"CONTENT_HASH_MISMATCH is corruption", CONTENT_HASH_MISMATCH, 7},
+		{"LEDGER_INCONSISTENT is corruption", LEDGER_INCONSISTENT, 7},
+	}
+
+	// Every error code must be listed, so a new code cannot silently fall
+	// through to ExitGeneric
+	listed := make(map[ErrorCode]bool, len(tests))
+	for _, tt := range tests {
+		listed[tt.code] = true
+	}
+	for code := range errorCodeNames {
+		if !listed[code] {
+			t.Errorf("error code %s has no expected exit code", code)
+		}
 	}
 
 	for _, tt := range tests {
@@ -292,6 +333,24 @@ func TestCodeFromNonAFError(t *testing.T) {
 	})
 }
 
+// TestCodeName tests the stable string code of errors
+func TestCodeName(t *testing.T) {
+	if got := New(NODE_NOT_FOUND, "msg").Code(); got != "NODE_NOT_FOUND" {
+		t.Errorf("AFError.Code() = %q, want NODE_NOT_FOUND", got)
+	}
+
+	wrapped := fmt.Errorf("context: %w", New(BLOCKING_CHALLENGES, "msg"))
+	if got := CodeName(wrapped); got != "BLOCKING_CHALLENGES" {
+		t.Errorf("CodeName(wrapped) = %q, want BLOCKING_CHALLENGES", got)
+	}
+	if got := CodeName(fmt.Errorf("standard error")); got != "" {
+		t.Errorf("CodeName(non-AFError) = %q, want empty", got)
+	}
+	if got := CodeName(nil); got != "" {
+		t.Errorf("CodeName(nil) = %q, want empty", got)
+	}
+}
+
 // TestNewf tests creating errors with formatted messages
 func TestNewf(t *testing.T) {
 	tests := []struct {
@@ -337,7 +396,8 @@ func TestIsRetriable(t *testing.T) {
 	}{
 		{"ALREADY_CLAIMED is retriable", New(ALREADY_CLAIMED, ""), true},
 		{"NOT_CLAIM_HOLDER is retriable", New(NOT_CLAIM_HOLDER, ""), true},
-		{"VALIDATION_INVARIANT_FAILED is retriable", New(VALIDATION_INVARIANT_FAILED, ""), true},
+		{"CONCURRENT_MODIFICATION is retriable", New(CONCURRENT_MODIFICATION, ""), true},
+		{"VALIDATION_INVARIANT_FAILED is not retriable", New(VALIDATION_INVARIANT_FAILED, ""), false},
 		{"NODE_BLOCKED is not retriable", New(NODE_BLOCKED, ""), false},
 		{"INVALID_TYPE is not retriable", New(INVALID_TYPE, ""), false},
 		{"LEDGER_INCONSISTENT is not retriable", New(LEDGER_INCONSISTENT, ""), false},
@@ -407,8 +467,9 @@ func TestExitCodeFunc(t *testing.T) {
 		err      error
 		wantCode int
 	}{
-		{"AFError returns correct exit code", New(NODE_BLOCKED, ""), 2},
-		{"wrapped AFError returns correct exit code", Wrap(New(LEDGER_INCONSISTENT, ""), "ctx"), 4},
+		{"AFError returns correct exit code", New(NODE_BLOCKED, ""), 5},
+		{"wrapped AFError returns correct exit code", Wrap(New(LEDGER_INCONSISTENT, ""), "ctx"), 7},
+		{"wrapped standard error returns 1", Wrap(fmt.Errorf("standard"), "ctx"), 1},
 		{"nil returns 0", nil, 0},
 		{"standard error returns 1", fmt.Errorf("standard"), 1},
 	}
@@ -423,6 +484,32 @@ func TestExitCodeFunc(t *testing.T) {
 	}
 }
 
+// TestExitCodeConstants pins the numeric exit code contract. Scripts branch
+// on these values, so changing one is a breaking change.
+func TestExitCodeConstants(t *testing.T) {
+	tests := []struct {
+		name  string
+		got   int
+		value int
+	}{
+		{"ExitOK", ExitOK, 0},
+		{"ExitGeneric", ExitGeneric, 1},
+		{"ExitNotFound", ExitNotFound, 3},
+		{"ExitConflict", ExitConflict, 4},
+		{"ExitBlocked", ExitBlocked, 5},
+		{"ExitValidation", ExitValidation, 6},
+		{"ExitCorruption", ExitCorruption, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.value {
+				t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.value)
+			}
+		})
+	}
+}
+
 // TestSanitizePaths tests the SanitizePaths function that removes sensitive file paths from errors
 func TestSanitizePaths(t *testing.T) {
 	tests := []struct {
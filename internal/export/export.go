@@ -3,30 +3,59 @@ package export
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/state"
-	"github.com/tobias/vibefeld/internal/types"
 )
 
+// formats lists the supported export format names, including aliases.
+var formats = []string{"markdown", "md", "latex", "tex", "dot", "mermaid", "csv"}
+
+// Formats returns the supported export format names, including aliases,
+// in a consistent order.
+func Formats() []string {
+	result := make([]string, len(formats))
+	copy(result, formats)
+	return result
+}
+
 // ValidateFormat checks if the given format string is valid.
-// Valid formats: markdown, md, latex, tex (case-insensitive).
+// Valid formats: markdown, md, latex, tex, dot, mermaid, csv (case-insensitive).
 func ValidateFormat(format string) error {
 	f := strings.ToLower(format)
-	switch f {
-	case "markdown", "md", "latex", "tex":
-		return nil
-	default:
-		return fmt.Errorf("invalid export format %q: must be one of: markdown, md, latex, tex", format)
+	for _, valid := range formats {
+		if f == valid {
+			return nil
+		}
 	}
+	return fmt.Errorf("invalid export format %q: must be one of: %s", format, strings.Join(formats, ", "))
+}
+
+// Options controls format-specific export behavior.
+// The zero value gives the default output for every format.
+type Options struct {
+	// IncludeRefuted keeps refuted nodes (and their subtrees) in LaTeX
+	// output, marked as refuted, and refuted and archived nodes in Markdown
+	// output. By default they are omitted.
+	IncludeRefuted bool
+
+	// IncludeStatements adds a statement column to CSV output.
+	IncludeStatements bool
 }
 
 // Export exports the proof state to the specified format.
 // Returns an error if the format is invalid.
 func Export(s *state.State, format string) (string, error) {
+	return ExportWithOptions(s, format, Options{})
+}
+
+// ExportWithOptions exports the proof state to the specified format,
+// applying opts where the format supports them.
+// Returns an error if the format is invalid.
+func ExportWithOptions(s *state.State, format string, opts Options) (string, error) {
 	if err := ValidateFormat(format); err != nil {
 		return "", err
 	}
@@ -34,75 +63,117 @@ func Export(s *state.State, format string) (string, error) {
 	f := strings.ToLower(format)
 	switch f {
 	case "markdown", "md":
-		return ToMarkdown(s), nil
+		return ToMarkdownWithOptions(s, opts), nil
 	case "latex", "tex":
-		return ToLaTeX(s), nil
+		return ToLaTeXWithOptions(s, opts), nil
+	case "dot":
+		return ToDOT(s), nil
+	case "mermaid":
+		return ToMermaid(s), nil
+	case "csv":
+		return ToCSVWithOptions(s, opts), nil
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
-// ToMarkdown exports the proof state to Markdown format.
+// ToMarkdown exports the proof state to Markdown format with default options.
 func ToMarkdown(s *state.State) string {
+	return ToMarkdownWithOptions(s, Options{})
+}
+
+// ToMarkdownWithOptions exports the proof state as a linked Markdown
+// document with a table of contents, one section per node, and dependency
+// links between sections (see render.RenderProofMarkdown). Refuted and
+// archived nodes and their subtrees are omitted unless opts.IncludeRefuted
+// is set.
+func ToMarkdownWithOptions(s *state.State, opts Options) string {
 	if s == nil {
 		return "# No Proof Data\n\nNo proof data available to export.\n"
 	}
 
-	nodes := s.AllNodes()
+	nodes := s.AllNodesSorted()
 	if len(nodes) == 0 {
 		return "# No Proof Data\n\nNo nodes in the proof tree.\n"
 	}
 
-	// Sort nodes by ID for deterministic output
-	sortedNodes := sortNodesByID(nodes)
-
-	var sb strings.Builder
-	sb.WriteString("# Proof Export\n\n")
-
-	// Build tree structure
-	root := buildTree(sortedNodes)
-	if root != nil {
-		renderMarkdownNode(&sb, root, 1)
-	}
-
-	return sb.String()
+	return render.RenderProofMarkdown(render.StateToDependencyGraphView(s), opts.IncludeRefuted)
 }
 
-// ToLaTeX exports the proof state to LaTeX format.
+// ToLaTeX exports the proof state to LaTeX format with default options.
 func ToLaTeX(s *state.State) string {
+	return ToLaTeXWithOptions(s, Options{})
+}
+
+// ToLaTeXWithOptions exports the proof state to LaTeX format.
+//
+// The root node is stated as a theorem, and the children of every node form
+// a proof environment nested inside the proof of their parent, following the
+// NodeID hierarchy. Each node's body is its LaTeX field, falling back to its
+// escaped statement; its inference rule is set as a margin note. Admitted
+// nodes are marked as such. Refuted nodes and their subtrees are omitted
+// unless opts.IncludeRefuted is set.
+func ToLaTeXWithOptions(s *state.State, opts Options) string {
 	if s == nil {
 		return latexDocument("No proof data available to export.")
 	}
 
-	nodes := s.AllNodes()
+	nodes := s.AllNodesSorted()
 	if len(nodes) == 0 {
 		return latexDocument("No nodes in the proof tree.")
 	}
 
-	// Sort nodes by ID for deterministic output
-	sortedNodes := sortNodesByID(nodes)
-
 	var sb strings.Builder
 	sb.WriteString("\\documentclass{article}\n")
 	sb.WriteString("\\usepackage[utf8]{inputenc}\n")
 	sb.WriteString("\\usepackage{amsmath}\n")
 	sb.WriteString("\\usepackage{amssymb}\n")
-	sb.WriteString("\\usepackage{enumitem}\n\n")
+	sb.WriteString("\\usepackage{amsthm}\n")
+	sb.WriteString("\\usepackage{xcolor}\n\n")
+	sb.WriteString("\\newtheorem{theorem}{Theorem}\n\n")
 	sb.WriteString("\\title{Proof Export}\n")
 	sb.WriteString("\\date{}\n\n")
 	sb.WriteString("\\begin{document}\n")
 	sb.WriteString("\\maketitle\n\n")
 
 	// Build tree structure
-	root := buildTree(sortedNodes)
-	if root != nil {
-		renderLaTeXNode(&sb, root, 0)
+	root := buildTree(nodes)
+	if root != nil && includeInLaTeX(root.node, opts) {
+		renderLaTeXTheorem(&sb, root, opts)
+	} else {
+		sb.WriteString("No nodes to export.\n")
 	}
 
 	sb.WriteString("\n\\end{document}\n")
 	return sb.String()
 }
 
+// ToDOT exports the proof dependency graph to Graphviz DOT format.
+// A nil or empty state produces an empty digraph.
+func ToDOT(s *state.State) string {
+	return render.RenderDependencyGraphDOT(render.StateToDependencyGraphView(s))
+}
+
+// ToMermaid exports the proof tree and dependency graph as a Mermaid
+// flowchart for embedding in Markdown documents.
+// A nil or empty state produces a flowchart with no nodes.
+func ToMermaid(s *state.State) string {
+	return render.RenderDependencyGraphMermaid(render.StateToDependencyGraphView(s))
+}
+
+// ToCSV exports the status of every node as CSV for spreadsheet analysis,
+// one row per node sorted by ID.
+// A nil or empty state produces just the header row.
+func ToCSV(s *state.State) string {
+	return ToCSVWithOptions(s, Options{})
+}
+
+// ToCSVWithOptions exports node status as CSV, adding a statement column
+// if opts.IncludeStatements is set.
+func ToCSVWithOptions(s *state.State, opts Options) string {
+	return render.RenderNodeStatusCSV(render.StateToNodeStatusTableView(s), opts.IncludeStatements)
+}
+
 // =============================================================================
 // Tree Building
 // =============================================================================
@@ -113,7 +184,8 @@ type treeNode struct {
 	children []*treeNode
 }
 
-// buildTree builds a hierarchical tree from a flat list of nodes.
+// buildTree builds a hierarchical tree from a flat list of nodes sorted by
+// ID, as returned by State.AllNodesSorted. Children keep that order.
 func buildTree(nodes []*node.Node) *treeNode {
 	if len(nodes) == 0 {
 		return nil
@@ -147,146 +219,85 @@ func buildTree(nodes []*node.Node) *treeNode {
 		}
 	}
 
-	// Sort children at each level for deterministic output
-	sortTreeChildren(root)
-
 	return root
 }
 
-// sortTreeChildren recursively sorts children at each level by their ID.
-func sortTreeChildren(tn *treeNode) {
-	if tn == nil {
-		return
-	}
-
-	sort.Slice(tn.children, func(i, j int) bool {
-		return compareNodeIDs(tn.children[i].node.ID, tn.children[j].node.ID) < 0
-	})
-
-	for _, child := range tn.children {
-		sortTreeChildren(child)
-	}
-}
-
 // =============================================================================
-// Markdown Rendering
+// LaTeX Rendering
 // =============================================================================
 
-// renderMarkdownNode renders a node and its children in Markdown format.
-func renderMarkdownNode(sb *strings.Builder, tn *treeNode, depth int) {
-	if tn == nil || tn.node == nil {
-		return
-	}
-
+// renderLaTeXTheorem renders the root node as a theorem followed by the
+// proof built from its children.
+func renderLaTeXTheorem(sb *strings.Builder, tn *treeNode, opts Options) {
 	n := tn.node
 
-	// Create header level based on depth (## for root, ### for children, etc.)
-	headerLevel := depth + 1
-	if headerLevel > 6 {
-		headerLevel = 6 // Max markdown header level
-	}
-	header := strings.Repeat("#", headerLevel)
-
-	// Write node header
-	sb.WriteString(fmt.Sprintf("%s Node %s\n\n", header, n.ID.String()))
-
-	// Write node details
-	sb.WriteString(fmt.Sprintf("**Statement:** %s\n\n", n.Statement))
-	sb.WriteString(fmt.Sprintf("**Type:** %s\n\n", formatNodeType(n.Type)))
-	sb.WriteString(fmt.Sprintf("**Inference:** %s\n\n", formatInference(n.Inference)))
-	sb.WriteString(fmt.Sprintf("**Status:** %s\n\n", n.EpistemicState))
+	writeLaTeXNodeComment(sb, n, "")
+	sb.WriteString("\\begin{theorem}\n")
+	sb.WriteString(latexNodeBody(n))
+	sb.WriteString("\n\\end{theorem}\n")
 
-	if n.TaintState != "" {
-		sb.WriteString(fmt.Sprintf("**Taint:** %s\n\n", n.TaintState))
-	}
+	renderLaTeXProof(sb, tn, opts, "")
+}
 
-	// Render children
+// renderLaTeXProof renders the children of tn, if any survive filtering,
+// inside a proof environment. Each child's own children are rendered as a
+// proof nested within it.
+func renderLaTeXProof(sb *strings.Builder, tn *treeNode, opts Options, indent string) {
+	var children []*treeNode
 	for _, child := range tn.children {
-		renderMarkdownNode(sb, child, depth+1)
+		if includeInLaTeX(child.node, opts) {
+			children = append(children, child)
+		}
 	}
-}
-
-// =============================================================================
-// LaTeX Rendering
-// =============================================================================
-
-// renderLaTeXNode renders a node and its children in LaTeX format.
-func renderLaTeXNode(sb *strings.Builder, tn *treeNode, depth int) {
-	if tn == nil || tn.node == nil {
+	if len(children) == 0 {
 		return
 	}
 
-	n := tn.node
-
-	// Use sections for top-level, subsections for children
-	var sectionCmd string
-	switch depth {
-	case 0:
-		sectionCmd = "\\section*"
-	case 1:
-		sectionCmd = "\\subsection*"
-	case 2:
-		sectionCmd = "\\subsubsection*"
-	default:
-		sectionCmd = "\\paragraph*"
-	}
-
-	// Write section header
-	sb.WriteString(fmt.Sprintf("%s{Node %s}\n\n", sectionCmd, escapeLatex(n.ID.String())))
-
-	// Write node content
-	sb.WriteString(fmt.Sprintf("\\textbf{Statement:} %s\n\n", escapeLatex(n.Statement)))
-	sb.WriteString(fmt.Sprintf("\\textbf{Type:} %s\n\n", escapeLatex(formatNodeType(n.Type))))
-	sb.WriteString(fmt.Sprintf("\\textbf{Inference:} %s\n\n", escapeLatex(formatInference(n.Inference))))
-	sb.WriteString(fmt.Sprintf("\\textbf{Status:} %s\n\n", escapeLatex(string(n.EpistemicState))))
-
-	if n.TaintState != "" {
-		sb.WriteString(fmt.Sprintf("\\textbf{Taint:} %s\n\n", escapeLatex(string(n.TaintState))))
-	}
-
-	// Render children
-	if len(tn.children) > 0 {
-		sb.WriteString("\\begin{enumerate}\n")
-		for _, child := range tn.children {
-			sb.WriteString("\\item ")
-			renderLaTeXNodeAsItem(sb, child, depth+1)
+	inner := indent + "  "
+	sb.WriteString(fmt.Sprintf("\n%s\\begin{proof}\n", indent))
+	for i, child := range children {
+		if i > 0 {
+			sb.WriteString("\n")
 		}
-		sb.WriteString("\\end{enumerate}\n")
+		writeLaTeXNodeComment(sb, child.node, inner)
+		sb.WriteString(fmt.Sprintf("%s\\textbf{%s.} %s\n", inner, escapeLatex(child.node.ID.String()), latexNodeBody(child.node)))
+		renderLaTeXProof(sb, child, opts, inner)
 	}
+	sb.WriteString(fmt.Sprintf("%s\\end{proof}\n", indent))
 }
 
-// renderLaTeXNodeAsItem renders a node as a list item (for nested children).
-func renderLaTeXNodeAsItem(sb *strings.Builder, tn *treeNode, depth int) {
-	if tn == nil || tn.node == nil {
-		return
-	}
-
-	n := tn.node
-
-	// Write node content as item
-	sb.WriteString(fmt.Sprintf("\\textbf{Node %s:} %s\n\n",
-		escapeLatex(n.ID.String()),
-		escapeLatex(n.Statement)))
+// includeInLaTeX reports whether n belongs in the LaTeX export.
+// Refuted nodes are left out unless opts.IncludeRefuted is set.
+func includeInLaTeX(n *node.Node, opts Options) bool {
+	return n.EpistemicState != schema.EpistemicRefuted || opts.IncludeRefuted
+}
 
-	sb.WriteString(fmt.Sprintf("  Type: %s, Inference: %s, Status: %s",
-		escapeLatex(formatNodeType(n.Type)),
-		escapeLatex(formatInference(n.Inference)),
-		escapeLatex(string(n.EpistemicState))))
+// writeLaTeXNodeComment writes a LaTeX comment identifying the node with
+// its type and epistemic state.
+func writeLaTeXNodeComment(sb *strings.Builder, n *node.Node, indent string) {
+	sb.WriteString(fmt.Sprintf("%s%% Node %s (%s, %s)\n", indent, n.ID.String(), formatNodeType(n.Type), n.EpistemicState))
+}
 
-	if n.TaintState != "" {
-		sb.WriteString(fmt.Sprintf(", Taint: %s", escapeLatex(string(n.TaintState))))
+// latexNodeBody returns the body of a node: a state marker for admitted
+// and refuted nodes, the node's LaTeX field or escaped statement, and its
+// inference rule as a margin note.
+func latexNodeBody(n *node.Node) string {
+	var sb strings.Builder
+	switch n.EpistemicState {
+	case schema.EpistemicAdmitted:
+		sb.WriteString("\\textcolor{orange}{[admitted]} ")
+	case schema.EpistemicRefuted:
+		sb.WriteString("\\textcolor{red}{[refuted]} ")
 	}
-	sb.WriteString("\n\n")
-
-	// Render children as nested list
-	if len(tn.children) > 0 {
-		sb.WriteString("\\begin{enumerate}\n")
-		for _, child := range tn.children {
-			sb.WriteString("\\item ")
-			renderLaTeXNodeAsItem(sb, child, depth+1)
-		}
-		sb.WriteString("\\end{enumerate}\n")
+
+	if strings.TrimSpace(n.Latex) != "" {
+		sb.WriteString(n.Latex)
+	} else {
+		sb.WriteString(escapeLatex(n.Statement))
 	}
+
+	sb.WriteString(fmt.Sprintf("\\marginpar{\\footnotesize %s}", escapeLatex(formatInference(n.Inference))))
+	return sb.String()
 }
 
 // =============================================================================
@@ -303,29 +314,24 @@ func latexDocument(content string) string {
 `, content)
 }
 
+// latexEscaper replaces each LaTeX special character in a single pass, so
+// the braces it introduces (e.g. in \textbackslash{}) are not escaped again.
+var latexEscaper = strings.NewReplacer(
+	"\\", "\\textbackslash{}",
+	"{", "\\{",
+	"}", "\\}",
+	"$", "\\$",
+	"&", "\\&",
+	"%", "\\%",
+	"#", "\\#",
+	"_", "\\_",
+	"~", "\\textasciitilde{}",
+	"^", "\\textasciicircum{}",
+)
+
 // escapeLatex escapes special LaTeX characters.
 func escapeLatex(s string) string {
-	// Order matters: backslash first
-	replacements := []struct {
-		old, new string
-	}{
-		{"\\", "\\textbackslash{}"},
-		{"{", "\\{"},
-		{"}", "\\}"},
-		{"$", "\\$"},
-		{"&", "\\&"},
-		{"%", "\\%"},
-		{"#", "\\#"},
-		{"_", "\\_"},
-		{"~", "\\textasciitilde{}"},
-		{"^", "\\textasciicircum{}"},
-	}
-
-	result := s
-	for _, r := range replacements {
-		result = strings.ReplaceAll(result, r.old, r.new)
-	}
-	return result
+	return latexEscaper.Replace(s)
 }
 
 // formatNodeType returns a human-readable node type string.
@@ -338,52 +344,3 @@ func formatInference(i schema.InferenceType) string {
 	return string(i)
 }
 
-// sortNodesByID sorts nodes by their hierarchical ID.
-func sortNodesByID(nodes []*node.Node) []*node.Node {
-	sorted := make([]*node.Node, len(nodes))
-	copy(sorted, nodes)
-
-	sort.Slice(sorted, func(i, j int) bool {
-		return compareNodeIDs(sorted[i].ID, sorted[j].ID) < 0
-	})
-
-	return sorted
-}
-
-// compareNodeIDs compares two NodeIDs lexicographically by their parts.
-// Returns -1 if a < b, 0 if a == b, 1 if a > b.
-func compareNodeIDs(a, b types.NodeID) int {
-	aStr := a.String()
-	bStr := b.String()
-
-	aParts := strings.Split(aStr, ".")
-	bParts := strings.Split(bStr, ".")
-
-	minLen := len(aParts)
-	if len(bParts) < minLen {
-		minLen = len(bParts)
-	}
-
-	for i := 0; i < minLen; i++ {
-		aNum := 0
-		bNum := 0
-		fmt.Sscanf(aParts[i], "%d", &aNum)
-		fmt.Sscanf(bParts[i], "%d", &bNum)
-
-		if aNum < bNum {
-			return -1
-		}
-		if aNum > bNum {
-			return 1
-		}
-	}
-
-	// Same prefix - shorter ID comes first
-	if len(aParts) < len(bParts) {
-		return -1
-	}
-	if len(aParts) > len(bParts) {
-		return 1
-	}
-	return 0
-}
@@ -41,6 +41,16 @@ func addTestNode(
 	return n
 }
 
+// mustParse parses a node ID or fails the test.
+func mustParse(t *testing.T, id string) types.NodeID {
+	t.Helper()
+	nodeID, err := types.Parse(id)
+	if err != nil {
+		t.Fatalf("invalid test node ID %q: %v", id, err)
+	}
+	return nodeID
+}
+
 // =============================================================================
 // Markdown Export Tests
 // =============================================================================
@@ -163,6 +173,58 @@ func TestToMarkdown_DeterministicOutput(t *testing.T) {
 	}
 }
 
+// TestToMarkdown_NumericSiblingOrder tests that siblings are ordered by
+// number, so 1.2 comes before 1.10, with each subtree kept together.
+func TestToMarkdown_NumericSiblingOrder(t *testing.T) {
+	s := state.NewState()
+	for _, id := range []string{"1.10", "1.2.1", "1", "1.2", "1.1"} {
+		addTestNode(t, s, id, "Step "+id, schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	}
+
+	result := ToMarkdown(s)
+	last := -1
+	for _, id := range []string{"1", "1.1", "1.2", "1.2.1", "1.10"} {
+		idx := strings.Index(result, "Step "+id+"\n")
+		if idx < 0 {
+			t.Fatalf("ToMarkdown output missing %q:\n%s", "Step "+id, result)
+		}
+		if idx < last {
+			t.Errorf("Step %s is out of order in:\n%s", id, result)
+		}
+		last = idx
+	}
+}
+
+// TestToMarkdownWithOptions_Refuted tests that refuted and archived nodes
+// and their subtrees are left out unless IncludeRefuted is set, and that
+// dependencies link to the sections of the nodes they name.
+func TestToMarkdownWithOptions_Refuted(t *testing.T) {
+	s := state.NewState()
+	addTestNode(t, s, "1", "Root", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	addTestNode(t, s, "1.1", "Kept step", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicValidated, node.TaintClean)
+	addTestNode(t, s, "1.2", "Refuted step", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicRefuted, node.TaintClean)
+	addTestNode(t, s, "1.2.1", "Under refuted", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	addTestNode(t, s, "1.3", "Archived step", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicArchived, node.TaintClean)
+	s.GetNode(mustParse(t, "1.1")).Dependencies = []types.NodeID{mustParse(t, "1")}
+
+	result := ToMarkdown(s)
+	if !strings.Contains(result, "**Depends on:** [1](#node-1)") {
+		t.Errorf("dependency should link to its section:\n%s", result)
+	}
+	for _, stmt := range []string{"Refuted step", "Under refuted", "Archived step"} {
+		if strings.Contains(result, stmt) {
+			t.Errorf("%q should be omitted by default:\n%s", stmt, result)
+		}
+	}
+
+	result = ToMarkdownWithOptions(s, Options{IncludeRefuted: true})
+	for _, stmt := range []string{"Refuted step", "Under refuted", "Archived step"} {
+		if !strings.Contains(result, stmt) {
+			t.Errorf("%q should be included with IncludeRefuted:\n%s", stmt, result)
+		}
+	}
+}
+
 // TestToMarkdown_MultipleNodeTypes tests that different node types are shown.
 func TestToMarkdown_MultipleNodeTypes(t *testing.T) {
 	s := state.NewState()
@@ -293,23 +355,89 @@ func TestToLaTeX_DeterministicOutput(t *testing.T) {
 	}
 }
 
-// TestToLaTeX_UsesEnumerateOrItemize tests that LaTeX uses proper list structure.
-func TestToLaTeX_UsesEnumerateOrItemize(t *testing.T) {
+// TestToLaTeX_NestsProofEnvironments tests that each node's children form a
+// proof environment nested inside the proof of their parent.
+func TestToLaTeX_NestsProofEnvironments(t *testing.T) {
 	s := state.NewState()
 	addTestNode(t, s, "1", "Root", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
 	addTestNode(t, s, "1.1", "Child 1", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicValidated, node.TaintClean)
+	addTestNode(t, s, "1.1.1", "Grandchild", schema.NodeTypeClaim, schema.InferenceByDefinition, schema.EpistemicValidated, node.TaintClean)
 	addTestNode(t, s, "1.2", "Child 2", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicValidated, node.TaintClean)
 
 	result := ToLaTeX(s)
 
-	// Should use either enumerate or itemize for structure
-	hasListStructure := strings.Contains(result, "\\begin{enumerate}") ||
-		strings.Contains(result, "\\begin{itemize}") ||
-		strings.Contains(result, "\\section") ||
-		strings.Contains(result, "\\subsection")
+	want := `\begin{theorem}
+Root\marginpar{\footnotesize modus\_ponens}
+\end{theorem}
 
-	if !hasListStructure {
-		t.Error("LaTeX output should use proper hierarchical structure (enumerate, itemize, or sections)")
+\begin{proof}
+  % Node 1.1 (claim, validated)
+  \textbf{1.1.} Child 1\marginpar{\footnotesize modus\_ponens}
+
+  \begin{proof}
+    % Node 1.1.1 (claim, validated)
+    \textbf{1.1.1.} Grandchild\marginpar{\footnotesize by\_definition}
+  \end{proof}
+
+  % Node 1.2 (claim, validated)
+  \textbf{1.2.} Child 2\marginpar{\footnotesize modus\_ponens}
+\end{proof}
+`
+	if !strings.Contains(result, want) {
+		t.Errorf("LaTeX output missing nested proof structure:\n%s\nwant substring:\n%s", result, want)
+	}
+}
+
+// TestToLaTeX_PrefersLatexField tests that a node's LaTeX field is used
+// verbatim as its body, with the escaped statement as fallback.
+func TestToLaTeX_PrefersLatexField(t *testing.T) {
+	s := state.NewState()
+	addTestNode(t, s, "1", "Root with x_1", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	n := addTestNode(t, s, "1.1", "x squared is non-negative", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	n.Latex = "$x^2 \\geq 0$"
+
+	result := ToLaTeX(s)
+
+	if !strings.Contains(result, "Root with x\\_1") {
+		t.Error("statement fallback should be escaped")
+	}
+	if !strings.Contains(result, "$x^2 \\geq 0$") {
+		t.Error("LaTeX field should be used verbatim")
+	}
+	if strings.Contains(result, "x squared is non-negative") {
+		t.Error("statement should not be used when the LaTeX field is set")
+	}
+}
+
+// TestToLaTeX_MarksAdmittedAndOmitsRefuted tests the admitted marker and
+// that refuted subtrees are left out unless IncludeRefuted is set.
+func TestToLaTeX_MarksAdmittedAndOmitsRefuted(t *testing.T) {
+	s := state.NewState()
+	addTestNode(t, s, "1", "Root", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	addTestNode(t, s, "1.1", "Taken on faith", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicAdmitted, node.TaintClean)
+	addTestNode(t, s, "1.2", "Wrong turn", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicRefuted, node.TaintClean)
+	addTestNode(t, s, "1.2.1", "Below wrong turn", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+
+	result := ToLaTeX(s)
+	if !strings.Contains(result, "\\textcolor{orange}{[admitted]} Taken on faith") {
+		t.Errorf("admitted node should be marked:\n%s", result)
+	}
+	if strings.Contains(result, "Wrong turn") || strings.Contains(result, "Below wrong turn") {
+		t.Errorf("refuted subtree should be omitted by default:\n%s", result)
+	}
+
+	result = ToLaTeXWithOptions(s, Options{IncludeRefuted: true})
+	if !strings.Contains(result, "\\textcolor{red}{[refuted]} Wrong turn") || !strings.Contains(result, "Below wrong turn") {
+		t.Errorf("refuted subtree should be included with IncludeRefuted:\n%s", result)
+	}
+}
+
+// TestEscapeLatex tests that every special character is escaped exactly once.
+func TestEscapeLatex(t *testing.T) {
+	got := escapeLatex(`a\b {c} $d$ & 5% #1 x_2 ~ ^`)
+	want := `a\textbackslash{}b \{c\} \$d\$ \& 5\% \#1 x\_2 \textasciitilde{} \textasciicircum{}`
+	if got != want {
+		t.Errorf("escapeLatex() = %q, want %q", got, want)
 	}
 }
 
@@ -330,6 +458,9 @@ func TestValidateFormat(t *testing.T) {
 		{"valid tex", "tex", false},
 		{"valid uppercase MARKDOWN", "MARKDOWN", false},
 		{"valid uppercase LATEX", "LATEX", false},
+		{"valid dot", "dot", false},
+		{"valid mermaid", "mermaid", false},
+		{"valid csv", "csv", false},
 		{"invalid xml", "xml", true},
 		{"invalid pdf", "pdf", true},
 		{"invalid empty", "", true},
@@ -368,9 +499,113 @@ func TestExport_UsesCorrectFormat(t *testing.T) {
 		t.Error("LaTeX export should contain \\documentclass")
 	}
 
+	// Test dot format
+	dotResult, err := Export(s, "dot")
+	if err != nil {
+		t.Errorf("Export to dot failed: %v", err)
+	}
+	if !strings.HasPrefix(dotResult, "digraph") {
+		t.Error("DOT export should start with digraph")
+	}
+
+	// Test mermaid format
+	mermaidResult, err := Export(s, "mermaid")
+	if err != nil {
+		t.Errorf("Export to mermaid failed: %v", err)
+	}
+	if !strings.HasPrefix(mermaidResult, "flowchart TD") {
+		t.Error("Mermaid export should start with flowchart TD")
+	}
+
+	// Test csv format
+	csvResult, err := Export(s, "csv")
+	if err != nil {
+		t.Errorf("Export to csv failed: %v", err)
+	}
+	if !strings.HasPrefix(csvResult, "id,depth,type,") {
+		t.Error("CSV export should start with the header row")
+	}
+
 	// Test invalid format
 	_, err = Export(s, "invalid")
 	if err == nil {
 		t.Error("Export should fail for invalid format")
 	}
 }
+
+// =============================================================================
+// DOT Export Tests
+// =============================================================================
+
+// TestToDOT_DependencyEdges tests that dependencies become edges in the graph.
+func TestToDOT_DependencyEdges(t *testing.T) {
+	s := state.NewState()
+	addTestNode(t, s, "1", "Root", schema.NodeTypeClaim, schema.InferenceAssumption, schema.EpistemicPending, node.TaintClean)
+	addTestNode(t, s, "1.1", "Base case", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicValidated, node.TaintClean)
+	step := addTestNode(t, s, "1.2", "Inductive step", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	step.Dependencies = []types.NodeID{mustParse(t, "1.1")}
+	step.ValidationDeps = []types.NodeID{mustParse(t, "1.1")}
+
+	result := ToDOT(s)
+
+	for _, want := range []string{
+		`"1.1" [label="1.1\nBase case", fillcolor=green];`,
+		`"1.2" -> "1.1";`,
+		`"1.2" -> "1.1" [style=dashed];`,
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("DOT export missing %q\ngot:\n%s", want, result)
+		}
+	}
+
+	if ToDOT(s) != result {
+		t.Error("DOT export should be deterministic")
+	}
+}
+
+// TestToDOT_NilState tests that a nil state exports an empty graph.
+func TestToDOT_NilState(t *testing.T) {
+	result := ToDOT(nil)
+	if !strings.HasPrefix(result, "digraph") || strings.Contains(result, "label=") {
+		t.Errorf("DOT export of nil state = %q, want empty digraph", result)
+	}
+}
+
+// =============================================================================
+// Mermaid Export Tests
+// =============================================================================
+
+// TestToMermaid_TreeAndDependencyEdges tests that the flowchart has both
+// hierarchy edges and styled dependency edges.
+func TestToMermaid_TreeAndDependencyEdges(t *testing.T) {
+	s := state.NewState()
+	addTestNode(t, s, "1", "Root", schema.NodeTypeClaim, schema.InferenceAssumption, schema.EpistemicPending, node.TaintClean)
+	addTestNode(t, s, "1.1", "Base case", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicValidated, node.TaintClean)
+	step := addTestNode(t, s, "1.2", "Inductive step", schema.NodeTypeClaim, schema.InferenceModusPonens, schema.EpistemicPending, node.TaintClean)
+	step.Dependencies = []types.NodeID{mustParse(t, "1.1")}
+
+	result := ToMermaid(s)
+
+	for _, want := range []string{
+		"flowchart TD\n",
+		`1.1["Base case"]`,
+		"1 --> 1.1\n",
+		"1 --> 1.2\n",
+		"1.2 -.-> 1.1\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Mermaid export missing %q\ngot:\n%s", want, result)
+		}
+	}
+
+	if ToMermaid(s) != result {
+		t.Error("Mermaid export should be deterministic")
+	}
+}
+
+// TestToMermaid_NilState tests that a nil state exports an empty flowchart.
+func TestToMermaid_NilState(t *testing.T) {
+	if result := ToMermaid(nil); result != "flowchart TD\n" {
+		t.Errorf("Mermaid export of nil state = %q, want empty flowchart", result)
+	}
+}
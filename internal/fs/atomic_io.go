@@ -0,0 +1,50 @@
+// Package fs provides filesystem operations for the AF proof framework.
+package fs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic writes data to filePath so that readers see either the
+// previous contents or the complete new contents, never a partial write.
+// The data is written to a uniquely named temp file in the same directory,
+// synced, and renamed over the target (atomic on POSIX).
+//
+// Unlike WriteJSON, the parent directory is not created: filePath is usually
+// supplied by the user and a missing directory is reported as an error.
+// On failure the temp file is removed and any existing file is left intact.
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := tmp.Name()
+
+	// Ignore the error from Remove on the failure paths below: the write
+	// error is more important to return, and a leftover temp file is harmless.
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if err := os.Chmod(tempPath, perm); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+
+	if err := os.Rename(tempPath, filePath); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
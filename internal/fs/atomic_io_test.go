@@ -0,0 +1,84 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic_WritesAndOverwrites(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "proof.md")
+
+	if err := WriteFileAtomic(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() error: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("second"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() overwrite error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "second" {
+		t.Errorf("file contents = %q, want %q", got, "second")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("file mode = %v, want 0644", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the target file (temp file left behind?)", len(entries))
+	}
+}
+
+func TestWriteFileAtomic_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "proof.md")
+
+	if err := WriteFileAtomic(path, []byte("data"), 0644); err == nil {
+		t.Error("WriteFileAtomic() into a missing directory should fail")
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Error("WriteFileAtomic() should not create the parent directory")
+	}
+}
+
+func TestWriteFileAtomic_FailedRenameCleansUp(t *testing.T) {
+	dir := t.TempDir()
+
+	// A non-empty directory at the target path makes the final rename fail
+	target := filepath.Join(dir, "export")
+	if err := os.Mkdir(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "keep"), []byte("kept"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(target, []byte("new"), 0644); err == nil {
+		t.Fatal("WriteFileAtomic() over a non-empty directory should fail")
+	}
+
+	got, err := os.ReadFile(filepath.Join(target, "keep"))
+	if err != nil || string(got) != "kept" {
+		t.Errorf("existing target was disturbed: %q, %v", got, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want 1 (temp file left behind?)", len(entries))
+	}
+}
@@ -0,0 +1,147 @@
+package jsonschema
+
+import (
+	"reflect"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Document names accepted by Document.
+const (
+	DocumentEvents = "events"
+	DocumentViews  = "views"
+)
+
+// viewModels lists the render view models described by the views document.
+// Add new view models here so they are covered by the schema.
+var viewModels = []interface{}{
+	render.NodeView{},
+	render.ChallengeView{},
+	render.DefinitionView{},
+	render.AssumptionView{},
+	render.ExternalView{},
+	render.JobListView{},
+	render.JobsView{},
+	render.StatusView{},
+	render.ProverContextView{},
+	render.VerifierContextView{},
+	render.TreeView{},
+	render.SearchResultView{},
+	render.SearchResultsView{},
+	render.DependencyStatusView{},
+	render.ChallengeGroupView{},
+	render.NodeDetailView{},
+	render.NodeHistoryEntryView{},
+	render.StateDiffView{},
+	render.StatusDeltaView{},
+	render.EventLogView{},
+	render.DependencyGraphView{},
+	render.NodeStatusTableView{},
+	render.NodeListView{},
+	render.ActivityView{},
+	render.ValidationReportView{},
+	render.LedgerVerificationView{},
+	render.StateSnapshotView{},
+	render.UrgentItem{},
+}
+
+// Document returns the named schema document, or false if name is unknown.
+func Document(name string) (*Schema, bool) {
+	switch name {
+	case DocumentEvents:
+		return Events(), true
+	case DocumentViews:
+		return Views(), true
+	default:
+		return nil, false
+	}
+}
+
+// Events returns the schema of a ledger event as stored in the ledger. The
+// "type" field selects the event; af log -f json emits an array of these
+// with an added "seq" field.
+func Events() *Schema {
+	g := newGenerator()
+	root := &Schema{
+		Draft:       Draft,
+		Title:       "AF ledger event",
+		Description: "A single ledger event, discriminated by its type field. af log -f json emits an array of these with an added integer seq field.",
+	}
+	for _, eventType := range state.EventTypes() {
+		event, _ := state.NewEvent(eventType)
+		name := g.define(reflect.TypeOf(event).Elem())
+		g.Defs[name].Properties["type"] = &Schema{Type: []string{"string"}, Const: string(eventType)}
+		root.OneOf = append(root.OneOf, &Schema{Ref: "#/$defs/" + name})
+	}
+	root.Defs = g.Defs
+	return root
+}
+
+// Views returns the schema of the --json output envelope. The envelope's
+// data field holds one of the view models defined in $defs; which one
+// depends on the command.
+func Views() *Schema {
+	g := newGenerator()
+	root := &Schema{
+		Draft:       Draft,
+		Title:       "AF --json output",
+		Description: "The envelope written by --json. The data field holds the command's view model, one of the definitions in $defs.",
+		OneOf: []*Schema{
+			g.Define(reflect.TypeOf(render.JSONEnvelope{})),
+			g.Define(reflect.TypeOf(render.JSONErrorEnvelope{})),
+		},
+	}
+	for _, v := range viewModels {
+		g.Define(reflect.TypeOf(v))
+	}
+	root.Defs = g.Defs
+	return root
+}
+
+// newGenerator returns a Generator configured with the AF enums and the
+// encodings of the types package.
+func newGenerator() *Generator {
+	g := NewGenerator()
+	g.Overrides[reflect.TypeOf(types.NodeID{})] = &Schema{Type: []string{"string"}}
+	g.Overrides[reflect.TypeOf(types.Timestamp{})] = &Schema{Type: []string{"string"}, Format: "date-time"}
+
+	var workflow, epistemic, severities, nodeTypes, inferences []string
+	for _, info := range schema.AllWorkflowStates() {
+		workflow = append(workflow, string(info.ID))
+	}
+	for _, info := range schema.AllEpistemicStates() {
+		epistemic = append(epistemic, string(info.ID))
+	}
+	for _, info := range schema.AllChallengeSeverities() {
+		severities = append(severities, string(info.ID))
+	}
+	for _, info := range schema.AllNodeTypes() {
+		nodeTypes = append(nodeTypes, string(info.ID))
+	}
+	for _, info := range schema.AllInferences() {
+		inferences = append(inferences, string(info.ID))
+	}
+	taint := []string{
+		string(node.TaintClean),
+		string(node.TaintSelfAdmitted),
+		string(node.TaintTainted),
+		string(node.TaintUnresolved),
+	}
+
+	g.Enums[reflect.TypeOf(schema.WorkflowState(""))] = workflow
+	g.Enums[reflect.TypeOf(schema.EpistemicState(""))] = epistemic
+	g.Enums[reflect.TypeOf(schema.ChallengeSeverity(""))] = severities
+	g.Enums[reflect.TypeOf(schema.NodeType(""))] = nodeTypes
+	g.Enums[reflect.TypeOf(schema.InferenceType(""))] = inferences
+	g.Enums[reflect.TypeOf(node.TaintState(""))] = taint
+
+	g.TagEnums["workflow_state"] = workflow
+	g.TagEnums["epistemic_state"] = epistemic
+	g.TagEnums["taint_state"] = taint
+	g.TagEnums["challenge_severity"] = severities
+	return g
+}
@@ -0,0 +1,246 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/render"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func mustParseNodeID(t *testing.T, s string) types.NodeID {
+	t.Helper()
+	id, err := types.Parse(s)
+	if err != nil {
+		t.Fatalf("types.Parse(%q): %v", s, err)
+	}
+	return id
+}
+
+func mustNewNode(t *testing.T, id string, statement string) *node.Node {
+	t.Helper()
+	n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, statement, schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("NewNode(%s): %v", id, err)
+	}
+	return n
+}
+
+// sampleEvents returns one populated event of every type, keyed by type.
+func sampleEvents(t *testing.T) map[ledger.EventType]ledger.Event {
+	root := mustParseNodeID(t, "1")
+	child := mustParseNodeID(t, "1.1")
+	timeout := types.Now().Add(time.Hour)
+
+	n := mustNewNode(t, "1.1", "x > 0")
+	n.Dependencies = []types.NodeID{root}
+	amended := *n
+	amended.Statement = "x >= 0"
+
+	events := []ledger.Event{
+		ledger.NewProofInitialized("P", "alice"),
+		ledger.NewNodeCreated(*n),
+		ledger.NewNodesClaimed([]types.NodeID{child}, "prover", timeout),
+		ledger.NewNodesReleased([]types.NodeID{child}),
+		ledger.NewChallengeRaisedWithSeverity("ch-1", child, "statement", "why?", "minor", "verifier"),
+		ledger.NewChallengeResolved("ch-1"),
+		ledger.NewChallengeWithdrawn("ch-1"),
+		ledger.NewChallengeSuperseded("ch-1", child),
+		ledger.NewChallengeEscalated("ch-1", "minor", "critical"),
+		ledger.NewNodeValidatedWithNote(child, "checked"),
+		ledger.NewNodeAdmitted(child),
+		ledger.NewNodeRefuted(child),
+		ledger.NewNodeArchived(child),
+		ledger.NewNodeAmendedFull(*n, amended, "prover", []string{"statement"}),
+		ledger.NewTaintRecomputed(child, node.TaintTainted),
+		ledger.NewDefAdded(ledger.Definition{ID: "def-1", Name: "prime", Definition: "...", Created: types.Now()}),
+		ledger.NewLemmaExtracted(ledger.Lemma{ID: "lem-1", Statement: "x > 0", NodeID: child, Created: types.Now()}),
+		ledger.NewLemmaApplied(child, "lem-1", "prover"),
+		ledger.NewLockReaped(child, "prover"),
+		ledger.NewScopeOpened(child, "Assume x"),
+		ledger.NewScopeClosed(child, mustParseNodeID(t, "1.2")),
+		ledger.NewClaimRefreshed(child, "prover", timeout),
+		ledger.NewNodesReassigned([]types.NodeID{child}, "prover", "prover-2", timeout),
+		ledger.NewRefinementRequested(child, "too coarse", "verifier"),
+		ledger.NewNodeReopened(child),
+		ledger.NewNodeTagged(child, "hard"),
+		ledger.NewNodeUntagged(child, "hard"),
+		ledger.NewNoteAdded(child, "note-1", "verifier", "consider citing the lemma"),
+		ledger.NewNodeLatexSet(child, "", "x \\geq 0", "prover"),
+	}
+
+	samples := make(map[ledger.EventType]ledger.Event, len(events))
+	for _, e := range events {
+		samples[e.Type()] = e
+	}
+	return samples
+}
+
+func TestEvents_ValidateSamples(t *testing.T) {
+	doc := Events()
+	samples := sampleEvents(t)
+
+	for _, eventType := range state.EventTypes() {
+		t.Run(string(eventType), func(t *testing.T) {
+			event, ok := samples[eventType]
+			if !ok {
+				t.Fatalf("no sample for event type %s; add one to sampleEvents", eventType)
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Validate(doc, data); err != nil {
+				t.Errorf("sample does not match the schema: %v\n%s", err, data)
+			}
+		})
+	}
+	if len(doc.OneOf) != len(state.EventTypes()) {
+		t.Errorf("schema has %d event types, want %d", len(doc.OneOf), len(state.EventTypes()))
+	}
+}
+
+func TestEvents_Rejects(t *testing.T) {
+	doc := Events()
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unknown type", `{"type":"no_such_event","timestamp":"2025-01-01T00:00:00Z"}`, "matches 0"},
+		{"bad severity", `{"type":"challenge_raised","timestamp":"2025-01-01T00:00:00Z","challenge_id":"c","node_id":"1","target":"statement","reason":"r","severity":"blocker","raised_by":""}`, "matches 0"},
+		{"missing field", `{"type":"node_tagged","timestamp":"2025-01-01T00:00:00Z","node_id":"1"}`, "matches 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(doc, []byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// viewSchema returns a schema for the named definition in the views document.
+func viewSchema(t *testing.T, name string) *Schema {
+	t.Helper()
+	doc := Views()
+	if _, ok := doc.Defs[name]; !ok {
+		t.Fatalf("views schema has no definition %q", name)
+	}
+	return &Schema{Ref: "#/$defs/" + name, Defs: doc.Defs}
+}
+
+// sampleState returns a small proof state with a claimed, challenged child.
+func sampleState(t *testing.T) *state.State {
+	st := state.NewState()
+	st.AddNode(mustNewNode(t, "1", "P"))
+	child := mustNewNode(t, "1.1", "x > 0")
+	child.WorkflowState = schema.WorkflowClaimed
+	child.ClaimedBy = "prover"
+	child.ClaimedAt = types.Now()
+	child.Tags = []string{"hard"}
+	st.AddNode(child)
+	st.AddChallenge(&state.Challenge{
+		ID:       "ch-1",
+		NodeID:   child.ID,
+		Target:   "statement",
+		Reason:   "why?",
+		Status:   state.ChallengeStatusOpen,
+		Severity: "major",
+		Created:  types.Now(),
+	})
+	return st
+}
+
+func TestViews_ValidateSamples(t *testing.T) {
+	st := sampleState(t)
+	tests := []struct {
+		def  string
+		view interface{}
+	}{
+		{"StatusView", render.StateToStatusView(st)},
+		{"TreeView", render.StateToTreeView(st, nil)},
+		{"NodeListView", render.StateToNodeListView(st, "", "")},
+		{"NodeStatusTableView", render.StateToNodeStatusTableView(st)},
+		{"DependencyGraphView", render.StateToDependencyGraphView(st)},
+		{"ChallengeView", render.StateChallengeToView(st.AllChallenges()[0])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.def, func(t *testing.T) {
+			data, err := json.Marshal(tt.view)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Validate(viewSchema(t, tt.def), data); err != nil {
+				t.Errorf("view does not match the schema: %v\n%s", err, data)
+			}
+
+			envelope, err := render.RenderJSONEnvelope("status", tt.view)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Validate(Views(), []byte(envelope)); err != nil {
+				t.Errorf("envelope does not match the schema: %v", err)
+			}
+		})
+	}
+
+	t.Run("UrgentItem", func(t *testing.T) {
+		data, err := json.Marshal(render.FilterUrgentNodes(st))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := &Schema{
+			Type:  []string{"array", "null"},
+			Items: &Schema{Ref: "#/$defs/UrgentItem"},
+			Defs:  Views().Defs,
+		}
+		if err := Validate(s, data); err != nil {
+			t.Errorf("urgent items do not match the schema: %v\n%s", err, data)
+		}
+	})
+}
+
+func TestViews_Rejects(t *testing.T) {
+	view := render.StateToNodeListView(sampleState(t), "", "")
+	view.Nodes[0].TaintState = "dirty"
+	data, err := json.Marshal(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Validate(viewSchema(t, "NodeListView"), data)
+	if err == nil || !strings.Contains(err.Error(), "$.nodes[0].taint_state") {
+		t.Errorf("Validate() error = %v, want one naming $.nodes[0].taint_state", err)
+	}
+
+	err = Validate(Views(), []byte(`{"schema_version":1,"error":"boom"}`))
+	if err == nil {
+		t.Error("Validate() accepted an error envelope without exit_code")
+	}
+}
+
+func TestDocument(t *testing.T) {
+	for _, name := range []string{DocumentEvents, DocumentViews} {
+		doc, ok := Document(name)
+		if !ok {
+			t.Fatalf("Document(%q) not found", name)
+		}
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("marshal %s: %v", name, err)
+		}
+		if !strings.Contains(string(data), `"$schema":"`+Draft+`"`) {
+			t.Errorf("%s schema does not declare the draft", name)
+		}
+	}
+	if _, ok := Document("nope"); ok {
+		t.Error(`Document("nope") found a schema`)
+	}
+}
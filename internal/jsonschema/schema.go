@@ -0,0 +1,235 @@
+// Package jsonschema generates JSON Schema documents describing the AF JSON
+// formats from the Go structs that produce them, and validates JSON against
+// those documents.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema. Only the keywords the
+// generator emits are modelled.
+type Schema struct {
+	Draft       string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is a JSON type name, or several when a value may also be null.
+	Type   []string `json:"-"`
+	Format string   `json:"format,omitempty"`
+	Const  string   `json:"const,omitempty"`
+	Enum   []string `json:"enum,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// MarshalJSON writes Type as a string when it has a single entry and as an
+// array otherwise.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	out := struct {
+		*plain
+		Type interface{} `json:"type,omitempty"`
+	}{plain: (*plain)(s)}
+	switch len(s.Type) {
+	case 0:
+	case 1:
+		out.Type = s.Type[0]
+	default:
+		out.Type = s.Type
+	}
+	return json.Marshal(out)
+}
+
+// Generator derives schemas from Go types using their json struct tags.
+//
+// Named struct types become entries in Defs referenced by $ref, so shared
+// types such as a node are described once. Fields tagged omitempty are
+// optional; all others are required. Slices, maps, and pointers that are not
+// omitempty may also be null.
+type Generator struct {
+	// Enums lists the allowed values of named string types, such as
+	// schema.EpistemicState.
+	Enums map[reflect.Type][]string
+
+	// TagEnums lists the allowed values for string fields carrying an
+	// `enum:"name"` struct tag, for fields declared as plain strings.
+	TagEnums map[string][]string
+
+	// Overrides gives the schema of types with custom JSON encodings,
+	// such as types.NodeID, which marshals as a string.
+	Overrides map[reflect.Type]*Schema
+
+	// Defs collects the named struct types generated so far.
+	Defs map[string]*Schema
+
+	names map[reflect.Type]string
+}
+
+// NewGenerator returns a Generator with no enums or overrides.
+func NewGenerator() *Generator {
+	return &Generator{
+		Enums:     make(map[reflect.Type][]string),
+		TagEnums:  make(map[string][]string),
+		Overrides: make(map[reflect.Type]*Schema),
+		Defs:      make(map[string]*Schema),
+		names:     make(map[reflect.Type]string),
+	}
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Define generates the definition of the named struct type t (or a pointer
+// to it) in g.Defs, along with the types it refers to, and returns a $ref
+// schema pointing at it.
+func (g *Generator) Define(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return &Schema{Ref: "#/$defs/" + g.define(t)}
+}
+
+// schemaFor returns the schema for values of type t.
+func (g *Generator) schemaFor(t reflect.Type) *Schema {
+	if s, ok := g.Overrides[t]; ok {
+		copied := *s
+		return &copied
+	}
+	if values, ok := g.Enums[t]; ok {
+		return &Schema{Type: []string{"string"}, Enum: values}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		// Unknown custom encoding: accept any value
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: []string{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: []string{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: []string{"number"}}
+	case reflect.String:
+		return &Schema{Type: []string{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: []string{"string"}, Format: "byte"}
+		}
+		return &Schema{Type: []string{"array"}, Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: []string{"object"}, AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Pointer:
+		return g.schemaFor(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + g.define(t)}
+	default:
+		// Interfaces and anything else: accept any value
+		return &Schema{}
+	}
+}
+
+// define generates the definition of the named struct type t if it has not
+// been generated yet, and returns its name in g.Defs. Types from different
+// packages that share a name are qualified with their package name.
+func (g *Generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.Defs[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	g.names[t] = name
+
+	// Reserve the name before recursing so self-referencing types terminate
+	g.Defs[name] = &Schema{}
+	*g.Defs[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema returns the object schema for struct type t. Fields of
+// embedded structs without a json tag are promoted, as encoding/json does.
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: []string{"object"}, Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	sort.Strings(s.Required)
+	return s
+}
+
+// addFields adds the JSON properties of struct type t to s.
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		omitempty := strings.Contains(","+opts+",", ",omitempty,")
+
+		prop := g.schemaFor(f.Type)
+		if enum := f.Tag.Get("enum"); enum != "" {
+			if values, ok := g.TagEnums[enum]; ok {
+				prop.Enum = values
+			}
+		}
+		if !omitempty {
+			switch f.Type.Kind() {
+			case reflect.Slice, reflect.Map, reflect.Pointer:
+				if f.Type.Kind() != reflect.Slice || f.Type.Elem().Kind() != reflect.Uint8 {
+					prop = nullable(prop)
+				}
+			}
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// nullable returns a schema that also accepts null.
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" || len(s.Enum) > 0 {
+		return &Schema{OneOf: []*Schema{s, {Type: []string{"null"}}}}
+	}
+	if len(s.Type) == 0 {
+		return s
+	}
+	s.Type = append(s.Type, "null")
+	return s
+}
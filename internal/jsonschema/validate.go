@@ -0,0 +1,144 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Validate checks the JSON document data against root. It supports the
+// keywords the generator emits; $ref must point into root's $defs.
+// The returned error names the JSON path of the first violation.
+func Validate(root *Schema, data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return validate(root, root, value, "$")
+}
+
+// validate checks value at path against s, resolving references in root.
+func validate(root, s *Schema, value interface{}, path string) error {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/$defs/")
+		def, ok := root.Defs[name]
+		if !ok {
+			return fmt.Errorf("%s: unresolvable reference %q", path, s.Ref)
+		}
+		if err := validate(root, def, value, path); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Type) > 0 && !hasType(s.Type, value) {
+		return fmt.Errorf("%s: got %s, want %s", path, jsonType(value), strings.Join(s.Type, " or "))
+	}
+	if s.Const != "" && value != s.Const {
+		return fmt.Errorf("%s: got %v, want %q", path, value, s.Const)
+	}
+	if len(s.Enum) > 0 {
+		str, _ := value.(string)
+		found := false
+		for _, allowed := range s.Enum {
+			if str == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %s", path, value, strings.Join(s.Enum, ", "))
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		var firstErr error
+		for _, option := range s.OneOf {
+			if err := validate(root, option, value, path); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			matched++
+		}
+		if matched != 1 {
+			if matched == 0 && len(s.OneOf) == 1 {
+				return firstErr
+			}
+			return fmt.Errorf("%s: matches %d of the allowed schemas, want exactly 1", path, matched)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				prop = s.AdditionalProperties
+			}
+			if prop == nil {
+				continue
+			}
+			if err := validate(root, prop, v[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := validate(root, s.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasType reports whether value is of one of the JSON types in types.
+func hasType(types []string, value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON type name of a value decoded by encoding/json.
+// Numbers with no fractional part are reported as integers.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -2,7 +2,6 @@
 package ledger
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -90,8 +89,17 @@ func AppendWithTimeout(dir string, event Event, timeout time.Duration) (int, err
 		return 0, fmt.Errorf("failed to determine next sequence: %w", err)
 	}
 
+	// Link the event to its predecessor in the hash chain
+	if err := ensureChainStart(dir, seq); err != nil {
+		return 0, err
+	}
+	prevHash, err := prevHashFor(dir, seq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hash previous event: %w", err)
+	}
+
 	// Marshal event to JSON
-	data, err := json.Marshal(event)
+	data, err := marshalChained(event, prevHash)
 	if err != nil {
 		return 0, fmt.Errorf("failed to marshal event: %w", err)
 	}
@@ -185,8 +193,17 @@ func AppendIfSequenceWithTimeout(dir string, event Event, expectedSeq int, timeo
 	// Sequence matches - proceed with append (same logic as AppendWithTimeout)
 	seq := currentSeq
 
+	// Link the event to its predecessor in the hash chain
+	if err := ensureChainStart(dir, seq); err != nil {
+		return 0, err
+	}
+	prevHash, err := prevHashFor(dir, seq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hash previous event: %w", err)
+	}
+
 	// Marshal event to JSON
-	data, err := json.Marshal(event)
+	data, err := marshalChained(event, prevHash)
 	if err != nil {
 		return 0, fmt.Errorf("failed to marshal event: %w", err)
 	}
@@ -261,6 +278,65 @@ func AppendBatch(dir string, events []Event) ([]int, error) {
 		return nil, fmt.Errorf("failed to determine next sequence: %w", err)
 	}
 
+	return writeBatch(dir, events, startSeq)
+}
+
+// AppendBatchIfSequence adds multiple events atomically to the ledger only if
+// the current sequence matches the expected value, combining the CAS semantics
+// of AppendIfSequence with the all-or-nothing write of AppendBatch.
+//
+// The sequence check and every write happen under a single ledger lock, so no
+// other writer can append between the events of the batch. If any event fails
+// to be written, the events already written are removed again and the ledger
+// is left as it was.
+//
+// Returns the sequence numbers assigned to each event, or ErrSequenceMismatch
+// if the ledger was concurrently modified. Other errors indicate infrastructure
+// failures.
+func AppendBatchIfSequence(dir string, events []Event, expectedSeq int) ([]int, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	if err := validateDirectory(dir); err != nil {
+		return nil, err
+	}
+
+	// Acquire lock for concurrent safety
+	lock := NewLedgerLock(dir)
+	if err := lock.Acquire("append-batch-if-sequence-operation", defaultLockTimeout); err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer releaseLock(lock, "append-batch-if-sequence")
+
+	// Get current sequence number (inside lock to ensure atomicity)
+	startSeq, err := NextSequence(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine current sequence: %w", err)
+	}
+	if actualLatest := startSeq - 1; actualLatest != expectedSeq {
+		return nil, fmt.Errorf("%w: expected sequence %d, but ledger is at %d",
+			ErrSequenceMismatch, expectedSeq, actualLatest)
+	}
+
+	return writeBatch(dir, events, startSeq)
+}
+
+// writeBatch writes events to dir with consecutive sequence numbers from
+// startSeq. All events are first written to temp files, then renamed into
+// place; if a rename fails, the files already renamed are removed again.
+// The caller must hold the ledger lock.
+func writeBatch(dir string, events []Event, startSeq int) ([]int, error) {
+	// Link the first event to the current ledger tip; later events in the
+	// batch chain to each other in memory.
+	if err := ensureChainStart(dir, startSeq); err != nil {
+		return nil, err
+	}
+	prevHash, err := prevHashFor(dir, startSeq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash previous event: %w", err)
+	}
+
 	seqs := make([]int, len(events))
 	tempPaths := make([]string, len(events))
 
@@ -270,11 +346,12 @@ func AppendBatch(dir string, events []Event) ([]int, error) {
 		seqs[i] = seq
 
 		// Marshal event to JSON
-		data, err := json.Marshal(event)
+		data, err := marshalChained(event, prevHash)
 		if err != nil {
 			cleanupTempFiles(tempPaths, 0, i)
 			return nil, fmt.Errorf("failed to marshal event %d: %w", i, err)
 		}
+		prevHash = HashEvent(data)
 
 		// Create temp file
 		tempFile, err := os.CreateTemp(dir, ".event-*.tmp")
@@ -0,0 +1,98 @@
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppendBatchIfSequence_AppendsAll(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 2)
+
+	seqs, err := AppendBatchIfSequence(dir, []Event{
+		NewChallengeResolved("chal-a"),
+		NewChallengeResolved("chal-b"),
+		NewChallengeResolved("chal-c"),
+	}, 2)
+	if err != nil {
+		t.Fatalf("AppendBatchIfSequence failed: %v", err)
+	}
+	for i, want := range []int{3, 4, 5} {
+		if seqs[i] != want {
+			t.Errorf("seqs[%d] = %d, want %d", i, seqs[i], want)
+		}
+	}
+
+	// The batch extends the hash chain
+	v := NewChainVerifier()
+	for seq := 1; seq <= 5; seq++ {
+		data, err := ReadEvent(dir, seq)
+		if err != nil {
+			t.Fatalf("ReadEvent(%d) failed: %v", seq, err)
+		}
+		if err := v.Verify(seq, data); err != nil {
+			t.Errorf("Verify(%d) failed: %v", seq, err)
+		}
+	}
+}
+
+func TestAppendBatchIfSequence_MismatchWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 2)
+
+	_, err := AppendBatchIfSequence(dir, []Event{
+		NewChallengeResolved("chal-a"),
+		NewChallengeResolved("chal-b"),
+	}, 1)
+	if !errors.Is(err, ErrSequenceMismatch) {
+		t.Fatalf("AppendBatchIfSequence error = %v, want ErrSequenceMismatch", err)
+	}
+	if count, err := Count(dir); err != nil || count != 2 {
+		t.Errorf("Count = %d (err %v), want 2", count, err)
+	}
+}
+
+// TestAppendBatchIfSequence_FailureMidBatchWritesNothing blocks the file name
+// of the second event, so the batch fails after the first event is in place,
+// and checks that the ledger is left as it was.
+func TestAppendBatchIfSequence_FailureMidBatchWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 1)
+
+	// Renaming a file over a non-empty directory fails
+	blocker := EventFilePath(dir, 3)
+	if err := os.MkdirAll(blocker, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blocker, "blocker"), []byte("block"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := AppendBatchIfSequence(dir, []Event{
+		NewChallengeResolved("chal-a"),
+		NewChallengeResolved("chal-b"),
+		NewChallengeResolved("chal-c"),
+	}, 1)
+	if err == nil {
+		t.Fatal("AppendBatchIfSequence should fail when an event file name is blocked")
+	}
+
+	if _, err := os.Stat(EventFilePath(dir, 2)); !os.IsNotExist(err) {
+		t.Errorf("event 2 left behind after failed batch (stat err = %v)", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".event-") {
+			t.Errorf("temp file %q left behind after failed batch", entry.Name())
+		}
+	}
+	if count, err := Count(dir); err != nil || count != 1 {
+		t.Errorf("Count = %d (err %v), want 1", count, err)
+	}
+}
@@ -0,0 +1,255 @@
+// Package ledger provides event-sourced ledger operations for the AF proof framework.
+package ledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// GenesisHash is the prev_hash of the first event in a ledger.
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// ErrChainBroken is returned when an event's prev_hash does not match the
+// hash of the event before it.
+var ErrChainBroken = errors.New("ledger hash chain broken")
+
+// HashEvent returns the hex-encoded SHA-256 of raw event bytes.
+// This is the value the next event stores in its prev_hash field.
+func HashEvent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// marshalChained marshals event to JSON and appends a prev_hash field to the
+// top-level object, linking the event to the one before it.
+func marshalChained(event Event, prevHash string) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return linkEvent(data, prevHash)
+}
+
+// linkEvent appends a prev_hash field to the top-level JSON object data,
+// which must not already have one.
+func linkEvent(data []byte, prevHash string) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '{' || data[len(data)-1] != '}' {
+		return nil, fmt.Errorf("event does not marshal to a JSON object")
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(data) + len(prevHash) + 16)
+	buf.Write(data[:len(data)-1])
+	if len(data) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"prev_hash":"`)
+	buf.WriteString(prevHash)
+	buf.WriteString(`"}`)
+	return buf.Bytes(), nil
+}
+
+// prevHashFor returns the prev_hash for an event appended at seq:
+// GenesisHash for the first event, otherwise the hash of event seq-1.
+func prevHashFor(dir string, seq int) (string, error) {
+	if seq <= 1 {
+		return GenesisHash, nil
+	}
+	return eventHash(dir, seq-1)
+}
+
+// ChainFileName is the name of the file within the ledger directory that
+// records where the ledger's prev_hash chain starts. It does not match the
+// event filename pattern, so it is ignored by Scan and Count.
+const ChainFileName = "chain.json"
+
+// chainRecord is the content of ChainFileName.
+type chainRecord struct {
+	// Start is the sequence number of the first chained event. Every event
+	// from Start on must carry a prev_hash.
+	Start int `json:"start"`
+}
+
+// ReadChainStart returns the sequence number from which every event of the
+// ledger in dir must carry a prev_hash, or 0 if none is recorded because
+// the ledger has never had a chained event written to it.
+func ReadChainStart(dir string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ChainFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read chain start: %w", err)
+	}
+
+	var rec chainRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return 0, fmt.Errorf("failed to parse chain start: %w", err)
+	}
+	if rec.Start <= 0 {
+		return 0, fmt.Errorf("invalid chain start: %d", rec.Start)
+	}
+	return rec.Start, nil
+}
+
+// ensureChainStart records where the chain of the ledger in dir starts
+// before the chained event seq is written, unless a start is recorded
+// already. The chain starts at the first event in the ledger that carries a
+// prev_hash, written before the start was recorded, or else at seq.
+// The caller must hold the ledger lock.
+func ensureChainStart(dir string, seq int) error {
+	start, err := ReadChainStart(dir)
+	if err != nil || start > 0 {
+		return err
+	}
+
+	start = seq
+	err = Scan(dir, func(s int, data []byte) error {
+		if s >= seq {
+			return ErrStopScan
+		}
+		if hasPrevHash(data) {
+			start = s
+			return ErrStopScan
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return writeChainStart(dir, start)
+}
+
+// firstChainedSeq returns the sequence number of the first of events, which
+// are numbered from 1, that carries a prev_hash, or 0 if none does.
+func firstChainedSeq(events [][]byte) int {
+	for i, data := range events {
+		if hasPrevHash(data) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// hasPrevHash reports whether the event data carries a prev_hash field.
+// Data that cannot be parsed has none.
+func hasPrevHash(data []byte) bool {
+	var envelope struct {
+		PrevHash *string `json:"prev_hash"`
+	}
+	return json.Unmarshal(data, &envelope) == nil && envelope.PrevHash != nil
+}
+
+// writeChainStart records start as the chain start of the ledger in dir.
+// The write is atomic: the record is first written to a temp file, then
+// renamed. The caller must hold the ledger lock.
+func writeChainStart(dir string, start int) error {
+	data, err := json.Marshal(chainRecord{Start: start})
+	if err != nil {
+		return fmt.Errorf("failed to marshal chain start: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(dir, ".chain-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the write error
+		return fmt.Errorf("failed to write chain start: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the sync error
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the close error
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the chmod error
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filepath.Join(dir, ChainFileName)); err != nil {
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the rename error
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+// ChainVerifier checks the prev_hash links of a ledger's events in order.
+//
+// Events written before hash chaining was introduced have no prev_hash.
+// They are accepted as a prefix of the ledger, but once an event carries a
+// prev_hash every later event must carry a valid one. A verifier created
+// with the ledger's recorded chain start (see ReadChainStart) also requires
+// a prev_hash on every event from that start on, so stripping the prev_hash
+// of every event does not make a chained ledger pass as a legacy one.
+type ChainVerifier struct {
+	prevHash string
+	chained  bool
+	start    int
+}
+
+// NewChainVerifier returns a verifier positioned before the first event,
+// for a ledger with no recorded chain start.
+func NewChainVerifier() *ChainVerifier {
+	return NewChainVerifierFrom(0)
+}
+
+// NewChainVerifierFrom returns a verifier positioned before the first event
+// that requires a prev_hash on every event from sequence number start on.
+// A start of 0 requires none until the first chained event.
+func NewChainVerifierFrom(start int) *ChainVerifier {
+	return &ChainVerifier{prevHash: GenesisHash, start: start}
+}
+
+// Verify checks that event seq links to the previously verified event.
+// Events must be passed in sequence order starting at 1.
+// Returns an error wrapping ErrChainBroken that names seq if the link is
+// missing or does not match. The verifier moves past seq either way, so a
+// single break is reported once and later events are checked against seq.
+func (v *ChainVerifier) Verify(seq int, data []byte) error {
+	var envelope struct {
+		PrevHash *string `json:"prev_hash"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("failed to read prev_hash of event %d: %w", seq, err)
+	}
+
+	prevHash := v.prevHash
+	v.prevHash = HashEvent(data)
+
+	switch {
+	case envelope.PrevHash == nil && v.start > 0 && seq >= v.start:
+		return fmt.Errorf("%w at sequence %d: missing prev_hash (the chain starts at sequence %d)",
+			ErrChainBroken, seq, v.start)
+	case envelope.PrevHash == nil && v.chained:
+		return fmt.Errorf("%w at sequence %d: missing prev_hash", ErrChainBroken, seq)
+	case envelope.PrevHash != nil && *envelope.PrevHash != prevHash:
+		v.chained = true
+		return fmt.Errorf("%w at sequence %d: prev_hash %s does not match hash %s of the preceding event",
+			ErrChainBroken, seq, *envelope.PrevHash, prevHash)
+	case envelope.PrevHash != nil:
+		v.chained = true
+	}
+	return nil
+}
+
+// Chained reports whether the ledger is chained: whether any event verified
+// so far carried a prev_hash, or the verifier was given a chain start. It is
+// false for ledgers written before hash chaining was introduced.
+func (v *ChainVerifier) Chained() bool {
+	return v.chained || v.start > 0
+}
@@ -0,0 +1,216 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readPrevHash(t *testing.T, dir string, seq int) string {
+	t.Helper()
+	data, err := ReadEvent(dir, seq)
+	if err != nil {
+		t.Fatalf("ReadEvent(%d) failed: %v", seq, err)
+	}
+	var envelope struct {
+		PrevHash string `json:"prev_hash"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		t.Fatalf("unmarshal event %d: %v", seq, err)
+	}
+	return envelope.PrevHash
+}
+
+func TestChain_AppendLinksEvents(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+	}, dir)
+	if _, err := AppendIfSequence(dir, NewChallengeResolved("chal-2"), 2); err != nil {
+		t.Fatalf("AppendIfSequence failed: %v", err)
+	}
+	if _, err := AppendBatch(dir, []Event{
+		NewChallengeResolved("chal-3"),
+		NewChallengeResolved("chal-4"),
+	}); err != nil {
+		t.Fatalf("AppendBatch failed: %v", err)
+	}
+
+	if got := readPrevHash(t, dir, 1); got != GenesisHash {
+		t.Errorf("event 1 prev_hash = %q, want genesis hash", got)
+	}
+	for seq := 2; seq <= 5; seq++ {
+		prev, err := ReadEvent(dir, seq-1)
+		if err != nil {
+			t.Fatalf("ReadEvent(%d) failed: %v", seq-1, err)
+		}
+		if got, want := readPrevHash(t, dir, seq), HashEvent(prev); got != want {
+			t.Errorf("event %d prev_hash = %q, want %q", seq, got, want)
+		}
+	}
+}
+
+func TestChainVerifier_Valid(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+		NewChallengeResolved("chal-2"),
+	}, dir)
+
+	v := NewChainVerifier()
+	for seq := 1; seq <= 3; seq++ {
+		data, err := ReadEvent(dir, seq)
+		if err != nil {
+			t.Fatalf("ReadEvent(%d) failed: %v", seq, err)
+		}
+		if err := v.Verify(seq, data); err != nil {
+			t.Fatalf("Verify(%d) failed: %v", seq, err)
+		}
+	}
+}
+
+func TestChainVerifier_DetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+		NewChallengeResolved("chal-2"),
+	}, dir)
+
+	// Rewrite event 2 in place; event 3 no longer links to it.
+	path := filepath.Join(dir, GenerateFilename(2))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "chal-1", "chal-9", 1)
+	if err := os.WriteFile(path, []byte(tampered), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := NewChainVerifier()
+	var verr error
+	for seq := 1; seq <= 3 && verr == nil; seq++ {
+		data, err := ReadEvent(dir, seq)
+		if err != nil {
+			t.Fatalf("ReadEvent(%d) failed: %v", seq, err)
+		}
+		verr = v.Verify(seq, data)
+	}
+	if !errors.Is(verr, ErrChainBroken) {
+		t.Fatalf("expected ErrChainBroken, got %v", verr)
+	}
+	if !strings.Contains(verr.Error(), "sequence 3") {
+		t.Errorf("error should name sequence 3: %v", verr)
+	}
+}
+
+func TestChainVerifier_LegacyPrefix(t *testing.T) {
+	legacy := []byte(`{"type":"challenge_resolved","challenge_id":"c1"}`)
+	v := NewChainVerifier()
+	if err := v.Verify(1, legacy); err != nil {
+		t.Fatalf("legacy event rejected: %v", err)
+	}
+
+	chained, err := marshalChained(NewChallengeResolved("c2"), HashEvent(legacy))
+	if err != nil {
+		t.Fatalf("marshalChained failed: %v", err)
+	}
+	if err := v.Verify(2, chained); err != nil {
+		t.Fatalf("chained event after legacy prefix rejected: %v", err)
+	}
+
+	// Once the chain has started, unchained events are rejected.
+	if err := v.Verify(3, legacy); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected ErrChainBroken for missing prev_hash, got %v", err)
+	}
+}
+
+func TestChainStart_RecordedAtFirstChainedEvent(t *testing.T) {
+	t.Run("new ledger", func(t *testing.T) {
+		dir := t.TempDir()
+		if start, err := ReadChainStart(dir); err != nil || start != 0 {
+			t.Fatalf("ReadChainStart on empty ledger = %d (err %v), want 0", start, err)
+		}
+		appendN(t, dir, 2)
+		if start, err := ReadChainStart(dir); err != nil || start != 1 {
+			t.Errorf("ReadChainStart = %d (err %v), want 1", start, err)
+		}
+	})
+
+	t.Run("legacy prefix", func(t *testing.T) {
+		dir := t.TempDir()
+		for seq := 1; seq <= 2; seq++ {
+			legacy := []byte(`{"type":"challenge_resolved","challenge_id":"c1"}`)
+			if err := os.WriteFile(EventFilePath(dir, seq), legacy, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		appendN(t, dir, 2)
+		if start, err := ReadChainStart(dir); err != nil || start != 3 {
+			t.Errorf("ReadChainStart = %d (err %v), want 3", start, err)
+		}
+	})
+}
+
+// TestChainVerifier_StrippedChain edits an event and strips the prev_hash of
+// every event. Without the recorded chain start this passes as a ledger
+// written before hash chaining; with it, the first stripped event is
+// rejected.
+func TestChainVerifier_StrippedChain(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+		NewChallengeResolved("chal-2"),
+	}, dir)
+
+	for seq := 1; seq <= 3; seq++ {
+		path := EventFilePath(dir, seq)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stripped, err := stripPrevHash(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stripped = []byte(strings.Replace(string(stripped), "chal-1", "chal-9", 1))
+		if err := os.WriteFile(path, stripped, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start, err := ReadChainStart(dir)
+	if err != nil {
+		t.Fatalf("ReadChainStart failed: %v", err)
+	}
+	verify := func(v *ChainVerifier) error {
+		for seq := 1; seq <= 3; seq++ {
+			data, err := ReadEvent(dir, seq)
+			if err != nil {
+				t.Fatalf("ReadEvent(%d) failed: %v", seq, err)
+			}
+			if err := v.Verify(seq, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := verify(NewChainVerifier()); err != nil {
+		t.Fatalf("stripped ledger rejected without a chain start: %v", err)
+	}
+	err = verify(NewChainVerifierFrom(start))
+	if !errors.Is(err, ErrChainBroken) {
+		t.Fatalf("Verify error = %v, want ErrChainBroken", err)
+	}
+	if !strings.Contains(err.Error(), "sequence 1") {
+		t.Errorf("error should name sequence 1: %v", err)
+	}
+}
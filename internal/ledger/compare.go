@@ -0,0 +1,136 @@
+// Package ledger provides event-sourced ledger operations for the AF proof framework.
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// LedgerComparison describes how two ledgers relate event-for-event.
+type LedgerComparison struct {
+	// CountA and CountB are the number of events in each ledger.
+	CountA int `json:"count_a"`
+	CountB int `json:"count_b"`
+
+	// CommonPrefix is the number of leading events that are identical in both ledgers.
+	CommonPrefix int `json:"common_prefix"`
+
+	// FirstDivergence is the first sequence number at which both ledgers have an
+	// event but the events differ. Zero if no such sequence exists.
+	FirstDivergence int `json:"first_divergence,omitempty"`
+
+	// ExtraInA and ExtraInB list sequence numbers present in one ledger but not
+	// the other (i.e. beyond the end of the shorter ledger).
+	ExtraInA []int `json:"extra_in_a,omitempty"`
+	ExtraInB []int `json:"extra_in_b,omitempty"`
+
+	// PrefixCompatible is true when one ledger is a prefix of the other
+	// (including when they are identical).
+	PrefixCompatible bool `json:"prefix_compatible"`
+}
+
+// Identical returns true if both ledgers contain exactly the same events.
+func (c *LedgerComparison) Identical() bool {
+	return c.PrefixCompatible && c.CountA == c.CountB
+}
+
+// Compare compares two ledger directories event-for-event.
+// Events are compared by their canonical JSON bytes, so differences in
+// whitespace or key order do not count as divergence.
+//
+// Both ledgers must be free of sequence gaps; a gap is reported as an error
+// since sequence numbers would no longer line up.
+func Compare(dirA, dirB string) (*LedgerComparison, error) {
+	seqsA, err := contiguousSequences(dirA)
+	if err != nil {
+		return nil, fmt.Errorf("ledger A: %w", err)
+	}
+	seqsB, err := contiguousSequences(dirB)
+	if err != nil {
+		return nil, fmt.Errorf("ledger B: %w", err)
+	}
+
+	cmp := &LedgerComparison{
+		CountA: len(seqsA),
+		CountB: len(seqsB),
+	}
+
+	shared := len(seqsA)
+	if len(seqsB) < shared {
+		shared = len(seqsB)
+	}
+
+	for seq := 1; seq <= shared; seq++ {
+		equal, err := eventsEqual(dirA, dirB, seq)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			cmp.FirstDivergence = seq
+			break
+		}
+		cmp.CommonPrefix = seq
+	}
+
+	for seq := shared + 1; seq <= len(seqsA); seq++ {
+		cmp.ExtraInA = append(cmp.ExtraInA, seq)
+	}
+	for seq := shared + 1; seq <= len(seqsB); seq++ {
+		cmp.ExtraInB = append(cmp.ExtraInB, seq)
+	}
+
+	cmp.PrefixCompatible = cmp.FirstDivergence == 0
+
+	return cmp, nil
+}
+
+// contiguousSequences lists event sequences in dir and verifies there are no gaps.
+func contiguousSequences(dir string) ([]int, error) {
+	seqs, err := listEventSequences(dir)
+	if err != nil {
+		return nil, err
+	}
+	for i, seq := range seqs {
+		if seq != i+1 {
+			return nil, fmt.Errorf("sequence gap: expected event %d, found %d", i+1, seq)
+		}
+	}
+	return seqs, nil
+}
+
+// eventsEqual reports whether the event at seq has the same canonical bytes in both ledgers.
+func eventsEqual(dirA, dirB string, seq int) (bool, error) {
+	a, err := readCanonical(dirA, seq)
+	if err != nil {
+		return false, fmt.Errorf("ledger A: %w", err)
+	}
+	b, err := readCanonical(dirB, seq)
+	if err != nil {
+		return false, fmt.Errorf("ledger B: %w", err)
+	}
+	return bytes.Equal(a, b), nil
+}
+
+// readCanonical reads an event and returns its canonical JSON encoding.
+// Decoding into a generic value and re-encoding sorts object keys and
+// strips insignificant whitespace.
+func readCanonical(dir string, seq int) ([]byte, error) {
+	data, err := ReadEvent(dir, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("event %d: failed to decode: %w", seq, err)
+	}
+
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("event %d: failed to encode: %w", seq, err)
+	}
+	return canonical, nil
+}
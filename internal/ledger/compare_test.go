@@ -0,0 +1,117 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// appendAll appends each event to every given ledger directory.
+func appendAll(t *testing.T, events []Event, dirs ...string) {
+	t.Helper()
+	for _, e := range events {
+		for _, dir := range dirs {
+			if _, err := Append(dir, e); err != nil {
+				t.Fatalf("Append to %s failed: %v", dir, err)
+			}
+		}
+	}
+}
+
+func TestCompare_Identical(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+	}, dirA, dirB)
+
+	cmp, err := Compare(dirA, dirB)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !cmp.Identical() {
+		t.Errorf("expected identical ledgers, got %+v", cmp)
+	}
+	if cmp.CommonPrefix != 2 {
+		t.Errorf("CommonPrefix = %d, want 2", cmp.CommonPrefix)
+	}
+}
+
+func TestCompare_SharedPrefixThenDiverge(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+	}, dirA, dirB)
+	appendAll(t, []Event{NewChallengeResolved("chal-2"), NewChallengeWithdrawn("chal-3")}, dirA)
+	appendAll(t, []Event{NewChallengeWithdrawn("chal-9")}, dirB)
+
+	cmp, err := Compare(dirA, dirB)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if cmp.FirstDivergence != 3 {
+		t.Errorf("FirstDivergence = %d, want 3", cmp.FirstDivergence)
+	}
+	if cmp.CommonPrefix != 2 {
+		t.Errorf("CommonPrefix = %d, want 2", cmp.CommonPrefix)
+	}
+	if cmp.PrefixCompatible {
+		t.Error("expected PrefixCompatible = false for divergent ledgers")
+	}
+	if len(cmp.ExtraInA) != 1 || cmp.ExtraInA[0] != 4 {
+		t.Errorf("ExtraInA = %v, want [4]", cmp.ExtraInA)
+	}
+	if len(cmp.ExtraInB) != 0 {
+		t.Errorf("ExtraInB = %v, want empty", cmp.ExtraInB)
+	}
+}
+
+func TestCompare_PrefixCompatible(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	appendAll(t, []Event{NewProofInitialized("conjecture", "agent")}, dirA, dirB)
+	appendAll(t, []Event{NewChallengeResolved("chal-1")}, dirB)
+
+	cmp, err := Compare(dirA, dirB)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !cmp.PrefixCompatible {
+		t.Error("expected PrefixCompatible = true")
+	}
+	if cmp.Identical() {
+		t.Error("expected ledgers of different length to not be identical")
+	}
+	if len(cmp.ExtraInB) != 1 || cmp.ExtraInB[0] != 2 {
+		t.Errorf("ExtraInB = %v, want [2]", cmp.ExtraInB)
+	}
+}
+
+func TestCompare_IgnoresFormatting(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(dirA, GenerateFilename(1)), []byte(`{"type":"x","a":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirB, GenerateFilename(1)), []byte("{\n  \"a\": 1,\n  \"type\": \"x\"\n}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmp, err := Compare(dirA, dirB)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !cmp.Identical() {
+		t.Errorf("expected formatting-only differences to compare equal, got %+v", cmp)
+	}
+}
+
+func TestCompare_GapIsError(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(dirA, GenerateFilename(2)), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Compare(dirA, dirB); err == nil {
+		t.Error("expected error for ledger with sequence gap")
+	}
+}
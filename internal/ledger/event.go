@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/types"
 )
 
@@ -28,11 +29,19 @@ const (
 	EventTaintRecomputed      EventType = "taint_recomputed"
 	EventDefAdded             EventType = "def_added"
 	EventLemmaExtracted       EventType = "lemma_extracted"
+	EventLemmaApplied         EventType = "lemma_applied"
 	EventLockReaped           EventType = "lock_reaped"
 	EventScopeOpened          EventType = "scope_opened"
 	EventScopeClosed          EventType = "scope_closed"
 	EventClaimRefreshed       EventType = "claim_refreshed"
+	EventNodesReassigned      EventType = "nodes_reassigned"
 	EventRefinementRequested  EventType = "refinement_requested"
+	EventNodeReopened         EventType = "node_reopened"
+	EventNodeTagged           EventType = "node_tagged"
+	EventNodeUntagged         EventType = "node_untagged"
+	EventChallengeEscalated   EventType = "challenge_escalated"
+	EventNoteAdded            EventType = "note_added"
+	EventNodeLatexSet         EventType = "node_latex_set"
 )
 
 // Event is the base interface for all ledger events.
@@ -82,6 +91,8 @@ type NodesClaimed struct {
 }
 
 // NodesReleased is emitted when one or more nodes are released from a claim.
+// Its timestamp marks when the claims ended, so together with the matching
+// NodesClaimed it gives how long each node was held.
 type NodesReleased struct {
 	BaseEvent
 	NodeIDs []types.NodeID `json:"node_ids"`
@@ -94,8 +105,8 @@ type ChallengeRaised struct {
 	NodeID      types.NodeID `json:"node_id"`
 	Target      string       `json:"target"`
 	Reason      string       `json:"reason"`
-	Severity    string       `json:"severity"`  // "critical", "major", "minor", or "note"
-	RaisedBy    string       `json:"raised_by"` // Agent ID of the verifier who raised this challenge
+	Severity    string       `json:"severity" enum:"challenge_severity"` // "critical", "major", "minor", or "note"
+	RaisedBy    string       `json:"raised_by"`                          // Agent ID of the verifier who raised this challenge
 }
 
 // ChallengeResolved is emitted when a challenge is resolved (answered).
@@ -178,6 +189,14 @@ type LemmaExtracted struct {
 	Lemma Lemma `json:"lemma"`
 }
 
+// LemmaApplied is emitted when a lemma is cited as a justification for a node.
+type LemmaApplied struct {
+	BaseEvent
+	NodeID  types.NodeID `json:"node_id"`
+	LemmaID string       `json:"lemma_id"`
+	Owner   string       `json:"owner"`
+}
+
 // LockReaped is emitted when a stale lock is cleaned up.
 type LockReaped struct {
 	BaseEvent
@@ -185,16 +204,36 @@ type LockReaped struct {
 	Owner  string       `json:"owner"`
 }
 
-// NodeAmended is emitted when a prover corrects the statement of a node they own.
+// NodeAmended is emitted when a prover corrects a node they own.
 // The original statement is preserved in the PreviousStatement field for history.
+//
+// Fields lists which of the amendable fields (see the AmendField constants)
+// the event changes. Events without Fields predate full amendments and change
+// only the statement.
 type NodeAmended struct {
 	BaseEvent
 	NodeID            types.NodeID `json:"node_id"`
 	PreviousStatement string       `json:"previous_statement"`
 	NewStatement      string       `json:"new_statement"`
 	Owner             string       `json:"owner"`
+
+	Fields                 []string             `json:"fields,omitempty"`
+	PreviousInference      schema.InferenceType `json:"previous_inference,omitempty"`
+	NewInference           schema.InferenceType `json:"new_inference,omitempty"`
+	PreviousDependencies   []types.NodeID       `json:"previous_dependencies,omitempty"`
+	NewDependencies        []types.NodeID       `json:"new_dependencies,omitempty"`
+	PreviousValidationDeps []types.NodeID       `json:"previous_validation_deps,omitempty"`
+	NewValidationDeps      []types.NodeID       `json:"new_validation_deps,omitempty"`
 }
 
+// Amendable node fields recorded in NodeAmended.Fields.
+const (
+	AmendFieldStatement      = "statement"
+	AmendFieldInference      = "inference"
+	AmendFieldDependencies   = "dependencies"
+	AmendFieldValidationDeps = "validation_deps"
+)
+
 // NewProofInitialized creates a ProofInitialized event.
 func NewProofInitialized(conjecture, author string) ProofInitialized {
 	return ProofInitialized{
@@ -384,6 +423,19 @@ func NewLemmaExtracted(lemma Lemma) LemmaExtracted {
 	}
 }
 
+// NewLemmaApplied creates a LemmaApplied event.
+func NewLemmaApplied(nodeID types.NodeID, lemmaID, owner string) LemmaApplied {
+	return LemmaApplied{
+		BaseEvent: BaseEvent{
+			EventType: EventLemmaApplied,
+			EventTime: types.Now(),
+		},
+		NodeID:  nodeID,
+		LemmaID: lemmaID,
+		Owner:   owner,
+	}
+}
+
 // NewLockReaped creates a LockReaped event.
 // Note: Uses FromTime to preserve full timestamp precision for accurate
 // comparison with caller's timing windows.
@@ -412,6 +464,29 @@ func NewNodeAmended(nodeID types.NodeID, previousStatement, newStatement, owner
 	}
 }
 
+// NewNodeAmendedFull creates a NodeAmended event that changes the listed
+// fields of a node from their values in prev to their values in next.
+// Values of fields not listed are ignored, except that the statement is
+// always carried so that history shows the statement in effect.
+func NewNodeAmendedFull(prev, next node.Node, owner string, fields []string) NodeAmended {
+	e := NewNodeAmended(prev.ID, prev.Statement, next.Statement, owner)
+	e.Fields = fields
+	for _, f := range fields {
+		switch f {
+		case AmendFieldInference:
+			e.PreviousInference = prev.Inference
+			e.NewInference = next.Inference
+		case AmendFieldDependencies:
+			e.PreviousDependencies = prev.Dependencies
+			e.NewDependencies = next.Dependencies
+		case AmendFieldValidationDeps:
+			e.PreviousValidationDeps = prev.ValidationDeps
+			e.NewValidationDeps = next.ValidationDeps
+		}
+	}
+	return e
+}
+
 // ScopeOpened is emitted when a local_assume node opens a new assumption scope.
 // All descendant nodes of the assumption node are considered "inside" the scope
 // until the scope is closed.
@@ -475,6 +550,30 @@ func NewClaimRefreshed(nodeID types.NodeID, owner string, newTimeout types.Times
 	}
 }
 
+// NodesReassigned is emitted when claimed nodes are handed from one agent to
+// another in a single step, so no other agent can claim them in between.
+type NodesReassigned struct {
+	BaseEvent
+	NodeIDs   []types.NodeID  `json:"node_ids"`
+	FromOwner string          `json:"from_owner"`
+	ToOwner   string          `json:"to_owner"`
+	Timeout   types.Timestamp `json:"timeout"`
+}
+
+// NewNodesReassigned creates a NodesReassigned event.
+func NewNodesReassigned(nodeIDs []types.NodeID, fromOwner, toOwner string, timeout types.Timestamp) NodesReassigned {
+	return NodesReassigned{
+		BaseEvent: BaseEvent{
+			EventType: EventNodesReassigned,
+			EventTime: types.Now(),
+		},
+		NodeIDs:   nodeIDs,
+		FromOwner: fromOwner,
+		ToOwner:   toOwner,
+		Timeout:   timeout,
+	}
+}
+
 // RefinementRequested is emitted when a verifier requests refinement on a validated node.
 // This reopens the node for further proof development by provers.
 type RefinementRequested struct {
@@ -496,3 +595,129 @@ func NewRefinementRequested(nodeID types.NodeID, reason, requestedBy string) Ref
 		RequestedBy: requestedBy,
 	}
 }
+
+// NodeReopened is emitted when a refuted or archived node is reopened,
+// returning it to the pending epistemic state so it can be revisited.
+// Undo also emits it to revert the validation or admission of a node.
+type NodeReopened struct {
+	BaseEvent
+	NodeID types.NodeID `json:"node_id"`
+}
+
+// NewNodeReopened creates a NodeReopened event.
+func NewNodeReopened(nodeID types.NodeID) NodeReopened {
+	return NodeReopened{
+		BaseEvent: BaseEvent{
+			EventType: EventNodeReopened,
+			EventTime: types.Now(),
+		},
+		NodeID: nodeID,
+	}
+}
+
+// NodeTagged is emitted when a free-form tag is added to a node.
+type NodeTagged struct {
+	BaseEvent
+	NodeID types.NodeID `json:"node_id"`
+	Tag    string       `json:"tag"`
+}
+
+// NewNodeTagged creates a NodeTagged event.
+func NewNodeTagged(nodeID types.NodeID, tag string) NodeTagged {
+	return NodeTagged{
+		BaseEvent: BaseEvent{
+			EventType: EventNodeTagged,
+			EventTime: types.Now(),
+		},
+		NodeID: nodeID,
+		Tag:    tag,
+	}
+}
+
+// NodeUntagged is emitted when a tag is removed from a node.
+type NodeUntagged struct {
+	BaseEvent
+	NodeID types.NodeID `json:"node_id"`
+	Tag    string       `json:"tag"`
+}
+
+// NewNodeUntagged creates a NodeUntagged event.
+func NewNodeUntagged(nodeID types.NodeID, tag string) NodeUntagged {
+	return NodeUntagged{
+		BaseEvent: BaseEvent{
+			EventType: EventNodeUntagged,
+			EventTime: types.Now(),
+		},
+		NodeID: nodeID,
+		Tag:    tag,
+	}
+}
+
+// ChallengeEscalated is emitted when the severity of an open challenge is
+// changed, either raised (escalation) or lowered (de-escalation).
+type ChallengeEscalated struct {
+	BaseEvent
+	ChallengeID      string `json:"challenge_id"`
+	Severity         string `json:"severity" enum:"challenge_severity"`          // The new severity
+	PreviousSeverity string `json:"previous_severity" enum:"challenge_severity"` // The severity before the change
+}
+
+// NewChallengeEscalated creates a ChallengeEscalated event.
+func NewChallengeEscalated(challengeID, previousSeverity, severity string) ChallengeEscalated {
+	return ChallengeEscalated{
+		BaseEvent: BaseEvent{
+			EventType: EventChallengeEscalated,
+			EventTime: types.Now(),
+		},
+		ChallengeID:      challengeID,
+		Severity:         severity,
+		PreviousSeverity: previousSeverity,
+	}
+}
+
+// NoteAdded is emitted when an informational note is left on a node.
+type NoteAdded struct {
+	BaseEvent
+	NodeID types.NodeID `json:"node_id"`
+	NoteID string       `json:"note_id"`
+	Author string       `json:"author"`
+	Text   string       `json:"text"`
+}
+
+// NewNoteAdded creates a NoteAdded event.
+func NewNoteAdded(nodeID types.NodeID, noteID, author, text string) NoteAdded {
+	return NoteAdded{
+		BaseEvent: BaseEvent{
+			EventType: EventNoteAdded,
+			EventTime: types.Now(),
+		},
+		NodeID: nodeID,
+		NoteID: noteID,
+		Author: author,
+		Text:   text,
+	}
+}
+
+// NodeLatexSet is emitted when the LaTeX rendering of a node is attached,
+// changed, or cleared. The statement is left untouched.
+type NodeLatexSet struct {
+	BaseEvent
+	NodeID        types.NodeID `json:"node_id"`
+	PreviousLatex string       `json:"previous_latex,omitempty"`
+	Latex         string       `json:"latex"`
+	Owner         string       `json:"owner"`
+}
+
+// NewNodeLatexSet creates a NodeLatexSet event.
+func NewNodeLatexSet(nodeID types.NodeID, previousLatex, latex, owner string) NodeLatexSet {
+	return NodeLatexSet{
+		BaseEvent: BaseEvent{
+			EventType: EventNodeLatexSet,
+			EventTime: types.Now(),
+		},
+		NodeID:        nodeID,
+		PreviousLatex: previousLatex,
+		Latex:         latex,
+		Owner:         owner,
+	}
+}
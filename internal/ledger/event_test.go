@@ -33,6 +33,7 @@ func TestEventTypeConstants(t *testing.T) {
 		{"DefAdded", EventDefAdded, "def_added"},
 		{"LemmaExtracted", EventLemmaExtracted, "lemma_extracted"},
 		{"RefinementRequested", EventRefinementRequested, "refinement_requested"},
+		{"NodeReopened", EventNodeReopened, "node_reopened"},
 	}
 
 	for _, tt := range tests {
@@ -726,6 +727,7 @@ func TestBaseEventInterface(t *testing.T) {
 		NewDefAdded(Definition{ID: "def", Name: "name", Definition: "def", Created: types.Now()}),
 		NewLemmaExtracted(Lemma{ID: "lemma", Statement: "stmt", NodeID: nodeID, Created: types.Now()}),
 		NewRefinementRequested(nodeID, "reason", "requester"),
+		NewNodeReopened(nodeID),
 	}
 
 	for _, e := range events {
@@ -4,12 +4,18 @@ package ledger
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 )
 
+// MaxSequence is the highest sequence number an event file may carry. It
+// keeps seq+1 from overflowing on any platform; files numbered above it are
+// not event files (see ParseFilename) and are reported by ValidateSequences.
+const MaxSequence = math.MaxInt32
+
 // GenerateFilename creates a filename for an event with the given sequence number.
 // Format: {seq:06d}.json (e.g., 000001.json, 000042.json)
 // The sequence number is zero-padded to at least 6 digits.
@@ -18,7 +24,8 @@ func GenerateFilename(seq int) string {
 }
 
 // ParseFilename extracts the sequence number from an event filename.
-// Returns an error if the filename is not in the expected format.
+// Returns an error if the filename is not in the expected format or the
+// sequence number is above MaxSequence.
 func ParseFilename(name string) (int, error) {
 	if name == "" {
 		return 0, errors.New("empty filename")
@@ -44,6 +51,9 @@ func ParseFilename(name string) (int, error) {
 	if seq < 0 {
 		return 0, errors.New("sequence number cannot be negative")
 	}
+	if seq > MaxSequence {
+		return 0, fmt.Errorf("sequence number exceeds %d", MaxSequence)
+	}
 
 	return seq, nil
 }
@@ -77,6 +77,8 @@ func TestParseFilename(t *testing.T) {
 		{"empty string", "", 0, true},
 		{"just extension", ".json", 0, true},
 		{"negative in name", "-00001.json", 0, true},
+		{"above MaxSequence", "2147483648.json", 0, true},
+		{"at MaxSequence", "2147483647.json", MaxSequence, false},
 	}
 
 	for _, tt := range tests {
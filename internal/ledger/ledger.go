@@ -37,6 +37,12 @@ func (l *Ledger) ReadAll() ([][]byte, error) {
 	return ReadAll(l.dir)
 }
 
+// ReadRange reads at most count events starting at sequence number startSeq.
+// See ReadRange for details.
+func (l *Ledger) ReadRange(startSeq, count int) ([]RangeEvent, error) {
+	return ReadRange(l.dir, startSeq, count)
+}
+
 // Scan iterates over all events in sequence order, calling fn for each.
 // Scanning stops if fn returns an error.
 // If fn returns ErrStopScan, Scan returns nil (clean stop).
@@ -50,6 +56,13 @@ func (l *Ledger) Count() (int, error) {
 	return Count(l.dir)
 }
 
+// ValidateSequences checks the ledger's event file names for out-of-range,
+// duplicate, malformed, and missing sequence numbers.
+// See ValidateSequences for details.
+func (l *Ledger) ValidateSequences() error {
+	return ValidateSequences(l.dir)
+}
+
 // AppendIfSequence adds an event to the ledger only if the current sequence
 // matches the expected value. This implements Compare-And-Swap (CAS) semantics
 // for optimistic concurrency control.
@@ -63,3 +76,34 @@ func (l *Ledger) Count() (int, error) {
 func (l *Ledger) AppendIfSequence(event Event, expectedSeq int) (int, error) {
 	return AppendIfSequence(l.dir, event, expectedSeq)
 }
+
+// AppendBatchIfSequence adds events to the ledger as one all-or-nothing batch,
+// only if the current sequence matches expectedSeq.
+// See AppendBatchIfSequence for details.
+func (l *Ledger) AppendBatchIfSequence(events []Event, expectedSeq int) ([]int, error) {
+	return AppendBatchIfSequence(l.dir, events, expectedSeq)
+}
+
+// ChainStart returns the sequence number from which every event must carry
+// a prev_hash, or 0 if none is recorded. See ReadChainStart for details.
+func (l *Ledger) ChainStart() (int, error) {
+	return ReadChainStart(l.dir)
+}
+
+// ScanFrom iterates over events with sequence numbers greater than after.
+// See ScanFrom for details.
+func (l *Ledger) ScanFrom(after int, fn ScanFunc) error {
+	return ScanFrom(l.dir, after, fn)
+}
+
+// Snapshot writes state as a snapshot of the ledger up to and including event seq.
+// state may be any JSON-serializable value; see WriteSnapshot for details.
+func (l *Ledger) Snapshot(state interface{}, seq int) error {
+	return WriteSnapshot(l.dir, state, seq)
+}
+
+// LoadSnapshot reads and validates the ledger's snapshot.
+// See ReadSnapshot for the errors returned.
+func (l *Ledger) LoadSnapshot() (*Snapshot, error) {
+	return ReadSnapshot(l.dir)
+}
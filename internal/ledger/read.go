@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 )
 
 // MaxEventSize is the maximum size in bytes for a single event file.
@@ -93,6 +94,52 @@ func ReadAll(dir string) ([][]byte, error) {
 	return result, nil
 }
 
+// RangeEvent is a raw event paired with its sequence number, as returned by ReadRange.
+type RangeEvent struct {
+	Seq  int
+	Data []byte
+}
+
+// ReadRange reads at most count events starting at sequence number startSeq.
+// Fewer events are returned if the ledger ends before the window is full, and
+// an empty result is returned if startSeq is past the last event.
+// startSeq must be >= 1 and count must be > 0.
+// Returns an error if a sequence number is missing within the window.
+func ReadRange(dir string, startSeq, count int) ([]RangeEvent, error) {
+	if startSeq < 1 {
+		return nil, fmt.Errorf("invalid start sequence: %d", startSeq)
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid count: %d", count)
+	}
+
+	seqs, err := listEventSequences(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	start := sort.SearchInts(seqs, startSeq)
+	var result []RangeEvent
+	expected := startSeq
+	for _, seq := range seqs[start:] {
+		if len(result) == count {
+			break
+		}
+		if seq != expected {
+			return nil, fmt.Errorf("sequence gap: expected event %d, found %d", expected, seq)
+		}
+
+		data, err := ReadEvent(dir, seq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event %d: %w", seq, err)
+		}
+		result = append(result, RangeEvent{Seq: seq, Data: data})
+		expected++
+	}
+
+	return result, nil
+}
+
 // Scan iterates over all events in sequence order, calling fn for each.
 // Scanning stops if fn returns an error.
 // If fn returns ErrStopScan, Scan returns nil (clean stop).
@@ -153,6 +200,120 @@ func HasGaps(dir string) (bool, error) {
 	return false, nil
 }
 
+// MissingSequences returns the sequence numbers absent from the ledger,
+// i.e. those between 1 and the highest sequence present that have no event
+// file, in ascending order. Returns nil if the sequence is contiguous or empty.
+func MissingSequences(dir string) ([]int, error) {
+	seqs, err := listEventSequences(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []int
+	expected := 1
+	for _, seq := range seqs {
+		for ; expected < seq; expected++ {
+			missing = append(missing, expected)
+		}
+		expected = seq + 1
+	}
+
+	return missing, nil
+}
+
+// ValidateSequences checks the event file names in dir, before any event is
+// read, so that a malformed ledger is rejected as a whole instead of partway
+// through a replay. Each error names the offending file:
+//
+//   - out of range: a numbered .json file whose sequence is 0 or above
+//     MaxSequence (including numbers too large to parse)
+//   - duplicate: two files for one sequence, e.g. 000001.json and 01.json
+//   - malformed: a file whose name is not the canonical GenerateFilename
+//     form of its sequence, e.g. 1.json, which reads would never find
+//   - missing: the highest sequence is above the event count (see Count),
+//     e.g. 000002.json without 000001.json is reported as "missing event 1"
+//
+// Returns nil for an empty or well-formed ledger.
+func ValidateSequences(dir string) error {
+	if err := validateDirectory(dir); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	names := make(map[int][]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		seq, err := ParseFilename(name)
+		if err != nil {
+			if isNumberedJSON(name) {
+				return fmt.Errorf("event file %s: sequence number out of range (must be 1 to %d)", name, MaxSequence)
+			}
+			continue // not an event file
+		}
+		if seq == 0 {
+			return fmt.Errorf("event file %s: sequence number out of range (must be 1 to %d)", name, MaxSequence)
+		}
+		names[seq] = append(names[seq], name)
+	}
+
+	seqs := make([]int, 0, len(names))
+	for seq := range names {
+		seqs = append(seqs, seq)
+	}
+	sort.Ints(seqs)
+
+	for _, seq := range seqs {
+		files := names[seq]
+		if len(files) > 1 {
+			sort.Strings(files)
+			return fmt.Errorf("duplicate sequence number %d: %s", seq, strings.Join(files, ", "))
+		}
+		if want := GenerateFilename(seq); files[0] != want {
+			return fmt.Errorf("malformed event file name %s: want %s", files[0], want)
+		}
+	}
+
+	// With duplicates ruled out, the latest sequence equals the count
+	// exactly when no event is missing
+	if len(seqs) == 0 || seqs[len(seqs)-1] == len(seqs) {
+		return nil
+	}
+	expected := 1
+	for _, seq := range seqs {
+		if seq != expected {
+			missing := fmt.Sprintf("missing event %d", expected)
+			if seq-1 > expected {
+				missing = fmt.Sprintf("missing events %d-%d", expected, seq-1)
+			}
+			return fmt.Errorf("sequence gap detected: %s before %s", missing, names[seq][0])
+		}
+		expected++
+	}
+	return nil
+}
+
+// isNumberedJSON reports whether name is a run of ASCII digits followed by
+// ".json", the shape of an event file name.
+func isNumberedJSON(name string) bool {
+	base := strings.TrimSuffix(name, ".json")
+	if base == name || base == "" {
+		return false
+	}
+	for _, r := range base {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // listEventSequences returns all valid event sequence numbers in sorted order.
 func listEventSequences(dir string) ([]int, error) {
 	if err := validateDirectory(dir); err != nil {
@@ -0,0 +1,104 @@
+package ledger
+
+import (
+	"os"
+	"testing"
+)
+
+// appendN appends n ChallengeResolved events to dir.
+func appendN(t *testing.T, dir string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := Append(dir, NewChallengeResolved("chal")); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+}
+
+func TestReadRange_Window(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 5)
+
+	events, err := ReadRange(dir, 2, 3)
+	if err != nil {
+		t.Fatalf("ReadRange failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("ReadRange returned %d events, want 3", len(events))
+	}
+	for i, e := range events {
+		if want := 2 + i; e.Seq != want {
+			t.Errorf("events[%d].Seq = %d, want %d", i, e.Seq, want)
+		}
+		if len(e.Data) == 0 {
+			t.Errorf("events[%d].Data is empty", i)
+		}
+	}
+}
+
+func TestReadRange_ShortAtEnd(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 3)
+
+	events, err := ReadRange(dir, 2, 10)
+	if err != nil {
+		t.Fatalf("ReadRange failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("ReadRange returned %d events, want 2", len(events))
+	}
+	if events[1].Seq != 3 {
+		t.Errorf("last Seq = %d, want 3", events[1].Seq)
+	}
+
+	events, err = ReadRange(dir, 4, 10)
+	if err != nil {
+		t.Fatalf("ReadRange past end failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("ReadRange past end returned %d events, want 0", len(events))
+	}
+}
+
+func TestReadRange_InvalidArguments(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 1)
+
+	tests := []struct {
+		name            string
+		startSeq, count int
+	}{
+		{"zero start", 0, 1},
+		{"negative start", -1, 1},
+		{"zero count", 1, 0},
+		{"negative count", 1, -5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadRange(dir, tt.startSeq, tt.count); err == nil {
+				t.Errorf("ReadRange(%d, %d) expected error", tt.startSeq, tt.count)
+			}
+		})
+	}
+}
+
+func TestReadRange_GapInWindowIsError(t *testing.T) {
+	dir := t.TempDir()
+	appendN(t, dir, 5)
+	if err := os.Remove(EventFilePath(dir, 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadRange(dir, 2, 3); err == nil {
+		t.Error("expected error for gap inside window")
+	}
+
+	// A gap outside the window does not affect the read.
+	events, err := ReadRange(dir, 4, 2)
+	if err != nil {
+		t.Fatalf("ReadRange after gap failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Seq != 4 {
+		t.Errorf("ReadRange after gap = %+v, want seqs 4,5", events)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -555,6 +557,79 @@ func TestHasGaps_SparseSequenceWithGaps(t *testing.T) {
 	}
 }
 
+// TestMissingSequences verifies that each absent sequence number is reported.
+func TestMissingSequences(t *testing.T) {
+	tests := []struct {
+		name string
+		seqs []int
+		want []int
+	}{
+		{"empty", nil, nil},
+		{"contiguous", []int{1, 2, 3}, nil},
+		{"interior gaps", []int{1, 2, 5, 7}, []int{3, 4, 6}},
+		{"not starting from one", []int{3, 4}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, seq := range tt.seqs {
+				path := filepath.Join(dir, GenerateFilename(seq))
+				content := `{"type":"proof_initialized","timestamp":"2025-01-01T00:00:00Z","conjecture":"test","author":"agent"}`
+				os.WriteFile(path, []byte(content), 0644)
+			}
+
+			got, err := MissingSequences(dir)
+			if err != nil {
+				t.Fatalf("MissingSequences failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MissingSequences = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSequences(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		wantErr string // empty for no error
+	}{
+		{"empty", nil, ""},
+		{"contiguous", []string{"000001.json", "000002.json"}, ""},
+		{"ignores non-event files", []string{"000001.json", "meta.json", "x1.json"}, ""},
+		{"missing first", []string{"000002.json"}, "missing event 1 before 000002.json"},
+		{"missing range", []string{"000001.json", "000005.json"}, "missing events 2-4 before 000005.json"},
+		{"zero", []string{"000000.json", "000001.json"}, "000000.json: sequence number out of range"},
+		{"too large", []string{"000001.json", "4294967296.json"}, "4294967296.json: sequence number out of range"},
+		{"duplicate", []string{"000001.json", "0000001.json"}, "duplicate sequence number 1: 0000001.json, 000001.json"},
+		{"unpadded", []string{"1.json"}, "malformed event file name 1.json: want 000001.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := ValidateSequences(dir)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSequences() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSequences() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 // TestReadEventTyped_Success verifies typed event reading.
 func TestReadEventTyped_Success(t *testing.T) {
 	dir := t.TempDir()
@@ -0,0 +1,180 @@
+// Package ledger provides event-sourced ledger operations for the AF proof framework.
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SnapshotFileName is the name of the snapshot file within the ledger directory.
+// It does not match the event filename pattern, so it is ignored by Scan and Count.
+const SnapshotFileName = "snapshot.json"
+
+// SnapshotVersion is the current snapshot format version.
+// Snapshots with a different version are treated as absent.
+const SnapshotVersion = 2
+
+// ErrNoSnapshot is returned when the ledger has no usable snapshot.
+var ErrNoSnapshot = errors.New("no snapshot")
+
+// ErrSnapshotStale is returned when a snapshot no longer matches the ledger,
+// e.g. because the ledger was truncated or rewound past the snapshot boundary.
+var ErrSnapshotStale = errors.New("snapshot does not match ledger")
+
+// Snapshot is derived state captured at a given ledger sequence number.
+// The boundary hash ties the snapshot to the exact event it was taken after,
+// so a snapshot is only used while that event is unchanged.
+type Snapshot struct {
+	Version      int             `json:"version"`
+	Seq          int             `json:"seq"`
+	BoundaryHash string          `json:"boundary_hash"`
+	State        json.RawMessage `json:"state"`
+}
+
+// WriteSnapshot writes state (any JSON-serializable value) to the snapshot file,
+// recording that it represents the ledger up to and including event seq.
+// The write is atomic: the snapshot is first written to a temp file, then renamed.
+func WriteSnapshot(dir string, state interface{}, seq int) error {
+	if err := validateDirectory(dir); err != nil {
+		return err
+	}
+	if seq <= 0 {
+		return fmt.Errorf("invalid snapshot sequence number: %d", seq)
+	}
+
+	hash, err := eventHash(dir, seq)
+	if err != nil {
+		return fmt.Errorf("snapshot boundary: %w", err)
+	}
+
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot state: %w", err)
+	}
+
+	data, err := json.Marshal(Snapshot{
+		Version:      SnapshotVersion,
+		Seq:          seq,
+		BoundaryHash: hash,
+		State:        stateData,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	// Create temp file for atomic write
+	tempFile, err := os.CreateTemp(dir, ".snapshot-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the write error
+		return fmt.Errorf("failed to write snapshot data: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the sync error
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the close error
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filepath.Join(dir, SnapshotFileName)); err != nil {
+		_ = os.Remove(tempPath) // Best-effort cleanup; don't mask the rename error
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return nil
+}
+
+// ReadSnapshot reads the snapshot file and validates it against the ledger.
+// Returns ErrNoSnapshot if there is no snapshot (or it has an unknown version),
+// and ErrSnapshotStale if the event at the snapshot boundary is missing or has
+// changed since the snapshot was taken.
+func ReadSnapshot(dir string) (*Snapshot, error) {
+	if err := validateDirectory(dir); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSnapshot
+		}
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if snap.Version != SnapshotVersion {
+		return nil, ErrNoSnapshot
+	}
+	if snap.Seq <= 0 {
+		return nil, fmt.Errorf("invalid snapshot sequence number: %d", snap.Seq)
+	}
+
+	hash, err := eventHash(dir, snap.Seq)
+	if err != nil || hash != snap.BoundaryHash {
+		return nil, fmt.Errorf("%w: event %d", ErrSnapshotStale, snap.Seq)
+	}
+
+	return &snap, nil
+}
+
+// RemoveSnapshot deletes the snapshot file if present.
+func RemoveSnapshot(dir string) error {
+	err := os.Remove(filepath.Join(dir, SnapshotFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove snapshot: %w", err)
+	}
+	return nil
+}
+
+// ScanFrom iterates over events with sequence numbers greater than after,
+// in sequence order, calling fn for each. Events at or before after are not read.
+// Stop semantics match Scan.
+func ScanFrom(dir string, after int, fn ScanFunc) error {
+	seqs, err := listEventSequences(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, seq := range seqs {
+		if seq <= after {
+			continue
+		}
+
+		data, err := ReadEvent(dir, seq)
+		if err != nil {
+			return fmt.Errorf("failed to read event %d: %w", seq, err)
+		}
+
+		if err := fn(seq, data); err != nil {
+			if err == ErrStopScan {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eventHash returns the hex-encoded SHA-256 of the raw bytes of event seq.
+func eventHash(dir string, seq int) (string, error) {
+	data, err := ReadEvent(dir, seq)
+	if err != nil {
+		return "", err
+	}
+	return HashEvent(data), nil
+}
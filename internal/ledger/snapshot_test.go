@@ -0,0 +1,123 @@
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testSnapshotState struct {
+	Nodes []string `json:"nodes"`
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+	}, dir)
+
+	if err := WriteSnapshot(dir, testSnapshotState{Nodes: []string{"1"}}, 2); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	snap, err := ReadSnapshot(dir)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+	if snap.Seq != 2 {
+		t.Errorf("Seq = %d, want 2", snap.Seq)
+	}
+	if string(snap.State) != `{"nodes":["1"]}` {
+		t.Errorf("State = %s, want {\"nodes\":[\"1\"]}", snap.State)
+	}
+
+	// The snapshot file must not be mistaken for an event
+	if n, err := Count(dir); err != nil || n != 2 {
+		t.Errorf("Count() = %d, %v; want 2, nil", n, err)
+	}
+}
+
+func TestSnapshot_Missing(t *testing.T) {
+	if _, err := ReadSnapshot(t.TempDir()); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("ReadSnapshot on empty dir: got %v, want ErrNoSnapshot", err)
+	}
+}
+
+func TestSnapshot_StaleBoundary(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T, dir string)
+	}{
+		{
+			name: "boundary event truncated",
+			mutate: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, GenerateFilename(2))); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "boundary event rewritten",
+			mutate: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, GenerateFilename(2))); err != nil {
+					t.Fatal(err)
+				}
+				appendAll(t, []Event{NewChallengeWithdrawn("chal-other")}, dir)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			appendAll(t, []Event{
+				NewProofInitialized("conjecture", "agent"),
+				NewChallengeResolved("chal-1"),
+			}, dir)
+			if err := WriteSnapshot(dir, testSnapshotState{}, 2); err != nil {
+				t.Fatalf("WriteSnapshot failed: %v", err)
+			}
+
+			tt.mutate(t, dir)
+
+			if _, err := ReadSnapshot(dir); !errors.Is(err, ErrSnapshotStale) {
+				t.Errorf("ReadSnapshot: got %v, want ErrSnapshotStale", err)
+			}
+		})
+	}
+}
+
+func TestSnapshot_InvalidSeq(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{NewProofInitialized("conjecture", "agent")}, dir)
+
+	if err := WriteSnapshot(dir, testSnapshotState{}, 0); err == nil {
+		t.Error("expected error for snapshot at sequence 0")
+	}
+	if err := WriteSnapshot(dir, testSnapshotState{}, 5); err == nil {
+		t.Error("expected error for snapshot beyond the end of the ledger")
+	}
+}
+
+func TestScanFrom_SkipsPrefix(t *testing.T) {
+	dir := t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-1"),
+		NewChallengeResolved("chal-2"),
+	}, dir)
+
+	var seen []int
+	err := ScanFrom(dir, 1, func(seq int, data []byte) error {
+		seen = append(seen, seq)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanFrom failed: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != 2 || seen[1] != 3 {
+		t.Errorf("ScanFrom(1) visited %v, want [2 3]", seen)
+	}
+}
@@ -0,0 +1,339 @@
+// Package ledger provides event-sourced ledger operations for the AF proof framework.
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrLedgerNotEmpty is returned by Import when the target ledger already
+// contains events.
+var ErrLedgerNotEmpty = errors.New("ledger is not empty")
+
+// Export serializes every event in the ledger as a JSON array, in sequence
+// order. Each element is the event's raw bytes exactly as stored on disk, so
+// prev_hash links survive a round trip through Import.
+// Returns an error if the ledger has a gap in its sequence numbers.
+func Export(dir string) ([]byte, error) {
+	seqs, err := contiguousSequences(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, seq := range seqs {
+		data, err := ReadEvent(dir, seq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event %d: %w", seq, err)
+		}
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+		buf.Write(bytes.TrimSpace(data))
+	}
+	if len(seqs) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+
+	return buf.Bytes(), nil
+}
+
+// Import writes the events of a JSON array produced by Export into an empty
+// ledger directory as event files 1..n.
+//
+// The whole stream is verified before anything is written: every element
+// must be a JSON object with a type no larger than MaxEventSize, the
+// prev_hash chain must be continuous, and every node_created event must
+// carry a node whose content hash matches its content. If writing an event
+// fails, the events already written are removed, so a failed import leaves
+// dir without events.
+//
+// Returns ErrLedgerNotEmpty if dir already contains events.
+func Import(dir string, data []byte) error {
+	if err := validateDirectory(dir); err != nil {
+		return err
+	}
+
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil {
+		return fmt.Errorf("failed to parse event stream: %w", err)
+	}
+
+	verifier := NewChainVerifier()
+	for i, raw := range events {
+		seq := i + 1
+		if err := verifyImportedEvent(seq, raw); err != nil {
+			return err
+		}
+		if err := verifier.Verify(seq, raw); err != nil {
+			return err
+		}
+	}
+
+	lock := NewLedgerLock(dir)
+	if err := lock.Acquire("import-operation", defaultLockTimeout); err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer releaseLock(lock, "import")
+
+	// Check emptiness inside the lock so a concurrent append can't be clobbered
+	seqs, err := listEventSequences(dir)
+	if err != nil {
+		return err
+	}
+	if len(seqs) > 0 {
+		return fmt.Errorf("%w: %s contains %d events", ErrLedgerNotEmpty, dir, len(seqs))
+	}
+
+	raws := make([][]byte, len(events))
+	for i, raw := range events {
+		raws[i] = raw
+	}
+	return writeEventFiles(dir, raws)
+}
+
+// verifyImportedEvent checks a single event from an import stream.
+func verifyImportedEvent(seq int, raw []byte) error {
+	if len(raw) > MaxEventSize {
+		return fmt.Errorf("event %d: %w (size: %d bytes)", seq, ErrEventTooLarge, len(raw))
+	}
+
+	var base BaseEvent
+	if err := json.Unmarshal(raw, &base); err != nil {
+		return fmt.Errorf("event %d is not a JSON object: %w", seq, err)
+	}
+	if base.EventType == "" {
+		return fmt.Errorf("event %d has no type", seq)
+	}
+
+	if base.EventType == EventNodeCreated {
+		var created NodeCreated
+		if err := json.Unmarshal(raw, &created); err != nil {
+			return fmt.Errorf("event %d: failed to decode node_created: %w", seq, err)
+		}
+		if !created.Node.VerifyContentHash() {
+			return fmt.Errorf("event %d: content hash mismatch for node %s", seq, created.Node.ID.String())
+		}
+	}
+
+	return nil
+}
+
+// writeEventFiles writes events into the empty ledger in dir as event files
+// 1..n, recording the first of them that carries a prev_hash as the start of
+// the ledger's chain (see ReadChainStart). If any write fails, the files
+// already written are removed again, so the ledger is left as empty as it
+// was found.
+// The caller must hold the ledger lock.
+func writeEventFiles(dir string, events [][]byte) error {
+	if start := firstChainedSeq(events); start > 0 {
+		if err := writeChainStart(dir, start); err != nil {
+			return err
+		}
+	}
+	for i, data := range events {
+		if err := writeEventFile(dir, i+1, data); err != nil {
+			for seq := 1; seq <= i; seq++ {
+				_ = os.Remove(filepath.Join(dir, GenerateFilename(seq)))
+			}
+			_ = os.Remove(filepath.Join(dir, ChainFileName))
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEventFile atomically writes data as event file seq in dir.
+// The caller must hold the ledger lock.
+func writeEventFile(dir string, seq int, data []byte) error {
+	tempFile, err := os.CreateTemp(dir, ".event-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for event %d: %w", seq, err)
+	}
+	tempPath := tempFile.Name()
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to write event %d: %w", seq, err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to sync event %d: %w", seq, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to close temp file for event %d: %w", seq, err)
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to set permissions for event %d: %w", seq, err)
+	}
+
+	finalPath := filepath.Join(dir, GenerateFilename(seq))
+	if err := os.Rename(tempPath, finalPath); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename temp file for event %d: %w", seq, err)
+	}
+
+	return nil
+}
+
+// Copy copies every event of the ledger in srcDir into the empty ledger in
+// destDir, byte for byte and under the same sequence numbers, so the copy's
+// prev_hash chain and content hashes are identical to the source's.
+//
+// Every event is verified with the same checks Import applies before
+// anything is written, so a failed copy leaves destDir without events.
+//
+// Returns the number of events copied, or ErrLedgerNotEmpty if destDir
+// already contains events.
+func Copy(srcDir, destDir string) (int, error) {
+	if err := validateDirectory(destDir); err != nil {
+		return 0, err
+	}
+	seqs, err := contiguousSequences(srcDir)
+	if err != nil {
+		return 0, err
+	}
+
+	chainStart, err := ReadChainStart(srcDir)
+	if err != nil {
+		return 0, err
+	}
+
+	events := make([][]byte, len(seqs))
+	verifier := NewChainVerifierFrom(chainStart)
+	for i, seq := range seqs {
+		data, err := ReadEvent(srcDir, seq)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read event %d: %w", seq, err)
+		}
+		if err := verifyImportedEvent(seq, data); err != nil {
+			return 0, err
+		}
+		if err := verifier.Verify(seq, data); err != nil {
+			return 0, err
+		}
+		events[i] = data
+	}
+
+	lock := NewLedgerLock(destDir)
+	if err := lock.Acquire("copy-operation", defaultLockTimeout); err != nil {
+		return 0, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer releaseLock(lock, "copy")
+
+	existing, err := listEventSequences(destDir)
+	if err != nil {
+		return 0, err
+	}
+	if len(existing) > 0 {
+		return 0, fmt.Errorf("%w: %s contains %d events", ErrLedgerNotEmpty, destDir, len(existing))
+	}
+
+	if err := writeEventFiles(destDir, events); err != nil {
+		return 0, err
+	}
+
+	return len(seqs), nil
+}
+
+// Rebuild writes events into the empty ledger in dir as event files 1..n,
+// for rewriting a ledger into a new directory. Any prev_hash an event
+// carries is discarded and the hash chain is recomputed over the new
+// sequence, so events may be dropped or edited relative to their source.
+//
+// Every event must pass the checks Import applies to a single event; all of
+// them are verified before anything is written.
+//
+// Returns ErrLedgerNotEmpty if dir already contains events.
+func Rebuild(dir string, events []json.RawMessage) error {
+	if err := validateDirectory(dir); err != nil {
+		return err
+	}
+
+	chained := make([][]byte, len(events))
+	prevHash := GenesisHash
+	for i, raw := range events {
+		seq := i + 1
+		if err := verifyImportedEvent(seq, raw); err != nil {
+			return err
+		}
+
+		unlinked, err := stripPrevHash(raw)
+		if err != nil {
+			return fmt.Errorf("event %d: %w", seq, err)
+		}
+
+		data, err := linkEvent(unlinked, prevHash)
+		if err != nil {
+			return fmt.Errorf("event %d: %w", seq, err)
+		}
+		chained[i] = data
+		prevHash = HashEvent(data)
+	}
+
+	lock := NewLedgerLock(dir)
+	if err := lock.Acquire("rebuild-operation", defaultLockTimeout); err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer releaseLock(lock, "rebuild")
+
+	seqs, err := listEventSequences(dir)
+	if err != nil {
+		return err
+	}
+	if len(seqs) > 0 {
+		return fmt.Errorf("%w: %s contains %d events", ErrLedgerNotEmpty, dir, len(seqs))
+	}
+
+	return writeEventFiles(dir, chained)
+}
+
+// stripPrevHash returns the JSON object data without its top-level
+// prev_hash field. The other fields keep their order, since replay reads the
+// event type from the first "type" key.
+func stripPrevHash(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("event is not a JSON object")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event field: %w", err)
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to read event field %q: %w", key, err)
+		}
+		if key == "prev_hash" {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,298 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newTransferNode creates a valid root node for export/import tests.
+func newTransferNode(t *testing.T) node.Node {
+	t.Helper()
+	id, err := types.Parse("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := node.NewNode(id, schema.NodeTypeClaim, "Root claim", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
+	}
+	return *n
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	n := newTransferNode(t)
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewNodeCreated(n),
+		NewNodeValidated(n.ID),
+	}, src)
+
+	data, err := Export(src)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil || len(events) != 3 {
+		t.Fatalf("Export produced %d events (err %v), want JSON array of 3", len(events), err)
+	}
+
+	if err := Import(dst, data); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	for seq := 1; seq <= 3; seq++ {
+		a, err := ReadEvent(src, seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ReadEvent(dst, seq)
+		if err != nil {
+			t.Fatalf("imported event %d missing: %v", seq, err)
+		}
+		if !bytes.Equal(a, b) {
+			t.Errorf("event %d differs after round trip:\n%s\n%s", seq, a, b)
+		}
+	}
+
+	// The imported ledger keeps a valid hash chain, so appends continue it.
+	if _, err := Append(dst, NewChallengeResolved("chal-1")); err != nil {
+		t.Fatalf("Append after import failed: %v", err)
+	}
+}
+
+func TestExport_EmptyLedger(t *testing.T) {
+	data, err := Export(t.TempDir())
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil || len(events) != 0 {
+		t.Errorf("Export of empty ledger = %q, want empty JSON array", data)
+	}
+}
+
+func TestImport_RefusesNonEmptyLedger(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	appendAll(t, []Event{NewProofInitialized("conjecture", "agent")}, src, dst)
+
+	data, err := Export(src)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if err := Import(dst, data); !errors.Is(err, ErrLedgerNotEmpty) {
+		t.Errorf("Import error = %v, want ErrLedgerNotEmpty", err)
+	}
+}
+
+func TestImport_RejectsInvalidStreams(t *testing.T) {
+	src := t.TempDir()
+	n := newTransferNode(t)
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewNodeCreated(n),
+	}, src)
+	data, err := Export(src)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tampering with the node statement breaks its content hash.
+	tampered := bytes.Replace(events[1], []byte("Root claim"), []byte("Root claim!"), 1)
+
+	tests := []struct {
+		name   string
+		stream []byte
+	}{
+		{"not an array", []byte(`{"type":"proof_initialized"}`)},
+		{"missing type", []byte(`[{"conjecture":"x"}]`)},
+		{"reordered events", mustMarshalRaw(t, events[1], events[0])},
+		{"dropped event breaks chain", mustMarshalRaw(t, events[1])},
+		{"content hash mismatch", mustMarshalRaw(t, events[0], tampered)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := t.TempDir()
+			if err := Import(dst, tt.stream); err == nil {
+				t.Fatal("expected Import to fail")
+			}
+			entries, err := os.ReadDir(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				if _, err := ParseFilename(e.Name()); err == nil {
+					t.Errorf("failed import left event file %s", filepath.Join(dst, e.Name()))
+				}
+			}
+		})
+	}
+}
+
+func TestImport_WriteFailureRemovesWrittenEvents(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewNodeCreated(newTransferNode(t)),
+	}, src)
+	data, err := Export(src)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	// A directory in place of event 2 makes its write fail after event 1
+	// has been written.
+	blocker := filepath.Join(dst, GenerateFilename(2))
+	if err := os.MkdirAll(filepath.Join(blocker, "x"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Import(dst, data); err == nil {
+		t.Fatal("expected Import to fail")
+	}
+	if _, err := os.Stat(filepath.Join(dst, GenerateFilename(1))); !os.IsNotExist(err) {
+		t.Errorf("failed import left event 1 behind (stat error: %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, ChainFileName)); !os.IsNotExist(err) {
+		t.Errorf("failed import left the chain start behind (stat error: %v)", err)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	n := newTransferNode(t)
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewNodeCreated(n),
+		NewNodeValidated(n.ID),
+	}, src)
+
+	count, err := Copy(src, dst)
+	if err != nil {
+		t.Fatalf("Copy failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Copy copied %d events, want 3", count)
+	}
+	cmp, err := Compare(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Identical() {
+		t.Errorf("copy differs from source: %+v", cmp)
+	}
+	if start, err := ReadChainStart(dst); err != nil || start != 1 {
+		t.Errorf("copy chain start = %d (err %v), want 1", start, err)
+	}
+
+	// The copy is a ledger of its own: appends to it leave the source alone.
+	if _, err := Append(dst, NewChallengeResolved("chal-1")); err != nil {
+		t.Fatalf("Append after copy failed: %v", err)
+	}
+	if n, err := Count(src); err != nil || n != 3 {
+		t.Errorf("source has %d events (err %v) after appending to the copy, want 3", n, err)
+	}
+
+	if _, err := Copy(src, dst); !errors.Is(err, ErrLedgerNotEmpty) {
+		t.Errorf("Copy into non-empty ledger error = %v, want ErrLedgerNotEmpty", err)
+	}
+}
+
+func TestCopy_RejectsTamperedEvent(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewNodeCreated(newTransferNode(t)),
+	}, src)
+
+	path := EventFilePath(src, 2)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Replace(data, []byte("Root claim"), []byte("Root claim!"), 1)
+	if err := os.WriteFile(path, tampered, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Copy(src, dst); err == nil {
+		t.Fatal("expected Copy to fail on a content hash mismatch")
+	}
+	if n, err := Count(dst); err != nil || n != 0 {
+		t.Errorf("failed copy left %d events (err %v), want 0", n, err)
+	}
+}
+
+func TestRebuild_RechainsEvents(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	n := newTransferNode(t)
+	appendAll(t, []Event{
+		NewProofInitialized("conjecture", "agent"),
+		NewChallengeResolved("chal-0"),
+		NewNodeCreated(n),
+		NewNodeValidated(n.ID),
+	}, src)
+	data, err := Export(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dropping event 2 breaks the source chain; Rebuild recomputes it.
+	kept := []json.RawMessage{events[0], events[2], events[3]}
+	if err := Rebuild(dst, kept); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	verifier := NewChainVerifier()
+	err = Scan(dst, func(seq int, data []byte) error {
+		return verifier.Verify(seq, data)
+	})
+	if err != nil {
+		t.Errorf("rebuilt ledger has a broken chain: %v", err)
+	}
+	if count, err := Count(dst); err != nil || count != 3 {
+		t.Errorf("rebuilt ledger has %d events (err %v), want 3", count, err)
+	}
+	if _, err := Append(dst, NewNodeArchived(n.ID)); err != nil {
+		t.Errorf("Append after Rebuild failed: %v", err)
+	}
+
+	// Fields keep their order, with the event type first
+	first, err := ReadEvent(dst, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(first, []byte(`{"type":"node_created"`)) {
+		t.Errorf("rebuilt event lost its field order: %s", first)
+	}
+
+	if err := Rebuild(dst, kept); !errors.Is(err, ErrLedgerNotEmpty) {
+		t.Errorf("Rebuild into non-empty ledger error = %v, want ErrLedgerNotEmpty", err)
+	}
+}
+
+// mustMarshalRaw encodes raw events as a JSON array.
+func mustMarshalRaw(t *testing.T, events ...json.RawMessage) []byte {
+	t.Helper()
+	data, err := json.Marshal(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,113 @@
+// Package lock provides node locking for exclusive agent access.
+package lock
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// NodeSet holds one mutex per node and serializes in-process operations
+// that touch several nodes at once.
+//
+// LockAll acquires the mutexes of a set of nodes in canonical order (sorted
+// by NodeID, duplicates removed) and UnlockAll releases them in reverse.
+// Because every caller acquires in the same order, a caller waiting for a
+// node never holds a node that sorts after it, so two callers grabbing
+// overlapping sets can never wait on each other in a cycle: sorted
+// acquisition prevents deadlock. Callers must not lock a second set while
+// holding one.
+//
+// NodeSet only coordinates goroutines within a process. Concurrent writers in
+// other processes are still detected by the ledger's sequence check.
+type NodeSet struct {
+	mu    sync.Mutex
+	nodes map[string]*sync.Mutex // keyed by NodeID.String()
+}
+
+// NewNodeSet creates a NodeSet with no nodes locked.
+func NewNodeSet() *NodeSet {
+	return &NodeSet{
+		nodes: make(map[string]*sync.Mutex),
+	}
+}
+
+// LockAll blocks until the locks of all ids are held, acquiring them in
+// canonical order. It returns the ids in that order.
+func (ns *NodeSet) LockAll(ids []types.NodeID) []types.NodeID {
+	ordered := canonicalOrder(ids)
+	for _, id := range ordered {
+		ns.nodeMutex(id).Lock()
+	}
+	return ordered
+}
+
+// UnlockAll releases the locks of ids, taken with LockAll, in reverse
+// canonical order.
+func (ns *NodeSet) UnlockAll(ids []types.NodeID) {
+	ordered := canonicalOrder(ids)
+	for i := len(ordered) - 1; i >= 0; i-- {
+		ns.nodeMutex(ordered[i]).Unlock()
+	}
+}
+
+// nodeMutex returns the mutex of id, creating it on first use.
+func (ns *NodeSet) nodeMutex(id types.NodeID) *sync.Mutex {
+	key := id.String()
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	m, ok := ns.nodes[key]
+	if !ok {
+		m = &sync.Mutex{}
+		ns.nodes[key] = m
+	}
+	return m
+}
+
+// canonicalOrder returns ids sorted by NodeID with duplicates removed.
+// Locking a node twice would block forever, so duplicates must go.
+func canonicalOrder(ids []types.NodeID) []types.NodeID {
+	ordered := make([]types.NodeID, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id.String()] {
+			continue
+		}
+		seen[id.String()] = true
+		ordered = append(ordered, id)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Less(ordered[j])
+	})
+	return ordered
+}
+
+// nodeSetRegistry provides one NodeSet per proof directory, so every
+// service working on the same proof in this process shares its node locks.
+var (
+	nodeSetRegistry     = make(map[string]*NodeSet)
+	nodeSetRegistryLock sync.Mutex
+)
+
+// NodeSetFor returns the NodeSet shared by every caller in this process for
+// the proof directory at path, creating it on first use. Paths are compared
+// after conversion to absolute, cleaned form.
+func NodeSetFor(path string) *NodeSet {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	nodeSetRegistryLock.Lock()
+	defer nodeSetRegistryLock.Unlock()
+
+	ns, ok := nodeSetRegistry[path]
+	if !ok {
+		ns = NewNodeSet()
+		nodeSetRegistry[path] = ns
+	}
+	return ns
+}
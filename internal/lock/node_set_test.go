@@ -0,0 +1,110 @@
+// Package lock provides node locking for exclusive agent access.
+package lock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func mustParseIDs(t *testing.T, ids ...string) []types.NodeID {
+	t.Helper()
+	parsed := make([]types.NodeID, len(ids))
+	for i, s := range ids {
+		id, err := types.Parse(s)
+		if err != nil {
+			t.Fatalf("failed to parse node ID %q: %v", s, err)
+		}
+		parsed[i] = id
+	}
+	return parsed
+}
+
+func TestNodeSet_LockAllCanonicalOrder(t *testing.T) {
+	ns := NewNodeSet()
+	ids := mustParseIDs(t, "1.10", "1.2", "1", "1.2", "1.1.1")
+
+	done := make(chan []types.NodeID, 1)
+	go func() {
+		// The duplicate 1.2 must not make LockAll wait on itself
+		ordered := ns.LockAll(ids)
+		ns.UnlockAll(ids)
+		done <- ordered
+	}()
+
+	select {
+	case ordered := <-done:
+		got := types.ToStringSlice(ordered)
+		want := []string{"1", "1.1.1", "1.2", "1.10"}
+		if len(got) != len(want) {
+			t.Fatalf("LockAll order = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("LockAll order = %v, want %v", got, want)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("LockAll with a duplicate ID did not return")
+	}
+}
+
+// TestNodeSet_OverlappingSetsNoDeadlock has goroutines lock overlapping node
+// sets, each listed in a different order. Locking in listed order could
+// deadlock; LockAll must not.
+func TestNodeSet_OverlappingSetsNoDeadlock(t *testing.T) {
+	ns := NewNodeSet()
+	sets := [][]types.NodeID{
+		mustParseIDs(t, "1.1", "1.2", "1.3"),
+		mustParseIDs(t, "1.3", "1.2", "1.1"),
+		mustParseIDs(t, "1.2", "1.4", "1.1"),
+		mustParseIDs(t, "1.4", "1.3"),
+	}
+
+	const rounds = 200
+	counts := make(map[string]int) // guarded by the node locks: every pair of sets overlaps
+	var wg sync.WaitGroup
+	for _, set := range sets {
+		wg.Add(1)
+		go func(set []types.NodeID) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				ns.LockAll(set)
+				for _, id := range set {
+					counts[id.String()]++
+				}
+				ns.UnlockAll(set)
+			}
+		}(set)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("goroutines locking overlapping node sets deadlocked")
+	}
+
+	want := map[string]int{"1.1": 3 * rounds, "1.2": 3 * rounds, "1.3": 3 * rounds, "1.4": 2 * rounds}
+	for id, n := range want {
+		if counts[id] != n {
+			t.Errorf("count[%s] = %d, want %d", id, counts[id], n)
+		}
+	}
+}
+
+func TestNodeSetFor_SharedPerPath(t *testing.T) {
+	dir := t.TempDir()
+	if NodeSetFor(dir) != NodeSetFor(dir+"/.") {
+		t.Error("NodeSetFor returned different sets for the same directory")
+	}
+	if NodeSetFor(dir) == NodeSetFor(t.TempDir()) {
+		t.Error("NodeSetFor returned the same set for different directories")
+	}
+}
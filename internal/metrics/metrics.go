@@ -4,7 +4,9 @@
 package metrics
 
 import (
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/schema"
@@ -36,8 +38,248 @@ type QualityReport struct {
 	DefinedRefs        int     `json:"defined_refs"`
 	DefinitionCoverage float64 `json:"definition_coverage"`
 
+	// Taint metrics (tainted or self-admitted nodes carry epistemic debt)
+	TaintedNodes int `json:"tainted_nodes"`
+
+	// Assumption metrics
+	AssumptionRefs    int     `json:"assumption_refs"`
+	AssumptionDensity float64 `json:"assumption_density"`
+
+	// Verbosity metrics (statements longer than VerboseStatementLength)
+	VerboseNodes int `json:"verbose_nodes"`
+
 	// Composite score (0-100)
 	QualityScore float64 `json:"quality_score"`
+
+	// Breakdown explains how QualityScore was derived.
+	// Only populated when requested via WithBreakdown.
+	Breakdown *ScoreBreakdown `json:"breakdown,omitempty"`
+
+	// Nodes holds the score of every node in the report except archived
+	// ones, sorted by node ID. See WeakestNodes for the weakest first.
+	Nodes []NodeScore `json:"nodes,omitempty"`
+}
+
+// Score weights in points. Each factor contributes Weight * Ratio to the
+// composite score, where Ratio is a health measure in [0, 1]. The weights
+// sum to 100 so a perfectly healthy proof scores 100.
+const (
+	// WeightValidation rewards validated and admitted nodes.
+	WeightValidation = 30.0
+	// WeightChallenges penalizes open challenges per node.
+	WeightChallenges = 20.0
+	// WeightDefinitions rewards referenced terms that have definitions.
+	WeightDefinitions = 15.0
+	// WeightTaint penalizes tainted and self-admitted nodes.
+	WeightTaint = 15.0
+	// WeightAssumptions penalizes heavy reliance on assumptions.
+	WeightAssumptions = 10.0
+	// WeightVerbosity penalizes overly long statements.
+	WeightVerbosity = 10.0
+)
+
+// Per-node score weights in points. Like the composite weights, each factor
+// contributes Weight * Ratio and the weights sum to 100.
+const (
+	// NodeWeightStatus rewards validated nodes and, less, admitted ones.
+	NodeWeightStatus = 40.0
+	// NodeWeightChallenges penalizes open challenges, weighted by severity.
+	NodeWeightChallenges = 25.0
+	// NodeWeightTaint penalizes tainted, self-admitted, and unresolved taint.
+	NodeWeightTaint = 20.0
+	// NodeWeightDependencies rewards dependencies that are validated or admitted.
+	NodeWeightDependencies = 15.0
+)
+
+// NodeAdmittedRatio is the status health of an admitted node: it is
+// accepted, but without proof.
+const NodeAdmittedRatio = 0.5
+
+// NodeUnresolvedTaintRatio is the taint health of a node whose taint has
+// not been computed yet.
+const NodeUnresolvedTaintRatio = 0.5
+
+// Penalties for a single open challenge on a node, by severity, as a
+// fraction of NodeWeightChallenges. The total penalty is capped at 1.
+// Challenges with an unknown severity count as critical.
+const (
+	ChallengePenaltyCritical = 1.0
+	ChallengePenaltyMajor    = 0.5
+	ChallengePenaltyMinor    = 0.2
+	ChallengePenaltyNote     = 0.0
+)
+
+// VerboseStatementLength is the statement length (in characters) above which
+// a node is counted as a verbosity outlier. Long statements usually bundle
+// several steps that should be separate refinements.
+const VerboseStatementLength = 500
+
+// Score component names used in ScoreBreakdown.
+const (
+	ComponentValidation  = "validation"
+	ComponentChallenges  = "challenges"
+	ComponentDefinitions = "definitions"
+	ComponentTaint       = "taint"
+	ComponentAssumptions = "assumptions"
+	ComponentVerbosity   = "verbosity"
+)
+
+// ScoreComponent is a single factor's contribution to the quality score.
+type ScoreComponent struct {
+	// Name identifies the factor (see Component* constants).
+	Name string `json:"name"`
+	// Weight is the maximum number of points this factor can contribute.
+	Weight float64 `json:"weight"`
+	// Ratio is the factor's health in [0, 1]; 1 means no penalty.
+	Ratio float64 `json:"ratio"`
+	// Points is Weight * Ratio.
+	Points float64 `json:"points"`
+}
+
+// ScoreBreakdown lists the components of a quality score.
+// Total is the sum of all component points and equals QualityScore.
+type ScoreBreakdown struct {
+	Components []ScoreComponent `json:"components"`
+	Total      float64          `json:"total"`
+}
+
+// Component returns the component with the given name, or nil if not present.
+func (b *ScoreBreakdown) Component(name string) *ScoreComponent {
+	for i := range b.Components {
+		if b.Components[i].Name == name {
+			return &b.Components[i]
+		}
+	}
+	return nil
+}
+
+// NodeScore is the quality score of a single node.
+type NodeScore struct {
+	NodeID         types.NodeID `json:"node_id"`
+	EpistemicState string       `json:"epistemic_state"`
+	TaintState     string       `json:"taint_state"`
+
+	// OpenChallenges is the number of open challenges on the node.
+	OpenChallenges int `json:"open_challenges"`
+
+	// Dependencies and SatisfiedDependencies count the node's dependencies
+	// and validation dependencies, and those that are validated or admitted.
+	Dependencies          int `json:"dependencies"`
+	SatisfiedDependencies int `json:"satisfied_dependencies"`
+
+	// Score is the weighted sum of the node's factors (0-100); see the
+	// NodeWeight* constants.
+	Score float64 `json:"score"`
+}
+
+// NodeQuality computes the quality score of a node from its epistemic
+// state, its open challenges weighted by severity (see the
+// ChallengePenalty* constants), its taint, and whether its dependencies
+// are satisfied. A node without open challenges or dependencies gets full
+// points for those factors.
+//
+// Returns a zero NodeScore and false if the node doesn't exist.
+func NodeQuality(st *state.State, nodeID types.NodeID) (NodeScore, bool) {
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return NodeScore{}, false
+	}
+	return nodeQuality(st, n, st.GetChallengesForNode(nodeID)), true
+}
+
+// nodeQuality scores n given the challenges raised against it.
+func nodeQuality(st *state.State, n *node.Node, challenges []*state.Challenge) NodeScore {
+	score := NodeScore{
+		NodeID:         n.ID,
+		EpistemicState: string(n.EpistemicState),
+		TaintState:     string(n.TaintState),
+	}
+
+	status := 0.0
+	switch n.EpistemicState {
+	case schema.EpistemicValidated:
+		status = 1.0
+	case schema.EpistemicAdmitted:
+		status = NodeAdmittedRatio
+	}
+
+	penalty := 0.0
+	for _, c := range challenges {
+		if c.Status == state.ChallengeStatusOpen {
+			score.OpenChallenges++
+			penalty += challengePenalty(c.Severity)
+		}
+	}
+
+	taint := 0.0
+	switch n.TaintState {
+	case node.TaintClean:
+		taint = 1.0
+	case node.TaintUnresolved:
+		taint = NodeUnresolvedTaintRatio
+	}
+
+	for _, deps := range [][]types.NodeID{n.Dependencies, n.ValidationDeps} {
+		for _, depID := range deps {
+			score.Dependencies++
+			dep := st.GetNode(depID)
+			if dep != nil && (dep.EpistemicState == schema.EpistemicValidated || dep.EpistemicState == schema.EpistemicAdmitted) {
+				score.SatisfiedDependencies++
+			}
+		}
+	}
+	dependencies := 1.0
+	if score.Dependencies > 0 {
+		dependencies = float64(score.SatisfiedDependencies) / float64(score.Dependencies)
+	}
+
+	score.Score = NodeWeightStatus*status +
+		NodeWeightChallenges*(1.0-min(penalty, 1.0)) +
+		NodeWeightTaint*taint +
+		NodeWeightDependencies*dependencies
+	return score
+}
+
+// challengePenalty returns the ChallengePenalty* constant for severity.
+// An empty severity is treated as the default severity.
+func challengePenalty(severity string) float64 {
+	if severity == "" {
+		severity = string(schema.DefaultChallengeSeverity())
+	}
+	switch schema.ChallengeSeverity(severity) {
+	case schema.SeverityCritical:
+		return ChallengePenaltyCritical
+	case schema.SeverityMajor:
+		return ChallengePenaltyMajor
+	case schema.SeverityMinor:
+		return ChallengePenaltyMinor
+	case schema.SeverityNote:
+		return ChallengePenaltyNote
+	default:
+		return ChallengePenaltyCritical
+	}
+}
+
+// nodeScores scores the given nodes except archived ones, sorted by ID.
+func nodeScores(st *state.State, nodes []*node.Node) []NodeScore {
+	byNode := st.ChallengesByNodeID()
+	var scores []NodeScore
+	for _, n := range nodes {
+		if n.EpistemicState != schema.EpistemicArchived {
+			scores = append(scores, nodeQuality(st, n, byNode[n.ID.String()]))
+		}
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].NodeID.Less(scores[j].NodeID) })
+	return scores
+}
+
+// WeakestNodes returns a copy of the report's node scores sorted from the
+// lowest score to the highest, ties broken by node ID.
+func (r *QualityReport) WeakestNodes() []NodeScore {
+	scores := make([]NodeScore, len(r.Nodes))
+	copy(scores, r.Nodes)
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].Score < scores[j].Score })
+	return scores
 }
 
 // RefinementDepth calculates the maximum depth of the refinement tree
@@ -209,6 +451,9 @@ func OverallQuality(st *state.State) *QualityReport {
 		}
 	}
 
+	countNodeFactors(report, nodes)
+	report.Nodes = nodeScores(st, nodes)
+
 	// Calculate composite quality score
 	report.QualityScore = calculateQualityScore(report)
 
@@ -226,7 +471,7 @@ func SubtreeQuality(st *state.State, rootID types.NodeID) *QualityReport {
 	}
 
 	// Collect all nodes in the subtree
-	subtreeNodes := collectSubtreeNodes(st, rootID)
+	subtreeNodes := st.Subtree(rootID)
 	challenges := st.AllChallenges()
 
 	report := &QualityReport{
@@ -295,74 +540,101 @@ func SubtreeQuality(st *state.State, rootID types.NodeID) *QualityReport {
 		report.DefinitionCoverage = 1.0
 	}
 
+	countNodeFactors(report, subtreeNodes)
+	report.Nodes = nodeScores(st, subtreeNodes)
+
 	// Calculate composite quality score
 	report.QualityScore = calculateQualityScore(report)
 
 	return report
 }
 
-// collectSubtreeNodes collects all nodes in the subtree rooted at the given node.
-func collectSubtreeNodes(st *state.State, rootID types.NodeID) []*node.Node {
-	var result []*node.Node
-
-	rootNode := st.GetNode(rootID)
-	if rootNode == nil {
-		return result
-	}
-
-	result = append(result, rootNode)
+// QualityScore calculates a composite quality score (0-100) for the entire proof.
+func QualityScore(st *state.State) float64 {
+	report := OverallQuality(st)
+	return report.QualityScore
+}
 
-	// Find all descendants
-	rootIDStr := rootID.String()
-	for _, n := range st.AllNodes() {
-		if n.ID.String() != rootIDStr && isDescendant(n.ID, rootID) {
-			result = append(result, n)
+// countNodeFactors fills in the taint, assumption, and verbosity metrics
+// of a report from the given nodes.
+func countNodeFactors(report *QualityReport, nodes []*node.Node) {
+	for _, n := range nodes {
+		if n.TaintState == node.TaintTainted || n.TaintState == node.TaintSelfAdmitted {
+			report.TaintedNodes++
+		}
+		for _, ctx := range n.Context {
+			if strings.HasPrefix(ctx, "assume:") {
+				report.AssumptionRefs++
+			}
+		}
+		if utf8.RuneCountInString(n.Statement) > VerboseStatementLength {
+			report.VerboseNodes++
 		}
 	}
 
-	return result
+	if report.NodeCount > 0 {
+		report.AssumptionDensity = float64(report.AssumptionRefs) / float64(report.NodeCount)
+	}
 }
 
-// isDescendant checks if nodeID is a descendant of ancestorID.
-func isDescendant(nodeID, ancestorID types.NodeID) bool {
-	return ancestorID.IsAncestorOf(nodeID)
+// WithBreakdown populates the report's Breakdown field and returns the report.
+func (r *QualityReport) WithBreakdown() *QualityReport {
+	r.Breakdown = ComputeBreakdown(r)
+	return r
 }
 
-// QualityScore calculates a composite quality score (0-100) for the entire proof.
-func QualityScore(st *state.State) float64 {
-	report := OverallQuality(st)
-	return report.QualityScore
-}
-
-// calculateQualityScore computes the composite quality score from a report.
-// The score is based on:
-// - Validation progress (40%): Percentage of nodes validated
-// - Challenge health (30%): Lower open challenge density is better
-// - Definition coverage (30%): Higher coverage is better
-func calculateQualityScore(report *QualityReport) float64 {
-	if report.NodeCount == 0 {
-		return 100.0 // Empty proof is "perfect" by default
+// ComputeBreakdown computes the per-factor contributions to the quality score.
+// The components are:
+//   - validation:  fraction of nodes validated or admitted
+//   - challenges:  1 - open challenges per node (floored at 0)
+//   - definitions: definition coverage
+//   - taint:       1 - fraction of tainted or self-admitted nodes
+//   - assumptions: 1 - assumption references per node (floored at 0)
+//   - verbosity:   1 - fraction of nodes with overly long statements
+//
+// An empty report scores every component at full health.
+func ComputeBreakdown(report *QualityReport) *ScoreBreakdown {
+	validation, challenges, taint, assumptions, verbosity := 1.0, 1.0, 1.0, 1.0, 1.0
+	if report.NodeCount > 0 {
+		count := float64(report.NodeCount)
+		validation = float64(report.ValidatedNodes+report.AdmittedNodes) / count
+		challenges = 1.0 - min(float64(report.OpenChallenges)/count, 1.0)
+		taint = 1.0 - float64(report.TaintedNodes)/count
+		assumptions = 1.0 - min(float64(report.AssumptionRefs)/count, 1.0)
+		verbosity = 1.0 - float64(report.VerboseNodes)/count
 	}
 
-	// Validation score (0-40 points)
-	// Validated + Admitted nodes contribute positively
-	validatedRatio := float64(report.ValidatedNodes+report.AdmittedNodes) / float64(report.NodeCount)
-	validationScore := validatedRatio * 40
+	definitions := report.DefinitionCoverage
+	if report.DefinitionRefs == 0 {
+		definitions = 1.0
+	}
 
-	// Challenge health score (0-30 points)
-	// Fewer open challenges per node is better
-	// If open density > 1.0, score approaches 0
-	// If open density = 0, score is 30
-	openDensity := 0.0
-	if report.NodeCount > 0 {
-		openDensity = float64(report.OpenChallenges) / float64(report.NodeCount)
+	b := &ScoreBreakdown{}
+	add := func(name string, weight, ratio float64) {
+		points := weight * ratio
+		b.Components = append(b.Components, ScoreComponent{
+			Name:   name,
+			Weight: weight,
+			Ratio:  ratio,
+			Points: points,
+		})
+		b.Total += points
 	}
-	challengeScore := 30.0 * (1.0 - min(openDensity, 1.0))
+	add(ComponentValidation, WeightValidation, validation)
+	add(ComponentChallenges, WeightChallenges, challenges)
+	add(ComponentDefinitions, WeightDefinitions, definitions)
+	add(ComponentTaint, WeightTaint, taint)
+	add(ComponentAssumptions, WeightAssumptions, assumptions)
+	add(ComponentVerbosity, WeightVerbosity, verbosity)
 
-	// Definition coverage score (0-30 points)
-	defScore := report.DefinitionCoverage * 30
+	return b
+}
 
-	return validationScore + challengeScore + defScore
+// calculateQualityScore computes the composite quality score from a report.
+// See ComputeBreakdown for the factors and the Weight* constants for how
+// they are weighted.
+func calculateQualityScore(report *QualityReport) float64 {
+	return ComputeBreakdown(report).Total
 }
 
 // min returns the minimum of two float64 values.
@@ -2,6 +2,10 @@
 package metrics
 
 import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/tobias/vibefeld/internal/node"
@@ -501,6 +505,182 @@ func TestQualityScore_ProblematicProof(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Score Breakdown Tests
+// =============================================================================
+
+// scoreOf returns the quality score of a state with the given nodes and
+// open challenges on the root.
+func scoreOf(t *testing.T, openChallenges int, nodes ...*node.Node) float64 {
+	t.Helper()
+	st := createTestState(t, nodes...)
+	for i := 0; i < openChallenges; i++ {
+		st.AddChallenge(&state.Challenge{ID: fmt.Sprintf("c%d", i), NodeID: nodes[0].ID, Status: "open"})
+	}
+	return QualityScore(st)
+}
+
+func TestQualityScore_FactorDirections(t *testing.T) {
+	validated := func(n *node.Node) { n.EpistemicState = schema.EpistemicValidated }
+	clean := func(n *node.Node) { n.TaintState = node.TaintClean }
+
+	baseline := scoreOf(t, 0,
+		createTestNode(t, "1", clean), createTestNode(t, "1.1", clean))
+
+	tests := []struct {
+		name   string
+		score  float64
+		higher bool
+	}{
+		{
+			name: "validation raises score",
+			score: scoreOf(t, 0,
+				createTestNode(t, "1", clean, validated), createTestNode(t, "1.1", clean)),
+			higher: true,
+		},
+		{
+			name: "open challenges lower score",
+			score: scoreOf(t, 1,
+				createTestNode(t, "1", clean), createTestNode(t, "1.1", clean)),
+		},
+		{
+			name: "taint lowers score",
+			score: scoreOf(t, 0,
+				createTestNode(t, "1", clean), createTestNode(t, "1.1", func(n *node.Node) { n.TaintState = node.TaintTainted })),
+		},
+		{
+			name: "self-admitted debt lowers score",
+			score: scoreOf(t, 0,
+				createTestNode(t, "1", clean), createTestNode(t, "1.1", func(n *node.Node) { n.TaintState = node.TaintSelfAdmitted })),
+		},
+		{
+			name: "assumptions lower score",
+			score: scoreOf(t, 0,
+				createTestNode(t, "1", clean), createTestNode(t, "1.1", clean, func(n *node.Node) { n.Context = []string{"assume:A1"} })),
+		},
+		{
+			name: "verbose statements lower score",
+			score: scoreOf(t, 0,
+				createTestNode(t, "1", clean), createTestNode(t, "1.1", clean, func(n *node.Node) {
+					n.Statement = strings.Repeat("x", VerboseStatementLength+1)
+				})),
+		},
+		{
+			name: "undefined terms lower score",
+			score: scoreOf(t, 0,
+				createTestNode(t, "1", clean), createTestNode(t, "1.1", clean, func(n *node.Node) { n.Context = []string{"def:missing"} })),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.higher && tt.score <= baseline {
+				t.Errorf("score = %f, want > baseline %f", tt.score, baseline)
+			}
+			if !tt.higher && tt.score >= baseline {
+				t.Errorf("score = %f, want < baseline %f", tt.score, baseline)
+			}
+		})
+	}
+}
+
+func TestComputeBreakdown_SumsToScore(t *testing.T) {
+	root := createTestNode(t, "1", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicValidated
+		n.TaintState = node.TaintClean
+		n.Context = []string{"def:group", "assume:A1"}
+	})
+	child := createTestNode(t, "1.1", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicAdmitted
+		n.TaintState = node.TaintSelfAdmitted
+		n.Statement = strings.Repeat("y", VerboseStatementLength+10)
+	})
+	other := createTestNode(t, "1.2")
+	st := createTestState(t, root, child, other)
+	st.AddChallenge(&state.Challenge{ID: "c1", NodeID: other.ID, Status: "open"})
+
+	report := OverallQuality(st).WithBreakdown()
+	if report.Breakdown == nil {
+		t.Fatal("WithBreakdown did not populate Breakdown")
+	}
+
+	var sum, weights float64
+	for _, c := range report.Breakdown.Components {
+		if c.Ratio < 0 || c.Ratio > 1 {
+			t.Errorf("component %s ratio %f out of [0,1]", c.Name, c.Ratio)
+		}
+		if math.Abs(c.Points-c.Weight*c.Ratio) > 1e-9 {
+			t.Errorf("component %s points %f != weight*ratio %f", c.Name, c.Points, c.Weight*c.Ratio)
+		}
+		sum += c.Points
+		weights += c.Weight
+	}
+
+	if math.Abs(weights-100) > 1e-9 {
+		t.Errorf("weights sum to %f, want 100", weights)
+	}
+	if math.Abs(sum-report.Breakdown.Total) > 1e-9 {
+		t.Errorf("components sum to %f, Total is %f", sum, report.Breakdown.Total)
+	}
+	if math.Abs(sum-report.QualityScore) > 1e-9 {
+		t.Errorf("components sum to %f, QualityScore is %f", sum, report.QualityScore)
+	}
+
+	// Spot-check individual factor inputs
+	if report.TaintedNodes != 1 {
+		t.Errorf("TaintedNodes = %d, want 1", report.TaintedNodes)
+	}
+	if report.AssumptionRefs != 1 {
+		t.Errorf("AssumptionRefs = %d, want 1", report.AssumptionRefs)
+	}
+	if report.VerboseNodes != 1 {
+		t.Errorf("VerboseNodes = %d, want 1", report.VerboseNodes)
+	}
+	if c := report.Breakdown.Component(ComponentValidation); c == nil || math.Abs(c.Ratio-2.0/3.0) > 1e-9 {
+		t.Errorf("validation component = %+v, want ratio 2/3", c)
+	}
+}
+
+func TestOverallQuality_VerboseCountsCharacters(t *testing.T) {
+	// "∀" is three bytes long: the first statement exceeds the limit in bytes
+	// but not in characters
+	atLimit := createTestNode(t, "1", func(n *node.Node) {
+		n.Statement = strings.Repeat("∀", VerboseStatementLength)
+	})
+	overLimit := createTestNode(t, "1.1", func(n *node.Node) {
+		n.Statement = strings.Repeat("∀", VerboseStatementLength+1)
+	})
+	report := OverallQuality(createTestState(t, atLimit, overLimit))
+
+	if report.VerboseNodes != 1 {
+		t.Errorf("VerboseNodes = %d, want 1", report.VerboseNodes)
+	}
+}
+
+func TestComputeBreakdown_EmptyReport(t *testing.T) {
+	b := ComputeBreakdown(&QualityReport{})
+	if math.Abs(b.Total-100) > 1e-9 {
+		t.Errorf("empty report total = %f, want 100", b.Total)
+	}
+	if len(b.Components) != 6 {
+		t.Errorf("got %d components, want 6", len(b.Components))
+	}
+}
+
+func TestSubtreeQuality_CountsFactorsOnlyInSubtree(t *testing.T) {
+	root := createTestNode(t, "1", func(n *node.Node) { n.TaintState = node.TaintTainted })
+	child := createTestNode(t, "1.1", func(n *node.Node) { n.Context = []string{"assume:A1"} })
+	st := createTestState(t, root, child)
+
+	report := SubtreeQuality(st, child.ID)
+	if report.TaintedNodes != 0 {
+		t.Errorf("TaintedNodes = %d, want 0", report.TaintedNodes)
+	}
+	if report.AssumptionRefs != 1 {
+		t.Errorf("AssumptionRefs = %d, want 1", report.AssumptionRefs)
+	}
+}
+
 // =============================================================================
 // Benchmark Tests
 // =============================================================================
@@ -542,3 +722,124 @@ func BenchmarkOverallQuality(b *testing.B) {
 		OverallQuality(st)
 	}
 }
+
+// =============================================================================
+// NodeQuality Tests
+// =============================================================================
+
+func TestNodeQuality_Factors(t *testing.T) {
+	healthy := createTestNode(t, "1", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicValidated
+		n.TaintState = node.TaintClean
+	})
+	admitted := createTestNode(t, "1.1", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicAdmitted
+		n.TaintState = node.TaintSelfAdmitted
+	})
+	challenged := createTestNode(t, "1.2", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicValidated
+		n.TaintState = node.TaintClean
+	})
+	dependent := createTestNode(t, "1.3", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicValidated
+		n.TaintState = node.TaintClean
+		n.Dependencies = []types.NodeID{admitted.ID, challenged.ID}
+		n.ValidationDeps = []types.NodeID{mustParseMetricsID(t, "1.4")}
+	})
+	pending := createTestNode(t, "1.4")
+	st := createTestState(t, healthy, admitted, challenged, dependent, pending)
+	st.AddChallenge(&state.Challenge{ID: "c1", NodeID: challenged.ID, Status: "open", Severity: "major"})
+	st.AddChallenge(&state.Challenge{ID: "c2", NodeID: challenged.ID, Status: "open", Severity: "note"})
+	st.AddChallenge(&state.Challenge{ID: "c3", NodeID: challenged.ID, Status: "resolved", Severity: "critical"})
+
+	tests := []struct {
+		id   string
+		want float64
+	}{
+		{"1", 100},
+		{"1.1", NodeWeightStatus*NodeAdmittedRatio + NodeWeightChallenges + NodeWeightDependencies},
+		{"1.2", 100 - NodeWeightChallenges*ChallengePenaltyMajor},
+		{"1.3", 100 - NodeWeightDependencies/3},
+		{"1.4", NodeWeightChallenges + NodeWeightTaint*NodeUnresolvedTaintRatio + NodeWeightDependencies},
+	}
+	for _, tt := range tests {
+		score, ok := NodeQuality(st, mustParseMetricsID(t, tt.id))
+		if !ok {
+			t.Fatalf("NodeQuality(%s) not found", tt.id)
+		}
+		if math.Abs(score.Score-tt.want) > 1e-9 {
+			t.Errorf("NodeQuality(%s) = %.2f, want %.2f", tt.id, score.Score, tt.want)
+		}
+	}
+
+	score, _ := NodeQuality(st, challenged.ID)
+	if score.OpenChallenges != 2 {
+		t.Errorf("OpenChallenges = %d, want 2", score.OpenChallenges)
+	}
+	score, _ = NodeQuality(st, dependent.ID)
+	if score.Dependencies != 3 || score.SatisfiedDependencies != 2 {
+		t.Errorf("dependencies = %d/%d, want 2/3", score.SatisfiedDependencies, score.Dependencies)
+	}
+}
+
+func TestNodeQuality_ChallengePenaltyCapped(t *testing.T) {
+	root := createTestNode(t, "1", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicValidated
+		n.TaintState = node.TaintClean
+	})
+	st := createTestState(t, root)
+	for i := 0; i < 3; i++ {
+		st.AddChallenge(&state.Challenge{ID: fmt.Sprintf("c%d", i), NodeID: root.ID, Status: "open", Severity: "critical"})
+	}
+
+	score, _ := NodeQuality(st, root.ID)
+	if want := 100 - NodeWeightChallenges; score.Score != want {
+		t.Errorf("Score = %.2f, want %.2f", score.Score, want)
+	}
+}
+
+func TestNodeQuality_NonExistentNode(t *testing.T) {
+	st := createTestState(t, createTestNode(t, "1"))
+
+	score, ok := NodeQuality(st, mustParseMetricsID(t, "1.5"))
+	if ok {
+		t.Error("NodeQuality for a missing node returned ok=true")
+	}
+	if !reflect.DeepEqual(score, NodeScore{}) {
+		t.Errorf("NodeQuality for a missing node = %+v, want zero value", score)
+	}
+}
+
+func TestQualityReport_Nodes(t *testing.T) {
+	root := createTestNode(t, "1", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicValidated
+		n.TaintState = node.TaintClean
+	})
+	weak := createTestNode(t, "1.1")
+	archived := createTestNode(t, "1.2", func(n *node.Node) {
+		n.EpistemicState = schema.EpistemicArchived
+	})
+	st := createTestState(t, root, weak, archived)
+
+	report := OverallQuality(st)
+	if len(report.Nodes) != 2 || report.Nodes[0].NodeID.String() != "1" || report.Nodes[1].NodeID.String() != "1.1" {
+		t.Fatalf("Nodes = %+v, want scores for 1 and 1.1", report.Nodes)
+	}
+	if weakest := report.WeakestNodes(); weakest[0].NodeID.String() != "1.1" {
+		t.Errorf("WeakestNodes()[0] = %s, want 1.1", weakest[0].NodeID)
+	}
+
+	subtree := SubtreeQuality(st, weak.ID)
+	if len(subtree.Nodes) != 1 || subtree.Nodes[0].NodeID.String() != "1.1" {
+		t.Errorf("subtree Nodes = %+v, want only 1.1", subtree.Nodes)
+	}
+}
+
+func mustParseMetricsID(t *testing.T, s string) types.NodeID {
+	t.Helper()
+	id, err := types.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -222,8 +222,8 @@ func TestValidateChallengeLimit_ErrorExitCode(t *testing.T) {
 
 	// CHALLENGE_LIMIT_EXCEEDED should have exit code 3 (logic error)
 	exitCode := aferrors.ExitCode(err)
-	if exitCode != 3 {
-		t.Errorf("ExitCode = %d, want 3 (logic error)", exitCode)
+	if exitCode != 6 {
+		t.Errorf("ExitCode = %d, want 6 (validation error)", exitCode)
 	}
 }
 
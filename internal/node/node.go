@@ -7,6 +7,7 @@ import (
 	"errors"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/types"
@@ -51,6 +52,18 @@ type Node struct {
 	// can be accepted. This enables cross-branch dependency tracking.
 	ValidationDeps []types.NodeID `json:"validation_deps,omitempty"`
 
+	// Lemmas lists the IDs of extracted lemmas cited as justification for
+	// this node, in the order they were applied.
+	Lemmas []string `json:"lemmas,omitempty"`
+
+	// Tags are free-form labels agents attach to the node for coordination,
+	// kept sorted and without duplicates.
+	Tags []string `json:"tags,omitempty"`
+
+	// Notes are informational remarks left on the node, oldest first.
+	// They carry no proof semantics.
+	Notes []Note `json:"notes,omitempty"`
+
 	// WorkflowState is the current workflow state (available, claimed, blocked).
 	WorkflowState schema.WorkflowState `json:"workflow_state"`
 
@@ -74,6 +87,26 @@ type Node struct {
 
 	// ClaimedAt is the timestamp when the node was claimed.
 	ClaimedAt types.Timestamp `json:"claimed_at,omitempty"`
+
+	// ClaimTimeout is how long the claim lasts from ClaimedAt.
+	ClaimTimeout time.Duration `json:"claim_timeout,omitempty"`
+}
+
+// ClaimExpiry returns when the current claim expires.
+// Returns the zero timestamp if the node is not claimed.
+func (n *Node) ClaimExpiry() types.Timestamp {
+	if n.ClaimedAt.IsZero() {
+		return types.Timestamp{}
+	}
+	return n.ClaimedAt.Add(n.ClaimTimeout)
+}
+
+// IsClaimExpired returns true if the node is claimed and its claim expired before now.
+func (n *Node) IsClaimExpired(now types.Timestamp) bool {
+	if n.WorkflowState != schema.WorkflowClaimed || n.ClaimedAt.IsZero() {
+		return false
+	}
+	return n.ClaimExpiry().Before(now)
 }
 
 // NewNode creates a new Node with the given parameters.
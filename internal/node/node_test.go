@@ -1245,3 +1245,35 @@ func TestNode_InvalidUTF8(t *testing.T) {
 		})
 	}
 }
+
+// TestNode_ClaimExpiry tests claim expiry computed from claim time and timeout.
+func TestNode_ClaimExpiry(t *testing.T) {
+	id, _ := types.Parse("1")
+	n, err := node.NewNode(id, schema.NodeTypeClaim, "Test", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("NewNode() error: %v", err)
+	}
+
+	now := types.Now()
+	if !n.ClaimExpiry().IsZero() {
+		t.Errorf("ClaimExpiry() of unclaimed node = %v, want zero", n.ClaimExpiry())
+	}
+	if n.IsClaimExpired(now) {
+		t.Error("IsClaimExpired() = true for unclaimed node")
+	}
+
+	n.WorkflowState = schema.WorkflowClaimed
+	n.ClaimedAt = now.Add(-time.Hour)
+	n.ClaimTimeout = 30 * time.Minute
+	if want := now.Add(-30 * time.Minute); !n.ClaimExpiry().Equal(want) {
+		t.Errorf("ClaimExpiry() = %v, want %v", n.ClaimExpiry(), want)
+	}
+	if !n.IsClaimExpired(now) {
+		t.Error("IsClaimExpired() = false for claim that expired 30 minutes ago")
+	}
+
+	n.ClaimTimeout = 2 * time.Hour
+	if n.IsClaimExpired(now) {
+		t.Error("IsClaimExpired() = true for claim that expires in an hour")
+	}
+}
@@ -0,0 +1,20 @@
+// Package node provides data structures for proof nodes in the AF system.
+package node
+
+import "github.com/tobias/vibefeld/internal/types"
+
+// Note is a non-blocking remark left on a node, typically by a verifier.
+// Unlike a challenge, a note has no lifecycle and never blocks acceptance.
+type Note struct {
+	// ID uniquely identifies the note.
+	ID string `json:"id"`
+
+	// Author is the agent that left the note.
+	Author string `json:"author"`
+
+	// Text is the remark itself.
+	Text string `json:"text"`
+
+	// Created is when the note was added.
+	Created types.Timestamp `json:"created"`
+}
@@ -7,8 +7,8 @@ import (
 
 // ValidateRefinementCount checks if a node can accept additional refinements.
 // It returns nil if refinementCount < maxRefinements (refinement is allowed).
-// It returns a REFINEMENT_LIMIT_EXCEEDED error (exit code 3) if the count
-// is at or over the limit.
+// It returns a REFINEMENT_LIMIT_EXCEEDED error (exit code 6, validation) if
+// the count is at or over the limit.
 //
 // Parameters:
 //   - n: The node being refined (must not be nil)
@@ -216,8 +216,8 @@ func TestValidateRefinementCount_ErrorExitCode(t *testing.T) {
 
 	// REFINEMENT_LIMIT_EXCEEDED should have exit code 3 (logic error)
 	exitCode := aferrors.ExitCode(err)
-	if exitCode != 3 {
-		t.Errorf("ExitCode = %d, want 3 (logic error)", exitCode)
+	if exitCode != 6 {
+		t.Errorf("ExitCode = %d, want 6 (validation error)", exitCode)
 	}
 }
 
@@ -650,8 +650,8 @@ func TestValidateRefinementCount_AtLimit_HasCorrectErrorCode(t *testing.T) {
 	}
 
 	exitCode := aferrors.ExitCode(err)
-	if exitCode != 3 {
-		t.Errorf("ExitCode = %d, want 3 (logic error)", exitCode)
+	if exitCode != 6 {
+		t.Errorf("ExitCode = %d, want 6 (validation error)", exitCode)
 	}
 }
 
@@ -670,8 +670,8 @@ func TestValidateRefinementCount_OverLimit_HasCorrectErrorCode(t *testing.T) {
 	}
 
 	exitCode := aferrors.ExitCode(err)
-	if exitCode != 3 {
-		t.Errorf("ExitCode = %d, want 3 (logic error)", exitCode)
+	if exitCode != 6 {
+		t.Errorf("ExitCode = %d, want 6 (validation error)", exitCode)
 	}
 }
 
@@ -0,0 +1,55 @@
+// Package node provides data structures for proof nodes in the AF system.
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ValidateTag checks that tag can be used as a node tag: a non-empty token
+// without whitespace, such as "needs-expert" or "approach-A".
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return errors.New("tag cannot be empty")
+	}
+	if strings.IndexFunc(tag, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("tag %q cannot contain whitespace", tag)
+	}
+	return nil
+}
+
+// HasTag reports whether the node carries tag.
+func (n *Node) HasTag(tag string) bool {
+	i := sort.SearchStrings(n.Tags, tag)
+	return i < len(n.Tags) && n.Tags[i] == tag
+}
+
+// AddTag adds tag to the node, keeping Tags sorted and free of duplicates.
+// Returns false if the node already carries tag.
+func (n *Node) AddTag(tag string) bool {
+	i := sort.SearchStrings(n.Tags, tag)
+	if i < len(n.Tags) && n.Tags[i] == tag {
+		return false
+	}
+	n.Tags = append(n.Tags, "")
+	copy(n.Tags[i+1:], n.Tags[i:])
+	n.Tags[i] = tag
+	return true
+}
+
+// RemoveTag removes tag from the node.
+// Returns false if the node does not carry tag.
+func (n *Node) RemoveTag(tag string) bool {
+	i := sort.SearchStrings(n.Tags, tag)
+	if i >= len(n.Tags) || n.Tags[i] != tag {
+		return false
+	}
+	n.Tags = append(n.Tags[:i], n.Tags[i+1:]...)
+	if len(n.Tags) == 0 {
+		n.Tags = nil
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/node"
+)
+
+// TestValidateTag tests which tags are accepted
+func TestValidateTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{"needs-expert", false},
+		{"approach-A", false},
+		{"ε-δ", false},
+		{"", true},
+		{"needs expert", true},
+		{"tab\tseparated", true},
+		{"trailing\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			err := node.ValidateTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTag(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNode_AddRemoveTag tests that tags stay sorted and deduplicated
+func TestNode_AddRemoveTag(t *testing.T) {
+	n := &node.Node{}
+
+	for _, tag := range []string{"b", "a", "c"} {
+		if !n.AddTag(tag) {
+			t.Errorf("AddTag(%q) = false, want true", tag)
+		}
+	}
+	if n.AddTag("b") {
+		t.Error("AddTag(duplicate) = true, want false")
+	}
+	if got := n.Tags; len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("Tags = %v, want [a b c]", got)
+	}
+	if !n.HasTag("c") || n.HasTag("d") {
+		t.Errorf("HasTag mismatch for Tags = %v", n.Tags)
+	}
+
+	if !n.RemoveTag("b") {
+		t.Error("RemoveTag(b) = false, want true")
+	}
+	if n.RemoveTag("b") {
+		t.Error("RemoveTag(missing) = true, want false")
+	}
+	n.RemoveTag("a")
+	n.RemoveTag("c")
+	if n.Tags != nil {
+		t.Errorf("Tags = %v after removing all, want nil", n.Tags)
+	}
+}
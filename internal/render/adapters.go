@@ -5,6 +5,10 @@
 package render
 
 import (
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/tobias/vibefeld/internal/jobs"
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/schema"
@@ -38,6 +42,7 @@ func NodeToView(n *node.Node) NodeView {
 	if !n.ClaimedAt.IsZero() {
 		view.ClaimedAt = n.ClaimedAt.String()
 	}
+	view.Expired = n.IsClaimExpired(types.Now())
 
 	// Convert context
 	if len(n.Context) > 0 {
@@ -55,6 +60,18 @@ func NodeToView(n *node.Node) NodeView {
 		view.ValidationDeps = types.ToStringSlice(n.ValidationDeps)
 	}
 
+	// Convert lemma justifications
+	if len(n.Lemmas) > 0 {
+		view.Lemmas = make([]string, len(n.Lemmas))
+		copy(view.Lemmas, n.Lemmas)
+	}
+
+	// Convert tags
+	if len(n.Tags) > 0 {
+		view.Tags = make([]string, len(n.Tags))
+		copy(view.Tags, n.Tags)
+	}
+
 	// Convert scope
 	if len(n.Scope) > 0 {
 		view.Scope = make([]string, len(n.Scope))
@@ -170,15 +187,135 @@ func ExternalToView(e *node.External) ExternalView {
 	}
 }
 
+// LemmaToView converts a node.Lemma to a LemmaView.
+func LemmaToView(l *node.Lemma) LemmaView {
+	if l == nil {
+		return LemmaView{}
+	}
+	return LemmaView{
+		ID:           l.ID,
+		Statement:    l.Statement,
+		SourceNodeID: l.SourceNodeID.String(),
+		Proof:        l.Proof,
+		Created:      l.Created.String(),
+	}
+}
+
+// StateToSnapshotView converts s to a StateSnapshotView tagged with
+// JSONSchemaVersion. Every list is non-nil and sorted (see
+// StateSnapshotView), so the same state always gives the same snapshot.
+func StateToSnapshotView(s *state.State) StateSnapshotView {
+	view := StateSnapshotView{
+		SchemaVersion: JSONSchemaVersion,
+		LatestSeq:     s.LatestSeq(),
+		Nodes:         make([]NodeView, 0),
+		Definitions:   make([]DefinitionView, 0),
+		Assumptions:   make([]AssumptionView, 0),
+		Externals:     make([]ExternalView, 0),
+		Lemmas:        make([]LemmaView, 0),
+		Challenges:    make([]ChallengeView, 0),
+	}
+
+	for _, n := range s.AllNodesSorted() {
+		view.Nodes = append(view.Nodes, NodeToView(n))
+	}
+	for _, d := range s.AllDefinitions() {
+		view.Definitions = append(view.Definitions, DefinitionToView(d))
+	}
+	sort.Slice(view.Definitions, func(i, j int) bool { return view.Definitions[i].ID < view.Definitions[j].ID })
+	for _, a := range s.AllAssumptions() {
+		view.Assumptions = append(view.Assumptions, AssumptionToView(a))
+	}
+	sort.Slice(view.Assumptions, func(i, j int) bool { return view.Assumptions[i].ID < view.Assumptions[j].ID })
+	for _, e := range s.AllExternals() {
+		view.Externals = append(view.Externals, ExternalToView(e))
+	}
+	sort.Slice(view.Externals, func(i, j int) bool { return view.Externals[i].ID < view.Externals[j].ID })
+	for _, l := range s.AllLemmas() {
+		view.Lemmas = append(view.Lemmas, LemmaToView(l))
+	}
+	sort.Slice(view.Lemmas, func(i, j int) bool { return view.Lemmas[i].ID < view.Lemmas[j].ID })
+	for _, c := range s.AllChallenges() {
+		view.Challenges = append(view.Challenges, StateChallengeToView(c))
+	}
+	sort.Slice(view.Challenges, func(i, j int) bool {
+		a, b := view.Challenges[i], view.Challenges[j]
+		if a.TargetID != b.TargetID {
+			return compareNodeIDs(a.TargetID, b.TargetID)
+		}
+		return a.ID < b.ID
+	})
+
+	return view
+}
+
 // JobResultToView converts a jobs.JobResult to a JobListView.
 func JobResultToView(jr *jobs.JobResult) JobListView {
+	// Empty lists are [] rather than null in JSON
+	view := JobListView{
+		ProverJobs:   []NodeView{},
+		VerifierJobs: []NodeView{},
+		Reclaimable:  []NodeView{},
+	}
 	if jr == nil {
-		return JobListView{}
+		return view
 	}
-	return JobListView{
-		ProverJobs:   NodesToViews(jr.ProverJobs),
-		VerifierJobs: NodesToViews(jr.VerifierJobs),
+	if views := NodesToViews(jr.ProverJobs); views != nil {
+		view.ProverJobs = views
 	}
+	if views := NodesToViews(jr.VerifierJobs); views != nil {
+		view.VerifierJobs = views
+	}
+	return view
+}
+
+// JobNodeToView converts a job node to a JobView, resolving its
+// dependencies against s. Severity counts and recommendations are left for
+// the caller, which knows the listing order.
+func JobNodeToView(s *state.State, n *node.Node) JobView {
+	deps := DependencyStatusViews(s, n.Dependencies)
+	return JobView{
+		NodeID:         n.ID.String(),
+		Statement:      n.Statement,
+		Type:           string(n.Type),
+		Depth:          n.Depth(),
+		Dependencies:   deps,
+		ValidationDeps: DependencyStatusViews(s, n.ValidationDeps),
+		DepsSatisfied:  dependenciesSatisfied(deps),
+		ClaimedBy:      n.ClaimedBy,
+	}
+}
+
+// DependenciesSatisfied returns true if every dependency of n exists in s
+// and is validated or admitted. A node without dependencies is satisfied.
+// Validation dependencies are not considered.
+func DependenciesSatisfied(s *state.State, n *node.Node) bool {
+	return dependenciesSatisfied(DependencyStatusViews(s, n.Dependencies))
+}
+
+// dependenciesSatisfied returns true if every dependency in deps is satisfied.
+func dependenciesSatisfied(deps []DependencyStatusView) bool {
+	for _, d := range deps {
+		if !d.Satisfied() {
+			return false
+		}
+	}
+	return true
+}
+
+// ReclaimableClaimsToViews returns views of the claimed nodes whose claim
+// timeout has passed, sorted by ID. Each view has Expired set.
+// The claims are only reported; releasing them is left to the caller.
+func ReclaimableClaimsToViews(nodes []*node.Node) []NodeView {
+	now := types.Now()
+	views := []NodeView{}
+	for _, n := range nodes {
+		if n != nil && n.IsClaimExpired(now) {
+			views = append(views, NodeToView(n))
+		}
+	}
+	sortNodeViewsByID(views)
+	return views
 }
 
 // StateToStatusView converts a state.State to a StatusView.
@@ -187,7 +324,7 @@ func StateToStatusView(s *state.State) StatusView {
 		return StatusView{}
 	}
 
-	nodes := s.AllNodes()
+	nodes := s.AllNodesSorted()
 	challenges := s.AllChallenges()
 
 	// Count jobs
@@ -204,29 +341,66 @@ func StateToStatusView(s *state.State) StatusView {
 		}
 	}
 
+	// Sort challenges so that serialized output is stable across runs
+	nodeViews := NodesToViews(nodes)
+	challengeViews := StateChallengesToViews(challenges)
+	sort.Slice(challengeViews, func(i, j int) bool {
+		if challengeViews[i].TargetID != challengeViews[j].TargetID {
+			return compareNodeIDs(challengeViews[i].TargetID, challengeViews[j].TargetID)
+		}
+		return challengeViews[i].ID < challengeViews[j].ID
+	})
+
 	return StatusView{
-		Nodes:            NodesToViews(nodes),
-		Challenges:       StateChallengesToViews(challenges),
-		ProverJobCount:   proverJobs,
-		VerifierJobCount: verifierJobs,
+		Nodes:             nodeViews,
+		Challenges:        challengeViews,
+		ProverJobCount:    proverJobs,
+		VerifierJobCount:  verifierJobs,
+		CompletionPercent: s.CompletionPercent(),
+		RootProven:        s.RootProven(),
+		OpenChallenges:    s.OpenChallengesBySeverity(),
+		BlockedNodes:      s.BlockedNodeCount(),
 	}
 }
 
 // StateToTreeView converts a state.State to a TreeView with optional custom root.
 func StateToTreeView(s *state.State, customRoot *types.NodeID) TreeView {
+	return StateToTreeViewWithDepth(s, customRoot, -1)
+}
+
+// StateToTreeViewWithDepth is StateToTreeView with the tree trimmed to at
+// most maxDepth levels below the rendering root (customRoot, or the proof
+// root). maxDepth 0 keeps only the root; a negative maxDepth keeps every
+// level. Nodes on the last kept level record how many descendants and how
+// many levels were trimmed beneath them. NodeLookup still covers every node
+// so dependency status stays accurate.
+func StateToTreeViewWithDepth(s *state.State, customRoot *types.NodeID, maxDepth int) TreeView {
 	if s == nil {
 		return TreeView{}
 	}
 
-	nodes := s.AllNodes()
+	nodes := s.AllNodesSorted()
 	views := NodesToViews(nodes)
 
+	settled := settledSubtrees(nodes)
+	for i := range views {
+		views[i].SubtreeSettled = settled[views[i].ID]
+	}
+
 	// Build lookup map
 	lookup := make(map[string]NodeView, len(views))
 	for _, v := range views {
 		lookup[v.ID] = v
 	}
 
+	if maxDepth >= 0 {
+		baseDepth := 1
+		if customRoot != nil {
+			baseDepth = customRoot.Depth()
+		}
+		views = trimTreeDepth(views, baseDepth+maxDepth)
+	}
+
 	var root *NodeView
 	if customRoot != nil {
 		if v, ok := lookup[customRoot.String()]; ok {
@@ -241,6 +415,473 @@ func StateToTreeView(s *state.State, customRoot *types.NodeID) TreeView {
 	}
 }
 
+// trimTreeDepth drops views deeper than cutoff and records, on each kept view
+// at the cutoff depth, the number of dropped descendants and dropped levels.
+func trimTreeDepth(views []NodeView, cutoff int) []NodeView {
+	kept := make([]NodeView, 0, len(views))
+	index := make(map[string]int, len(views))
+	for _, v := range views {
+		if v.Depth <= cutoff {
+			index[v.ID] = len(kept)
+			kept = append(kept, v)
+		}
+	}
+
+	for _, v := range views {
+		if v.Depth <= cutoff {
+			continue
+		}
+		// The ancestor at the cutoff depth is the first cutoff segments of the ID.
+		segments := strings.SplitN(v.ID, ".", cutoff+1)
+		i, ok := index[strings.Join(segments[:cutoff], ".")]
+		if !ok {
+			continue
+		}
+		kept[i].HiddenDescendants++
+		if levels := v.Depth - cutoff; levels > kept[i].HiddenLevels {
+			kept[i].HiddenLevels = levels
+		}
+	}
+
+	return kept
+}
+
+// settledSubtrees reports, by node ID, whether a node and every descendant
+// present in nodes is validated or admitted, unclaimed, and not tainted.
+func settledSubtrees(nodes []*node.Node) map[string]bool {
+	settled := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		settled[n.ID.String()] = true
+	}
+
+	for _, n := range nodes {
+		if isSettledNode(n) {
+			continue
+		}
+		// An unsettled node unsettles itself and every ancestor.
+		for id, ok := n.ID, true; ok; id, ok = id.Parent() {
+			settled[id.String()] = false
+		}
+	}
+
+	return settled
+}
+
+// isSettledNode reports whether a single node can be collapsed in a pruned tree.
+func isSettledNode(n *node.Node) bool {
+	if n.EpistemicState != schema.EpistemicValidated && n.EpistemicState != schema.EpistemicAdmitted {
+		return false
+	}
+	return n.WorkflowState != schema.WorkflowClaimed && n.TaintState != node.TaintTainted
+}
+
+// StateToDependencyGraphView converts a state.State to a DependencyGraphView
+// via state.NewGraph, so the view has no cycles.
+func StateToDependencyGraphView(s *state.State) DependencyGraphView {
+	if s == nil {
+		return DependencyGraphView{}
+	}
+
+	return GraphToView(state.NewGraph(s))
+}
+
+// GraphToView converts a dependency graph to a DependencyGraphView,
+// keeping the order of its nodes, edges, and cycles.
+func GraphToView(g *state.Graph) DependencyGraphView {
+	if g == nil {
+		return DependencyGraphView{}
+	}
+
+	view := DependencyGraphView{
+		Nodes:  make([]NodeView, 0, len(g.Nodes)),
+		Edges:  make([]GraphEdgeView, 0, len(g.Edges)),
+		Cycles: make([][]string, 0, len(g.Cycles)),
+	}
+	view.Nodes = append(view.Nodes, NodesToViews(g.Nodes)...)
+	for _, e := range g.Edges {
+		view.Edges = append(view.Edges, GraphEdgeView{From: e.From.String(), To: e.To.String(), Kind: e.Kind})
+	}
+	for _, c := range g.Cycles {
+		view.Cycles = append(view.Cycles, types.ToStringSlice(c))
+	}
+	return view
+}
+
+// StateToNodeStatusTableView builds a NodeStatusTableView with one row per
+// node in s, sorted by node ID. A nil state produces an empty table.
+func StateToNodeStatusTableView(s *state.State) NodeStatusTableView {
+	if s == nil {
+		return NodeStatusTableView{Rows: []NodeStatusRowView{}}
+	}
+
+	nodes := s.AllNodesSorted()
+	children := make(map[string]int, len(nodes))
+	for _, n := range nodes {
+		if parent, ok := n.ID.Parent(); ok {
+			children[parent.String()]++
+		}
+	}
+
+	rows := make([]NodeStatusRowView, 0, len(nodes))
+	for _, n := range nodes {
+		open := 0
+		for _, c := range s.GetChallengesForNode(n.ID) {
+			if c.Status == state.ChallengeStatusOpen {
+				open++
+			}
+		}
+		rows = append(rows, NodeStatusRowView{
+			ID:              n.ID.String(),
+			Depth:           n.ID.Depth(),
+			Type:            string(n.Type),
+			Inference:       string(n.Inference),
+			WorkflowState:   string(n.WorkflowState),
+			EpistemicState:  string(n.EpistemicState),
+			TaintState:      string(n.TaintState),
+			NumDependencies: len(n.Dependencies),
+			NumChildren:     children[n.ID.String()],
+			ClaimedBy:       n.ClaimedBy,
+			OpenChallenges:  open,
+			Statement:       n.Statement,
+		})
+	}
+	return NodeStatusTableView{Rows: rows}
+}
+
+// StateToNodeListView builds a NodeListView of the nodes in s that match
+// filter, ordered by sortBy. The filters (see ValidListFilters) are:
+//
+//   - pending, validated, refuted: nodes in that epistemic state
+//   - claimed: nodes currently claimed
+//   - tainted: nodes that are tainted or self-admitted
+//
+// An empty filter keeps every node. sortBy is "id" (the default), "depth"
+// (shallowest first), or "state" (epistemic state in lifecycle order); ties
+// are broken by node ID. A nil state produces an empty list.
+func StateToNodeListView(s *state.State, filter, sortBy string) NodeListView {
+	if s == nil {
+		return NodeListView{Nodes: []NodeListItemView{}}
+	}
+
+	items := make([]NodeListItemView, 0)
+	for _, n := range s.AllNodesSorted() {
+		if !matchesListFilter(n, filter) {
+			continue
+		}
+		items = append(items, NodeListItemView{
+			ID:             n.ID.String(),
+			Depth:          n.ID.Depth(),
+			WorkflowState:  string(n.WorkflowState),
+			EpistemicState: string(n.EpistemicState),
+			TaintState:     string(n.TaintState),
+			ClaimedBy:      n.ClaimedBy,
+			Statement:      n.Statement,
+		})
+	}
+
+	// Items are in ID order, so the stable sorts break ties by node ID.
+	switch sortBy {
+	case "depth":
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Depth < items[j].Depth
+		})
+	case "state":
+		sort.SliceStable(items, func(i, j int) bool {
+			return listStateRank[items[i].EpistemicState] < listStateRank[items[j].EpistemicState]
+		})
+	}
+	return NodeListView{Nodes: items}
+}
+
+// listStateRank orders epistemic states by lifecycle for af list --sort state.
+var listStateRank = map[string]int{
+	string(schema.EpistemicPending):         0,
+	string(schema.EpistemicNeedsRefinement): 1,
+	string(schema.EpistemicValidated):       2,
+	string(schema.EpistemicAdmitted):        3,
+	string(schema.EpistemicRefuted):         4,
+	string(schema.EpistemicArchived):        5,
+}
+
+// matchesListFilter reports whether n passes an af list --filter value.
+func matchesListFilter(n *node.Node, filter string) bool {
+	switch filter {
+	case "":
+		return true
+	case "claimed":
+		return n.WorkflowState == schema.WorkflowClaimed
+	case "tainted":
+		return n.TaintState == node.TaintTainted || n.TaintState == node.TaintSelfAdmitted
+	default:
+		return string(n.EpistemicState) == filter
+	}
+}
+
+// NodeToSearchMatchView builds a SearchMatchView for a node matched by query.
+// The snippet is taken from the statement, or from the LaTeX if the query only
+// occurs there. An empty query yields the start of the statement unhighlighted.
+func NodeToSearchMatchView(n *node.Node, query string, caseSensitive bool) SearchMatchView {
+	if n == nil {
+		return SearchMatchView{}
+	}
+
+	view := SearchMatchView{
+		ID:             n.ID.String(),
+		EpistemicState: string(n.EpistemicState),
+		WorkflowState:  string(n.WorkflowState),
+		Field:          "statement",
+	}
+
+	text := sanitizeStatement(n.Statement)
+	if query != "" && findMatch(text, query, caseSensitive) < 0 {
+		if latex := sanitizeStatement(n.Latex); findMatch(latex, query, caseSensitive) >= 0 {
+			view.Field = "latex"
+			text = latex
+		}
+	}
+	view.Snippet, view.MatchStart, view.MatchEnd = searchSnippet(text, query, caseSensitive)
+	return view
+}
+
+// StateDiffToView converts a state.StateDiff to a StateDiffView.
+func StateDiffToView(d *state.StateDiff) StateDiffView {
+	if d == nil {
+		return StateDiffView{}
+	}
+
+	view := StateDiffView{
+		FromSeq: d.FromSeq,
+		ToSeq:   d.ToSeq,
+		Added:   NodesToViews(d.Added),
+		Removed: NodesToViews(d.Removed),
+	}
+	for _, c := range d.Changed {
+		view.Changed = append(view.Changed, FieldChangeView{
+			NodeID: c.NodeID.String(),
+			Field:  c.Field,
+			From:   c.From,
+			To:     c.To,
+		})
+	}
+	return view
+}
+
+// StatusDeltaToView converts the diff from sinceSeq to the latest sequence of
+// st into a StatusDeltaView. Validated nodes include nodes created validated
+// in the range; they are looked up in st. A nil diff yields an empty delta,
+// as when sinceSeq is at or past the latest sequence.
+func StatusDeltaToView(sinceSeq int, d *state.StateDiff, st *state.State) StatusDeltaView {
+	view := StatusDeltaView{
+		SinceSeq:         sinceSeq,
+		Created:          []NodeView{},
+		Validated:        []NodeView{},
+		ChallengesRaised: []ChallengeView{},
+	}
+	if st != nil {
+		view.LatestSeq = st.LatestSeq()
+	}
+	if d == nil || st == nil {
+		return view
+	}
+
+	for _, n := range d.Added {
+		view.Created = append(view.Created, NodeToView(n))
+		if n.EpistemicState == schema.EpistemicValidated {
+			view.Validated = append(view.Validated, NodeToView(n))
+		}
+	}
+	for _, c := range d.Changed {
+		if c.Field != state.DiffFieldEpistemic || c.To != string(schema.EpistemicValidated) {
+			continue
+		}
+		if n := st.GetNode(c.NodeID); n != nil {
+			view.Validated = append(view.Validated, NodeToView(n))
+		}
+	}
+	sortNodeViewsByID(view.Validated)
+
+	for _, c := range d.ChallengesRaised {
+		view.ChallengesRaised = append(view.ChallengesRaised, StateChallengeToView(c))
+	}
+	return view
+}
+
+// Status group names, in the order StateToStatusGroupsView lists them.
+const (
+	statusGroupValidated = "Validated"
+	statusGroupAdmitted  = "Admitted"
+	statusGroupRefuted   = "Refuted"
+	statusGroupPending   = "Pending"
+	statusGroupClaimed   = "Claimed"
+)
+
+// StateToStatusGroupsView groups the nodes of s by epistemic state, with
+// claimed nodes listed under Claimed rather than Pending and nodes that need
+// refinement counted as pending. Archived nodes are left out. Every group is
+// present, even when empty, and lists its node IDs sorted by node ID.
+func StateToStatusGroupsView(s *state.State) StatusGroupsView {
+	names := []string{statusGroupValidated, statusGroupAdmitted, statusGroupRefuted, statusGroupPending, statusGroupClaimed}
+	ids := make(map[string][]string, len(names))
+	for _, name := range names {
+		ids[name] = []string{}
+	}
+
+	if s != nil {
+		for _, n := range s.AllNodesSorted() {
+			var group string
+			switch {
+			case n.EpistemicState == schema.EpistemicValidated:
+				group = statusGroupValidated
+			case n.EpistemicState == schema.EpistemicAdmitted:
+				group = statusGroupAdmitted
+			case n.EpistemicState == schema.EpistemicRefuted:
+				group = statusGroupRefuted
+			case n.EpistemicState == schema.EpistemicArchived:
+				continue
+			case n.WorkflowState == schema.WorkflowClaimed:
+				group = statusGroupClaimed
+			default:
+				group = statusGroupPending
+			}
+			ids[group] = append(ids[group], n.ID.String())
+		}
+	}
+
+	view := StatusGroupsView{Groups: make([]StatusGroupView, 0, len(names))}
+	for _, name := range names {
+		view.Groups = append(view.Groups, StatusGroupView{
+			Name:    name,
+			Count:   len(ids[name]),
+			NodeIDs: ids[name],
+		})
+	}
+	return view
+}
+
+// ActivityToView converts per-agent activity to an ActivityView,
+// keeping the order of activities.
+func ActivityToView(activities []*state.AgentActivity) ActivityView {
+	view := ActivityView{Agents: make([]AgentActivityView, 0, len(activities))}
+	for _, a := range activities {
+		view.Agents = append(view.Agents, AgentActivityView{
+			Agent:            a.Agent,
+			Claims:           a.Claims,
+			Refinements:      a.Refinements,
+			ChallengesRaised: a.ChallengesRaised,
+			Acceptances:      a.Acceptances,
+			Total:            a.Total(),
+			FirstAction:      a.FirstAction.String(),
+			LastAction:       a.LastAction.String(),
+		})
+	}
+	return view
+}
+
+// ClaimDurationsToView converts claim durations keyed by node ID to views,
+// most claimed node first, then by total time held, longest first, then by
+// node ID.
+func ClaimDurationsToView(durations map[string][]time.Duration) []NodeClaimsView {
+	type entry struct {
+		id             string
+		total, longest time.Duration
+		claims         int
+	}
+	entries := make([]entry, 0, len(durations))
+	for id, ds := range durations {
+		e := entry{id: id, claims: len(ds)}
+		for _, d := range ds {
+			e.total += d
+			e.longest = max(e.longest, d)
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.claims != b.claims {
+			return a.claims > b.claims
+		}
+		if a.total != b.total {
+			return a.total > b.total
+		}
+		return compareNodeIDs(a.id, b.id)
+	})
+
+	views := make([]NodeClaimsView, 0, len(entries))
+	for _, e := range entries {
+		views = append(views, NodeClaimsView{
+			NodeID:      e.id,
+			Claims:      e.claims,
+			TotalHeld:   e.total.Round(time.Second).String(),
+			LongestHeld: e.longest.Round(time.Second).String(),
+		})
+	}
+	return views
+}
+
+// NodeHistoryToView converts a node's history entries to views, keeping
+// their order.
+func NodeHistoryToView(entries []state.NodeHistoryEntry) []NodeHistoryEntryView {
+	views := make([]NodeHistoryEntryView, 0, len(entries))
+	for _, e := range entries {
+		views = append(views, NodeHistoryEntryView{
+			Seq:       e.Seq,
+			Timestamp: e.Timestamp.String(),
+			Type:      string(e.Type),
+			Summary:   e.Summary,
+		})
+	}
+	return views
+}
+
+// ValidationReportToView converts an invariant report to a ValidationReportView.
+func ValidationReportToView(r *state.InvariantReport) ValidationReportView {
+	if r == nil {
+		return ValidationReportView{Valid: true, Violations: []InvariantViolationView{}}
+	}
+	view := ValidationReportView{
+		Valid:         !r.HasCritical(),
+		EventsChecked: r.EventsChecked,
+		NodesChecked:  r.NodesChecked,
+		Violations:    make([]InvariantViolationView, 0, len(r.Violations)),
+	}
+	for _, v := range r.Violations {
+		severity := "warning"
+		if v.Critical {
+			severity = "critical"
+		}
+		view.Violations = append(view.Violations, InvariantViolationView{
+			Kind:     v.Kind,
+			Severity: severity,
+			NodeIDs:  types.ToStringSlice(v.NodeIDs),
+			Message:  v.Message,
+		})
+	}
+	return view
+}
+
+// LedgerVerificationToView converts a ledger verification report to a
+// LedgerVerificationView.
+func LedgerVerificationToView(r *state.LedgerVerification) LedgerVerificationView {
+	if r == nil {
+		return LedgerVerificationView{OK: true, Issues: []LedgerIssueView{}}
+	}
+	view := LedgerVerificationView{
+		OK:            r.OK(),
+		EventsChecked: r.EventsChecked,
+		Chained:       r.Chained,
+		Issues:        make([]LedgerIssueView, 0, len(r.Issues)),
+	}
+	for _, issue := range r.Issues {
+		view.Issues = append(view.Issues, LedgerIssueView{
+			Kind:    issue.Kind,
+			Seq:     issue.Seq,
+			NodeID:  issue.NodeID.String(),
+			Message: issue.Message,
+		})
+	}
+	return view
+}
+
 // BuildProverContextView builds a ProverContextView from state and node ID.
 func BuildProverContextView(s *state.State, nodeID types.NodeID) ProverContextView {
 	if s == nil {
@@ -268,7 +909,7 @@ func BuildProverContextView(s *state.State, nodeID types.NodeID) ProverContextVi
 	if parentID, hasParent := nodeID.Parent(); hasParent {
 		parentStr := parentID.String()
 		nodeStr := nodeID.String()
-		for _, sib := range s.AllNodes() {
+		for _, sib := range s.AllNodesSorted() {
 			p, ok := sib.ID.Parent()
 			if !ok {
 				continue
@@ -330,7 +971,7 @@ func BuildVerifierContextView(s *state.State, challenge *node.Challenge) Verifie
 	if parentID, hasParent := challenge.TargetID.Parent(); hasParent {
 		parentStr := parentID.String()
 		nodeStr := challenge.TargetID.String()
-		for _, sib := range s.AllNodes() {
+		for _, sib := range s.AllNodesSorted() {
 			p, ok := sib.ID.Parent()
 			if !ok {
 				continue
@@ -356,6 +997,78 @@ func BuildVerifierContextView(s *state.State, challenge *node.Challenge) Verifie
 	return view
 }
 
+// BuildNodeDetailView builds a NodeDetailView from state and node ID.
+// Returns an empty view if the node doesn't exist.
+func BuildNodeDetailView(s *state.State, nodeID types.NodeID) NodeDetailView {
+	if s == nil {
+		return NodeDetailView{}
+	}
+
+	n := s.GetNode(nodeID)
+	if n == nil {
+		return NodeDetailView{}
+	}
+
+	view := NodeDetailView{
+		Node:           NodeToView(n),
+		Dependencies:   DependencyStatusViews(s, n.Dependencies),
+		ValidationDeps: DependencyStatusViews(s, n.ValidationDeps),
+	}
+
+	for _, note := range n.Notes {
+		view.Notes = append(view.Notes, NoteView{
+			ID:      note.ID,
+			Author:  note.Author,
+			Text:    note.Text,
+			Created: note.Created.String(),
+		})
+	}
+
+	// Group open challenges by severity, most severe first
+	challenges := s.GetChallengesForNode(nodeID)
+	view.TotalChallenges = len(challenges)
+	groups := make(map[string][]ChallengeView)
+	for _, c := range challenges {
+		if c.Status != state.ChallengeStatusOpen {
+			continue
+		}
+		cv := StateChallengeToView(c)
+		groups[cv.Severity] = append(groups[cv.Severity], cv)
+	}
+	for severity, cvs := range groups {
+		sort.Slice(cvs, func(i, j int) bool { return cvs[i].ID < cvs[j].ID })
+		view.OpenChallenges = append(view.OpenChallenges, ChallengeGroupView{
+			Severity:   severity,
+			Blocking:   schema.SeverityBlocksAcceptance(schema.ChallengeSeverity(severity)),
+			Challenges: cvs,
+		})
+	}
+	sort.Slice(view.OpenChallenges, func(i, j int) bool {
+		return severityOrder(view.OpenChallenges[i].Severity) < severityOrder(view.OpenChallenges[j].Severity)
+	})
+
+	return view
+}
+
+// DependencyStatusViews resolves dependency IDs against state, marking
+// dependencies that don't exist.
+func DependencyStatusViews(s *state.State, ids []types.NodeID) []DependencyStatusView {
+	if len(ids) == 0 {
+		return nil
+	}
+	views := make([]DependencyStatusView, 0, len(ids))
+	for _, id := range ids {
+		dv := DependencyStatusView{ID: id.String()}
+		if dep := s.GetNode(id); dep != nil {
+			dv.Exists = true
+			dv.EpistemicState = string(dep.EpistemicState)
+			dv.Statement = dep.Statement
+		}
+		views = append(views, dv)
+	}
+	return views
+}
+
 // collectDefinitionViews collects definitions referenced by a node.
 func collectDefinitionViews(s *state.State, n *node.Node) []DefinitionView {
 	nameSet := make(map[string]bool)
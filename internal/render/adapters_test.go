@@ -1,10 +1,13 @@
 package render
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
 	"github.com/tobias/vibefeld/internal/types"
 )
 
@@ -392,3 +395,387 @@ func TestGetNodeViewParentID_Adapters(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildNodeDetailView(t *testing.T) {
+	s := state.NewState()
+
+	dep, _ := node.NewNode(mustParseNodeID("1.1"), schema.NodeTypeClaim, "Lemma A", schema.InferenceModusPonens)
+	dep.EpistemicState = schema.EpistemicValidated
+	s.AddNode(dep)
+
+	target, err := node.NewNodeWithOptions(mustParseNodeID("1.2"), schema.NodeTypeClaim, "Main step", schema.InferenceModusPonens, node.NodeOptions{
+		Dependencies:   []types.NodeID{mustParseNodeID("1.1"), mustParseNodeID("1.9")},
+		ValidationDeps: []types.NodeID{mustParseNodeID("1.1")},
+	})
+	if err != nil {
+		t.Fatalf("NewNodeWithOptions failed: %v", err)
+	}
+	s.AddNode(target)
+
+	s.AddChallenge(&state.Challenge{ID: "ch-b", NodeID: target.ID, Target: "statement", Status: state.ChallengeStatusOpen, Severity: "minor"})
+	s.AddChallenge(&state.Challenge{ID: "ch-a", NodeID: target.ID, Target: "inference", Status: state.ChallengeStatusOpen, Severity: "critical"})
+	s.AddChallenge(&state.Challenge{ID: "ch-c", NodeID: target.ID, Target: "gap", Status: state.ChallengeStatusResolved, Severity: "major"})
+
+	v := BuildNodeDetailView(s, target.ID)
+
+	if v.Node.ID != "1.2" {
+		t.Errorf("Node.ID = %q, want %q", v.Node.ID, "1.2")
+	}
+	if len(v.Dependencies) != 2 {
+		t.Fatalf("len(Dependencies) = %d, want 2", len(v.Dependencies))
+	}
+	if !v.Dependencies[0].Satisfied() || v.Dependencies[0].EpistemicState != "validated" {
+		t.Errorf("Dependencies[0] = %+v, want satisfied validated dependency", v.Dependencies[0])
+	}
+	if v.Dependencies[1].Exists {
+		t.Errorf("Dependencies[1] = %+v, want missing dependency", v.Dependencies[1])
+	}
+	if len(v.ValidationDeps) != 1 {
+		t.Errorf("len(ValidationDeps) = %d, want 1", len(v.ValidationDeps))
+	}
+
+	if v.TotalChallenges != 3 {
+		t.Errorf("TotalChallenges = %d, want 3", v.TotalChallenges)
+	}
+	if len(v.OpenChallenges) != 2 {
+		t.Fatalf("len(OpenChallenges) = %d, want 2 groups", len(v.OpenChallenges))
+	}
+	if g := v.OpenChallenges[0]; g.Severity != "critical" || !g.Blocking || g.Challenges[0].ID != "ch-a" {
+		t.Errorf("OpenChallenges[0] = %+v, want blocking critical group with ch-a", g)
+	}
+	if g := v.OpenChallenges[1]; g.Severity != "minor" || g.Blocking {
+		t.Errorf("OpenChallenges[1] = %+v, want non-blocking minor group", g)
+	}
+}
+
+func TestJobNodeToView(t *testing.T) {
+	s := state.NewState()
+
+	dep, _ := node.NewNode(mustParseNodeID("1.1"), schema.NodeTypeClaim, "Lemma A", schema.InferenceModusPonens)
+	dep.EpistemicState = schema.EpistemicAdmitted
+	s.AddNode(dep)
+
+	job, err := node.NewNodeWithOptions(mustParseNodeID("1.2"), schema.NodeTypeClaim, "Main step", schema.InferenceModusPonens, node.NodeOptions{
+		Dependencies:   []types.NodeID{mustParseNodeID("1.1")},
+		ValidationDeps: []types.NodeID{mustParseNodeID("1.9")},
+	})
+	if err != nil {
+		t.Fatalf("NewNodeWithOptions failed: %v", err)
+	}
+	s.AddNode(job)
+
+	v := JobNodeToView(s, job)
+
+	if v.NodeID != "1.2" || v.Type != "claim" || v.Statement != "Main step" || v.Depth != 2 {
+		t.Errorf("JobNodeToView = %+v, want claim 1.2 at depth 2", v)
+	}
+	if len(v.Dependencies) != 1 || v.Dependencies[0].EpistemicState != "admitted" {
+		t.Errorf("Dependencies = %+v, want admitted 1.1", v.Dependencies)
+	}
+	if len(v.ValidationDeps) != 1 || v.ValidationDeps[0].Exists {
+		t.Errorf("ValidationDeps = %+v, want missing 1.9", v.ValidationDeps)
+	}
+	if !v.DepsSatisfied {
+		t.Error("DepsSatisfied = false, want true: the only dependency is admitted (missing validation deps don't count)")
+	}
+	if v.SeverityCounts != nil || v.Recommended {
+		t.Errorf("JobNodeToView set caller-owned fields: %+v", v)
+	}
+}
+
+func TestDependenciesSatisfied(t *testing.T) {
+	s := state.NewState()
+	add := func(id string, es schema.EpistemicState, deps ...string) *node.Node {
+		var depIDs []types.NodeID
+		for _, d := range deps {
+			depIDs = append(depIDs, mustParseNodeID(d))
+		}
+		n, err := node.NewNodeWithOptions(mustParseNodeID(id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceModusPonens, node.NodeOptions{
+			Dependencies: depIDs,
+		})
+		if err != nil {
+			t.Fatalf("NewNodeWithOptions failed: %v", err)
+		}
+		n.EpistemicState = es
+		s.AddNode(n)
+		return n
+	}
+
+	add("1.1", schema.EpistemicValidated)
+	add("1.2", schema.EpistemicAdmitted)
+	add("1.3", schema.EpistemicPending)
+
+	tests := []struct {
+		name string
+		n    *node.Node
+		want bool
+	}{
+		{"no dependencies", add("1.4", schema.EpistemicPending), true},
+		{"validated and admitted", add("1.5", schema.EpistemicPending, "1.1", "1.2"), true},
+		{"pending dependency", add("1.6", schema.EpistemicPending, "1.1", "1.3"), false},
+		{"missing dependency", add("1.7", schema.EpistemicPending, "1.9"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DependenciesSatisfied(s, tt.n); got != tt.want {
+				t.Errorf("DependenciesSatisfied = %v, want %v", got, tt.want)
+			}
+			if got := JobNodeToView(s, tt.n).DepsSatisfied; got != tt.want {
+				t.Errorf("JobNodeToView DepsSatisfied = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildNodeDetailView_MissingNode(t *testing.T) {
+	v := BuildNodeDetailView(state.NewState(), mustParseNodeID("1.5"))
+	if v.Node.ID != "" {
+		t.Errorf("expected empty view for missing node, got %+v", v)
+	}
+	if got := RenderNodeDetail(v); got != "" {
+		t.Errorf("RenderNodeDetail(empty) = %q, want empty", got)
+	}
+}
+
+func TestStateToTreeView_SubtreeSettled(t *testing.T) {
+	s := state.NewState()
+	add := func(id string, es schema.EpistemicState, mutate func(n *node.Node)) {
+		n, err := node.NewNode(mustParseNodeID(id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatalf("NewNode(%s) failed: %v", id, err)
+		}
+		n.EpistemicState = es
+		if mutate != nil {
+			mutate(n)
+		}
+		s.AddNode(n)
+	}
+
+	add("1", schema.EpistemicValidated, nil)
+	add("1.1", schema.EpistemicValidated, nil)
+	add("1.1.1", schema.EpistemicAdmitted, nil)
+	add("1.2", schema.EpistemicValidated, nil)
+	add("1.2.1", schema.EpistemicPending, nil)
+	add("1.3", schema.EpistemicValidated, func(n *node.Node) { n.TaintState = node.TaintTainted })
+	add("1.4", schema.EpistemicValidated, func(n *node.Node) { n.WorkflowState = schema.WorkflowClaimed })
+	add("1.5", schema.EpistemicRefuted, nil)
+	add("1.10", schema.EpistemicValidated, nil)
+
+	tv := StateToTreeView(s, nil)
+
+	want := map[string]bool{
+		"1":     false, // has unsettled descendants
+		"1.1":   true,
+		"1.1.1": true,
+		"1.2":   false, // pending child
+		"1.2.1": false,
+		"1.3":   false, // tainted
+		"1.4":   false, // claimed
+		"1.5":   false, // refuted
+		"1.10":  true,
+	}
+	for _, v := range tv.Nodes {
+		if v.SubtreeSettled != want[v.ID] {
+			t.Errorf("node %s SubtreeSettled = %v, want %v", v.ID, v.SubtreeSettled, want[v.ID])
+		}
+	}
+}
+
+func TestReclaimableClaimsToViews(t *testing.T) {
+	now := types.Now()
+	claimed := func(id string, claimedAt types.Timestamp, timeout time.Duration) *node.Node {
+		n, err := node.NewNode(mustParseNodeID(id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatalf("NewNode(%s) failed: %v", id, err)
+		}
+		n.WorkflowState = schema.WorkflowClaimed
+		n.ClaimedBy = "agent-" + id
+		n.ClaimedAt = claimedAt
+		n.ClaimTimeout = timeout
+		return n
+	}
+	available, err := node.NewNode(mustParseNodeID("1"), schema.NodeTypeClaim, "Root", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := []*node.Node{
+		available,
+		claimed("1.10", now.Add(-2*time.Hour), time.Hour), // expired
+		claimed("1.2", now, time.Hour),                    // still live
+		claimed("1.9", now.Add(-time.Hour), time.Minute),  // expired
+		nil,
+	}
+
+	views := ReclaimableClaimsToViews(nodes)
+	if len(views) != 2 || views[0].ID != "1.9" || views[1].ID != "1.10" {
+		t.Fatalf("ReclaimableClaimsToViews() = %+v, want 1.9 and 1.10 in ID order", views)
+	}
+	for _, v := range views {
+		if !v.Expired || v.ClaimedBy != "agent-"+v.ID {
+			t.Errorf("view %s: Expired = %v, ClaimedBy = %q", v.ID, v.Expired, v.ClaimedBy)
+		}
+	}
+	if NodeToView(nodes[2]).Expired {
+		t.Error("live claim should not be marked expired")
+	}
+}
+
+// newWideTreeState builds a proof where the root has width children, each of
+// which has width children of its own, each with one grandchild:
+// 1 + width + width^2 + width^2 nodes in total.
+func newWideTreeState(t *testing.T, width int) *state.State {
+	t.Helper()
+	s := state.NewState()
+	add := func(id types.NodeID) {
+		n, err := node.NewNode(id, schema.NodeTypeClaim, "Claim "+id.String(), schema.InferenceAssumption)
+		if err != nil {
+			t.Fatalf("NewNode(%s) failed: %v", id, err)
+		}
+		s.AddNode(n)
+	}
+
+	root := mustParseNodeID("1")
+	add(root)
+	for i := 1; i <= width; i++ {
+		child, _ := root.Child(i)
+		add(child)
+		for j := 1; j <= width; j++ {
+			grandchild, _ := child.Child(j)
+			add(grandchild)
+			leaf, _ := grandchild.Child(1)
+			add(leaf)
+		}
+	}
+	return s
+}
+
+func TestStateToTreeViewWithDepth(t *testing.T) {
+	const width = 12 // wide enough that 1.1 and 1.10-1.12 share string prefixes
+	s := newWideTreeState(t, width)
+
+	tests := []struct {
+		name       string
+		root       string
+		maxDepth   int
+		wantNodes  int
+		checkID    string
+		wantHidden int
+		wantLevels int
+	}{
+		{"unlimited", "", -1, 1 + width + 2*width*width, "1", 0, 0},
+		{"root only", "", 0, 1, "1", width + 2*width*width, 3},
+		{"one level", "", 1, 1 + width, "1.1", 2 * width, 2},
+		{"one level, prefix sibling", "", 1, 1 + width, "1.10", 2 * width, 2},
+		{"two levels", "", 2, 1 + width + width*width, "1.11.12", 1, 1},
+		{"custom root only", "1.12", 0, 1 + width, "1.12", 2 * width, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var customRoot *types.NodeID
+			if tt.root != "" {
+				id := mustParseNodeID(tt.root)
+				customRoot = &id
+			}
+
+			tv := StateToTreeViewWithDepth(s, customRoot, tt.maxDepth)
+			if len(tv.Nodes) != tt.wantNodes {
+				t.Errorf("len(Nodes) = %d, want %d", len(tv.Nodes), tt.wantNodes)
+			}
+			if len(tv.NodeLookup) != 1+width+2*width*width {
+				t.Errorf("NodeLookup should cover every node, has %d", len(tv.NodeLookup))
+			}
+
+			var found bool
+			for _, v := range tv.Nodes {
+				if v.ID != tt.checkID {
+					continue
+				}
+				found = true
+				if v.HiddenDescendants != tt.wantHidden || v.HiddenLevels != tt.wantLevels {
+					t.Errorf("node %s hidden = %d nodes / %d levels, want %d / %d",
+						v.ID, v.HiddenDescendants, v.HiddenLevels, tt.wantHidden, tt.wantLevels)
+				}
+			}
+			if !found {
+				t.Errorf("node %s missing from trimmed view", tt.checkID)
+			}
+		})
+	}
+}
+
+func TestNodeToSearchMatchView(t *testing.T) {
+	n, err := node.NewNodeWithOptions(mustParseNodeID("1.3"), schema.NodeTypeClaim, "Apply the INDUCTION hypothesis",
+		schema.InferenceModusPonens, node.NodeOptions{Latex: `P(n) \implies P(n+1)`})
+	if err != nil {
+		t.Fatalf("NewNodeWithOptions failed: %v", err)
+	}
+
+	v := NodeToSearchMatchView(n, "induction", false)
+	if v.ID != "1.3" || v.Field != "statement" {
+		t.Errorf("ID, Field = %q, %q, want 1.3, statement", v.ID, v.Field)
+	}
+	if got := v.Snippet[v.MatchStart:v.MatchEnd]; got != "INDUCTION" {
+		t.Errorf("matched text = %q, want %q", got, "INDUCTION")
+	}
+
+	// A query only present in the LaTeX takes the snippet from there
+	v = NodeToSearchMatchView(n, `\implies`, false)
+	if v.Field != "latex" {
+		t.Errorf("Field = %q, want latex", v.Field)
+	}
+	if got := v.Snippet[v.MatchStart:v.MatchEnd]; got != `\implies` {
+		t.Errorf("matched text = %q, want %q", got, `\implies`)
+	}
+
+	// No text query: snippet is the statement without highlight
+	v = NodeToSearchMatchView(n, "", false)
+	if v.Snippet != "Apply the INDUCTION hypothesis" || v.MatchStart != v.MatchEnd {
+		t.Errorf("snippet = %q [%d:%d], want full statement, no highlight", v.Snippet, v.MatchStart, v.MatchEnd)
+	}
+}
+
+func TestStateToStatusGroupsView(t *testing.T) {
+	s := state.NewState()
+	add := func(id string, epistemic schema.EpistemicState, workflow schema.WorkflowState) {
+		n, err := node.NewNode(mustParseNodeID(id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatalf("NewNode(%s) failed: %v", id, err)
+		}
+		n.EpistemicState = epistemic
+		n.WorkflowState = workflow
+		s.AddNode(n)
+	}
+	add("1", schema.EpistemicPending, schema.WorkflowAvailable)
+	add("1.10", schema.EpistemicValidated, schema.WorkflowAvailable)
+	add("1.2", schema.EpistemicValidated, schema.WorkflowAvailable)
+	add("1.3", schema.EpistemicAdmitted, schema.WorkflowAvailable)
+	add("1.4", schema.EpistemicPending, schema.WorkflowClaimed)
+	add("1.5", schema.EpistemicNeedsRefinement, schema.WorkflowAvailable)
+	add("1.6", schema.EpistemicArchived, schema.WorkflowAvailable)
+
+	view := StateToStatusGroupsView(s)
+	want := []struct {
+		name string
+		ids  []string
+	}{
+		{"Validated", []string{"1.2", "1.10"}},
+		{"Admitted", []string{"1.3"}},
+		{"Refuted", []string{}},
+		{"Pending", []string{"1", "1.5"}},
+		{"Claimed", []string{"1.4"}},
+	}
+	if len(view.Groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(view.Groups), len(want))
+	}
+	for i, w := range want {
+		g := view.Groups[i]
+		if g.Name != w.name || g.Count != len(w.ids) || strings.Join(g.NodeIDs, ",") != strings.Join(w.ids, ",") {
+			t.Errorf("group %d = %+v, want %s %v", i, g, w.name, w.ids)
+		}
+		if g.NodeIDs == nil {
+			t.Errorf("group %s: NodeIDs is nil, want an empty slice", g.Name)
+		}
+	}
+}
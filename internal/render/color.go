@@ -2,8 +2,11 @@
 package render
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/schema"
@@ -53,14 +56,166 @@ func IsColorEnabled() bool {
 	return colorEnabled
 }
 
-// colorize wraps text with ANSI color codes if color is enabled.
-func colorize(text, colorCode string) string {
-	if !colorEnabled {
+// ColorMode selects when the CLI emits ANSI color codes.
+type ColorMode string
+
+// Color modes accepted by the --color flag.
+const (
+	ColorAuto   ColorMode = "auto"   // Color when writing to a terminal and NO_COLOR is unset
+	ColorAlways ColorMode = "always" // Always color, even when piped
+	ColorNever  ColorMode = "never"  // Never color
+)
+
+// ParseColorMode parses a --color flag value. The empty string means auto.
+func ParseColorMode(s string) (ColorMode, error) {
+	switch ColorMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", ColorAuto:
+		return ColorAuto, nil
+	case ColorAlways:
+		return ColorAlways, nil
+	case ColorNever:
+		return ColorNever, nil
+	default:
+		return "", fmt.Errorf("invalid color mode %q: must be 'auto', 'always', or 'never'", s)
+	}
+}
+
+// ShouldColor reports whether output written to out should be colored under mode.
+// In auto mode color is used only when out is a terminal, NO_COLOR is unset,
+// and TERM is not "dumb". The always and never modes override the environment.
+func ShouldColor(mode ColorMode, out io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if _, exists := os.LookupEnv("NO_COLOR"); exists {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(out)
+}
+
+// IsTerminal reports whether w is a character device such as a TTY.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// RenderOptions controls presentation details of the human-readable renderers.
+type RenderOptions struct {
+	// Color wraps status tokens (epistemic states, taint states, severities)
+	// in ANSI color codes.
+	Color bool
+
+	// CollapseValidated renders each tree node whose SubtreeSettled flag is
+	// set as a single summary line instead of expanding its subtree.
+	CollapseValidated bool
+
+	// AbbreviateIDs shows each node in the tree view by the last segment
+	// of its ID, below the full ID of the node the tree starts from, so the
+	// indentation conveys the common prefix. It only affects text output;
+	// view models and other views keep full IDs.
+	AbbreviateIDs bool
+
+	// Width is the column width statements are wrapped to in tree, list, and
+	// node detail output; 0 disables wrapping. The CLI sets it from
+	// TerminalWidth.
+	Width int
+
+	// Now, if set, is the reference time event log and node history
+	// timestamps are rendered relative to ("3m ago"); the zero value renders
+	// them absolutely.
+	Now time.Time
+}
+
+// DefaultRenderOptions returns options reflecting the package-wide color setting.
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{Color: colorEnabled}
+}
+
+// colorize wraps text with ANSI color codes if o.Color is set.
+func (o RenderOptions) colorize(text, colorCode string) string {
+	if !o.Color {
 		return text
 	}
 	return colorCode + text + ansiReset
 }
 
+// ColorEpistemicState is the string form of the package-level ColorEpistemicState
+// that honors o.Color.
+func (o RenderOptions) ColorEpistemicState(state string) string {
+	switch schema.EpistemicState(state) {
+	case schema.EpistemicPending:
+		return o.colorize(state, ansiYellow)
+	case schema.EpistemicValidated:
+		return o.colorize(state, ansiGreen)
+	case schema.EpistemicAdmitted:
+		return o.colorize(state, ansiCyan)
+	case schema.EpistemicRefuted:
+		return o.colorize(state, ansiRed)
+	case schema.EpistemicArchived:
+		return o.colorize(state, ansiGray)
+	case schema.EpistemicNeedsRefinement:
+		return o.colorize(state, ansiMagenta)
+	default:
+		return state
+	}
+}
+
+// ColorTaintState is the string form of the package-level ColorTaintState
+// that honors o.Color.
+func (o RenderOptions) ColorTaintState(state string) string {
+	switch node.TaintState(state) {
+	case node.TaintClean:
+		return o.colorize(state, ansiGreen)
+	case node.TaintSelfAdmitted:
+		return o.colorize(state, ansiCyan)
+	case node.TaintTainted:
+		return o.colorize(state, ansiRed)
+	case node.TaintUnresolved:
+		return o.colorize(state, ansiYellow)
+	default:
+		return state
+	}
+}
+
+// ColorSeverity returns text colored by challenge severity.
+// Color mapping:
+//   - critical = red (blocks acceptance)
+//   - major = yellow (blocks acceptance)
+//   - minor = cyan
+//   - note = gray
+func (o RenderOptions) ColorSeverity(severity, text string) string {
+	switch schema.ChallengeSeverity(severity) {
+	case schema.SeverityCritical:
+		return o.colorize(text, ansiRed)
+	case schema.SeverityMajor:
+		return o.colorize(text, ansiYellow)
+	case schema.SeverityMinor:
+		return o.colorize(text, ansiCyan)
+	case schema.SeverityNote:
+		return o.colorize(text, ansiGray)
+	default:
+		return text
+	}
+}
+
+// colorize wraps text with ANSI color codes if color is enabled.
+func colorize(text, colorCode string) string {
+	return DefaultRenderOptions().colorize(text, colorCode)
+}
+
 // Red returns text colored red.
 func Red(text string) string {
 	return colorize(text, ansiRed)
@@ -110,23 +265,7 @@ func Bold(text string) string {
 //   - archived = gray (inactive/superseded)
 //   - needs_refinement = magenta (reopened for further development)
 func ColorEpistemicState(state schema.EpistemicState) string {
-	s := string(state)
-	switch state {
-	case schema.EpistemicPending:
-		return Yellow(s)
-	case schema.EpistemicValidated:
-		return Green(s)
-	case schema.EpistemicAdmitted:
-		return Cyan(s)
-	case schema.EpistemicRefuted:
-		return Red(s)
-	case schema.EpistemicArchived:
-		return Gray(s)
-	case schema.EpistemicNeedsRefinement:
-		return Magenta(s)
-	default:
-		return s
-	}
+	return DefaultRenderOptions().ColorEpistemicState(string(state))
 }
 
 // ColorTaintState returns the taint state string with appropriate color coding.
@@ -136,19 +275,7 @@ func ColorEpistemicState(state schema.EpistemicState) string {
 //   - tainted = red (depends on tainted/refuted node)
 //   - unresolved = yellow (taint not yet computed)
 func ColorTaintState(state node.TaintState) string {
-	s := string(state)
-	switch state {
-	case node.TaintClean:
-		return Green(s)
-	case node.TaintSelfAdmitted:
-		return Cyan(s)
-	case node.TaintTainted:
-		return Red(s)
-	case node.TaintUnresolved:
-		return Yellow(s)
-	default:
-		return s
-	}
+	return DefaultRenderOptions().ColorTaintState(string(state))
 }
 
 // StripANSI removes ANSI escape codes from a string.
@@ -481,3 +481,97 @@ func TestAllColorsProduceDistinctOutput(t *testing.T) {
 		seen[name] = result
 	}
 }
+
+// TestParseColorMode tests parsing of --color flag values.
+func TestParseColorMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ColorMode
+		wantErr bool
+	}{
+		{"", ColorAuto, false},
+		{"auto", ColorAuto, false},
+		{"ALWAYS", ColorAlways, false},
+		{" never ", ColorNever, false},
+		{"sometimes", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseColorMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseColorMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColorMode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestShouldColor tests color resolution for each mode.
+func TestShouldColor(t *testing.T) {
+	var buf strings.Builder
+
+	if !ShouldColor(ColorAlways, &buf) {
+		t.Error("always should color even when not writing to a terminal")
+	}
+	if ShouldColor(ColorNever, &buf) {
+		t.Error("never should not color")
+	}
+	if ShouldColor(ColorAuto, &buf) {
+		t.Error("auto should not color when not writing to a terminal")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ShouldColor(ColorAuto, &buf) {
+		t.Error("auto should not color when NO_COLOR is set")
+	}
+	if !ShouldColor(ColorAlways, &buf) {
+		t.Error("always should override NO_COLOR")
+	}
+}
+
+// TestRenderOptions_IgnoresPackageColorState tests that explicit options
+// take precedence over EnableColor/DisableColor.
+func TestRenderOptions_IgnoresPackageColorState(t *testing.T) {
+	restore := saveColorState()
+	defer restore()
+
+	DisableColor()
+	if got := (RenderOptions{Color: true}).ColorEpistemicState("validated"); got != ansiGreen+"validated"+ansiReset {
+		t.Errorf("Color: true should color validated green, got %q", got)
+	}
+
+	EnableColor()
+	if got := (RenderOptions{}).ColorTaintState("tainted"); got != "tainted" {
+		t.Errorf("Color: false should leave tainted plain, got %q", got)
+	}
+}
+
+// TestRenderOptions_ColorSeverity tests severity color coding.
+func TestRenderOptions_ColorSeverity(t *testing.T) {
+	opts := RenderOptions{Color: true}
+
+	tests := []struct {
+		severity string
+		code     string
+	}{
+		{"critical", ansiRed},
+		{"major", ansiYellow},
+		{"minor", ansiCyan},
+		{"note", ansiGray},
+	}
+	for _, tt := range tests {
+		got := opts.ColorSeverity(tt.severity, "1 "+tt.severity)
+		if got != tt.code+"1 "+tt.severity+ansiReset {
+			t.Errorf("ColorSeverity(%q) = %q", tt.severity, got)
+		}
+	}
+
+	if got := opts.ColorSeverity("unknown", "x"); got != "x" {
+		t.Errorf("unknown severity should be plain, got %q", got)
+	}
+	if got := (RenderOptions{}).ColorSeverity("critical", "x"); got != "x" {
+		t.Errorf("Color: false should be plain, got %q", got)
+	}
+}
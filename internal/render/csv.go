@@ -0,0 +1,66 @@
+// Package render provides CSV formatting for AF framework types.
+package render
+
+import (
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// nodeStatusCSVHeader is the header row of RenderNodeStatusCSV, without the
+// optional statement column.
+var nodeStatusCSVHeader = []string{
+	"id", "depth", "type", "inference", "workflow_state", "epistemic_state",
+	"taint_state", "num_dependencies", "num_children", "claimed_by", "open_challenges",
+}
+
+// RenderNodeStatusCSV renders the node status table as CSV: a header row,
+// then one row per node sorted by ID. If includeStatements is true, a
+// trailing statement column is added. Fields are quoted per RFC 4180 where
+// needed, so statements may contain commas, quotes, and newlines.
+func RenderNodeStatusCSV(v NodeStatusTableView, includeStatements bool) string {
+	rows := make([]NodeStatusRowView, len(v.Rows))
+	copy(rows, v.Rows)
+	sortNodeStatusRowsByID(rows)
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	header := nodeStatusCSVHeader
+	if includeStatements {
+		header = append(append([]string{}, header...), "statement")
+	}
+	// Writing to a strings.Builder cannot fail
+	_ = w.Write(header)
+
+	for _, r := range rows {
+		record := []string{
+			r.ID,
+			strconv.Itoa(r.Depth),
+			r.Type,
+			r.Inference,
+			r.WorkflowState,
+			r.EpistemicState,
+			r.TaintState,
+			strconv.Itoa(r.NumDependencies),
+			strconv.Itoa(r.NumChildren),
+			r.ClaimedBy,
+			strconv.Itoa(r.OpenChallenges),
+		}
+		if includeStatements {
+			record = append(record, r.Statement)
+		}
+		_ = w.Write(record)
+	}
+
+	w.Flush()
+	return sb.String()
+}
+
+// sortNodeStatusRowsByID sorts rows by hierarchical node ID.
+func sortNodeStatusRowsByID(rows []NodeStatusRowView) {
+	sort.Slice(rows, func(i, j int) bool {
+		return compareNodeIDs(rows[i].ID, rows[j].ID)
+	})
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestRenderNodeStatusCSV(t *testing.T) {
+	v := NodeStatusTableView{Rows: []NodeStatusRowView{
+		{ID: "1.10", Depth: 2, Type: "claim", Inference: "assumption", WorkflowState: "available",
+			EpistemicState: "pending", TaintState: "unresolved", Statement: "Plain"},
+		{ID: "1", Depth: 1, Type: "claim", Inference: "assumption", WorkflowState: "claimed",
+			EpistemicState: "pending", TaintState: "unresolved", NumChildren: 2, ClaimedBy: "prover",
+			OpenChallenges: 1, Statement: "Has, a comma and \"quotes\"\nand a newline"},
+		{ID: "1.2", Depth: 2, Type: "claim", Inference: "modus_ponens", WorkflowState: "available",
+			EpistemicState: "validated", TaintState: "clean", NumDependencies: 1, Statement: "Depends"},
+	}}
+
+	got := RenderNodeStatusCSV(v, false)
+	want := `id,depth,type,inference,workflow_state,epistemic_state,taint_state,num_dependencies,num_children,claimed_by,open_challenges
+1,1,claim,assumption,claimed,pending,unresolved,0,2,prover,1
+1.2,2,claim,modus_ponens,available,validated,clean,1,0,,0
+1.10,2,claim,assumption,available,pending,unresolved,0,0,,0
+`
+	if got != want {
+		t.Errorf("RenderNodeStatusCSV() =\n%s\nwant:\n%s", got, want)
+	}
+
+	got = RenderNodeStatusCSV(v, true)
+	want = `id,depth,type,inference,workflow_state,epistemic_state,taint_state,num_dependencies,num_children,claimed_by,open_challenges,statement
+1,1,claim,assumption,claimed,pending,unresolved,0,2,prover,1,"Has, a comma and ""quotes""
+and a newline"
+1.2,2,claim,modus_ponens,available,validated,clean,1,0,,0,Depends
+1.10,2,claim,assumption,available,pending,unresolved,0,0,,0,Plain
+`
+	if got != want {
+		t.Errorf("RenderNodeStatusCSV(includeStatements) =\n%s\nwant:\n%s", got, want)
+	}
+
+	header := "id,depth,type,inference,workflow_state,epistemic_state,taint_state,num_dependencies,num_children,claimed_by,open_challenges\n"
+	if empty := RenderNodeStatusCSV(NodeStatusTableView{}, false); empty != header {
+		t.Errorf("RenderNodeStatusCSV(empty) = %q", empty)
+	}
+}
+
+func TestStateToNodeStatusTableView(t *testing.T) {
+	s := state.NewState()
+	for _, id := range []string{"1", "1.1", "1.2", "1.1.1"} {
+		opts := node.NodeOptions{}
+		if id == "1.2" {
+			opts.Dependencies = []types.NodeID{mustParseNodeID("1.1")}
+		}
+		n, err := node.NewNodeWithOptions(mustParseNodeID(id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.AddNode(n)
+	}
+	s.GetNode(mustParseNodeID("1.1")).ClaimedBy = "prover"
+	s.AddChallenge(&state.Challenge{ID: "c1", NodeID: mustParseNodeID("1.1"), Status: state.ChallengeStatusOpen, Created: types.FromTime(time.Now())})
+	s.AddChallenge(&state.Challenge{ID: "c2", NodeID: mustParseNodeID("1.1"), Status: state.ChallengeStatusResolved, Created: types.FromTime(time.Now())})
+
+	view := StateToNodeStatusTableView(s)
+	ids := []string{"1", "1.1", "1.1.1", "1.2"}
+	if len(view.Rows) != len(ids) {
+		t.Fatalf("got %d rows, want %d", len(view.Rows), len(ids))
+	}
+	for i, id := range ids {
+		if view.Rows[i].ID != id {
+			t.Errorf("row %d ID = %s, want %s", i, view.Rows[i].ID, id)
+		}
+	}
+
+	if r := view.Rows[0]; r.Depth != 1 || r.NumChildren != 2 {
+		t.Errorf("root row = %+v, want depth 1 and 2 children", r)
+	}
+	if r := view.Rows[1]; r.NumChildren != 1 || r.ClaimedBy != "prover" || r.OpenChallenges != 1 {
+		t.Errorf("1.1 row = %+v, want 1 child, claimed by prover, 1 open challenge", r)
+	}
+	if r := view.Rows[3]; r.NumDependencies != 1 || r.Depth != 2 || r.Statement != "Claim 1.2" {
+		t.Errorf("1.2 row = %+v, want 1 dependency at depth 2", r)
+	}
+
+	if empty := StateToNodeStatusTableView(nil); len(empty.Rows) != 0 {
+		t.Errorf("nil state produced %d rows", len(empty.Rows))
+	}
+}
@@ -0,0 +1,97 @@
+// Package render provides Graphviz DOT formatting for AF framework types.
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dotLabelMaxLen is the maximum statement length shown in a DOT node label.
+const dotLabelMaxLen = 40
+
+// dotFillColors maps epistemic states to Graphviz fill colors.
+// States not listed here use dotDefaultFillColor.
+var dotFillColors = map[string]string{
+	"validated":        "green",
+	"refuted":          "red",
+	"admitted":         "yellow",
+	"pending":          "grey",
+	"archived":         "white",
+	"needs_refinement": "orange",
+}
+
+// dotDefaultFillColor is used for unknown epistemic states.
+const dotDefaultFillColor = "grey"
+
+// RenderDependencyGraphDOT renders the dependency graph as a Graphviz digraph.
+// Each node is labeled with its ID and truncated statement and filled by
+// epistemic state. Reference dependencies are drawn as solid edges and
+// validation dependencies as dashed edges, both pointing from the dependent
+// node to the node it depends on. Edges are taken from v.Edges.
+//
+// Nodes and edges are emitted in sorted ID order so the output is stable
+// across runs and diff-friendly.
+func RenderDependencyGraphDOT(v DependencyGraphView) string {
+	nodes := make([]NodeView, len(v.Nodes))
+	copy(nodes, v.Nodes)
+	sortNodeViewsByID(nodes)
+
+	var sb strings.Builder
+	sb.WriteString("digraph proof {\n")
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  node [shape=box, style=filled, fontname=\"Helvetica\"];\n")
+
+	if len(nodes) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, n := range nodes {
+		label := n.ID + "\n" + truncateRunes(n.Statement, dotLabelMaxLen)
+		fmt.Fprintf(&sb, "  %s [label=%s, fillcolor=%s];\n",
+			dotQuote(n.ID), dotQuote(label), dotFillColor(n.EpistemicState))
+	}
+
+	var edges strings.Builder
+	for _, e := range sortedGraphEdges(v.Edges) {
+		if e.Kind == graphEdgeValidation {
+			fmt.Fprintf(&edges, "  %s -> %s [style=dashed];\n", dotQuote(e.From), dotQuote(e.To))
+		} else {
+			fmt.Fprintf(&edges, "  %s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+		}
+	}
+	if edges.Len() > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(edges.String())
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotFillColor returns the fill color for an epistemic state.
+func dotFillColor(epistemicState string) string {
+	if c, ok := dotFillColors[epistemicState]; ok {
+		return c
+	}
+	return dotDefaultFillColor
+}
+
+// dotQuote returns s as a quoted DOT string, escaping quotes and backslashes
+// and encoding newlines as DOT line breaks.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// sortedCopy returns a copy of node ID strings sorted in hierarchical order.
+func sortedCopy(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	sorted := make([]string, len(ids))
+	copy(sorted, ids)
+	sort.Slice(sorted, func(i, j int) bool {
+		return compareNodeIDs(sorted[i], sorted[j])
+	})
+	return sorted
+}
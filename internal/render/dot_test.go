@@ -0,0 +1,97 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderDependencyGraphDOT(t *testing.T) {
+	v := DependencyGraphView{
+		Nodes: []NodeView{
+			{ID: "1.10", Statement: "Tenth step", EpistemicState: "admitted"},
+			{ID: "1.2", Statement: `Uses "quoted" text`, EpistemicState: "refuted",
+				Dependencies: []string{"1.10", "1.1"}, ValidationDeps: []string{"1"}},
+			{ID: "1", Statement: "Root claim", EpistemicState: "pending"},
+			{ID: "1.1", Statement: "A statement that is far too long to fit in a graph label", EpistemicState: "validated"},
+		},
+		Edges: []GraphEdgeView{
+			{From: "1.2", To: "1", Kind: "validation"},
+			{From: "1.2", To: "1.10", Kind: "dependency"},
+			{From: "1.2", To: "1.1", Kind: "dependency"},
+		},
+	}
+
+	got := RenderDependencyGraphDOT(v)
+
+	want := `digraph proof {
+  rankdir=TB;
+  node [shape=box, style=filled, fontname="Helvetica"];
+
+  "1" [label="1\nRoot claim", fillcolor=grey];
+  "1.1" [label="1.1\nA statement that is far too long to f...", fillcolor=green];
+  "1.2" [label="1.2\nUses \"quoted\" text", fillcolor=red];
+  "1.10" [label="1.10\nTenth step", fillcolor=yellow];
+
+  "1.2" -> "1.1";
+  "1.2" -> "1.10";
+  "1.2" -> "1" [style=dashed];
+}
+`
+	if got != want {
+		t.Errorf("RenderDependencyGraphDOT() =\n%s\nwant:\n%s", got, want)
+	}
+
+	// Input order must not affect output
+	reversed := DependencyGraphView{Nodes: make([]NodeView, len(v.Nodes)), Edges: make([]GraphEdgeView, len(v.Edges))}
+	for i, n := range v.Nodes {
+		reversed.Nodes[len(v.Nodes)-1-i] = n
+	}
+	for i, e := range v.Edges {
+		reversed.Edges[len(v.Edges)-1-i] = e
+	}
+	if RenderDependencyGraphDOT(reversed) != got {
+		t.Error("RenderDependencyGraphDOT() output depends on input order")
+	}
+}
+
+func TestRenderDependencyGraphDOT_Empty(t *testing.T) {
+	got := RenderDependencyGraphDOT(DependencyGraphView{})
+	if !strings.HasPrefix(got, "digraph proof {\n") || !strings.HasSuffix(got, "}\n") {
+		t.Errorf("RenderDependencyGraphDOT() = %q, want empty digraph", got)
+	}
+	if strings.Contains(got, "->") {
+		t.Errorf("RenderDependencyGraphDOT() = %q, want no edges", got)
+	}
+}
+
+func TestRenderDependencyGraph(t *testing.T) {
+	v := DependencyGraphView{
+		Nodes: []NodeView{{ID: "1"}, {ID: "1.1"}, {ID: "1.2"}},
+		Edges: []GraphEdgeView{
+			{From: "1.2", To: "1", Kind: "validation"},
+			{From: "1.2", To: "1.1", Kind: "dependency"},
+			{From: "1.1", To: "1.2", Kind: "dependency"},
+		},
+		Cycles: [][]string{{"1.1", "1.2", "1.1"}},
+	}
+
+	got := RenderDependencyGraph(v)
+
+	want := `Dependency graph: 3 node(s), 3 edge(s)
+
+Edges:
+  1.1 -> 1.2 (dependency)
+  1.2 -> 1.1 (dependency)
+  1.2 -> 1 (validation)
+
+Cycles (1):
+  1.1 -> 1.2 -> 1.1
+`
+	if got != want {
+		t.Errorf("RenderDependencyGraph() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := RenderDependencyGraph(DependencyGraphView{}); got != "Dependency graph: 0 node(s), 0 edge(s)\n" {
+		t.Errorf("RenderDependencyGraph(empty) = %q", got)
+	}
+}
@@ -0,0 +1,58 @@
+// Package render provides the JSON envelope used by the global --json flag.
+package render
+
+// JSONSchemaVersion is the version of the JSON output contract.
+// It is bumped whenever a view model's json tags change incompatibly.
+const JSONSchemaVersion = 1
+
+// JSONEnvelope wraps a command's machine-readable output.
+// Every command emits the same top-level fields so consumers can
+// dispatch on Command and check SchemaVersion before reading Data.
+type JSONEnvelope struct {
+	Command       string      `json:"command"`
+	SchemaVersion int         `json:"schema_version"`
+	Data          interface{} `json:"data"`
+}
+
+// JSONErrorEnvelope is the machine-readable form of a failed command.
+// Code is the stable error code (e.g. "NODE_NOT_FOUND") that consumers
+// should branch on; it is omitted for errors that carry none.
+type JSONErrorEnvelope struct {
+	Command       string `json:"command,omitempty"`
+	SchemaVersion int    `json:"schema_version"`
+	Code          string `json:"code,omitempty"`
+	Error         string `json:"error"`
+	ExitCode      int    `json:"exit_code"`
+}
+
+// RenderJSONEnvelope renders data wrapped in a JSONEnvelope for the given command.
+// Data should be a view model (or a struct of view models) so the output shape
+// is stable across releases.
+func RenderJSONEnvelope(command string, data interface{}) (string, error) {
+	b, err := marshalJSON(JSONEnvelope{
+		Command:       command,
+		SchemaVersion: JSONSchemaVersion,
+		Data:          data,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// RenderJSONError renders an error code, message and exit code as a
+// JSONErrorEnvelope. The message should already be sanitized by the caller.
+func RenderJSONError(command, code, message string, exitCode int) string {
+	b, err := marshalJSON(JSONErrorEnvelope{
+		Command:       command,
+		SchemaVersion: JSONSchemaVersion,
+		Code:          code,
+		Error:         message,
+		ExitCode:      exitCode,
+	})
+	if err != nil {
+		// A struct of strings and ints cannot fail to marshal; fall back defensively.
+		return `{"error":"failed to encode error","exit_code":1}`
+	}
+	return string(b)
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderJSONEnvelope(t *testing.T) {
+	view := StatusView{
+		Nodes:          []NodeView{{ID: "1", Statement: "a < b & c"}},
+		ProverJobCount: 1,
+	}
+
+	out, err := RenderJSONEnvelope("status", view)
+	if err != nil {
+		t.Fatalf("RenderJSONEnvelope failed: %v", err)
+	}
+
+	var got struct {
+		Command       string `json:"command"`
+		SchemaVersion int    `json:"schema_version"`
+		Data          struct {
+			Nodes []struct {
+				ID        string `json:"id"`
+				Statement string `json:"statement"`
+			} `json:"nodes"`
+			ProverJobCount int `json:"prover_job_count"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("envelope is not valid JSON: %v\n%s", err, out)
+	}
+
+	if got.Command != "status" {
+		t.Errorf("command = %q, want %q", got.Command, "status")
+	}
+	if got.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("schema_version = %d, want %d", got.SchemaVersion, JSONSchemaVersion)
+	}
+	if len(got.Data.Nodes) != 1 || got.Data.Nodes[0].ID != "1" {
+		t.Errorf("data.nodes = %+v, want one node with id 1", got.Data.Nodes)
+	}
+	if got.Data.ProverJobCount != 1 {
+		t.Errorf("data.prover_job_count = %d, want 1", got.Data.ProverJobCount)
+	}
+}
+
+func TestRenderJSONEnvelope_NoHTMLEscape(t *testing.T) {
+	out, err := RenderJSONEnvelope("get", NodeView{Statement: "x < y"})
+	if err != nil {
+		t.Fatalf("RenderJSONEnvelope failed: %v", err)
+	}
+	if !json.Valid([]byte(out)) {
+		t.Fatalf("invalid JSON: %s", out)
+	}
+	if !strings.Contains(out, "x < y") {
+		t.Errorf("expected unescaped statement in output, got %s", out)
+	}
+}
+
+func TestRenderJSONError(t *testing.T) {
+	out := RenderJSONError("claim", "NODE_NOT_FOUND", "node 1.2 not found", 3)
+
+	var got JSONErrorEnvelope
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("error envelope is not valid JSON: %v\n%s", err, out)
+	}
+	if got.Command != "claim" || got.Code != "NODE_NOT_FOUND" || got.Error != "node 1.2 not found" || got.ExitCode != 3 {
+		t.Errorf("unexpected error envelope: %+v", got)
+	}
+	if got.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("schema_version = %d, want %d", got.SchemaVersion, JSONSchemaVersion)
+	}
+
+	// Errors without a code omit the field
+	if out := RenderJSONError("claim", "", "boom", 1); strings.Contains(out, `"code"`) {
+		t.Errorf("expected no code field, got %s", out)
+	}
+}
@@ -17,13 +17,14 @@ type RenderedError struct {
 	Code       string   // Error code (e.g., "ALREADY_CLAIMED")
 	Message    string   // Human-readable error message
 	Recovery   []string // Suggested recovery actions
-	ExitCode   int      // Process exit code (0, 1, 2, 3, or 4)
+	ExitCode   int      // Process exit code (see errors.ExitCode)
 	JSONOutput string   // Pre-computed JSON representation (optional)
 }
 
 // RenderError renders any error for display.
 // If the error is an AFError, it includes recovery suggestions.
-// Generic errors are rendered with default exit code 1 (retriable).
+// Generic errors are rendered with errors.ExitGeneric (1); they are not
+// retriable, which is reserved for conflicts (errors.ExitConflict).
 // Returns a zero-value RenderedError for nil errors.
 func RenderError(err error) RenderedError {
 	if err == nil {
@@ -38,7 +39,7 @@ func RenderError(err error) RenderedError {
 			Code:     "",
 			Message:  err.Error(),
 			Recovery: []string{},
-			ExitCode: 1, // Default to retriable
+			ExitCode: errors.ExitGeneric,
 		}
 	}
 
@@ -94,6 +95,18 @@ func getRecoverySuggestions(code errors.ErrorCode, message string) []string {
 		}
 		return suggestions
 
+	case errors.CLAIM_NOT_EXPIRED:
+		if nodeID != "" {
+			return []string{
+				fmt.Sprintf("Check when the claim expires with 'af get %s'", nodeID),
+				"Wait for the claim to expire, then retry 'af reclaim'",
+			}
+		}
+		return []string{
+			"Wait for the claim to expire, then retry 'af reclaim'",
+			"Use 'af jobs' to find available nodes",
+		}
+
 	case errors.NOT_CLAIM_HOLDER:
 		if nodeID != "" {
 			return []string{
@@ -106,7 +119,7 @@ func getRecoverySuggestions(code errors.ErrorCode, message string) []string {
 			"Check who owns the claim with 'af get <node>'",
 		}
 
-	case errors.NODE_BLOCKED:
+	case errors.NODE_BLOCKED, errors.BLOCKING_CHALLENGES:
 		if nodeID != "" {
 			return []string{
 				fmt.Sprintf("Check blockers with 'af get %s'", nodeID),
@@ -210,6 +223,18 @@ func getRecoverySuggestions(code errors.ErrorCode, message string) []string {
 			"Use 'af externals' to list all external references",
 		}
 
+	case errors.LEMMA_NOT_FOUND:
+		return []string{
+			"Use 'af lemmas' to list extracted lemmas",
+			"Lemmas are created with 'af extract-lemma'",
+		}
+
+	case errors.CHECKPOINT_NOT_FOUND:
+		return []string{
+			"Use 'af checkpoint list' to list checkpoints",
+			"Checkpoints are created with 'af checkpoint create'",
+		}
+
 	case errors.SCOPE_VIOLATION:
 		return []string{
 			"Use 'af scope' to check current scope boundaries",
@@ -228,18 +253,30 @@ func getRecoverySuggestions(code errors.ErrorCode, message string) []string {
 			"Restructure dependencies to eliminate the cycle",
 		}
 
+	case errors.READ_ONLY:
+		return []string{
+			"This proof was opened read-only, e.g. for a dashboard",
+			"Open it without read-only mode to make changes",
+		}
+
 	case errors.CONTENT_HASH_MISMATCH:
 		return []string{
 			"Data integrity issue detected - do not modify .af files manually",
 			"Contact administrator if problem persists",
 		}
 
-	case errors.VALIDATION_INVARIANT_FAILED:
+	case errors.VALIDATION_INVARIANT_FAILED, errors.CONCURRENT_MODIFICATION:
 		return []string{
 			"Retry the operation - this may be a transient issue",
 			"If problem persists, run 'af replay' to rebuild state",
 		}
 
+	case errors.NO_AVAILABLE_NODES:
+		return []string{
+			"Every open node is claimed or blocked - retry later",
+			"Use 'af jobs' to see what other agents are working on",
+		}
+
 	case errors.LEDGER_INCONSISTENT:
 		return []string{
 			"Ledger corruption detected - do not modify .af files manually",
@@ -276,6 +313,19 @@ func getRecoverySuggestions(code errors.ErrorCode, message string) []string {
 			"Use 'af status' to see the current proof structure",
 		}
 
+	case errors.DEPENDENCIES_UNVALIDATED:
+		if nodeID != "" {
+			return []string{
+				fmt.Sprintf("Validate or admit the dependencies of %s first", nodeID),
+				fmt.Sprintf("View dependencies with 'af get %s'", nodeID),
+				"Use 'af accept --allow-unvalidated-deps' to validate bottom-up",
+			}
+		}
+		return []string{
+			"Validate or admit the node's dependencies first",
+			"Use 'af accept --allow-unvalidated-deps' to validate bottom-up",
+		}
+
 	case errors.EXTRACTION_INVALID:
 		if nodeID != "" {
 			return []string{
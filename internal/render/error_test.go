@@ -22,35 +22,35 @@ func TestRenderError_AFError(t *testing.T) {
 			name:         "ALREADY_CLAIMED error",
 			err:          errors.New(errors.ALREADY_CLAIMED, "node 1.2 is claimed by agent-xyz"),
 			wantCode:     "ALREADY_CLAIMED",
-			wantExitCode: 1,
+			wantExitCode: 4,
 			wantRecovery: true,
 		},
 		{
 			name:         "NOT_CLAIM_HOLDER error",
 			err:          errors.New(errors.NOT_CLAIM_HOLDER, "agent-abc does not hold claim on node 1.2"),
 			wantCode:     "NOT_CLAIM_HOLDER",
-			wantExitCode: 1,
+			wantExitCode: 4,
 			wantRecovery: true,
 		},
 		{
 			name:         "NODE_BLOCKED error",
 			err:          errors.New(errors.NODE_BLOCKED, "node 1.3 is blocked by pending challenge"),
 			wantCode:     "NODE_BLOCKED",
-			wantExitCode: 2,
+			wantExitCode: 5,
 			wantRecovery: true,
 		},
 		{
 			name:         "INVALID_PARENT error",
 			err:          errors.New(errors.INVALID_PARENT, "parent node 1.5 does not exist"),
 			wantCode:     "INVALID_PARENT",
-			wantExitCode: 3,
+			wantExitCode: 6,
 			wantRecovery: true,
 		},
 		{
 			name:         "LEDGER_INCONSISTENT error",
 			err:          errors.New(errors.LEDGER_INCONSISTENT, "sequence gap detected at line 42"),
 			wantCode:     "LEDGER_INCONSISTENT",
-			wantExitCode: 4,
+			wantExitCode: 7,
 			wantRecovery: true,
 		},
 	}
@@ -273,15 +273,16 @@ func TestRenderAFError_ExitCodes(t *testing.T) {
 		code     errors.ErrorCode
 		wantExit int
 	}{
-		{"ALREADY_CLAIMED returns 1", errors.ALREADY_CLAIMED, 1},
-		{"NOT_CLAIM_HOLDER returns 1", errors.NOT_CLAIM_HOLDER, 1},
-		{"VALIDATION_INVARIANT_FAILED returns 1", errors.VALIDATION_INVARIANT_FAILED, 1},
-		{"NODE_BLOCKED returns 2", errors.NODE_BLOCKED, 2},
-		{"INVALID_PARENT returns 3", errors.INVALID_PARENT, 3},
-		{"INVALID_TYPE returns 3", errors.INVALID_TYPE, 3},
-		{"SCOPE_VIOLATION returns 3", errors.SCOPE_VIOLATION, 3},
-		{"CONTENT_HASH_MISMATCH returns 4", errors.CONTENT_HASH_MISMATCH, 4},
-		{"LEDGER_INCONSISTENT returns 4", errors.LEDGER_INCONSISTENT, 4},
+		{"NODE_NOT_FOUND returns 3", errors.NODE_NOT_FOUND, 3},
+		{"ALREADY_CLAIMED returns 4", errors.ALREADY_CLAIMED, 4},
+		{"NOT_CLAIM_HOLDER returns 4", errors.NOT_CLAIM_HOLDER, 4},
+		{"NODE_BLOCKED returns 5", errors.NODE_BLOCKED, 5},
+		{"VALIDATION_INVARIANT_FAILED returns 6", errors.VALIDATION_INVARIANT_FAILED, 6},
+		{"INVALID_PARENT returns 6", errors.INVALID_PARENT, 6},
+		{"INVALID_TYPE returns 6", errors.INVALID_TYPE, 6},
+		{"SCOPE_VIOLATION returns 6", errors.SCOPE_VIOLATION, 6},
+		{"CONTENT_HASH_MISMATCH returns 7", errors.CONTENT_HASH_MISMATCH, 7},
+		{"LEDGER_INCONSISTENT returns 7", errors.LEDGER_INCONSISTENT, 7},
 	}
 
 	for _, tt := range tests {
@@ -478,8 +479,8 @@ func TestRenderError_WrappedAFError(t *testing.T) {
 		t.Errorf("Code = %q, want INVALID_TYPE", result.Code)
 	}
 
-	if result.ExitCode != 3 {
-		t.Errorf("ExitCode = %d, want 3", result.ExitCode)
+	if result.ExitCode != 6 {
+		t.Errorf("ExitCode = %d, want 6", result.ExitCode)
 	}
 
 	// Message should contain context from wrapping
@@ -513,6 +514,8 @@ func TestRenderError_AllErrorCodes(t *testing.T) {
 		errors.CHALLENGE_LIMIT_EXCEEDED,
 		errors.REFINEMENT_LIMIT_EXCEEDED,
 		errors.EXTRACTION_INVALID,
+		errors.DEPENDENCIES_UNVALIDATED,
+		errors.CLAIM_NOT_EXPIRED,
 	}
 
 	for _, code := range errorCodes {
@@ -0,0 +1,102 @@
+// Package render provides streaming event log writers for AF framework.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// EventLogWriter writes ledger events one at a time as they are read, so a
+// log can be rendered while the ledger is scanned instead of after every
+// event has been collected.
+type EventLogWriter interface {
+	// WriteEvent writes one event. fields holds the event's decoded JSON;
+	// writers that only show the summary may ignore it.
+	WriteEvent(e EventLogEntryView, fields map[string]interface{}) error
+
+	// WriteError reports an event that could not be read or parsed.
+	WriteError(seq int, err error) error
+
+	// Close finishes the log. It does not close the underlying writer.
+	Close() error
+}
+
+// textEventLogWriter writes events in the format of RenderEventLog.
+type textEventLogWriter struct {
+	w       io.Writer
+	opts    RenderOptions
+	written int
+}
+
+// NewTextEventLogWriter returns an EventLogWriter that writes one line per
+// event, as RenderEventLogWithOptions does.
+func NewTextEventLogWriter(w io.Writer, opts RenderOptions) EventLogWriter {
+	return &textEventLogWriter{w: w, opts: opts}
+}
+
+func (t *textEventLogWriter) WriteEvent(e EventLogEntryView, _ map[string]interface{}) error {
+	t.written++
+	_, err := fmt.Fprintln(t.w, renderEventLogEntry(e, t.opts))
+	return err
+}
+
+func (t *textEventLogWriter) WriteError(seq int, err error) error {
+	t.written++
+	_, werr := fmt.Fprintf(t.w, "#%-3d  error: %s\n", seq, err)
+	return werr
+}
+
+func (t *textEventLogWriter) Close() error {
+	if t.written == 0 {
+		_, err := io.WriteString(t.w, "No events found.\n")
+		return err
+	}
+	return nil
+}
+
+// jsonlEventLogWriter writes one JSON object per line.
+type jsonlEventLogWriter struct {
+	w io.Writer
+}
+
+// NewJSONLEventLogWriter returns an EventLogWriter that writes each event as
+// a JSON object on its own line (JSON Lines). Each object carries seq, type
+// and timestamp followed by the event's own fields. An event that cannot be
+// read is written as {"seq": N, "error": "..."} so a consumer sees where the
+// stream broke.
+func NewJSONLEventLogWriter(w io.Writer) EventLogWriter {
+	return &jsonlEventLogWriter{w: w}
+}
+
+func (j *jsonlEventLogWriter) WriteEvent(e EventLogEntryView, fields map[string]interface{}) error {
+	item := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		item[k] = v
+	}
+	item["seq"] = e.Seq
+	item["type"] = e.Type
+	item["timestamp"] = e.Timestamp
+	return j.writeLine(item)
+}
+
+func (j *jsonlEventLogWriter) WriteError(seq int, err error) error {
+	return j.writeLine(map[string]interface{}{
+		"seq":   seq,
+		"error": err.Error(),
+	})
+}
+
+func (j *jsonlEventLogWriter) Close() error {
+	return nil
+}
+
+func (j *jsonlEventLogWriter) writeLine(item map[string]interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	data = append(data, '\n')
+	_, err = j.w.Write(data)
+	return err
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestJSONLEventLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewJSONLEventLogWriter(&buf)
+
+	e := EventLogEntryView{Seq: 3, Type: "node_validated", Timestamp: "2025-01-02T03:04:05Z", Summary: "Validated node 1"}
+	fields := map[string]interface{}{"type": "node_validated", "timestamp": "2025-01-02T03:04:05Z", "node_id": "1"}
+	if err := w.WriteEvent(e, fields); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteError(4, errors.New("failed to parse event 4")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"node_id":"1","seq":3,"timestamp":"2025-01-02T03:04:05Z","type":"node_validated"}` + "\n" +
+		`{"error":"failed to parse event 4","seq":4}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("JSONL output mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if _, ok := fields["seq"]; ok {
+		t.Error("WriteEvent modified the caller's fields")
+	}
+}
+
+func TestTextEventLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewTextEventLogWriter(&buf, RenderOptions{})
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "No events found.\n" {
+		t.Errorf("empty log = %q", got)
+	}
+
+	buf.Reset()
+	w = NewTextEventLogWriter(&buf, RenderOptions{})
+	w.WriteEvent(EventLogEntryView{Seq: 1, Type: "proof_initialized", Timestamp: "2025-01-02T03:04:05Z", Summary: "Initialized proof"}, nil)
+	w.WriteError(2, errors.New("failed to parse event 2"))
+	w.Close()
+
+	want := "#1    ProofInitialized      2025-01-02 03:04:05  Initialized proof\n" +
+		"#2    error: failed to parse event 2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("text output mismatch:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
@@ -8,6 +8,14 @@ var CommandExamples = map[string][]string{
 		"af claim 1 --owner agent1 --role prover",
 		"af claim 1.2 --owner agent1 --role verifier --timeout 30m",
 	},
+	"af reclaim": {
+		"af reclaim 1.2 --owner agent2",
+		"af reclaim 1.2 -o agent2 --timeout 2h",
+	},
+	"af who": {
+		"af who 1.2",
+		"af who 1 -f json",
+	},
 	"af release": {
 		"af release 1 --owner agent1",
 		"af release 1.2 -o agent1",
@@ -83,6 +91,11 @@ var CommandExamples = map[string][]string{
 		"af search --workflow available",
 		"af search --state validated --json",
 	},
+	"af list": {
+		"af list",
+		"af list --filter pending",
+		"af list --sort depth",
+	},
 }
 
 // ValidRoles contains the valid role values for commands that accept --role.
@@ -114,6 +127,12 @@ var ValidEpistemicStates = []string{"pending", "validated", "admitted", "refuted
 // ValidWorkflowStates contains the valid workflow state values.
 var ValidWorkflowStates = []string{"available", "claimed", "blocked"}
 
+// ValidListFilters contains the valid af list --filter values.
+var ValidListFilters = []string{"pending", "claimed", "validated", "refuted", "tainted"}
+
+// ValidListSorts contains the valid af list --sort values.
+var ValidListSorts = []string{"id", "depth", "state"}
+
 // GetExamples returns example usage for a command, or nil if not found.
 func GetExamples(command string) []string {
 	return CommandExamples[command]
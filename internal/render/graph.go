@@ -0,0 +1,54 @@
+// Package render provides dependency graph formatting for AF framework types.
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// graphEdgeValidation is the GraphEdgeView kind of a validation dependency.
+const graphEdgeValidation = "validation"
+
+// RenderDependencyGraph renders the dependency graph as plain text: a count
+// of nodes and edges, one line per edge, and the dependency cycles, if any.
+// Edges are sorted by source node, then dependencies before validation
+// dependencies, then target node.
+func RenderDependencyGraph(v DependencyGraphView) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Dependency graph: %d node(s), %d edge(s)\n", len(v.Nodes), len(v.Edges))
+
+	if len(v.Edges) > 0 {
+		sb.WriteString("\nEdges:\n")
+		for _, e := range sortedGraphEdges(v.Edges) {
+			fmt.Fprintf(&sb, "  %s -> %s (%s)\n", e.From, e.To, e.Kind)
+		}
+	}
+
+	if len(v.Cycles) > 0 {
+		fmt.Fprintf(&sb, "\nCycles (%d):\n", len(v.Cycles))
+		for _, c := range v.Cycles {
+			fmt.Fprintf(&sb, "  %s\n", strings.Join(c, " -> "))
+		}
+	}
+	return sb.String()
+}
+
+// sortedGraphEdges returns a copy of edges sorted by source node, then
+// dependencies before validation dependencies, then target node, so output
+// does not depend on the order edges were collected in.
+func sortedGraphEdges(edges []GraphEdgeView) []GraphEdgeView {
+	sorted := make([]GraphEdgeView, len(edges))
+	copy(sorted, edges)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.From != b.From {
+			return compareNodeIDs(a.From, b.From)
+		}
+		if av, bv := a.Kind == graphEdgeValidation, b.Kind == graphEdgeValidation; av != bv {
+			return bv
+		}
+		return compareNodeIDs(a.To, b.To)
+	})
+	return sorted
+}
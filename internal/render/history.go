@@ -133,9 +133,7 @@ func formatHistoryDetails(eventType string, data map[string]interface{}) string
 			}
 			if s, ok := node["statement"].(string); ok {
 				stmt = s
-				if len(stmt) > 60 {
-					stmt = stmt[:57] + "..."
-				}
+				stmt = truncateRunes(stmt, 60)
 			}
 			return fmt.Sprintf("Type: %s, Statement: %q", nodeType, stmt)
 		}
@@ -145,6 +143,11 @@ func formatHistoryDetails(eventType string, data map[string]interface{}) string
 			return fmt.Sprintf("Timeout: %s", timeout)
 		}
 
+	case "nodes_reassigned":
+		if from, ok := data["from_owner"].(string); ok {
+			return fmt.Sprintf("From: %s", from)
+		}
+
 	case "node_validated", "node_admitted", "node_refuted", "node_archived":
 		// These events don't need additional details beyond the event type
 		return ""
@@ -155,9 +158,7 @@ func formatHistoryDetails(eventType string, data map[string]interface{}) string
 			parts = append(parts, fmt.Sprintf("Target: %s", target))
 		}
 		if reason, ok := data["reason"].(string); ok {
-			if len(reason) > 50 {
-				reason = reason[:47] + "..."
-			}
+			reason = truncateRunes(reason, 50)
 			parts = append(parts, fmt.Sprintf("Reason: %q", reason))
 		}
 		return strings.Join(parts, ", ")
@@ -167,6 +168,11 @@ func formatHistoryDetails(eventType string, data map[string]interface{}) string
 			return fmt.Sprintf("Challenge: %s", challengeID)
 		}
 
+	case "challenge_escalated":
+		if challengeID, ok := data["challenge_id"].(string); ok {
+			return fmt.Sprintf("Challenge: %s, Severity: %v -> %v", challengeID, data["previous_severity"], data["severity"])
+		}
+
 	case "taint_recomputed":
 		if newTaint, ok := data["new_taint"].(string); ok {
 			return fmt.Sprintf("New taint: %s", newTaint)
@@ -179,6 +185,26 @@ func formatHistoryDetails(eventType string, data map[string]interface{}) string
 			}
 		}
 
+	case "node_tagged", "node_untagged":
+		if tag, ok := data["tag"].(string); ok {
+			return fmt.Sprintf("Tag: %s", tag)
+		}
+
+	case "note_added":
+		if text, ok := data["text"].(string); ok {
+			return fmt.Sprintf("Note: %s", text)
+		}
+
+	case "node_latex_set":
+		if latex, ok := data["latex"].(string); ok && latex != "" {
+			return fmt.Sprintf("LaTeX: %s", truncateRunes(latex, 50))
+		}
+
+	case "lemma_applied":
+		if lemmaID, ok := data["lemma_id"].(string); ok {
+			return fmt.Sprintf("Lemma ID: %s", lemmaID)
+		}
+
 	case "lock_reaped":
 		// Details are already in the actor field
 		return ""
@@ -8,6 +8,7 @@ import (
 
 	"github.com/tobias/vibefeld/internal/jobs"
 	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/state"
 )
 
@@ -15,6 +16,13 @@ import (
 // Shows job details including node ID, reason, and instructions.
 // Jobs are sorted by ID for consistent output.
 func RenderJobs(jobList *jobs.JobResult) string {
+	return RenderJobsWithOptions(jobList, DefaultRenderOptions())
+}
+
+// RenderJobsWithOptions is RenderJobs with explicit render options.
+// Section headers are emphasized and states of nodes that need refinement are
+// colored only when opts.Color is set.
+func RenderJobsWithOptions(jobList *jobs.JobResult, opts RenderOptions) string {
 	// Handle nil job list
 	if jobList == nil {
 		return ""
@@ -39,10 +47,10 @@ func RenderJobs(jobList *jobs.JobResult) string {
 
 	// Render prover jobs section
 	if len(proverJobs) > 0 {
-		sb.WriteString(fmt.Sprintf("=== Prover Jobs (%d available) ===\n", len(proverJobs)))
-		sb.WriteString("Nodes awaiting refinement. Claim one and refine the proof.\n\n")
+		sb.WriteString(opts.colorize(fmt.Sprintf("=== Prover Jobs (%d available) ===", len(proverJobs)), ansiBold))
+		sb.WriteString("\nNodes awaiting refinement. Claim one and refine the proof.\n\n")
 		for _, n := range proverJobs {
-			renderJobNode(&sb, n)
+			renderJobNode(&sb, n, opts)
 		}
 		sb.WriteString("\nNext: Run 'af claim <id>' to claim a prover job, then 'af refine <id>' to work on it.\n")
 	}
@@ -54,10 +62,10 @@ func RenderJobs(jobList *jobs.JobResult) string {
 
 	// Render verifier jobs section
 	if len(verifierJobs) > 0 {
-		sb.WriteString(fmt.Sprintf("=== Verifier Jobs (%d available) ===\n", len(verifierJobs)))
-		sb.WriteString("Nodes ready for review. Verify or challenge the proof.\n\n")
+		sb.WriteString(opts.colorize(fmt.Sprintf("=== Verifier Jobs (%d available) ===", len(verifierJobs)), ansiBold))
+		sb.WriteString("\nNodes ready for review. Verify or challenge the proof.\n\n")
 		for _, n := range verifierJobs {
-			renderJobNode(&sb, n)
+			renderJobNode(&sb, n, opts)
 		}
 		sb.WriteString("\nNext: Run 'af accept <id>' to validate or 'af challenge <id>' to raise objections.\n")
 	}
@@ -68,12 +76,17 @@ func RenderJobs(jobList *jobs.JobResult) string {
 // renderJobNode renders a single job node entry.
 // Note: Statements are NOT truncated because mathematical proofs require precision.
 // Agents need the full statement text to work with.
-func renderJobNode(sb *strings.Builder, n *node.Node) {
+func renderJobNode(sb *strings.Builder, n *node.Node, opts RenderOptions) {
 	// Sanitize statement (remove control chars, normalize whitespace) but do NOT truncate
 	stmt := sanitizeStatement(n.Statement)
 
-	// Render node line with ID, type, and full statement
-	sb.WriteString(fmt.Sprintf("  [%s] %s: %q\n", n.ID.String(), string(n.Type), stmt))
+	// Render node line with ID, type, and full statement; reopened nodes are tagged
+	if n.EpistemicState == schema.EpistemicNeedsRefinement {
+		sb.WriteString(fmt.Sprintf("  [%s] %s: %q (%s)\n", n.ID.String(), string(n.Type), stmt,
+			opts.ColorEpistemicState(string(n.EpistemicState))))
+	} else {
+		sb.WriteString(fmt.Sprintf("  [%s] %s: %q\n", n.ID.String(), string(n.Type), stmt))
+	}
 
 	// Show claimed-by info for verifier jobs
 	if n.ClaimedBy != "" {
@@ -478,6 +478,7 @@ func TestFormatJSON_AllErrorCodes(t *testing.T) {
 		errors.CHALLENGE_LIMIT_EXCEEDED,
 		errors.REFINEMENT_LIMIT_EXCEEDED,
 		errors.EXTRACTION_INVALID,
+		errors.DEPENDENCIES_UNVALIDATED,
 	}
 
 	for _, code := range errorCodes {
@@ -0,0 +1,64 @@
+// Package render provides the compact node list for AF framework types.
+package render
+
+import "strings"
+
+// RenderNodeList renders one line per node, in the order given:
+//
+//	1.2.1  [claimed by alice]  [pending]    "Statement"
+//
+// The ID, workflow, and epistemic columns are padded to a common width. The
+// quoted statement has its whitespace collapsed and is wrapped so each line
+// fits in opts.Width characters, continuing under the opening quote; width 0
+// disables wrapping.
+func RenderNodeList(v NodeListView, opts RenderOptions) string {
+	if len(v.Nodes) == 0 {
+		return "No nodes.\n"
+	}
+
+	ids := make([]string, len(v.Nodes))
+	workflows := make([]string, len(v.Nodes))
+	epistemics := make([]string, len(v.Nodes))
+	var idWidth, workflowWidth, epistemicWidth int
+	for i, n := range v.Nodes {
+		ids[i] = n.ID
+		workflows[i] = "[" + n.WorkflowState + "]"
+		if n.ClaimedBy != "" {
+			workflows[i] = "[claimed by " + n.ClaimedBy + "]"
+		}
+		epistemics[i] = "[" + n.EpistemicState + "]"
+
+		idWidth = max(idWidth, visibleWidth(ids[i]))
+		workflowWidth = max(workflowWidth, visibleWidth(workflows[i]))
+		epistemicWidth = max(epistemicWidth, visibleWidth(epistemics[i]))
+	}
+
+	// Columns are separated by two spaces; the statement is quoted, and the
+	// closing quote is wrapped along with it
+	prefixWidth := idWidth + workflowWidth + epistemicWidth + 3*2
+	wrapWidth := 0
+	if opts.Width > 0 {
+		wrapWidth = max(opts.Width-prefixWidth-1, minWrapWidth)
+	}
+	indent := strings.Repeat(" ", prefixWidth+1)
+
+	var sb strings.Builder
+	for i, n := range v.Nodes {
+		sb.WriteString(padRight(ids[i], idWidth))
+		sb.WriteString("  ")
+		sb.WriteString(padRight(workflows[i], workflowWidth))
+		sb.WriteString("  ")
+		sb.WriteString(padRight(epistemics[i], epistemicWidth))
+		sb.WriteString("  \"")
+		writeWrapped(&sb, n.Statement+"\"", wrapWidth, indent)
+	}
+	return sb.String()
+}
+
+// padRight pads s with spaces to width visible characters.
+func padRight(s string, width int) string {
+	if n := visibleWidth(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+)
+
+func TestStateToNodeListView(t *testing.T) {
+	s := state.NewState()
+	for _, id := range []string{"1", "1.1", "1.2", "1.10", "1.1.1"} {
+		n, err := node.NewNode(mustParseNodeID(id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.AddNode(n)
+	}
+	s.GetNode(mustParseNodeID("1.1")).WorkflowState = schema.WorkflowClaimed
+	s.GetNode(mustParseNodeID("1.1")).ClaimedBy = "alice"
+	s.GetNode(mustParseNodeID("1.2")).EpistemicState = schema.EpistemicValidated
+	s.GetNode(mustParseNodeID("1.10")).EpistemicState = schema.EpistemicRefuted
+	s.GetNode(mustParseNodeID("1.10")).TaintState = node.TaintTainted
+	s.GetNode(mustParseNodeID("1.1.1")).TaintState = node.TaintSelfAdmitted
+
+	ids := func(v NodeListView) string {
+		out := make([]string, len(v.Nodes))
+		for i, n := range v.Nodes {
+			out[i] = n.ID
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		filter, sortBy, want string
+	}{
+		{"", "id", "1,1.1,1.1.1,1.2,1.10"},
+		{"", "depth", "1,1.1,1.2,1.10,1.1.1"},
+		{"", "state", "1,1.1,1.1.1,1.2,1.10"},
+		{"pending", "id", "1,1.1,1.1.1"},
+		{"claimed", "id", "1.1"},
+		{"validated", "id", "1.2"},
+		{"refuted", "id", "1.10"},
+		{"tainted", "id", "1.1.1,1.10"},
+	}
+	for _, tt := range tests {
+		if got := ids(StateToNodeListView(s, tt.filter, tt.sortBy)); got != tt.want {
+			t.Errorf("StateToNodeListView(%q, %q) = %s, want %s", tt.filter, tt.sortBy, got, tt.want)
+		}
+	}
+
+	if v := StateToNodeListView(s, "claimed", "id"); v.Nodes[0].ClaimedBy != "alice" || v.Nodes[0].Depth != 2 {
+		t.Errorf("claimed item = %+v, want claimed by alice at depth 2", v.Nodes[0])
+	}
+	if empty := StateToNodeListView(nil, "", "id"); len(empty.Nodes) != 0 {
+		t.Errorf("nil state produced %d nodes", len(empty.Nodes))
+	}
+}
+
+func TestRenderNodeList(t *testing.T) {
+	v := NodeListView{Nodes: []NodeListItemView{
+		{ID: "1", WorkflowState: "available", EpistemicState: "validated", Statement: "Root"},
+		{ID: "1.2.1", WorkflowState: "claimed", EpistemicState: "pending", ClaimedBy: "alice",
+			Statement: "A rather long statement\nthat wraps onto a second line"},
+	}}
+
+	got := RenderNodeList(v, RenderOptions{})
+	want := "1      [available]         [validated]  \"Root\"\n" +
+		"1.2.1  [claimed by alice]  [pending]    \"A rather long statement that wraps onto a second line\"\n"
+	if got != want {
+		t.Errorf("RenderNodeList(width 0) =\n%s\nwant\n%s", got, want)
+	}
+
+	// The prefix is 40 characters, leaving 19 for the statement and its
+	// closing quote; continuation lines start under the opening quote
+	indent := strings.Repeat(" ", 41)
+	got = RenderNodeList(v, RenderOptions{Width: 60})
+	want = "1      [available]         [validated]  \"Root\"\n" +
+		"1.2.1  [claimed by alice]  [pending]    \"A rather long\n" +
+		indent + "statement that\n" +
+		indent + "wraps onto a second\n" +
+		indent + "line\"\n"
+	if got != want {
+		t.Errorf("RenderNodeList(width 60) =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := RenderNodeList(NodeListView{}, RenderOptions{Width: 80}); got != "No nodes.\n" {
+		t.Errorf("empty list = %q", got)
+	}
+}
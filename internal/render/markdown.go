@@ -0,0 +1,148 @@
+// Package render provides Markdown document formatting for AF framework types.
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// markdownTOCStatementWidth is the widest statement shown in a table of
+// contents entry before it is truncated.
+const markdownTOCStatementWidth = 60
+
+// RenderProofMarkdown renders the proof as a linked Markdown document: a
+// table of contents listing every node by ID, nested by depth, followed by
+// a section per node whose heading level follows the node's depth. Each
+// section starts with status badges and links its dependencies and
+// validation dependencies to their sections.
+//
+// Every section is preceded by an explicit HTML anchor (see
+// markdownAnchors), so links work whatever heading anchors the Markdown
+// renderer generates. Refuted and archived nodes are left out together
+// with their subtrees unless includeRefuted is true; references to nodes
+// that are left out are rendered as plain IDs marked "(omitted)".
+//
+// Nodes are emitted in sorted ID order so the output is stable across runs
+// and diff-friendly.
+func RenderProofMarkdown(v DependencyGraphView, includeRefuted bool) string {
+	nodes := make([]NodeView, len(v.Nodes))
+	copy(nodes, v.Nodes)
+	sortNodeViewsByID(nodes)
+
+	// Parents sort before their children, so one pass settles omissions
+	omitted := make(map[string]bool)
+	var shown []NodeView
+	for _, n := range nodes {
+		if !includeRefuted && (n.EpistemicState == "refuted" || n.EpistemicState == "archived" || omitted[parentNodeID(n.ID)]) {
+			omitted[n.ID] = true
+			continue
+		}
+		shown = append(shown, n)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Proof Export\n\n")
+	if len(shown) == 0 {
+		sb.WriteString("No nodes to export.\n")
+		return sb.String()
+	}
+
+	anchors := markdownAnchors(shown)
+	rootDepth := shown[0].Depth
+	for _, n := range shown {
+		rootDepth = min(rootDepth, n.Depth)
+	}
+
+	sb.WriteString("## Contents\n\n")
+	for _, n := range shown {
+		indent := strings.Repeat("  ", max(n.Depth-rootDepth, 0))
+		statement := truncateRunes(strings.Join(strings.Fields(n.Statement), " "), markdownTOCStatementWidth)
+		fmt.Fprintf(&sb, "%s- [%s](#%s) %s %s\n", indent, n.ID, anchors[n.ID], markdownBadges(n), statement)
+	}
+
+	for _, n := range shown {
+		// "#" is the document title and "##" the contents, so the root
+		// node's section is "##" as well and each level nests one deeper
+		level := min(n.Depth+1, 6)
+		fmt.Fprintf(&sb, "\n<a id=\"%s\"></a>\n\n", anchors[n.ID])
+		fmt.Fprintf(&sb, "%s Node %s\n\n", strings.Repeat("#", level), n.ID)
+		fmt.Fprintf(&sb, "%s\n\n", markdownBadges(n))
+		fmt.Fprintf(&sb, "**Statement:** %s\n\n", n.Statement)
+		fmt.Fprintf(&sb, "**Type:** %s\n\n", n.Type)
+		fmt.Fprintf(&sb, "**Inference:** %s\n\n", n.Inference)
+		fmt.Fprintf(&sb, "**Status:** %s\n\n", n.EpistemicState)
+		if n.TaintState != "" {
+			fmt.Fprintf(&sb, "**Taint:** %s\n\n", n.TaintState)
+		}
+		if len(n.Dependencies) > 0 {
+			fmt.Fprintf(&sb, "**Depends on:** %s\n\n", markdownNodeLinks(n.Dependencies, anchors, omitted))
+		}
+		if len(n.ValidationDeps) > 0 {
+			fmt.Fprintf(&sb, "**Requires validated:** %s\n\n", markdownNodeLinks(n.ValidationDeps, anchors, omitted))
+		}
+	}
+
+	return sb.String()
+}
+
+// markdownBadges returns inline-code badges for a node's epistemic state,
+// its taint unless clean, and its workflow state unless available.
+func markdownBadges(n NodeView) string {
+	badges := []string{"`" + n.EpistemicState + "`"}
+	if n.TaintState != "" && n.TaintState != "clean" {
+		badges = append(badges, "`"+n.TaintState+"`")
+	}
+	if n.WorkflowState != "" && n.WorkflowState != "available" {
+		badges = append(badges, "`"+n.WorkflowState+"`")
+	}
+	return strings.Join(badges, " ")
+}
+
+// markdownNodeLinks renders ids, sorted, as links to their sections,
+// separated by commas. IDs without a section are rendered as plain code,
+// marked "(omitted)" if they were filtered out.
+func markdownNodeLinks(ids []string, anchors map[string]string, omitted map[string]bool) string {
+	links := make([]string, 0, len(ids))
+	for _, id := range sortedCopy(ids) {
+		switch {
+		case anchors[id] != "":
+			links = append(links, fmt.Sprintf("[%s](#%s)", id, anchors[id]))
+		case omitted[id]:
+			links = append(links, fmt.Sprintf("`%s` (omitted)", id))
+		default:
+			links = append(links, fmt.Sprintf("`%s`", id))
+		}
+	}
+	return strings.Join(links, ", ")
+}
+
+// markdownAnchors returns the anchor of each node's section, keyed by node
+// ID. Anchors are "node-" followed by the ID with every character other
+// than a lowercase letter or digit replaced by "-", so "1.2.3" becomes
+// "node-1-2-3". Should two IDs map to the same anchor, later ones get a
+// numeric suffix, keeping every anchor unique.
+func markdownAnchors(nodes []NodeView) map[string]string {
+	anchors := make(map[string]string, len(nodes))
+	used := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		base := "node-" + markdownSlug(n.ID)
+		anchor := base
+		for i := 2; used[anchor]; i++ {
+			anchor = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[anchor] = true
+		anchors[n.ID] = anchor
+	}
+	return anchors
+}
+
+// markdownSlug lowercases s and replaces every character other than a
+// letter or digit with "-".
+func markdownSlug(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(s))
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderProofMarkdown(t *testing.T) {
+	v := DependencyGraphView{
+		Nodes: []NodeView{
+			{ID: "1.2", Depth: 2, Type: "claim", Inference: "modus_ponens", Statement: "Second",
+				EpistemicState: "pending", TaintState: "unresolved", WorkflowState: "claimed",
+				Dependencies: []string{"1.3", "1.1"}, ValidationDeps: []string{"1.4"}},
+			{ID: "1", Depth: 1, Type: "claim", Inference: "assumption", Statement: "Root",
+				EpistemicState: "pending", TaintState: "clean", WorkflowState: "available"},
+			{ID: "1.1", Depth: 2, Type: "claim", Inference: "assumption", Statement: "First\nstep",
+				EpistemicState: "validated", TaintState: "clean", WorkflowState: "available"},
+			{ID: "1.3", Depth: 2, Type: "claim", Inference: "assumption", Statement: "Wrong",
+				EpistemicState: "refuted", TaintState: "clean", WorkflowState: "available"},
+			{ID: "1.3.1", Depth: 3, Type: "claim", Inference: "assumption", Statement: "Under wrong",
+				EpistemicState: "pending", TaintState: "clean", WorkflowState: "available"},
+		},
+	}
+
+	got := RenderProofMarkdown(v, false)
+
+	want := "# Proof Export\n\n" +
+		"## Contents\n\n" +
+		"- [1](#node-1) `pending` Root\n" +
+		"  - [1.1](#node-1-1) `validated` First step\n" +
+		"  - [1.2](#node-1-2) `pending` `unresolved` `claimed` Second\n" +
+		"\n<a id=\"node-1-2\"></a>\n\n" +
+		"### Node 1.2\n\n" +
+		"`pending` `unresolved` `claimed`\n\n" +
+		"**Statement:** Second\n\n" +
+		"**Type:** claim\n\n" +
+		"**Inference:** modus_ponens\n\n" +
+		"**Status:** pending\n\n" +
+		"**Taint:** unresolved\n\n" +
+		"**Depends on:** [1.1](#node-1-1), `1.3` (omitted)\n\n" +
+		"**Requires validated:** `1.4`\n\n"
+	if !strings.HasPrefix(got, want[:strings.Index(want, "\n<a")]) {
+		t.Errorf("contents mismatch:\n%s", got)
+	}
+	if !strings.HasSuffix(got, want[strings.Index(want, "\n<a"):]) {
+		t.Errorf("section for 1.2 mismatch:\n%s", got)
+	}
+	for _, section := range []string{"\n<a id=\"node-1\"></a>\n\n## Node 1\n", "\n<a id=\"node-1-1\"></a>\n\n### Node 1.1\n"} {
+		if !strings.Contains(got, section) {
+			t.Errorf("output missing %q:\n%s", section, got)
+		}
+	}
+	if strings.Contains(got, "Under wrong") || strings.Contains(got, "Node 1.3") {
+		t.Errorf("refuted subtree should be omitted:\n%s", got)
+	}
+
+	got = RenderProofMarkdown(v, true)
+	for _, want := range []string{"    - [1.3.1](#node-1-3-1)", "#### Node 1.3.1", "**Depends on:** [1.1](#node-1-1), [1.3](#node-1-3)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("with includeRefuted, output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderProofMarkdown_Empty(t *testing.T) {
+	got := RenderProofMarkdown(DependencyGraphView{}, false)
+	if got != "# Proof Export\n\nNo nodes to export.\n" {
+		t.Errorf("RenderProofMarkdown() = %q", got)
+	}
+}
+
+func TestMarkdownAnchors_Unique(t *testing.T) {
+	anchors := markdownAnchors([]NodeView{{ID: "1.2"}, {ID: "1-2"}, {ID: "1_2"}})
+	want := map[string]string{"1.2": "node-1-2", "1-2": "node-1-2-2", "1_2": "node-1-2-3"}
+	for id, anchor := range want {
+		if anchors[id] != anchor {
+			t.Errorf("anchor of %q = %q, want %q", id, anchors[id], anchor)
+		}
+	}
+}
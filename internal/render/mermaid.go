@@ -0,0 +1,85 @@
+// Package render provides Mermaid flowchart formatting for AF framework types.
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderDependencyGraphMermaid renders the proof as a Mermaid top-down
+// flowchart. Each node is declared as id["statement"]. Tree edges run from
+// a parent to each of its children, as derived from the node ID hierarchy.
+// Reference dependencies are drawn as dotted edges and validation
+// dependencies as dotted edges labeled "validation", both pointing from the
+// dependent node to the node it depends on. Dependency edges are taken from
+// v.Edges.
+//
+// Nodes and edges are emitted in sorted ID order so the output is stable
+// across runs and diff-friendly.
+func RenderDependencyGraphMermaid(v DependencyGraphView) string {
+	nodes := make([]NodeView, len(v.Nodes))
+	copy(nodes, v.Nodes)
+	sortNodeViewsByID(nodes)
+
+	present := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		present[n.ID] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	for _, n := range nodes {
+		fmt.Fprintf(&sb, "    %s[\"%s\"]\n", n.ID, mermaidEscape(n.Statement))
+	}
+
+	var tree strings.Builder
+	for _, n := range nodes {
+		if parent := parentNodeID(n.ID); parent != "" && present[parent] {
+			fmt.Fprintf(&tree, "    %s --> %s\n", parent, n.ID)
+		}
+	}
+	if tree.Len() > 0 {
+		sb.WriteString(tree.String())
+	}
+
+	var deps strings.Builder
+	for _, e := range sortedGraphEdges(v.Edges) {
+		if e.Kind == graphEdgeValidation {
+			fmt.Fprintf(&deps, "    %s -. validation .-> %s\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&deps, "    %s -.-> %s\n", e.From, e.To)
+		}
+	}
+	if deps.Len() > 0 {
+		sb.WriteString(deps.String())
+	}
+
+	return sb.String()
+}
+
+// parentNodeID returns the ID with its last segment removed, or "" for a
+// root ID that has no parent.
+func parentNodeID(id string) string {
+	i := strings.LastIndex(id, ".")
+	if i < 0 {
+		return ""
+	}
+	return id[:i]
+}
+
+// mermaidEscape makes s safe inside a quoted Mermaid label. Characters the
+// Mermaid parser treats specially are replaced with entity codes and line
+// breaks are collapsed to spaces.
+func mermaidEscape(s string) string {
+	r := strings.NewReplacer(
+		"#", "#35;",
+		`"`, "#quot;",
+		"<", "#lt;",
+		">", "#gt;",
+		"\r\n", " ",
+		"\r", " ",
+		"\n", " ",
+	)
+	return r.Replace(s)
+}
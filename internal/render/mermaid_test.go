@@ -0,0 +1,66 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestRenderDependencyGraphMermaid(t *testing.T) {
+	v := DependencyGraphView{
+		Nodes: []NodeView{
+			{ID: "1.10", Statement: "Tenth step"},
+			{ID: "1.2", Statement: `Uses "quoted" <text> #1`,
+				Dependencies: []string{"1.10", "1.1"}, ValidationDeps: []string{"1"}},
+			{ID: "1", Statement: "Root claim"},
+			{ID: "1.1", Statement: "Line one\nline two"},
+		},
+		Edges: []GraphEdgeView{
+			{From: "1.2", To: "1", Kind: "validation"},
+			{From: "1.2", To: "1.10", Kind: "dependency"},
+			{From: "1.2", To: "1.1", Kind: "dependency"},
+		},
+	}
+
+	got := RenderDependencyGraphMermaid(v)
+
+	want := `flowchart TD
+    1["Root claim"]
+    1.1["Line one line two"]
+    1.2["Uses #quot;quoted#quot; #lt;text#gt; #35;1"]
+    1.10["Tenth step"]
+    1 --> 1.1
+    1 --> 1.2
+    1 --> 1.10
+    1.2 -.-> 1.1
+    1.2 -.-> 1.10
+    1.2 -. validation .-> 1
+`
+	if got != want {
+		t.Errorf("RenderDependencyGraphMermaid() =\n%s\nwant:\n%s", got, want)
+	}
+
+	// Input order must not affect output
+	reversed := DependencyGraphView{Nodes: make([]NodeView, len(v.Nodes)), Edges: make([]GraphEdgeView, len(v.Edges))}
+	for i, n := range v.Nodes {
+		reversed.Nodes[len(v.Nodes)-1-i] = n
+	}
+	for i, e := range v.Edges {
+		reversed.Edges[len(v.Edges)-1-i] = e
+	}
+	if RenderDependencyGraphMermaid(reversed) != got {
+		t.Error("RenderDependencyGraphMermaid() output depends on input order")
+	}
+}
+
+func TestRenderDependencyGraphMermaid_Empty(t *testing.T) {
+	if got := RenderDependencyGraphMermaid(DependencyGraphView{}); got != "flowchart TD\n" {
+		t.Errorf("RenderDependencyGraphMermaid() = %q, want empty flowchart", got)
+	}
+}
+
+func TestRenderDependencyGraphMermaid_MissingParent(t *testing.T) {
+	v := DependencyGraphView{Nodes: []NodeView{{ID: "1.1.1", Statement: "Orphan"}}}
+	want := "flowchart TD\n    1.1.1[\"Orphan\"]\n"
+	if got := RenderDependencyGraphMermaid(v); got != want {
+		t.Errorf("RenderDependencyGraphMermaid() = %q, want %q", got, want)
+	}
+}
@@ -74,10 +74,16 @@ func RenderNodeVerbose(n *node.Node) string {
 		sb.WriteString(fmt.Sprintf("Requires validated: %s\n", strings.Join(types.ToStringSlice(n.ValidationDeps), ", ")))
 	}
 
+	writeLemmaJustifications(&sb, n.Lemmas)
+
 	if len(n.Scope) > 0 {
 		sb.WriteString(fmt.Sprintf("Scope:      %s\n", strings.Join(n.Scope, ", ")))
 	}
 
+	if len(n.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags:       %s\n", strings.Join(n.Tags, ", ")))
+	}
+
 	if n.ClaimedBy != "" {
 		sb.WriteString(fmt.Sprintf("Claimed by: %s\n", n.ClaimedBy))
 	}
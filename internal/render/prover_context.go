@@ -166,9 +166,7 @@ func renderSiblings(sb *strings.Builder, s *state.State, nodeID types.NodeID) {
 		sb.WriteString(": ")
 		// Truncate statement for readability
 		stmt := sanitizeStatement(sib.Statement)
-		if len(stmt) > maxStatementDisplay {
-			stmt = stmt[:maxStatementDisplay-3] + "..."
-		}
+		stmt = truncateRunes(stmt, maxStatementDisplay)
 		sb.WriteString(stmt)
 		sb.WriteString("\n")
 	}
@@ -201,9 +199,7 @@ func renderDependencies(sb *strings.Builder, s *state.State, n *node.Node) {
 		if depNode != nil {
 			sb.WriteString(": ")
 			stmt := sanitizeStatement(depNode.Statement)
-			if len(stmt) > maxStatementDisplay {
-				stmt = stmt[:maxStatementDisplay-3] + "..."
-			}
+			stmt = truncateRunes(stmt, maxStatementDisplay)
 			sb.WriteString(stmt)
 		}
 		sb.WriteString("\n")
@@ -275,9 +271,7 @@ func renderDefinitions(sb *strings.Builder, s *state.State, n *node.Node) {
 			sb.WriteString(def.Name)
 			sb.WriteString(": ")
 			content := def.Content
-			if len(content) > maxContentDisplay {
-				content = content[:maxContentDisplay-3] + "..."
-			}
+			content = truncateRunes(content, maxContentDisplay)
 			sb.WriteString(content)
 			sb.WriteString("\n")
 		} else {
@@ -358,9 +352,7 @@ func renderAssumptions(sb *strings.Builder, s *state.State, n *node.Node) {
 		if assume != nil {
 			sb.WriteString("  - ")
 			stmt := assume.Statement
-			if len(stmt) > maxContentDisplay {
-				stmt = stmt[:maxContentDisplay-3] + "..."
-			}
+			stmt = truncateRunes(stmt, maxContentDisplay)
 			sb.WriteString(stmt)
 			if assume.Justification != "" {
 				sb.WriteString(" (")
@@ -0,0 +1,56 @@
+// Package render provides relative timestamp formatting for AF framework types.
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// relativeTimeLimit is the age from which FormatRelativeTime falls back to an
+// absolute timestamp.
+const relativeTimeLimit = 7 * 24 * time.Hour
+
+// timestampWidth is the width of an absolute "2006-01-02 15:04:05" timestamp;
+// relative timestamps are padded to it so columns stay aligned.
+const timestampWidth = 19
+
+// FormatRelativeTime formats t relative to now: "just now" under a minute,
+// "3m ago" under an hour, "2h ago" under a day, "yesterday" under two days,
+// and "4d ago" under a week. Older times are formatted absolutely as
+// "2006-01-02 15:04:05". Times after now, as from clock skew between agents,
+// are "just now".
+func FormatRelativeTime(t, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	case age < 48*time.Hour:
+		return "yesterday"
+	case age < relativeTimeLimit:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// timestamp formats an RFC3339 timestamp for event log and history columns:
+// relative to o.Now if it is set, as "2006-01-02 15:04:05" otherwise. A
+// missing timestamp is rendered as blank padding so columns stay aligned.
+func (o RenderOptions) timestamp(ts string) string {
+	if ts == "" {
+		return strings.Repeat(" ", timestampWidth)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ts[:min(timestampWidth, len(ts))]
+	}
+	if o.Now.IsZero() {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprintf("%-*s", timestampWidth, FormatRelativeTime(t, o.Now))
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2025, 1, 11, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		age  time.Duration
+		want string
+	}{
+		{"same instant", 0, "just now"},
+		{"under a minute", 59 * time.Second, "just now"},
+		{"one minute", time.Minute, "1m ago"},
+		{"minutes round down", 3*time.Minute + 59*time.Second, "3m ago"},
+		{"under an hour", 59 * time.Minute, "59m ago"},
+		{"one hour", time.Hour, "1h ago"},
+		{"under a day", 23*time.Hour + 59*time.Minute, "23h ago"},
+		{"one day", 24 * time.Hour, "yesterday"},
+		{"under two days", 47 * time.Hour, "yesterday"},
+		{"two days", 48 * time.Hour, "2d ago"},
+		{"under a week", 7*24*time.Hour - time.Second, "6d ago"},
+		{"one week", 7 * 24 * time.Hour, "2025-01-04 12:00:00"},
+		{"future", -time.Second, "just now"},
+		{"far future", -48 * time.Hour, "just now"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatRelativeTime(now.Add(-tt.age), now); got != tt.want {
+				t.Errorf("FormatRelativeTime(now - %v) = %q, want %q", tt.age, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEventLogWithOptions_Relative(t *testing.T) {
+	v := EventLogView{Entries: []EventLogEntryView{
+		{Seq: 1, Type: "proof_initialized", Timestamp: "2025-01-02T03:04:05Z", Summary: "old"},
+		{Seq: 2, Type: "nodes_claimed", Timestamp: "2025-01-11T11:57:00Z", Summary: "recent"},
+		{Seq: 3, Type: "", Summary: "?"},
+	}}
+	opts := RenderOptions{Now: time.Date(2025, 1, 11, 12, 0, 0, 0, time.UTC)}
+
+	want := "#1    ProofInitialized      2025-01-02 03:04:05  old\n" +
+		"#2    NodesClaimed          3m ago               recent\n" +
+		"#3    Unknown                                    ?\n"
+	if got := RenderEventLogWithOptions(v, opts); got != want {
+		t.Errorf("RenderEventLogWithOptions mismatch:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestRenderNodeDetailWithOptions_RelativeHistory(t *testing.T) {
+	v := NodeDetailView{
+		Node: NodeView{ID: "1", Type: "claim", Statement: "Root"},
+		History: []NodeHistoryEntryView{
+			{Seq: 2, Timestamp: "2025-01-10T10:00:00Z", Type: "node_created", Summary: "created as claim: Root"},
+			{Seq: 4, Timestamp: "2025-01-11T10:00:00Z", Type: "nodes_claimed", Summary: "claimed by alice"},
+		},
+	}
+	opts := RenderOptions{Now: time.Date(2025, 1, 11, 12, 0, 0, 0, time.UTC)}
+
+	got := RenderNodeDetailWithOptions(v, opts)
+	want := "\nHistory (2 events):\n" +
+		"  #2     yesterday            node_created          created as claim: Root\n" +
+		"  #4     2h ago               nodes_claimed         claimed by alice\n"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("RenderNodeDetailWithOptions should end with\n%s\ngot:\n%s", want, got)
+	}
+}
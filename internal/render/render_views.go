@@ -55,9 +55,13 @@ func RenderNodeViewVerbose(v NodeView) string {
 	if len(v.ValidationDeps) > 0 {
 		sb.WriteString(fmt.Sprintf("Requires validated: %s\n", strings.Join(v.ValidationDeps, ", ")))
 	}
+	writeLemmaJustifications(&sb, v.Lemmas)
 	if len(v.Scope) > 0 {
 		sb.WriteString(fmt.Sprintf("Scope:      %s\n", strings.Join(v.Scope, ", ")))
 	}
+	if len(v.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags:       %s\n", strings.Join(v.Tags, ", ")))
+	}
 	if v.ClaimedBy != "" {
 		sb.WriteString(fmt.Sprintf("Claimed by: %s\n", v.ClaimedBy))
 	}
@@ -65,10 +69,373 @@ func RenderNodeViewVerbose(v NodeView) string {
 	return sb.String()
 }
 
+// RenderNodeDetail renders the complete record of a single node, including
+// dependency status, open challenges grouped by severity, and the node's
+// event timeline if the view has one.
+func RenderNodeDetail(v NodeDetailView) string {
+	return RenderNodeDetailWithOptions(v, DefaultRenderOptions())
+}
+
+// RenderNodeDetailWithOptions is RenderNodeDetail with explicit render
+// options. If opts.Width is set the statement is wrapped to fit, continuing
+// under the first line.
+func RenderNodeDetailWithOptions(v NodeDetailView, opts RenderOptions) string {
+	n := v.Node
+	if n.ID == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Node %s [%s]\n", n.ID, n.Type))
+	sb.WriteString("Statement:  ")
+	if opts.Width > 0 {
+		writeWrapped(&sb, n.Statement, max(opts.Width-len(detailIndent), minWrapWidth), detailIndent)
+	} else {
+		sb.WriteString(n.Statement + "\n")
+	}
+	if n.Latex != "" {
+		sb.WriteString(fmt.Sprintf("LaTeX:      %s\n", n.Latex))
+	}
+	sb.WriteString(fmt.Sprintf("Inference:  %s\n", n.Inference))
+	sb.WriteString(fmt.Sprintf("Workflow:   %s\n", n.WorkflowState))
+	if n.ClaimedBy != "" {
+		sb.WriteString(fmt.Sprintf("Claimed by: %s\n", n.ClaimedBy))
+	}
+	sb.WriteString(fmt.Sprintf("Epistemic:  %s\n", opts.ColorEpistemicState(n.EpistemicState)))
+	sb.WriteString(fmt.Sprintf("Taint:      %s\n", opts.ColorTaintState(n.TaintState)))
+	sb.WriteString(fmt.Sprintf("Created:    %s\n", n.Created))
+	sb.WriteString(fmt.Sprintf("Hash:       %s\n", n.ContentHash))
+	if len(n.Context) > 0 {
+		sb.WriteString(fmt.Sprintf("Context:    %s\n", strings.Join(n.Context, ", ")))
+	}
+	if len(n.Scope) > 0 {
+		sb.WriteString(fmt.Sprintf("Scope:      %s\n", strings.Join(n.Scope, ", ")))
+	}
+	if len(n.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags:       %s\n", strings.Join(n.Tags, ", ")))
+	}
+	writeLemmaJustifications(&sb, n.Lemmas)
+
+	renderDependencyStatusView(&sb, "Dependencies", v.Dependencies)
+	renderDependencyStatusView(&sb, "Validation dependencies", v.ValidationDeps)
+	if len(v.TransitiveDeps) > 0 {
+		renderDependencyStatusView(&sb, fmt.Sprintf("Rests on (%d nodes, transitively)", len(v.TransitiveDeps)), v.TransitiveDeps)
+	}
+	if v.DependencyCycle {
+		sb.WriteString(fmt.Sprintf("  %s: the dependencies form a cycle\n", Red("warning")))
+	}
+
+	openCount := 0
+	for _, g := range v.OpenChallenges {
+		openCount += len(g.Challenges)
+	}
+	if openCount == 0 {
+		sb.WriteString(fmt.Sprintf("\nOpen challenges: (none, %d total)\n", v.TotalChallenges))
+	} else {
+		sb.WriteString(fmt.Sprintf("\nOpen challenges (%d of %d total):\n", openCount, v.TotalChallenges))
+		for _, g := range v.OpenChallenges {
+			sb.WriteString(fmt.Sprintf("  %s (%d)", g.Severity, len(g.Challenges)))
+			if g.Blocking {
+				sb.WriteString(" - blocking")
+			}
+			sb.WriteString(":\n")
+			for _, c := range g.Challenges {
+				sb.WriteString(fmt.Sprintf("    %s [%s] %s\n", c.ID, c.Target, sanitizeStatement(c.Reason)))
+			}
+		}
+	}
+
+	if len(v.Notes) > 0 {
+		sb.WriteString(fmt.Sprintf("\nNotes (%d):\n", len(v.Notes)))
+		for _, note := range v.Notes {
+			sb.WriteString(fmt.Sprintf("  %s  %s  %s: %s\n",
+				note.ID, opts.timestamp(note.Created), note.Author, sanitizeStatement(note.Text)))
+		}
+	}
+
+	if len(v.History) > 0 {
+		sb.WriteString(fmt.Sprintf("\nHistory (%d events):\n", len(v.History)))
+		for _, e := range v.History {
+			sb.WriteString(fmt.Sprintf("  #%-4d  %s  %-20s  %s\n",
+				e.Seq, opts.timestamp(e.Timestamp), e.Type, sanitizeStatement(e.Summary)))
+		}
+	}
+
+	return sb.String()
+}
+
+// detailIndent is the width of the field labels in RenderNodeDetail, such as
+// "Statement:  ", under which wrapped values continue.
+var detailIndent = strings.Repeat(" ", 12)
+
+// writeLemmaJustifications writes one "Justified by lemma <id>" line per
+// lemma cited by a node.
+func writeLemmaJustifications(sb *strings.Builder, lemmas []string) {
+	for _, id := range lemmas {
+		sb.WriteString(fmt.Sprintf("Justified by lemma %s\n", id))
+	}
+}
+
+// RenderStateDiff renders the node changes between two ledger sequence numbers.
+func RenderStateDiff(v StateDiffView) string {
+	if v.IsEmpty() {
+		return fmt.Sprintf("No node changes between seq %d and seq %d.\n", v.FromSeq, v.ToSeq)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Changes from seq %d to seq %d:\n", v.FromSeq, v.ToSeq))
+
+	if len(v.Added) > 0 {
+		sb.WriteString(fmt.Sprintf("\nAdded (%d):\n", len(v.Added)))
+		for _, n := range v.Added {
+			sb.WriteString(fmt.Sprintf("  + %s [%s] %s\n", n.ID, colorEpistemicStateString(n.EpistemicState), sanitizeStatement(n.Statement)))
+		}
+	}
+
+	if len(v.Removed) > 0 {
+		sb.WriteString(fmt.Sprintf("\nArchived (%d):\n", len(v.Removed)))
+		for _, n := range v.Removed {
+			sb.WriteString(fmt.Sprintf("  - %s %s\n", n.ID, sanitizeStatement(n.Statement)))
+		}
+	}
+
+	if len(v.Changed) > 0 {
+		sb.WriteString(fmt.Sprintf("\nChanged (%d):\n", len(v.Changed)))
+		for _, c := range v.Changed {
+			sb.WriteString(fmt.Sprintf("  ~ %s %s: %s -> %s\n", c.NodeID, c.Field, c.From, c.To))
+		}
+	}
+
+	return sb.String()
+}
+
+// RenderStatusDelta renders the nodes created, nodes validated, and
+// challenges raised after a ledger sequence number.
+func RenderStatusDelta(v StatusDeltaView, opts RenderOptions) string {
+	if v.IsEmpty() {
+		return fmt.Sprintf("No changes since seq %d.\n", v.SinceSeq)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Changes since seq %d (now at seq %d):\n", v.SinceSeq, v.LatestSeq))
+
+	if len(v.Created) > 0 {
+		sb.WriteString(fmt.Sprintf("\nCreated (%d):\n", len(v.Created)))
+		for _, n := range v.Created {
+			sb.WriteString(fmt.Sprintf("  + %s [%s] %s\n", n.ID, opts.ColorEpistemicState(n.EpistemicState), sanitizeStatement(n.Statement)))
+		}
+	}
+
+	if len(v.Validated) > 0 {
+		sb.WriteString(fmt.Sprintf("\nValidated (%d):\n", len(v.Validated)))
+		for _, n := range v.Validated {
+			sb.WriteString(fmt.Sprintf("  %s %s\n", n.ID, sanitizeStatement(n.Statement)))
+		}
+	}
+
+	if len(v.ChallengesRaised) > 0 {
+		sb.WriteString(fmt.Sprintf("\nChallenges raised (%d):\n", len(v.ChallengesRaised)))
+		for _, c := range v.ChallengesRaised {
+			sb.WriteString(fmt.Sprintf("  %s on %s [%s, %s] %s\n", c.ID, c.TargetID, c.Target, c.Severity, sanitizeStatement(c.Reason)))
+		}
+	}
+
+	return sb.String()
+}
+
+// RenderStatusGroups renders the nodes of a proof in one section per group,
+// each headed by the group's name and count and listing its node IDs.
+func RenderStatusGroups(v StatusGroupsView) string {
+	var sb strings.Builder
+	sb.WriteString("Nodes by state:\n")
+	for _, g := range v.Groups {
+		sb.WriteString(fmt.Sprintf("\n%s (%d):\n", g.Name, g.Count))
+		if len(g.NodeIDs) == 0 {
+			sb.WriteString("  (none)\n")
+			continue
+		}
+		for _, id := range g.NodeIDs {
+			sb.WriteString(fmt.Sprintf("  %s\n", id))
+		}
+	}
+	return sb.String()
+}
+
+// RenderActivity renders a table of per-agent action counts with the time
+// of each agent's first and last action, in the order given, followed by a
+// table of claim times per node if the view has any.
+func RenderActivity(v ActivityView) string {
+	if len(v.Agents) == 0 {
+		return "No agent activity recorded.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Agent activity (%d agents):\n", len(v.Agents)))
+	sb.WriteString(fmt.Sprintf("  %-20s %6s %7s %10s %7s %6s  %-19s  %s\n",
+		"AGENT", "CLAIMS", "REFINED", "CHALLENGES", "ACCEPTS", "TOTAL", "FIRST ACTION", "LAST ACTION"))
+	for _, a := range v.Agents {
+		sb.WriteString(fmt.Sprintf("  %-20s %6d %7d %10d %7d %6d  %s  %s\n",
+			sanitizeStatement(a.Agent), a.Claims, a.Refinements, a.ChallengesRaised, a.Acceptances, a.Total,
+			eventLogTimestamp(a.FirstAction), eventLogTimestamp(a.LastAction)))
+	}
+
+	if len(v.Nodes) > 0 {
+		sb.WriteString(fmt.Sprintf("\nClaim time by node (%d nodes):\n", len(v.Nodes)))
+		sb.WriteString(fmt.Sprintf("  %-12s %6s  %-12s  %s\n", "NODE", "CLAIMS", "TOTAL HELD", "LONGEST"))
+		for _, n := range v.Nodes {
+			sb.WriteString(fmt.Sprintf("  %-12s %6d  %-12s  %s\n", n.NodeID, n.Claims, n.TotalHeld, n.LongestHeld))
+		}
+	}
+	return sb.String()
+}
+
+// RenderValidationReport renders the result of an invariant check: a summary
+// line followed by one line per violation, critical violations first.
+func RenderValidationReport(v ValidationReportView) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Checked %d events and %d nodes.\n", v.EventsChecked, v.NodesChecked))
+
+	if len(v.Violations) == 0 {
+		sb.WriteString("All invariants hold.\n")
+		return sb.String()
+	}
+
+	critical, warnings := 0, 0
+	for _, violation := range v.Violations {
+		if violation.Severity == "critical" {
+			critical++
+		} else {
+			warnings++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Found %d critical violation(s) and %d warning(s):\n", critical, warnings))
+	for _, severity := range []string{"critical", "warning"} {
+		for _, violation := range v.Violations {
+			if violation.Severity != severity {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  [%s] %s: %s\n", strings.ToUpper(severity), violation.Kind, sanitizeStatement(violation.Message)))
+		}
+	}
+
+	if v.Valid {
+		sb.WriteString("No critical invariants are violated.\n")
+	} else {
+		sb.WriteString("Proof is INVALID.\n")
+	}
+	return sb.String()
+}
+
+// RenderLedgerVerification renders the result of verifying a ledger: a
+// one-line "ledger OK" summary on success, otherwise every issue in sequence
+// order.
+func RenderLedgerVerification(v LedgerVerificationView) string {
+	var sb strings.Builder
+	if len(v.Issues) == 0 {
+		sb.WriteString(fmt.Sprintf("ledger OK, %d events verified\n", v.EventsChecked))
+		if !v.Chained {
+			sb.WriteString("Note: ledger has no hash chain; event links were not checked.\n")
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("ledger FAILED, %d events checked, %d issue(s):\n", v.EventsChecked, len(v.Issues)))
+	for _, issue := range v.Issues {
+		sb.WriteString(fmt.Sprintf("  [seq %d] %s: %s\n", issue.Seq, issue.Kind, sanitizeStatement(issue.Message)))
+	}
+	return sb.String()
+}
+
+// RenderEventLog renders ledger events one per line:
+// sequence number, event type, timestamp, and summary.
+func RenderEventLog(v EventLogView) string {
+	return RenderEventLogWithOptions(v, RenderOptions{})
+}
+
+// RenderEventLogWithOptions is RenderEventLog with timestamps rendered
+// relative to opts.Now when it is set.
+func RenderEventLogWithOptions(v EventLogView, opts RenderOptions) string {
+	var sb strings.Builder
+	w := NewTextEventLogWriter(&sb, opts)
+	for _, e := range v.Entries {
+		w.WriteEvent(e, nil)
+	}
+	w.Close()
+	return sb.String()
+}
+
+// RenderEventLogEntry renders a single event log line without a trailing newline.
+func RenderEventLogEntry(e EventLogEntryView) string {
+	return renderEventLogEntry(e, RenderOptions{})
+}
+
+// renderEventLogEntry renders a single event log line with timestamps
+// formatted according to opts.
+func renderEventLogEntry(e EventLogEntryView, opts RenderOptions) string {
+	return fmt.Sprintf("#%-3d  %-20s  %s  %s", e.Seq, eventTypeDisplayName(e.Type), opts.timestamp(e.Timestamp), e.Summary)
+}
+
+// eventTypeDisplayName converts a snake_case event type to PascalCase for display.
+func eventTypeDisplayName(eventType string) string {
+	if eventType == "" {
+		return "Unknown"
+	}
+
+	parts := strings.Split(eventType, "_")
+	for i, part := range parts {
+		if len(part) > 0 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// eventLogTimestamp formats an RFC3339 timestamp as "2006-01-02 15:04:05".
+// A missing timestamp is rendered as blank padding so columns stay aligned.
+func eventLogTimestamp(ts string) string {
+	return RenderOptions{}.timestamp(ts)
+}
+
+// renderDependencyStatusView writes a dependency section with the current
+// epistemic state of each referenced node.
+func renderDependencyStatusView(sb *strings.Builder, title string, deps []DependencyStatusView) {
+	if len(deps) == 0 {
+		sb.WriteString(fmt.Sprintf("\n%s: (none)\n", title))
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%s:\n", title))
+	for _, d := range deps {
+		if !d.Exists {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", d.ID, Red("missing")))
+			continue
+		}
+		marker := " "
+		if !d.Satisfied() {
+			marker = "!"
+		}
+		stmt := sanitizeStatement(d.Statement)
+		stmt = truncateRunes(stmt, 50)
+		sb.WriteString(fmt.Sprintf(" %s%s [%s] %s\n", marker, d.ID, colorEpistemicStateString(d.EpistemicState), stmt))
+	}
+}
+
 // RenderJobListView renders the list of available jobs from a view model.
 func RenderJobListView(jl JobListView) string {
+	return RenderJobListViewWithOptions(jl, DefaultRenderOptions())
+}
+
+// RenderJobListViewWithOptions is RenderJobListView with explicit render options.
+// Section headers are emphasized and states of nodes that need refinement are
+// colored only when opts.Color is set.
+func RenderJobListViewWithOptions(jl JobListView, opts RenderOptions) string {
 	if jl.IsEmpty() {
-		return "No jobs available.\n\nProver jobs: 0 nodes awaiting refinement\nVerifier jobs: 0 nodes ready for review"
+		empty := "No jobs available.\n\nProver jobs: 0 nodes awaiting refinement\nVerifier jobs: 0 nodes ready for review"
+		if len(jl.Reclaimable) > 0 {
+			empty += "\n\n" + RenderReclaimableViews(jl.Reclaimable, opts)
+		}
+		return empty
 	}
 
 	var sb strings.Builder
@@ -85,10 +452,10 @@ func RenderJobListView(jl JobListView) string {
 
 	// Render prover jobs section
 	if len(proverJobs) > 0 {
-		sb.WriteString(fmt.Sprintf("=== Prover Jobs (%d available) ===\n", len(proverJobs)))
-		sb.WriteString("Nodes awaiting refinement. Claim one and refine the proof.\n\n")
+		sb.WriteString(opts.colorize(fmt.Sprintf("=== Prover Jobs (%d available) ===", len(proverJobs)), ansiBold))
+		sb.WriteString("\nNodes awaiting refinement. Claim one and refine the proof.\n\n")
 		for _, n := range proverJobs {
-			renderJobNodeView(&sb, n)
+			renderJobNodeView(&sb, n, opts)
 		}
 		sb.WriteString("\nNext: Run 'af claim <id>' to claim a prover job, then 'af refine <id>' to work on it.\n")
 	}
@@ -99,21 +466,52 @@ func RenderJobListView(jl JobListView) string {
 
 	// Render verifier jobs section
 	if len(verifierJobs) > 0 {
-		sb.WriteString(fmt.Sprintf("=== Verifier Jobs (%d available) ===\n", len(verifierJobs)))
-		sb.WriteString("Nodes ready for review. Verify or challenge the proof.\n\n")
+		sb.WriteString(opts.colorize(fmt.Sprintf("=== Verifier Jobs (%d available) ===", len(verifierJobs)), ansiBold))
+		sb.WriteString("\nNodes ready for review. Verify or challenge the proof.\n\n")
 		for _, n := range verifierJobs {
-			renderJobNodeView(&sb, n)
+			renderJobNodeView(&sb, n, opts)
 		}
 		sb.WriteString("\nNext: Run 'af accept <id>' to validate or 'af challenge <id>' to raise objections.\n")
 	}
 
+	if len(jl.Reclaimable) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(RenderReclaimableViews(jl.Reclaimable, opts))
+	}
+
+	return sb.String()
+}
+
+// RenderReclaimableViews renders the section listing claimed nodes whose
+// claim has expired. Returns an empty string if there are none.
+// The section header is emphasized only when opts.Color is set.
+func RenderReclaimableViews(views []NodeView, opts RenderOptions) string {
+	if len(views) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(opts.colorize(fmt.Sprintf("=== Reclaimable Claims (%d expired) ===", len(views)), ansiBold))
+	sb.WriteString("\nClaims whose timeout has passed. Their owners may have stopped working.\n\n")
+	for _, v := range views {
+		stmt := sanitizeStatement(v.Statement)
+		sb.WriteString(fmt.Sprintf("  [%s] %s: %q\n", v.ID, v.Type, stmt))
+		sb.WriteString(fmt.Sprintf("         claimed by: %s (%s)\n", v.ClaimedBy, opts.colorize("expired", ansiYellow)))
+	}
+	sb.WriteString("\nNext: Run 'af reap' to release expired claims, or 'af jobs --reap' to release them before listing jobs.\n")
 	return sb.String()
 }
 
 // renderJobNodeView renders a single job node entry.
-func renderJobNodeView(sb *strings.Builder, v NodeView) {
+// Nodes reopened for refinement are tagged with their epistemic state so they
+// stand out from fresh pending work.
+func renderJobNodeView(sb *strings.Builder, v NodeView, opts RenderOptions) {
 	stmt := sanitizeStatement(v.Statement)
-	sb.WriteString(fmt.Sprintf("  [%s] %s: %q\n", v.ID, v.Type, stmt))
+	if v.EpistemicState == "needs_refinement" {
+		sb.WriteString(fmt.Sprintf("  [%s] %s: %q (%s)\n", v.ID, v.Type, stmt, opts.ColorEpistemicState(v.EpistemicState)))
+	} else {
+		sb.WriteString(fmt.Sprintf("  [%s] %s: %q\n", v.ID, v.Type, stmt))
+	}
 
 	if v.ClaimedBy != "" {
 		sb.WriteString(fmt.Sprintf("         claimed by: %s\n", v.ClaimedBy))
@@ -122,6 +520,11 @@ func renderJobNodeView(sb *strings.Builder, v NodeView) {
 
 // RenderStatusView renders the full proof status from a view model.
 func RenderStatusView(sv StatusView) string {
+	return RenderStatusViewWithOptions(sv, DefaultRenderOptions())
+}
+
+// RenderStatusViewWithOptions is RenderStatusView with explicit render options.
+func RenderStatusViewWithOptions(sv StatusView, opts RenderOptions) string {
 	if len(sv.Nodes) == 0 {
 		return "No proof initialized. Run 'af init' to start a new proof."
 	}
@@ -132,7 +535,7 @@ func RenderStatusView(sv StatusView) string {
 	sb.WriteString("=== Proof Status ===\n\n")
 
 	// 2. Tree view section
-	treeOutput := RenderTreeView(TreeView{Nodes: sv.Nodes, NodeLookup: buildNodeViewLookup(sv.Nodes)})
+	treeOutput := RenderTreeViewWithOptions(TreeView{Nodes: sv.Nodes, NodeLookup: buildNodeViewLookup(sv.Nodes)}, opts)
 	if treeOutput != "" {
 		sb.WriteString(treeOutput)
 		sb.WriteString("\n")
@@ -140,7 +543,9 @@ func RenderStatusView(sv StatusView) string {
 
 	// 3. Statistics section
 	sb.WriteString("--- Statistics ---\n")
-	renderStatisticsView(&sb, sv.Nodes)
+	renderStatisticsView(&sb, sv.Nodes, opts)
+	writeProgress(&sb, sv.CompletionPercent, sv.RootProven)
+	writeOpenChallenges(&sb, sv.OpenChallenges, sv.BlockedNodes, opts)
 	sb.WriteString("\n")
 
 	// 4. Jobs section
@@ -151,7 +556,7 @@ func RenderStatusView(sv StatusView) string {
 
 	// 5. Legend section
 	sb.WriteString("--- Legend ---\n")
-	renderLegendView(&sb)
+	renderLegendView(&sb, opts)
 
 	return sb.String()
 }
@@ -166,7 +571,7 @@ func buildNodeViewLookup(nodes []NodeView) map[string]NodeView {
 }
 
 // renderStatisticsView writes the statistics section from node views.
-func renderStatisticsView(sb *strings.Builder, nodes []NodeView) {
+func renderStatisticsView(sb *strings.Builder, nodes []NodeView, opts RenderOptions) {
 	total := len(nodes)
 
 	// Count epistemic states
@@ -189,7 +594,7 @@ func renderStatisticsView(sb *strings.Builder, nodes []NodeView) {
 	epistemicStates := []string{"pending", "validated", "admitted", "refuted", "archived"}
 	epistemicParts := make([]string, len(epistemicStates))
 	for i, state := range epistemicStates {
-		epistemicParts[i] = fmt.Sprintf("%d %s", epistemicCounts[state], colorEpistemicStateString(state))
+		epistemicParts[i] = fmt.Sprintf("%d %s", epistemicCounts[state], opts.ColorEpistemicState(state))
 	}
 	sb.WriteString(strings.Join(epistemicParts, ", "))
 	sb.WriteString("\n")
@@ -199,31 +604,97 @@ func renderStatisticsView(sb *strings.Builder, nodes []NodeView) {
 	taintStates := []string{"clean", "self_admitted", "tainted", "unresolved"}
 	taintParts := make([]string, len(taintStates))
 	for i, state := range taintStates {
-		taintParts[i] = fmt.Sprintf("%d %s", taintCounts[state], colorTaintStateString(state))
+		taintParts[i] = fmt.Sprintf("%d %s", taintCounts[state], opts.ColorTaintState(state))
 	}
 	sb.WriteString(strings.Join(taintParts, ", "))
 	sb.WriteString("\n")
 }
 
+// progressBarWidth is the number of cells in the status progress bar.
+const progressBarWidth = 20
+
+// renderProgressBar returns a fixed-width text bar such as
+// "[##########----------]" for the given completion percentage.
+func renderProgressBar(percent float64) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	filled := int(percent * progressBarWidth / 100)
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled) + "]"
+}
+
+// writeProgress writes the completion progress line of the statistics section.
+func writeProgress(sb *strings.Builder, percent float64, rootProven bool) {
+	sb.WriteString(fmt.Sprintf("  Progress: %s %.1f%%", renderProgressBar(percent), percent))
+	if rootProven {
+		sb.WriteString(" (root proven)")
+	}
+	sb.WriteString("\n")
+}
+
+// challengeSeverityOrder lists challenge severities from most to least severe.
+var challengeSeverityOrder = []string{"critical", "major", "minor", "note"}
+
+// writeOpenChallenges writes the open challenge counts of the statistics
+// section. Non-zero blocking counts (critical, major, and blocked nodes) are
+// colored so blocking work stands out.
+func writeOpenChallenges(sb *strings.Builder, counts map[string]int, blockedNodes int, opts RenderOptions) {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if total == 0 {
+		sb.WriteString("  Open challenges: none\n")
+		return
+	}
+
+	parts := make([]string, 0, len(challengeSeverityOrder))
+	for _, severity := range challengeSeverityOrder {
+		part := fmt.Sprintf("%d %s", counts[severity], severity)
+		if counts[severity] > 0 {
+			part = opts.ColorSeverity(severity, part)
+		}
+		parts = append(parts, part)
+	}
+	sb.WriteString(fmt.Sprintf("  Open challenges: %s", strings.Join(parts, ", ")))
+
+	if blockedNodes > 0 {
+		noun := "nodes"
+		if blockedNodes == 1 {
+			noun = "node"
+		}
+		sb.WriteString(" " + opts.colorize(fmt.Sprintf("(%d %s blocked)", blockedNodes, noun), ansiRed))
+	}
+	sb.WriteString("\n")
+}
+
 // renderLegendView writes the legend section.
-func renderLegendView(sb *strings.Builder) {
+func renderLegendView(sb *strings.Builder, opts RenderOptions) {
 	sb.WriteString("Epistemic States:\n")
-	sb.WriteString(fmt.Sprintf("  %s    - Awaiting proof/verification\n", colorEpistemicStateString("pending")))
-	sb.WriteString(fmt.Sprintf("  %s  - Verified by adversarial verifier\n", colorEpistemicStateString("validated")))
-	sb.WriteString(fmt.Sprintf("  %s   - Accepted without full verification\n", colorEpistemicStateString("admitted")))
-	sb.WriteString(fmt.Sprintf("  %s    - Proven false\n", colorEpistemicStateString("refuted")))
-	sb.WriteString(fmt.Sprintf("  %s   - Superseded or abandoned\n", colorEpistemicStateString("archived")))
+	sb.WriteString(fmt.Sprintf("  %s    - Awaiting proof/verification\n", opts.ColorEpistemicState("pending")))
+	sb.WriteString(fmt.Sprintf("  %s  - Verified by adversarial verifier\n", opts.ColorEpistemicState("validated")))
+	sb.WriteString(fmt.Sprintf("  %s   - Accepted without full verification\n", opts.ColorEpistemicState("admitted")))
+	sb.WriteString(fmt.Sprintf("  %s    - Proven false\n", opts.ColorEpistemicState("refuted")))
+	sb.WriteString(fmt.Sprintf("  %s   - Superseded or abandoned\n", opts.ColorEpistemicState("archived")))
 	sb.WriteString("\n")
 
 	sb.WriteString("Taint States:\n")
-	sb.WriteString(fmt.Sprintf("  %s         - No epistemic uncertainty\n", colorTaintStateString("clean")))
-	sb.WriteString(fmt.Sprintf("  %s - Contains admitted node\n", colorTaintStateString("self_admitted")))
-	sb.WriteString(fmt.Sprintf("  %s       - Depends on tainted/refuted node\n", colorTaintStateString("tainted")))
-	sb.WriteString(fmt.Sprintf("  %s    - Taint status not yet computed\n", colorTaintStateString("unresolved")))
+	sb.WriteString(fmt.Sprintf("  %s         - No epistemic uncertainty\n", opts.ColorTaintState("clean")))
+	sb.WriteString(fmt.Sprintf("  %s - Contains admitted node\n", opts.ColorTaintState("self_admitted")))
+	sb.WriteString(fmt.Sprintf("  %s       - Depends on tainted/refuted node\n", opts.ColorTaintState("tainted")))
+	sb.WriteString(fmt.Sprintf("  %s    - Taint status not yet computed\n", opts.ColorTaintState("unresolved")))
 }
 
 // RenderTreeView renders a proof tree from a view model.
 func RenderTreeView(tv TreeView) string {
+	return RenderTreeViewWithOptions(tv, DefaultRenderOptions())
+}
+
+// RenderTreeViewWithOptions is RenderTreeView with explicit render options.
+func RenderTreeViewWithOptions(tv TreeView, opts RenderOptions) string {
 	if len(tv.Nodes) == 0 {
 		return ""
 	}
@@ -253,7 +724,7 @@ func RenderTreeView(tv TreeView) string {
 	// Build the tree output
 	var sb strings.Builder
 	for i, root := range rootNodes {
-		renderSubtreeView(&sb, root, tv.NodeLookup, tv.Nodes, "", i == len(rootNodes)-1, true, tv.Root)
+		renderSubtreeView(&sb, root, tv.NodeLookup, tv.Nodes, "", i == len(rootNodes)-1, true, tv.Root, opts)
 	}
 
 	return sb.String()
@@ -269,44 +740,74 @@ func renderSubtreeView(
 	isLast bool,
 	isRoot bool,
 	customRoot *NodeView,
+	opts RenderOptions,
 ) {
-	// Format node line
-	nodeStr := formatNodeView(v, nodeLookup)
-
+	// Calculate new prefix for children
+	var childPrefix string
 	if isRoot {
-		sb.WriteString(nodeStr)
+		childPrefix = ""
 	} else {
 		if isLast {
-			sb.WriteString(prefix + treeLastNode + nodeStr)
+			childPrefix = prefix + treeSpace
 		} else {
-			sb.WriteString(prefix + treeBranch + nodeStr)
+			childPrefix = prefix + treeVertical
 		}
 	}
-	sb.WriteString("\n")
 
 	// Find children
 	children := findChildrenView(v.ID, allNodes, customRoot)
 	sortNodeViewsByID(children)
 
-	// Calculate new prefix for children
-	var childPrefix string
+	// Format node line; a wrapped statement continues under childPrefix,
+	// which is as wide as the prefix before the node ID
+	label := treeNodeLabel(v.ID, isRoot, opts)
+	hasChildren := len(children) > 0 || v.HiddenDescendants > 0
+	nodeStr := formatNodeView(v, label, nodeLookup, opts, childPrefix, hasChildren)
+	collapse := opts.CollapseValidated && v.SubtreeSettled
+	if collapse {
+		nodeStr = formatCollapsedSubtree(v, label, allNodes, opts)
+	}
+
 	if isRoot {
-		childPrefix = ""
+		sb.WriteString(nodeStr)
 	} else {
 		if isLast {
-			childPrefix = prefix + treeSpace
+			sb.WriteString(prefix + treeLastNode + nodeStr)
 		} else {
-			childPrefix = prefix + treeVertical
+			sb.WriteString(prefix + treeBranch + nodeStr)
 		}
 	}
+	sb.WriteString("\n")
+	if collapse {
+		return
+	}
 
 	// Render children
 	for i, child := range children {
 		childIsLast := i == len(children)-1
-		renderSubtreeView(sb, child, nodeLookup, allNodes, childPrefix, childIsLast, false, customRoot)
+		renderSubtreeView(sb, child, nodeLookup, allNodes, childPrefix, childIsLast, false, customRoot, opts)
+	}
+
+	// Descendants trimmed by a depth limit are summarized in one line
+	if v.HiddenDescendants > 0 {
+		sb.WriteString(childPrefix + treeLastNode + formatHiddenLevels(v) + "\n")
 	}
 }
 
+// formatHiddenLevels formats the indicator shown in place of descendants
+// trimmed by a depth limit, e.g. "… (2 more levels, 6 nodes)".
+func formatHiddenLevels(v NodeView) string {
+	levels := "levels"
+	if v.HiddenLevels == 1 {
+		levels = "level"
+	}
+	nodes := "nodes"
+	if v.HiddenDescendants == 1 {
+		nodes = "node"
+	}
+	return fmt.Sprintf("\u2026 (%d more %s, %d %s)", v.HiddenLevels, levels, v.HiddenDescendants, nodes)
+}
+
 // findChildrenView finds all direct children of a given node ID from views.
 func findChildrenView(parentID string, allNodes []NodeView, customRoot *NodeView) []NodeView {
 	var children []NodeView
@@ -329,6 +830,34 @@ func findChildrenView(parentID string, allNodes []NodeView, customRoot *NodeView
 	return children
 }
 
+// treeNodeLabel returns the ID shown for a node in the tree view: the full
+// ID, or with opts.AbbreviateIDs only its last segment for every node but
+// the rendering root, whose full ID anchors the abbreviated ones below it.
+func treeNodeLabel(id string, isRoot bool, opts RenderOptions) string {
+	if !opts.AbbreviateIDs || isRoot {
+		return id
+	}
+	return id[strings.LastIndex(id, ".")+1:]
+}
+
+// formatCollapsedSubtree formats the summary line, labeled label, that
+// replaces a settled subtree when CollapseValidated is set, e.g.
+// "1.2 [✓ 3 nodes]".
+func formatCollapsedSubtree(v NodeView, label string, allNodes []NodeView, opts RenderOptions) string {
+	count := 0
+	for _, n := range allNodes {
+		if isDescendantOrEqualView(n.ID, v.ID) {
+			count += 1 + n.HiddenDescendants
+		}
+	}
+
+	noun := "nodes"
+	if count == 1 {
+		noun = "node"
+	}
+	return label + " " + opts.colorize(fmt.Sprintf("[\u2713 %d %s]", count, noun), ansiGreen)
+}
+
 // isDescendantOrEqualView returns true if nodeID is equal to or a descendant of ancestorID.
 func isDescendantOrEqualView(nodeID, ancestorID string) bool {
 	if nodeID == ancestorID {
@@ -337,23 +866,40 @@ func isDescendantOrEqualView(nodeID, ancestorID string) bool {
 	return strings.HasPrefix(nodeID, ancestorID+".")
 }
 
-// formatNodeView formats a single node view for tree display.
-func formatNodeView(v NodeView, nodeLookup map[string]NodeView) string {
+// formatNodeView formats a single node view, shown with ID label, for tree
+// display. If opts.Width is set the statement is wrapped to fit, with
+// continuation lines starting with childPrefix, indented under the
+// statement, and carrying the vertical line down to the node's children if
+// it has any.
+func formatNodeView(v NodeView, label string, nodeLookup map[string]NodeView, opts RenderOptions, childPrefix string, hasChildren bool) string {
 	var sb strings.Builder
 
-	sb.WriteString(v.ID)
+	sb.WriteString(label)
 	sb.WriteString(" ")
 
 	// Status bracket [epistemic/taint]
 	sb.WriteString("[")
-	sb.WriteString(colorEpistemicStateString(v.EpistemicState))
+	sb.WriteString(opts.ColorEpistemicState(v.EpistemicState))
 	sb.WriteString("/")
-	sb.WriteString(colorTaintStateString(v.TaintState))
+	sb.WriteString(opts.ColorTaintState(v.TaintState))
 	sb.WriteString("] ")
 
-	// Statement (sanitized but NOT truncated)
-	stmt := sanitizeStatement(v.Statement)
-	sb.WriteString(stmt)
+	// Statement (sanitized and wrapped but NOT truncated)
+	headWidth := visibleWidth(label + " [" + v.EpistemicState + "/" + v.TaintState + "] ")
+	wrapWidth := 0
+	if opts.Width > 0 {
+		wrapWidth = max(opts.Width-visibleWidth(childPrefix)-headWidth, minWrapWidth)
+	}
+	indent := strings.Repeat(" ", headWidth)
+	if hasChildren {
+		indent = strings.TrimRight(treeVertical, " ") + indent[1:]
+	}
+	for i, line := range wrapText(v.Statement, wrapWidth) {
+		if i > 0 {
+			sb.WriteString("\n" + childPrefix + indent)
+		}
+		sb.WriteString(line)
+	}
 
 	// Show validation dependency status if present
 	if len(v.ValidationDeps) > 0 {
@@ -373,9 +919,9 @@ func formatNodeView(v NodeView, nodeLookup map[string]NodeView) string {
 		}
 		if blockedCount > 0 {
 			sb.WriteString(" ")
-			sb.WriteString(Red("[BLOCKED: "))
-			sb.WriteString(Red(strings.Join(blocked, ", ")))
-			sb.WriteString(Red("]"))
+			sb.WriteString(opts.colorize("[BLOCKED: ", ansiRed))
+			sb.WriteString(opts.colorize(strings.Join(blocked, ", "), ansiRed))
+			sb.WriteString(opts.colorize("]", ansiRed))
 		}
 	}
 
@@ -498,9 +1044,7 @@ func renderSiblingsView(sb *strings.Builder, siblings []NodeView) {
 		sb.WriteString(sib.ID)
 		sb.WriteString(": ")
 		stmt := sanitizeStatement(sib.Statement)
-		if len(stmt) > 50 {
-			stmt = stmt[:47] + "..."
-		}
+		stmt = truncateRunes(stmt, 50)
 		sb.WriteString(stmt)
 		sb.WriteString("\n")
 	}
@@ -527,9 +1071,7 @@ func renderDependenciesView(sb *strings.Builder, deps []NodeView) {
 		sb.WriteString(dep.ID)
 		sb.WriteString(": ")
 		stmt := sanitizeStatement(dep.Statement)
-		if len(stmt) > 50 {
-			stmt = stmt[:47] + "..."
-		}
+		stmt = truncateRunes(stmt, 50)
 		sb.WriteString(stmt)
 		sb.WriteString("\n")
 	}
@@ -594,9 +1136,7 @@ func renderDefinitionsView(sb *strings.Builder, defs []DefinitionView) {
 		sb.WriteString(def.Name)
 		sb.WriteString(": ")
 		content := def.Content
-		if len(content) > 60 {
-			content = content[:57] + "..."
-		}
+		content = truncateRunes(content, 60)
 		sb.WriteString(content)
 		sb.WriteString("\n")
 	}
@@ -621,9 +1161,7 @@ func renderAssumptionsView(sb *strings.Builder, assumptions []AssumptionView) {
 	for _, assume := range sorted {
 		sb.WriteString("  - ")
 		stmt := assume.Statement
-		if len(stmt) > 60 {
-			stmt = stmt[:57] + "..."
-		}
+		stmt = truncateRunes(stmt, 60)
 		sb.WriteString(stmt)
 		if assume.Justification != "" {
 			sb.WriteString(" (")
@@ -913,9 +1451,7 @@ func RenderSearchResultViews(results []SearchResultView) string {
 		}
 
 		stmt := sanitizeStatement(r.Node.Statement)
-		if len(stmt) > 60 {
-			stmt = stmt[:57] + "..."
-		}
+		stmt = truncateRunes(stmt, 60)
 
 		sb.WriteString("[")
 		sb.WriteString(r.Node.ID)
@@ -946,36 +1482,112 @@ func RenderSearchResultViews(results []SearchResultView) string {
 	return sb.String()
 }
 
+// searchSnippetContext is the number of columns of context kept on each side
+// of a search match.
+const searchSnippetContext = 30
+
+// RenderSearchMatches formats search matches with the matched text highlighted.
+func RenderSearchMatches(v SearchResultsView) string {
+	if len(v.Results) == 0 {
+		return "No matching nodes found.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Search Results:\n")
+	sb.WriteString(strings.Repeat("-", 60))
+	sb.WriteString("\n")
+
+	for _, m := range v.Results {
+		sb.WriteString(fmt.Sprintf("[%s] (%s, %s) ", m.ID, colorEpistemicStateString(m.EpistemicState), m.WorkflowState))
+		if m.Field != "" && m.Field != "statement" {
+			sb.WriteString(m.Field)
+			sb.WriteString(": ")
+		}
+		sb.WriteString(highlightSnippet(m))
+		if m.MatchReason != "" {
+			sb.WriteString(" -- ")
+			sb.WriteString(m.MatchReason)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Repeat("-", 60))
+	sb.WriteString("\n")
+	sb.WriteString("Total: ")
+	sb.WriteString(formatCount(v.Total))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// highlightSnippet returns the snippet with the matched text emphasized:
+// bold yellow when color is enabled, otherwise wrapped in asterisks.
+func highlightSnippet(m SearchMatchView) string {
+	if m.MatchStart < 0 || m.MatchEnd <= m.MatchStart || m.MatchEnd > len(m.Snippet) {
+		return m.Snippet
+	}
+	match := m.Snippet[m.MatchStart:m.MatchEnd]
+	if IsColorEnabled() {
+		match = Bold(Yellow(match))
+	} else {
+		match = "*" + match + "*"
+	}
+	return m.Snippet[:m.MatchStart] + match + m.Snippet[m.MatchEnd:]
+}
+
+// findMatch returns the byte offset of the first occurrence of query in text,
+// or -1. Without caseSensitive, runes are compared with Unicode case folding.
+func findMatch(text, query string, caseSensitive bool) int {
+	if query == "" {
+		return -1
+	}
+	if caseSensitive {
+		return strings.Index(text, query)
+	}
+	for i := range text {
+		if i+len(query) > len(text) {
+			break
+		}
+		if strings.EqualFold(text[i:i+len(query)], query) {
+			return i
+		}
+	}
+	return -1
+}
+
+// searchSnippet returns an excerpt of text around the first match of query,
+// with the byte range of the match within the excerpt. Elided text is marked
+// with Ellipsis. If query is empty or does not occur, the excerpt is the start
+// of text and the match range is empty.
+func searchSnippet(text, query string, caseSensitive bool) (snippet string, start, end int) {
+	idx := findMatch(text, query, caseSensitive)
+	if idx < 0 {
+		return truncateRunes(text, 2*searchSnippetContext), 0, 0
+	}
+
+	matchEnd := idx + len(query)
+	from := visibleTailCut(text[:idx], searchSnippetContext)
+	to := matchEnd + visibleCut(text[matchEnd:], searchSnippetContext)
+
+	var prefix, suffix string
+	if from > 0 {
+		prefix = Ellipsis
+	}
+	if to < len(text) {
+		suffix = Ellipsis
+	}
+
+	snippet = prefix + text[from:to] + suffix
+	start = len(prefix) + idx - from
+	return snippet, start, start + len(query)
+}
+
 // colorEpistemicStateString returns the epistemic state string with color coding.
 func colorEpistemicStateString(state string) string {
-	switch state {
-	case "pending":
-		return Yellow(state)
-	case "validated":
-		return Green(state)
-	case "admitted":
-		return Cyan(state)
-	case "refuted":
-		return Red(state)
-	case "archived":
-		return Gray(state)
-	default:
-		return state
-	}
+	return DefaultRenderOptions().ColorEpistemicState(state)
 }
 
 // colorTaintStateString returns the taint state string with color coding.
 func colorTaintStateString(state string) string {
-	switch state {
-	case "clean":
-		return Green(state)
-	case "self_admitted":
-		return Cyan(state)
-	case "tainted":
-		return Red(state)
-	case "unresolved":
-		return Yellow(state)
-	default:
-		return state
-	}
+	return DefaultRenderOptions().ColorTaintState(state)
 }
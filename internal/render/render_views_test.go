@@ -3,6 +3,7 @@ package render
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestRenderNodeView(t *testing.T) {
@@ -157,6 +158,27 @@ func TestRenderJobListView(t *testing.T) {
 			},
 			contains: []string{"Prover Jobs (1 available)", "Verifier Jobs (1 available)"},
 		},
+		{
+			name: "reclaimable claims without jobs",
+			jl: JobListView{
+				Reclaimable: []NodeView{
+					{ID: "1.3", Type: "claim", Statement: "Stale", ClaimedBy: "crashed", Expired: true},
+				},
+			},
+			contains: []string{"No jobs available", "Reclaimable Claims (1 expired)", "[1.3]", "claimed by: crashed (expired)", "af reap"},
+		},
+		{
+			name: "reclaimable claims after jobs",
+			jl: JobListView{
+				ProverJobs: []NodeView{
+					{ID: "1", Type: "claim", Statement: "Prove this"},
+				},
+				Reclaimable: []NodeView{
+					{ID: "1.3", Type: "claim", Statement: "Stale", ClaimedBy: "crashed", Expired: true},
+				},
+			},
+			contains: []string{"Prover Jobs (1 available)", "Reclaimable Claims (1 expired)"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -200,9 +222,38 @@ func TestRenderStatusView(t *testing.T) {
 				"Statistics",
 				"Nodes: 1 total",
 				"Prover: 1 nodes awaiting refinement",
+				"Progress: [--------------------] 0.0%",
 				"Legend",
 			},
 		},
+		{
+			name: "status with root proven",
+			sv: StatusView{
+				Nodes: []NodeView{
+					{ID: "1", Type: "claim", Statement: "Root", Depth: 1, EpistemicState: "validated", TaintState: "clean"},
+					{ID: "1.1", Type: "claim", Statement: "Step", Depth: 2, EpistemicState: "pending", TaintState: "clean"},
+				},
+				CompletionPercent: 50,
+				RootProven:        true,
+			},
+			contains: []string{
+				"Progress: [##########----------] 50.0% (root proven)",
+				"Open challenges: none",
+			},
+		},
+		{
+			name: "status with open challenges",
+			sv: StatusView{
+				Nodes: []NodeView{
+					{ID: "1", Type: "claim", Statement: "Root", Depth: 1, EpistemicState: "pending", TaintState: "clean"},
+				},
+				OpenChallenges: map[string]int{"critical": 1, "major": 2, "note": 1},
+				BlockedNodes:   1,
+			},
+			contains: []string{
+				"Open challenges: 1 critical, 2 major, 0 minor, 1 note (1 node blocked)",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -283,6 +334,134 @@ func TestRenderTreeView(t *testing.T) {
 	}
 }
 
+func TestRenderTreeView_HiddenLevels(t *testing.T) {
+	nodes := []NodeView{
+		{ID: "1", Depth: 1, EpistemicState: "pending", TaintState: "clean", Statement: "Root"},
+		{ID: "1.1", Depth: 2, EpistemicState: "pending", TaintState: "clean", Statement: "Wide", HiddenDescendants: 7, HiddenLevels: 2},
+		{ID: "1.2", Depth: 2, EpistemicState: "pending", TaintState: "clean", Statement: "Narrow", HiddenDescendants: 1, HiddenLevels: 1},
+		{ID: "1.3", Depth: 2, EpistemicState: "pending", TaintState: "clean", Statement: "Leaf"},
+	}
+	tv := TreeView{Nodes: nodes, NodeLookup: buildNodeViewLookup(nodes)}
+
+	got := RenderTreeViewWithOptions(tv, RenderOptions{})
+	for _, want := range []string{
+		"\u251c\u2500\u2500 1.1 [pending/clean] Wide\n\u2502   \u2514\u2500\u2500 \u2026 (2 more levels, 7 nodes)\n",
+		"\u2514\u2500\u2500 \u2026 (1 more level, 1 node)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("tree should contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "\u2026") != 2 {
+		t.Errorf("expected exactly two hidden-level indicators, got:\n%s", got)
+	}
+}
+
+func TestRenderTreeViewWithOptions_Width(t *testing.T) {
+	nodes := []NodeView{
+		{ID: "1", Depth: 1, EpistemicState: "pending", TaintState: "clean",
+			Statement: "For every natural number n greater than zero the sum of the first n odd numbers equals n squared"},
+		{ID: "1.1", Depth: 2, EpistemicState: "pending", TaintState: "clean", Statement: "Base case n equals one holds trivially"},
+	}
+	tv := TreeView{Nodes: nodes, NodeLookup: buildNodeViewLookup(nodes)}
+
+	// The root's statement starts in column 18, leaving 22 per line; its
+	// continuation lines carry the vertical line down to its child. The
+	// child's statement starts in column 24, leaving 16.
+	got := RenderTreeViewWithOptions(tv, RenderOptions{Width: 40})
+	want := "1 [pending/clean] For every natural\n" +
+		"\u2502                 number n greater than\n" +
+		"\u2502                 zero the sum of the\n" +
+		"\u2502                 first n odd numbers\n" +
+		"\u2502                 equals n squared\n" +
+		"\u2514\u2500\u2500 1.1 [pending/clean] Base case n\n" +
+		"                        equals one holds\n" +
+		"                        trivially\n"
+	if got != want {
+		t.Errorf("RenderTreeViewWithOptions(width 40) =\n%s\nwant\n%s", got, want)
+	}
+
+	// Width 0 keeps each statement on one line
+	if got := RenderTreeViewWithOptions(tv, RenderOptions{}); strings.Count(got, "\n") != 2 {
+		t.Errorf("unwrapped tree should have two lines, got:\n%s", got)
+	}
+}
+
+func TestRenderTreeViewWithOptions_CollapseValidated(t *testing.T) {
+	nodes := []NodeView{
+		{ID: "1", Depth: 1, EpistemicState: "pending", TaintState: "clean", Statement: "Root"},
+		{ID: "1.1", Depth: 2, EpistemicState: "validated", TaintState: "clean", Statement: "Done", SubtreeSettled: true},
+		{ID: "1.1.1", Depth: 3, EpistemicState: "validated", TaintState: "clean", Statement: "Done leaf A", SubtreeSettled: true},
+		{ID: "1.1.2", Depth: 3, EpistemicState: "admitted", TaintState: "self_admitted", Statement: "Done leaf B", SubtreeSettled: true},
+		{ID: "1.2", Depth: 2, EpistemicState: "pending", TaintState: "clean", Statement: "Open"},
+		{ID: "1.10", Depth: 2, EpistemicState: "validated", TaintState: "clean", Statement: "Done leaf C", SubtreeSettled: true},
+		{ID: "1.11", Depth: 2, EpistemicState: "validated", TaintState: "clean", Statement: "Trimmed", SubtreeSettled: true, HiddenDescendants: 4, HiddenLevels: 2},
+	}
+	tv := TreeView{Nodes: nodes, NodeLookup: buildNodeViewLookup(nodes)}
+
+	pruned := RenderTreeViewWithOptions(tv, RenderOptions{CollapseValidated: true})
+	for _, want := range []string{"1 [pending/clean] Root", "1.1 [\u2713 3 nodes]", "1.2 [pending/clean] Open", "1.10 [\u2713 1 node]", "1.11 [\u2713 5 nodes]"} {
+		if !strings.Contains(pruned, want) {
+			t.Errorf("pruned tree should contain %q, got:\n%s", want, pruned)
+		}
+	}
+	for _, hidden := range []string{"1.1.1", "Done leaf A", "Done leaf B", "Done leaf C"} {
+		if strings.Contains(pruned, hidden) {
+			t.Errorf("pruned tree should not contain %q, got:\n%s", hidden, pruned)
+		}
+	}
+
+	full := RenderTreeViewWithOptions(tv, RenderOptions{})
+	for _, want := range []string{"1.1.1 [validated/clean] Done leaf A", "1.10 [validated/clean] Done leaf C"} {
+		if !strings.Contains(full, want) {
+			t.Errorf("unpruned tree should contain %q, got:\n%s", want, full)
+		}
+	}
+}
+
+func TestRenderTreeViewWithOptions_AbbreviateIDs(t *testing.T) {
+	nodes := []NodeView{
+		{ID: "1", Depth: 1, EpistemicState: "pending", TaintState: "clean", Statement: "Root"},
+		{ID: "1.2", Depth: 2, EpistemicState: "pending", TaintState: "clean", Statement: "Middle"},
+		{ID: "1.2.3", Depth: 3, EpistemicState: "pending", TaintState: "clean", Statement: "Leaf",
+			ValidationDeps: []string{"1.2"}},
+		{ID: "1.10", Depth: 2, EpistemicState: "validated", TaintState: "clean", Statement: "Done", SubtreeSettled: true},
+	}
+	tv := TreeView{Nodes: nodes, NodeLookup: buildNodeViewLookup(nodes)}
+	before := make([]string, len(tv.Nodes))
+	for i, n := range tv.Nodes {
+		before[i] = n.ID
+	}
+
+	full := RenderTreeViewWithOptions(tv, RenderOptions{})
+	abbreviated := RenderTreeViewWithOptions(tv, RenderOptions{AbbreviateIDs: true})
+
+	// Only the labels lose their parent's prefix; references keep full IDs
+	want := strings.NewReplacer("1.2.3 [", "3 [", "1.2 [", "2 [", "1.10 [", "10 [").Replace(full)
+	if abbreviated != want {
+		t.Errorf("abbreviated tree =\n%s\nwant\n%s", abbreviated, want)
+	}
+	if !strings.HasPrefix(abbreviated, "1 [pending/clean] Root") || !strings.Contains(abbreviated, "[BLOCKED: 1.2]") {
+		t.Errorf("abbreviated tree should keep the root's and referenced IDs in full, got:\n%s", abbreviated)
+	}
+	for i, n := range tv.Nodes {
+		if n.ID != before[i] {
+			t.Errorf("view model node %d ID changed from %q to %q", i, before[i], n.ID)
+		}
+	}
+
+	// A subtree starts from its own full ID; collapsed lines are abbreviated too
+	root := tv.NodeLookup["1.2"]
+	sub := RenderTreeViewWithOptions(TreeView{Nodes: nodes, NodeLookup: tv.NodeLookup, Root: &root}, RenderOptions{AbbreviateIDs: true})
+	if !strings.HasPrefix(sub, "1.2 [pending/clean] Middle\n\u2514\u2500\u2500 3 [pending/clean] Leaf") {
+		t.Errorf("abbreviated subtree should start from the full root ID, got:\n%s", sub)
+	}
+	pruned := RenderTreeViewWithOptions(tv, RenderOptions{AbbreviateIDs: true, CollapseValidated: true})
+	if !strings.Contains(pruned, "\u2514\u2500\u2500 10 [\u2713 1 node]") {
+		t.Errorf("collapsed subtree should be labeled with the last ID segment, got:\n%s", pruned)
+	}
+}
+
 func TestRenderProverContextView(t *testing.T) {
 	originalColor := colorEnabled
 	colorEnabled = false
@@ -575,3 +754,451 @@ func TestSortNodeViewsByID(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderNodeDetail(t *testing.T) {
+	restore := saveColorState()
+	defer restore()
+	DisableColor()
+
+	v := NodeDetailView{
+		Node: NodeView{
+			ID:             "1.2",
+			Type:           "claim",
+			Statement:      "Main step",
+			Latex:          "x^2 \\geq 0",
+			Inference:      "modus_ponens",
+			WorkflowState:  "available",
+			EpistemicState: "pending",
+			TaintState:     "unresolved",
+			Lemmas:         []string{"LEM-001"},
+			Tags:           []string{"approach-A", "needs-expert"},
+		},
+		Dependencies: []DependencyStatusView{
+			{ID: "1.1", Exists: true, EpistemicState: "validated", Statement: "Lemma A"},
+			{ID: "1.3", Exists: true, EpistemicState: "pending", Statement: "Lemma B"},
+			{ID: "1.9"},
+		},
+		OpenChallenges: []ChallengeGroupView{
+			{Severity: "critical", Blocking: true, Challenges: []ChallengeView{{ID: "ch-a", Target: "inference", Reason: "Invalid step"}}},
+			{Severity: "note", Challenges: []ChallengeView{{ID: "ch-b", Target: "statement", Reason: "Typo"}}},
+		},
+		TotalChallenges: 3,
+		Notes: []NoteView{
+			{ID: "note-1", Author: "verifier", Text: "Could cite Lemma 3", Created: "2026-01-02T03:04:05Z"},
+		},
+	}
+
+	got := RenderNodeDetail(v)
+
+	for _, want := range []string{
+		"Node 1.2 [claim]",
+		"LaTeX:      x^2 \\geq 0",
+		"Justified by lemma LEM-001",
+		"Tags:       approach-A, needs-expert",
+		"Dependencies:",
+		"  1.1 [validated] Lemma A",
+		" !1.3 [pending] Lemma B",
+		"1.9: missing",
+		"Validation dependencies: (none)",
+		"Open challenges (2 of 3 total):",
+		"critical (1) - blocking:",
+		"ch-a [inference] Invalid step",
+		"note (1):",
+		"Notes (1):",
+		"note-1  2026-01-02 03:04:05  verifier: Could cite Lemma 3",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderNodeDetail missing %q in output:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderNodeDetail_TransitiveDependencies(t *testing.T) {
+	restore := saveColorState()
+	defer restore()
+	DisableColor()
+
+	v := NodeDetailView{
+		Node:         NodeView{ID: "1.4", Type: "claim", Statement: "Step"},
+		Dependencies: []DependencyStatusView{{ID: "1.2", Exists: true, EpistemicState: "validated", Statement: "A"}},
+		TransitiveDeps: []DependencyStatusView{
+			{ID: "1.1", Exists: true, EpistemicState: "pending", Statement: "Base"},
+			{ID: "1.2", Exists: true, EpistemicState: "validated", Statement: "A"},
+		},
+		DependencyCycle: true,
+	}
+
+	got := RenderNodeDetail(v)
+	for _, want := range []string{
+		"Rests on (2 nodes, transitively):",
+		" !1.1 [pending] Base",
+		"warning: the dependencies form a cycle",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderNodeDetail missing %q in output:\n%s", want, got)
+		}
+	}
+
+	v.TransitiveDeps, v.DependencyCycle = nil, false
+	if got := RenderNodeDetail(v); strings.Contains(got, "Rests on") || strings.Contains(got, "cycle") {
+		t.Errorf("RenderNodeDetail without transitive dependencies:\n%s", got)
+	}
+}
+
+func TestRenderNodeDetailWithOptions_Width(t *testing.T) {
+	v := NodeDetailView{Node: NodeView{
+		ID:        "1",
+		Type:      "claim",
+		Statement: "For every natural number n greater than zero the sum of the first n odd numbers equals n squared",
+	}}
+
+	// The statement continues under the first line, leaving 28 per line
+	got := RenderNodeDetailWithOptions(v, RenderOptions{Width: 40})
+	want := "Statement:  For every natural number n\n" +
+		"            greater than zero the sum of\n" +
+		"            the first n odd numbers\n" +
+		"            equals n squared\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("RenderNodeDetailWithOptions(width 40) missing\n%s\nin output:\n%s", want, got)
+	}
+}
+
+func TestRenderNodeDetail_History(t *testing.T) {
+	v := NodeDetailView{
+		Node: NodeView{ID: "1", Type: "claim", Statement: "Root"},
+		History: []NodeHistoryEntryView{
+			{Seq: 2, Timestamp: "2025-01-11T10:00:00Z", Type: "node_created", Summary: "created as claim: Root"},
+			{Seq: 4, Timestamp: "2025-01-11T10:05:00Z", Type: "nodes_claimed", Summary: "claimed by alice"},
+		},
+	}
+
+	got := RenderNodeDetail(v)
+	want := "\nHistory (2 events):\n" +
+		"  #2     2025-01-11 10:00:00  node_created          created as claim: Root\n" +
+		"  #4     2025-01-11 10:05:00  nodes_claimed         claimed by alice\n"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("RenderNodeDetail should end with\n%s\ngot:\n%s", want, got)
+	}
+
+	// Without history the section is omitted
+	v.History = nil
+	if got := RenderNodeDetail(v); strings.Contains(got, "History") {
+		t.Errorf("unexpected history section:\n%s", got)
+	}
+}
+
+func TestRenderStateDiff(t *testing.T) {
+	restore := saveColorState()
+	defer restore()
+	DisableColor()
+
+	v := StateDiffView{
+		FromSeq: 4,
+		ToSeq:   9,
+		Added:   []NodeView{{ID: "1.3", Statement: "Second step", EpistemicState: "pending"}},
+		Removed: []NodeView{{ID: "1.2", Statement: "Dead end", EpistemicState: "archived"}},
+		Changed: []FieldChangeView{{NodeID: "1.1", Field: "epistemic_state", From: "pending", To: "validated"}},
+	}
+
+	got := RenderStateDiff(v)
+	want := `Changes from seq 4 to seq 9:
+
+Added (1):
+  + 1.3 [pending] Second step
+
+Archived (1):
+  - 1.2 Dead end
+
+Changed (1):
+  ~ 1.1 epistemic_state: pending -> validated
+`
+	if got != want {
+		t.Errorf("RenderStateDiff() =\n%s\nwant:\n%s", got, want)
+	}
+
+	empty := RenderStateDiff(StateDiffView{FromSeq: 3, ToSeq: 3})
+	if empty != "No node changes between seq 3 and seq 3.\n" {
+		t.Errorf("RenderStateDiff(empty) = %q", empty)
+	}
+}
+
+func TestRenderStatusDelta(t *testing.T) {
+	if got := RenderStatusDelta(StatusDeltaView{SinceSeq: 12, LatestSeq: 9}, RenderOptions{}); got != "No changes since seq 12.\n" {
+		t.Errorf("empty delta = %q", got)
+	}
+
+	v := StatusDeltaView{
+		SinceSeq:         4,
+		LatestSeq:        9,
+		Created:          []NodeView{{ID: "1.3", Statement: "Second step", EpistemicState: "pending"}},
+		Validated:        []NodeView{{ID: "1.1", Statement: "First step", EpistemicState: "validated"}},
+		ChallengesRaised: []ChallengeView{{ID: "ch-1", TargetID: "1.3", Target: "inference", Severity: "major", Reason: "Gap"}},
+	}
+	got := RenderStatusDelta(v, RenderOptions{})
+	want := `Changes since seq 4 (now at seq 9):
+
+Created (1):
+  + 1.3 [pending] Second step
+
+Validated (1):
+  1.1 First step
+
+Challenges raised (1):
+  ch-1 on 1.3 [inference, major] Gap
+`
+	if got != want {
+		t.Errorf("RenderStatusDelta =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderStatusGroups(t *testing.T) {
+	v := StatusGroupsView{Groups: []StatusGroupView{
+		{Name: "Validated", Count: 2, NodeIDs: []string{"1.1", "1.2"}},
+		{Name: "Refuted", Count: 0, NodeIDs: []string{}},
+	}}
+	want := `Nodes by state:
+
+Validated (2):
+  1.1
+  1.2
+
+Refuted (0):
+  (none)
+`
+	if got := RenderStatusGroups(v); got != want {
+		t.Errorf("RenderStatusGroups =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderActivity(t *testing.T) {
+	v := ActivityView{Agents: []AgentActivityView{
+		{Agent: "verifier", Claims: 1, ChallengesRaised: 1, Acceptances: 1, Total: 3,
+			FirstAction: "2025-01-11T10:05:00Z", LastAction: "2025-01-11T10:09:00Z"},
+		{Agent: "prover", Claims: 1, Refinements: 1, Total: 2,
+			FirstAction: "2025-01-11T10:01:00Z", LastAction: "2025-01-11T10:02:00Z"},
+	}}
+
+	got := RenderActivity(v)
+	want := `Agent activity (2 agents):
+  AGENT                CLAIMS REFINED CHALLENGES ACCEPTS  TOTAL  FIRST ACTION         LAST ACTION
+  verifier                  1       0          1       1      3  2025-01-11 10:05:00  2025-01-11 10:09:00
+  prover                    1       1          0       0      2  2025-01-11 10:01:00  2025-01-11 10:02:00
+`
+	if got != want {
+		t.Errorf("RenderActivity() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if empty := RenderActivity(ActivityView{}); empty != "No agent activity recorded.\n" {
+		t.Errorf("RenderActivity(empty) = %q", empty)
+	}
+}
+
+func TestRenderActivity_ClaimTimes(t *testing.T) {
+	v := ActivityView{
+		Agents: []AgentActivityView{{Agent: "prover", Claims: 2, Total: 2,
+			FirstAction: "2025-01-11T10:01:00Z", LastAction: "2025-01-11T10:02:00Z"}},
+		Nodes: ClaimDurationsToView(map[string][]time.Duration{
+			"1.10": {time.Minute},
+			"1.2":  {time.Minute},
+			"1":    {30 * time.Second, 90 * time.Second},
+		}),
+	}
+
+	got := RenderActivity(v)
+	want := `
+Claim time by node (3 nodes):
+  NODE         CLAIMS  TOTAL HELD    LONGEST
+  1                 2  2m0s          1m30s
+  1.2               1  1m0s          1m0s
+  1.10              1  1m0s          1m0s
+`
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("RenderActivity() =\n%s\nwant suffix:\n%s", got, want)
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	long := "We first establish the base case and then proceed by induction on the length of the sequence of terms"
+
+	tests := []struct {
+		name          string
+		text          string
+		query         string
+		caseSensitive bool
+		wantSnippet   string
+		wantMatch     string
+	}{
+		{"short text", "Proof by induction", "INDUCTION", false, "Proof by induction", "induction"},
+		{"case-sensitive miss", "Proof by induction", "INDUCTION", true, "Proof by induction", ""},
+		{"elided on both sides", long, "induction", false,
+			"...base case and then proceed by induction on the length of the sequence...", "induction"},
+		{"empty query", "Proof by induction", "", false, "Proof by induction", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snippet, start, end := searchSnippet(tt.text, tt.query, tt.caseSensitive)
+			if snippet != tt.wantSnippet {
+				t.Errorf("snippet = %q, want %q", snippet, tt.wantSnippet)
+			}
+			if got := snippet[start:end]; got != tt.wantMatch {
+				t.Errorf("match = %q, want %q", got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestRenderSearchMatches(t *testing.T) {
+	restore := saveColorState()
+	defer restore()
+	DisableColor()
+
+	v := SearchResultsView{
+		Results: []SearchMatchView{
+			{ID: "1.2", EpistemicState: "refuted", WorkflowState: "available", Field: "statement",
+				Snippet: "Inductive step", MatchStart: 0, MatchEnd: 6, MatchReason: "text match"},
+			{ID: "1.4", EpistemicState: "pending", WorkflowState: "claimed", Field: "latex",
+				Snippet: `\sum_{k} k`, MatchStart: 0, MatchEnd: 4},
+		},
+		Total: 2,
+	}
+
+	got := RenderSearchMatches(v)
+	for _, want := range []string{
+		"[1.2] (refuted, available) *Induct*ive step -- text match\n",
+		"[1.4] (pending, claimed) latex: *\\sum*_{k} k\n",
+		"Total: 2 nodes\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderSearchMatches() missing %q\ngot:\n%s", want, got)
+		}
+	}
+
+	if got := RenderSearchMatches(SearchResultsView{}); got != "No matching nodes found.\n" {
+		t.Errorf("RenderSearchMatches(empty) = %q", got)
+	}
+}
+
+func TestRenderViewsWithOptions_Color(t *testing.T) {
+	// Package-wide color is on; explicit options must win either way
+	originalColor := colorEnabled
+	colorEnabled = true
+	defer func() { colorEnabled = originalColor }()
+
+	nodes := []NodeView{
+		{ID: "1", Type: "claim", Statement: "Root", Depth: 1, EpistemicState: "pending", TaintState: "clean", ValidationDeps: []string{"2"}},
+		{ID: "1.1", Type: "claim", Statement: "Child", Depth: 2, EpistemicState: "needs_refinement", TaintState: "clean"},
+	}
+	sv := StatusView{Nodes: nodes, ProverJobCount: 1}
+	jl := JobListView{ProverJobs: nodes[1:]}
+
+	plain := RenderOptions{}
+	color := RenderOptions{Color: true}
+
+	outputs := map[string][2]string{
+		"status": {RenderStatusViewWithOptions(sv, plain), RenderStatusViewWithOptions(sv, color)},
+		"tree": {
+			RenderTreeViewWithOptions(TreeView{Nodes: nodes, NodeLookup: buildNodeViewLookup(nodes)}, plain),
+			RenderTreeViewWithOptions(TreeView{Nodes: nodes, NodeLookup: buildNodeViewLookup(nodes)}, color),
+		},
+		"jobs": {RenderJobListViewWithOptions(jl, plain), RenderJobListViewWithOptions(jl, color)},
+	}
+
+	for name, out := range outputs {
+		if strings.Contains(out[0], "\033[") {
+			t.Errorf("%s: Color: false output contains ANSI codes:\n%q", name, out[0])
+		}
+		if !strings.Contains(out[1], "\033[") {
+			t.Errorf("%s: Color: true output has no ANSI codes:\n%q", name, out[1])
+		}
+		if StripANSI(out[1]) != out[0] {
+			t.Errorf("%s: colored output differs from plain output beyond ANSI codes:\nplain: %q\ncolor: %q", name, out[0], StripANSI(out[1]))
+		}
+	}
+
+	if !strings.Contains(outputs["tree"][1], ansiYellow+"pending"+ansiReset) {
+		t.Errorf("tree should color pending yellow, got:\n%q", outputs["tree"][1])
+	}
+	if !strings.Contains(outputs["tree"][1], ansiRed+"[BLOCKED: "+ansiReset) {
+		t.Errorf("tree should color blocked deps red, got:\n%q", outputs["tree"][1])
+	}
+	if !strings.Contains(outputs["jobs"][0], `"Child" (needs_refinement)`) {
+		t.Errorf("jobs should tag nodes needing refinement, got:\n%s", outputs["jobs"][0])
+	}
+}
+
+func TestRenderEventLog(t *testing.T) {
+	if got := RenderEventLog(EventLogView{}); got != "No events found.\n" {
+		t.Errorf("empty log = %q", got)
+	}
+
+	v := EventLogView{Entries: []EventLogEntryView{
+		{Seq: 1, Type: "proof_initialized", Timestamp: "2025-01-02T03:04:05Z", Summary: `Initialized proof: "P"`},
+		{Seq: 12, Type: "nodes_claimed", Timestamp: "2025-01-02T03:04:06.123456789Z", Summary: "Claimed nodes: 1 by alice", NodeIDs: []string{"1"}},
+		{Seq: 13, Type: "", Summary: "?"},
+	}}
+
+	want := "#1    ProofInitialized      2025-01-02 03:04:05  Initialized proof: \"P\"\n" +
+		"#12   NodesClaimed          2025-01-02 03:04:06  Claimed nodes: 1 by alice\n" +
+		"#13   Unknown                                    ?\n"
+	if got := RenderEventLog(v); got != want {
+		t.Errorf("RenderEventLog mismatch:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestRenderValidationReport(t *testing.T) {
+	v := ValidationReportView{
+		EventsChecked: 12,
+		NodesChecked:  4,
+		Violations: []InvariantViolationView{
+			{Kind: "unvalidated_dependency", Severity: "warning", Message: "node 1.3 is validated but depends on 1.2, which is pending"},
+			{Kind: "missing_dependency", Severity: "critical", Message: "node 1.1 depends on 1.9, which does not exist"},
+		},
+	}
+
+	got := RenderValidationReport(v)
+	want := `Checked 12 events and 4 nodes.
+Found 1 critical violation(s) and 1 warning(s):
+  [CRITICAL] missing_dependency: node 1.1 depends on 1.9, which does not exist
+  [WARNING] unvalidated_dependency: node 1.3 is validated but depends on 1.2, which is pending
+Proof is INVALID.
+`
+	if got != want {
+		t.Errorf("RenderValidationReport() =\n%s\nwant:\n%s", got, want)
+	}
+
+	clean := RenderValidationReport(ValidationReportView{Valid: true, EventsChecked: 3, NodesChecked: 1})
+	if clean != "Checked 3 events and 1 nodes.\nAll invariants hold.\n" {
+		t.Errorf("RenderValidationReport(clean) = %q", clean)
+	}
+}
+
+func TestRenderLedgerVerification(t *testing.T) {
+	v := LedgerVerificationView{
+		EventsChecked: 7,
+		Chained:       true,
+		Issues: []LedgerIssueView{
+			{Kind: "sequence_gap", Seq: 3, Message: "ledger is missing event(s) 3"},
+			{Kind: "content_hash_mismatch", Seq: 5, NodeID: "1.2", Message: "node 1.2 content hash does not match its content"},
+		},
+	}
+
+	got := RenderLedgerVerification(v)
+	want := `ledger FAILED, 7 events checked, 2 issue(s):
+  [seq 3] sequence_gap: ledger is missing event(s) 3
+  [seq 5] content_hash_mismatch: node 1.2 content hash does not match its content
+`
+	if got != want {
+		t.Errorf("RenderLedgerVerification() =\n%s\nwant:\n%s", got, want)
+	}
+
+	clean := RenderLedgerVerification(LedgerVerificationView{OK: true, EventsChecked: 12, Chained: true})
+	if clean != "ledger OK, 12 events verified\n" {
+		t.Errorf("RenderLedgerVerification(clean) = %q", clean)
+	}
+
+	legacy := RenderLedgerVerification(LedgerVerificationView{OK: true, EventsChecked: 2})
+	if !strings.Contains(legacy, "no hash chain") {
+		t.Errorf("RenderLedgerVerification(unchained) = %q, want a note about the missing chain", legacy)
+	}
+}
@@ -42,9 +42,7 @@ func FormatSearchResults(results []SearchResult) string {
 		// Format: [ID] (epistemic_state) "statement" -- match reason
 		stmt := sanitizeStatement(r.Node.Statement)
 		// Truncate statement for search results to keep output readable
-		if len(stmt) > 60 {
-			stmt = stmt[:57] + "..."
-		}
+		stmt = truncateRunes(stmt, 60)
 
 		sb.WriteString("[")
 		sb.WriteString(r.Node.ID.String())
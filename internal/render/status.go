@@ -17,6 +17,13 @@ import (
 //
 // Returns a meaningful message for nil/empty state (not empty string).
 func RenderStatus(s *state.State, limit, offset int) string {
+	return RenderStatusWithOptions(s, limit, offset, DefaultRenderOptions())
+}
+
+// RenderStatusWithOptions is RenderStatus with explicit render options.
+// State tokens in the tree, statistics, and legend are colored only when
+// opts.Color is set.
+func RenderStatusWithOptions(s *state.State, limit, offset int, opts RenderOptions) string {
 	// Handle nil state
 	if s == nil {
 		return "No proof state initialized."
@@ -40,7 +47,7 @@ func RenderStatus(s *state.State, limit, offset int) string {
 	sb.WriteString("=== Proof Status ===\n\n")
 
 	// 2. Tree view section (uses paginated nodes)
-	treeOutput := RenderTreeForNodes(s, paginatedNodes)
+	treeOutput := renderTreeForNodes(s, paginatedNodes, opts)
 	if treeOutput != "" {
 		sb.WriteString(treeOutput)
 		sb.WriteString("\n")
@@ -48,7 +55,9 @@ func RenderStatus(s *state.State, limit, offset int) string {
 
 	// 3. Statistics section (uses paginated nodes for display, but shows pagination info)
 	sb.WriteString("--- Statistics ---\n")
-	renderStatisticsWithPagination(&sb, paginatedNodes, len(nodes), limit, offset)
+	renderStatisticsWithPagination(&sb, paginatedNodes, len(nodes), limit, offset, opts)
+	writeProgress(&sb, s.CompletionPercent(), s.RootProven())
+	writeOpenChallenges(&sb, s.OpenChallengesBySeverity(), s.BlockedNodeCount(), opts)
 	sb.WriteString("\n")
 
 	// 4. Jobs section (calculated from paginated nodes)
@@ -58,7 +67,7 @@ func RenderStatus(s *state.State, limit, offset int) string {
 
 	// 5. Legend section
 	sb.WriteString("--- Legend ---\n")
-	renderLegend(&sb)
+	renderLegend(&sb, opts)
 
 	return sb.String()
 }
@@ -83,7 +92,7 @@ func countStates(nodes []*node.Node) stateCounts {
 }
 
 // writeStateCounts writes epistemic and taint state counts to the builder.
-func writeStateCounts(sb *strings.Builder, counts stateCounts) {
+func writeStateCounts(sb *strings.Builder, counts stateCounts, opts RenderOptions) {
 	// Write epistemic state counts (in fixed order for determinism) with color coding
 	sb.WriteString("  Epistemic: ")
 	epistemicStates := []schema.EpistemicState{
@@ -96,7 +105,7 @@ func writeStateCounts(sb *strings.Builder, counts stateCounts) {
 	}
 	epistemicParts := make([]string, len(epistemicStates))
 	for i, state := range epistemicStates {
-		epistemicParts[i] = fmt.Sprintf("%d %s", counts.epistemic[state], ColorEpistemicState(state))
+		epistemicParts[i] = fmt.Sprintf("%d %s", counts.epistemic[state], opts.ColorEpistemicState(string(state)))
 	}
 	sb.WriteString(strings.Join(epistemicParts, ", "))
 	sb.WriteString("\n")
@@ -111,7 +120,7 @@ func writeStateCounts(sb *strings.Builder, counts stateCounts) {
 	}
 	taintParts := make([]string, len(taintStates))
 	for i, state := range taintStates {
-		taintParts[i] = fmt.Sprintf("%d %s", counts.taint[state], ColorTaintState(state))
+		taintParts[i] = fmt.Sprintf("%d %s", counts.taint[state], opts.ColorTaintState(string(state)))
 	}
 	sb.WriteString(strings.Join(taintParts, ", "))
 	sb.WriteString("\n")
@@ -139,7 +148,7 @@ func applyPagination(nodes []*node.Node, limit, offset int) []*node.Node {
 }
 
 // renderStatisticsWithPagination writes the statistics section including pagination info.
-func renderStatisticsWithPagination(sb *strings.Builder, nodes []*node.Node, totalNodes, limit, offset int) {
+func renderStatisticsWithPagination(sb *strings.Builder, nodes []*node.Node, totalNodes, limit, offset int, opts RenderOptions) {
 	displayed := len(nodes)
 	counts := countStates(nodes)
 
@@ -150,7 +159,7 @@ func renderStatisticsWithPagination(sb *strings.Builder, nodes []*node.Node, tot
 		sb.WriteString(fmt.Sprintf("Nodes: %d total\n", totalNodes))
 	}
 
-	writeStateCounts(sb, counts)
+	writeStateCounts(sb, counts, opts)
 }
 
 // renderStatistics writes the statistics section to the builder.
@@ -158,7 +167,7 @@ func renderStatistics(sb *strings.Builder, nodes []*node.Node) {
 	counts := countStates(nodes)
 
 	sb.WriteString(fmt.Sprintf("Nodes: %d total\n", len(nodes)))
-	writeStateCounts(sb, counts)
+	writeStateCounts(sb, counts, DefaultRenderOptions())
 }
 
 // renderJobs writes the jobs section to the builder.
@@ -188,31 +197,31 @@ func renderJobs(sb *strings.Builder, s *state.State, nodes []*node.Node) {
 
 // renderLegend writes the legend section to the builder.
 // Uses color coding to visually demonstrate each state's color.
-func renderLegend(sb *strings.Builder) {
+func renderLegend(sb *strings.Builder, opts RenderOptions) {
 	// Epistemic states legend with color coding
 	sb.WriteString("Epistemic States:\n")
-	sb.WriteString(fmt.Sprintf("  %s    - Awaiting proof/verification\n", ColorEpistemicState(schema.EpistemicPending)))
-	sb.WriteString(fmt.Sprintf("  %s  - Verified by adversarial verifier\n", ColorEpistemicState(schema.EpistemicValidated)))
-	sb.WriteString(fmt.Sprintf("  %s   - Accepted without full verification\n", ColorEpistemicState(schema.EpistemicAdmitted)))
-	sb.WriteString(fmt.Sprintf("  %s    - Proven false\n", ColorEpistemicState(schema.EpistemicRefuted)))
-	sb.WriteString(fmt.Sprintf("  %s   - Superseded or abandoned\n", ColorEpistemicState(schema.EpistemicArchived)))
-	sb.WriteString(fmt.Sprintf("  %s - Reopened for further refinement\n", ColorEpistemicState(schema.EpistemicNeedsRefinement)))
+	sb.WriteString(fmt.Sprintf("  %s    - Awaiting proof/verification\n", opts.ColorEpistemicState(string(schema.EpistemicPending))))
+	sb.WriteString(fmt.Sprintf("  %s  - Verified by adversarial verifier\n", opts.ColorEpistemicState(string(schema.EpistemicValidated))))
+	sb.WriteString(fmt.Sprintf("  %s   - Accepted without full verification\n", opts.ColorEpistemicState(string(schema.EpistemicAdmitted))))
+	sb.WriteString(fmt.Sprintf("  %s    - Proven false\n", opts.ColorEpistemicState(string(schema.EpistemicRefuted))))
+	sb.WriteString(fmt.Sprintf("  %s   - Superseded or abandoned\n", opts.ColorEpistemicState(string(schema.EpistemicArchived))))
+	sb.WriteString(fmt.Sprintf("  %s - Reopened for further refinement\n", opts.ColorEpistemicState(string(schema.EpistemicNeedsRefinement))))
 	sb.WriteString("\n")
 
 	// Taint states legend with color coding
 	sb.WriteString("Taint States:\n")
-	sb.WriteString(fmt.Sprintf("  %s         - No epistemic uncertainty\n", ColorTaintState(node.TaintClean)))
-	sb.WriteString(fmt.Sprintf("  %s - Contains admitted node\n", ColorTaintState(node.TaintSelfAdmitted)))
-	sb.WriteString(fmt.Sprintf("  %s       - Depends on tainted/refuted node\n", ColorTaintState(node.TaintTainted)))
-	sb.WriteString(fmt.Sprintf("  %s    - Taint status not yet computed\n", ColorTaintState(node.TaintUnresolved)))
+	sb.WriteString(fmt.Sprintf("  %s         - No epistemic uncertainty\n", opts.ColorTaintState(string(node.TaintClean))))
+	sb.WriteString(fmt.Sprintf("  %s - Contains admitted node\n", opts.ColorTaintState(string(node.TaintSelfAdmitted))))
+	sb.WriteString(fmt.Sprintf("  %s       - Depends on tainted/refuted node\n", opts.ColorTaintState(string(node.TaintTainted))))
+	sb.WriteString(fmt.Sprintf("  %s    - Taint status not yet computed\n", opts.ColorTaintState(string(node.TaintUnresolved))))
 }
 
 // UrgentItem represents a single urgent work item for display.
 type UrgentItem struct {
-	NodeID    string
-	Statement string
-	Category  string // "blocking_challenge", "prover_job", "verifier_job"
-	Details   string // Additional context (e.g., challenge reason)
+	NodeID    string `json:"node_id"`
+	Statement string `json:"statement"`
+	Category  string `json:"category"`          // "blocking_challenge", "prover_job", "verifier_job"
+	Details   string `json:"details,omitempty"` // Additional context (e.g., challenge reason)
 }
 
 // FilterUrgentNodes returns nodes that need immediate attention:
@@ -334,7 +343,7 @@ func RenderStatusUrgent(s *state.State) string {
 	if len(blockingChallenges) > 0 {
 		sb.WriteString(fmt.Sprintf("--- Blocking Challenges (%d) ---\n", len(blockingChallenges)))
 		for _, item := range blockingChallenges {
-			sb.WriteString(fmt.Sprintf("  %s: %s\n", item.NodeID, truncateStatement(item.Statement, 50)))
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", item.NodeID, truncateRunes(item.Statement, 50)))
 			sb.WriteString(fmt.Sprintf("    %s\n", item.Details))
 		}
 		sb.WriteString("\n")
@@ -344,7 +353,7 @@ func RenderStatusUrgent(s *state.State) string {
 	if len(proverJobs) > 0 {
 		sb.WriteString(fmt.Sprintf("--- Prover Jobs (%d) ---\n", len(proverJobs)))
 		for _, item := range proverJobs {
-			sb.WriteString(fmt.Sprintf("  %s: %s\n", item.NodeID, truncateStatement(item.Statement, 50)))
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", item.NodeID, truncateRunes(item.Statement, 50)))
 		}
 		sb.WriteString("\n")
 	}
@@ -353,7 +362,7 @@ func RenderStatusUrgent(s *state.State) string {
 	if len(verifierJobs) > 0 {
 		sb.WriteString(fmt.Sprintf("--- Verifier Jobs (%d) ---\n", len(verifierJobs)))
 		for _, item := range verifierJobs {
-			sb.WriteString(fmt.Sprintf("  %s: %s\n", item.NodeID, truncateStatement(item.Statement, 50)))
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", item.NodeID, truncateRunes(item.Statement, 50)))
 		}
 		sb.WriteString("\n")
 	}
@@ -373,14 +382,3 @@ func RenderStatusUrgent(s *state.State) string {
 
 	return sb.String()
 }
-
-// truncateStatement truncates a statement to maxLen characters, adding "..." if needed.
-func truncateStatement(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return "..."
-	}
-	return s[:maxLen-3] + "..."
-}
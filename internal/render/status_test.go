@@ -999,13 +999,16 @@ func TestTruncateStatement(t *testing.T) {
 		{"very short max", "hello", 3, "..."},
 		{"zero max", "hello", 0, "..."},
 		{"empty string", "", 10, ""},
+		{"multibyte", "∀x∈ℕ: x≥0 holds", 8, "∀x∈ℕ:..."},
+		{"colored fits", ansiRed + "hello" + ansiReset, 5, ansiRed + "hello" + ansiReset},
+		{"colored truncated", ansiRed + "hello world" + ansiReset, 8, ansiRed + "hello" + ansiReset + "..."},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := truncateStatement(tt.input, tt.maxLen)
+			result := truncateRunes(tt.input, tt.maxLen)
 			if result != tt.expected {
-				t.Errorf("truncateStatement(%q, %d) = %q, want %q", tt.input, tt.maxLen, result, tt.expected)
+				t.Errorf("truncateRunes(%q, %d) = %q, want %q", tt.input, tt.maxLen, result, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package render
+
+import "os"
+
+// terminalColumns returns 0: terminal size detection is not supported on
+// this platform, so callers fall back to defaultTerminalWidth.
+func terminalColumns(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,22 @@
+//go:build linux || darwin
+
+package render
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalColumns returns the width of the terminal f refers to, or 0 if it
+// cannot be determined.
+func terminalColumns(f *os.File) int {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.Col)
+}
@@ -23,6 +23,13 @@ const (
 // If customRoot is provided, only the subtree starting at that node is rendered.
 // Returns an empty string for nil or empty state.
 func RenderTree(s *state.State, customRoot *types.NodeID) string {
+	return RenderTreeWithOptions(s, customRoot, DefaultRenderOptions())
+}
+
+// RenderTreeWithOptions is RenderTree with explicit render options.
+// Epistemic/taint states and blocked dependencies are colored only when
+// opts.Color is set.
+func RenderTreeWithOptions(s *state.State, customRoot *types.NodeID, opts RenderOptions) string {
 	if s == nil {
 		return ""
 	}
@@ -67,7 +74,7 @@ func RenderTree(s *state.State, customRoot *types.NodeID) string {
 	// Build the tree output
 	var sb strings.Builder
 	for i, root := range rootNodes {
-		renderSubtree(&sb, s, root, nodeMap, allNodes, "", i == len(rootNodes)-1, true, customRoot)
+		renderSubtree(&sb, s, root, nodeMap, allNodes, "", i == len(rootNodes)-1, true, customRoot, opts)
 	}
 
 	return sb.String()
@@ -78,6 +85,11 @@ func RenderTree(s *state.State, customRoot *types.NodeID) string {
 // Each node is rendered on its own line with indentation based on depth.
 // Returns an empty string for nil or empty node list.
 func RenderTreeForNodes(s *state.State, nodes []*node.Node) string {
+	return renderTreeForNodes(s, nodes, DefaultRenderOptions())
+}
+
+// renderTreeForNodes implements RenderTreeForNodes with explicit render options.
+func renderTreeForNodes(s *state.State, nodes []*node.Node, opts RenderOptions) string {
 	if len(nodes) == 0 {
 		return ""
 	}
@@ -87,7 +99,7 @@ func RenderTreeForNodes(s *state.State, nodes []*node.Node) string {
 		// Indent based on depth (2 spaces per level)
 		depth := n.ID.Depth()
 		indent := strings.Repeat("  ", depth-1)
-		nodeStr := formatNodeWithState(n, s)
+		nodeStr := formatNodeWithOptions(n, s, opts)
 		sb.WriteString(indent)
 		sb.WriteString(nodeStr)
 		sb.WriteString("\n")
@@ -108,9 +120,10 @@ func renderSubtree(
 	isLast bool,
 	isRoot bool,
 	customRoot *types.NodeID,
+	opts RenderOptions,
 ) {
 	// Render this node with state context for validation dependency info
-	nodeStr := formatNodeWithState(n, s)
+	nodeStr := formatNodeWithOptions(n, s, opts)
 
 	// For the root node, just write the node line (no branch characters)
 	if isRoot {
@@ -146,7 +159,7 @@ func renderSubtree(
 	// Render children
 	for i, child := range children {
 		childIsLast := i == len(children)-1
-		renderSubtree(sb, s, child, nodeMap, allNodes, childPrefix, childIsLast, false, customRoot)
+		renderSubtree(sb, s, child, nodeMap, allNodes, childPrefix, childIsLast, false, customRoot, opts)
 	}
 }
 
@@ -201,6 +214,11 @@ func formatNode(n *node.Node) string {
 // Mathematical statements are shown in full without truncation to preserve precision.
 // Uses color coding for epistemic and taint states when color is enabled.
 func formatNodeWithState(n *node.Node, s *state.State) string {
+	return formatNodeWithOptions(n, s, DefaultRenderOptions())
+}
+
+// formatNodeWithOptions implements formatNodeWithState with explicit render options.
+func formatNodeWithOptions(n *node.Node, s *state.State, opts RenderOptions) string {
 	var sb strings.Builder
 
 	// Node ID
@@ -209,9 +227,9 @@ func formatNodeWithState(n *node.Node, s *state.State) string {
 
 	// Status bracket [epistemic/taint] with color coding
 	sb.WriteString("[")
-	sb.WriteString(ColorEpistemicState(n.EpistemicState))
+	sb.WriteString(opts.ColorEpistemicState(string(n.EpistemicState)))
 	sb.WriteString("/")
-	sb.WriteString(ColorTaintState(n.TaintState))
+	sb.WriteString(opts.ColorTaintState(string(n.TaintState)))
 	sb.WriteString("] ")
 
 	// Statement (sanitized but NOT truncated - mathematical formulas must be shown in full)
@@ -223,9 +241,9 @@ func formatNodeWithState(n *node.Node, s *state.State) string {
 		blockedCount := countUnvalidatedDeps(n, s)
 		if blockedCount > 0 {
 			sb.WriteString(" ")
-			sb.WriteString(Red("[BLOCKED: "))
-			sb.WriteString(Red(formatBlockedDeps(n, s)))
-			sb.WriteString(Red("]"))
+			sb.WriteString(opts.colorize("[BLOCKED: ", ansiRed))
+			sb.WriteString(opts.colorize(formatBlockedDeps(n, s), ansiRed))
+			sb.WriteString(opts.colorize("]", ansiRed))
 		}
 	}
 
@@ -135,9 +135,7 @@ func renderDependenciesCheck(sb *strings.Builder, n *node.Node, s *state.State)
 			sb.WriteString(": ")
 			// Truncate statement for readability
 			stmt := sanitizeStatement(depNode.Statement)
-			if len(stmt) > 50 {
-				stmt = stmt[:47] + "..."
-			}
+			stmt = truncateRunes(stmt, 50)
 			sb.WriteString(stmt)
 		} else {
 			sb.WriteString(" (not found)")
@@ -205,9 +205,7 @@ func renderVerifierSiblings(sb *strings.Builder, s *state.State, nodeID types.No
 		sb.WriteString(": ")
 		// Truncate statement for readability
 		stmt := sanitizeStatement(sib.Statement)
-		if len(stmt) > 50 {
-			stmt = stmt[:47] + "..."
-		}
+		stmt = truncateRunes(stmt, 50)
 		sb.WriteString(stmt)
 		sb.WriteString("\n")
 	}
@@ -240,9 +238,7 @@ func renderVerifierDependencies(sb *strings.Builder, s *state.State, n *node.Nod
 		if depNode != nil {
 			sb.WriteString(": ")
 			stmt := sanitizeStatement(depNode.Statement)
-			if len(stmt) > 50 {
-				stmt = stmt[:47] + "..."
-			}
+			stmt = truncateRunes(stmt, 50)
 			sb.WriteString(stmt)
 		}
 		sb.WriteString("\n")
@@ -310,9 +306,7 @@ func renderVerifierDefinitions(sb *strings.Builder, s *state.State, n *node.Node
 			sb.WriteString(def.Name)
 			sb.WriteString(": ")
 			content := def.Content
-			if len(content) > 60 {
-				content = content[:57] + "..."
-			}
+			content = truncateRunes(content, 60)
 			sb.WriteString(content)
 			sb.WriteString("\n")
 		} else {
@@ -385,9 +379,7 @@ func renderVerifierAssumptions(sb *strings.Builder, s *state.State, n *node.Node
 		if assume != nil {
 			sb.WriteString("  - ")
 			stmt := assume.Statement
-			if len(stmt) > 60 {
-				stmt = stmt[:57] + "..."
-			}
+			stmt = truncateRunes(stmt, 60)
 			sb.WriteString(stmt)
 			if assume.Justification != "" {
 				sb.WriteString(" (")
@@ -1,28 +1,40 @@
 // Package render provides human-readable formatting for AF framework types.
 // This file defines view model types that decouple render from domain packages.
 // The render package receives these view models instead of importing domain types.
+//
+// The json tags on view models are a stable contract: the global --json flag
+// serializes view models directly, so renaming a tag is a breaking change and
+// requires bumping JSONSchemaVersion.
 package render
 
 // NodeView is a view model representing a proof node for rendering.
 // This decouples render from the node package.
 type NodeView struct {
-	ID             string   // Hierarchical ID (e.g., "1", "1.2", "1.2.3")
-	Type           string   // Node type (claim, local_assume, etc.)
-	Statement      string   // Mathematical assertion text
-	Latex          string   // Optional LaTeX representation
-	Inference      string   // Inference rule used
-	WorkflowState  string   // available, claimed, blocked
-	EpistemicState string   // pending, validated, admitted, refuted, archived
-	TaintState     string   // clean, self_admitted, tainted, unresolved
-	ContentHash    string   // SHA256 hash of content
-	Created        string   // ISO8601 timestamp
-	Context        []string // References to definitions, assumptions, externals
-	Dependencies   []string // NodeIDs this node depends on
-	ValidationDeps []string // NodeIDs that must be validated first
-	Scope          []string // Scope entries active at this node
-	ClaimedBy      string   // Agent ID holding the claim
-	ClaimedAt      string   // When the node was claimed
-	Depth          int      // Depth in the tree (root = 1)
+	ID             string   `json:"id"`                                     // Hierarchical ID (e.g., "1", "1.2", "1.2.3")
+	Type           string   `json:"type"`                                   // Node type (claim, local_assume, etc.)
+	Statement      string   `json:"statement"`                              // Mathematical assertion text
+	Latex          string   `json:"latex,omitempty"`                        // Optional LaTeX representation
+	Inference      string   `json:"inference"`                              // Inference rule used
+	WorkflowState  string   `json:"workflow_state" enum:"workflow_state"`   // available, claimed, blocked
+	EpistemicState string   `json:"epistemic_state" enum:"epistemic_state"` // pending, validated, admitted, refuted, archived
+	TaintState     string   `json:"taint_state" enum:"taint_state"`         // clean, self_admitted, tainted, unresolved
+	ContentHash    string   `json:"content_hash,omitempty"`                 // SHA256 hash of content
+	Created        string   `json:"created,omitempty"`                      // ISO8601 timestamp
+	Context        []string `json:"context,omitempty"`                      // References to definitions, assumptions, externals
+	Dependencies   []string `json:"dependencies,omitempty"`                 // NodeIDs this node depends on
+	ValidationDeps []string `json:"validation_deps,omitempty"`              // NodeIDs that must be validated first
+	Lemmas         []string `json:"lemmas,omitempty"`                       // Lemma IDs cited as justification
+	Tags           []string `json:"tags,omitempty"`                         // Free-form coordination tags
+	Scope          []string `json:"scope,omitempty"`                        // Scope entries active at this node
+	ClaimedBy      string   `json:"claimed_by,omitempty"`                   // Agent ID holding the claim
+	ClaimedAt      string   `json:"claimed_at,omitempty"`                   // When the node was claimed
+	Expired        bool     `json:"expired,omitempty"`                      // Claim timeout has passed; the node can be reaped
+	Depth          int      `json:"depth"`                                  // Depth in the tree (root = 1)
+	SubtreeSettled bool     `json:"subtree_settled,omitempty"`              // Node and all descendants are settled (set by StateToTreeView)
+
+	// Set by StateToTreeViewWithDepth on nodes whose descendants were trimmed.
+	HiddenDescendants int `json:"hidden_descendants,omitempty"` // Number of trimmed descendants
+	HiddenLevels      int `json:"hidden_levels,omitempty"`      // Number of trimmed levels below this node
 }
 
 // Challenge status values for ChallengeView.Status field.
@@ -34,46 +46,72 @@ const (
 
 // ChallengeView is a view model representing a challenge for rendering.
 type ChallengeView struct {
-	ID         string // Unique challenge identifier
-	TargetID   string // Node ID being challenged
-	Target     string // What aspect is challenged (statement, inference, etc.)
-	TargetDesc string // Description of the challenge target
-	Reason     string // Explanation of the challenge
-	Status     string // One of ChallengeStatusOpen, ChallengeStatusResolved, or ChallengeStatusWithdrawn
-	Severity   string // critical, major, minor, note
-	Raised     string // ISO8601 timestamp when raised
-	Resolution string // Resolution text (if resolved)
+	ID         string `json:"id"`                                 // Unique challenge identifier
+	TargetID   string `json:"target_id"`                          // Node ID being challenged
+	Target     string `json:"target"`                             // What aspect is challenged (statement, inference, etc.)
+	TargetDesc string `json:"target_desc,omitempty"`              // Description of the challenge target
+	Reason     string `json:"reason"`                             // Explanation of the challenge
+	Status     string `json:"status"`                             // One of ChallengeStatusOpen, ChallengeStatusResolved, or ChallengeStatusWithdrawn
+	Severity   string `json:"severity" enum:"challenge_severity"` // critical, major, minor, note
+	Raised     string `json:"raised,omitempty"`                   // ISO8601 timestamp when raised
+	Resolution string `json:"resolution,omitempty"`               // Resolution text (if resolved)
 }
 
 // DefinitionView is a view model representing a definition for rendering.
 type DefinitionView struct {
-	ID      string // Unique definition identifier
-	Name    string // Human-readable name
-	Content string // Definition content/body
+	ID      string `json:"id"`      // Unique definition identifier
+	Name    string `json:"name"`    // Human-readable name
+	Content string `json:"content"` // Definition content/body
 }
 
 // AssumptionView is a view model representing an assumption for rendering.
 type AssumptionView struct {
-	ID            string // Unique assumption identifier
-	Statement     string // The assumption statement
-	Justification string // Why this assumption is made
+	ID            string `json:"id"`                      // Unique assumption identifier
+	Statement     string `json:"statement"`               // The assumption statement
+	Justification string `json:"justification,omitempty"` // Why this assumption is made
 }
 
 // ExternalView is a view model representing an external reference for rendering.
 type ExternalView struct {
-	ID     string // Unique external identifier
-	Name   string // Human-readable name
-	Source string // Source reference (book, paper, URL, etc.)
-	Notes  string // Additional notes
+	ID     string `json:"id"`              // Unique external identifier
+	Name   string `json:"name"`            // Human-readable name
+	Source string `json:"source"`          // Source reference (book, paper, URL, etc.)
+	Notes  string `json:"notes,omitempty"` // Additional notes
+}
+
+// LemmaView is a view model representing an extracted lemma for rendering.
+type LemmaView struct {
+	ID           string `json:"id"`                // Unique lemma identifier
+	Statement    string `json:"statement"`         // The lemma statement
+	SourceNodeID string `json:"source_node_id"`    // Node the lemma was extracted from
+	Proof        string `json:"proof,omitempty"`   // Proof text, if any
+	Created      string `json:"created,omitempty"` // ISO8601 timestamp
+}
+
+// StateSnapshotView is a self-contained view of a proof's derived state,
+// written by ProofService.ExportState. Unlike the ledger it holds the
+// result, not the history. Every list is sorted by ID (challenges by node
+// ID, then ID), so snapshots of the same state are identical.
+type StateSnapshotView struct {
+	SchemaVersion int              `json:"schema_version"` // JSONSchemaVersion when written
+	LatestSeq     int              `json:"latest_seq"`     // Sequence number of the last event reflected
+	Nodes         []NodeView       `json:"nodes"`
+	Definitions   []DefinitionView `json:"definitions"`
+	Assumptions   []AssumptionView `json:"assumptions"`
+	Externals     []ExternalView   `json:"externals"`
+	Lemmas        []LemmaView      `json:"lemmas"`
+	Challenges    []ChallengeView  `json:"challenges"`
 }
 
 // JobListView is a view model representing available jobs for rendering.
 type JobListView struct {
-	ProverJobs   []NodeView // Nodes needing prover attention
-	VerifierJobs []NodeView // Nodes ready for verifier review
+	ProverJobs   []NodeView `json:"prover_jobs"`   // Nodes needing prover attention
+	VerifierJobs []NodeView `json:"verifier_jobs"` // Nodes ready for verifier review
+	Reclaimable  []NodeView `json:"reclaimable"`   // Claimed nodes whose claim has expired
 }
 
 // IsEmpty returns true if there are no jobs of either type.
+// Reclaimable claims are not jobs and do not count.
 func (j *JobListView) IsEmpty() bool {
 	return len(j.ProverJobs) == 0 && len(j.VerifierJobs) == 0
 }
@@ -83,47 +121,345 @@ func (j *JobListView) TotalCount() int {
 	return len(j.ProverJobs) + len(j.VerifierJobs)
 }
 
+// SeverityCountsView is a view model for the number of open challenges on a
+// node by severity.
+type SeverityCountsView struct {
+	Critical int `json:"critical,omitempty"`
+	Major    int `json:"major,omitempty"`
+	Minor    int `json:"minor,omitempty"`
+	Note     int `json:"note,omitempty"`
+}
+
+// JobView is a view model for one available job in a machine-readable job
+// listing.
+type JobView struct {
+	NodeID         string                 `json:"node_id"`
+	Statement      string                 `json:"statement"`
+	Type           string                 `json:"type"`
+	Depth          int                    `json:"depth"`
+	Dependencies   []DependencyStatusView `json:"dependencies,omitempty"`    // Reference dependencies with status
+	ValidationDeps []DependencyStatusView `json:"validation_deps,omitempty"` // Validation dependencies with status
+	DepsSatisfied  bool                   `json:"deps_satisfied"`            // Every dependency is validated or admitted (true with none)
+	SeverityCounts *SeverityCountsView    `json:"severity_counts,omitempty"` // Open challenges by severity
+	Blocking       bool                   `json:"blocking,omitempty"`        // Has open challenges that block acceptance
+	Recommended    bool                   `json:"recommended,omitempty"`     // First job in priority order
+	PriorityReason string                 `json:"priority_reason,omitempty"` // Why the recommended job comes first
+	ClaimedBy      string                 `json:"claimed_by,omitempty"`
+	Expired        bool                   `json:"expired,omitempty"` // Claim timeout has passed (reclaimable entries only)
+}
+
+// JobsView is a view model for the jobs listing of af jobs --format json,
+// with each list in display order.
+type JobsView struct {
+	ProverJobs   []JobView `json:"prover_jobs"`
+	VerifierJobs []JobView `json:"verifier_jobs"`
+	Reclaimable  []JobView `json:"reclaimable,omitempty"` // Claimed nodes whose claim has expired
+}
+
 // StatusView is a view model for rendering proof status.
 type StatusView struct {
-	Nodes            []NodeView
-	Challenges       []ChallengeView
-	ProverJobCount   int
-	VerifierJobCount int
+	Nodes            []NodeView      `json:"nodes"`
+	Challenges       []ChallengeView `json:"challenges,omitempty"`
+	ProverJobCount   int             `json:"prover_job_count"`
+	VerifierJobCount int             `json:"verifier_job_count"`
+
+	// CompletionPercent is the share of validated or admitted nodes (0-100).
+	CompletionPercent float64 `json:"completion_percent"`
+	// RootProven is true when the root and its transitive dependencies are proven.
+	RootProven bool `json:"root_proven"`
+	// OpenChallenges counts open challenges by severity (critical, major, minor, note).
+	OpenChallenges map[string]int `json:"open_challenges"`
+	// BlockedNodes is the number of nodes with an open critical or major challenge.
+	BlockedNodes int `json:"blocked_nodes"`
 }
 
 // ProverContextView is a view model for rendering prover context.
 type ProverContextView struct {
-	Node        NodeView
-	Parent      *NodeView        // nil if root
-	Siblings    []NodeView       // sibling nodes
-	Dependencies []NodeView      // dependency nodes
-	Definitions []DefinitionView // definitions in scope
-	Assumptions []AssumptionView // assumptions in scope
-	Externals   []ExternalView   // externals in scope
-	Challenges  []ChallengeView  // challenges on this node
+	Node         NodeView         `json:"node"`
+	Parent       *NodeView        `json:"parent,omitempty"`       // nil if root
+	Siblings     []NodeView       `json:"siblings,omitempty"`     // sibling nodes
+	Dependencies []NodeView       `json:"dependencies,omitempty"` // dependency nodes
+	Definitions  []DefinitionView `json:"definitions,omitempty"`  // definitions in scope
+	Assumptions  []AssumptionView `json:"assumptions,omitempty"`  // assumptions in scope
+	Externals    []ExternalView   `json:"externals,omitempty"`    // externals in scope
+	Challenges   []ChallengeView  `json:"challenges,omitempty"`   // challenges on this node
 }
 
 // VerifierContextView is a view model for rendering verifier context.
 type VerifierContextView struct {
-	Challenge   ChallengeView
-	Node        NodeView          // The challenged node
-	Parent      *NodeView         // Parent of challenged node (nil if root)
-	Siblings    []NodeView        // Sibling nodes
-	Dependencies []NodeView       // Dependency nodes
-	Definitions []DefinitionView  // definitions in scope
-	Assumptions []AssumptionView  // assumptions in scope
-	Externals   []ExternalView    // externals in scope
+	Challenge    ChallengeView    `json:"challenge"`
+	Node         NodeView         `json:"node"`                   // The challenged node
+	Parent       *NodeView        `json:"parent,omitempty"`       // Parent of challenged node (nil if root)
+	Siblings     []NodeView       `json:"siblings,omitempty"`     // Sibling nodes
+	Dependencies []NodeView       `json:"dependencies,omitempty"` // Dependency nodes
+	Definitions  []DefinitionView `json:"definitions,omitempty"`  // definitions in scope
+	Assumptions  []AssumptionView `json:"assumptions,omitempty"`  // assumptions in scope
+	Externals    []ExternalView   `json:"externals,omitempty"`    // externals in scope
 }
 
 // TreeView is a view model for rendering a proof tree.
 type TreeView struct {
-	Root       *NodeView   // The root node to render (nil renders all roots)
-	Nodes      []NodeView  // All nodes in the tree
-	NodeLookup map[string]NodeView // Quick lookup by ID string
+	Root       *NodeView           `json:"root,omitempty"` // The root node to render (nil renders all roots)
+	Nodes      []NodeView          `json:"nodes"`          // All nodes in the tree
+	NodeLookup map[string]NodeView `json:"-"`              // Quick lookup by ID string
 }
 
 // SearchResultView is a view model representing a node match from a search query.
 type SearchResultView struct {
-	Node        NodeView
-	MatchReason string // Describes why this node matched
+	Node        NodeView `json:"node"`
+	MatchReason string   `json:"match_reason"` // Describes why this node matched
+}
+
+// SearchMatchView is a compact view model for a node matched by a text search.
+// Snippet is an excerpt of the matched field; the matched text is
+// Snippet[MatchStart:MatchEnd], which is empty when no text query was given.
+type SearchMatchView struct {
+	ID             string `json:"id"`
+	EpistemicState string `json:"epistemic_state" enum:"epistemic_state"`
+	WorkflowState  string `json:"workflow_state" enum:"workflow_state"`
+	Field          string `json:"field"`                  // statement or latex
+	Snippet        string `json:"snippet"`                // Excerpt around the match
+	MatchStart     int    `json:"match_start"`            // Byte offset of the match in Snippet
+	MatchEnd       int    `json:"match_end"`              // Byte offset just past the match
+	MatchReason    string `json:"match_reason,omitempty"` // Describes why this node matched
+}
+
+// SearchResultsView is a view model for a list of search matches.
+type SearchResultsView struct {
+	Results []SearchMatchView `json:"results"`
+	Total   int               `json:"total"`
+}
+
+// DependencyStatusView is a view model for a dependency reference and the
+// current state of the node it points at.
+type DependencyStatusView struct {
+	ID             string `json:"id"`                                               // Dependency node ID
+	Exists         bool   `json:"exists"`                                           // False if the referenced node is missing
+	EpistemicState string `json:"epistemic_state,omitempty" enum:"epistemic_state"` // Current epistemic state (empty if missing)
+	Statement      string `json:"statement,omitempty"`                              // Dependency statement (empty if missing)
+}
+
+// Satisfied returns true if the dependency exists and is validated or admitted.
+func (d DependencyStatusView) Satisfied() bool {
+	return d.Exists && (d.EpistemicState == "validated" || d.EpistemicState == "admitted")
+}
+
+// ChallengeGroupView is a view model for challenges of a single severity.
+type ChallengeGroupView struct {
+	Severity   string          `json:"severity" enum:"challenge_severity"` // critical, major, minor, note
+	Blocking   bool            `json:"blocking"`                           // True if this severity blocks acceptance
+	Challenges []ChallengeView `json:"challenges"`                         // Challenges with this severity, sorted by ID
+}
+
+// NodeDetailView is a view model for rendering the complete record of a single node.
+type NodeDetailView struct {
+	Node            NodeView               `json:"node"`
+	Dependencies    []DependencyStatusView `json:"dependencies,omitempty"`            // Reference dependencies with status
+	ValidationDeps  []DependencyStatusView `json:"validation_deps,omitempty"`         // Validation dependencies with status
+	TransitiveDeps  []DependencyStatusView `json:"transitive_dependencies,omitempty"` // Every node reached through Dependencies, sorted by ID
+	DependencyCycle bool                   `json:"dependency_cycle,omitempty"`        // The walk for TransitiveDeps met a cycle
+	OpenChallenges  []ChallengeGroupView   `json:"open_challenges,omitempty"`         // Open challenges grouped by severity
+	TotalChallenges int                    `json:"total_challenges"`                  // All challenges, including closed ones
+	Notes           []NoteView             `json:"notes,omitempty"`                   // Informational notes, oldest first
+	History         []NodeHistoryEntryView `json:"history,omitempty"`                 // Ledger events referencing the node, oldest first
+}
+
+// NoteView is a view model for an informational note left on a node.
+type NoteView struct {
+	ID      string `json:"id"`
+	Author  string `json:"author"`
+	Text    string `json:"text"`
+	Created string `json:"created"` // RFC3339 timestamp when the note was added
+}
+
+// NodeHistoryEntryView is a view model for one ledger event in a node's timeline.
+type NodeHistoryEntryView struct {
+	Seq       int    `json:"seq"`
+	Timestamp string `json:"timestamp"` // RFC3339 timestamp of the event
+	Type      string `json:"type"`
+	Summary   string `json:"summary"`
+}
+
+// FieldChangeView is a view model for a change to one state field of a node.
+type FieldChangeView struct {
+	NodeID string `json:"node_id"`
+	Field  string `json:"field"` // workflow_state, epistemic_state, or taint_state
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// StateDiffView is a view model for the node changes between two ledger
+// sequence numbers.
+type StateDiffView struct {
+	FromSeq int               `json:"from_seq"`
+	ToSeq   int               `json:"to_seq"`
+	Added   []NodeView        `json:"added,omitempty"`   // Nodes created in the range
+	Removed []NodeView        `json:"removed,omitempty"` // Nodes archived in the range
+	Changed []FieldChangeView `json:"changed,omitempty"` // Per-field changes to existing nodes
+}
+
+// IsEmpty returns true if the diff contains no changes.
+func (v StateDiffView) IsEmpty() bool {
+	return len(v.Added) == 0 && len(v.Removed) == 0 && len(v.Changed) == 0
+}
+
+// StatusDeltaView is a view model for what changed in a proof after a ledger
+// sequence number: nodes created, nodes validated, and challenges raised.
+type StatusDeltaView struct {
+	SinceSeq         int             `json:"since_seq"`
+	LatestSeq        int             `json:"latest_seq"`
+	Created          []NodeView      `json:"created"`           // Nodes created after SinceSeq
+	Validated        []NodeView      `json:"validated"`         // Nodes validated after SinceSeq
+	ChallengesRaised []ChallengeView `json:"challenges_raised"` // Challenges raised after SinceSeq
+}
+
+// IsEmpty returns true if no node was created or validated and no challenge
+// was raised.
+func (v StatusDeltaView) IsEmpty() bool {
+	return len(v.Created) == 0 && len(v.Validated) == 0 && len(v.ChallengesRaised) == 0
+}
+
+// StatusGroupView is a view model for one section of the detailed status:
+// the IDs of the nodes in one state, sorted by node ID.
+type StatusGroupView struct {
+	Name    string   `json:"name"` // Section name (e.g., "Validated")
+	Count   int      `json:"count"`
+	NodeIDs []string `json:"node_ids"`
+}
+
+// StatusGroupsView is a view model for the nodes of a proof grouped into
+// Validated, Admitted, Refuted, Pending and Claimed sections, in that order.
+type StatusGroupsView struct {
+	Groups []StatusGroupView `json:"groups"`
+}
+
+// EventLogEntryView is a view model for one ledger event in the event log.
+type EventLogEntryView struct {
+	Seq       int      `json:"seq"`
+	Type      string   `json:"type"`               // Event type as stored (e.g., "node_created")
+	Timestamp string   `json:"timestamp"`          // RFC3339 timestamp, empty if the event has none
+	Summary   string   `json:"summary"`            // Type-specific one-line description
+	NodeIDs   []string `json:"node_ids,omitempty"` // Nodes the event references
+}
+
+// EventLogView is a view model for a sequence of ledger events.
+type EventLogView struct {
+	Entries []EventLogEntryView `json:"entries"`
+}
+
+// GraphEdgeView is a view model for a dependency graph edge, from the
+// dependent node to the node it depends on.
+type GraphEdgeView struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"` // "dependency" or "validation"
+}
+
+// DependencyGraphView is a view model for rendering the proof dependency graph.
+type DependencyGraphView struct {
+	Nodes  []NodeView      `json:"nodes"`  // All nodes in the proof
+	Edges  []GraphEdgeView `json:"edges"`  // Dependencies and validation dependencies
+	Cycles [][]string      `json:"cycles"` // Dependency cycles, each closing on its first node
+}
+
+// NodeStatusRowView is a view model for one node's status in a flat,
+// spreadsheet-style table.
+type NodeStatusRowView struct {
+	ID              string `json:"id"`
+	Depth           int    `json:"depth"`
+	Type            string `json:"type"`
+	Inference       string `json:"inference"`
+	WorkflowState   string `json:"workflow_state" enum:"workflow_state"`
+	EpistemicState  string `json:"epistemic_state" enum:"epistemic_state"`
+	TaintState      string `json:"taint_state" enum:"taint_state"`
+	NumDependencies int    `json:"num_dependencies"` // Reference dependencies
+	NumChildren     int    `json:"num_children"`     // Direct children only
+	ClaimedBy       string `json:"claimed_by,omitempty"`
+	OpenChallenges  int    `json:"open_challenges"`
+	Statement       string `json:"statement"`
+}
+
+// NodeStatusTableView is a view model for the status of every node,
+// one row per node sorted by ID.
+type NodeStatusTableView struct {
+	Rows []NodeStatusRowView `json:"rows"`
+}
+
+// NodeListItemView is a view model for one line of the compact node list.
+type NodeListItemView struct {
+	ID             string `json:"id"`
+	Depth          int    `json:"depth"`
+	WorkflowState  string `json:"workflow_state" enum:"workflow_state"`
+	EpistemicState string `json:"epistemic_state" enum:"epistemic_state"`
+	TaintState     string `json:"taint_state" enum:"taint_state"`
+	ClaimedBy      string `json:"claimed_by,omitempty"`
+	Statement      string `json:"statement"`
+}
+
+// NodeListView is a view model for the compact one-line-per-node listing,
+// with nodes in display order.
+type NodeListView struct {
+	Nodes []NodeListItemView `json:"nodes"`
+}
+
+// AgentActivityView is a view model for one agent's actions in a proof.
+type AgentActivityView struct {
+	Agent            string `json:"agent"`
+	Claims           int    `json:"claims"`
+	Refinements      int    `json:"refinements"`
+	ChallengesRaised int    `json:"challenges_raised"`
+	Acceptances      int    `json:"acceptances"`
+	Total            int    `json:"total"`
+	FirstAction      string `json:"first_action"` // RFC3339 timestamp of the agent's first counted action
+	LastAction       string `json:"last_action"`  // RFC3339 timestamp of the agent's last counted action
+}
+
+// NodeClaimsView is a view model for how long a node was held across its
+// claims.
+type NodeClaimsView struct {
+	NodeID      string `json:"node_id"`
+	Claims      int    `json:"claims"`
+	TotalHeld   string `json:"total_held"`   // Go duration, rounded to the second
+	LongestHeld string `json:"longest_held"` // Go duration, rounded to the second
+}
+
+// ActivityView is a view model for per-agent activity, most active agent
+// first, optionally followed by claim times per node, most claimed first.
+type ActivityView struct {
+	Agents []AgentActivityView `json:"agents"`
+	Nodes  []NodeClaimsView    `json:"nodes,omitempty"`
+}
+
+// InvariantViolationView is a view model for one structural problem in a proof.
+type InvariantViolationView struct {
+	Kind     string   `json:"kind"`
+	Severity string   `json:"severity"` // "critical" or "warning"
+	NodeIDs  []string `json:"node_ids,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// ValidationReportView is a view model for the result of checking a proof's
+// structural invariants. Valid is false if any violation is critical.
+type ValidationReportView struct {
+	Valid         bool                     `json:"valid"`
+	EventsChecked int                      `json:"events_checked"`
+	NodesChecked  int                      `json:"nodes_checked"`
+	Violations    []InvariantViolationView `json:"violations"`
+}
+
+// LedgerIssueView is a view model for one problem found while verifying a ledger.
+type LedgerIssueView struct {
+	Kind    string `json:"kind"` // sequence_gap, chain_broken, content_hash_mismatch, invalid_event
+	Seq     int    `json:"seq"`  // Offending event, or first missing sequence for a gap
+	NodeID  string `json:"node_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// LedgerVerificationView is a view model for the result of verifying a
+// ledger event by event. OK is true if no issues were found.
+type LedgerVerificationView struct {
+	OK            bool              `json:"ok"`
+	EventsChecked int               `json:"events_checked"`
+	Chained       bool              `json:"chained"` // Ledger carries a prev_hash chain
+	Issues        []LedgerIssueView `json:"issues"`
 }
@@ -0,0 +1,221 @@
+// Package render provides terminal-width handling for AF framework types.
+package render
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultTerminalWidth is the width assumed when output is not a terminal or
+// the terminal's size cannot be detected.
+const defaultTerminalWidth = 80
+
+// minWrapWidth is the fewest statement characters per line the renderers
+// wrap to, however deeply a statement is indented.
+const minWrapWidth = 10
+
+// TerminalWidth returns the width to fit output written to out into: $COLUMNS
+// if it is set to a positive number, the terminal's width if out is a
+// terminal whose size can be detected, and defaultTerminalWidth otherwise.
+func TerminalWidth(out io.Writer) int {
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	if f, ok := out.(*os.File); ok && IsTerminal(out) {
+		if cols := terminalColumns(f); cols > 0 {
+			return cols
+		}
+	}
+	return defaultTerminalWidth
+}
+
+// ansiEscapeLen returns the length in bytes of the ANSI escape sequence
+// (ESC [ parameters final-byte) at the start of s, or 0 if s doesn't start
+// with one. An unterminated sequence runs to the end of s.
+func ansiEscapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// Ellipsis marks text cut short by truncateRunes.
+const Ellipsis = "..."
+
+// wideRanges lists the East Asian wide and fullwidth code points, which
+// terminals draw two columns wide.
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi radicals, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi syllables and radicals
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Pictographs and emoticons
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x20000, 0x3FFFD}, // CJK unified ideographs extensions B and beyond
+}
+
+// runeWidth returns the number of terminal columns r occupies: 2 for wide
+// East Asian characters, 0 for combining marks, and 1 otherwise. Mathematical
+// symbols such as ∀ and → are one column wide.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me) {
+		return 0
+	}
+	for _, w := range wideRanges {
+		if r < w.lo {
+			break
+		}
+		if r <= w.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// visibleWidth returns the number of columns s occupies on a terminal:
+// the widths of its runes, not counting ANSI escape sequences such as color
+// codes. Alignment and truncation must use it instead of len or rune
+// counts, which overcount colored text and undercount wide characters.
+func visibleWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := ansiEscapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// visibleCut returns the byte offset in s just past its longest prefix at
+// most width columns wide, together with any escape sequences that directly
+// follow it, so that cutting s there never splits a rune or a sequence or
+// drops a trailing reset. A wide rune that would straddle width is left out.
+func visibleCut(s string, width int) int {
+	i := 0
+	for i < len(s) {
+		if n := ansiEscapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runeWidth(r)
+		if w > width {
+			break
+		}
+		i += size
+		width -= w
+	}
+	return i
+}
+
+// visibleTailCut returns the byte offset in s at which its longest suffix
+// at most width columns wide starts. It is the counterpart of visibleCut
+// for keeping the end of s, and likewise never splits a rune.
+func visibleTailCut(s string, width int) int {
+	i := len(s)
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		w := runeWidth(r)
+		if w > width {
+			break
+		}
+		i -= size
+		width -= w
+	}
+	return i
+}
+
+// truncateRunes truncates s to at most max columns, ending it with Ellipsis
+// if anything was cut. See truncateWith.
+func truncateRunes(s string, max int) string {
+	return truncateWith(s, max, Ellipsis)
+}
+
+// truncateWith truncates s to at most max columns, ending it with ellipsis
+// if anything was cut. It cuts on rune boundaries, counts wide runes as two
+// columns, and ignores color codes, which are closed before the ellipsis.
+// If max leaves no room for the ellipsis, the ellipsis alone is returned.
+func truncateWith(s string, max int, ellipsis string) string {
+	if visibleWidth(s) <= max {
+		return s
+	}
+	room := max - visibleWidth(ellipsis)
+	if room <= 0 {
+		return ellipsis
+	}
+	cut := visibleCut(s, room)
+	if strings.Contains(s[:cut], "\x1b[") && !strings.HasSuffix(s[:cut], ansiReset) {
+		// Don't let color cut off with the rest of s bleed into the ellipsis
+		return s[:cut] + ansiReset + ellipsis
+	}
+	return s[:cut] + ellipsis
+}
+
+// wrapText splits text into lines of at most width columns,
+// breaking at spaces. A word longer than width is broken across lines. Runs
+// of whitespace are collapsed; a width of 0 or less returns text as a single
+// line.
+func wrapText(text string, width int) []string {
+	text = sanitizeStatement(text)
+	if width <= 0 || visibleWidth(text) <= width {
+		return []string{text}
+	}
+
+	var lines []string
+	var line string
+	lineWidth := 0
+	for _, word := range strings.Fields(text) {
+		wordWidth := visibleWidth(word)
+		if line != "" && lineWidth+1+wordWidth <= width {
+			line += " " + word
+			lineWidth += 1 + wordWidth
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		// Break a word that does not fit on a line of its own
+		for wordWidth > width {
+			// A wide rune always fits on a line of two columns
+			cut := visibleCut(word, max(width, 2))
+			lines = append(lines, word[:cut])
+			wordWidth -= visibleWidth(word[:cut])
+			word = word[cut:]
+		}
+		line = word
+		lineWidth = wordWidth
+	}
+	return append(lines, line)
+}
+
+// writeWrapped writes text wrapped to width characters, putting indent before
+// every line but the first. Each line ends with a newline.
+func writeWrapped(sb *strings.Builder, text string, width int, indent string) {
+	for i, line := range wrapText(text, width) {
+		if i > 0 {
+			sb.WriteString(indent)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+}
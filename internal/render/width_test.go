@@ -0,0 +1,170 @@
+package render
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWrapText(t *testing.T) {
+	statement := "For every natural number n greater than zero the sum of the first n odd numbers equals n squared"
+
+	got := wrapText(statement, 40)
+	want := []string{
+		"For every natural number n greater than",
+		"zero the sum of the first n odd numbers",
+		"equals n squared",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText(width 40) = %q, want %q", got, want)
+	}
+
+	// Words longer than the width are broken across lines
+	got = wrapText("see abcdefghijklmnopqrstuvwxyz now", 10)
+	want = []string{"see", "abcdefghij", "klmnopqrst", "uvwxyz now"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText(long word) = %q, want %q", got, want)
+	}
+
+	// Width 0 disables wrapping but still collapses whitespace
+	if got := wrapText("  two\nlines  ", 0); !reflect.DeepEqual(got, []string{"two lines"}) {
+		t.Errorf("wrapText(width 0) = %q", got)
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	plain := "[validated] x ≥ 0"
+	colored := "[" + ansiGreen + "validated" + ansiReset + "] " + ansiBold + "x ≥ 0" + ansiReset
+
+	if got, want := visibleWidth(colored), visibleWidth(plain); got != want {
+		t.Errorf("visibleWidth(colored) = %d, want plain width %d", got, want)
+	}
+	if got := visibleWidth(plain); got != 17 {
+		t.Errorf("visibleWidth(%q) = %d, want 17", plain, got)
+	}
+	if got := visibleWidth(""); got != 0 {
+		t.Errorf("visibleWidth(\"\") = %d, want 0", got)
+	}
+
+	// Padding counts only visible characters, so colored columns align
+	if got := padRight(ansiRed+"1.2"+ansiReset, 5); got != ansiRed+"1.2"+ansiReset+"  " {
+		t.Errorf("padRight(colored) = %q", got)
+	}
+}
+
+func TestWrapText_Colored(t *testing.T) {
+	word := ansiRed + "refuted" + ansiReset
+	got := wrapText("the claim is "+word+" by 1.3", 20)
+	want := []string{"the claim is " + word, "by 1.3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText(colored) = %q, want %q", got, want)
+	}
+
+	// Escape sequences are not split when a long word is broken
+	got = wrapText(ansiRed+"abcdefgh"+ansiReset, 4)
+	want = []string{ansiRed + "abcd", "efgh" + ansiReset}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText(colored long word) = %q, want %q", got, want)
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+	if got := TerminalWidth(&bytes.Buffer{}); got != 120 {
+		t.Errorf("TerminalWidth with COLUMNS=120 = %d, want 120", got)
+	}
+
+	t.Setenv("COLUMNS", "")
+	if got := TerminalWidth(&bytes.Buffer{}); got != defaultTerminalWidth {
+		t.Errorf("TerminalWidth of a non-terminal = %d, want %d", got, defaultTerminalWidth)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	const statement = "∀x. P(x) → Q(x)" // 15 columns, 19 bytes
+
+	for max := 0; max <= visibleWidth(statement)+1; max++ {
+		got := truncateRunes(statement, max)
+		if !utf8.ValidString(got) {
+			t.Fatalf("truncateRunes(%q, %d) = %q, which splits a rune", statement, max, got)
+		}
+		if max >= visibleWidth(statement) {
+			if got != statement {
+				t.Errorf("truncateRunes(%q, %d) = %q, want it unchanged", statement, max, got)
+			}
+			continue
+		}
+		if !strings.HasSuffix(got, Ellipsis) || !strings.HasPrefix(statement, strings.TrimSuffix(got, Ellipsis)) {
+			t.Errorf("truncateRunes(%q, %d) = %q, want a prefix followed by %q", statement, max, got, Ellipsis)
+		}
+		if max >= len(Ellipsis) && visibleWidth(got) > max {
+			t.Errorf("truncateRunes(%q, %d) = %q is %d columns wide", statement, max, got, visibleWidth(got))
+		}
+	}
+
+	for _, tt := range []struct {
+		max  int
+		want string
+	}{
+		{4, "∀..."},
+		{6, "∀x...."},
+		{10, "∀x. P(x..."},
+		{11, "∀x. P(x)..."},
+		{13, "∀x. P(x) →..."},
+	} {
+		if got := truncateRunes(statement, tt.max); got != tt.want {
+			t.Errorf("truncateRunes(%q, %d) = %q, want %q", statement, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateRunes_Wide(t *testing.T) {
+	// Each ideograph is two columns wide, so only whole ideographs that fit
+	// in the room left by the ellipsis are kept
+	const text = "定理：素数は無限に存在する"
+	if got := visibleWidth(text); got != 26 {
+		t.Errorf("visibleWidth(%q) = %d, want 26", text, got)
+	}
+	if got, want := truncateRunes(text, 10), "定理："+Ellipsis; got != want {
+		t.Errorf("truncateRunes(%q, 10) = %q, want %q", text, got, want)
+	}
+	if got, want := truncateRunes(text, 9), "定理："+Ellipsis; got != want {
+		t.Errorf("truncateRunes(%q, 9) = %q, want %q", text, got, want)
+	}
+	if got, want := truncateRunes(text, 8), "定理"+Ellipsis; got != want {
+		t.Errorf("truncateRunes(%q, 8) = %q, want %q", text, got, want)
+	}
+
+	// Combining marks take no column of their own
+	if got := visibleWidth("e\u0301"); got != 1 {
+		t.Errorf("visibleWidth(e + combining acute) = %d, want 1", got)
+	}
+}
+
+func TestTruncateWith(t *testing.T) {
+	if got, want := truncateWith("∀x. P(x) → Q(x)", 10, "…"), "∀x. P(x) …"; got != want {
+		t.Errorf("truncateWith(…) = %q, want %q", got, want)
+	}
+	if got := truncateWith("hello world", 1, " [more]"); got != " [more]" {
+		t.Errorf("truncateWith(no room) = %q, want the ellipsis alone", got)
+	}
+}
+
+func TestSearchSnippet_Unicode(t *testing.T) {
+	text := strings.Repeat("∀ε>0 ∃δ>0 ", 5) + "continuity" + strings.Repeat(" ∀x∈ℝ", 10)
+	snippet, start, end := searchSnippet(text, "continuity", true)
+	if !utf8.ValidString(snippet) {
+		t.Fatalf("snippet %q splits a rune", snippet)
+	}
+	if snippet[start:end] != "continuity" {
+		t.Errorf("match = %q, want continuity", snippet[start:end])
+	}
+	before := strings.TrimPrefix(snippet[:start], Ellipsis)
+	after := strings.TrimSuffix(snippet[end:], Ellipsis)
+	if visibleWidth(before) != searchSnippetContext || visibleWidth(after) != searchSnippetContext {
+		t.Errorf("snippet %q keeps %d and %d columns of context, want %d on each side",
+			snippet, visibleWidth(before), visibleWidth(after), searchSnippetContext)
+	}
+}
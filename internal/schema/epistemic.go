@@ -169,3 +169,18 @@ func IntroducesTaint(s EpistemicState) bool {
 	}
 	return info.IntroducesTaint
 }
+
+// CanReopen returns true if a node in the given epistemic state may be
+// reopened (returned to pending). Only refuted and archived nodes can be
+// reopened; this is the sole way out of those otherwise terminal states.
+func CanReopen(s EpistemicState) bool {
+	return s == EpistemicRefuted || s == EpistemicArchived
+}
+
+// CanRevertToPending returns true if a node_reopened event may return a node
+// in the given epistemic state to pending. Besides the states CanReopen
+// allows, this includes validated and admitted so that an undo can revert
+// an acceptance or admission with a forward event.
+func CanRevertToPending(s EpistemicState) bool {
+	return CanReopen(s) || s == EpistemicValidated || s == EpistemicAdmitted
+}
@@ -418,3 +418,25 @@ func TestIntroducesTaint_InvalidState(t *testing.T) {
 		t.Error("IntroducesTaint(invalid) returned true, want false")
 	}
 }
+
+func TestCanReopen(t *testing.T) {
+	tests := []struct {
+		state EpistemicState
+		want  bool
+	}{
+		{EpistemicRefuted, true},
+		{EpistemicArchived, true},
+		{EpistemicPending, false},
+		{EpistemicValidated, false},
+		{EpistemicAdmitted, false},
+		{EpistemicNeedsRefinement, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			if got := CanReopen(tt.state); got != tt.want {
+				t.Errorf("CanReopen(%q) = %v, want %v", tt.state, got, tt.want)
+			}
+		})
+	}
+}
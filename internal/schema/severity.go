@@ -80,6 +80,56 @@ func SeverityBlocksAcceptance(severity ChallengeSeverity) bool {
 	return info.BlocksAcceptance
 }
 
+// SeverityBlocksAcceptanceWith returns true if challenges with the given
+// severity block node acceptance when the severities in blocking are the
+// ones configured as blocking. An empty severity is treated as
+// DefaultChallengeSeverity; unknown severities always block (fail-safe).
+func SeverityBlocksAcceptanceWith(severity ChallengeSeverity, blocking []string) bool {
+	if severity == "" {
+		severity = DefaultChallengeSeverity()
+	}
+	if _, exists := challengeSeverityRegistry[severity]; !exists {
+		return true
+	}
+	for _, b := range blocking {
+		if ChallengeSeverity(b) == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// severityOrder lists the valid severities, most severe first.
+var severityOrder = []ChallengeSeverity{SeverityCritical, SeverityMajor, SeverityMinor, SeverityNote}
+
+// SeverityRank returns the rank of a severity for ordering challenges, most
+// severe first: 0 for critical, 1 for major, 2 for minor, and 3 for note.
+// An empty severity is treated as DefaultChallengeSeverity; unknown
+// severities rank with critical (fail-safe, as in SeverityBlocksAcceptance).
+func SeverityRank(severity ChallengeSeverity) int {
+	if severity == "" {
+		severity = DefaultChallengeSeverity()
+	}
+	for i, s := range severityOrder {
+		if s == severity {
+			return i
+		}
+	}
+	return 0
+}
+
+// DefaultBlockingSeverities returns the severities that block acceptance
+// unless a proof configures its own, most severe first.
+func DefaultBlockingSeverities() []string {
+	var result []string
+	for _, s := range severityOrder {
+		if challengeSeverityRegistry[s].BlocksAcceptance {
+			result = append(result, string(s))
+		}
+	}
+	return result
+}
+
 // GetChallengeSeverityInfo returns metadata for a given challenge severity.
 // The boolean return value indicates whether the severity exists.
 func GetChallengeSeverityInfo(s ChallengeSeverity) (ChallengeSeverityInfo, bool) {
@@ -99,6 +99,58 @@ func TestChallengeSeverityBlocksAcceptance(t *testing.T) {
 	}
 }
 
+// TestSeverityBlocksAcceptanceWith tests blocking against a configured set.
+func TestSeverityBlocksAcceptanceWith(t *testing.T) {
+	onlyCritical := []string{"critical"}
+	withMinor := []string{"critical", "major", "minor"}
+
+	tests := []struct {
+		severity schema.ChallengeSeverity
+		blocking []string
+		want     bool
+	}{
+		{schema.SeverityCritical, onlyCritical, true},
+		{schema.SeverityMajor, onlyCritical, false},
+		{"", onlyCritical, false}, // empty means the default, major
+		{schema.SeverityMinor, withMinor, true},
+		{schema.SeverityNote, withMinor, false},
+		{"", withMinor, true},
+		{"bogus", nil, true}, // unknown severities always block
+	}
+
+	for _, tc := range tests {
+		if got := schema.SeverityBlocksAcceptanceWith(tc.severity, tc.blocking); got != tc.want {
+			t.Errorf("SeverityBlocksAcceptanceWith(%q, %v) = %v, want %v", tc.severity, tc.blocking, got, tc.want)
+		}
+	}
+
+	defaults := schema.DefaultBlockingSeverities()
+	if len(defaults) != 2 || defaults[0] != "critical" || defaults[1] != "major" {
+		t.Errorf("DefaultBlockingSeverities() = %v, want [critical major]", defaults)
+	}
+}
+
+// TestSeverityRank tests that severities rank most severe first.
+func TestSeverityRank(t *testing.T) {
+	tests := []struct {
+		severity schema.ChallengeSeverity
+		want     int
+	}{
+		{schema.SeverityCritical, 0},
+		{schema.SeverityMajor, 1},
+		{schema.SeverityMinor, 2},
+		{schema.SeverityNote, 3},
+		{"", 1},      // empty means the default, major
+		{"bogus", 0}, // unknown severities rank with critical
+	}
+
+	for _, tc := range tests {
+		if got := schema.SeverityRank(tc.severity); got != tc.want {
+			t.Errorf("SeverityRank(%q) = %d, want %d", tc.severity, got, tc.want)
+		}
+	}
+}
+
 // TestAllChallengeSeverities returns all valid severity levels.
 func TestAllChallengeSeverities(t *testing.T) {
 	severities := schema.AllChallengeSeverities()
@@ -99,6 +99,27 @@ func (t *Tracker) GetScope(nodeID types.NodeID) *Entry {
 	return t.scopes[nodeID.String()]
 }
 
+// RestoreScope adds an existing scope entry (active or closed) as-is.
+// This is used when rebuilding a tracker from a snapshot; unlike OpenScope
+// it preserves the entry's timestamps and discharge state.
+// Returns an error if the entry is nil or a scope already exists for its node.
+func (t *Tracker) RestoreScope(entry *Entry) error {
+	if entry == nil {
+		return errors.New("cannot restore nil scope entry")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := entry.NodeID.String()
+	if _, exists := t.scopes[key]; exists {
+		return errors.New("scope already exists for node " + key)
+	}
+
+	t.scopes[key] = entry
+	return nil
+}
+
 // AllScopes returns all scope entries (both active and closed).
 func (t *Tracker) AllScopes() []*Entry {
 	t.mu.RLock()
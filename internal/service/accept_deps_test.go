@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// setupAcceptDepsTest creates a proof where 1.2 depends on the pending node 1.1.
+func setupAcceptDepsTest(t *testing.T) *ProofService {
+	t.Helper()
+	svc, _ := setupTestProof(t)
+
+	rootID := parseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNode(rootID, "prover", parseNodeID(t, "1.1"), schema.NodeTypeClaim, "Lemma", schema.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNodeWithDeps(rootID, "prover", parseNodeID(t, "1.2"), schema.NodeTypeClaim, "Uses lemma", schema.InferenceModusPonens, []types.NodeID{parseNodeID(t, "1.1")}); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestAcceptNode_RejectsPendingDependency(t *testing.T) {
+	svc := setupAcceptDepsTest(t)
+
+	err := svc.AcceptNode(parseNodeID(t, "1.2"))
+	if !errors.Is(err, ErrUnvalidatedDependencies) {
+		t.Fatalf("AcceptNode error = %v, want ErrUnvalidatedDependencies", err)
+	}
+	if !strings.Contains(err.Error(), "1.1") {
+		t.Errorf("error %q should name the offending dependency 1.1", err)
+	}
+}
+
+func TestAcceptNode_AllowsSettledDependency(t *testing.T) {
+	tests := []struct {
+		name   string
+		settle func(svc *ProofService, id types.NodeID) error
+	}{
+		{"validated", func(svc *ProofService, id types.NodeID) error { return svc.AcceptNode(id) }},
+		{"admitted", func(svc *ProofService, id types.NodeID) error { return svc.AdmitNode(id) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := setupAcceptDepsTest(t)
+			if err := tt.settle(svc, parseNodeID(t, "1.1")); err != nil {
+				t.Fatalf("settling dependency failed: %v", err)
+			}
+			if err := svc.AcceptNode(parseNodeID(t, "1.2")); err != nil {
+				t.Errorf("AcceptNode with %s dependency failed: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestAcceptNode_DependencyGateOptOut(t *testing.T) {
+	svc := setupAcceptDepsTest(t)
+	svc.SetRequireValidatedDependencies(false)
+
+	if err := svc.AcceptNode(parseNodeID(t, "1.2")); err != nil {
+		t.Fatalf("AcceptNode with gate disabled failed: %v", err)
+	}
+
+	svc.SetRequireValidatedDependencies(true)
+	if err := svc.AcceptNode(parseNodeID(t, "1.1")); err != nil {
+		t.Fatalf("AcceptNode of dependency-free node failed: %v", err)
+	}
+}
+
+func TestAcceptNodeBulk_DependencyGate(t *testing.T) {
+	svc := setupAcceptDepsTest(t)
+
+	err := svc.AcceptNodeBulk([]types.NodeID{parseNodeID(t, "1.2")})
+	if !errors.Is(err, ErrUnvalidatedDependencies) {
+		t.Fatalf("AcceptNodeBulk error = %v, want ErrUnvalidatedDependencies", err)
+	}
+
+	// Accepting the dependency in the same batch satisfies the gate
+	if err := svc.AcceptNodeBulk([]types.NodeID{parseNodeID(t, "1.2"), parseNodeID(t, "1.1")}); err != nil {
+		t.Fatalf("AcceptNodeBulk with dependency in batch failed: %v", err)
+	}
+}
+
+// setupValidationDepsTest extends setupAcceptDepsTest with 1.3, which has
+// 1.1 as a validation dependency rather than a plain dependency.
+func setupValidationDepsTest(t *testing.T) *ProofService {
+	t.Helper()
+	svc := setupAcceptDepsTest(t)
+	if err := svc.RefineNodeWithAllDeps(parseNodeID(t, "1"), "prover", parseNodeID(t, "1.3"), schema.NodeTypeClaim, "Needs lemma checked",
+		schema.InferenceModusPonens, nil, []types.NodeID{parseNodeID(t, "1.1")}); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestValidationDepsOf(t *testing.T) {
+	svc := setupValidationDepsTest(t)
+
+	deps, err := svc.ValidationDepsOf(parseNodeID(t, "1.3"))
+	if err != nil {
+		t.Fatalf("ValidationDepsOf failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].String() != "1.1" {
+		t.Errorf("ValidationDepsOf(1.3) = %v, want [1.1]", deps)
+	}
+
+	// Plain dependencies are not validation dependencies
+	if deps, err := svc.ValidationDepsOf(parseNodeID(t, "1.2")); err != nil || len(deps) != 0 {
+		t.Errorf("ValidationDepsOf(1.2) = %v, %v; want none", deps, err)
+	}
+
+	if _, err := svc.ValidationDepsOf(parseNodeID(t, "1.9")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("ValidationDepsOf(1.9) error = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestAcceptNode_ValidationDepMustBeValidated(t *testing.T) {
+	svc := setupValidationDepsTest(t)
+	if err := svc.AdmitNode(parseNodeID(t, "1.1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// An admitted plain dependency is enough
+	if err := svc.AcceptNode(parseNodeID(t, "1.2")); err != nil {
+		t.Errorf("AcceptNode with admitted plain dependency failed: %v", err)
+	}
+
+	// An admitted validation dependency is not
+	err := svc.AcceptNode(parseNodeID(t, "1.3"))
+	if !errors.Is(err, ErrUnvalidatedDependencies) {
+		t.Fatalf("AcceptNode error = %v, want ErrUnvalidatedDependencies", err)
+	}
+	for _, want := range []string{"1.1", "admitted", "must be validated"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+
+	// The opt-out applies to plain dependencies only
+	svc.SetRequireValidatedDependencies(false)
+	if err := svc.AcceptNode(parseNodeID(t, "1.3")); !errors.Is(err, ErrUnvalidatedDependencies) {
+		t.Errorf("AcceptNode with gate disabled error = %v, want ErrUnvalidatedDependencies", err)
+	}
+}
+
+func TestAcceptNodeBulk_ValidationDeps(t *testing.T) {
+	svc := setupValidationDepsTest(t)
+
+	err := svc.AcceptNodeBulk([]types.NodeID{parseNodeID(t, "1.3")})
+	if !errors.Is(err, ErrUnvalidatedDependencies) {
+		t.Fatalf("AcceptNodeBulk error = %v, want ErrUnvalidatedDependencies", err)
+	}
+
+	// Validating the dependency in the same batch satisfies it
+	if err := svc.AcceptNodeBulk([]types.NodeID{parseNodeID(t, "1.3"), parseNodeID(t, "1.1")}); err != nil {
+		t.Fatalf("AcceptNodeBulk with validation dependency in batch failed: %v", err)
+	}
+}
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/types"
 )
 
@@ -296,3 +298,173 @@ func TestAmendNode_NodeNotFoundFails(t *testing.T) {
 		t.Fatal("expected error for non-existent node, got nil")
 	}
 }
+
+// setupAmendFullTest creates a proof whose root has children 1.1 and 1.2,
+// where 1.2 depends on 1.1 and is claimed by "prover".
+func setupAmendFullTest(t *testing.T) *ProofService {
+	t.Helper()
+	svc, _ := setupTestProof(t)
+
+	rootID := parseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNode(rootID, "prover", parseNodeID(t, "1.1"), schema.NodeTypeClaim, "First step", schema.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefineNodeWithDeps(rootID, "prover", parseNodeID(t, "1.2"), schema.NodeTypeClaim, "Second step", schema.InferenceAssumption, []types.NodeID{parseNodeID(t, "1.1")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ClaimNode(parseNodeID(t, "1.2"), "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestAmendNodeFull_AppliesOnlyProvidedFields(t *testing.T) {
+	svc := setupAmendFullTest(t)
+	nodeID := parseNodeID(t, "1.2")
+
+	inference := schema.InferenceModusPonens
+	deps := []types.NodeID{}
+	valDeps := []types.NodeID{parseNodeID(t, "1.1")}
+	err := svc.AmendNodeFull(nodeID, "prover", AmendSpec{
+		Inference:      &inference,
+		Dependencies:   &deps,
+		ValidationDeps: &valDeps,
+	})
+	if err != nil {
+		t.Fatalf("AmendNodeFull failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := st.GetNode(nodeID)
+	if n.Statement != "Second step" {
+		t.Errorf("Statement = %q, want unchanged %q", n.Statement, "Second step")
+	}
+	if n.Inference != schema.InferenceModusPonens {
+		t.Errorf("Inference = %q, want %q", n.Inference, schema.InferenceModusPonens)
+	}
+	if len(n.Dependencies) != 0 {
+		t.Errorf("Dependencies = %v, want none", n.Dependencies)
+	}
+	if len(n.ValidationDeps) != 1 || n.ValidationDeps[0].String() != "1.1" {
+		t.Errorf("ValidationDeps = %v, want [1.1]", n.ValidationDeps)
+	}
+	if n.ContentHash != n.ComputeContentHash() {
+		t.Error("ContentHash not recomputed after amendment")
+	}
+
+	history, err := svc.LoadAmendmentHistory(nodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected 1 amendment in history, got %d", len(history))
+	}
+	a := history[0]
+	wantFields := []string{"inference", "dependencies", "validation_deps"}
+	if len(a.Fields) != len(wantFields) {
+		t.Fatalf("Fields = %v, want %v", a.Fields, wantFields)
+	}
+	for i, f := range wantFields {
+		if a.Fields[i] != f {
+			t.Errorf("Fields[%d] = %q, want %q", i, a.Fields[i], f)
+		}
+	}
+	if a.PreviousInference != schema.InferenceAssumption || a.NewInference != schema.InferenceModusPonens {
+		t.Errorf("inference change = %q -> %q, want assumption -> modus_ponens", a.PreviousInference, a.NewInference)
+	}
+	if len(a.PreviousDependencies) != 1 || a.PreviousDependencies[0].String() != "1.1" {
+		t.Errorf("PreviousDependencies = %v, want [1.1]", a.PreviousDependencies)
+	}
+	if a.Owner != "prover" {
+		t.Errorf("Owner = %q, want %q", a.Owner, "prover")
+	}
+}
+
+func TestAmendNodeFull_Statement(t *testing.T) {
+	svc := setupAmendFullTest(t)
+	nodeID := parseNodeID(t, "1.2")
+
+	statement := "Corrected second step"
+	if err := svc.AmendNodeFull(nodeID, "prover", AmendSpec{Statement: &statement}); err != nil {
+		t.Fatalf("AmendNodeFull failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := st.GetNode(nodeID)
+	if n.Statement != statement {
+		t.Errorf("Statement = %q, want %q", n.Statement, statement)
+	}
+	if len(n.Dependencies) != 1 {
+		t.Errorf("Dependencies = %v, want unchanged [1.1]", n.Dependencies)
+	}
+
+	history := st.GetAmendmentHistory(nodeID)
+	if len(history) != 1 || history[0].PreviousStatement != "Second step" || history[0].NewStatement != statement {
+		t.Errorf("unexpected amendment history: %+v", history)
+	}
+}
+
+func TestAmendNodeFull_Errors(t *testing.T) {
+	statement := "New statement"
+	empty := "  "
+	badInference := schema.InferenceType("guesswork")
+	depsOn := func(t *testing.T, id string) *[]types.NodeID {
+		deps := []types.NodeID{parseNodeID(t, id)}
+		return &deps
+	}
+
+	tests := []struct {
+		name    string
+		nodeID  string
+		owner   string
+		spec    func(t *testing.T) AmendSpec
+		wantErr error
+	}{
+		{"empty owner", "1.2", "", func(t *testing.T) AmendSpec { return AmendSpec{Statement: &statement} }, ErrEmptyInput},
+		{"no fields", "1.2", "prover", func(t *testing.T) AmendSpec { return AmendSpec{} }, ErrEmptyInput},
+		{"empty statement", "1.2", "prover", func(t *testing.T) AmendSpec { return AmendSpec{Statement: &empty} }, ErrEmptyInput},
+		{"invalid inference", "1.2", "prover", func(t *testing.T) AmendSpec { return AmendSpec{Inference: &badInference} }, nil},
+		{"node not found", "1.9", "prover", func(t *testing.T) AmendSpec { return AmendSpec{Statement: &statement} }, ErrNodeNotFound},
+		{"node not claimed", "1.1", "prover", func(t *testing.T) AmendSpec { return AmendSpec{Statement: &statement} }, ErrNotClaimed},
+		{"wrong owner", "1.2", "intruder", func(t *testing.T) AmendSpec { return AmendSpec{Statement: &statement} }, ErrOwnerMismatch},
+		{"missing dependency", "1.2", "prover", func(t *testing.T) AmendSpec { return AmendSpec{Dependencies: depsOn(t, "1.7")} }, nil},
+		{"self dependency", "1.2", "prover", func(t *testing.T) AmendSpec { return AmendSpec{ValidationDeps: depsOn(t, "1.2")} }, ErrCircularDependency},
+		{"dependency cycle", "1.1", "prover", func(t *testing.T) AmendSpec { return AmendSpec{Dependencies: depsOn(t, "1.2")} }, ErrCircularDependency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := setupAmendFullTest(t)
+			if tt.name == "dependency cycle" {
+				if err := svc.ClaimNode(parseNodeID(t, "1.1"), "prover", time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := svc.AmendNodeFull(parseNodeID(t, tt.nodeID), tt.owner, tt.spec(t))
+			if err == nil {
+				t.Fatal("AmendNodeFull succeeded, want error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("AmendNodeFull error = %v, want %v", err, tt.wantErr)
+			}
+
+			history, loadErr := svc.LoadAmendmentHistory(parseNodeID(t, "1.2"))
+			if loadErr != nil {
+				t.Fatal(loadErr)
+			}
+			if len(history) != 0 {
+				t.Errorf("failed amendment was recorded: %+v", history)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// setupArchiveSubtreeTest creates a proof with the subtree 1.1, 1.1.1, 1.1.2
+// and the siblings 1.2 through 1.10. Node 1.10 shares a string prefix with
+// 1.1 but is not in its subtree.
+func setupArchiveSubtreeTest(t *testing.T) *ProofService {
+	t.Helper()
+	svc, _ := setupTestProof(t)
+
+	refine := func(parent, child string) {
+		t.Helper()
+		parentID := parseNodeID(t, parent)
+		if err := svc.ClaimNode(parentID, "prover", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.RefineNode(parentID, "prover", parseNodeID(t, child), schema.NodeTypeClaim, "Step "+child, schema.InferenceAssumption); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.ReleaseNode(parentID, "prover"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refine("1", "1.1")
+	refine("1.1", "1.1.1")
+	refine("1.1", "1.1.2")
+	for i := 2; i <= 10; i++ {
+		refine("1", fmt.Sprintf("1.%d", i))
+	}
+	return svc
+}
+
+func TestArchiveSubtree_ArchivesNodeAndDescendants(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+
+	archived, err := svc.ArchiveSubtree(parseNodeID(t, "1.1"), false)
+	if err != nil {
+		t.Fatalf("ArchiveSubtree failed: %v", err)
+	}
+	if got, want := strings.Join(types.ToStringSlice(archived), ","), "1.1,1.1.1,1.1.2"; got != want {
+		t.Errorf("archived = %s, want %s", got, want)
+	}
+
+	// Reloading replays the batch, archiving a parent that still has children.
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	for _, id := range []string{"1.1", "1.1.1", "1.1.2"} {
+		if es := st.GetNode(parseNodeID(t, id)).EpistemicState; es != schema.EpistemicArchived {
+			t.Errorf("node %s state = %s, want archived", id, es)
+		}
+	}
+	for _, id := range []string{"1", "1.10"} {
+		if es := st.GetNode(parseNodeID(t, id)).EpistemicState; es != schema.EpistemicPending {
+			t.Errorf("node %s state = %s, want pending", id, es)
+		}
+	}
+}
+
+func TestArchiveSubtree_ValidatedDescendant(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+	if err := svc.AcceptNode(parseNodeID(t, "1.1.2")); err != nil {
+		t.Fatalf("AcceptNode failed: %v", err)
+	}
+
+	_, err := svc.ArchiveSubtree(parseNodeID(t, "1.1"), false)
+	if !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("ArchiveSubtree error = %v, want ErrInvalidState", err)
+	}
+	if !strings.Contains(err.Error(), "1.1.2") {
+		t.Errorf("error %q should name the validated node 1.1.2", err)
+	}
+
+	archived, err := svc.ArchiveSubtree(parseNodeID(t, "1.1"), true)
+	if err != nil {
+		t.Fatalf("ArchiveSubtree with force failed: %v", err)
+	}
+	if got, want := strings.Join(types.ToStringSlice(archived), ","), "1.1,1.1.1"; got != want {
+		t.Errorf("archived = %s, want %s", got, want)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if es := st.GetNode(parseNodeID(t, "1.1.2")).EpistemicState; es != schema.EpistemicValidated {
+		t.Errorf("validated node state = %s, want validated", es)
+	}
+}
+
+func TestArchiveSubtree_Errors(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+
+	if _, err := svc.ArchiveSubtree(parseNodeID(t, "1.9.9"), false); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("missing node error = %v, want ErrNodeNotFound", err)
+	}
+
+	if _, err := svc.ArchiveSubtree(parseNodeID(t, "1.1"), false); err != nil {
+		t.Fatalf("ArchiveSubtree failed: %v", err)
+	}
+	if _, err := svc.ArchiveSubtree(parseNodeID(t, "1.1"), false); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("already archived error = %v, want ErrInvalidState", err)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// taintEventsAfter returns the taint_recomputed events appended after seq,
+// as node ID -> new taint.
+func taintEventsAfter(t *testing.T, svc *ProofService, seq int) map[string]node.TaintState {
+	t.Helper()
+	ldg, err := svc.getLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make(map[string]node.TaintState)
+	err = ldg.ScanFrom(seq, func(_ int, data []byte) error {
+		var e ledger.TaintRecomputed
+		if err := json.Unmarshal(data, &e); err != nil {
+			return err
+		}
+		if e.EventType == ledger.EventTaintRecomputed {
+			events[e.NodeID.String()] = e.NewTaint
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestAutoTaint_RecordsChangedTaint(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AdmitNode(mustParseID(t, "1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The root's taint changes; pending 1.1 stays unresolved and is not recorded
+	events := taintEventsAfter(t, svc, st.LatestSeq())
+	if len(events) != 1 || events["1"] != node.TaintSelfAdmitted {
+		t.Errorf("taint events = %v, want only 1 -> self_admitted", events)
+	}
+
+	st, err = svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefuteNode(mustParseID(t, "1.1")); err != nil {
+		t.Fatal(err)
+	}
+	events = taintEventsAfter(t, svc, st.LatestSeq())
+	if len(events) != 1 || events["1.1"] != node.TaintTainted {
+		t.Errorf("taint events = %v, want only 1.1 -> tainted", events)
+	}
+}
+
+func TestAutoTaint_Disabled(t *testing.T) {
+	base := newRenumberTestService(t, "1.1")
+	svc, err := NewProofService(base.Path(), WithAutoTaint(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AdmitNode(mustParseID(t, "1")); err != nil {
+		t.Fatal(err)
+	}
+	if events := taintEventsAfter(t, svc, st.LatestSeq()); len(events) != 0 {
+		t.Errorf("taint events = %v, want none with auto-taint disabled", events)
+	}
+
+	// Taint is still derived on replay
+	st, err = svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetNode(mustParseID(t, "1")).TaintState; got != node.TaintSelfAdmitted {
+		t.Errorf("root taint = %s, want self_admitted", got)
+	}
+}
+
+func TestTaintAffectedNodes_DependencyCycle(t *testing.T) {
+	st := state.NewState()
+	add := func(id string, deps ...string) {
+		t.Helper()
+		var depIDs []types.NodeID
+		for _, d := range deps {
+			depIDs = append(depIDs, mustParseID(t, d))
+		}
+		n, err := node.NewNodeWithOptions(mustParseID(t, id), schema.NodeTypeClaim, "Claim "+id,
+			schema.InferenceAssumption, node.NodeOptions{Dependencies: depIDs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		st.AddNode(n)
+	}
+
+	// 1.1 -> 1.2 -> 1.3 -> 1.1, plus a child of 1.3 and an unrelated 1.4
+	add("1")
+	add("1.1", "1.2")
+	add("1.2", "1.3")
+	add("1.3", "1.1")
+	add("1.3.1")
+	add("1.4")
+
+	affected := taintAffectedNodes(st, []types.NodeID{mustParseID(t, "1.2")})
+	ids := make([]types.NodeID, len(affected))
+	for i, n := range affected {
+		ids[i] = n.ID
+	}
+	if got := strings.Join(types.ToStringSlice(ids), ","); got != "1.2,1.1,1.3,1.3.1" {
+		t.Errorf("taintAffectedNodes(1.2) = %s, want 1.2,1.1,1.3,1.3.1", got)
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Batch operation names accepted in the "op" field of a BatchOp.
+const (
+	BatchOpCreate    = "create"
+	BatchOpClaim     = "claim"
+	BatchOpRelease   = "release"
+	BatchOpRefine    = "refine"
+	BatchOpAccept    = "accept"
+	BatchOpAdmit     = "admit"
+	BatchOpRefute    = "refute"
+	BatchOpArchive   = "archive"
+	BatchOpReopen    = "reopen"
+	BatchOpChallenge = "challenge"
+	BatchOpResolve   = "resolve"
+	BatchOpWithdraw  = "withdraw"
+	BatchOpTag       = "tag"
+	BatchOpUntag     = "untag"
+)
+
+// BatchOp describes one operation in a batch file. Which fields are used
+// depends on Op:
+//
+//	create     node, type, statement, inference
+//	claim      node, owner, timeout (defaults to the configured lock timeout)
+//	release    node, owner
+//	refine     node (the parent), owner, type, statement, inference,
+//	           child (optional; allocated when empty)
+//	accept, admit, refute, archive, reopen
+//	           node
+//	challenge  node, target, reason, severity
+//	resolve, withdraw
+//	           challenge
+//	tag, untag node, tag
+type BatchOp struct {
+	Op        string `json:"op"`
+	Node      string `json:"node,omitempty"`
+	Child     string `json:"child,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+	Timeout   string `json:"timeout,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Statement string `json:"statement,omitempty"`
+	Inference string `json:"inference,omitempty"`
+	Target    string `json:"target,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Challenge string `json:"challenge,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+}
+
+// BatchStepResult records the outcome of one applied batch operation.
+type BatchStepResult struct {
+	Index       int    `json:"index"`
+	Op          string `json:"op"`
+	NodeID      string `json:"node_id,omitempty"`      // Node created by create or refine
+	ChallengeID string `json:"challenge_id,omitempty"` // Challenge raised by challenge
+}
+
+// BatchResult reports how far a batch got.
+type BatchResult struct {
+	Total   int               `json:"total"`
+	Applied int               `json:"applied"`
+	DryRun  bool              `json:"dry_run"`
+	Steps   []BatchStepResult `json:"steps"`
+
+	// FailedIndex is the zero-based index of the first operation that
+	// failed, or -1 if every operation succeeded.
+	FailedIndex int    `json:"failed_index"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ParseBatch decodes a JSON array of batch operations. Unknown fields are
+// rejected so that misspelled keys are not silently ignored.
+func ParseBatch(data []byte) ([]BatchOp, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var ops []BatchOp
+	if err := dec.Decode(&ops); err != nil {
+		return nil, fmt.Errorf("invalid batch file: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: batch has no operations", ErrEmptyInput)
+	}
+	return ops, nil
+}
+
+// BatchFromFile reads a JSON array of operations from path (see BatchOp) and
+// applies them in order.
+//
+// The whole batch is first run against a dry-run copy of the service, where
+// each operation sees the effects of the ones before it but nothing is
+// written. Only if every operation passes is the batch applied for real. If
+// the service itself is in dry-run mode, the batch is only validated and its
+// events are added to PreviewEvents.
+//
+// On failure the returned result has FailedIndex set to the index of the
+// first failing operation, and the error wraps that operation's error.
+// A failure in the validation pass writes nothing. A failure while applying,
+// e.g. ErrConcurrentModification because another process changed the proof
+// after validation, leaves the operations before FailedIndex applied.
+func (s *ProofService) BatchFromFile(path string) (BatchResult, error) {
+	result := BatchResult{FailedIndex: -1, DryRun: s.dryRun}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("reading batch file: %w", err)
+	}
+	ops, err := ParseBatch(data)
+	if err != nil {
+		return result, err
+	}
+	result.Total = len(ops)
+
+	if !s.dryRun {
+		dry := &ProofService{
+			path:                 s.path,
+			allowUnvalidatedDeps: s.allowUnvalidatedDeps,
+			dryRun:               true,
+			readOnly:             s.readOnly,
+			noAutoTaint:          s.noAutoTaint,
+		}
+		if err := dry.runBatch(ops, &result); err != nil {
+			result.Applied = 0
+			result.Steps = nil
+			return result, err
+		}
+	}
+
+	err = s.runBatch(ops, &result)
+	return result, err
+}
+
+// runBatch applies ops in order, recording each step in result, and stops
+// at the first failure.
+func (s *ProofService) runBatch(ops []BatchOp, result *BatchResult) error {
+	result.Steps = make([]BatchStepResult, 0, len(ops))
+	result.Applied = 0
+	for i, op := range ops {
+		step, err := s.applyBatchOp(op)
+		if err != nil {
+			result.FailedIndex = i
+			result.Error = err.Error()
+			return fmt.Errorf("batch operation %d (%s): %w", i, op.Op, err)
+		}
+		step.Index = i
+		step.Op = op.Op
+		result.Steps = append(result.Steps, step)
+		result.Applied = i + 1
+	}
+	return nil
+}
+
+// applyBatchOp performs a single batch operation.
+func (s *ProofService) applyBatchOp(op BatchOp) (BatchStepResult, error) {
+	var step BatchStepResult
+
+	name := strings.ToLower(strings.TrimSpace(op.Op))
+	switch name {
+	case BatchOpResolve, BatchOpWithdraw:
+		if strings.TrimSpace(op.Challenge) == "" {
+			return step, fmt.Errorf("%w: challenge", ErrEmptyInput)
+		}
+		if name == BatchOpResolve {
+			return step, s.ResolveChallenge(op.Challenge)
+		}
+		return step, s.WithdrawChallenge(op.Challenge)
+	case "":
+		return step, fmt.Errorf("%w: op", ErrEmptyInput)
+	}
+
+	if strings.TrimSpace(op.Node) == "" {
+		return step, fmt.Errorf("%w: node", ErrEmptyInput)
+	}
+	id, err := types.Parse(op.Node)
+	if err != nil {
+		return step, fmt.Errorf("invalid node ID %q: %w", op.Node, err)
+	}
+
+	switch name {
+	case BatchOpCreate:
+		if err := s.CreateNode(id, schema.NodeType(op.Type), op.Statement, schema.InferenceType(op.Inference)); err != nil {
+			return step, err
+		}
+		step.NodeID = id.String()
+	case BatchOpClaim:
+		timeout, err := s.batchTimeout(op.Timeout)
+		if err != nil {
+			return step, err
+		}
+		return step, s.ClaimNode(id, op.Owner, timeout)
+	case BatchOpRelease:
+		return step, s.ReleaseNode(id, op.Owner)
+	case BatchOpRefine:
+		var childID types.NodeID
+		if op.Child == "" {
+			childID, err = s.AllocateChildID(id)
+		} else {
+			childID, err = types.Parse(op.Child)
+		}
+		if err != nil {
+			return step, err
+		}
+		if err := s.RefineNode(id, op.Owner, childID, schema.NodeType(op.Type), op.Statement, schema.InferenceType(op.Inference)); err != nil {
+			return step, err
+		}
+		step.NodeID = childID.String()
+	case BatchOpAccept:
+		return step, s.AcceptNode(id)
+	case BatchOpAdmit:
+		return step, s.AdmitNode(id)
+	case BatchOpRefute:
+		return step, s.RefuteNode(id)
+	case BatchOpArchive:
+		return step, s.ArchiveNode(id)
+	case BatchOpReopen:
+		return step, s.ReopenNode(id)
+	case BatchOpChallenge:
+		challengeID, err := s.RaiseChallenge(id, op.Target, op.Reason, op.Severity)
+		if err != nil {
+			return step, err
+		}
+		step.ChallengeID = challengeID
+	case BatchOpTag:
+		return step, s.TagNode(id, op.Tag)
+	case BatchOpUntag:
+		return step, s.UntagNode(id, op.Tag)
+	default:
+		return step, fmt.Errorf("unknown batch operation %q", op.Op)
+	}
+	return step, nil
+}
+
+// batchTimeout parses a claim timeout, defaulting to the configured lock
+// timeout when value is empty.
+func (s *ProofService) batchTimeout(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timeout %q: %w", value, err)
+		}
+		return timeout, nil
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	return cfg.LockTimeout, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeBatchFile writes a batch file into a temporary directory and returns its path.
+func writeBatchFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "batch.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const refinePlanBatch = `[
+	{"op": "claim", "node": "1", "owner": "prover", "timeout": "1h"},
+	{"op": "refine", "node": "1", "owner": "prover", "type": "claim", "statement": "Base case", "inference": "assumption"},
+	{"op": "refine", "node": "1", "owner": "prover", "child": "1.5", "type": "claim", "statement": "Inductive step", "inference": "assumption"},
+	{"op": "release", "node": "1", "owner": "prover"},
+	{"op": "challenge", "node": "1.1", "target": "statement", "reason": "Which base?", "severity": "minor"}
+]`
+
+func TestBatchFromFile_AppliesInOrder(t *testing.T) {
+	svc := newChallengeTestService(t)
+	before := ledgerCount(t, svc)
+
+	result, err := svc.BatchFromFile(writeBatchFile(t, refinePlanBatch))
+	if err != nil {
+		t.Fatalf("BatchFromFile failed: %v", err)
+	}
+	if result.Total != 5 || result.Applied != 5 || result.FailedIndex != -1 {
+		t.Errorf("result = total %d, applied %d, failed %d; want 5, 5, -1", result.Total, result.Applied, result.FailedIndex)
+	}
+	if got := ledgerCount(t, svc); got != before+5 {
+		t.Errorf("ledger has %d events, want %d", got, before+5)
+	}
+
+	if len(result.Steps) != 5 {
+		t.Fatalf("got %d steps, want 5", len(result.Steps))
+	}
+	if result.Steps[1].NodeID != "1.1" || result.Steps[2].NodeID != "1.5" {
+		t.Errorf("refined node IDs = %q, %q; want 1.1, 1.5", result.Steps[1].NodeID, result.Steps[2].NodeID)
+	}
+	if result.Steps[4].ChallengeID == "" {
+		t.Error("challenge step has no challenge ID")
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child := st.GetNode(mustParseID(t, "1.5"))
+	if child == nil || child.Statement != "Inductive step" {
+		t.Errorf("node 1.5 = %v, want the inductive step", child)
+	}
+	if root := st.GetNode(mustParseID(t, "1")); root.ClaimedBy != "" {
+		t.Errorf("root still claimed by %q after release", root.ClaimedBy)
+	}
+}
+
+func TestBatchFromFile_FailureWritesNothing(t *testing.T) {
+	svc := newChallengeTestService(t)
+	before := ledgerCount(t, svc)
+
+	// The third operation refines without holding the claim on 1
+	path := writeBatchFile(t, `[
+		{"op": "claim", "node": "1", "owner": "prover", "timeout": "1h"},
+		{"op": "refine", "node": "1", "owner": "prover", "type": "claim", "statement": "A", "inference": "assumption"},
+		{"op": "refine", "node": "1", "owner": "someone-else", "type": "claim", "statement": "B", "inference": "assumption"},
+		{"op": "release", "node": "1", "owner": "prover"}
+	]`)
+
+	result, err := svc.BatchFromFile(path)
+	if err == nil {
+		t.Fatal("BatchFromFile succeeded, want an error")
+	}
+	if result.FailedIndex != 2 {
+		t.Errorf("FailedIndex = %d, want 2", result.FailedIndex)
+	}
+	if result.Applied != 0 || result.Error == "" {
+		t.Errorf("Applied = %d, Error = %q; want 0 and a message", result.Applied, result.Error)
+	}
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("ledger has %d events after rejected batch, want %d", got, before)
+	}
+}
+
+func TestBatchFromFile_ErrorWrapsCause(t *testing.T) {
+	svc := newChallengeTestService(t)
+
+	result, err := svc.BatchFromFile(writeBatchFile(t, `[{"op": "accept", "node": "1.9"}]`))
+	if !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("error = %v, want ErrNodeNotFound", err)
+	}
+	if result.FailedIndex != 0 {
+		t.Errorf("FailedIndex = %d, want 0", result.FailedIndex)
+	}
+}
+
+func TestBatchFromFile_DryRun(t *testing.T) {
+	svc := newChallengeTestService(t)
+	before := ledgerCount(t, svc)
+
+	dry, err := NewProofService(svc.Path(), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := dry.BatchFromFile(writeBatchFile(t, refinePlanBatch))
+	if err != nil {
+		t.Fatalf("dry-run BatchFromFile failed: %v", err)
+	}
+	if !result.DryRun || result.Applied != 5 {
+		t.Errorf("result = dry run %v, applied %d; want true, 5", result.DryRun, result.Applied)
+	}
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("ledger has %d events after dry run, want %d", got, before)
+	}
+	if got := len(dry.PreviewEvents()); got != 5 {
+		t.Errorf("PreviewEvents() has %d events, want 5", got)
+	}
+}
+
+func TestParseBatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty array", `[]`},
+		{"not an array", `{"op": "claim"}`},
+		{"unknown field", `[{"op": "claim", "nodes": "1"}]`},
+		{"malformed", `[{"op": `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseBatch([]byte(tt.data)); err == nil {
+				t.Errorf("ParseBatch(%s) succeeded, want an error", tt.data)
+			}
+		})
+	}
+}
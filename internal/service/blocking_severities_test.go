@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/config"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newBlockingTestService creates a proof whose config blocks acceptance on
+// the given severities.
+func newBlockingTestService(t *testing.T, severities ...string) *ProofService {
+	t.Helper()
+	svc := newChallengeTestService(t)
+	cfg := config.Default()
+	cfg.Title = "Blocking test"
+	cfg.Conjecture = "Test conjecture"
+	cfg.BlockingSeverities = severities
+	if err := config.Save(cfg, filepath.Join(svc.Path(), "meta.json")); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := NewProofService(svc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestAcceptNode_ConfiguredBlockingSeverities(t *testing.T) {
+	rootID, _ := types.Parse("1")
+
+	t.Run("minor blocks when configured", func(t *testing.T) {
+		svc := newBlockingTestService(t, "critical", "major", "minor")
+		if _, err := svc.RaiseChallenge(rootID, "statement", "Typo", "minor"); err != nil {
+			t.Fatal(err)
+		}
+		err := svc.AcceptNode(rootID)
+		if !errors.Is(err, ErrBlockingChallenges) {
+			t.Fatalf("AcceptNode error = %v, want ErrBlockingChallenges", err)
+		}
+		if !strings.Contains(err.Error(), "blocking severities: critical, major, minor") {
+			t.Errorf("error should report the configured severities, got: %v", err)
+		}
+		if err := svc.AcceptNodeBulk([]types.NodeID{rootID}); !errors.Is(err, ErrBlockingChallenges) {
+			t.Errorf("AcceptNodeBulk error = %v, want ErrBlockingChallenges", err)
+		}
+	})
+
+	t.Run("major does not block when only critical is configured", func(t *testing.T) {
+		svc := newBlockingTestService(t, "critical")
+		if _, err := svc.RaiseChallenge(rootID, "statement", "Gap", "major"); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.AcceptNodeWithNote(rootID, "major gap noted"); err != nil {
+			t.Errorf("AcceptNodeWithNote failed: %v", err)
+		}
+	})
+
+	t.Run("default blocks critical and major", func(t *testing.T) {
+		svc := newChallengeTestService(t)
+		if got, err := svc.BlockingSeverities(); err != nil || strings.Join(got, ",") != "critical,major" {
+			t.Fatalf("BlockingSeverities() = %v, %v; want [critical major]", got, err)
+		}
+		if _, err := svc.RaiseChallenge(rootID, "statement", "Gap", "major"); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.AcceptNode(rootID); !errors.Is(err, ErrBlockingChallenges) {
+			t.Errorf("AcceptNode error = %v, want ErrBlockingChallenges", err)
+		}
+	})
+}
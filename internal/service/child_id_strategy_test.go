@@ -0,0 +1,89 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/config"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newChildIDTestService creates a proof using the given child ID strategy
+// whose root, claimed by "prover", has children 1.2 and 1.3, with the
+// highest child 1.3 archived.
+func newChildIDTestService(t *testing.T, strategy string) *ProofService {
+	t.Helper()
+	svc := newChallengeTestService(t)
+	cfg := config.Default()
+	cfg.Title = "Child ID test"
+	cfg.Conjecture = "Test conjecture"
+	cfg.ChildIDStrategy = strategy
+	if err := config.Save(cfg, filepath.Join(svc.Path(), "meta.json")); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := NewProofService(svc.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"1.2", "1.3"} {
+		if err := svc.CreateNode(mustParseID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption); err != nil {
+			t.Fatalf("CreateNode(%s) failed: %v", id, err)
+		}
+	}
+	if err := svc.ArchiveNode(mustParseID(t, "1.3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ClaimNode(mustParseID(t, "1"), "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestChildIDStrategy(t *testing.T) {
+	tests := []struct {
+		strategy string
+		next     string   // AllocateChildID
+		bulk     []string // RefineNodeBulk with two children
+	}{
+		// The gap at 1.1 is filled; archived 1.3 is still taken
+		{config.ChildIDLowestFree, "1.1", []string{"1.1", "1.4"}},
+		// Numbering continues after the archived highest child
+		{config.ChildIDMonotonic, "1.4", []string{"1.4", "1.5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			svc := newChildIDTestService(t, tt.strategy)
+			rootID := mustParseID(t, "1")
+
+			next, err := svc.AllocateChildID(rootID)
+			if err != nil {
+				t.Fatalf("AllocateChildID failed: %v", err)
+			}
+			if next.String() != tt.next {
+				t.Errorf("AllocateChildID = %s, want %s", next, tt.next)
+			}
+
+			spec := ChildSpec{NodeType: schema.NodeTypeClaim, Statement: "Step", Inference: schema.InferenceAssumption}
+			ids, err := svc.RefineNodeBulk(rootID, "prover", []ChildSpec{spec, spec})
+			if err != nil {
+				t.Fatalf("RefineNodeBulk failed: %v", err)
+			}
+			if got := idStrings(ids); len(got) != 2 || got[0] != tt.bulk[0] || got[1] != tt.bulk[1] {
+				t.Errorf("RefineNodeBulk IDs = %v, want %v", got, tt.bulk)
+			}
+		})
+	}
+}
+
+// idStrings returns the string form of each ID.
+func idStrings(ids []types.NodeID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestDependentsOf(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	rootID := mustParseID(t, "1")
+
+	refine := func(child string, deps, validationDeps []types.NodeID) {
+		t.Helper()
+		if err := svc.Refine(RefineSpec{
+			ParentID:       rootID,
+			Owner:          "prover",
+			ChildID:        mustParseID(t, child),
+			NodeType:       schema.NodeTypeClaim,
+			Statement:      "Claim " + child,
+			Inference:      schema.InferenceAssumption,
+			Dependencies:   deps,
+			ValidationDeps: validationDeps,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dep := []types.NodeID{mustParseID(t, "1.1")}
+
+	// 1.12 sorts after 1.3 by NodeID; 1.2 cites 1.1 both ways but is listed once
+	refine("1.12", dep, nil)
+	refine("1.2", dep, dep)
+	refine("1.3", nil, dep)
+	refine("1.4", nil, nil)
+
+	dependents, err := svc.DependentsOf(mustParseID(t, "1.1"))
+	if err != nil {
+		t.Fatalf("DependentsOf failed: %v", err)
+	}
+	if got := strings.Join(types.ToStringSlice(dependents), ","); got != "1.2,1.3,1.12" {
+		t.Errorf("DependentsOf(1.1) = %s, want 1.2,1.3,1.12", got)
+	}
+
+	dependents, err = svc.DependentsOf(mustParseID(t, "1.4"))
+	if err != nil || len(dependents) != 0 {
+		t.Errorf("DependentsOf(1.4) = %v, %v; want none", dependents, err)
+	}
+
+	if _, err := svc.DependentsOf(mustParseID(t, "1.9")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("DependentsOf(missing) error = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestTransitiveDependencies(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	rootID := mustParseID(t, "1")
+
+	refine := func(child string, deps ...string) {
+		t.Helper()
+		var ids []types.NodeID
+		for _, dep := range deps {
+			ids = append(ids, mustParseID(t, dep))
+		}
+		if err := svc.Refine(RefineSpec{
+			ParentID:     rootID,
+			Owner:        "prover",
+			ChildID:      mustParseID(t, child),
+			NodeType:     schema.NodeTypeClaim,
+			Statement:    "Claim " + child,
+			Inference:    schema.InferenceAssumption,
+			Dependencies: ids,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 1.4 -> 1.2, 1.3; both -> 1.1; 1.10 -> 1.4
+	refine("1.2", "1.1")
+	refine("1.3", "1.1")
+	refine("1.4", "1.2", "1.3")
+	refine("1.10", "1.4")
+
+	deps, err := svc.TransitiveDependencies(mustParseID(t, "1.10"))
+	if err != nil {
+		t.Fatalf("TransitiveDependencies failed: %v", err)
+	}
+	if got := strings.Join(types.ToStringSlice(deps), ","); got != "1.1,1.2,1.3,1.4" {
+		t.Errorf("TransitiveDependencies(1.10) = %s, want 1.1,1.2,1.3,1.4", got)
+	}
+
+	deps, err = svc.TransitiveDependencies(mustParseID(t, "1.1"))
+	if err != nil || len(deps) != 0 {
+		t.Errorf("TransitiveDependencies(1.1) = %v, %v; want none", deps, err)
+	}
+
+	if _, err := svc.TransitiveDependencies(mustParseID(t, "1.9")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("TransitiveDependencies(missing) error = %v, want ErrNodeNotFound", err)
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// ledgerCount returns the number of events in the service's ledger.
+func ledgerCount(t *testing.T, svc *ProofService) int {
+	t.Helper()
+	ldg, err := svc.getLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := ldg.Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestWithDryRun_PreviewsWithoutWriting(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+	childID, _ := types.Parse("1.1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := NewProofService(svc.Path(), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dry.DryRun() || svc.DryRun() {
+		t.Fatalf("DryRun() = %v for dry-run service, %v for default service", dry.DryRun(), svc.DryRun())
+	}
+	before := ledgerCount(t, svc)
+
+	if err := dry.RefineNode(rootID, "prover", childID, schema.NodeTypeClaim, "Step A", schema.InferenceAssumption); err != nil {
+		t.Fatalf("dry-run RefineNode failed: %v", err)
+	}
+	if _, err := dry.AddAssumption("x > 0"); err != nil {
+		t.Fatalf("dry-run AddAssumption failed: %v", err)
+	}
+
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("ledger has %d events after dry run, want %d", got, before)
+	}
+	if ids, err := svc.ListAssumptions(); err != nil || len(ids) != 0 {
+		t.Errorf("ListAssumptions() = %v, %v; want no assumptions written", ids, err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.GetNode(childID) != nil {
+		t.Errorf("node %s exists after dry-run refine", childID)
+	}
+
+	preview := dry.PreviewEvents()
+	if len(preview) != 1 || preview[0].Type() != ledger.EventNodeCreated {
+		t.Fatalf("PreviewEvents() = %v, want one node_created event", preview)
+	}
+	if created := preview[0].(ledger.NodeCreated); !created.Node.ID.Equal(childID) {
+		t.Errorf("previewed node ID = %s, want %s", created.Node.ID, childID)
+	}
+}
+
+func TestWithDryRun_StillValidates(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+	childID, _ := types.Parse("1.1")
+	if _, err := svc.RaiseChallenge(rootID, "", "Gap", "critical"); err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := NewProofService(svc.Path(), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Root is not claimed, so refining it must fail
+	if err := dry.RefineNode(rootID, "prover", childID, schema.NodeTypeClaim, "Step A", schema.InferenceAssumption); !errors.Is(err, ErrNotClaimed) {
+		t.Errorf("dry-run RefineNode error = %v, want ErrNotClaimed", err)
+	}
+	// The open critical challenge blocks acceptance
+	if err := dry.AcceptNode(rootID); !errors.Is(err, ErrBlockingChallenges) {
+		t.Errorf("dry-run AcceptNode error = %v, want ErrBlockingChallenges", err)
+	}
+	if preview := dry.PreviewEvents(); preview != nil {
+		t.Errorf("PreviewEvents() = %v after failed calls, want nil", preview)
+	}
+}
+
+func TestWithDryRun_LaterCallsSeePreview(t *testing.T) {
+	svc := newChallengeTestService(t)
+	dry, err := NewProofService(svc.Path(), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	childID := mustParseID(t, "1.1")
+	if err := dry.CreateNode(childID, schema.NodeTypeClaim, "Step", schema.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	// A second create of the same node fails only if the first is visible
+	if err := dry.CreateNode(childID, schema.NodeTypeClaim, "Step", schema.InferenceAssumption); err == nil {
+		t.Error("second dry-run CreateNode of the same node succeeded")
+	}
+	if err := dry.AcceptNode(childID); err != nil {
+		t.Errorf("dry-run AcceptNode of previewed node failed: %v", err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/tobias/vibefeld/internal/metrics"
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/patterns"
+	"github.com/tobias/vibefeld/internal/render"
 	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/scope"
 	"github.com/tobias/vibefeld/internal/shell"
@@ -59,6 +60,11 @@ var ParseTimestamp = types.ParseTimestamp
 // See fs.InitProofDir for full documentation.
 var InitProofDir = fs.InitProofDir
 
+// WriteFileAtomic writes data to a file via a temp file and rename, so a
+// failed write never leaves a partially written file in place.
+// This is a re-export of fs.WriteFileAtomic to reduce cmd/af imports.
+var WriteFileAtomic = fs.WriteFileAtomic
+
 // Re-exported types from internal/schema to reduce cmd/af import count.
 // Consumers should use service.NodeType, service.InferenceType, etc. instead of
 // importing the schema package directly.
@@ -175,6 +181,8 @@ var (
 	GetChallengeSeverityInfo    = schema.GetChallengeSeverityInfo
 	AllChallengeSeverities      = schema.AllChallengeSeverities
 	DefaultChallengeSeverity    = schema.DefaultChallengeSeverity
+	SeverityBlocksAcceptanceWith = schema.SeverityBlocksAcceptanceWith
+	SeverityRank                = schema.SeverityRank
 )
 
 // Info types re-exported from internal/schema.
@@ -213,6 +221,23 @@ var SanitizeError = errors.SanitizeError
 // Re-export of errors.ExitCode.
 var ExitCode = errors.ExitCode
 
+// ErrorCodeName returns the stable string code of an error (e.g.
+// "NODE_NOT_FOUND"), or "" if it carries none.
+// Re-export of errors.CodeName.
+var ErrorCodeName = errors.CodeName
+
+// Process exit codes returned by ExitCode.
+// Re-exports of the errors package exit code constants.
+const (
+	ExitOK         = errors.ExitOK
+	ExitGeneric    = errors.ExitGeneric
+	ExitNotFound   = errors.ExitNotFound
+	ExitConflict   = errors.ExitConflict
+	ExitBlocked    = errors.ExitBlocked
+	ExitValidation = errors.ExitValidation
+	ExitCorruption = errors.ExitCorruption
+)
+
 // Re-exported constants from internal/config to reduce cmd/af import count.
 // Consumers should use service.DefaultClaimTimeout instead of
 // importing the config package directly.
@@ -221,6 +246,10 @@ var ExitCode = errors.ExitCode
 // Re-export of config.DefaultClaimTimeout.
 const DefaultClaimTimeout = config.DefaultClaimTimeout
 
+// Config holds the configuration for an AF proof, stored in meta.json.
+// Re-export of config.Config.
+type Config = config.Config
+
 // Re-exported types from internal/scope to reduce cmd/af import count.
 // Consumers should use service.ScopeEntry and service.ScopeInfo instead of
 // importing the scope package directly.
@@ -263,10 +292,14 @@ var ValidateDefCitations = lemma.ValidateDefCitations
 // instead of importing the export package directly.
 
 // ValidateExportFormat checks if the given format string is valid.
-// Valid formats: markdown, md, latex, tex (case-insensitive).
+// Valid formats: markdown, md, latex, tex, dot, mermaid, csv (case-insensitive).
 // Re-export of export.ValidateFormat.
 var ValidateExportFormat = export.ValidateFormat
 
+// ExportFormats returns the supported export format names, including aliases.
+// Re-export of export.Formats.
+var ExportFormats = export.Formats
+
 // ExportProof exports the proof state to the specified format.
 // Returns an error if the format is invalid.
 // Re-export of export.Export.
@@ -274,6 +307,17 @@ func ExportProof(s *state.State, format string) (string, error) {
 	return export.Export(s, format)
 }
 
+// ExportOptions controls format-specific export behavior.
+// Re-export of export.Options.
+type ExportOptions = export.Options
+
+// ExportProofWithOptions exports the proof state to the specified format,
+// applying opts where the format supports them.
+// Re-export of export.ExportWithOptions.
+func ExportProofWithOptions(s *state.State, format string, opts ExportOptions) (string, error) {
+	return export.ExportWithOptions(s, format, opts)
+}
+
 // Re-exported types and functions from internal/metrics to reduce cmd/af import count.
 // Consumers should use service.QualityReport, service.OverallQuality, and
 // service.SubtreeQuality instead of importing the metrics package directly.
@@ -282,6 +326,18 @@ func ExportProof(s *state.State, format string) (string, error) {
 // Re-export of metrics.QualityReport.
 type QualityReport = metrics.QualityReport
 
+// ScoreBreakdown lists the weighted components of a quality score.
+// Re-export of metrics.ScoreBreakdown.
+type ScoreBreakdown = metrics.ScoreBreakdown
+
+// ScoreComponent is a single factor's contribution to the quality score.
+// Re-export of metrics.ScoreComponent.
+type ScoreComponent = metrics.ScoreComponent
+
+// NodeScore is the quality score of a single node.
+// Re-export of metrics.NodeScore.
+type NodeScore = metrics.NodeScore
+
 // OverallQuality computes comprehensive quality metrics for the entire proof.
 // Re-export of metrics.OverallQuality.
 func OverallQuality(s *state.State) *QualityReport {
@@ -295,6 +351,13 @@ func SubtreeQuality(s *state.State, rootID NodeID) *QualityReport {
 	return metrics.SubtreeQuality(s, rootID)
 }
 
+// NodeQuality computes the quality score of a single node.
+// Returns a zero NodeScore and false if the node doesn't exist.
+// Re-export of metrics.NodeQuality.
+func NodeQuality(s *state.State, nodeID NodeID) (NodeScore, bool) {
+	return metrics.NodeQuality(s, nodeID)
+}
+
 // Re-exported types and functions from internal/templates to reduce cmd/af import count.
 // Consumers should use service.Template, service.GetTemplate, etc. instead of
 // importing the templates package directly.
@@ -624,6 +687,30 @@ type Challenge = state.Challenge
 // Re-export of state.Amendment.
 type Amendment = state.Amendment
 
+// NodeHistoryEntry is one ledger event in the timeline of a node.
+// Re-export of state.NodeHistoryEntry.
+type NodeHistoryEntry = state.NodeHistoryEntry
+
+// Graph is the proof's dependency graph as raw adjacency data.
+// Re-export of state.Graph.
+type Graph = state.Graph
+
+// GraphEdge is a directed edge of the dependency graph.
+// Re-export of state.GraphEdge.
+type GraphEdge = state.GraphEdge
+
+// Dependency graph edge kinds.
+// Re-exports of state.EdgeDependency and state.EdgeValidation.
+const (
+	EdgeDependency = state.EdgeDependency
+	EdgeValidation = state.EdgeValidation
+)
+
+// StateSnapshot is the self-contained derived state written by
+// ProofService.ExportState.
+// Re-export of render.StateSnapshotView.
+type StateSnapshot = render.StateSnapshotView
+
 // NewState creates a new empty State with all maps initialized.
 // Re-export of state.NewState.
 var NewState = state.NewState
@@ -636,6 +723,18 @@ var Replay = state.Replay
 // Re-export of state.ReplayWithVerify.
 var ReplayWithVerify = state.ReplayWithVerify
 
+// ReplayProgressFunc reports replay progress as each event is applied.
+// Re-export of state.ProgressFunc.
+type ReplayProgressFunc = state.ProgressFunc
+
+// ReplayWithProgress replays events, calling progress after each one.
+// Re-export of state.ReplayWithProgress.
+var ReplayWithProgress = state.ReplayWithProgress
+
+// ReplayWithVerifyProgress verifies as ReplayWithVerify does, calling
+// progress after each event. Re-export of state.ReplayWithVerifyProgress.
+var ReplayWithVerifyProgress = state.ReplayWithVerifyProgress
+
 // NodeSummary is a view model containing only the fields needed for CLI display.
 // This decouples the CLI from the internal node.Node type, allowing the CLI
 // to work with a stable API without importing domain packages directly.
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+func TestGetSubtree_OrderAndSiblingPrefixes(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	refine := func(parent, child string) {
+		t.Helper()
+		parentID := parseNodeID(t, parent)
+		if err := svc.ClaimNode(parentID, "prover", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.RefineNode(parentID, "prover", parseNodeID(t, child), schema.NodeTypeClaim, "Step "+child, schema.InferenceAssumption); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.ReleaseNode(parentID, "prover"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Wide: 1.1 through 1.12, and 1.1.1 through 1.1.11
+	for i := 1; i <= 12; i++ {
+		refine("1", fmt.Sprintf("1.%d", i))
+	}
+	for i := 1; i <= 11; i++ {
+		refine("1.1", fmt.Sprintf("1.1.%d", i))
+	}
+	// Deep: a chain under 1.1.2, plus children of the look-alike siblings 1.10 and 1.11
+	refine("1.1.2", "1.1.2.1")
+	refine("1.1.2.1", "1.1.2.1.1")
+	refine("1.1.2.1.1", "1.1.2.1.1.1")
+	refine("1.10", "1.10.1")
+	refine("1.11", "1.11.1")
+
+	subtree, err := svc.GetSubtree(parseNodeID(t, "1.1"))
+	if err != nil {
+		t.Fatalf("GetSubtree failed: %v", err)
+	}
+	ids := make([]string, len(subtree))
+	for i, n := range subtree {
+		ids[i] = n.ID.String()
+	}
+	want := "1.1,1.1.1,1.1.2,1.1.2.1,1.1.2.1.1,1.1.2.1.1.1,1.1.3,1.1.4,1.1.5,1.1.6,1.1.7,1.1.8,1.1.9,1.1.10,1.1.11"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("GetSubtree(1.1) =\n  %s\nwant\n  %s", got, want)
+	}
+
+	// A leaf is its own subtree
+	leaf, err := svc.GetSubtree(parseNodeID(t, "1.1.2.1.1.1"))
+	if err != nil || len(leaf) != 1 || leaf[0].ID.String() != "1.1.2.1.1.1" {
+		t.Errorf("GetSubtree(leaf) = %v, %v; want just the leaf", leaf, err)
+	}
+
+	// The whole tree: root plus 12 + 11 + 3 + 2 descendants
+	all, err := svc.GetSubtree(parseNodeID(t, "1"))
+	if err != nil || len(all) != 29 || all[0].ID.String() != "1" {
+		t.Errorf("GetSubtree(1) returned %d nodes (err %v), want 29 starting at 1", len(all), err)
+	}
+}
+
+func TestGetSubtree_NodeNotFound(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	if _, err := svc.GetSubtree(parseNodeID(t, "1.7")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("GetSubtree(missing) error = %v, want ErrNodeNotFound", err)
+	}
+}
@@ -5,6 +5,7 @@ package service
 import (
 	"time"
 
+	"github.com/tobias/vibefeld/internal/config"
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/state"
@@ -26,9 +27,89 @@ type ProofQueryOperations interface {
 	// Note: This method performs I/O to load state from disk.
 	LoadAvailableNodes() ([]*node.Node, error)
 
+	// SearchNodes returns nodes whose statement contains query and that pass
+	// the filters in opts, sorted by node ID.
+	// Note: This method performs I/O to load state from disk.
+	SearchNodes(query string, opts SearchOptions) ([]*node.Node, error)
+
+	// ListChallenges returns the challenges that pass opts, sorted by node ID
+	// and then by challenge ID.
+	// Note: This method performs I/O to load state from disk.
+	ListChallenges(opts ChallengeFilter) ([]*state.Challenge, error)
+
+	// GetSubtree returns a node followed by all of its descendants, sorted
+	// by node ID.
+	// Note: This method performs I/O to load state from disk.
+	GetSubtree(rootID types.NodeID) ([]*node.Node, error)
+
+	// DependentsOf returns the IDs of the nodes that depend on nodeID,
+	// sorted by node ID.
+	// Note: This method performs I/O to load state from disk.
+	DependentsOf(nodeID types.NodeID) ([]types.NodeID, error)
+
+	// FindReclaimable returns the claimed nodes whose claim timeout has
+	// passed, sorted by node ID.
+	// Note: This method performs I/O to load state from disk.
+	FindReclaimable() ([]*node.Node, error)
+
+	// WhoHolds returns the current claimant of a node, when the claim was
+	// made and when it expires, or an empty owner if the node is not claimed.
+	// Note: This method performs I/O to load state from disk.
+	WhoHolds(nodeID types.NodeID) (owner string, since time.Time, expires time.Time, err error)
+
+	// TransitiveDependencies returns every node nodeID rests on through its
+	// Dependencies, recursively, sorted by node ID. On a cyclic graph the
+	// list is returned together with an error wrapping ErrCircularDependency.
+	// Note: This method performs I/O to load state from disk.
+	TransitiveDependencies(nodeID types.NodeID) ([]types.NodeID, error)
+
+	// ValidationDepsOf returns the nodes that must be validated (not merely
+	// admitted) before nodeID can be accepted.
+	ValidationDepsOf(nodeID types.NodeID) ([]types.NodeID, error)
+
+	// ListNodesByTag returns the nodes carrying tag, sorted by node ID.
+	// Note: This method performs I/O to load state from disk.
+	ListNodesByTag(tag string) ([]*node.Node, error)
+
+	// NodeHistory returns every ledger event that references nodeID, in
+	// order, each with a human-readable summary.
+	// Note: This method performs I/O to scan the ledger.
+	NodeHistory(nodeID types.NodeID) ([]NodeHistoryEntry, error)
+
+	// ClaimDurations returns how long each node was held in every claim of
+	// its history, keyed by node ID string.
+	// Note: This method performs I/O to scan the ledger.
+	ClaimDurations() (map[string][]time.Duration, error)
+
+	// DependencyGraph returns the nodes, the dependency and validation
+	// edges, and the dependency cycles of the proof.
+	// Note: This method performs I/O to load state from disk.
+	DependencyGraph() (*Graph, error)
+
+	// ExportState writes the derived state of the proof to path as a single
+	// JSON snapshot that LoadStateFromSnapshot can read without the ledger.
+	// Note: This method performs I/O to load state and write the file.
+	ExportState(path string) error
+
 	// Status returns the current status of the proof.
 	Status() (*ProofStatus, error)
 
+	// Stats computes structural metrics such as depth, branching, and the
+	// longest dependency chain.
+	// Note: This method performs I/O to load state from disk.
+	Stats() (*ProofStats, error)
+
+	// Lint returns proof-quality warnings, such as validated claims without
+	// children or claims held past their timeout, ordered by node ID, using
+	// DefaultLintOptions.
+	// Note: This method performs I/O to load state from disk.
+	Lint() ([]LintWarning, error)
+
+	// LintWithOptions is Lint with custom thresholds; each warning names a
+	// stable rule ID that opts can disable.
+	// Note: This method performs I/O to load state from disk.
+	LintWithOptions(opts LintOptions) ([]LintWarning, error)
+
 	// Path returns the proof directory path.
 	Path() string
 }
@@ -44,6 +125,34 @@ type ProverOperations interface {
 	// claiming the same node. Callers should retry after reloading state.
 	ClaimNode(id types.NodeID, owner string, timeout time.Duration) error
 
+	// ClaimNodeBulk claims multiple nodes atomically in a single event.
+	// If any node is missing or not available, no node is claimed.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ClaimNodeBulk(ids []types.NodeID, owner string, timeout time.Duration) error
+
+	// ReapExpiredClaims releases all nodes whose claim timeout has passed and
+	// returns their IDs. Returns nil if no claim has expired.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ReapExpiredClaims() ([]types.NodeID, error)
+
+	// ReclaimNode takes over a node whose claim has expired, claiming it for
+	// newOwner in a single event.
+	// Returns ErrClaimNotExpired if the claim has not expired yet.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ReclaimNode(nodeID types.NodeID, newOwner string, timeout time.Duration) error
+
+	// ClaimNext selects the deepest available, open node matching opts and
+	// claims it for owner in one operation.
+	// Returns ErrNoAvailableNodes if no node matches.
+	// Returns ErrConcurrentModification if the proof was modified concurrently.
+	ClaimNext(owner string, timeout time.Duration, opts ClaimNextOptions) (*node.Node, error)
+
 	// RefreshClaim extends the claim timeout for a node the caller owns.
 	// This allows agents to extend their claims without releasing and reclaiming,
 	// which would risk another agent claiming the node in between.
@@ -55,6 +164,16 @@ type ProverOperations interface {
 	// since state was loaded. Callers should retry after reloading state.
 	RefreshClaim(id types.NodeID, owner string, timeout time.Duration) error
 
+	// ReassignClaim transfers a claimed node from fromOwner to toOwner in a
+	// single event, so no other agent can claim it during the handoff.
+	//
+	// Returns an error if either owner is empty, the owners are the same, the
+	// node doesn't exist, is not claimed, or is not claimed by fromOwner.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ReassignClaim(id types.NodeID, fromOwner, toOwner string, timeout time.Duration) error
+
 	// ReleaseNode releases a claimed node, making it available again.
 	// Returns an error if the node is not claimed or the owner doesn't match.
 	//
@@ -90,6 +209,41 @@ type ProverOperations interface {
 	// Returns ErrConcurrentModification if the proof was modified by another process
 	// since state was loaded. Callers should retry after reloading state.
 	ExtractLemma(sourceNodeID types.NodeID, statement string) (string, error)
+
+	// ApplyLemma records an extracted lemma as a justification for a node
+	// claimed by owner.
+	// Returns ErrNodeNotFound if the node doesn't exist.
+	// Returns ErrLemmaNotFound if the lemma doesn't exist.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ApplyLemma(nodeID types.NodeID, lemmaID string, owner string) error
+
+	// RefineFromLemma creates a child of a claimed parent that restates an
+	// extracted lemma and cites it as justification, returning the child ID.
+	RefineFromLemma(parentID types.NodeID, owner string, lemmaID string) (types.NodeID, error)
+
+	// SetNodeLatex attaches, replaces, or clears the LaTeX rendering of a
+	// node claimed by owner, leaving its statement unchanged.
+	// Returns ErrNodeNotFound if the node doesn't exist.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	SetNodeLatex(nodeID types.NodeID, owner, latex string) error
+
+	// ResolveChallenge marks an open challenge as resolved.
+	// Returns ErrChallengeNotFound if the challenge doesn't exist.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ResolveChallenge(challengeID string) error
+
+	// ResolveAllChallenges resolves every open challenge on a node claimed
+	// by owner and returns the resolved IDs (empty if none were open).
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ResolveAllChallenges(nodeID types.NodeID, owner string) ([]string, error)
 }
 
 // VerifierOperations defines operations that verifier agents perform.
@@ -109,6 +263,14 @@ type VerifierOperations interface {
 	// since state was loaded. Callers should retry after reloading state.
 	AcceptNodeBulk(ids []types.NodeID) error
 
+	// AcceptSubtree validates a node and all of its descendants, deepest first,
+	// in a single batch. Nothing is validated if any node is blocked.
+	// Returns the IDs of the validated nodes in validation order.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	AcceptSubtree(rootID types.NodeID) ([]types.NodeID, error)
+
 	// AdmitNode admits a node without full verification.
 	// Returns an error if the node doesn't exist.
 	//
@@ -129,6 +291,43 @@ type VerifierOperations interface {
 	// Returns ErrConcurrentModification if the proof was modified by another process
 	// since state was loaded. Callers should retry after reloading state.
 	ArchiveNode(id types.NodeID) error
+
+	// ReopenNode returns a refuted or archived node to the pending state.
+	// Returns an error if the node doesn't exist or is in any other state.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	ReopenNode(id types.NodeID) error
+
+	// RaiseChallenge raises a challenge against a node.
+	// Returns the generated challenge ID and any error.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	RaiseChallenge(nodeID types.NodeID, target, reason, severity string) (string, error)
+
+	// WithdrawChallenge withdraws an open challenge.
+	// Returns ErrChallengeNotFound if the challenge doesn't exist.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	WithdrawChallenge(challengeID string) error
+
+	// EscalateChallenge changes the severity of an open challenge, which
+	// can make it start or stop blocking acceptance of its node.
+	// Returns ErrChallengeNotFound if the challenge doesn't exist.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	EscalateChallenge(challengeID, newSeverity string) error
+
+	// AddNote leaves an informational note on a node and returns the
+	// generated note ID. Notes never block acceptance.
+	// Returns ErrNodeNotFound if the node doesn't exist.
+	//
+	// Returns ErrConcurrentModification if the proof was modified by another process
+	// since state was loaded. Callers should retry after reloading state.
+	AddNote(nodeID types.NodeID, author, text string) (string, error)
 }
 
 // AdminOperations defines administrative operations for proof setup.
@@ -145,6 +344,45 @@ type AdminOperations interface {
 	// Returns ErrConcurrentModification if the proof was modified by another process
 	// since state was loaded. Callers should retry after reloading state.
 	CreateNode(id types.NodeID, nodeType schema.NodeType, statement string, inference schema.InferenceType) error
+
+	// BatchFromFile applies a JSON array of operations from a file after
+	// validating the whole batch in dry-run mode. On failure the result
+	// reports the index of the first failing operation.
+	BatchFromFile(path string) (BatchResult, error)
+
+	// CloneProof copies the proof into destDir, which must not exist or be
+	// empty, preserving ledger sequence numbers and bytes exactly and
+	// re-verifying hashes as it copies. The clone is independently mutable.
+	CloneProof(destDir string) error
+
+	// Rebase appends the nodes created in a clone since it forked from the
+	// proof, renumbering those whose IDs the proof has used meanwhile. If
+	// any node conflicts with the proof, nothing is appended and the
+	// result lists the conflicts.
+	Rebase(sourceDir string) (RebaseResult, error)
+
+	// PruneArchived writes a copy of the proof to destDir without the
+	// events of archived subtrees, renumbering and re-chaining the kept
+	// events, and returns the number of events dropped. The source ledger
+	// is not modified.
+	PruneArchived(destDir string) (int, error)
+
+	// Checkpoint records the latest ledger sequence number under a unique
+	// name and returns it.
+	Checkpoint(name string) (int, error)
+
+	// ListCheckpoints returns the recorded checkpoints ordered by sequence
+	// number.
+	ListCheckpoints() ([]Checkpoint, error)
+
+	// RestoreCheckpoint writes a copy of the proof to destDir whose ledger
+	// ends at the named checkpoint. The source ledger is not modified.
+	RestoreCheckpoint(name, destDir string) error
+
+	// SetConfig validates the runtime-tunable limits in cfg and writes it
+	// to meta.json atomically. Lowered limits only constrain later
+	// operations; existing nodes are left in place.
+	SetConfig(cfg *config.Config) error
 }
 
 // ProofOperations defines the full interface for proof manipulation operations.
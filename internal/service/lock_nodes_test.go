@@ -0,0 +1,222 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// TestClaimNodeBulk_ConcurrentOverlappingSets has agents, each with its own
+// service on the same proof, bulk-claim overlapping node sets listed in
+// different orders. No call may deadlock, and every claim must be all or
+// nothing: each node ends up claimed by exactly one agent, and an agent
+// holds either its whole set or none of it.
+func TestClaimNodeBulk_ConcurrentOverlappingSets(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+
+	sets := map[string][]string{
+		"agent-a": {"1.2", "1.3", "1.4"},
+		"agent-b": {"1.4", "1.3", "1.2"},
+		"agent-c": {"1.3", "1.5"},
+		"agent-d": {"1.5", "1.6", "1.2"},
+		"agent-e": {"1.7", "1.6"},
+		"agent-f": {"1.8", "1.9"},
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := make(map[string]bool)
+	for owner, set := range sets {
+		ids := make([]types.NodeID, len(set))
+		for i, s := range set {
+			ids[i] = parseNodeID(t, s)
+		}
+
+		agentSvc, err := NewProofService(svc.Path())
+		if err != nil {
+			t.Fatalf("NewProofService failed: %v", err)
+		}
+
+		wg.Add(1)
+		go func(owner string, ids []types.NodeID) {
+			defer wg.Done()
+			for {
+				err := agentSvc.ClaimNodeBulk(ids, owner, time.Hour)
+				if errors.Is(err, ErrConcurrentModification) {
+					continue // a disjoint set was claimed first; retry on fresh state
+				}
+				if err == nil {
+					mu.Lock()
+					succeeded[owner] = true
+					mu.Unlock()
+				} else if !errors.Is(err, ErrInvalidState) {
+					t.Errorf("%s: ClaimNodeBulk error = %v, want nil or ErrInvalidState", owner, err)
+				}
+				return
+			}
+		}(owner, ids)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("concurrent ClaimNodeBulk calls on overlapping node sets deadlocked")
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	claimedBy := make(map[string]string)
+	for owner, set := range sets {
+		for _, id := range set {
+			n := st.GetNode(parseNodeID(t, id))
+			if n.WorkflowState == schema.WorkflowClaimed && n.ClaimedBy == owner {
+				if prev, ok := claimedBy[id]; ok && prev != owner {
+					t.Errorf("node %s claimed by both %s and %s", id, prev, owner)
+				}
+				claimedBy[id] = owner
+			}
+		}
+	}
+
+	for owner, set := range sets {
+		held := 0
+		for _, id := range set {
+			if claimedBy[id] == owner {
+				held++
+			}
+		}
+		switch {
+		case succeeded[owner] && held != len(set):
+			t.Errorf("%s succeeded but holds %d of %d nodes", owner, held, len(set))
+		case !succeeded[owner] && held != 0:
+			t.Errorf("%s failed but holds %d nodes", owner, held)
+		}
+	}
+
+	// The set of agent-f overlaps no other, so it always gets its nodes
+	if !succeeded["agent-f"] {
+		t.Error("agent-f, whose set overlaps no other, did not claim it")
+	}
+	for _, n := range st.AllNodes() {
+		if n.WorkflowState == schema.WorkflowClaimed {
+			if _, ok := claimedBy[n.ID.String()]; !ok {
+				t.Errorf("node %s claimed by %s outside every agent's set", n.ID, n.ClaimedBy)
+			}
+		}
+	}
+	if len(claimedBy) == 0 {
+		t.Error("no agent claimed anything")
+	}
+}
+
+// TestMultiNodeOperations_WaitForTouchedNodes holds the lock of one node an
+// operation writes or cites, away from the node it is called on, and checks
+// that the operation waits for it and then completes.
+func TestMultiNodeOperations_WaitForTouchedNodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim []string // claimed by "prover" before the operation
+		held  string
+		op    func(svc *ProofService) error
+	}{
+		{
+			name: "ArchiveSubtree",
+			held: "1.1.2",
+			op: func(svc *ProofService) error {
+				_, err := svc.ArchiveSubtree(parseNodeID(t, "1.1"), false)
+				return err
+			},
+		},
+		{
+			name: "AcceptSubtree",
+			held: "1.1.1",
+			op: func(svc *ProofService) error {
+				_, err := svc.AcceptSubtree(parseNodeID(t, "1.1"))
+				return err
+			},
+		},
+		{
+			name:  "MoveNode",
+			claim: []string{"1.2"},
+			held:  "1.1.2",
+			op: func(svc *ProofService) error {
+				_, err := svc.MoveNode(parseNodeID(t, "1.1"), parseNodeID(t, "1.2"), "prover")
+				return err
+			},
+		},
+		{
+			name:  "RenumberSubtree",
+			claim: []string{"1"},
+			held:  "1.1.1",
+			op: func(svc *ProofService) error {
+				_, err := svc.RenumberSubtree(parseNodeID(t, "1"), "prover")
+				return err
+			},
+		},
+		{
+			name:  "RefineNodeBulkWithDeps",
+			claim: []string{"1.2"},
+			held:  "1.3",
+			op: func(svc *ProofService) error {
+				_, err := svc.RefineNodeBulkWithDeps(parseNodeID(t, "1.2"), "prover", []ChildSpecWithDeps{{
+					ChildSpec:    ChildSpec{NodeType: schema.NodeTypeClaim, Statement: "Uses 1.3", Inference: schema.InferenceModusPonens},
+					Dependencies: []types.NodeID{parseNodeID(t, "1.3")},
+				}})
+				return err
+			},
+		},
+		{
+			name: "ClaimNext",
+			held: "1.1.1",
+			op: func(svc *ProofService) error {
+				_, err := svc.ClaimNext("agent", time.Hour, ClaimNextOptions{})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := setupArchiveSubtreeTest(t)
+			for _, id := range tt.claim {
+				if err := svc.ClaimNode(parseNodeID(t, id), "prover", time.Hour); err != nil {
+					t.Fatalf("ClaimNode(%s) failed: %v", id, err)
+				}
+			}
+
+			held := []types.NodeID{parseNodeID(t, tt.held)}
+			svc.lockNodes(held)
+			done := make(chan error, 1)
+			go func() { done <- tt.op(svc) }()
+
+			select {
+			case err := <-done:
+				svc.unlockNodes(held)
+				t.Fatalf("%s returned (err = %v) while node %s was locked", tt.name, err, tt.held)
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			svc.unlockNodes(held)
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("%s failed after node %s was released: %v", tt.name, tt.held, err)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("%s still blocked after node %s was released", tt.name, tt.held)
+			}
+		})
+	}
+}
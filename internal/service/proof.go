@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tobias/vibefeld/internal/config"
@@ -16,6 +18,7 @@ import (
 	"github.com/tobias/vibefeld/internal/fs"
 	"github.com/tobias/vibefeld/internal/ledger"
 	"github.com/tobias/vibefeld/internal/lemma"
+	"github.com/tobias/vibefeld/internal/lock"
 	"github.com/tobias/vibefeld/internal/node"
 	"github.com/tobias/vibefeld/internal/schema"
 	"github.com/tobias/vibefeld/internal/state"
@@ -26,54 +29,87 @@ import (
 // ErrConcurrentModification is returned when an operation fails due to
 // concurrent modification of the proof state. Callers should retry the
 // operation after reloading the current state.
-// Exit code: 1 (retriable)
-var ErrConcurrentModification = aferrors.New(aferrors.VALIDATION_INVARIANT_FAILED, "concurrent modification detected")
+// Its code is CONCURRENT_MODIFICATION; before error codes were exposed it
+// shared VALIDATION_INVARIANT_FAILED with unrelated validation failures.
+// Exit code: 4 (conflict)
+var ErrConcurrentModification = aferrors.New(aferrors.CONCURRENT_MODIFICATION, "concurrent modification detected")
 
 // ErrMaxDepthExceeded is returned when an operation would exceed the configured MaxDepth.
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrMaxDepthExceeded = aferrors.New(aferrors.DEPTH_EXCEEDED, "maximum proof depth exceeded")
 
 // ErrMaxChildrenExceeded is returned when an operation would exceed the configured MaxChildren.
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrMaxChildrenExceeded = aferrors.New(aferrors.REFINEMENT_LIMIT_EXCEEDED, "maximum children per node exceeded")
 
 // ErrBlockingChallenges is returned when an operation cannot proceed due to
 // unresolved blocking challenges (critical or major severity) on a node.
-// Exit code: 2 (blocked)
-var ErrBlockingChallenges = aferrors.New(aferrors.NODE_BLOCKED, "node has unresolved blocking challenges")
+// Its code is BLOCKING_CHALLENGES; before error codes were exposed it
+// shared NODE_BLOCKED with other blocked nodes.
+// Exit code: 5 (blocked)
+var ErrBlockingChallenges = aferrors.New(aferrors.BLOCKING_CHALLENGES, "node has unresolved blocking challenges")
+
+// ErrUnvalidatedDependencies is returned when a node cannot be accepted because
+// one of its reference dependencies is not yet validated or admitted, or one
+// of its validation dependencies is not yet validated.
+// Exit code: 5 (blocked)
+var ErrUnvalidatedDependencies = aferrors.New(aferrors.DEPENDENCIES_UNVALIDATED, "node has unvalidated dependencies")
 
 // ErrNotClaimed is returned when an operation requires a node to be claimed
 // but the node is not currently claimed by any owner.
-// Exit code: 1 (retriable - caller should claim the node first)
+// Exit code: 4 (conflict - caller should claim the node first)
 var ErrNotClaimed = aferrors.New(aferrors.NOT_CLAIM_HOLDER, "node is not claimed")
 
 // ErrOwnerMismatch is returned when an operation is attempted by an owner
 // that does not match the current claim owner of the node.
-// Exit code: 1 (retriable - caller should claim the node)
+// Exit code: 4 (conflict - caller should claim the node)
 var ErrOwnerMismatch = aferrors.New(aferrors.NOT_CLAIM_HOLDER, "owner does not match")
 
+// ErrClaimNotExpired is returned by ReclaimNode when the node's claim has
+// not expired yet.
+// Exit code: 4 (conflict - the claim may expire later)
+var ErrClaimNotExpired = aferrors.New(aferrors.CLAIM_NOT_EXPIRED, "claim has not expired")
+
+// ErrNoAvailableNodes is returned by ClaimNext when no node matching the
+// request is available to claim.
+// Exit code: 4 (conflict - other agents may release nodes later)
+var ErrNoAvailableNodes = aferrors.New(aferrors.NO_AVAILABLE_NODES, "no available nodes")
+
 // ErrNodeNotFound is returned when a node does not exist.
-// Exit code: 3 (logic error)
+// Exit code: 3 (not found)
 var ErrNodeNotFound = aferrors.New(aferrors.NODE_NOT_FOUND, "node not found")
 
+// ErrChallengeNotFound is returned when a challenge does not exist.
+// Exit code: 3 (not found)
+var ErrChallengeNotFound = aferrors.New(aferrors.CHALLENGE_NOT_FOUND, "challenge not found")
+
 // ErrParentNotFound is returned when a parent node does not exist.
-// Exit code: 3 (logic error)
+// Exit code: 3 (not found)
 var ErrParentNotFound = aferrors.New(aferrors.PARENT_NOT_FOUND, "parent node not found")
 
+// ErrLemmaNotFound is returned when a lemma does not exist.
+// Exit code: 3 (not found)
+var ErrLemmaNotFound = aferrors.New(aferrors.LEMMA_NOT_FOUND, "lemma not found")
+
 // ErrEmptyInput is returned when a required input is empty or whitespace.
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrEmptyInput = aferrors.New(aferrors.EMPTY_INPUT, "required input cannot be empty")
 
 // ErrInvalidState is returned when an operation is attempted in an invalid state.
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrInvalidState = aferrors.New(aferrors.INVALID_STATE, "invalid state for operation")
 
+// ErrReadOnly is returned by every mutating method of a read-only
+// ProofService. See WithReadOnly.
+// Exit code: 6 (validation error)
+var ErrReadOnly = aferrors.New(aferrors.READ_ONLY, "proof service is read-only")
+
 // ErrAlreadyExists is returned when attempting to create something that already exists.
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrAlreadyExists = aferrors.New(aferrors.ALREADY_EXISTS, "resource already exists")
 
 // ErrInvalidTimeout is returned when a timeout value is invalid (e.g., negative or zero).
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrInvalidTimeout = aferrors.New(aferrors.INVALID_TIMEOUT, "timeout must be positive")
 
 // wrapSequenceMismatch converts ledger.ErrSequenceMismatch to ErrConcurrentModification
@@ -85,8 +121,55 @@ func wrapSequenceMismatch(err error, operation string) error {
 	return err
 }
 
+// checkDependenciesValidated returns ErrUnvalidatedDependencies naming the
+// first reference dependency of n that is not validated or admitted.
+// Dependencies listed in accepting are treated as validated, so nodes accepted
+// together in one batch may depend on each other.
+func checkDependenciesValidated(st *state.State, n *node.Node, accepting map[string]bool) error {
+	for _, depID := range n.Dependencies {
+		if accepting[depID.String()] {
+			continue
+		}
+		dep := st.GetNode(depID)
+		if dep == nil {
+			return fmt.Errorf("%w: node %s depends on %s, which does not exist",
+				ErrUnvalidatedDependencies, n.ID.String(), depID.String())
+		}
+		if dep.EpistemicState != schema.EpistemicValidated && dep.EpistemicState != schema.EpistemicAdmitted {
+			return fmt.Errorf("%w: node %s depends on %s, which is %s",
+				ErrUnvalidatedDependencies, n.ID.String(), depID.String(), dep.EpistemicState)
+		}
+	}
+	return nil
+}
+
+// checkValidationDepsValidated returns ErrUnvalidatedDependencies naming
+// every validation dependency of n that is not validated. Unlike reference
+// dependencies, an admitted validation dependency does not count. Nodes
+// listed in accepting are treated as validated.
+func checkValidationDepsValidated(st *state.State, n *node.Node, accepting map[string]bool) error {
+	var blocking []string
+	for _, depID := range n.ValidationDeps {
+		if accepting[depID.String()] {
+			continue
+		}
+		dep := st.GetNode(depID)
+		if dep == nil {
+			blocking = append(blocking, depID.String()+" (not found)")
+		} else if dep.EpistemicState != schema.EpistemicValidated {
+			blocking = append(blocking, fmt.Sprintf("%s (%s)", depID.String(), dep.EpistemicState))
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: node %s has validation dependencies that are not validated: %s "+
+		"(validation dependencies must be validated; plain dependencies may be admitted)",
+		ErrUnvalidatedDependencies, n.ID.String(), strings.Join(blocking, ", "))
+}
+
 // formatBlockingChallengesError creates an error message listing blocking challenges.
-func formatBlockingChallengesError(nodeID types.NodeID, challenges []*state.Challenge) error {
+func formatBlockingChallengesError(nodeID types.NodeID, challenges []*state.Challenge, severities []string) error {
 	if len(challenges) == 0 {
 		return nil
 	}
@@ -94,8 +177,8 @@ func formatBlockingChallengesError(nodeID types.NodeID, challenges []*state.Chal
 	for _, c := range challenges {
 		ids = append(ids, c.ID)
 	}
-	return fmt.Errorf("%w: node %s has %d blocking challenge(s): %s",
-		ErrBlockingChallenges, nodeID.String(), len(challenges), strings.Join(ids, ", "))
+	return fmt.Errorf("%w: node %s has %d blocking challenge(s): %s (blocking severities: %s)",
+		ErrBlockingChallenges, nodeID.String(), len(challenges), strings.Join(ids, ", "), strings.Join(severities, ", "))
 }
 
 // TaintChange represents a change in taint state for a node.
@@ -118,11 +201,80 @@ type RecomputeTaintResult struct {
 type ProofService struct {
 	path string
 	cfg  *config.Config // cached config, loaded lazily
+
+	// allowUnvalidatedDeps disables the dependency gate in AcceptNode.
+	// The zero value enforces the gate.
+	allowUnvalidatedDeps bool
+
+	// dryRun makes mutating methods validate and record their events in
+	// preview instead of writing them. See WithDryRun.
+	dryRun  bool
+	preview []ledger.Event
+
+	// readOnly makes every mutating method fail with ErrReadOnly.
+	// See WithReadOnly.
+	readOnly bool
+
+	// noAutoTaint stops epistemic state changes from appending
+	// taint_recomputed events. The zero value records them. See WithAutoTaint.
+	noAutoTaint bool
+
+	// cache holds the state from the last LoadState so later calls replay
+	// only new events. See replayState.
+	cacheMu sync.Mutex
+	cache   *stateCache
+}
+
+// Option is a functional option for configuring a ProofService.
+type Option func(*ProofService)
+
+// WithDryRun enables or disables dry-run mode.
+//
+// In dry-run mode every mutating method runs its full validation and returns
+// the error it would have returned, but nothing is written to the ledger or
+// the proof directory. The events a successful call would have appended are
+// collected instead and can be read back with PreviewEvents. Later calls on
+// the same service see the effects of earlier previewed ones, so a sequence
+// of operations can be validated as a whole. Derived taint_recomputed events
+// are not previewed.
+func WithDryRun(enabled bool) Option {
+	return func(s *ProofService) {
+		s.dryRun = enabled
+	}
+}
+
+// WithReadOnly enables or disables read-only mode.
+//
+// In read-only mode the service never writes to the proof directory: every
+// mutating method fails with ErrReadOnly, while queries, exports, and
+// LoadState work as usual, even on a directory mounted read-only. This is
+// enforced where writes happen (see checkWritable) rather than in each
+// method, so mutating methods added later are covered too. A call that
+// would fail validation anyway may report that error instead. Read-only
+// mode takes precedence over dry-run mode.
+func WithReadOnly(enabled bool) Option {
+	return func(s *ProofService) {
+		s.readOnly = enabled
+	}
+}
+
+// WithAutoTaint enables or disables automatic taint recording.
+//
+// When enabled (the default), AcceptNode, AdmitNode, RefuteNode, ArchiveNode,
+// ReopenNode and their bulk variants recompute taint for the changed node,
+// its descendants, and the nodes that depend on it, and append a
+// taint_recomputed event for each node whose taint changed. Disabling it
+// skips that work for performance-sensitive batch operations; taint is still
+// derived on replay and can be recorded later with RecomputeAllTaint.
+func WithAutoTaint(enabled bool) Option {
+	return func(s *ProofService) {
+		s.noAutoTaint = !enabled
+	}
 }
 
 // NewProofService creates a new ProofService for the given proof directory.
 // Returns an error if the directory is invalid or inaccessible.
-func NewProofService(path string) (*ProofService, error) {
+func NewProofService(path string, opts ...Option) (*ProofService, error) {
 	// Validate path is not empty or whitespace
 	if strings.TrimSpace(path) == "" {
 		return nil, fmt.Errorf("%w: path", ErrEmptyInput)
@@ -141,7 +293,47 @@ func NewProofService(path string) (*ProofService, error) {
 		return nil, fmt.Errorf("%w: path is not a directory", ErrInvalidState)
 	}
 
-	return &ProofService{path: path}, nil
+	s := &ProofService{path: path}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// NewReadOnlyProofService creates a ProofService for the given proof
+// directory that refuses all mutations; see WithReadOnly.
+func NewReadOnlyProofService(path string, opts ...Option) (*ProofService, error) {
+	return NewProofService(path, append(opts, WithReadOnly(true))...)
+}
+
+// ReadOnly reports whether the service is in read-only mode.
+func (s *ProofService) ReadOnly() bool {
+	return s.readOnly
+}
+
+// checkWritable returns ErrReadOnly in read-only mode. Every write to the
+// proof directory goes through it: appendIfSequence for the ledger, and
+// the methods that write other files directly.
+func (s *ProofService) checkWritable() error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// DryRun reports whether the service is in dry-run mode.
+func (s *ProofService) DryRun() bool {
+	return s.dryRun
+}
+
+// PreviewEvents returns the events that mutating calls made in dry-run mode
+// would have appended, in order. Returns nil when not in dry-run mode.
+func (s *ProofService) PreviewEvents() []ledger.Event {
+	if len(s.preview) == 0 {
+		return nil
+	}
+	return append([]ledger.Event(nil), s.preview...)
 }
 
 // LoadConfig loads and caches the config from meta.json.
@@ -167,6 +359,27 @@ func (s *ProofService) LoadConfig() (*config.Config, error) {
 	return s.cfg, nil
 }
 
+// BlockingSeverities returns the challenge severities configured to block
+// node acceptance (see config.Config.BlockingSeverities).
+func (s *ProofService) BlockingSeverities() ([]string, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.BlockingSeverities) == 0 {
+		return schema.DefaultBlockingSeverities(), nil
+	}
+	return cfg.BlockingSeverities, nil
+}
+
+// SetRequireValidatedDependencies controls whether accepting a node requires
+// all of its reference dependencies to be validated or admitted first.
+// The requirement is enforced by default; workflows that deliberately
+// validate bottom-up can turn it off.
+func (s *ProofService) SetRequireValidatedDependencies(require bool) {
+	s.allowUnvalidatedDeps = !require
+}
+
 // Config returns the current config, loading it if necessary.
 // Returns an error if the config cannot be loaded (e.g., permission denied,
 // corrupt JSON). Note that a missing meta.json returns a default config,
@@ -279,6 +492,9 @@ func Init(proofDir, conjecture, author string) error {
 // Creates the initial proof structure and ledger event.
 // Returns an error if the proof is already initialized or validation fails.
 func (s *ProofService) Init(conjecture, author string) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
 	return Init(s.path, conjecture, author)
 }
 
@@ -288,17 +504,105 @@ func (s *ProofService) getLedger() (*ledger.Ledger, error) {
 	return ledger.NewLedger(ledgerDir)
 }
 
+// lockNodes blocks until this process holds the node locks of ids for the
+// proof, acquiring them in sorted order (see lock.NodeSet). Operations that
+// touch several nodes lock every node they write for the whole
+// load-check-append sequence, so two of them working on overlapping node
+// sets in this process wait for each other instead of deadlocking.
+//
+// The locks do not narrow the sequence check: the append is still checked
+// against the latest sequence of the whole ledger, so any other append in
+// between, from this process or another, still fails the operation with
+// ErrConcurrentModification.
+// Every lockNodes must be paired with unlockNodes on the same ids.
+func (s *ProofService) lockNodes(ids []types.NodeID) {
+	lock.NodeSetFor(s.path).LockAll(ids)
+}
+
+// unlockNodes releases the node locks taken by lockNodes, in reverse order.
+func (s *ProofService) unlockNodes(ids []types.NodeID) {
+	lock.NodeSetFor(s.path).UnlockAll(ids)
+}
+
+// lockTouchedNodes locks the nodes that touched reports for the current
+// state, for operations whose node set depends on the state (a subtree, a
+// selected candidate, newly allocated child IDs). It returns the state,
+// loaded again once the locks are held, and the locked ids, which the
+// caller must release with unlockNodes.
+//
+// If another operation changed the set while this one waited, so that the
+// reloaded state touches a node that is not locked, the locks are released
+// and taken again for the new set.
+func (s *ProofService) lockTouchedNodes(touched func(*state.State) []types.NodeID) (*state.State, []types.NodeID, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := touched(st)
+	for {
+		s.lockNodes(ids)
+		st, err = s.LoadState()
+		if err != nil {
+			s.unlockNodes(ids)
+			return nil, nil, err
+		}
+		again := touched(st)
+		if coversNodes(ids, again) {
+			return st, ids, nil
+		}
+		s.unlockNodes(ids)
+		ids = again
+	}
+}
+
+// coversNodes reports whether every id in ids is also in locked.
+func coversNodes(locked, ids []types.NodeID) bool {
+	held := make(map[string]bool, len(locked))
+	for _, id := range locked {
+		held[id.String()] = true
+	}
+	for _, id := range ids {
+		if !held[id.String()] {
+			return false
+		}
+	}
+	return true
+}
+
+// subtreeIDs returns the IDs of the node with rootID and all of its
+// descendants, or nil if rootID doesn't exist.
+func subtreeIDs(st *state.State, rootID types.NodeID) []types.NodeID {
+	subtree := st.Subtree(rootID)
+	ids := make([]types.NodeID, len(subtree))
+	for i, n := range subtree {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
 // LoadState loads and returns the current proof state by replaying ledger events.
+// If the ledger has a valid snapshot, only events after it are replayed.
+// The ledger-derived state is also cached in memory, so repeated calls only
+// replay events appended since the previous call; the cache is dropped when
+// this service appends an event or the ledger is found to have been rewound.
+// In dry-run mode the previewed events are applied on top, so each call sees
+// the effects of the calls before it.
 // Also loads assumptions and externals from filesystem.
 func (s *ProofService) LoadState() (*state.State, error) {
 	ldg, err := s.getLedger()
 	if err != nil {
 		return nil, err
 	}
-	st, err := state.Replay(ldg)
+	st, err := s.replayState(ldg)
 	if err != nil {
 		return nil, err
 	}
+	for _, event := range s.preview {
+		if err := state.Apply(st, event); err != nil {
+			return nil, fmt.Errorf("applying previewed %s event: %w", event.Type(), err)
+		}
+		st.SetLatestSeq(st.LatestSeq() + 1)
+	}
 
 	// Load assumptions from filesystem
 	if err := s.loadAssumptionsIntoState(st); err != nil {
@@ -321,6 +625,167 @@ func (s *ProofService) LoadState() (*state.State, error) {
 	return st, nil
 }
 
+// Snapshot writes a snapshot of the current ledger-derived state so that later
+// calls to LoadState only replay events appended after it.
+// Returns the sequence number the snapshot represents.
+func (s *ProofService) Snapshot() (int, error) {
+	if err := s.checkWritable(); err != nil {
+		return 0, err
+	}
+	ldg, err := s.getLedger()
+	if err != nil {
+		return 0, err
+	}
+	st, err := state.ReplayWithSnapshot(ldg)
+	if err != nil {
+		return 0, err
+	}
+	if st.LatestSeq() == 0 {
+		return 0, fmt.Errorf("%w: cannot snapshot an empty ledger", ErrInvalidState)
+	}
+	if err := state.WriteSnapshot(ldg, st); err != nil {
+		return 0, err
+	}
+	return st.LatestSeq(), nil
+}
+
+// GetSubtree returns the node with rootID followed by all of its
+// descendants, sorted by NodeID. See state.State.Subtree.
+// Returns ErrNodeNotFound if rootID doesn't exist.
+func (s *ProofService) GetSubtree(rootID types.NodeID) ([]*node.Node, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	subtree := st.Subtree(rootID)
+	if subtree == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, rootID.String())
+	}
+	return subtree, nil
+}
+
+// DependentsOf returns the IDs of the nodes that list nodeID in their
+// Dependencies or ValidationDeps, sorted by NodeID. These are the nodes
+// whose taint may change if nodeID is refuted or amended.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+func (s *ProofService) DependentsOf(nodeID types.NodeID) ([]types.NodeID, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	if st.GetNode(nodeID) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	return st.Dependents(nodeID), nil
+}
+
+// TransitiveDependencies returns every node nodeID rests on through its
+// Dependencies, followed recursively, deduplicated, and sorted by NodeID.
+// Dependencies that don't exist are listed but not followed.
+//
+// The walk terminates on cyclic dependency graphs. If it encountered a
+// cycle, the full list is still returned together with an error wrapping
+// ErrCircularDependency, so callers can report both.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+func (s *ProofService) TransitiveDependencies(nodeID types.NodeID) ([]types.NodeID, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	if st.GetNode(nodeID) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+
+	deps, cyclic := st.TransitiveDependencies(nodeID)
+	if cyclic {
+		return deps, fmt.Errorf("%w: dependencies of %s", ErrCircularDependency, nodeID.String())
+	}
+	return deps, nil
+}
+
+// ValidationDepsOf returns the validation dependencies of nodeID: the nodes
+// that must be validated before it can be accepted. Unlike its reference
+// Dependencies, which may be admitted, each of these must be validated.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+func (s *ProofService) ValidationDepsOf(nodeID types.NodeID) ([]types.NodeID, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	return append([]types.NodeID{}, n.ValidationDeps...), nil
+}
+
+// Diff returns the node changes between ledger sequence numbers fromSeq and toSeq.
+// See state.Diff for the comparison rules.
+func (s *ProofService) Diff(fromSeq, toSeq int) (*state.StateDiff, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+	return state.Diff(ldg, fromSeq, toSeq)
+}
+
+// Activity returns per-agent action counts aggregated from the ledger,
+// most active agent first. See state.Activity for the attribution rules.
+func (s *ProofService) Activity() ([]*state.AgentActivity, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+	return state.Activity(ldg)
+}
+
+// ClaimDurations returns how long each node was held in every claim of its
+// history, keyed by node ID string, in ledger order. Claims still held are
+// measured up to now. Nodes that were never claimed are omitted. A node
+// with many short claims is one that agents keep picking up and dropping.
+// See state.ClaimHistory for how claims are delimited.
+func (s *ProofService) ClaimDurations() (map[string][]time.Duration, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+	history, err := state.ClaimHistory(ldg)
+	if err != nil {
+		return nil, err
+	}
+
+	now := types.Now()
+	durations := make(map[string][]time.Duration, len(history))
+	for id, periods := range history {
+		for _, p := range periods {
+			durations[id] = append(durations[id], p.Duration(now))
+		}
+	}
+	return durations, nil
+}
+
+// NodeHistory returns every ledger event that references nodeID, in order,
+// each with a human-readable summary. Unlike LoadAmendmentHistory it covers
+// the node's whole timeline: creation, claims, challenges, epistemic and
+// taint changes, and amendments. See state.NodeHistory for which events
+// are included.
+//
+// Returns ErrNodeNotFound if no event references nodeID.
+func (s *ProofService) NodeHistory(nodeID types.NodeID) ([]NodeHistoryEntry, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := state.NodeHistory(ldg, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	return entries, nil
+}
+
 // loadAssumptionsIntoState loads all assumptions from filesystem into state.
 func (s *ProofService) loadAssumptionsIntoState(st *state.State) error {
 	ids, err := fs.ListAssumptions(s.path)
@@ -424,7 +889,7 @@ func (s *ProofService) CreateNode(id types.NodeID, nodeType schema.NodeType, sta
 	}
 
 	event := ledger.NewNodeCreated(*n)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	return wrapSequenceMismatch(err, "CreateNode")
 }
 
@@ -473,10 +938,80 @@ func (s *ProofService) ClaimNode(id types.NodeID, owner string, timeout time.Dur
 	timeoutTS := types.FromTime(time.Now().Add(timeout))
 
 	event := ledger.NewNodesClaimed([]types.NodeID{id}, owner, timeoutTS)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	return wrapSequenceMismatch(err, "ClaimNode")
 }
 
+// ClaimNodeBulk claims multiple nodes for an agent in a single atomic operation.
+// This lets an agent grab a batch of nodes (e.g. sibling leaves) without risking
+// a partial claim if one of them turns out to be unavailable.
+//
+// Every node is validated before any mutation: if any node is missing, already
+// claimed, or listed more than once, the whole operation fails with an error
+// naming the offending node and the ledger is left untouched. On success a single
+// NodesClaimed event covering all nodes is appended.
+//
+// The nodes are locked in sorted order for the whole call (see lockNodes), so
+// concurrent bulk claims of overlapping sets in this process cannot deadlock.
+//
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ClaimNodeBulk(ids []types.NodeID, owner string, timeout time.Duration) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("%w: at least one node ID is required", ErrEmptyInput)
+	}
+
+	// Validate owner
+	if strings.TrimSpace(owner) == "" {
+		return fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+
+	// Validate timeout
+	if timeout <= 0 {
+		return ErrInvalidTimeout
+	}
+
+	s.lockNodes(ids)
+	defer s.unlockNodes(ids)
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	// Validate all nodes exist and are available before any mutation
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id.String()] {
+			return fmt.Errorf("%w: node %s is listed more than once", ErrInvalidState, id.String())
+		}
+		seen[id.String()] = true
+
+		n := st.GetNode(id)
+		if n == nil {
+			return fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
+		}
+		if n.WorkflowState != schema.WorkflowAvailable {
+			return fmt.Errorf("%w: node %s is not available", ErrInvalidState, id.String())
+		}
+	}
+
+	// Get ledger and append a single claim event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	// Calculate timeout timestamp
+	timeoutTS := types.FromTime(time.Now().Add(timeout))
+
+	event := ledger.NewNodesClaimed(ids, owner, timeoutTS)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, "ClaimNodeBulk")
+}
+
 // RefreshClaim extends the claim timeout for a node the caller owns.
 // This allows agents to extend their claims without releasing and reclaiming,
 // which would risk another agent claiming the node in between.
@@ -530,16 +1065,40 @@ func (s *ProofService) RefreshClaim(id types.NodeID, owner string, timeout time.
 	newTimeoutTS := types.FromTime(time.Now().Add(timeout))
 
 	event := ledger.NewClaimRefreshed(id, owner, newTimeoutTS)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	return wrapSequenceMismatch(err, "RefreshClaim")
 }
 
-// ReleaseNode releases a claimed node, making it available again.
-// Returns an error if the node is not claimed or the owner doesn't match.
+// ReassignClaim transfers a claimed node from fromOwner to toOwner with a new
+// timeout. The handoff is recorded as a single NodesReassigned event, so unlike
+// release-then-claim there is no window in which a third agent can take the node.
+//
+// Returns an error if either owner is empty, the owners are the same, the node
+// doesn't exist, is not claimed, or is not claimed by fromOwner.
 //
 // Returns ErrConcurrentModification if the proof was modified by another process
 // since state was loaded. Callers should retry after reloading state.
-func (s *ProofService) ReleaseNode(id types.NodeID, owner string) error {
+func (s *ProofService) ReassignClaim(id types.NodeID, fromOwner, toOwner string, timeout time.Duration) error {
+	// Validate owners
+	if strings.TrimSpace(fromOwner) == "" {
+		return fmt.Errorf("%w: from owner", ErrEmptyInput)
+	}
+	if strings.TrimSpace(toOwner) == "" {
+		return fmt.Errorf("%w: to owner", ErrEmptyInput)
+	}
+	if fromOwner == toOwner {
+		return fmt.Errorf("%w: node %s is already claimed by %s", ErrInvalidState, id.String(), toOwner)
+	}
+
+	// Validate timeout
+	if timeout <= 0 {
+		return ErrInvalidTimeout
+	}
+
+	locked := []types.NodeID{id}
+	s.lockNodes(locked)
+	defer s.unlockNodes(locked)
+
 	// Load current state and capture sequence for CAS
 	st, err := s.LoadState()
 	if err != nil {
@@ -558,36 +1117,117 @@ func (s *ProofService) ReleaseNode(id types.NodeID, owner string) error {
 		return ErrNotClaimed
 	}
 
-	// Check if owner matches
-	if n.ClaimedBy != owner {
-		return ErrOwnerMismatch
+	// Check if the current owner matches
+	if n.ClaimedBy != fromOwner {
+		return fmt.Errorf("%w: node is claimed by %s, not %s", ErrOwnerMismatch, n.ClaimedBy, fromOwner)
 	}
 
-	// Get ledger and append release event with CAS
+	// Get ledger and append reassignment event with CAS
 	ldg, err := s.getLedger()
 	if err != nil {
 		return err
 	}
 
-	event := ledger.NewNodesReleased([]types.NodeID{id})
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
-	return wrapSequenceMismatch(err, "ReleaseNode")
+	// Calculate timeout timestamp for the new owner
+	timeoutTS := types.FromTime(time.Now().Add(timeout))
+
+	event := ledger.NewNodesReassigned([]types.NodeID{id}, fromOwner, toOwner, timeoutTS)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, "ReassignClaim")
 }
 
-// RefineNode adds a child node to a claimed parent node.
-// Returns an error if the parent is not claimed by the owner or validation fails.
-//
-// Deprecated: Use Refine(RefineSpec{...}) instead, which provides a cleaner API
-// by consolidating all parameters into a single struct.
+// ReleaseNode releases a claimed node, making it available again.
+// Returns an error if the node is not claimed or the owner doesn't match.
 //
-// Returns ErrMaxDepthExceeded if the child node's depth would exceed config.MaxDepth.
-// Returns ErrMaxChildrenExceeded if the parent node already has config.MaxChildren children.
 // Returns ErrConcurrentModification if the proof was modified by another process
 // since state was loaded. Callers should retry after reloading state.
-func (s *ProofService) RefineNode(parentID types.NodeID, owner string, childID types.NodeID, nodeType schema.NodeType, statement string, inference schema.InferenceType) error {
-	return s.Refine(RefineSpec{
-		ParentID:  parentID,
-		Owner:     owner,
+func (s *ProofService) ReleaseNode(id types.NodeID, owner string) error {
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	// Check if node exists
+	n := st.GetNode(id)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
+	}
+
+	// Check if node is claimed
+	if n.WorkflowState != schema.WorkflowClaimed {
+		return ErrNotClaimed
+	}
+
+	// Check if owner matches
+	if n.ClaimedBy != owner {
+		return ErrOwnerMismatch
+	}
+
+	// Get ledger and append release event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	event := ledger.NewNodesReleased([]types.NodeID{id})
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, "ReleaseNode")
+}
+
+// ReapExpiredClaims releases every node whose claim has expired, so that work
+// held by a crashed or stalled agent becomes available again.
+// A claim is expired when its claim time plus its timeout is before types.Now().
+// All expired claims are released in a single NodesReleased event.
+//
+// Returns the released node IDs in sorted order, or nil if no claim had expired.
+//
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ReapExpiredClaims() ([]types.NodeID, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	expectedSeq := st.LatestSeq()
+
+	var expired []types.NodeID
+	for _, n := range expiredClaims(st, types.Now()) {
+		expired = append(expired, n.ID)
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	event := ledger.NewNodesReleased(expired)
+	if _, err := s.appendIfSequence(ldg, event, expectedSeq); err != nil {
+		return nil, wrapSequenceMismatch(err, "ReapExpiredClaims")
+	}
+	return expired, nil
+}
+
+// RefineNode adds a child node to a claimed parent node.
+// Returns an error if the parent is not claimed by the owner or validation fails.
+//
+// Deprecated: Use Refine(RefineSpec{...}) instead, which provides a cleaner API
+// by consolidating all parameters into a single struct.
+//
+// Returns ErrMaxDepthExceeded if the child node's depth would exceed config.MaxDepth.
+// Returns ErrMaxChildrenExceeded if the parent node already has config.MaxChildren children.
+// Returns ErrCircularDependency, naming the offending edge and cycle path, if a
+// dependency would create a cycle; nothing is written in that case.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) RefineNode(parentID types.NodeID, owner string, childID types.NodeID, nodeType schema.NodeType, statement string, inference schema.InferenceType) error {
+	return s.Refine(RefineSpec{
+		ParentID:  parentID,
+		Owner:     owner,
 		ChildID:   childID,
 		NodeType:  nodeType,
 		Statement: statement,
@@ -605,6 +1245,7 @@ func (s *ProofService) RefineNode(parentID types.NodeID, owner string, childID t
 //
 // Returns ErrMaxDepthExceeded if the child node's depth would exceed config.MaxDepth.
 // Returns ErrMaxChildrenExceeded if the parent node already has config.MaxChildren children.
+// Returns ErrCircularDependency if a dependency would create a cycle.
 // Returns ErrConcurrentModification if the proof was modified by another process
 // since state was loaded. Callers should retry after reloading state.
 func (s *ProofService) RefineNodeWithDeps(parentID types.NodeID, owner string, childID types.NodeID, nodeType schema.NodeType, statement string, inference schema.InferenceType, dependencies []types.NodeID) error {
@@ -631,6 +1272,7 @@ func (s *ProofService) RefineNodeWithDeps(parentID types.NodeID, owner string, c
 //
 // Returns ErrMaxDepthExceeded if the child node's depth would exceed config.MaxDepth.
 // Returns ErrMaxChildrenExceeded if the parent node already has config.MaxChildren children.
+// Returns ErrCircularDependency if a dependency would create a cycle.
 // Returns ErrConcurrentModification if the proof was modified by another process
 // since state was loaded. Callers should retry after reloading state.
 func (s *ProofService) RefineNodeWithAllDeps(parentID types.NodeID, owner string, childID types.NodeID, nodeType schema.NodeType, statement string, inference schema.InferenceType, dependencies []types.NodeID, validationDeps []types.NodeID) error {
@@ -698,31 +1340,37 @@ func (s *ProofService) Refine(spec RefineSpec) error {
 		return err
 	}
 
-	// Create provider for cycle check
-	provider := &stateDependencyProvider{st: st}
-
-	// Validate that all reference dependencies exist and don't create cycles
+	// Validate that all reference dependencies exist
 	for _, depID := range spec.Dependencies {
 		if st.GetNode(depID) == nil {
 			return fmt.Errorf("invalid dependency: node %s not found", depID.String())
 		}
-		
-		if res := cycle.WouldCreateCycle(provider, spec.ParentID, depID); res.HasCycle {
-			return fmt.Errorf("%w: adding dependency %s -> %s would create cycle %v", ErrCircularDependency, spec.ParentID.String(), depID.String(), res.Path)
-		}
 	}
 
-	// Validate that all validation dependencies exist and don't create cycles
+	// Validate that all validation dependencies exist
 	for _, valDepID := range spec.ValidationDeps {
 		if st.GetNode(valDepID) == nil {
 			return fmt.Errorf("invalid validation dependency: node %s not found", valDepID.String())
 		}
-		
-		if res := cycle.WouldCreateCycle(provider, spec.ParentID, valDepID); res.HasCycle {
-			return fmt.Errorf("%w: adding validation dependency %s -> %s would create cycle %v", ErrCircularDependency, spec.ParentID.String(), valDepID.String(), res.Path)
+	}
+
+	// Validate that all cited lemmas exist and do not restate an ancestor
+	for _, lemmaID := range spec.Lemmas {
+		lem := st.GetLemma(lemmaID)
+		if lem == nil {
+			return fmt.Errorf("%w: %s", ErrLemmaNotFound, lemmaID)
+		}
+		if lem.SourceNodeID.Equal(spec.ParentID) || lem.SourceNodeID.IsAncestorOf(spec.ParentID) {
+			return fmt.Errorf("%w: lemma %s was extracted from node %s and cannot justify a step of its own proof",
+				ErrInvalidState, lemmaID, lem.SourceNodeID.String())
 		}
 	}
 
+	// Reject dependencies that would create a cycle before anything is written
+	if err := checkRefineCycles(st, spec); err != nil {
+		return err
+	}
+
 	// Create the child node with both dependency types
 	opts := node.NodeOptions{
 		Dependencies:   spec.Dependencies,
@@ -732,6 +1380,9 @@ func (s *ProofService) Refine(spec RefineSpec) error {
 	if err != nil {
 		return err
 	}
+	if len(spec.Lemmas) > 0 {
+		child.Lemmas = append([]string(nil), spec.Lemmas...)
+	}
 
 	// Get ledger and append event with CAS
 	ldg, err := s.getLedger()
@@ -740,13 +1391,57 @@ func (s *ProofService) Refine(spec RefineSpec) error {
 	}
 
 	event := ledger.NewNodeCreated(*child)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	return wrapSequenceMismatch(err, "Refine")
 }
 
+// checkRefineCycles reports ErrCircularDependency if any dependency in spec
+// would close a cycle. Each edge is checked twice: from the new child, against
+// the current state extended with the proposed child node, and from the
+// parent, since the child's reasoning is part of the parent's justification.
+func checkRefineCycles(st *state.State, spec RefineSpec) error {
+	base := &stateDependencyProvider{st: st}
+	withChild := &amendedDependencyProvider{
+		base: base,
+		id:   spec.ChildID,
+		deps: append(append([]types.NodeID{}, spec.Dependencies...), spec.ValidationDeps...),
+	}
+
+	check := func(kind string, depID types.NodeID) error {
+		for _, c := range []struct {
+			provider cycle.DependencyProvider
+			from     types.NodeID
+		}{
+			{withChild, spec.ChildID},
+			{base, spec.ParentID},
+		} {
+			if res := cycle.WouldCreateCycle(c.provider, c.from, depID); res.HasCycle {
+				return fmt.Errorf("%w: adding %s %s -> %s would create cycle %v", ErrCircularDependency, kind, c.from.String(), depID.String(), res.Path)
+			}
+		}
+		return nil
+	}
+
+	for _, depID := range spec.Dependencies {
+		if err := check("dependency", depID); err != nil {
+			return err
+		}
+	}
+	for _, valDepID := range spec.ValidationDeps {
+		if err := check("validation dependency", valDepID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AcceptNode validates a node, marking it as verified correct.
 // Returns an error if the node doesn't exist.
-// Returns ErrBlockingChallenges if the node has unresolved critical or major challenges.
+// Returns ErrBlockingChallenges if the node has open challenges of a severity
+// configured as blocking (critical and major by default).
+// Returns ErrUnvalidatedDependencies if a validation dependency is not yet
+// validated, or a reference dependency is not yet validated or admitted
+// (unless disabled with SetRequireValidatedDependencies).
 //
 // After validation, automatically recomputes and emits taint state changes
 // for the node and any affected descendants.
@@ -765,7 +1460,11 @@ func (s *ProofService) AcceptNode(id types.NodeID) error {
 // exist but don't block validation.
 //
 // Returns an error if the node doesn't exist.
-// Returns ErrBlockingChallenges if the node has unresolved critical or major challenges.
+// Returns ErrBlockingChallenges if the node has open challenges of a severity
+// configured as blocking (critical and major by default).
+// Returns ErrUnvalidatedDependencies if a validation dependency is not yet
+// validated, or a reference dependency is not yet validated or admitted
+// (unless disabled with SetRequireValidatedDependencies).
 //
 // After validation, automatically recomputes and emits taint state changes
 // for the node and any affected descendants.
@@ -796,30 +1495,26 @@ func (s *ProofService) AcceptNodeWithNote(id types.NodeID, note string) error {
 		return fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
 	}
 
-	// Check for blocking challenges (critical or major severity)
-	blockingChallenges := st.GetBlockingChallengesForNode(id)
+	// Check for blocking challenges (severities configured as blocking)
+	severities, err := s.BlockingSeverities()
+	if err != nil {
+		return err
+	}
+	blockingChallenges := st.GetBlockingChallengesForNodeWithSeverities(id, severities)
 	if len(blockingChallenges) > 0 {
-		return formatBlockingChallengesError(id, blockingChallenges)
+		return formatBlockingChallengesError(id, blockingChallenges, severities)
 	}
 
-	// Check validation dependencies - all must be validated before this node can be accepted
-	if len(n.ValidationDeps) > 0 {
-		var unvalidatedDeps []string
-		for _, depID := range n.ValidationDeps {
-			depNode := st.GetNode(depID)
-			if depNode == nil {
-				// Dependency node doesn't exist (should be caught earlier, but be defensive)
-				unvalidatedDeps = append(unvalidatedDeps, depID.String()+" (not found)")
-				continue
-			}
-			// Check if the dependency is validated (or admitted, which counts as validated)
-			if depNode.EpistemicState != schema.EpistemicValidated && depNode.EpistemicState != schema.EpistemicAdmitted {
-				unvalidatedDeps = append(unvalidatedDeps, depID.String())
-			}
-		}
-		if len(unvalidatedDeps) > 0 {
-			return fmt.Errorf("cannot accept node %s: validation dependencies not yet validated: %s",
-				id.String(), strings.Join(unvalidatedDeps, ", "))
+	// Check validation dependencies - all must be validated, admitted is not enough
+	if err := checkValidationDepsValidated(st, n, nil); err != nil {
+		return err
+	}
+
+	// Check reference dependencies - all must be validated or admitted unless
+	// the service has been configured to allow bottom-up validation
+	if !s.allowUnvalidatedDeps {
+		if err := checkDependenciesValidated(st, n, nil); err != nil {
+			return err
 		}
 	}
 
@@ -861,13 +1556,13 @@ func (s *ProofService) AcceptNodeWithNote(id types.NodeID, note string) error {
 	}
 
 	event := ledger.NewNodeValidatedWithNote(id, note)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	if err != nil {
 		return wrapSequenceMismatch(err, "AcceptNodeWithNote")
 	}
 
 	// Auto-compute and emit taint events after successful validation
-	return s.emitTaintRecomputedEvents(ldg, id)
+	return s.emitTaintRecomputedEvents(ldg, st, id)
 }
 
 // AcceptNodeBulk validates multiple nodes atomically, marking them as verified correct.
@@ -883,7 +1578,12 @@ func (s *ProofService) AcceptNodeWithNote(id types.NodeID, note string) error {
 //
 // Returns nil if all nodes were successfully accepted.
 // Returns error if any node doesn't exist, isn't pending, or validation fails.
-// Returns ErrBlockingChallenges if any node has unresolved critical or major challenges.
+// Returns ErrBlockingChallenges if any node has open challenges of a severity
+// configured as blocking (critical and major by default).
+// Returns ErrUnvalidatedDependencies if any node has a validation dependency
+// that is neither validated nor part of the same batch, or depends on a node
+// that is neither validated, admitted, nor part of the same batch (unless
+// disabled with SetRequireValidatedDependencies).
 // Returns ErrConcurrentModification if the proof was modified by another process
 // since state was loaded. Callers should retry after reloading state.
 func (s *ProofService) AcceptNodeBulk(ids []types.NodeID) error {
@@ -891,6 +1591,9 @@ func (s *ProofService) AcceptNodeBulk(ids []types.NodeID) error {
 		return nil // Nothing to do
 	}
 
+	s.lockNodes(ids)
+	defer s.unlockNodes(ids)
+
 	// Load current state and capture sequence for CAS
 	st, err := s.LoadState()
 	if err != nil {
@@ -898,6 +1601,16 @@ func (s *ProofService) AcceptNodeBulk(ids []types.NodeID) error {
 	}
 	expectedSeq := st.LatestSeq()
 
+	severities, err := s.BlockingSeverities()
+	if err != nil {
+		return err
+	}
+
+	accepting := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		accepting[id.String()] = true
+	}
+
 	// Validate all nodes exist, have no blocking challenges, and are in pending state before any mutation
 	for _, id := range ids {
 		n := st.GetNode(id)
@@ -905,10 +1618,21 @@ func (s *ProofService) AcceptNodeBulk(ids []types.NodeID) error {
 			return fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
 		}
 
-		// Check for blocking challenges (critical or major severity)
-		blockingChallenges := st.GetBlockingChallengesForNode(id)
+		// Check for blocking challenges (severities configured as blocking)
+		blockingChallenges := st.GetBlockingChallengesForNodeWithSeverities(id, severities)
 		if len(blockingChallenges) > 0 {
-			return formatBlockingChallengesError(id, blockingChallenges)
+			return formatBlockingChallengesError(id, blockingChallenges, severities)
+		}
+
+		// Check validation and reference dependencies, allowing dependencies
+		// within the batch
+		if err := checkValidationDepsValidated(st, n, accepting); err != nil {
+			return err
+		}
+		if !s.allowUnvalidatedDeps {
+			if err := checkDependenciesValidated(st, n, accepting); err != nil {
+				return err
+			}
 		}
 
 		// Validate epistemic state transition (only pending -> validated allowed)
@@ -929,20 +1653,16 @@ func (s *ProofService) AcceptNodeBulk(ids []types.NodeID) error {
 		events[i] = ledger.NewNodeValidated(id)
 	}
 
-	// Append all events with CAS on first event (see appendBulkIfSequence ATOMICITY NOTE)
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
 	_, err = s.appendBulkIfSequence(ldg, events, expectedSeq)
 	if err != nil {
 		return wrapSequenceMismatch(err, "AcceptNodeBulk")
 	}
 
-	// Emit taint events for all accepted nodes
-	for _, id := range ids {
-		if err := s.emitTaintRecomputedEvents(ldg, id); err != nil {
-			// Log but don't fail - the validation events are already committed
-			// Taint will be recalculated on next state load
-			continue
-		}
-	}
+	// Emit taint events for all accepted nodes. Errors are ignored: the
+	// validation events are already committed and taint will be recalculated
+	// on the next state load
+	_ = s.emitTaintRecomputedEvents(ldg, st, ids...)
 
 	return nil
 }
@@ -1033,20 +1753,22 @@ func (s *ProofService) AdmitNode(id types.NodeID) error {
 	}
 
 	event := ledger.NewNodeAdmitted(id)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	if err != nil {
 		return wrapSequenceMismatch(err, "AdmitNode")
 	}
 
 	// Auto-compute and emit taint events after successful admission
-	return s.emitTaintRecomputedEvents(ldg, id)
+	return s.emitTaintRecomputedEvents(ldg, st, id)
 }
 
 // RefuteNode refutes a node, marking it as incorrect.
 // Returns an error if the node doesn't exist.
 //
 // After refutation, automatically recomputes and emits taint state changes
-// for the node and any affected descendants.
+// for the node and any affected descendants. Nodes elsewhere in the tree
+// that depend on it are not blocked by the refutation; use DependentsOf to
+// warn about them.
 //
 // ATOMICITY NOTE: The refutation event and subsequent taint events are NOT atomic.
 // See AcceptNodeWithNote for details on the implications and why this is acceptable.
@@ -1079,13 +1801,13 @@ func (s *ProofService) RefuteNode(id types.NodeID) error {
 	}
 
 	event := ledger.NewNodeRefuted(id)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	if err != nil {
 		return wrapSequenceMismatch(err, "RefuteNode")
 	}
 
 	// Auto-compute and emit taint events after successful refutation
-	return s.emitTaintRecomputedEvents(ldg, id)
+	return s.emitTaintRecomputedEvents(ldg, st, id)
 }
 
 // ArchiveNode archives a node, abandoning the branch.
@@ -1125,13 +1847,144 @@ func (s *ProofService) ArchiveNode(id types.NodeID) error {
 	}
 
 	event := ledger.NewNodeArchived(id)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	if err != nil {
 		return wrapSequenceMismatch(err, "ArchiveNode")
 	}
 
 	// Auto-compute and emit taint events after successful archiving
-	return s.emitTaintRecomputedEvents(ldg, id)
+	return s.emitTaintRecomputedEvents(ldg, st, id)
+}
+
+// ArchiveSubtree archives a node and all of its descendants, abandoning the
+// whole branch. Descendants are found by node ID prefix in the current state.
+// Pending and needs_refinement nodes are archived; admitted, refuted, and
+// already archived nodes are left as they are.
+//
+// Validated nodes cannot be archived. If the subtree contains one, an error
+// naming the first validated node (in ID order) is returned unless force is
+// set, in which case validated nodes are skipped and the rest are archived.
+//
+// All archive events are appended in a single batch, parent before children,
+// with the nodes of the subtree locked for the whole call (see lockNodes).
+// Returns the IDs of the archived nodes in ID order.
+//
+// ATOMICITY NOTE: The archive events are appended as one all-or-nothing batch
+// (see appendBulkIfSequence), but they and the subsequent taint events are NOT
+// atomic. See AcceptNodeWithNote for details.
+//
+// Returns ErrNodeNotFound if the node doesn't exist.
+// Returns ErrInvalidState if a validated node blocks the operation or no node
+// in the subtree can be archived.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ArchiveSubtree(id types.NodeID, force bool) ([]types.NodeID, error) {
+	// Lock the subtree, then capture the sequence of the state loaded under
+	// the locks for CAS
+	st, locked, err := s.lockTouchedNodes(func(st *state.State) []types.NodeID {
+		return subtreeIDs(st, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockNodes(locked)
+	expectedSeq := st.LatestSeq()
+
+	// The subtree is in ID order, so parents precede their children
+	subtree := st.Subtree(id)
+	if subtree == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
+	}
+
+	var archived []types.NodeID
+	for _, n := range subtree {
+		if n.EpistemicState == schema.EpistemicValidated {
+			if !force {
+				return nil, fmt.Errorf("%w: node %s in subtree %s is validated (use force to skip validated nodes)",
+					ErrInvalidState, n.ID.String(), id.String())
+			}
+			continue
+		}
+		if schema.ValidateEpistemicTransition(n.EpistemicState, schema.EpistemicArchived) != nil {
+			continue
+		}
+		archived = append(archived, n.ID)
+	}
+	if len(archived) == 0 {
+		return nil, fmt.Errorf("%w: no archivable nodes in subtree %s", ErrInvalidState, id.String())
+	}
+
+	// Get ledger
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ledger.Event, len(archived))
+	for i, archivedID := range archived {
+		events[i] = ledger.NewNodeArchived(archivedID)
+	}
+
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
+	if _, err := s.appendBulkIfSequence(ldg, events, expectedSeq); err != nil {
+		return nil, wrapSequenceMismatch(err, "ArchiveSubtree")
+	}
+
+	// Emit taint events for all archived nodes. Errors are ignored: the
+	// archive events are already committed and taint will be recalculated
+	// on the next state load
+	_ = s.emitTaintRecomputedEvents(ldg, st, archived...)
+
+	return archived, nil
+}
+
+// ReopenNode returns a refuted or archived node to the pending state so it
+// can be revisited after the prover addresses the issue.
+// Returns an error if the node doesn't exist.
+// Returns ErrInvalidState if the node is not refuted or archived.
+//
+// After reopening, automatically recomputes and emits taint state changes
+// for the node and any affected descendants.
+//
+// ATOMICITY NOTE: The reopen event and subsequent taint events are NOT atomic.
+// See AcceptNodeWithNote for details on the implications and why this is acceptable.
+//
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ReopenNode(id types.NodeID) error {
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	// Check if node exists
+	n := st.GetNode(id)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
+	}
+
+	// Only refuted or archived nodes can be reopened
+	if !schema.CanReopen(n.EpistemicState) {
+		return fmt.Errorf("%w: node %s is in %s state, must be %s or %s to reopen",
+			ErrInvalidState, id.String(), n.EpistemicState, schema.EpistemicRefuted, schema.EpistemicArchived)
+	}
+
+	// Get ledger and append reopen event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	event := ledger.NewNodeReopened(id)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	if err != nil {
+		return wrapSequenceMismatch(err, "ReopenNode")
+	}
+
+	// Auto-compute and emit taint events after successful reopening
+	return s.emitTaintRecomputedEvents(ldg, st, id)
 }
 
 // AddDefinition adds a new definition to the proof.
@@ -1168,7 +2021,7 @@ func (s *ProofService) AddDefinition(name, content string) (string, error) {
 	}
 
 	event := ledger.NewDefAdded(ledgerDef)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	if err != nil {
 		return "", wrapSequenceMismatch(err, "AddDefinition")
 	}
@@ -1190,6 +2043,13 @@ func (s *ProofService) AddAssumption(statement string) (string, error) {
 		return "", fmt.Errorf("creating assumption: %w", err)
 	}
 
+	if err := s.checkWritable(); err != nil {
+		return "", err
+	}
+	if s.dryRun {
+		return asm.ID, nil
+	}
+
 	// Store assumption in filesystem (base path is the proof directory)
 	if err := fs.WriteAssumption(s.path, asm); err != nil {
 		return "", err
@@ -1215,6 +2075,13 @@ func (s *ProofService) AddExternal(name, source string) (string, error) {
 		return "", fmt.Errorf("creating external: %w", err)
 	}
 
+	if err := s.checkWritable(); err != nil {
+		return "", err
+	}
+	if s.dryRun {
+		return ext.ID, nil
+	}
+
 	// Store in filesystem (base path is the proof directory)
 	if err := fs.WriteExternal(s.path, ext); err != nil {
 		return "", err
@@ -1267,7 +2134,7 @@ func (s *ProofService) ExtractLemma(sourceNodeID types.NodeID, statement string)
 	}
 
 	event := ledger.NewLemmaExtracted(ledgerLemma)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	if err != nil {
 		return "", wrapSequenceMismatch(err, "ExtractLemma")
 	}
@@ -1275,6 +2142,125 @@ func (s *ProofService) ExtractLemma(sourceNodeID types.NodeID, statement string)
 	return lemma.ID, nil
 }
 
+// ApplyLemma records lemmaID as a justification for nodeID, so an extracted
+// lemma can be reused elsewhere in the proof. Applying a lemma the node
+// already cites returns ErrAlreadyExists.
+//
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+// Returns ErrLemmaNotFound if lemmaID doesn't exist.
+// Returns ErrNotClaimed or ErrOwnerMismatch if nodeID is not claimed by owner.
+// Returns ErrInvalidState if the lemma was extracted from nodeID itself.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ApplyLemma(nodeID types.NodeID, lemmaID string, owner string) error {
+	// Validate inputs
+	if strings.TrimSpace(lemmaID) == "" {
+		return fmt.Errorf("%w: lemma ID", ErrEmptyInput)
+	}
+	if strings.TrimSpace(owner) == "" {
+		return fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+
+	// Load state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	lem := st.GetLemma(lemmaID)
+	if lem == nil {
+		return fmt.Errorf("%w: %s", ErrLemmaNotFound, lemmaID)
+	}
+	if n.WorkflowState != schema.WorkflowClaimed {
+		return fmt.Errorf("%w: node %s must be claimed to apply a lemma to it", ErrNotClaimed, nodeID.String())
+	}
+	if n.ClaimedBy != owner {
+		return ErrOwnerMismatch
+	}
+
+	// A lemma cannot justify the node it was extracted from
+	if lem.SourceNodeID.Equal(nodeID) {
+		return fmt.Errorf("%w: lemma %s was extracted from node %s and cannot justify it",
+			ErrInvalidState, lemmaID, nodeID.String())
+	}
+	for _, id := range n.Lemmas {
+		if id == lemmaID {
+			return fmt.Errorf("%w: node %s already cites lemma %s", ErrAlreadyExists, nodeID.String(), lemmaID)
+		}
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	event := ledger.NewLemmaApplied(nodeID, lemmaID, owner)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, "ApplyLemma")
+}
+
+// RefineFromLemma creates a child of parentID that restates an extracted
+// lemma: a claim whose statement is the lemma's statement, justified by the
+// assumption inference (af refine's default), citing lemmaID as its
+// justification (see RefineSpec.Lemmas). The child gets the next ID under
+// the configured child ID strategy, which is returned.
+//
+// Returns ErrLemmaNotFound if lemmaID doesn't exist.
+// Returns ErrParentNotFound if parentID doesn't exist.
+// Returns ErrNotClaimed or ErrOwnerMismatch if parentID is not claimed by owner.
+// Returns ErrInvalidState if the lemma was extracted from parentID or one of
+// its ancestors.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) RefineFromLemma(parentID types.NodeID, owner string, lemmaID string) (types.NodeID, error) {
+	if strings.TrimSpace(lemmaID) == "" {
+		return types.NodeID{}, fmt.Errorf("%w: lemma ID", ErrEmptyInput)
+	}
+	if strings.TrimSpace(owner) == "" {
+		return types.NodeID{}, fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return types.NodeID{}, err
+	}
+	lem := st.GetLemma(lemmaID)
+	if lem == nil {
+		return types.NodeID{}, fmt.Errorf("%w: %s", ErrLemmaNotFound, lemmaID)
+	}
+	if st.GetNode(parentID) == nil {
+		return types.NodeID{}, fmt.Errorf("%w: %s", ErrParentNotFound, parentID.String())
+	}
+
+	childNums, err := s.nextChildNums(st, parentID, 1)
+	if err != nil {
+		return types.NodeID{}, err
+	}
+	childID, err := parentID.Child(childNums[0])
+	if err != nil {
+		return types.NodeID{}, fmt.Errorf("failed to generate child ID: %w", err)
+	}
+
+	// Refine checks the claim, limits, and the lemma against fresh state
+	if err := s.Refine(RefineSpec{
+		ParentID:  parentID,
+		Owner:     owner,
+		ChildID:   childID,
+		NodeType:  schema.NodeTypeClaim,
+		Statement: lem.Statement,
+		Inference: schema.InferenceAssumption,
+		Lemmas:    []string{lemmaID},
+	}); err != nil {
+		return types.NodeID{}, err
+	}
+	return childID, nil
+}
+
 // ProofStatus contains status information about a proof.
 type ProofStatus struct {
 	Initialized    bool
@@ -1283,6 +2269,22 @@ type ProofStatus struct {
 	ClaimedNodes   int
 	ValidatedNodes int
 	PendingNodes   int
+
+	// CompletionPercent is the share of nodes that are validated or
+	// admitted, from 0 to 100.
+	CompletionPercent float64
+
+	// RootProven is true when the root node is validated and all of its
+	// transitive dependencies are validated or admitted.
+	RootProven bool
+
+	// OpenChallenges counts open challenges by severity
+	// (critical, major, minor, note).
+	OpenChallenges map[string]int
+
+	// BlockedNodes is the number of nodes with at least one open
+	// blocking (critical or major) challenge.
+	BlockedNodes int
 }
 
 // Status returns the current status of the proof.
@@ -1328,6 +2330,11 @@ func (s *ProofService) Status() (*ProofStatus, error) {
 		}
 	}
 
+	status.CompletionPercent = st.CompletionPercent()
+	status.RootProven = st.RootProven()
+	status.OpenChallenges = st.OpenChallengesBySeverity()
+	status.BlockedNodes = st.BlockedNodeCount()
+
 	return status, nil
 }
 
@@ -1354,12 +2361,20 @@ func (s *ProofService) Path() string {
 	return s.path
 }
 
-// emitTaintRecomputedEvents computes taint for a node and its descendants after
-// an epistemic state change, then emits TaintRecomputed events to the ledger.
+// emitTaintRecomputedEvents recomputes taint for the nodes affected by an
+// epistemic state change to ids, then emits a TaintRecomputed event to the
+// ledger for each one whose taint differs from before, the state loaded
+// ahead of the change.
+//
+// The affected nodes are each node in ids and its descendants (taint is
+// inherited from ancestors) and, transitively, the nodes that depend on any
+// of them together with their descendants. Dependencies may form cycles, so
+// each node is visited at most once.
 //
-// This is called automatically after validation events (AcceptNode, AdmitNode,
-// RefuteNode, ArchiveNode) to ensure the ledger contains explicit taint state
-// records for audit and replay purposes.
+// This is called automatically after epistemic state changes (AcceptNode,
+// AdmitNode, RefuteNode, ArchiveNode, ReopenNode) to ensure the ledger contains
+// explicit taint state records for audit and replay purposes. Nothing is
+// emitted when auto-taint is disabled (see WithAutoTaint).
 //
 // IMPORTANT: This function is intentionally NOT atomic with the preceding epistemic
 // state change event. The taint events are appended separately after the validation
@@ -1369,67 +2384,83 @@ func (s *ProofService) Path() string {
 //   is guaranteed on the next state replay even if these events are never written
 // - The ledger may lack explicit taint records, but the taint package will compute
 //   correct taint on replay
-func (s *ProofService) emitTaintRecomputedEvents(ldg *ledger.Ledger, nodeID types.NodeID) error {
-	// Reload state to get the updated epistemic state (validation event was just applied)
+func (s *ProofService) emitTaintRecomputedEvents(ldg *ledger.Ledger, before *state.State, ids ...types.NodeID) error {
+	// Nothing was written in dry-run mode, so there is no new state to derive taint from
+	if s.dryRun || s.noAutoTaint {
+		return nil
+	}
+
+	// Reload state to get the updated epistemic states (the change was just applied)
 	st, err := s.LoadState()
 	if err != nil {
 		return err
 	}
 
-	// Get the node that was just validated
-	n := st.GetNode(nodeID)
-	if n == nil {
-		// Node should exist - this would be a logic error
-		return nil
+	nodeMap := make(map[string]*node.Node)
+	for _, n := range st.AllNodes() {
+		nodeMap[n.ID.String()] = n
 	}
 
-	// Get all nodes for taint computation
-	allNodes := st.AllNodes()
-
-	// Build ancestor list for this node
-	nodeMap := make(map[string]*node.Node)
-	for _, nd := range allNodes {
-		if nd != nil {
-			nodeMap[nd.ID.String()] = nd
-		}
+	// Recompute parents before children so each node sees its ancestors' new taint
+	affected := taintAffectedNodes(st, ids)
+	sortNodesByDepthForTaint(affected)
+	for _, n := range affected {
+		n.TaintState = taint.ComputeTaint(n, getNodeAncestorsForTaint(n, nodeMap))
 	}
 
-	var ancestors []*node.Node
-	parentID, hasParent := nodeID.Parent()
-	for hasParent {
-		if parent, ok := nodeMap[parentID.String()]; ok {
-			ancestors = append(ancestors, parent)
+	for _, n := range affected {
+		if prev := before.GetNode(n.ID); prev != nil && prev.TaintState == n.TaintState {
+			continue
+		}
+		if err := s.checkWritable(); err != nil {
+			return err
+		}
+		s.invalidateStateCache()
+		if _, err := ldg.Append(ledger.NewTaintRecomputed(n.ID, n.TaintState)); err != nil {
+			return err
 		}
-		parentID, hasParent = parentID.Parent()
 	}
 
-	// Compute taint for this node
-	newTaint := taint.ComputeTaint(n, ancestors)
+	return nil
+}
 
-	// Emit TaintRecomputed event for this node if taint changed
-	if n.TaintState != newTaint {
-		taintEvent := ledger.NewTaintRecomputed(nodeID, newTaint)
-		if _, err := ldg.Append(taintEvent); err != nil {
-			return err
+// taintAffectedNodes returns the nodes whose taint may change when the
+// epistemic state of ids changes: each node in ids, its descendants, and
+// transitively every node that depends on one of those (through Dependencies
+// or ValidationDeps) together with its descendants. Each node is returned
+// once, so dependency cycles terminate.
+func taintAffectedNodes(st *state.State, ids []types.NodeID) []*node.Node {
+	// Reverse dependency index: node ID -> nodes that depend on it
+	dependents := make(map[string][]*node.Node)
+	for _, n := range st.AllNodes() {
+		for _, dep := range n.Dependencies {
+			dependents[dep.String()] = append(dependents[dep.String()], n)
+		}
+		for _, dep := range n.ValidationDeps {
+			dependents[dep.String()] = append(dependents[dep.String()], n)
 		}
 	}
 
-	// Propagate taint to descendants and get changed nodes
-	// Note: We need to update n.TaintState first so descendants see the correct parent taint
-	n.TaintState = newTaint
-	changedDescendants := taint.PropagateTaint(n, allNodes)
-
-	// Emit TaintRecomputed events for all changed descendants
-	for _, desc := range changedDescendants {
-		if desc != nil {
-			taintEvent := ledger.NewTaintRecomputed(desc.ID, desc.TaintState)
-			if _, err := ldg.Append(taintEvent); err != nil {
-				return err
+	var affected []*node.Node
+	visited := make(map[string]bool)
+	queue := append([]types.NodeID(nil), ids...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, n := range st.Subtree(id) {
+			if visited[n.ID.String()] {
+				continue
+			}
+			visited[n.ID.String()] = true
+			affected = append(affected, n)
+			for _, d := range dependents[n.ID.String()] {
+				if !visited[d.ID.String()] {
+					queue = append(queue, d.ID)
+				}
 			}
 		}
 	}
-
-	return nil
+	return affected
 }
 
 // ChildSpec specifies a child node to be created in a bulk refine operation.
@@ -1439,6 +2470,19 @@ type ChildSpec struct {
 	Inference schema.InferenceType
 }
 
+// ChildSpecWithDeps specifies a child node with dependencies to be created
+// in a RefineNodeBulkWithDeps operation.
+type ChildSpecWithDeps struct {
+	ChildSpec
+
+	// Dependencies are nodes that already exist which the child depends on.
+	Dependencies []types.NodeID
+
+	// BatchDeps are the indexes, in the same batch, of earlier children the
+	// child depends on. They are resolved to the children's allocated IDs.
+	BatchDeps []int
+}
+
 // RefineSpec specifies parameters for refining a node with a child.
 // This struct consolidates the many parameters of RefineNodeWithAllDeps
 // into a single, cleaner API.
@@ -1468,9 +2512,16 @@ type RefineSpec struct {
 	// ValidationDeps specify nodes that must be validated before this node
 	// can be accepted (optional).
 	ValidationDeps []types.NodeID
+
+	// Lemmas are the IDs of extracted lemmas cited as justification for
+	// the new node (optional). A lemma extracted from the parent or one of
+	// its ancestors cannot be cited, since the child is part of that node's
+	// own proof.
+	Lemmas []string
 }
 
-// AllocateChildID allocates the next available child ID for a parent node atomically.
+// AllocateChildID allocates the next child ID for a parent node atomically,
+// following the configured child ID strategy (see config.Config.ChildIDStrategy).
 // This method acquires the ledger lock and returns the next child ID that should be used.
 // The returned ID is guaranteed to not exist in the current state.
 //
@@ -1492,18 +2543,60 @@ func (s *ProofService) AllocateChildID(parentID types.NodeID) (types.NodeID, err
 		return types.NodeID{}, fmt.Errorf("%w: %s", ErrParentNotFound, parentID.String())
 	}
 
-	// Find next available child ID
-	childNum := 1
-	for {
+	// Find next child ID under the configured strategy
+	childNums, err := s.nextChildNums(st, parentID, 1)
+	if err != nil {
+		return types.NodeID{}, err
+	}
+	childID, err := parentID.Child(childNums[0])
+	if err != nil {
+		return types.NodeID{}, fmt.Errorf("failed to generate child ID: %w", err)
+	}
+	return childID, nil
+}
+
+// nextChildNums returns the numbers of the next count children to create
+// under parentID, following the configured child ID strategy: the lowest
+// numbers not taken by a node under config.ChildIDLowestFree, or the numbers
+// after the highest child number under config.ChildIDMonotonic. Archived
+// nodes stay in the state, so their numbers are never reissued under either
+// strategy.
+func (s *ProofService) nextChildNums(st *state.State, parentID types.NodeID, count int) ([]int, error) {
+	cfg, err := s.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	nums := make([]int, 0, count)
+	if cfg.ChildIDStrategy == config.ChildIDMonotonic {
+		highest := 0
+		prefix := parentID.String() + "."
+		for _, n := range st.AllNodes() {
+			if p, ok := n.ID.Parent(); !ok || !p.Equal(parentID) {
+				continue
+			}
+			num, err := strconv.Atoi(strings.TrimPrefix(n.ID.String(), prefix))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read child number of %s: %w", n.ID.String(), err)
+			}
+			highest = max(highest, num)
+		}
+		for i := 1; i <= count; i++ {
+			nums = append(nums, highest+i)
+		}
+		return nums, nil
+	}
+
+	for childNum := 1; len(nums) < count; childNum++ {
 		candidateID, err := parentID.Child(childNum)
 		if err != nil {
-			return types.NodeID{}, fmt.Errorf("failed to generate child ID: %w", err)
+			return nil, fmt.Errorf("failed to generate child ID: %w", err)
 		}
 		if st.GetNode(candidateID) == nil {
-			return candidateID, nil
+			nums = append(nums, childNum)
 		}
-		childNum++
 	}
+	return nums, nil
 }
 
 // RefineNodeBulk adds multiple child nodes to a claimed parent node in a single atomic operation.
@@ -1511,7 +2604,8 @@ func (s *ProofService) AllocateChildID(parentID types.NodeID) (types.NodeID, err
 // multiple times to add N children, allowing other agents to grab the node between cycles.
 //
 // All children are created atomically - either all succeed or none are created.
-// Child IDs are allocated sequentially starting from the next available child number.
+// Child IDs are allocated in order under the configured child ID strategy
+// (see config.Config.ChildIDStrategy).
 //
 // Returns the IDs of the created children in order, or an error if any validation fails.
 // Returns ErrMaxDepthExceeded if any child node's depth would exceed config.MaxDepth.
@@ -1519,6 +2613,33 @@ func (s *ProofService) AllocateChildID(parentID types.NodeID) (types.NodeID, err
 // Returns ErrConcurrentModification if the proof was modified by another process
 // since state was loaded. Callers should retry after reloading state.
 func (s *ProofService) RefineNodeBulk(parentID types.NodeID, owner string, children []ChildSpec) ([]types.NodeID, error) {
+	specs := make([]ChildSpecWithDeps, len(children))
+	for i, child := range children {
+		specs[i] = ChildSpecWithDeps{ChildSpec: child}
+	}
+	return s.RefineNodeBulkWithDeps(parentID, owner, specs)
+}
+
+// RefineNodeBulkWithDeps adds multiple child nodes with dependencies to a
+// claimed parent node in a single atomic operation, so that a planner can
+// lay out a small DAG of steps in one call. Each child may depend on nodes
+// that already exist and, through BatchDeps, on children listed before it
+// in the same batch.
+//
+// Child IDs are allocated first, in order under the configured child ID
+// strategy (see config.Config.ChildIDStrategy); batch references are then
+// resolved to the allocated IDs. Every dependency is validated before
+// anything is written - either all children are created or none are. The
+// parent, the allocated child IDs and the cited nodes are locked for the
+// whole call (see lockNodes).
+//
+// Returns the IDs of the created children in order, or an error if any validation fails.
+// Returns ErrMaxDepthExceeded if any child node's depth would exceed config.MaxDepth.
+// Returns ErrMaxChildrenExceeded if adding all children would exceed config.MaxChildren.
+// Returns ErrCircularDependency if a dependency would create a cycle.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) RefineNodeBulkWithDeps(parentID types.NodeID, owner string, children []ChildSpecWithDeps) ([]types.NodeID, error) {
 	if len(children) == 0 {
 		return nil, fmt.Errorf("%w: at least one child specification is required", ErrEmptyInput)
 	}
@@ -1529,11 +2650,27 @@ func (s *ProofService) RefineNodeBulk(parentID types.NodeID, owner string, child
 		return nil, err
 	}
 
-	// Load current state and capture sequence for CAS
-	st, err := s.LoadState()
+	// Lock the parent, the child IDs it will hand out and the nodes the
+	// children cite, then capture the sequence of the state loaded under the
+	// locks for CAS
+	st, locked, err := s.lockTouchedNodes(func(st *state.State) []types.NodeID {
+		ids := []types.NodeID{parentID}
+		if childNums, err := s.nextChildNums(st, parentID, len(children)); err == nil {
+			for _, num := range childNums {
+				if childID, err := parentID.Child(num); err == nil {
+					ids = append(ids, childID)
+				}
+			}
+		}
+		for _, spec := range children {
+			ids = append(ids, spec.Dependencies...)
+		}
+		return ids
+	})
 	if err != nil {
 		return nil, err
 	}
+	defer s.unlockNodes(locked)
 	expectedSeq := st.LatestSeq()
 
 	// Check if parent node exists
@@ -1569,23 +2706,24 @@ func (s *ProofService) RefineNodeBulk(parentID types.NodeID, owner string, child
 			ErrMaxChildrenExceeded, parentID.String(), existingChildCount, len(children), cfg.MaxChildren)
 	}
 
-	// Find next available child number
-	childNum := 1
-	for {
-		candidateID, err := parentID.Child(childNum)
+	// Find the next child numbers under the configured strategy
+	childNums, err := s.nextChildNums(st, parentID, len(children))
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate all child IDs first so batch references can be resolved
+	childIDs := make([]types.NodeID, len(children))
+	for i := range children {
+		childID, err := parentID.Child(childNums[i])
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate child ID: %w", err)
-		}
-		if st.GetNode(candidateID) == nil {
-			break
+			return nil, fmt.Errorf("child %d: failed to generate child ID: %w", i+1, err)
 		}
-		childNum++
+		childIDs[i] = childID
 	}
 
-	// Prepare all child nodes and their IDs
-	childIDs := make([]types.NodeID, len(children))
+	// Prepare all child nodes
 	events := make([]ledger.Event, len(children))
-
 	for i, spec := range children {
 		// Validate statement is not empty
 		if strings.TrimSpace(spec.Statement) == "" {
@@ -1597,15 +2735,29 @@ func (s *ProofService) RefineNodeBulk(parentID types.NodeID, owner string, child
 			return nil, fmt.Errorf("child %d: %w", i+1, err)
 		}
 
-		// Generate child ID
-		childID, err := parentID.Child(childNum + i)
-		if err != nil {
-			return nil, fmt.Errorf("child %d: failed to generate child ID: %w", i+1, err)
+		// Validate that dependencies on existing nodes exist and close no
+		// cycle. Batch dependencies cannot: they point to earlier siblings,
+		// which nothing outside the batch depends on yet.
+		for _, depID := range spec.Dependencies {
+			if st.GetNode(depID) == nil {
+				return nil, fmt.Errorf("child %d: invalid dependency: node %s not found", i+1, depID.String())
+			}
+		}
+		if err := checkRefineCycles(st, RefineSpec{ParentID: parentID, ChildID: childIDs[i], Dependencies: spec.Dependencies}); err != nil {
+			return nil, fmt.Errorf("child %d: %w", i+1, err)
+		}
+
+		// Resolve batch dependencies to the allocated IDs
+		deps := append([]types.NodeID{}, spec.Dependencies...)
+		for _, idx := range spec.BatchDeps {
+			if idx < 0 || idx >= i {
+				return nil, fmt.Errorf("child %d: invalid batch dependency %d: must be the index of an earlier child (0-%d)", i+1, idx, i-1)
+			}
+			deps = append(deps, childIDs[idx])
 		}
-		childIDs[i] = childID
 
 		// Create the child node
-		childNode, err := node.NewNode(childID, spec.NodeType, spec.Statement, spec.Inference)
+		childNode, err := node.NewNodeWithOptions(childIDs[i], spec.NodeType, spec.Statement, spec.Inference, node.NodeOptions{Dependencies: deps})
 		if err != nil {
 			return nil, fmt.Errorf("child %d: %w", i+1, err)
 		}
@@ -1620,86 +2772,65 @@ func (s *ProofService) RefineNodeBulk(parentID types.NodeID, owner string, child
 		return nil, err
 	}
 
-	// Append all events with CAS on first event (see appendBulkIfSequence ATOMICITY NOTE)
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
 	_, err = s.appendBulkIfSequence(ldg, events, expectedSeq)
 	if err != nil {
-		return nil, wrapSequenceMismatch(err, "RefineNodeBulk")
+		return nil, wrapSequenceMismatch(err, "RefineNodeBulkWithDeps")
 	}
 
 	return childIDs, nil
 }
 
-// appendBulkIfSequence appends multiple events with sequence verification on the first event.
-// This is an internal helper that combines CAS semantics with batch append.
-//
-// ATOMICITY NOTE: This function is NOT truly atomic for multiple events. The implementation:
-// 1. Uses CAS (AppendIfSequence) for the first event to detect concurrent modifications
-// 2. Uses simple Append for remaining events without further CAS checks
-//
-// This means:
-// - If the first CAS succeeds but a subsequent append fails (disk error, etc.), the ledger
-//   will contain a partial set of events. The function returns the successfully appended
-//   sequence numbers along with an error.
-// - Concurrent readers may observe partially-applied changes during the append window.
-// - However, each individual event is still a valid, consistent ledger entry.
-//
-// Why this is acceptable:
-// - Disk/IO failures during append are rare in practice
-// - Callers (RefineNodeBulk, AcceptNodeBulk) create logically related but independent events
-// - State replay handles partial updates correctly (each event is self-contained)
-// - True atomic batch append would require a more complex protocol (e.g., WAL or 2PC)
-//
-// Callers should be aware that partial failure is possible and handle the returned
-// sequence numbers accordingly.
+// appendBulkIfSequence appends events as one batch with CAS on expectedSeq
+// and returns their sequence numbers. The batch is all or nothing (see
+// ledger.AppendBatchIfSequence): the sequence check and every write happen
+// under one ledger lock, so no other writer can append in the middle of it,
+// and a failed write removes the events already written. In read-only mode it
+// returns ErrReadOnly, even in dry-run mode. In dry-run mode the events are
+// recorded in the preview instead.
 func (s *ProofService) appendBulkIfSequence(ldg *ledger.Ledger, events []ledger.Event, expectedSeq int) ([]int, error) {
 	if len(events) == 0 {
 		return nil, nil
 	}
 
-	// For single event, use the existing method
-	if len(events) == 1 {
-		seq, err := ldg.AppendIfSequence(events[0], expectedSeq)
-		if err != nil {
-			return nil, err
-		}
-		return []int{seq}, nil
-	}
-
-	// For multiple events, we need to append them all atomically.
-	// The ledger's AppendBatch doesn't support CAS, so we'll append one by one
-	// but verify the sequence only on the first append.
-	// This maintains atomicity from a concurrency perspective because:
-	// 1. The first append with CAS ensures we're working from a consistent state
-	// 2. Subsequent appends are guaranteed to succeed because we hold implied
-	//    serialization through the sequence numbers
-
-	seqs := make([]int, len(events))
-
-	// First event uses CAS
-	seq, err := ldg.AppendIfSequence(events[0], expectedSeq)
-	if err != nil {
+	if err := s.checkWritable(); err != nil {
 		return nil, err
 	}
-	seqs[0] = seq
 
-	// Remaining events use simple append - they will get sequential numbers
-	// because we just established our position in the sequence
-	for i := 1; i < len(events); i++ {
-		seq, err := ldg.Append(events[i])
-		if err != nil {
-			// Partial failure - some events were appended
-			// This is a best-effort situation; the ledger will be in a partially
-			// updated state but still consistent
-			return seqs[:i], fmt.Errorf("failed to append event %d: %w", i+1, err)
+	if s.dryRun {
+		seqs := make([]int, len(events))
+		for i, event := range events {
+			seq, err := s.appendIfSequence(ldg, event, expectedSeq+i)
+			if err != nil {
+				return nil, err
+			}
+			seqs[i] = seq
 		}
-		seqs[i] = seq
+		return seqs, nil
 	}
 
-	return seqs, nil
+	s.invalidateStateCache()
+	return ldg.AppendBatchIfSequence(events, expectedSeq)
+}
+
+// appendIfSequence appends event with CAS on expectedSeq and returns its
+// sequence number. In read-only mode it returns ErrReadOnly. In dry-run mode
+// the event is recorded in the preview instead and the sequence number it
+// would have received is returned.
+func (s *ProofService) appendIfSequence(ldg *ledger.Ledger, event ledger.Event, expectedSeq int) (int, error) {
+	if err := s.checkWritable(); err != nil {
+		return 0, err
+	}
+	if s.dryRun {
+		s.preview = append(s.preview, event)
+		return expectedSeq + 1, nil
+	}
+	s.invalidateStateCache()
+	return ldg.AppendIfSequence(event, expectedSeq)
 }
 
 // ErrCircularDependency is returned when a cycle is detected in node dependencies.
-// Exit code: 3 (logic error)
+// Exit code: 6 (validation error)
 var ErrCircularDependency = aferrors.New(aferrors.DEPENDENCY_CYCLE, "circular dependency detected")
 
 // AmendNode allows a prover to correct the statement of a node they own.
@@ -1755,10 +2886,138 @@ func (s *ProofService) AmendNode(nodeID types.NodeID, owner, newStatement string
 	}
 
 	event := ledger.NewNodeAmended(nodeID, n.Statement, newStatement, owner)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	return wrapSequenceMismatch(err, "AmendNode")
 }
 
+// AmendSpec describes an amendment made with AmendNodeFull.
+// Each field is optional: a nil field leaves that part of the node unchanged.
+// A non-nil pointer to an empty dependency list clears the dependencies.
+type AmendSpec struct {
+	// Statement is the corrected statement.
+	Statement *string
+
+	// Inference is the corrected inference type.
+	Inference *schema.InferenceType
+
+	// Dependencies replaces the node's reference dependencies.
+	Dependencies *[]types.NodeID
+
+	// ValidationDeps replaces the node's validation dependencies.
+	ValidationDeps *[]types.NodeID
+}
+
+// AmendNodeFull amends the statement, inference type, and dependencies of a
+// pending node, applying only the fields set in spec. The owner must hold the
+// claim on the node. New dependencies must exist and must not introduce a
+// dependency cycle. The amendment, including which fields changed, is
+// recorded in the node's amendment history.
+//
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) AmendNodeFull(nodeID types.NodeID, owner string, spec AmendSpec) error {
+	// Validate inputs
+	if strings.TrimSpace(owner) == "" {
+		return fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+	if spec.Statement == nil && spec.Inference == nil && spec.Dependencies == nil && spec.ValidationDeps == nil {
+		return fmt.Errorf("%w: amendment has no fields to change", ErrEmptyInput)
+	}
+	if spec.Statement != nil && strings.TrimSpace(*spec.Statement) == "" {
+		return fmt.Errorf("%w: statement", ErrEmptyInput)
+	}
+	if spec.Inference != nil {
+		if err := schema.ValidateInference(string(*spec.Inference)); err != nil {
+			return err
+		}
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	// Check if node exists
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+
+	// Check epistemic state - can only amend pending nodes
+	if n.EpistemicState != schema.EpistemicPending {
+		return fmt.Errorf("cannot amend node: epistemic state is %s, must be pending", n.EpistemicState)
+	}
+
+	// Check ownership - the caller must hold the claim
+	if n.WorkflowState != schema.WorkflowClaimed {
+		return fmt.Errorf("%w: node %s", ErrNotClaimed, nodeID.String())
+	}
+	if n.ClaimedBy != owner {
+		return ErrOwnerMismatch
+	}
+
+	// Build the amended node and the list of changed fields
+	next := *n
+	var fields []string
+	if spec.Statement != nil {
+		next.Statement = *spec.Statement
+		fields = append(fields, ledger.AmendFieldStatement)
+	}
+	if spec.Inference != nil {
+		next.Inference = *spec.Inference
+		fields = append(fields, ledger.AmendFieldInference)
+	}
+	if spec.Dependencies != nil {
+		next.Dependencies = *spec.Dependencies
+		fields = append(fields, ledger.AmendFieldDependencies)
+	}
+	if spec.ValidationDeps != nil {
+		next.ValidationDeps = *spec.ValidationDeps
+		fields = append(fields, ledger.AmendFieldValidationDeps)
+	}
+
+	// Validate that new dependencies exist and don't create cycles. The
+	// check runs against the amended node's dependencies so that edges
+	// being removed don't count toward a cycle.
+	provider := &amendedDependencyProvider{
+		base: &stateDependencyProvider{st: st},
+		id:   nodeID,
+		deps: append(append([]types.NodeID{}, next.Dependencies...), next.ValidationDeps...),
+	}
+	if spec.Dependencies != nil {
+		for _, depID := range next.Dependencies {
+			if st.GetNode(depID) == nil {
+				return fmt.Errorf("invalid dependency: node %s not found", depID.String())
+			}
+			if res := cycle.WouldCreateCycle(provider, nodeID, depID); res.HasCycle {
+				return fmt.Errorf("%w: adding dependency %s -> %s would create cycle %v", ErrCircularDependency, nodeID.String(), depID.String(), res.Path)
+			}
+		}
+	}
+	if spec.ValidationDeps != nil {
+		for _, valDepID := range next.ValidationDeps {
+			if st.GetNode(valDepID) == nil {
+				return fmt.Errorf("invalid validation dependency: node %s not found", valDepID.String())
+			}
+			if res := cycle.WouldCreateCycle(provider, nodeID, valDepID); res.HasCycle {
+				return fmt.Errorf("%w: adding validation dependency %s -> %s would create cycle %v", ErrCircularDependency, nodeID.String(), valDepID.String(), res.Path)
+			}
+		}
+	}
+
+	// Get ledger and append amendment event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	event := ledger.NewNodeAmendedFull(*n, next, owner, fields)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, "AmendNodeFull")
+}
+
 // LoadAmendmentHistory returns the amendment history for a node.
 // Returns an empty slice if no amendments have been made.
 // Note: This method performs I/O to load state from disk.
@@ -1774,6 +3033,9 @@ func (s *ProofService) LoadAmendmentHistory(nodeID types.NodeID) ([]state.Amendm
 // WritePendingDef writes a pending definition to the proof's pending_defs directory.
 // This is a convenience wrapper around fs.WritePendingDef that uses the service's path.
 func (s *ProofService) WritePendingDef(nodeID types.NodeID, pd *node.PendingDef) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
 	return fs.WritePendingDef(s.path, nodeID, pd)
 }
 
@@ -1795,6 +3057,9 @@ func (s *ProofService) ListPendingDefs() ([]types.NodeID, error) {
 // This is idempotent: it does NOT return an error if the pending def doesn't exist.
 // This is a convenience wrapper around fs.DeletePendingDef that uses the service's path.
 func (s *ProofService) DeletePendingDef(nodeID types.NodeID) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
 	return fs.DeletePendingDef(s.path, nodeID)
 }
 
@@ -1840,6 +3105,9 @@ func (s *ProofService) ReadExternal(id string) (*node.External, error) {
 // WriteExternal writes an external reference to the proof directory.
 // This is a convenience wrapper around fs.WriteExternal that uses the service's path.
 func (s *ProofService) WriteExternal(ext *node.External) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
 	return fs.WriteExternal(s.path, ext)
 }
 
@@ -1935,7 +3203,7 @@ func (s *ProofService) RecomputeAllTaint(dryRun bool) (*RecomputeTaintResult, er
 			}
 
 			event := ledger.NewTaintRecomputed(nodeID, node.TaintState(change.NewTaint))
-			newSeq, err := ldg.AppendIfSequence(event, seq)
+			newSeq, err := s.appendIfSequence(ldg, event, seq)
 			if err != nil {
 				return nil, err
 			}
@@ -2015,6 +3283,6 @@ func (s *ProofService) RequestRefinement(nodeID types.NodeID, reason, requestedB
 	}
 
 	event := ledger.NewRefinementRequested(nodeID, reason, requestedBy)
-	_, err = ldg.AppendIfSequence(event, expectedSeq)
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
 	return wrapSequenceMismatch(err, "RequestRefinement")
 }
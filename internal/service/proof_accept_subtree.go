@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// AcceptSubtree validates a node and all of its descendants, deepest nodes
+// first, so that every parent is validated only after its children, as
+// AcceptNodeWithNote requires. Nodes already validated or admitted are left
+// as they are; every other node in the subtree must be validatable.
+//
+// All nodes are checked before anything is written: if any of them has a
+// blocking challenge, an unsatisfied dependency, or cannot move to validated,
+// the error names that node and no event is appended. Dependencies on other
+// nodes of the subtree count as satisfied, since they are validated in the
+// same batch.
+//
+// All validation events are appended as one all-or-nothing batch (see
+// appendBulkIfSequence), with the nodes of the subtree locked for the whole
+// call (see lockNodes). Returns the IDs of the validated nodes in the order
+// they were validated.
+//
+// ATOMICITY NOTE: The validation events and subsequent taint events are NOT atomic.
+// See AcceptNodeWithNote for details.
+//
+// Returns ErrNodeNotFound if the root doesn't exist.
+// Returns ErrBlockingChallenges if a node has open challenges of a severity
+// configured as blocking.
+// Returns ErrUnvalidatedDependencies if a node depends on a node outside the
+// subtree that is not validated (or admitted, for reference dependencies).
+// Returns ErrInvalidState if every node in the subtree is already validated
+// or admitted.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) AcceptSubtree(rootID types.NodeID) ([]types.NodeID, error) {
+	// Lock the subtree, then capture the sequence of the state loaded under
+	// the locks for CAS
+	st, locked, err := s.lockTouchedNodes(func(st *state.State) []types.NodeID {
+		return subtreeIDs(st, rootID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockNodes(locked)
+	expectedSeq := st.LatestSeq()
+
+	subtree := st.Subtree(rootID)
+	if subtree == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, rootID.String())
+	}
+
+	hasChildren := make(map[string]bool)
+	var pending []*node.Node
+	for _, n := range subtree {
+		if parentID, ok := n.ID.Parent(); ok {
+			hasChildren[parentID.String()] = true
+		}
+		if n.EpistemicState == schema.EpistemicValidated || n.EpistemicState == schema.EpistemicAdmitted {
+			continue
+		}
+		pending = append(pending, n)
+	}
+	if len(pending) == 0 {
+		return nil, fmt.Errorf("%w: every node in subtree %s is already validated or admitted",
+			ErrInvalidState, rootID.String())
+	}
+
+	// Deepest first; ties in ID order
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].ID.Depth() > pending[j].ID.Depth()
+	})
+
+	accepting := make(map[string]bool, len(pending))
+	for _, n := range pending {
+		accepting[n.ID.String()] = true
+	}
+
+	severities, err := s.BlockingSeverities()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every node before any mutation. Children need no separate check:
+	// each one is either validated or admitted already, or in the batch ahead
+	// of its parent.
+	for _, n := range pending {
+		blockingChallenges := st.GetBlockingChallengesForNodeWithSeverities(n.ID, severities)
+		if len(blockingChallenges) > 0 {
+			return nil, formatBlockingChallengesError(n.ID, blockingChallenges, severities)
+		}
+
+		if err := checkValidationDepsValidated(st, n, accepting); err != nil {
+			return nil, err
+		}
+		if !s.allowUnvalidatedDeps {
+			if err := checkDependenciesValidated(st, n, accepting); err != nil {
+				return nil, err
+			}
+		}
+
+		if n.EpistemicState == schema.EpistemicNeedsRefinement && !hasChildren[n.ID.String()] {
+			return nil, fmt.Errorf("cannot accept node %s: node is in needs_refinement state but has no children; use 'af refine' to add child nodes first",
+				n.ID.String())
+		}
+
+		if err := schema.ValidateEpistemicTransition(n.EpistemicState, schema.EpistemicValidated); err != nil {
+			return nil, fmt.Errorf("node %s: %w", n.ID.String(), err)
+		}
+	}
+
+	// Get ledger
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	validated := make([]types.NodeID, len(pending))
+	events := make([]ledger.Event, len(pending))
+	for i, n := range pending {
+		validated[i] = n.ID
+		events[i] = ledger.NewNodeValidated(n.ID)
+	}
+
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
+	if _, err := s.appendBulkIfSequence(ldg, events, expectedSeq); err != nil {
+		return nil, wrapSequenceMismatch(err, "AcceptSubtree")
+	}
+
+	// Emit taint events for all validated nodes. Errors are ignored: the
+	// validation events are already committed and taint will be recalculated
+	// on the next state load
+	_ = s.emitTaintRecomputedEvents(ldg, st, validated...)
+
+	return validated, nil
+}
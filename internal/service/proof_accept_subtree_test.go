@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestAcceptSubtree_ValidatesDeepestFirst(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+
+	validated, err := svc.AcceptSubtree(parseNodeID(t, "1.1"))
+	if err != nil {
+		t.Fatalf("AcceptSubtree failed: %v", err)
+	}
+	if got, want := strings.Join(types.ToStringSlice(validated), ","), "1.1.1,1.1.2,1.1"; got != want {
+		t.Errorf("validated = %s, want %s", got, want)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	for _, id := range []string{"1.1", "1.1.1", "1.1.2"} {
+		if es := st.GetNode(parseNodeID(t, id)).EpistemicState; es != schema.EpistemicValidated {
+			t.Errorf("node %s state = %s, want validated", id, es)
+		}
+	}
+	for _, id := range []string{"1", "1.10"} {
+		if es := st.GetNode(parseNodeID(t, id)).EpistemicState; es != schema.EpistemicPending {
+			t.Errorf("node %s state = %s, want pending", id, es)
+		}
+	}
+}
+
+func TestAcceptSubtree_SkipsValidatedAndAdmitted(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+	if err := svc.AcceptNode(parseNodeID(t, "1.1.1")); err != nil {
+		t.Fatalf("AcceptNode failed: %v", err)
+	}
+	if err := svc.AdmitNode(parseNodeID(t, "1.1.2")); err != nil {
+		t.Fatalf("AdmitNode failed: %v", err)
+	}
+
+	validated, err := svc.AcceptSubtree(parseNodeID(t, "1.1"))
+	if err != nil {
+		t.Fatalf("AcceptSubtree failed: %v", err)
+	}
+	if got, want := strings.Join(types.ToStringSlice(validated), ","), "1.1"; got != want {
+		t.Errorf("validated = %s, want %s", got, want)
+	}
+
+	if _, err := svc.AcceptSubtree(parseNodeID(t, "1.1")); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("second AcceptSubtree error = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestAcceptSubtree_BlockingChallengeCommitsNothing(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+	if _, err := svc.RaiseChallenge(parseNodeID(t, "1.1.2"), "statement", "Gap", "major"); err != nil {
+		t.Fatalf("RaiseChallenge failed: %v", err)
+	}
+	before := ledgerCount(t, svc)
+
+	_, err := svc.AcceptSubtree(parseNodeID(t, "1.1"))
+	if !errors.Is(err, ErrBlockingChallenges) {
+		t.Fatalf("AcceptSubtree error = %v, want ErrBlockingChallenges", err)
+	}
+	if !strings.Contains(err.Error(), "node 1.1.2") {
+		t.Errorf("error %q does not name the blocked node 1.1.2", err)
+	}
+	if after := ledgerCount(t, svc); after != before {
+		t.Errorf("ledger grew from %d to %d events, want nothing committed", before, after)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if es := st.GetNode(parseNodeID(t, "1.1.1")).EpistemicState; es != schema.EpistemicPending {
+		t.Errorf("node 1.1.1 state = %s, want pending", es)
+	}
+}
+
+func TestAcceptSubtree_FailureMidBatchCommitsNothing(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+	before := ledgerCount(t, svc)
+
+	// The children are validated, then the parent
+	unblock := blockLedgerSeq(t, svc, before+3)
+	if _, err := svc.AcceptSubtree(parseNodeID(t, "1.1")); err == nil {
+		t.Fatal("AcceptSubtree succeeded with an event file name blocked")
+	}
+	unblock()
+
+	if after := ledgerCount(t, svc); after != before {
+		t.Errorf("ledger grew from %d to %d events, want nothing committed", before, after)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	for _, id := range []string{"1.1", "1.1.1", "1.1.2"} {
+		if es := st.GetNode(parseNodeID(t, id)).EpistemicState; es != schema.EpistemicPending {
+			t.Errorf("node %s state = %s, want pending", id, es)
+		}
+	}
+}
+
+func TestAcceptSubtree_RefutedDescendantBlocks(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+	if err := svc.RefuteNode(parseNodeID(t, "1.1.1")); err != nil {
+		t.Fatalf("RefuteNode failed: %v", err)
+	}
+	before := ledgerCount(t, svc)
+
+	_, err := svc.AcceptSubtree(parseNodeID(t, "1.1"))
+	if err == nil || !strings.Contains(err.Error(), "node 1.1.1") {
+		t.Fatalf("AcceptSubtree error = %v, want error naming node 1.1.1", err)
+	}
+	if after := ledgerCount(t, svc); after != before {
+		t.Errorf("ledger grew from %d to %d events, want nothing committed", before, after)
+	}
+}
+
+func TestAcceptSubtree_DependencyOutsideSubtree(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+
+	// 1.2.1 depends on 1.1, which is pending and outside the subtree of 1.2
+	parentID := parseNodeID(t, "1.2")
+	if err := svc.ClaimNode(parentID, "prover", time.Hour); err != nil {
+		t.Fatalf("ClaimNode failed: %v", err)
+	}
+	if err := svc.RefineNodeWithDeps(parentID, "prover", parseNodeID(t, "1.2.1"), schema.NodeTypeClaim,
+		"Uses 1.1", schema.InferenceModusPonens, []types.NodeID{parseNodeID(t, "1.1")}); err != nil {
+		t.Fatalf("RefineNodeWithDeps failed: %v", err)
+	}
+	if err := svc.ReleaseNode(parentID, "prover"); err != nil {
+		t.Fatalf("ReleaseNode failed: %v", err)
+	}
+
+	if _, err := svc.AcceptSubtree(parentID); !errors.Is(err, ErrUnvalidatedDependencies) {
+		t.Fatalf("AcceptSubtree error = %v, want ErrUnvalidatedDependencies", err)
+	}
+
+	// Validating the dependency first unblocks the subtree
+	if _, err := svc.AcceptSubtree(parseNodeID(t, "1.1")); err != nil {
+		t.Fatalf("AcceptSubtree(1.1) failed: %v", err)
+	}
+	validated, err := svc.AcceptSubtree(parentID)
+	if err != nil {
+		t.Fatalf("AcceptSubtree(1.2) failed: %v", err)
+	}
+	if got, want := strings.Join(types.ToStringSlice(validated), ","), "1.2.1,1.2"; got != want {
+		t.Errorf("validated = %s, want %s", got, want)
+	}
+}
+
+func TestAcceptSubtree_NotFound(t *testing.T) {
+	svc := setupArchiveSubtreeTest(t)
+
+	if _, err := svc.AcceptSubtree(parseNodeID(t, "1.9.9")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("AcceptSubtree error = %v, want ErrNodeNotFound", err)
+	}
+}
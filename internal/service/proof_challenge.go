@@ -0,0 +1,333 @@
+// Package service provides the proof service facade for coordinating
+// proof operations across ledger, state, locks, and filesystem.
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// RaiseChallenge raises a challenge against a node.
+// Returns the generated challenge ID and any error.
+//
+// Target may be empty; if non-empty it must be a valid challenge target.
+// Severity must be one of critical, major, minor, or note.
+//
+// Returns ErrNodeNotFound if the node doesn't exist.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) RaiseChallenge(nodeID types.NodeID, target, reason, severity string) (string, error) {
+	return s.RaiseChallengeWithAgent(nodeID, target, reason, severity, "")
+}
+
+// RaiseChallengeWithAgent raises a challenge against a node, recording the
+// agent that raised it. See RaiseChallenge for validation and error semantics.
+func (s *ProofService) RaiseChallengeWithAgent(nodeID types.NodeID, target, reason, severity, raisedBy string) (string, error) {
+	// Validate inputs
+	if strings.TrimSpace(reason) == "" {
+		return "", fmt.Errorf("%w: challenge reason", ErrEmptyInput)
+	}
+	if target != "" {
+		if err := schema.ValidateChallengeTarget(target); err != nil {
+			return "", err
+		}
+	}
+	if err := schema.ValidateChallengeSeverity(severity); err != nil {
+		return "", err
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return "", err
+	}
+	expectedSeq := st.LatestSeq()
+
+	// Check if node exists
+	if st.GetNode(nodeID) == nil {
+		return "", fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+
+	// Get ledger and append challenge event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return "", err
+	}
+
+	challengeID := generateChallengeID()
+	event := ledger.NewChallengeRaisedWithSeverity(challengeID, nodeID, target, reason, severity, raisedBy)
+	if _, err := s.appendIfSequence(ldg, event, expectedSeq); err != nil {
+		return "", wrapSequenceMismatch(err, "RaiseChallenge")
+	}
+
+	return challengeID, nil
+}
+
+// ResolveChallenge marks an open challenge as resolved.
+//
+// Returns ErrChallengeNotFound if the challenge doesn't exist.
+// Returns ErrInvalidState if the challenge is not open.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ResolveChallenge(challengeID string) error {
+	return s.closeChallenge(challengeID, ledger.NewChallengeResolved(challengeID), "ResolveChallenge")
+}
+
+// ResolveAllChallenges resolves every open challenge on a node claimed by
+// owner and returns the resolved challenge IDs, sorted. With no open
+// challenges it does nothing and returns an empty slice.
+//
+// It suits a prover who has addressed all objections to a node: resolve
+// them in one call, then the node can be accepted with AcceptNode, since no
+// open challenge blocks it any more.
+//
+// The resolve events are appended as one all-or-nothing batch with CAS (see
+// appendBulkIfSequence).
+//
+// Returns ErrNodeNotFound if the node doesn't exist.
+// Returns ErrNotClaimed or ErrOwnerMismatch if the node is not claimed by owner.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ResolveAllChallenges(nodeID types.NodeID, owner string) ([]string, error) {
+	if strings.TrimSpace(owner) == "" {
+		return nil, fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	expectedSeq := st.LatestSeq()
+
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	if n.WorkflowState != schema.WorkflowClaimed {
+		return nil, fmt.Errorf("%w: node %s must be claimed to resolve its challenges", ErrNotClaimed, nodeID.String())
+	}
+	if n.ClaimedBy != owner {
+		return nil, ErrOwnerMismatch
+	}
+
+	resolved := []string{}
+	for _, c := range st.GetChallengesForNode(nodeID) {
+		if c.Status == state.ChallengeStatusOpen {
+			resolved = append(resolved, c.ID)
+		}
+	}
+	if len(resolved) == 0 {
+		return resolved, nil
+	}
+	sort.Strings(resolved)
+
+	events := make([]ledger.Event, len(resolved))
+	for i, id := range resolved {
+		events[i] = ledger.NewChallengeResolved(id)
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.appendBulkIfSequence(ldg, events, expectedSeq); err != nil {
+		return nil, wrapSequenceMismatch(err, "ResolveAllChallenges")
+	}
+
+	return resolved, nil
+}
+
+// WithdrawChallenge withdraws an open challenge.
+//
+// Returns ErrChallengeNotFound if the challenge doesn't exist.
+// Returns ErrInvalidState if the challenge is not open.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) WithdrawChallenge(challengeID string) error {
+	return s.closeChallenge(challengeID, ledger.NewChallengeWithdrawn(challengeID), "WithdrawChallenge")
+}
+
+// EscalateChallenge changes the severity of an open challenge. Raising it
+// to a blocking severity makes the challenge block acceptance of its node
+// even if it did not before; lowering it to a non-blocking one unblocks it.
+//
+// Returns ErrChallengeNotFound if the challenge doesn't exist.
+// Returns ErrInvalidState if the challenge is not open or already has
+// newSeverity.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) EscalateChallenge(challengeID, newSeverity string) error {
+	if strings.TrimSpace(challengeID) == "" {
+		return fmt.Errorf("%w: challenge ID", ErrEmptyInput)
+	}
+	if err := schema.ValidateChallengeSeverity(newSeverity); err != nil {
+		return err
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	c := st.GetChallenge(challengeID)
+	if c == nil {
+		return fmt.Errorf("%w: %q", ErrChallengeNotFound, challengeID)
+	}
+	if c.Status != state.ChallengeStatusOpen {
+		return fmt.Errorf("%w: challenge %q is not open (already %s)", ErrInvalidState, challengeID, c.Status)
+	}
+	if c.Severity == newSeverity {
+		return fmt.Errorf("%w: challenge %q is already %s", ErrInvalidState, challengeID, newSeverity)
+	}
+
+	// Get ledger and append event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appendIfSequence(ldg, ledger.NewChallengeEscalated(challengeID, c.Severity, newSeverity), expectedSeq)
+	return wrapSequenceMismatch(err, "EscalateChallenge")
+}
+
+// closeChallenge validates that a challenge is open and appends the event
+// that closes it.
+func (s *ProofService) closeChallenge(challengeID string, event ledger.Event, operation string) error {
+	if strings.TrimSpace(challengeID) == "" {
+		return fmt.Errorf("%w: challenge ID", ErrEmptyInput)
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	c := st.GetChallenge(challengeID)
+	if c == nil {
+		return fmt.Errorf("%w: %q", ErrChallengeNotFound, challengeID)
+	}
+	if c.Status != state.ChallengeStatusOpen {
+		return fmt.Errorf("%w: challenge %q is not open (already %s)", ErrInvalidState, challengeID, c.Status)
+	}
+
+	// Get ledger and append event with CAS
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, operation)
+}
+
+// ChallengeFilter selects which challenges ListChallenges returns.
+// The zero value matches every challenge.
+type ChallengeFilter struct {
+	// Status, if set, restricts results to challenges in this status
+	// (open, resolved, withdrawn, or superseded).
+	Status string
+
+	// Severity, if set, restricts results to challenges of this severity.
+	// Challenges recorded without a severity count as major.
+	Severity string
+
+	// NodeID, if set, restricts results to challenges targeting this node.
+	NodeID types.NodeID
+}
+
+// ListChallenges returns the challenges that pass opts, most severe first
+// (see schema.SeverityRank), then by node ID and challenge ID.
+//
+// Returns an error if opts names an unknown status or severity.
+func (s *ProofService) ListChallenges(opts ChallengeFilter) ([]*state.Challenge, error) {
+	if opts.Status != "" {
+		if err := validateChallengeStatus(opts.Status); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Severity != "" {
+		if err := schema.ValidateChallengeSeverity(opts.Severity); err != nil {
+			return nil, err
+		}
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*state.Challenge
+	for _, c := range st.AllChallenges() {
+		if matchesChallengeFilter(c, opts) {
+			results = append(results, c)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		ri := schema.SeverityRank(schema.ChallengeSeverity(results[i].Severity))
+		rj := schema.SeverityRank(schema.ChallengeSeverity(results[j].Severity))
+		if ri != rj {
+			return ri < rj
+		}
+		if !results[i].NodeID.Equal(results[j].NodeID) {
+			return results[i].NodeID.Less(results[j].NodeID)
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// matchesChallengeFilter reports whether c passes every filter in opts.
+func matchesChallengeFilter(c *state.Challenge, opts ChallengeFilter) bool {
+	if opts.Status != "" && c.Status != opts.Status {
+		return false
+	}
+	if opts.Severity != "" {
+		severity := c.Severity
+		if severity == "" {
+			severity = string(schema.DefaultChallengeSeverity())
+		}
+		if severity != opts.Severity {
+			return false
+		}
+	}
+	if opts.NodeID.String() != "" && !c.NodeID.Equal(opts.NodeID) {
+		return false
+	}
+	return true
+}
+
+// validateChallengeStatus checks that status is a known challenge status.
+func validateChallengeStatus(status string) error {
+	switch status {
+	case state.ChallengeStatusOpen, state.ChallengeStatusResolved,
+		state.ChallengeStatusWithdrawn, state.ChallengeStatusSuperseded:
+		return nil
+	}
+	return fmt.Errorf("invalid challenge status %q: must be open, resolved, withdrawn, or superseded", status)
+}
+
+// generateChallengeID generates a unique identifier for a challenge.
+// Uses random bytes for uniqueness.
+func generateChallengeID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// If crypto/rand fails, this indicates a critical system issue
+		// Use timestamp-based fallback
+		return fmt.Sprintf("ch-%v", types.Now())
+	}
+	return "ch-" + hex.EncodeToString(b)
+}
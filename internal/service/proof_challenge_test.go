@@ -0,0 +1,408 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newChallengeTestService creates an initialized proof and returns a service for it.
+func newChallengeTestService(t *testing.T) *ProofService {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := Init(tmpDir, "Test conjecture", "test-author"); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := NewProofService(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestRaiseChallenge_Lifecycle(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+
+	resolvedID, err := svc.RaiseChallenge(rootID, "inference", "Step does not follow", "critical")
+	if err != nil {
+		t.Fatalf("RaiseChallenge failed: %v", err)
+	}
+	withdrawnID, err := svc.RaiseChallenge(rootID, "", "Unclear wording", "note")
+	if err != nil {
+		t.Fatalf("RaiseChallenge failed: %v", err)
+	}
+	if resolvedID == withdrawnID {
+		t.Fatalf("expected unique challenge IDs, got %q twice", resolvedID)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := st.GetChallenge(resolvedID)
+	if c == nil || c.Status != state.ChallengeStatusOpen || c.Severity != "critical" {
+		t.Fatalf("raised challenge = %+v, want open critical challenge", c)
+	}
+
+	if err := svc.ResolveChallenge(resolvedID); err != nil {
+		t.Fatalf("ResolveChallenge failed: %v", err)
+	}
+	if err := svc.WithdrawChallenge(withdrawnID); err != nil {
+		t.Fatalf("WithdrawChallenge failed: %v", err)
+	}
+
+	st, err = svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetChallenge(resolvedID).Status; got != state.ChallengeStatusResolved {
+		t.Errorf("resolved challenge status = %q, want %q", got, state.ChallengeStatusResolved)
+	}
+	if got := st.GetChallenge(withdrawnID).Status; got != state.ChallengeStatusWithdrawn {
+		t.Errorf("withdrawn challenge status = %q, want %q", got, state.ChallengeStatusWithdrawn)
+	}
+
+	// Closed challenges cannot be closed again
+	if err := svc.WithdrawChallenge(resolvedID); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("WithdrawChallenge on resolved challenge: got %v, want ErrInvalidState", err)
+	}
+	if err := svc.ResolveChallenge(withdrawnID); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("ResolveChallenge on withdrawn challenge: got %v, want ErrInvalidState", err)
+	}
+}
+
+func TestRaiseChallenge_ValidationErrors(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+	missingID, _ := types.Parse("1.9")
+
+	tests := []struct {
+		name     string
+		nodeID   types.NodeID
+		target   string
+		reason   string
+		severity string
+		wantErr  error
+	}{
+		{"missing node", missingID, "", "reason", "major", ErrNodeNotFound},
+		{"empty reason", rootID, "", "   ", "major", ErrEmptyInput},
+		{"invalid severity", rootID, "", "reason", "fatal", nil},
+		{"invalid target", rootID, "bogus", "reason", "major", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.RaiseChallenge(tt.nodeID, tt.target, tt.reason, tt.severity)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(st.AllChallenges()); n != 0 {
+		t.Errorf("expected no challenges after failed raises, got %d", n)
+	}
+}
+
+func TestResolveChallenge_UnknownID(t *testing.T) {
+	svc := newChallengeTestService(t)
+
+	if err := svc.ResolveChallenge("ch-missing"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("ResolveChallenge: got %v, want ErrChallengeNotFound", err)
+	}
+	if err := svc.WithdrawChallenge("ch-missing"); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("WithdrawChallenge: got %v, want ErrChallengeNotFound", err)
+	}
+}
+
+func TestResolveAllChallenges_ThenAccept(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+
+	var raised []string
+	for _, severity := range []string{"critical", "major", "note"} {
+		id, err := svc.RaiseChallenge(rootID, "", "Objection", severity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raised = append(raised, id)
+	}
+	withdrawnID, err := svc.RaiseChallenge(rootID, "", "Never mind", "minor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.WithdrawChallenge(withdrawnID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.AcceptNode(rootID); !errors.Is(err, ErrBlockingChallenges) {
+		t.Fatalf("AcceptNode before resolving: got %v, want ErrBlockingChallenges", err)
+	}
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := svc.ResolveAllChallenges(rootID, "prover")
+	if err != nil {
+		t.Fatalf("ResolveAllChallenges failed: %v", err)
+	}
+	sort.Strings(raised)
+	if !reflect.DeepEqual(resolved, raised) {
+		t.Errorf("resolved = %v, want %v", resolved, raised)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range raised {
+		if got := st.GetChallenge(id).Status; got != state.ChallengeStatusResolved {
+			t.Errorf("challenge %s status = %s, want resolved", id, got)
+		}
+	}
+	if got := st.GetChallenge(withdrawnID).Status; got != state.ChallengeStatusWithdrawn {
+		t.Errorf("withdrawn challenge status = %s, want withdrawn", got)
+	}
+
+	// Nothing is left open, so a second call is a no-op
+	again, err := svc.ResolveAllChallenges(rootID, "prover")
+	if err != nil || again == nil || len(again) != 0 {
+		t.Errorf("second ResolveAllChallenges = %v, %v; want empty slice, nil", again, err)
+	}
+
+	if err := svc.AcceptNode(rootID); err != nil {
+		t.Errorf("AcceptNode after resolving all challenges failed: %v", err)
+	}
+}
+
+func TestResolveAllChallenges_Errors(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+	missingID, _ := types.Parse("1.9")
+
+	if _, err := svc.RaiseChallenge(rootID, "", "Objection", "major"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ResolveAllChallenges(rootID, "prover"); !errors.Is(err, ErrNotClaimed) {
+		t.Errorf("unclaimed node: got %v, want ErrNotClaimed", err)
+	}
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		nodeID types.NodeID
+		owner  string
+		want   error
+	}{
+		{"unknown node", missingID, "prover", ErrNodeNotFound},
+		{"wrong owner", rootID, "someone-else", ErrOwnerMismatch},
+		{"empty owner", rootID, " ", ErrEmptyInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.ResolveAllChallenges(tt.nodeID, tt.owner); !errors.Is(err, tt.want) {
+				t.Errorf("ResolveAllChallenges() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListChallenges_Filters(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	children := []ChildSpec{
+		{NodeType: schema.NodeTypeClaim, Statement: "A", Inference: schema.InferenceAssumption},
+		{NodeType: schema.NodeTypeClaim, Statement: "B", Inference: schema.InferenceAssumption},
+	}
+	if _, err := svc.RefineNodeBulk(rootID, "prover", children); err != nil {
+		t.Fatal(err)
+	}
+	childID, _ := types.Parse("1.2")
+
+	criticalID, err := svc.RaiseChallenge(childID, "", "Gap in step", "critical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteID, err := svc.RaiseChallenge(rootID, "", "Typo", "note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolvedID, err := svc.RaiseChallenge(rootID, "", "Unclear", "major")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ResolveChallenge(resolvedID); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(cs []*state.Challenge) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	all, err := svc.ListChallenges(ChallengeFilter{})
+	if err != nil {
+		t.Fatalf("ListChallenges failed: %v", err)
+	}
+	if want := []string{criticalID, resolvedID, noteID}; !reflect.DeepEqual(ids(all), want) {
+		t.Fatalf("ListChallenges(all) = %v, want %v (critical, major, note)", ids(all), want)
+	}
+
+	tests := []struct {
+		name string
+		opts ChallengeFilter
+		want []string
+	}{
+		{"open", ChallengeFilter{Status: state.ChallengeStatusOpen}, nil},
+		{"resolved", ChallengeFilter{Status: state.ChallengeStatusResolved}, []string{resolvedID}},
+		{"severity", ChallengeFilter{Severity: "critical"}, []string{criticalID}},
+		{"node", ChallengeFilter{NodeID: childID}, []string{criticalID}},
+		{"open note on root", ChallengeFilter{Status: state.ChallengeStatusOpen, Severity: "note", NodeID: rootID}, []string{noteID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ListChallenges(tt.opts)
+			if err != nil {
+				t.Fatalf("ListChallenges failed: %v", err)
+			}
+			if tt.want == nil {
+				if len(got) != 2 {
+					t.Errorf("got %v, want the two open challenges", ids(got))
+				}
+				return
+			}
+			if len(got) != len(tt.want) || got[0].ID != tt.want[0] {
+				t.Errorf("got %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+
+	if _, err := svc.ListChallenges(ChallengeFilter{Status: "pending"}); err == nil {
+		t.Error("expected error for unknown status")
+	}
+	if _, err := svc.ListChallenges(ChallengeFilter{Severity: "blocker"}); err == nil {
+		t.Error("expected error for unknown severity")
+	}
+}
+
+func TestEscalateChallenge_BlocksAcceptance(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+
+	challengeID, err := svc.RaiseChallenge(rootID, "statement", "Typo", "minor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.EscalateChallenge(challengeID, "critical"); err != nil {
+		t.Fatalf("EscalateChallenge failed: %v", err)
+	}
+
+	if err := svc.AcceptNode(rootID); !errors.Is(err, ErrBlockingChallenges) {
+		t.Errorf("AcceptNode error = %v, want ErrBlockingChallenges", err)
+	}
+}
+
+func TestEscalateChallenge_DeEscalateUnblocks(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+
+	challengeID, err := svc.RaiseChallenge(rootID, "inference", "Gap", "critical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AcceptNode(rootID); !errors.Is(err, ErrBlockingChallenges) {
+		t.Fatalf("AcceptNode error = %v, want ErrBlockingChallenges", err)
+	}
+
+	if err := svc.EscalateChallenge(challengeID, "note"); err != nil {
+		t.Fatalf("EscalateChallenge failed: %v", err)
+	}
+	if err := svc.AcceptNode(rootID); err != nil {
+		t.Errorf("AcceptNode after de-escalation failed: %v", err)
+	}
+}
+
+func TestEscalateChallenge_ReplayOrder(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+
+	challengeID, err := svc.RaiseChallenge(rootID, "statement", "Unclear", "note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, severity := range []string{"critical", "minor", "major"} {
+		if err := svc.EscalateChallenge(challengeID, severity); err != nil {
+			t.Fatalf("EscalateChallenge(%s) failed: %v", severity, err)
+		}
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := st.GetChallenge(challengeID).Severity; got != "major" {
+		t.Errorf("Severity after replay = %q, want the last escalation %q", got, "major")
+	}
+}
+
+func TestEscalateChallenge_Errors(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID, _ := types.Parse("1")
+
+	openID, err := svc.RaiseChallenge(rootID, "statement", "Typo", "minor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolvedID, err := svc.RaiseChallenge(rootID, "statement", "Gap", "major")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ResolveChallenge(resolvedID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		challengeID string
+		severity    string
+		want        error
+	}{
+		{"unknown challenge", "ch-missing", "critical", ErrChallengeNotFound},
+		{"closed challenge", resolvedID, "critical", ErrInvalidState},
+		{"unchanged severity", openID, "minor", ErrInvalidState},
+		{"empty ID", "", "critical", ErrEmptyInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.EscalateChallenge(tt.challengeID, tt.severity); !errors.Is(err, tt.want) {
+				t.Errorf("EscalateChallenge error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := svc.EscalateChallenge(openID, "blocker"); err == nil {
+		t.Error("EscalateChallenge accepted an unknown severity")
+	}
+}
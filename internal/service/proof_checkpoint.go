@@ -0,0 +1,204 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	aferrors "github.com/tobias/vibefeld/internal/errors"
+	"github.com/tobias/vibefeld/internal/fs"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// ErrCheckpointNotFound is returned when a named checkpoint does not exist.
+// Exit code: 3 (not found)
+var ErrCheckpointNotFound = aferrors.New(aferrors.CHECKPOINT_NOT_FOUND, "checkpoint not found")
+
+// checkpointsFilename is the name of the checkpoints file in the .af directory.
+const checkpointsFilename = "checkpoints.json"
+
+// Checkpoint is a named ledger sequence number that the proof can later be
+// restored to with RestoreCheckpoint.
+type Checkpoint struct {
+	Name    string          `json:"name"`
+	Seq     int             `json:"seq"`
+	Created types.Timestamp `json:"created"`
+}
+
+// Checkpoint records the proof's latest ledger sequence number under name
+// in .af/checkpoints.json and returns it. In dry-run mode the name is
+// validated but nothing is written.
+//
+// Returns ErrEmptyInput if name is empty or whitespace-only.
+// Returns ErrAlreadyExists if a checkpoint called name exists.
+func (s *ProofService) Checkpoint(name string) (int, error) {
+	if strings.TrimSpace(name) == "" {
+		return 0, fmt.Errorf("%w: checkpoint name", ErrEmptyInput)
+	}
+	if err := s.checkWritable(); err != nil {
+		return 0, err
+	}
+
+	checkpoints, err := loadCheckpoints(s.path)
+	if err != nil {
+		return 0, err
+	}
+	for _, cp := range checkpoints {
+		if cp.Name == name {
+			return 0, fmt.Errorf("%w: checkpoint %q (sequence %d)", ErrAlreadyExists, name, cp.Seq)
+		}
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return 0, err
+	}
+	seq := st.LatestSeq()
+	if s.dryRun {
+		return seq, nil
+	}
+
+	checkpoints = append(checkpoints, Checkpoint{Name: name, Seq: seq, Created: types.Now()})
+	if err := saveCheckpoints(s.path, checkpoints); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// ListCheckpoints returns the proof's checkpoints ordered by sequence
+// number, then name. A proof without checkpoints returns an empty slice.
+func (s *ProofService) ListCheckpoints() ([]Checkpoint, error) {
+	checkpoints, err := loadCheckpoints(s.path)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(checkpoints, func(i, j int) bool {
+		if checkpoints[i].Seq != checkpoints[j].Seq {
+			return checkpoints[i].Seq < checkpoints[j].Seq
+		}
+		return checkpoints[i].Name < checkpoints[j].Name
+	})
+	return checkpoints, nil
+}
+
+// RestoreCheckpoint writes a copy of the proof to destDir whose ledger holds
+// only the events up to the checkpoint called name. The ledger is
+// append-only, so the source proof is never truncated; destDir must not
+// exist or be an empty directory, and meta.json and the files in .af are
+// copied as for CloneProof. The copy keeps the checkpoints recorded at or
+// before the restored one.
+//
+// Returns ErrCheckpointNotFound if no checkpoint is called name.
+// Returns ErrInvalidState if the ledger no longer holds every event up to
+// the checkpoint's sequence number.
+// Returns ErrAlreadyExists if destDir is not empty.
+func (s *ProofService) RestoreCheckpoint(name, destDir string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: checkpoint name", ErrEmptyInput)
+	}
+	checkpoints, err := loadCheckpoints(s.path)
+	if err != nil {
+		return err
+	}
+	var cp *Checkpoint
+	for i := range checkpoints {
+		if checkpoints[i].Name == name {
+			cp = &checkpoints[i]
+			break
+		}
+	}
+	if cp == nil {
+		return fmt.Errorf("%w: %q", ErrCheckpointNotFound, name)
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+	var events []json.RawMessage
+	err = ldg.Scan(func(seq int, data []byte) error {
+		if seq <= cp.Seq {
+			events = append(events, data)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(events) != cp.Seq {
+		return fmt.Errorf("%w: checkpoint %q is at sequence %d but the ledger holds only %d of those events",
+			ErrInvalidState, name, cp.Seq, len(events))
+	}
+
+	if err := s.initDestination(destDir); err != nil {
+		return err
+	}
+	destLedgerDir := filepath.Join(destDir, "ledger")
+	if err := ledger.Rebuild(destLedgerDir, events); err != nil {
+		return fmt.Errorf("failed to write restored ledger: %w", err)
+	}
+
+	var kept []Checkpoint
+	for _, c := range checkpoints {
+		if c.Seq <= cp.Seq {
+			kept = append(kept, c)
+		}
+	}
+	if err := saveCheckpoints(destDir, kept); err != nil {
+		return err
+	}
+
+	destLedger, err := ledger.NewLedger(destLedgerDir)
+	if err != nil {
+		return err
+	}
+	if _, err := state.Replay(destLedger); err != nil {
+		return fmt.Errorf("%w: restored ledger does not replay: %v", ErrInvalidState, err)
+	}
+	return nil
+}
+
+// loadCheckpoints reads the checkpoints recorded for the proof in proofDir.
+// A missing checkpoints file means there are none.
+func loadCheckpoints(proofDir string) ([]Checkpoint, error) {
+	data, err := os.ReadFile(filepath.Join(proofDir, ".af", checkpointsFilename))
+	if os.IsNotExist(err) {
+		return []Checkpoint{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+	var checkpoints []Checkpoint
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoints: %w", err)
+	}
+	if checkpoints == nil {
+		checkpoints = []Checkpoint{}
+	}
+	return checkpoints, nil
+}
+
+// saveCheckpoints atomically replaces the checkpoints file of the proof in
+// proofDir.
+func saveCheckpoints(proofDir string, checkpoints []Checkpoint) error {
+	afDir := filepath.Join(proofDir, ".af")
+	if err := os.MkdirAll(afDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", afDir, err)
+	}
+	if checkpoints == nil {
+		checkpoints = []Checkpoint{}
+	}
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoints: %w", err)
+	}
+	if err := fs.WriteFileAtomic(filepath.Join(afDir, checkpointsFilename), data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	return nil
+}
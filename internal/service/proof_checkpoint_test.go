@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+func TestCheckpoint_RestoreBeforeExperiment(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	seq, err := svc.Checkpoint("before")
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != st.LatestSeq() {
+		t.Errorf("Checkpoint returned %d, want latest sequence %d", seq, st.LatestSeq())
+	}
+
+	// The experiment that goes wrong, and a later checkpoint
+	if err := svc.RefineNode(mustParseID(t, "1"), "prover", mustParseID(t, "1.2"), schema.NodeTypeClaim, "Experiment", schema.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+	after, err := svc.Checkpoint("after")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoints, err := svc.ListCheckpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checkpoints) != 2 || checkpoints[0].Name != "before" || checkpoints[1].Name != "after" || checkpoints[1].Seq != after {
+		t.Errorf("ListCheckpoints() = %+v, want before then after", checkpoints)
+	}
+
+	dest := filepath.Join(t.TempDir(), "restored")
+	if err := svc.RestoreCheckpoint("before", dest); err != nil {
+		t.Fatalf("RestoreCheckpoint failed: %v", err)
+	}
+
+	count, err := ledger.Count(filepath.Join(dest, "ledger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != seq {
+		t.Errorf("restored ledger has %d events, want %d", count, seq)
+	}
+	restored, err := NewProofService(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rst, err := restored.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rst.GetNode(mustParseID(t, "1.1")) == nil || rst.GetNode(mustParseID(t, "1.2")) != nil {
+		t.Error("restored proof should have node 1.1 but not the experiment's node 1.2")
+	}
+	kept, err := restored.ListCheckpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 1 || kept[0].Name != "before" {
+		t.Errorf("restored checkpoints = %+v, want only before", kept)
+	}
+
+	// The source proof is untouched
+	if st, err := svc.LoadState(); err != nil || st.GetNode(mustParseID(t, "1.2")) == nil {
+		t.Errorf("source proof lost node 1.2 (err %v)", err)
+	}
+}
+
+func TestCheckpoint_Errors(t *testing.T) {
+	svc := newRenumberTestService(t)
+
+	if _, err := svc.Checkpoint("v1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Checkpoint("v1"); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("duplicate Checkpoint error = %v, want ErrAlreadyExists", err)
+	}
+	if _, err := svc.Checkpoint("  "); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("blank Checkpoint error = %v, want ErrEmptyInput", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "restored")
+	if err := svc.RestoreCheckpoint("missing", dest); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Errorf("RestoreCheckpoint(missing) error = %v, want ErrCheckpointNotFound", err)
+	}
+
+	// A destination that is not empty is refused
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RestoreCheckpoint("v1", dest); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("RestoreCheckpoint into non-empty dir error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestCheckpoint_SequenceMissing(t *testing.T) {
+	svc := newRenumberTestService(t)
+
+	// A checkpoint recorded beyond the end of the ledger, e.g. copied from
+	// another proof, no longer has its events
+	stale := []Checkpoint{{Name: "stale", Seq: 999}}
+	if err := saveCheckpoints(svc.Path(), stale); err != nil {
+		t.Fatal(err)
+	}
+	err := svc.RestoreCheckpoint("stale", filepath.Join(t.TempDir(), "restored"))
+	if !errors.Is(err, ErrInvalidState) {
+		t.Errorf("RestoreCheckpoint(stale) error = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestCheckpoint_DryRunWritesNothing(t *testing.T) {
+	svc, err := NewProofService(newRenumberTestService(t).Path(), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Checkpoint("v1"); err != nil {
+		t.Fatalf("dry-run Checkpoint failed: %v", err)
+	}
+	checkpoints, err := svc.ListCheckpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checkpoints) != 0 {
+		t.Errorf("dry-run Checkpoint recorded %+v", checkpoints)
+	}
+}
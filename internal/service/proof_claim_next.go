@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// ClaimNextOptions restricts which nodes ClaimNext may pick.
+// The zero value accepts any open node.
+type ClaimNextOptions struct {
+	// NodeType, if set, only accepts nodes of this type.
+	NodeType schema.NodeType
+
+	// Tag, if set, only accepts nodes carrying this tag.
+	Tag string
+}
+
+// ClaimNext selects the best node to work on and claims it for owner in one
+// operation, closing the gap in which agents that list available nodes and
+// then claim one race each other.
+//
+// Candidates are nodes that are available and still open (pending or
+// needing refinement) and match opts. The deepest candidate is chosen, as
+// the closest to a leaf of the proof; ties go to the lowest node ID. The
+// selection is made against state loaded with the chosen node locked (see
+// lockNodes) and the claim is appended only if the ledger has not changed
+// since, so a node claimed concurrently is never claimed twice.
+//
+// Returns the claimed node.
+// Returns ErrEmptyInput if owner is empty, ErrInvalidTimeout if timeout is
+// not positive, or an error if opts.NodeType or opts.Tag is invalid.
+// Returns ErrNoAvailableNodes if no node matches.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry, which selects again.
+func (s *ProofService) ClaimNext(owner string, timeout time.Duration, opts ClaimNextOptions) (*node.Node, error) {
+	if strings.TrimSpace(owner) == "" {
+		return nil, fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+	if timeout <= 0 {
+		return nil, ErrInvalidTimeout
+	}
+	if opts.NodeType != "" {
+		if err := schema.ValidateNodeType(string(opts.NodeType)); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Tag != "" {
+		if err := node.ValidateTag(opts.Tag); err != nil {
+			return nil, err
+		}
+	}
+
+	// Lock the candidate, then capture the sequence of the state loaded
+	// under the lock for CAS
+	st, locked, err := s.lockTouchedNodes(func(st *state.State) []types.NodeID {
+		if n := selectClaimCandidate(st, opts); n != nil {
+			return []types.NodeID{n.ID}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockNodes(locked)
+	expectedSeq := st.LatestSeq()
+
+	n := selectClaimCandidate(st, opts)
+	if n == nil {
+		return nil, ErrNoAvailableNodes
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	event := ledger.NewNodesClaimed([]types.NodeID{n.ID}, owner, types.FromTime(time.Now().Add(timeout)))
+	if _, err := s.appendIfSequence(ldg, event, expectedSeq); err != nil {
+		return nil, wrapSequenceMismatch(err, "ClaimNext")
+	}
+
+	// st is a private copy, so the claim can be applied to it to return the
+	// node as it now is
+	if err := state.Apply(st, event); err != nil {
+		return nil, err
+	}
+	return st.GetNode(n.ID), nil
+}
+
+// selectClaimCandidate returns the deepest available, open node in st that
+// matches opts, preferring the lowest ID among equally deep nodes, or nil
+// if there is none.
+func selectClaimCandidate(st *state.State, opts ClaimNextOptions) *node.Node {
+	var best *node.Node
+	for _, n := range st.AllNodesSorted() {
+		if n.WorkflowState != schema.WorkflowAvailable {
+			continue
+		}
+		if n.EpistemicState != schema.EpistemicPending && n.EpistemicState != schema.EpistemicNeedsRefinement {
+			continue
+		}
+		if opts.NodeType != "" && n.Type != opts.NodeType {
+			continue
+		}
+		if opts.Tag != "" && !n.HasTag(opts.Tag) {
+			continue
+		}
+		if best == nil || n.ID.Depth() > best.ID.Depth() {
+			best = n
+		}
+	}
+	return best
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+func TestClaimNext_PrefersDeepestNode(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2", "1.2.1", "1.2.2")
+
+	n, err := svc.ClaimNext("agent", time.Hour, ClaimNextOptions{})
+	if err != nil {
+		t.Fatalf("ClaimNext failed: %v", err)
+	}
+	if n.ID.String() != "1.2.1" {
+		t.Errorf("claimed %s, want 1.2.1 (deepest, lowest ID)", n.ID)
+	}
+	if n.WorkflowState != schema.WorkflowClaimed || n.ClaimedBy != "agent" {
+		t.Errorf("returned node is %s by %q, want claimed by agent", n.WorkflowState, n.ClaimedBy)
+	}
+
+	// The next call must skip the node that is now claimed
+	n, err = svc.ClaimNext("agent", time.Hour, ClaimNextOptions{})
+	if err != nil {
+		t.Fatalf("second ClaimNext failed: %v", err)
+	}
+	if n.ID.String() != "1.2.2" {
+		t.Errorf("second claim got %s, want 1.2.2", n.ID)
+	}
+}
+
+func TestClaimNext_Filters(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2")
+	if err := svc.TagNode(mustParseID(t, "1.1"), "algebra"); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := svc.ClaimNext("agent", time.Hour, ClaimNextOptions{Tag: "algebra"})
+	if err != nil {
+		t.Fatalf("ClaimNext(tag) failed: %v", err)
+	}
+	if n.ID.String() != "1.1" {
+		t.Errorf("claimed %s, want 1.1 (the only tagged node)", n.ID)
+	}
+
+	_, err = svc.ClaimNext("agent", time.Hour, ClaimNextOptions{NodeType: schema.NodeTypeLocalAssume})
+	if !errors.Is(err, ErrNoAvailableNodes) {
+		t.Errorf("ClaimNext(type) error = %v, want ErrNoAvailableNodes", err)
+	}
+}
+
+func TestClaimNext_SkipsSettledNodes(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	if err := svc.AcceptNode(mustParseID(t, "1.1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1 is claimed and 1.1 is validated
+	if _, err := svc.ClaimNext("agent", time.Hour, ClaimNextOptions{}); !errors.Is(err, ErrNoAvailableNodes) {
+		t.Errorf("ClaimNext error = %v, want ErrNoAvailableNodes", err)
+	}
+}
+
+func TestClaimNext_InvalidInput(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	tests := []struct {
+		name    string
+		owner   string
+		timeout time.Duration
+		opts    ClaimNextOptions
+		want    error
+	}{
+		{"empty owner", " ", time.Hour, ClaimNextOptions{}, ErrEmptyInput},
+		{"zero timeout", "agent", 0, ClaimNextOptions{}, ErrInvalidTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.ClaimNext(tt.owner, tt.timeout, tt.opts); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := svc.ClaimNext("agent", time.Hour, ClaimNextOptions{NodeType: "bogus"}); err == nil {
+		t.Error("ClaimNext with an unknown node type succeeded")
+	}
+}
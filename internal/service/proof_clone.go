@@ -0,0 +1,106 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tobias/vibefeld/internal/fs"
+	"github.com/tobias/vibefeld/internal/ledger"
+)
+
+// CloneProof copies the proof into destDir, which must not exist or be an
+// empty directory. The ledger is copied event by event with its sequence
+// numbers and bytes unchanged, re-verifying the prev_hash chain and node
+// content hashes as it goes; meta.json and the files in .af (hooks,
+// patterns) are copied alongside it.
+//
+// The clone shares nothing with the source, so either proof can be mutated
+// independently afterwards. Unlike a dump and restore, no intermediate
+// stream is produced.
+//
+// Returns ErrAlreadyExists if destDir is not empty.
+// Returns an error wrapping ledger.ErrChainBroken if the source ledger's
+// hash chain is broken.
+func (s *ProofService) CloneProof(destDir string) error {
+	if err := s.initDestination(destDir); err != nil {
+		return err
+	}
+	if _, err := ledger.Copy(filepath.Join(s.path, "ledger"), filepath.Join(destDir, "ledger")); err != nil {
+		return fmt.Errorf("failed to copy ledger: %w", err)
+	}
+	return nil
+}
+
+// initDestination checks that destDir does not exist or is empty, creates
+// a proof directory there, and copies meta.json and the files in .af into
+// it, leaving its ledger empty.
+// Returns ErrAlreadyExists if destDir is not empty.
+func (s *ProofService) initDestination(destDir string) error {
+	if destDir == "" {
+		return fmt.Errorf("%w: destination directory", ErrEmptyInput)
+	}
+	entries, err := os.ReadDir(destDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read destination: %w", err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: destination %s is not empty", ErrAlreadyExists, destDir)
+	}
+
+	if err := fs.InitProofDir(destDir); err != nil {
+		return err
+	}
+	for _, name := range []string{"meta.json", ".af"} {
+		if err := copyProofFiles(filepath.Join(s.path, name), filepath.Join(destDir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyProofFiles copies src to dst. If src is a directory, the regular
+// files directly inside it are copied into dst. A missing src is not an
+// error.
+func copyProofFiles(src, dst string) error {
+	info, err := os.Stat(src)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyProofFile(src, dst, info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		entryInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if err := copyProofFile(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), entryInfo.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyProofFile copies a single file, overwriting dst.
+func copyProofFile(src, dst string, perm os.FileMode) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, perm)
+}
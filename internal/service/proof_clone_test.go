@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+func TestCloneProof(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	meta := []byte(`{"version": "1.0", "max_depth": 7}`)
+	if err := os.WriteFile(filepath.Join(svc.Path(), "meta.json"), meta, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := svc.CloneProof(dest); err != nil {
+		t.Fatalf("CloneProof failed: %v", err)
+	}
+
+	cmp, err := ledger.Compare(filepath.Join(svc.Path(), "ledger"), filepath.Join(dest, "ledger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Identical() {
+		t.Errorf("cloned ledger differs from the source: %+v", cmp)
+	}
+	if got, err := os.ReadFile(filepath.Join(dest, "meta.json")); err != nil || string(got) != string(meta) {
+		t.Errorf("cloned meta.json = %q (err %v), want %q", got, err, meta)
+	}
+
+	// Mutating the clone leaves the source untouched.
+	clone, err := NewProofService(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := ledgerCount(t, svc)
+	if err := clone.CreateNode(mustParseID(t, "1.2"), schema.NodeTypeClaim, "Claim 1.2", schema.InferenceAssumption); err != nil {
+		t.Fatalf("CreateNode on clone failed: %v", err)
+	}
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("source has %d events after mutating the clone, want %d", got, before)
+	}
+	if got := ledgerCount(t, clone); got != before+1 {
+		t.Errorf("clone has %d events, want %d", got, before+1)
+	}
+}
+
+func TestCloneProof_NonEmptyDestination(t *testing.T) {
+	svc := newChallengeTestService(t)
+	dest := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dest, "notes.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.CloneProof(dest); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("CloneProof error = %v, want ErrAlreadyExists", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "ledger")); !os.IsNotExist(err) {
+		t.Errorf("CloneProof wrote into a non-empty destination")
+	}
+}
+
+func TestCloneProof_EmptyDestination(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if err := svc.CloneProof(t.TempDir()); err != nil {
+		t.Fatalf("CloneProof into an existing empty directory failed: %v", err)
+	}
+}
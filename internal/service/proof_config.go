@@ -0,0 +1,48 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/tobias/vibefeld/internal/config"
+	"github.com/tobias/vibefeld/internal/fs"
+)
+
+// SetConfig validates cfg's runtime-tunable settings (see
+// config.ValidateLimits) and writes it to meta.json atomically, replacing
+// the cached config returned by Config.
+//
+// Limits only constrain later operations: lowering MaxDepth or MaxChildren
+// below what existing nodes already use is allowed and leaves those nodes
+// in place. In dry-run mode cfg is validated but not written.
+//
+// Returns ErrEmptyInput if cfg is nil, or a validation error describing the
+// first invalid setting.
+func (s *ProofService) SetConfig(cfg *config.Config) error {
+	if cfg == nil {
+		return fmt.Errorf("%w: config", ErrEmptyInput)
+	}
+	if err := config.ValidateLimits(cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	if s.dryRun {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := fs.WriteFileAtomic(filepath.Join(s.path, "meta.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	saved := *cfg
+	saved.BlockingSeverities = append([]string(nil), cfg.BlockingSeverities...)
+	s.cfg = &saved
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+func TestSetConfig(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2", "1.3")
+
+	cfg, err := svc.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := *cfg
+	updated.MaxDepth = 30
+	updated.MaxChildren = 2
+	updated.LockTimeout = 10 * time.Minute
+	updated.BlockingSeverities = []string{"critical"}
+
+	// Lowering MaxChildren below the three existing children is allowed
+	if err := svc.SetConfig(&updated); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if got, _ := svc.Config(); got.MaxDepth != 30 || got.MaxChildren != 2 {
+		t.Errorf("cached config = depth %d, children %d; want 30, 2", got.MaxDepth, got.MaxChildren)
+	}
+
+	// The change is persisted for new services
+	reloaded, err := NewProofService(svc.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reloaded.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MaxDepth != 30 || got.MaxChildren != 2 || got.LockTimeout != 10*time.Minute {
+		t.Errorf("persisted config = depth %d, children %d, timeout %v; want 30, 2, 10m", got.MaxDepth, got.MaxChildren, got.LockTimeout)
+	}
+	if len(got.BlockingSeverities) != 1 || got.BlockingSeverities[0] != "critical" {
+		t.Errorf("persisted BlockingSeverities = %v, want [critical]", got.BlockingSeverities)
+	}
+
+	// ...but constrains later additions
+	err = reloaded.Refine(RefineSpec{
+		ParentID:  mustParseID(t, "1"),
+		Owner:     "prover",
+		ChildID:   mustParseID(t, "1.4"),
+		NodeType:  schema.NodeTypeClaim,
+		Statement: "Claim 1.4",
+		Inference: schema.InferenceAssumption,
+	})
+	if !errors.Is(err, ErrMaxChildrenExceeded) {
+		t.Errorf("Refine after lowering MaxChildren = %v, want ErrMaxChildrenExceeded", err)
+	}
+}
+
+func TestSetConfig_Invalid(t *testing.T) {
+	svc := newChallengeTestService(t)
+	metaPath := filepath.Join(svc.path, "meta.json")
+	before, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.SetConfig(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("SetConfig(nil) = %v, want ErrEmptyInput", err)
+	}
+	for name, modify := range map[string]func(c *Config){
+		"zero depth":       func(c *Config) { c.MaxDepth = 0 },
+		"zero children":    func(c *Config) { c.MaxChildren = 0 },
+		"negative timeout": func(c *Config) { c.LockTimeout = -time.Second },
+		"unknown severity": func(c *Config) { c.BlockingSeverities = []string{"urgent"} },
+	} {
+		cfg, err := svc.Config()
+		if err != nil {
+			t.Fatal(err)
+		}
+		invalid := *cfg
+		modify(&invalid)
+		if err := svc.SetConfig(&invalid); err == nil {
+			t.Errorf("SetConfig with %s succeeded", name)
+		}
+	}
+
+	after, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("meta.json changed after rejected updates:\n%s", after)
+	}
+	if cfg, _ := svc.Config(); cfg.MaxDepth != 20 {
+		t.Errorf("cached MaxDepth = %d, want 20", cfg.MaxDepth)
+	}
+}
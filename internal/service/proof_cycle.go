@@ -38,6 +38,27 @@ func (p *stateDependencyProvider) AllNodeIDs() []types.NodeID {
 	return ids
 }
 
+// amendedDependencyProvider overrides the dependencies of a single node so
+// that cycle checks see a proposed amendment instead of the current edges.
+type amendedDependencyProvider struct {
+	base cycle.DependencyProvider
+	id   types.NodeID
+	deps []types.NodeID
+}
+
+// GetNodeDependencies implements cycle.DependencyProvider.
+func (p *amendedDependencyProvider) GetNodeDependencies(id types.NodeID) ([]types.NodeID, bool) {
+	if id.String() == p.id.String() {
+		return p.deps, true
+	}
+	return p.base.GetNodeDependencies(id)
+}
+
+// AllNodeIDs implements cycle.DependencyProvider.
+func (p *amendedDependencyProvider) AllNodeIDs() []types.NodeID {
+	return p.base.AllNodeIDs()
+}
+
 // CheckCycles checks if there is a cycle in the dependency graph starting from
 // the given node ID. This is used to validate refinements don't introduce
 // circular reasoning.
@@ -67,8 +88,12 @@ func (s *ProofService) CheckAllCycles() ([]cycle.CycleResult, error) {
 		return nil, err
 	}
 
-	provider := &stateDependencyProvider{st: st}
-	return cycle.DetectAllCycles(provider), nil
+	return allCycles(st), nil
+}
+
+// allCycles returns every dependency cycle in st, one result per cycle.
+func allCycles(st *state.State) []cycle.CycleResult {
+	return cycle.DetectAllCycles(&stateDependencyProvider{st: st})
 }
 
 // WouldCreateCycle checks if adding a dependency from fromID to toID would
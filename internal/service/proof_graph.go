@@ -0,0 +1,22 @@
+package service
+
+import "github.com/tobias/vibefeld/internal/state"
+
+// DependencyGraph returns the proof's dependency graph as raw data: every
+// node with its metadata, every dependency and validation dependency as an
+// edge tagged by kind, and the dependency cycles found by CheckAllCycles.
+// It is the data the DOT and Mermaid exports are drawn from, for
+// integrators that lay out the graph themselves.
+// Note: This method performs I/O to load state from disk.
+func (s *ProofService) DependencyGraph() (*Graph, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	g := state.NewGraph(st)
+	for _, c := range allCycles(st) {
+		g.Cycles = append(g.Cycles, c.Path)
+	}
+	return g, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	if err := svc.Refine(RefineSpec{
+		ParentID:       mustParseID(t, "1"),
+		Owner:          "prover",
+		ChildID:        mustParseID(t, "1.2"),
+		NodeType:       schema.NodeTypeClaim,
+		Statement:      "Claim 1.2",
+		Inference:      schema.InferenceAssumption,
+		Dependencies:   []types.NodeID{mustParseID(t, "1.1")},
+		ValidationDeps: []types.NodeID{mustParseID(t, "1.1")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := svc.DependencyGraph()
+	if err != nil {
+		t.Fatalf("DependencyGraph() error: %v", err)
+	}
+
+	if len(g.Nodes) != 3 {
+		t.Errorf("len(Nodes) = %d, want 3", len(g.Nodes))
+	}
+	if len(g.Edges) != 2 {
+		t.Fatalf("Edges = %+v, want 2 edges", g.Edges)
+	}
+	for i, kind := range []string{EdgeDependency, EdgeValidation} {
+		e := g.Edges[i]
+		if e.From.String() != "1.2" || e.To.String() != "1.1" || e.Kind != kind {
+			t.Errorf("Edges[%d] = %s -> %s (%s), want 1.2 -> 1.1 (%s)", i, e.From, e.To, e.Kind, kind)
+		}
+	}
+	if len(g.Cycles) != 0 {
+		t.Errorf("Cycles = %v, want none", g.Cycles)
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("json.Marshal(graph) error: %v", err)
+	}
+	var decoded struct {
+		Edges []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+			Kind string `json:"kind"`
+		} `json:"edges"`
+		Cycles [][]string `json:"cycles"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(graph) error: %v", err)
+	}
+	if len(decoded.Edges) != 2 || decoded.Edges[0].From != "1.2" || decoded.Edges[1].Kind != EdgeValidation {
+		t.Errorf("decoded edges = %+v, want 1.2 -> 1.1 edges", decoded.Edges)
+	}
+	if decoded.Cycles == nil {
+		t.Error("cycles serialized as null, want []")
+	}
+}
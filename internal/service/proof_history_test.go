@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+)
+
+func TestNodeHistory(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	rootID := mustParseID(t, "1")
+
+	if err := svc.AmendNode(rootID, "prover", "Amended conjecture"); err != nil {
+		t.Fatal(err)
+	}
+	challengeID, err := svc.RaiseChallenge(rootID, "statement", "Gap in the argument", "major")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ResolveChallenge(challengeID); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ReleaseNode(rootID, "prover"); err != nil {
+		t.Fatal(err)
+	}
+	// Events on other nodes stay out of the root's history
+	if err := svc.AcceptNode(mustParseID(t, "1.1")); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := svc.NodeHistory(rootID)
+	if err != nil {
+		t.Fatalf("NodeHistory failed: %v", err)
+	}
+
+	want := []struct {
+		typ     ledger.EventType
+		summary string
+	}{
+		{ledger.EventNodeCreated, "created as claim: Test conjecture"},
+		{ledger.EventNodesClaimed, "claimed by prover"},
+		{ledger.EventNodeAmended, "amended by prover: statement"},
+		{ledger.EventChallengeRaised, "challenge " + challengeID + " raised on statement: Gap in the argument (major)"},
+		{ledger.EventChallengeResolved, "challenge " + challengeID + " resolved"},
+		{ledger.EventNodesReleased, "released"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i].Type != w.typ || entries[i].Summary != w.summary {
+			t.Errorf("entry %d = %s %q, want %s %q", i, entries[i].Type, entries[i].Summary, w.typ, w.summary)
+		}
+		if i > 0 && entries[i].Seq <= entries[i-1].Seq {
+			t.Errorf("entry %d has seq %d, not after %d", i, entries[i].Seq, entries[i-1].Seq)
+		}
+	}
+}
+
+func TestNodeHistory_NodeNotFound(t *testing.T) {
+	svc := newRenumberTestService(t)
+
+	if _, err := svc.NodeHistory(mustParseID(t, "1.9")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("NodeHistory(missing) error = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestClaimDurations(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	rootID := mustParseID(t, "1")
+
+	if err := svc.ReleaseNode(rootID, "prover"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ClaimNode(rootID, "prover-2", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	durations, err := svc.ClaimDurations()
+	if err != nil {
+		t.Fatalf("ClaimDurations failed: %v", err)
+	}
+	if got := len(durations["1"]); got != 2 {
+		t.Errorf("root has %d claim durations, want 2: %v", got, durations)
+	}
+	for _, d := range durations["1"] {
+		if d < 0 || d > time.Minute {
+			t.Errorf("claim duration %s out of range", d)
+		}
+	}
+	if _, ok := durations["1.1"]; ok {
+		t.Errorf("unclaimed node 1.1 has durations %v", durations["1.1"])
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	aferrors "github.com/tobias/vibefeld/internal/errors"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// CheckInvariants checks the structural invariants of the whole proof and
+// returns a report of every violation found:
+//
+//   - ledger sequence gaps (critical)
+//   - dependencies on nodes that don't exist (critical)
+//   - dependency cycles, via CheckAllCycles (critical)
+//   - content hashes that fail VerifyContentHash (critical)
+//   - validated nodes with dependencies that are not validated or admitted
+//
+// A ledger with gaps cannot be replayed, so when gaps are found the state
+// checks are skipped and only the gaps are reported.
+//
+// The returned error is reserved for failures to run the checks; violations
+// are reported, not returned. Use InvariantReportError to turn a report into
+// an exit-coded error.
+func (s *ProofService) CheckInvariants() (*state.InvariantReport, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &state.InvariantReport{}
+	if report.EventsChecked, err = ldg.Count(); err != nil {
+		return nil, err
+	}
+
+	missing, err := ledger.MissingSequences(ldg.Dir())
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		seqs := make([]string, len(missing))
+		for i, seq := range missing {
+			seqs[i] = strconv.Itoa(seq)
+		}
+		report.Violations = append(report.Violations, state.InvariantViolation{
+			Kind:     state.ViolationLedgerGap,
+			Critical: true,
+			Message: fmt.Sprintf("ledger is missing event(s) %s; state checks skipped because the ledger cannot be replayed",
+				strings.Join(seqs, ", ")),
+		})
+		return report, nil
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	report.NodesChecked = len(st.AllNodes())
+	report.Violations = append(report.Violations, state.CheckNodeInvariants(st)...)
+
+	cycles, err := s.CheckAllCycles()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cycles {
+		if !c.HasCycle {
+			continue
+		}
+		report.Violations = append(report.Violations, state.InvariantViolation{
+			Kind:     state.ViolationDependencyCycle,
+			Critical: true,
+			NodeIDs:  c.Path,
+			Message:  "dependency cycle: " + strings.Join(types.ToStringSlice(c.Path), " -> "),
+		})
+	}
+
+	return report, nil
+}
+
+// invariantErrorCodes maps critical violation kinds to the error code whose
+// exit code they should produce.
+var invariantErrorCodes = map[string]aferrors.ErrorCode{
+	state.ViolationLedgerGap:         aferrors.LEDGER_INCONSISTENT,
+	state.ViolationContentHash:       aferrors.CONTENT_HASH_MISMATCH,
+	state.ViolationDependencyCycle:   aferrors.DEPENDENCY_CYCLE,
+	state.ViolationMissingDependency: aferrors.NODE_NOT_FOUND,
+}
+
+// InvariantReportError returns nil if the report has no critical violations.
+// Otherwise it returns an error carrying the most severe exit code among
+// them: corruption (ledger gaps, hash mismatches) exits 7, dependency cycles
+// exit 6 and missing dependencies exit 3. The exit codes of these categories
+// increase with severity, so the highest one wins.
+func InvariantReportError(report *state.InvariantReport) error {
+	var code aferrors.ErrorCode
+	critical := 0
+	for _, v := range report.Violations {
+		if !v.Critical {
+			continue
+		}
+		critical++
+		c, ok := invariantErrorCodes[v.Kind]
+		if !ok {
+			c = aferrors.LEDGER_INCONSISTENT
+		}
+		if code == 0 || c.ExitCode() > code.ExitCode() {
+			code = c
+		}
+	}
+	if critical == 0 {
+		return nil
+	}
+	return aferrors.Newf(code, "proof validation failed: %d critical invariant violation(s)", critical)
+}
+
+// VerifyLedger verifies the proof's ledger event by event and returns a
+// report of every sequence gap, hash chain break, content hash mismatch, and
+// event that does not apply cleanly. See state.VerifyLedger.
+//
+// The returned error is reserved for failures to read the ledger; problems
+// are reported, not returned. Use LedgerVerificationError to turn a report
+// into an exit-coded error.
+func (s *ProofService) VerifyLedger() (*state.LedgerVerification, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+	return state.VerifyLedger(ldg)
+}
+
+// LedgerVerificationError returns nil if the ledger verified cleanly.
+// Otherwise it returns a LEDGER_INCONSISTENT error (exit code 7).
+func LedgerVerificationError(report *state.LedgerVerification) error {
+	if report.OK() {
+		return nil
+	}
+	return aferrors.Newf(aferrors.LEDGER_INCONSISTENT, "ledger verification failed: %d issue(s)", len(report.Issues))
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	aferrors "github.com/tobias/vibefeld/internal/errors"
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestCheckInvariants_Clean(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2")
+
+	report, err := svc.CheckInvariants()
+	if err != nil {
+		t.Fatalf("CheckInvariants failed: %v", err)
+	}
+	if len(report.Violations) != 0 {
+		t.Errorf("Violations = %+v, want none", report.Violations)
+	}
+	if report.NodesChecked != 3 {
+		t.Errorf("NodesChecked = %d, want 3", report.NodesChecked)
+	}
+	if report.EventsChecked == 0 {
+		t.Error("EventsChecked = 0")
+	}
+	if err := InvariantReportError(report); err != nil {
+		t.Errorf("InvariantReportError = %v, want nil", err)
+	}
+}
+
+func TestCheckInvariants_DependencyCycle(t *testing.T) {
+	svc := newChallengeTestService(t)
+	ldg, err := svc.getLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bypass Refine's cycle check by writing the nodes straight to the ledger
+	for _, ids := range [][2]string{{"1.1", "1.2"}, {"1.2", "1.1"}} {
+		n, err := node.NewNodeWithOptions(mustParseID(t, ids[0]), schema.NodeTypeClaim, "Claim "+ids[0], schema.InferenceAssumption,
+			node.NodeOptions{Dependencies: []types.NodeID{mustParseID(t, ids[1])}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ldg.Append(ledger.NewNodeCreated(*n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := svc.CheckInvariants()
+	if err != nil {
+		t.Fatalf("CheckInvariants failed: %v", err)
+	}
+	if len(report.Violations) != 1 || report.Violations[0].Kind != state.ViolationDependencyCycle {
+		t.Fatalf("Violations = %+v, want one dependency cycle", report.Violations)
+	}
+	if got := aferrors.ExitCode(InvariantReportError(report)); got != 6 {
+		t.Errorf("exit code = %d, want 6", got)
+	}
+}
+
+func TestCheckInvariants_LedgerGap(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	ldg, err := svc.getLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(ldg.Dir(), ledger.GenerateFilename(2))); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := svc.CheckInvariants()
+	if err != nil {
+		t.Fatalf("CheckInvariants failed: %v", err)
+	}
+	if len(report.Violations) != 1 || report.Violations[0].Kind != state.ViolationLedgerGap {
+		t.Fatalf("Violations = %+v, want one ledger gap", report.Violations)
+	}
+	if got := aferrors.ExitCode(InvariantReportError(report)); got != 7 {
+		t.Errorf("exit code = %d, want 7", got)
+	}
+}
+
+func TestInvariantReportError(t *testing.T) {
+	tests := []struct {
+		name       string
+		violations []state.InvariantViolation
+		want       int
+	}{
+		{"none", nil, 0},
+		{"warnings only", []state.InvariantViolation{{Kind: state.ViolationUnvalidatedDependency}}, 0},
+		{"missing dependency", []state.InvariantViolation{{Kind: state.ViolationMissingDependency, Critical: true}}, 3},
+		{"corruption wins", []state.InvariantViolation{
+			{Kind: state.ViolationDependencyCycle, Critical: true},
+			{Kind: state.ViolationContentHash, Critical: true},
+		}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := InvariantReportError(&state.InvariantReport{Violations: tt.violations})
+			if got := aferrors.ExitCode(err); got != tt.want {
+				t.Errorf("exit code = %d (err %v), want %d", got, err, tt.want)
+			}
+		})
+	}
+}
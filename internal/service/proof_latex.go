@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// SetNodeLatex attaches, replaces, or clears the LaTeX rendering of a node
+// without amending its statement, so formal notation can be added to a node
+// after it was created. An empty latex clears the rendering. The owner must
+// hold the claim on the node. Setting the LaTeX the node already has is a
+// no-op.
+//
+// Returns ErrEmptyInput if owner is empty or whitespace-only.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+// Returns ErrNotClaimed if the node is not claimed, or ErrOwnerMismatch if it
+// is claimed by someone else.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) SetNodeLatex(nodeID types.NodeID, owner, latex string) error {
+	if strings.TrimSpace(owner) == "" {
+		return fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+	if strings.TrimSpace(latex) == "" {
+		latex = ""
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	if n.WorkflowState != schema.WorkflowClaimed {
+		return ErrNotClaimed
+	}
+	if n.ClaimedBy != owner {
+		return fmt.Errorf("%w: node is claimed by %s, not %s", ErrOwnerMismatch, n.ClaimedBy, owner)
+	}
+	if n.Latex == latex {
+		return nil
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appendIfSequence(ldg, ledger.NewNodeLatexSet(nodeID, n.Latex, latex, owner), expectedSeq)
+	return wrapSequenceMismatch(err, "SetNodeLatex")
+}
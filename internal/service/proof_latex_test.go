@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetNodeLatex(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	id := mustParseID(t, "1")
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statement := st.GetNode(id).Statement
+
+	if err := svc.SetNodeLatex(id, "prover", `$\forall x, x^2 \geq 0$`); err != nil {
+		t.Fatalf("SetNodeLatex failed: %v", err)
+	}
+	st, err = svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := st.GetNode(id)
+	if n.Latex != `$\forall x, x^2 \geq 0$` {
+		t.Errorf("Latex = %q", n.Latex)
+	}
+	if n.Statement != statement {
+		t.Errorf("SetNodeLatex changed the statement to %q", n.Statement)
+	}
+	if n.ContentHash != n.ComputeContentHash() {
+		t.Error("content hash was not recomputed")
+	}
+
+	// The LaTeX export uses the rendering instead of the escaped statement
+	out, err := ExportProof(st, "latex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `$\forall x, x^2 \geq 0$`) {
+		t.Errorf("LaTeX export does not use the node's LaTeX:\n%s", out)
+	}
+
+	// Setting the same LaTeX again appends nothing; an empty one clears it
+	before := ledgerCount(t, svc)
+	if err := svc.SetNodeLatex(id, "prover", `$\forall x, x^2 \geq 0$`); err != nil {
+		t.Fatal(err)
+	}
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("unchanged LaTeX appended %d events", got-before)
+	}
+	if err := svc.SetNodeLatex(id, "prover", " "); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ = svc.LoadState(); st.GetNode(id).Latex != "" {
+		t.Errorf("Latex = %q after clearing", st.GetNode(id).Latex)
+	}
+}
+
+func TestSetNodeLatex_Errors(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	tests := []struct {
+		name  string
+		id    string
+		owner string
+		want  error
+	}{
+		{"empty owner", "1", " ", ErrEmptyInput},
+		{"missing node", "1.9", "prover", ErrNodeNotFound},
+		{"unclaimed node", "1.1", "prover", ErrNotClaimed},
+		{"other owner", "1", "someone-else", ErrOwnerMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.SetNodeLatex(mustParseID(t, tt.id), tt.owner, "$x$"); !errors.Is(err, tt.want) {
+				t.Errorf("SetNodeLatex error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyLemma_RecordsJustification(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2")
+
+	lemmaID, err := svc.ExtractLemma(mustParseID(t, "1.1"), "Lemma from 1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ApplyLemma(mustParseID(t, "1"), lemmaID, "prover"); err != nil {
+		t.Fatalf("ApplyLemma failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lemmas := st.GetNode(mustParseID(t, "1")).Lemmas
+	if len(lemmas) != 1 || lemmas[0] != lemmaID {
+		t.Errorf("node 1 Lemmas = %v, want [%s]", lemmas, lemmaID)
+	}
+
+	// Applying the same lemma twice is rejected
+	err = svc.ApplyLemma(mustParseID(t, "1"), lemmaID, "prover")
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("second ApplyLemma error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestApplyLemma_Errors(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	lemmaID, err := svc.ExtractLemma(mustParseID(t, "1"), "Lemma from the root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherLemmaID, err := svc.ExtractLemma(mustParseID(t, "1.1"), "Lemma from 1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		nodeID  string
+		lemmaID string
+		owner   string
+		want    error
+	}{
+		{"unknown node", "1.9", otherLemmaID, "prover", ErrNodeNotFound},
+		{"unknown lemma", "1", "LEM-missing", "prover", ErrLemmaNotFound},
+		{"unclaimed node", "1.1", lemmaID, "prover", ErrNotClaimed},
+		{"wrong owner", "1", otherLemmaID, "someone-else", ErrOwnerMismatch},
+		{"source node", "1", lemmaID, "prover", ErrInvalidState},
+		{"empty lemma ID", "1", "", "prover", ErrEmptyInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ApplyLemma(mustParseID(t, tt.nodeID), tt.lemmaID, tt.owner)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ApplyLemma() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefineFromLemma_CreatesChild(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	lemmaID, err := svc.ExtractLemma(mustParseID(t, "1.1"), "Lemma from 1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	childID, err := svc.RefineFromLemma(mustParseID(t, "1"), "prover", lemmaID)
+	if err != nil {
+		t.Fatalf("RefineFromLemma failed: %v", err)
+	}
+	if childID.String() != "1.2" {
+		t.Errorf("child ID = %s, want 1.2", childID)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	child := st.GetNode(childID)
+	if child == nil {
+		t.Fatalf("child %s not found", childID)
+	}
+	if child.Statement != "Lemma from 1.1" {
+		t.Errorf("child statement = %q, want the lemma's statement", child.Statement)
+	}
+	if len(child.Lemmas) != 1 || child.Lemmas[0] != lemmaID {
+		t.Errorf("child Lemmas = %v, want [%s]", child.Lemmas, lemmaID)
+	}
+}
+
+func TestRefineFromLemma_Errors(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	rootLemmaID, err := svc.ExtractLemma(mustParseID(t, "1"), "Lemma from the root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lemmaID, err := svc.ExtractLemma(mustParseID(t, "1.1"), "Lemma from 1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		parentID string
+		owner    string
+		lemmaID  string
+		want     error
+	}{
+		{"unknown lemma", "1", "prover", "LEM-missing", ErrLemmaNotFound},
+		{"unknown parent", "1.9", "prover", lemmaID, ErrParentNotFound},
+		{"unclaimed parent", "1.1", "prover", rootLemmaID, ErrNotClaimed},
+		{"wrong owner", "1", "someone-else", lemmaID, ErrOwnerMismatch},
+		{"lemma from parent", "1", "prover", rootLemmaID, ErrInvalidState},
+		{"empty lemma ID", "1", "prover", "", ErrEmptyInput},
+		{"empty owner", "1", "", lemmaID, ErrEmptyInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.RefineFromLemma(mustParseID(t, tt.parentID), tt.owner, tt.lemmaID)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("RefineFromLemma() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Lint rule IDs. They are stable so that teams can suppress specific rules
+// with LintOptions.Disabled.
+const (
+	// LintLongStatement: a node's statement is longer than
+	// LintOptions.MaxStatementLength characters.
+	LintLongStatement = "long_statement"
+	// LintUnsupportedLeaf: a claim was validated without any children
+	// supporting it.
+	LintUnsupportedLeaf = "unsupported_leaf"
+	// LintAdmittedDebt: a node was admitted without proof.
+	LintAdmittedDebt = "admitted_debt"
+	// LintLinearChain: a path of at least LintOptions.MinChainLength nodes
+	// in which every node but the last has exactly one child, which might
+	// be collapsible.
+	LintLinearChain = "linear_chain"
+	// LintExpiredClaim: a node is still claimed after its claim timeout.
+	LintExpiredClaim = "expired_claim"
+	// LintUnusedAssumption: no live node references an assumption in its
+	// context.
+	LintUnusedAssumption = "unused_assumption"
+)
+
+// Lint warning severities.
+const (
+	// LintSeverityWarning marks a likely problem worth fixing.
+	LintSeverityWarning = "warning"
+	// LintSeverityInfo marks something worth reviewing that is often fine.
+	LintSeverityInfo = "info"
+)
+
+// LintRules lists every lint rule ID in the order Lint checks them.
+func LintRules() []string {
+	return []string{LintLongStatement, LintUnsupportedLeaf, LintAdmittedDebt, LintLinearChain, LintExpiredClaim, LintUnusedAssumption}
+}
+
+// LintWarning is a proof-quality warning. Unlike invariant violations,
+// warnings never mean the proof is inconsistent.
+type LintWarning struct {
+	Rule     string         `json:"rule"`
+	Severity string         `json:"severity"`
+	NodeIDs  []types.NodeID `json:"node_ids"`
+	Message  string         `json:"message"`
+}
+
+// LintOptions holds the thresholds of the lint rules and the rules to skip.
+type LintOptions struct {
+	// MaxStatementLength is the longest statement, in characters, that
+	// LintLongStatement accepts.
+	MaxStatementLength int
+
+	// MinChainLength is the fewest nodes on a path without branching that
+	// LintLinearChain reports.
+	MinChainLength int
+
+	// Disabled lists rule IDs to skip.
+	Disabled []string
+}
+
+// DefaultLintOptions returns the thresholds Lint uses.
+func DefaultLintOptions() LintOptions {
+	return LintOptions{
+		MaxStatementLength: 500,
+		MinChainLength:     4,
+	}
+}
+
+// Lint checks the proof for quality problems with DefaultLintOptions.
+// See LintWithOptions.
+func (s *ProofService) Lint() ([]LintWarning, error) {
+	return s.LintWithOptions(DefaultLintOptions())
+}
+
+// LintWithOptions checks the proof for quality problems that are not
+// structural errors (see CheckInvariants for those):
+//
+//   - long_statement (info): statements longer than opts.MaxStatementLength
+//   - unsupported_leaf (warning): validated claims without children
+//   - admitted_debt (info): admitted nodes, which could still be proven
+//   - linear_chain (info): paths of at least opts.MinChainLength nodes
+//     without branching, reported once on the first node of the path
+//   - expired_claim (warning): nodes still claimed past their timeout
+//   - unused_assumption (warning): assumptions no live node references,
+//     reported without node IDs after the node warnings, in assumption ID
+//     order
+//
+// Archived nodes are skipped. Node warnings are ordered by the ID of their
+// first node, then by rule in LintRules order.
+//
+// Returns an error if opts names an unknown rule or has a non-positive
+// threshold.
+func (s *ProofService) LintWithOptions(opts LintOptions) ([]LintWarning, error) {
+	if opts.MaxStatementLength < 1 {
+		return nil, fmt.Errorf("max statement length must be positive, got %d", opts.MaxStatementLength)
+	}
+	if opts.MinChainLength < 2 {
+		return nil, fmt.Errorf("min chain length must be at least 2, got %d", opts.MinChainLength)
+	}
+	for _, rule := range opts.Disabled {
+		if !slices.Contains(LintRules(), rule) {
+			return nil, fmt.Errorf("unknown lint rule %q: must be one of %s", rule, strings.Join(LintRules(), ", "))
+		}
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return lintState(st, opts, types.Now()), nil
+}
+
+// lintState applies the enabled lint rules to st, treating claims that
+// expired before now as stuck.
+func lintState(st *state.State, opts LintOptions, now types.Timestamp) []LintWarning {
+	nodes := st.AllNodesSorted()
+	children := make(map[string][]*node.Node, len(nodes))
+	for _, n := range nodes {
+		if parent, ok := n.ID.Parent(); ok {
+			children[parent.String()] = append(children[parent.String()], n)
+		}
+	}
+
+	var warnings []LintWarning
+	enabled := func(rule string) bool { return !slices.Contains(opts.Disabled, rule) }
+	add := func(rule, severity string, ids []types.NodeID, format string, args ...interface{}) {
+		if enabled(rule) {
+			warnings = append(warnings, LintWarning{Rule: rule, Severity: severity, NodeIDs: ids, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	for _, n := range nodes {
+		if n.EpistemicState == schema.EpistemicArchived {
+			continue
+		}
+		id := []types.NodeID{n.ID}
+		kids := children[n.ID.String()]
+
+		if length := len([]rune(n.Statement)); length > opts.MaxStatementLength {
+			add(LintLongStatement, LintSeverityInfo, id, "statement is %d characters long (max %d); consider splitting it",
+				length, opts.MaxStatementLength)
+		}
+		if n.Type == schema.NodeTypeClaim && n.EpistemicState == schema.EpistemicValidated && len(kids) == 0 {
+			add(LintUnsupportedLeaf, LintSeverityWarning, id, "claim was validated without any supporting children")
+		}
+		if n.EpistemicState == schema.EpistemicAdmitted {
+			add(LintAdmittedDebt, LintSeverityInfo, id, "admitted without proof")
+		}
+		if chain := linearChain(n, children); len(chain) >= opts.MinChainLength && startsChain(st, n, children) {
+			add(LintLinearChain, LintSeverityInfo, chain, "%d nodes without branching (%s); the chain might be collapsible",
+				len(chain), strings.Join(types.ToStringSlice(chain), " -> "))
+		}
+		if n.IsClaimExpired(now) {
+			add(LintExpiredClaim, LintSeverityWarning, id, "claimed by %s since %s, past its timeout", n.ClaimedBy, n.ClaimedAt)
+		}
+	}
+
+	usage := assumptionUsage(st)
+	for _, assumptionID := range sortedUsageIDs(usage) {
+		u := usage[assumptionID]
+		switch {
+		case len(u.Live) > 0:
+		case len(u.Archived) > 0:
+			add(LintUnusedAssumption, LintSeverityWarning, []types.NodeID{}, "assumption %s is referenced only by archived nodes (%s)",
+				assumptionID, strings.Join(types.ToStringSlice(u.Archived), ", "))
+		default:
+			add(LintUnusedAssumption, LintSeverityWarning, []types.NodeID{}, "assumption %s is not referenced by any node", assumptionID)
+		}
+	}
+
+	return warnings
+}
+
+// linearChain returns n followed by its only child, that child's only
+// child, and so on, ending with the first node that does not have exactly
+// one child. An only child that is archived ends the chain before it.
+func linearChain(n *node.Node, children map[string][]*node.Node) []types.NodeID {
+	chain := []types.NodeID{n.ID}
+	for {
+		kids := children[n.ID.String()]
+		if len(kids) != 1 || kids[0].EpistemicState == schema.EpistemicArchived {
+			return chain
+		}
+		n = kids[0]
+		chain = append(chain, n.ID)
+	}
+}
+
+// startsChain reports whether a chain through n starts at n, that is,
+// whether n is not the only child of a live parent.
+func startsChain(st *state.State, n *node.Node, children map[string][]*node.Node) bool {
+	parentID, ok := n.ID.Parent()
+	if !ok {
+		return true
+	}
+	parent := st.GetNode(parentID)
+	return parent == nil || parent.EpistemicState == schema.EpistemicArchived || len(children[parentID.String()]) != 1
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestLintState(t *testing.T) {
+	st := state.NewState()
+	now := types.FromTime(time.Date(2025, 1, 11, 12, 0, 0, 0, time.UTC))
+	add := func(id string, epistemic schema.EpistemicState) *node.Node {
+		t.Helper()
+		n, err := node.NewNode(mustParseID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.EpistemicState = epistemic
+		st.AddNode(n)
+		return n
+	}
+
+	// 1 -> 1.1 -> 1.1.1 -> 1.1.1.1 is a chain of four; 1.2 branches off the
+	// root, so the chain starts at 1.1
+	add("1", schema.EpistemicPending)
+	add("1.1", schema.EpistemicPending)
+	add("1.1.1", schema.EpistemicPending)
+	add("1.1.1.1", schema.EpistemicValidated)
+	add("1.2", schema.EpistemicAdmitted).Statement = strings.Repeat("x", 30)
+	claimed := add("1.3", schema.EpistemicPending)
+	claimed.WorkflowState = schema.WorkflowClaimed
+	claimed.ClaimedBy = "prover"
+	claimed.ClaimedAt = now.Add(-2 * time.Hour)
+	claimed.ClaimTimeout = time.Hour
+	add("1.4", schema.EpistemicArchived).Statement = strings.Repeat("x", 30)
+
+	opts := LintOptions{MaxStatementLength: 20, MinChainLength: 3}
+	var got []string
+	for _, w := range lintState(st, opts, now) {
+		got = append(got, w.NodeIDs[0].String()+" "+w.Rule+" "+w.Severity)
+	}
+	want := []string{
+		"1.1 linear_chain info",
+		"1.1.1.1 unsupported_leaf warning",
+		"1.2 long_statement info",
+		"1.2 admitted_debt info",
+		"1.3 expired_claim warning",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lintState() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Longer thresholds and disabled rules suppress warnings
+	opts = LintOptions{MaxStatementLength: 30, MinChainLength: 5, Disabled: []string{LintAdmittedDebt, LintExpiredClaim}}
+	got = nil
+	for _, w := range lintState(st, opts, now) {
+		got = append(got, w.NodeIDs[0].String()+" "+w.Rule)
+	}
+	if want := []string{"1.1.1.1 unsupported_leaf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lintState() with suppressions = %v, want %v", got, want)
+	}
+}
+
+func TestLintWithOptions_InvalidOptions(t *testing.T) {
+	svc := newChallengeTestService(t)
+
+	for name, opts := range map[string]LintOptions{
+		"zero statement length": {MaxStatementLength: 0, MinChainLength: 4},
+		"chain of one":          {MaxStatementLength: 500, MinChainLength: 1},
+		"unknown rule":          {MaxStatementLength: 500, MinChainLength: 4, Disabled: []string{"no_such_rule"}},
+	} {
+		if _, err := svc.LintWithOptions(opts); err == nil {
+			t.Errorf("LintWithOptions with %s succeeded", name)
+		}
+	}
+
+	warnings, err := svc.Lint()
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Lint on a fresh proof = %+v, want no warnings", warnings)
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// MoveNode re-parents nodeID and its descendants under newParentID. The node
+// takes the next child ID of the new parent under the configured child ID
+// strategy, and each descendant keeps
+// its path below it: moving 1.2 (with child 1.2.1) under 1.3 yields 1.3.N and
+// 1.3.N.1.
+//
+// As with RenumberSubtree, moved nodes are recreated under their new IDs with
+// their state and notes intact, their old IDs are archived together with the
+// challenges and amendment history recorded under them, and dependencies and
+// validation dependencies pointing at moved nodes are rewritten, elsewhere
+// through amendments recorded for owner. All events are appended as one
+// all-or-nothing batch (see appendBulkIfSequence), with newParentID, the
+// moved subtree and every node citing one of its nodes locked for the whole
+// call (see lockNodes).
+//
+// Returns the new ID of nodeID.
+//
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+// Returns ErrParentNotFound if newParentID doesn't exist.
+// Returns ErrNotClaimed or ErrOwnerMismatch if newParentID is not claimed by owner.
+// Returns ErrInvalidState if nodeID is the root, if newParentID is nodeID,
+// one of its descendants, or already its parent, or if a moved node has open
+// challenges, an assumption scope, or cannot be archived.
+// Returns ErrMaxChildrenExceeded if newParentID already has the maximum
+// number of children.
+// Returns ErrMaxDepthExceeded if a moved node would exceed the maximum depth.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) MoveNode(nodeID, newParentID types.NodeID, owner string) (types.NodeID, error) {
+	// Lock the nodes the move may write, then capture the sequence of the
+	// state loaded under the locks for CAS
+	st, locked, err := s.lockTouchedNodes(func(st *state.State) []types.NodeID {
+		return append(relocationTouched(st, nodeID), newParentID)
+	})
+	if err != nil {
+		return types.NodeID{}, err
+	}
+	defer s.unlockNodes(locked)
+	expectedSeq := st.LatestSeq()
+
+	if st.GetNode(nodeID) == nil {
+		return types.NodeID{}, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	oldParentID, hasParent := nodeID.Parent()
+	if !hasParent {
+		return types.NodeID{}, fmt.Errorf("%w: the root node cannot be moved", ErrInvalidState)
+	}
+
+	newParent := st.GetNode(newParentID)
+	if newParent == nil {
+		return types.NodeID{}, fmt.Errorf("%w: %s", ErrParentNotFound, newParentID.String())
+	}
+	if newParent.WorkflowState != schema.WorkflowClaimed {
+		return types.NodeID{}, fmt.Errorf("%w: node %s must be claimed to move nodes under it", ErrNotClaimed, newParentID.String())
+	}
+	if newParent.ClaimedBy != owner {
+		return types.NodeID{}, ErrOwnerMismatch
+	}
+
+	if newParentID.Equal(nodeID) || newParentID.IsDescendantOf(nodeID) {
+		return types.NodeID{}, fmt.Errorf("%w: cannot move node %s under itself or its descendant %s",
+			ErrInvalidState, nodeID.String(), newParentID.String())
+	}
+	if newParentID.Equal(oldParentID) {
+		return types.NodeID{}, fmt.Errorf("%w: node %s is already a child of %s",
+			ErrInvalidState, nodeID.String(), newParentID.String())
+	}
+
+	if err := s.validateChildCount(st, newParentID); err != nil {
+		return types.NodeID{}, err
+	}
+
+	// Take the next child ID of the new parent
+	childNums, err := s.nextChildNums(st, newParentID, 1)
+	if err != nil {
+		return types.NodeID{}, err
+	}
+	newID, err := newParentID.Child(childNums[0])
+	if err != nil {
+		return types.NodeID{}, fmt.Errorf("failed to generate child ID: %w", err)
+	}
+
+	mapping := make(map[string]types.NodeID)
+	if err := mapSubtree(st, nodeID, newID, mapping); err != nil {
+		return types.NodeID{}, err
+	}
+	maxDepth := 0
+	for _, id := range mapping {
+		if id.Depth() > maxDepth {
+			maxDepth = id.Depth()
+		}
+	}
+	if err := s.validateDepth(maxDepth); err != nil {
+		return types.NodeID{}, err
+	}
+
+	events, err := relocationEvents(st, mapping, owner)
+	if err != nil {
+		return types.NodeID{}, err
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return types.NodeID{}, err
+	}
+
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
+	if _, err := s.appendBulkIfSequence(ldg, events, expectedSeq); err != nil {
+		return types.NodeID{}, wrapSequenceMismatch(err, "MoveNode")
+	}
+
+	return newID, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestMoveNode_MovesSubtree(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.1.1", "1.1.1.1")
+	rootID := mustParseID(t, "1")
+
+	// 1.2 cites 1.1.1.1, which is about to move
+	if err := svc.Refine(RefineSpec{
+		ParentID:     rootID,
+		Owner:        "prover",
+		ChildID:      mustParseID(t, "1.2"),
+		NodeType:     schema.NodeTypeClaim,
+		Statement:    "Claim 1.2",
+		Inference:    schema.InferenceAssumption,
+		Dependencies: []types.NodeID{mustParseID(t, "1.1.1.1")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	newID, err := svc.MoveNode(mustParseID(t, "1.1.1"), rootID, "prover")
+	if err != nil {
+		t.Fatalf("MoveNode failed: %v", err)
+	}
+	if newID.String() != "1.3" {
+		t.Errorf("MoveNode returned %s, want 1.3", newID)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statements := map[string]string{"1.3": "Claim 1.1.1", "1.3.1": "Claim 1.1.1.1"}
+	for id, stmt := range statements {
+		n := st.GetNode(mustParseID(t, id))
+		if n == nil || n.Statement != stmt {
+			t.Errorf("node %s = %+v, want statement %q", id, n, stmt)
+			continue
+		}
+		if n.EpistemicState != schema.EpistemicPending {
+			t.Errorf("node %s EpistemicState = %s, want pending", id, n.EpistemicState)
+		}
+	}
+	for _, id := range []string{"1.1.1", "1.1.1.1"} {
+		if n := st.GetNode(mustParseID(t, id)); n == nil || n.EpistemicState != schema.EpistemicArchived {
+			t.Errorf("vacated node %s = %+v, want archived", id, n)
+		}
+	}
+
+	deps := st.GetNode(mustParseID(t, "1.2")).Dependencies
+	if len(deps) != 1 || deps[0].String() != "1.3.1" {
+		t.Errorf("1.2 dependencies = %v, want [1.3.1]", deps)
+	}
+}
+
+func TestMoveNode_Errors(t *testing.T) {
+	rootID, _ := types.Parse("1")
+
+	t.Run("node not found", func(t *testing.T) {
+		svc := newRenumberTestService(t)
+		if _, err := svc.MoveNode(mustParseID(t, "1.9"), rootID, "prover"); !errors.Is(err, ErrNodeNotFound) {
+			t.Errorf("error = %v, want ErrNodeNotFound", err)
+		}
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1")
+		if _, err := svc.MoveNode(mustParseID(t, "1.1"), mustParseID(t, "1.9"), "prover"); !errors.Is(err, ErrParentNotFound) {
+			t.Errorf("error = %v, want ErrParentNotFound", err)
+		}
+	})
+
+	t.Run("parent not claimed", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1", "1.2")
+		if _, err := svc.MoveNode(mustParseID(t, "1.2"), mustParseID(t, "1.1"), "prover"); !errors.Is(err, ErrNotClaimed) {
+			t.Errorf("error = %v, want ErrNotClaimed", err)
+		}
+	})
+
+	t.Run("wrong owner", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1", "1.1.1")
+		if _, err := svc.MoveNode(mustParseID(t, "1.1.1"), rootID, "someone-else"); !errors.Is(err, ErrOwnerMismatch) {
+			t.Errorf("error = %v, want ErrOwnerMismatch", err)
+		}
+	})
+
+	t.Run("under its own descendant", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1", "1.1.1")
+		if err := svc.ClaimNode(mustParseID(t, "1.1.1"), "prover", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.MoveNode(mustParseID(t, "1.1"), mustParseID(t, "1.1.1"), "prover"); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+	})
+
+	t.Run("already a child", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1")
+		if _, err := svc.MoveNode(mustParseID(t, "1.1"), rootID, "prover"); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+	})
+
+	t.Run("root", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1")
+		if err := svc.ClaimNode(mustParseID(t, "1.1"), "prover", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.MoveNode(rootID, mustParseID(t, "1.1"), "prover"); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+	})
+}
+
+// blockLedgerSeq puts a non-empty directory where the event file of seq
+// would go, so a batch reaching seq fails after the events before it are
+// in place. The returned function removes the blocker again.
+func blockLedgerSeq(t *testing.T, svc *ProofService, seq int) func() {
+	t.Helper()
+	blocker := ledger.EventFilePath(filepath.Join(svc.Path(), "ledger"), seq)
+	if err := os.MkdirAll(blocker, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blocker, "blocker"), []byte("block"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		if err := os.RemoveAll(blocker); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMoveNode_FailureMidBatchCommitsNothing(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.1.1", "1.1.1.1")
+	before := ledgerCount(t, svc)
+
+	unblock := blockLedgerSeq(t, svc, before+2)
+	if _, err := svc.MoveNode(mustParseID(t, "1.1.1"), mustParseID(t, "1"), "prover"); err == nil {
+		t.Fatal("MoveNode succeeded with an event file name blocked")
+	}
+	unblock()
+
+	if after := ledgerCount(t, svc); after != before {
+		t.Errorf("ledger grew from %d to %d events, want nothing committed", before, after)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := st.GetNode(mustParseID(t, "1.3")); n != nil {
+		t.Errorf("node 1.3 = %+v after failed move, want none", n)
+	}
+	for _, id := range []string{"1.1.1", "1.1.1.1"} {
+		if n := st.GetNode(mustParseID(t, id)); n == nil || n.EpistemicState != schema.EpistemicPending {
+			t.Errorf("node %s = %+v after failed move, want pending", id, n)
+		}
+	}
+}
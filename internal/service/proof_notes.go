@@ -0,0 +1,64 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// AddNote leaves an informational note on a node and returns the generated
+// note ID. Notes let verifiers record remarks that don't warrant a challenge:
+// they never block acceptance and have no resolve or withdraw lifecycle.
+// Any agent may note any node, whatever its state.
+//
+// Returns ErrEmptyInput if author or text is empty or whitespace-only.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) AddNote(nodeID types.NodeID, author, text string) (string, error) {
+	if strings.TrimSpace(author) == "" {
+		return "", fmt.Errorf("%w: note author", ErrEmptyInput)
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("%w: note text", ErrEmptyInput)
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return "", err
+	}
+	expectedSeq := st.LatestSeq()
+
+	if st.GetNode(nodeID) == nil {
+		return "", fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return "", err
+	}
+
+	noteID := generateNoteID()
+	if _, err := s.appendIfSequence(ldg, ledger.NewNoteAdded(nodeID, noteID, author, text), expectedSeq); err != nil {
+		return "", wrapSequenceMismatch(err, "AddNote")
+	}
+
+	return noteID, nil
+}
+
+// generateNoteID generates a unique identifier for a note.
+// Uses random bytes for uniqueness.
+func generateNoteID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// If crypto/rand fails, this indicates a critical system issue
+		// Use timestamp-based fallback
+		return fmt.Sprintf("note-%v", types.Now())
+	}
+	return "note-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddNote_AccumulatesNotes(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	id := mustParseID(t, "1.1")
+
+	first, err := svc.AddNote(id, "verifier-1", "Could cite Lemma 3 here")
+	if err != nil {
+		t.Fatalf("AddNote failed: %v", err)
+	}
+	second, err := svc.AddNote(id, "verifier-2", "Nice argument")
+	if err != nil {
+		t.Fatalf("AddNote failed: %v", err)
+	}
+	if !strings.HasPrefix(first, "note-") || first == second {
+		t.Errorf("AddNote returned IDs %q and %q, want distinct note- IDs", first, second)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes := st.GetNode(id).Notes
+	if len(notes) != 2 {
+		t.Fatalf("node 1.1 has %d notes, want 2", len(notes))
+	}
+	if notes[0].ID != first || notes[0].Author != "verifier-1" || notes[0].Text != "Could cite Lemma 3 here" {
+		t.Errorf("first note = %+v", notes[0])
+	}
+	if notes[1].ID != second || notes[0].Created.IsZero() {
+		t.Errorf("second note = %+v, first created %v", notes[1], notes[0].Created)
+	}
+
+	// Notes never block acceptance
+	if err := svc.AcceptNode(id); err != nil {
+		t.Errorf("AcceptNode failed on a noted node: %v", err)
+	}
+}
+
+func TestAddNote_Errors(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+
+	tests := []struct {
+		name         string
+		id           string
+		author, text string
+		want         error
+	}{
+		{"empty author", "1.1", "", "text", ErrEmptyInput},
+		{"blank text", "1.1", "verifier", "  ", ErrEmptyInput},
+		{"missing node", "1.9", "verifier", "text", ErrNodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.AddNote(mustParseID(t, tt.id), tt.author, tt.text); !errors.Is(err, tt.want) {
+				t.Errorf("AddNote error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// PruneArchived writes a copy of the proof to destDir whose ledger leaves
+// out the events of archived subtrees, so the copy replays faster. The
+// source ledger is never modified. destDir must not exist or be an empty
+// directory; meta.json and the files in .af are copied as for CloneProof.
+//
+// An archived node is pruned unless a node that is kept depends on it,
+// descends from it, or is the source of a lemma, so every kept node keeps
+// its parent and its dependencies. Proof initialization, definitions, and
+// lemmas are always kept. Events naming several nodes (claims, releases,
+// reassignments) are rewritten to name only the kept ones. The remaining
+// events are renumbered contiguously from 1 and their hash chain is
+// recomputed.
+//
+// The new ledger is replayed and compared with the source state minus the
+// pruned nodes and their challenges, amendments, and scopes; if they
+// differ an error wrapping ErrInvalidState is returned and destDir is left
+// in place for inspection.
+//
+// Returns the number of events dropped.
+// Returns ErrAlreadyExists if destDir is not empty.
+func (s *ProofService) PruneArchived(destDir string) (int, error) {
+	ldg, err := s.getLedger()
+	if err != nil {
+		return 0, err
+	}
+	st, err := state.Replay(ldg)
+	if err != nil {
+		return 0, err
+	}
+	pruned := prunableNodes(st)
+
+	var kept []json.RawMessage
+	total := 0
+	challengeNodes := make(map[string]string)
+	err = ldg.Scan(func(seq int, data []byte) error {
+		// Events appended after the replay are not part of st
+		if seq > st.LatestSeq() {
+			return nil
+		}
+		total++
+		event, keep, err := pruneEvent(data, pruned, challengeNodes)
+		if err != nil {
+			return fmt.Errorf("event %d: %w", seq, err)
+		}
+		if keep {
+			kept = append(kept, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.initDestination(destDir); err != nil {
+		return 0, err
+	}
+	destLedgerDir := filepath.Join(destDir, "ledger")
+	if err := ledger.Rebuild(destLedgerDir, kept); err != nil {
+		return 0, fmt.Errorf("failed to write pruned ledger: %w", err)
+	}
+
+	destLedger, err := ledger.NewLedger(destLedgerDir)
+	if err != nil {
+		return 0, err
+	}
+	got, err := state.Replay(destLedger)
+	if err != nil {
+		return 0, fmt.Errorf("%w: pruned ledger does not replay: %v", ErrInvalidState, err)
+	}
+	want, err := st.CloneWithout(func(id types.NodeID) bool { return pruned[id.String()] })
+	if err != nil {
+		return 0, err
+	}
+	same, err := state.Equivalent(got, want)
+	if err != nil {
+		return 0, err
+	}
+	if !same {
+		return 0, fmt.Errorf("%w: pruned ledger in %s does not replay to the original state without archived nodes",
+			ErrInvalidState, destDir)
+	}
+
+	return total - len(kept), nil
+}
+
+// prunableNodes returns the IDs of the archived nodes in st that can be
+// dropped: every archived node, except those a kept node needs. A kept node
+// needs its parent and the nodes it depends on, and lemmas need the node
+// they were extracted from.
+func prunableNodes(st *state.State) map[string]bool {
+	nodes := st.AllNodes()
+	pruned := make(map[string]bool)
+	for _, n := range nodes {
+		if n.EpistemicState == schema.EpistemicArchived {
+			pruned[n.ID.String()] = true
+		}
+	}
+
+	for _, l := range st.AllLemmas() {
+		delete(pruned, l.SourceNodeID.String())
+	}
+
+	// Keeping a node can require keeping its parent or dependencies, which
+	// in turn can require keeping theirs, so repeat until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for _, n := range nodes {
+			if pruned[n.ID.String()] {
+				continue
+			}
+			needed := append(append([]types.NodeID{}, n.Dependencies...), n.ValidationDeps...)
+			if parent, ok := n.ID.Parent(); ok {
+				needed = append(needed, parent)
+			}
+			for _, id := range needed {
+				if pruned[id.String()] {
+					delete(pruned, id.String())
+					changed = true
+				}
+			}
+		}
+	}
+	return pruned
+}
+
+// pruneRefs holds the fields through which a ledger event refers to nodes.
+type pruneRefs struct {
+	Type        ledger.EventType `json:"type"`
+	NodeID      *types.NodeID    `json:"node_id"`
+	NodeIDs     []types.NodeID   `json:"node_ids"`
+	ChallengeID string           `json:"challenge_id"`
+	Node        *struct {
+		ID types.NodeID `json:"id"`
+	} `json:"node"`
+}
+
+// pruneEvent decides whether the raw event data survives pruning, and
+// returns it, rewritten if it names several nodes and some are pruned.
+// challengeNodes maps challenge IDs to node IDs and is filled in as
+// challenge_raised events are seen, so later challenge events can be
+// attributed to their node.
+func pruneEvent(data []byte, pruned map[string]bool, challengeNodes map[string]string) (json.RawMessage, bool, error) {
+	var refs pruneRefs
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, false, fmt.Errorf("failed to read node references: %w", err)
+	}
+
+	switch {
+	case refs.Node != nil:
+		return data, !pruned[refs.Node.ID.String()], nil
+
+	case refs.NodeIDs != nil:
+		var keep []types.NodeID
+		for _, id := range refs.NodeIDs {
+			if !pruned[id.String()] {
+				keep = append(keep, id)
+			}
+		}
+		if len(keep) == 0 {
+			return nil, false, nil
+		}
+		if len(keep) == len(refs.NodeIDs) {
+			return data, true, nil
+		}
+		rewritten, err := withNodeIDs(refs.Type, data, keep)
+		return rewritten, err == nil, err
+
+	case refs.NodeID != nil:
+		if refs.Type == ledger.EventChallengeRaised {
+			challengeNodes[refs.ChallengeID] = refs.NodeID.String()
+		}
+		return data, !pruned[refs.NodeID.String()], nil
+
+	case refs.ChallengeID != "":
+		return data, !pruned[challengeNodes[refs.ChallengeID]], nil
+	}
+
+	// Proof initialization, definitions, and lemmas
+	return data, true, nil
+}
+
+// withNodeIDs re-encodes the multi-node event data of the given type with
+// its node list replaced by ids.
+func withNodeIDs(eventType ledger.EventType, data []byte, ids []types.NodeID) (json.RawMessage, error) {
+	event, ok := state.NewEvent(eventType)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	switch e := event.(type) {
+	case *ledger.NodesClaimed:
+		e.NodeIDs = ids
+	case *ledger.NodesReleased:
+		e.NodeIDs = ids
+	case *ledger.NodesReassigned:
+		e.NodeIDs = ids
+	default:
+		return nil, fmt.Errorf("cannot rewrite the node list of %s events", eventType)
+	}
+	return json.Marshal(event)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestPruneArchived(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.1.1", "1.1.2", "1.2", "1.3")
+
+	// One claim event names a node that is pruned and one that is kept
+	bulk := []types.NodeID{mustParseID(t, "1.1.1"), mustParseID(t, "1.2")}
+	if err := svc.ClaimNodeBulk(bulk, "prover-2", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range bulk {
+		if err := svc.ReleaseNode(id, "prover-2"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.RaiseChallenge(mustParseID(t, "1.1.2"), "statement", "Unclear", "major"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ArchiveSubtree(mustParseID(t, "1.1"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1.3 is archived but 1.4 depends on it, so it must be kept
+	if err := svc.Refine(RefineSpec{
+		ParentID:     mustParseID(t, "1"),
+		Owner:        "prover",
+		ChildID:      mustParseID(t, "1.4"),
+		NodeType:     schema.NodeTypeClaim,
+		Statement:    "Uses 1.3",
+		Inference:    schema.InferenceAssumption,
+		Dependencies: []types.NodeID{mustParseID(t, "1.3")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ArchiveNode(mustParseID(t, "1.3")); err != nil {
+		t.Fatal(err)
+	}
+
+	before := ledgerCount(t, svc)
+	dest := filepath.Join(t.TempDir(), "pruned")
+	dropped, err := svc.PruneArchived(dest)
+	if err != nil {
+		t.Fatalf("PruneArchived failed: %v", err)
+	}
+	if dropped == 0 {
+		t.Error("PruneArchived dropped no events")
+	}
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("source ledger has %d events after pruning, want %d", got, before)
+	}
+
+	pruned, err := NewProofService(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ledgerCount(t, pruned); got != before-dropped {
+		t.Errorf("pruned ledger has %d events, want %d", got, before-dropped)
+	}
+	st, err := pruned.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState on pruned proof failed: %v", err)
+	}
+	for _, id := range []string{"1.1", "1.1.1", "1.1.2"} {
+		if st.GetNode(mustParseID(t, id)) != nil {
+			t.Errorf("archived node %s was not pruned", id)
+		}
+	}
+	for _, id := range []string{"1", "1.2", "1.3", "1.4"} {
+		if st.GetNode(mustParseID(t, id)) == nil {
+			t.Errorf("node %s is missing from the pruned proof", id)
+		}
+	}
+	if len(st.AllChallenges()) != 0 {
+		t.Errorf("pruned proof has %d challenges, want 0", len(st.AllChallenges()))
+	}
+
+	// The pruned ledger is a valid chain that can be extended
+	verifier := ledger.NewChainVerifier()
+	if err := ledger.Scan(filepath.Join(dest, "ledger"), verifier.Verify); err != nil {
+		t.Errorf("pruned ledger has a broken hash chain: %v", err)
+	}
+	if err := pruned.ClaimNode(mustParseID(t, "1.2"), "prover-3", time.Hour); err != nil {
+		t.Errorf("ClaimNode on pruned proof failed: %v", err)
+	}
+}
+
+func TestPruneArchived_NothingArchived(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	dest := t.TempDir()
+
+	dropped, err := svc.PruneArchived(dest)
+	if err != nil {
+		t.Fatalf("PruneArchived failed: %v", err)
+	}
+	if dropped != 0 {
+		t.Errorf("dropped = %d, want 0", dropped)
+	}
+
+	original, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied, err := NewProofService(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := copied.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same, err := state.Equivalent(original, st); err != nil || !same {
+		t.Errorf("Equivalent = %v (err %v), want the copy to match the source", same, err)
+	}
+}
+
+func TestPruneArchived_NonEmptyDestination(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if _, err := svc.PruneArchived(svc.Path()); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("PruneArchived error = %v, want ErrAlreadyExists", err)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestReadOnly_RefusesMutations(t *testing.T) {
+	dir := newChallengeTestService(t).path
+	svc, err := NewReadOnlyProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !svc.ReadOnly() {
+		t.Fatal("ReadOnly() = false, want true")
+	}
+	rootID := mustParseID(t, "1")
+
+	mutations := map[string]func() error{
+		"CreateNode": func() error {
+			return svc.CreateNode(mustParseID(t, "1.1"), schema.NodeTypeClaim, "Step", schema.InferenceAssumption)
+		},
+		"ClaimNode":  func() error { return svc.ClaimNode(rootID, "agent", time.Hour) },
+		"AcceptNode": func() error { return svc.AcceptNode(rootID) },
+		"AddNote": func() error {
+			_, err := svc.AddNote(rootID, "verifier", "remark")
+			return err
+		},
+		"AddAssumption": func() error {
+			_, err := svc.AddAssumption("x > 0")
+			return err
+		},
+		"SetConfig": func() error {
+			cfg, err := svc.Config()
+			if err != nil {
+				return err
+			}
+			return svc.SetConfig(cfg)
+		},
+		"Snapshot": func() error {
+			_, err := svc.Snapshot()
+			return err
+		},
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			if err := mutate(); !errors.Is(err, ErrReadOnly) {
+				t.Errorf("%s error = %v, want ErrReadOnly", name, err)
+			}
+		})
+	}
+
+	// Nothing was written
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if st.LatestSeq() != 2 {
+		t.Errorf("ledger has %d events, want the 2 written by Init", st.LatestSeq())
+	}
+	if n := st.GetNode(rootID); n == nil || n.WorkflowState != schema.WorkflowAvailable {
+		t.Errorf("root node changed: %+v", n)
+	}
+}
+
+func TestReadOnly_AllowsQueries(t *testing.T) {
+	dir := newChallengeTestService(t).path
+	svc, err := NewReadOnlyProofService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := svc.Status()
+	if err != nil || !status.Initialized {
+		t.Fatalf("Status() = %+v, %v", status, err)
+	}
+	if _, err := svc.SearchNodes("conjecture", SearchOptions{}); err != nil {
+		t.Errorf("SearchNodes failed: %v", err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if _, err := ExportProof(st, "markdown"); err != nil {
+		t.Errorf("ExportProof failed: %v", err)
+	}
+}
+
+// TestReadOnly_TakesPrecedenceOverDryRun checks that a service in both modes
+// refuses single and bulk mutations alike instead of previewing them.
+func TestReadOnly_TakesPrecedenceOverDryRun(t *testing.T) {
+	dir := newChallengeTestService(t).path
+	svc, err := NewProofService(dir, WithReadOnly(true), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootID := mustParseID(t, "1")
+
+	mutations := map[string]func() error{
+		"AcceptNode":     func() error { return svc.AcceptNode(rootID) },
+		"AcceptNodeBulk": func() error { return svc.AcceptNodeBulk([]types.NodeID{rootID}) },
+		"AcceptSubtree": func() error {
+			_, err := svc.AcceptSubtree(rootID)
+			return err
+		},
+		"ArchiveSubtree": func() error {
+			_, err := svc.ArchiveSubtree(rootID, false)
+			return err
+		},
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			if err := mutate(); !errors.Is(err, ErrReadOnly) {
+				t.Errorf("%s error = %v, want ErrReadOnly", name, err)
+			}
+		})
+	}
+
+	if preview := svc.PreviewEvents(); len(preview) != 0 {
+		t.Errorf("PreviewEvents() has %d events, want none", len(preview))
+	}
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/lemma"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// RebaseResult describes what Rebase brought back from a clone.
+type RebaseResult struct {
+	// ForkSeq is the last ledger sequence the clone shares with the proof.
+	ForkSeq int `json:"fork_seq"`
+
+	// Added maps the ID in the clone of each node appended to the proof to
+	// its ID in the proof. The two differ for renumbered nodes.
+	Added map[string]types.NodeID `json:"added"`
+
+	// Skipped lists the clone's new nodes that were not appended: nodes
+	// already in the proof with the same content, and nodes archived in the
+	// clone together with their descendants. Sorted by node ID.
+	Skipped []types.NodeID `json:"skipped"`
+
+	// Conflicts lists the nodes that stopped the rebase, sorted by node ID.
+	Conflicts []RebaseConflict `json:"conflicts"`
+}
+
+// RebaseConflict is a node of the clone that Rebase cannot bring back
+// without overwriting or losing something, and must be resolved by hand.
+type RebaseConflict struct {
+	NodeID types.NodeID `json:"node_id"` // ID in the clone
+	Reason string       `json:"reason"`
+}
+
+// Rebase brings the nodes created in a clone of the proof (see CloneProof)
+// back into the proof. The fork point is the longest run of leading events
+// the two ledgers share. Nodes the clone created after it are appended as
+// new pending, unclaimed nodes, keeping their type, statement, LaTeX,
+// inference, context, dependencies, lemmas, tags and notes; validations,
+// challenges and claims made in the clone are not carried over.
+//
+// A new node keeps its ID when that ID is free in the proof. If the proof
+// has meanwhile used the ID for a different node, the clone's node is
+// renumbered to the next free child ID of its parent and its descendants
+// are re-parented under it, keeping their paths below it. Dependencies on
+// renumbered nodes are rewritten. A new node whose ID is held by a node with
+// the same type, statement, LaTeX and inference is taken to be merged
+// already and skipped, so rebasing the same clone twice adds nothing. New
+// nodes archived in the clone are skipped along with their descendants.
+//
+// Nothing is overwritten. Nodes that existed at the fork and were amended in
+// the clone to differ from the proof, new nodes citing lemmas, externals or
+// nodes the proof does not have, and new nodes that open an assumption
+// scope are reported as conflicts; if there are any, no events are appended
+// and the result lists them.
+//
+// Returns ErrEmptyInput if sourceDir is empty.
+// Returns ErrInvalidState if the clone shares no history with the proof or
+// if there are conflicts.
+// Returns ErrMaxDepthExceeded or ErrMaxChildrenExceeded if an appended node
+// would exceed the proof's limits.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) Rebase(sourceDir string) (RebaseResult, error) {
+	result := RebaseResult{
+		Added:     map[string]types.NodeID{},
+		Skipped:   []types.NodeID{},
+		Conflicts: []RebaseConflict{},
+	}
+	if strings.TrimSpace(sourceDir) == "" {
+		return result, fmt.Errorf("%w: source directory", ErrEmptyInput)
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return result, err
+	}
+	expectedSeq := st.LatestSeq()
+
+	sourceLedgerDir := filepath.Join(sourceDir, "ledger")
+	cmp, err := ledger.Compare(filepath.Join(s.path, "ledger"), sourceLedgerDir)
+	if err != nil {
+		return result, fmt.Errorf("failed to compare ledgers: %w", err)
+	}
+	if cmp.CommonPrefix == 0 {
+		return result, fmt.Errorf("%w: %s shares no history with this proof", ErrInvalidState, sourceDir)
+	}
+	result.ForkSeq = cmp.CommonPrefix
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return result, err
+	}
+	forkSt, err := state.ReplayUntil(ldg, cmp.CommonPrefix)
+	if err != nil {
+		return result, fmt.Errorf("failed to replay the proof up to the fork: %w", err)
+	}
+	sourceLedger, err := ledger.NewLedger(sourceLedgerDir)
+	if err != nil {
+		return result, err
+	}
+	srcSt, err := state.Replay(sourceLedger)
+	if err != nil {
+		return result, fmt.Errorf("failed to replay the clone: %w", err)
+	}
+
+	plan := s.planRebase(st, forkSt, srcSt)
+	result.Skipped = plan.skipped
+	result.Conflicts = plan.conflicts
+	if len(plan.conflicts) > 0 {
+		return result, fmt.Errorf("%w: %d conflicting nodes in %s; resolve them before rebasing",
+			ErrInvalidState, len(plan.conflicts), sourceDir)
+	}
+	if len(plan.created) == 0 {
+		return result, nil
+	}
+
+	// Check the proof's limits for the appended nodes
+	addedUnder := make(map[string]int)
+	for _, n := range plan.created {
+		newID := plan.mapping[n.ID.String()]
+		if err := s.validateDepth(newID.Depth()); err != nil {
+			return result, err
+		}
+		if parentID, ok := newID.Parent(); ok {
+			addedUnder[parentID.String()]++
+		}
+	}
+	cfg, err := s.Config()
+	if err != nil {
+		return result, fmt.Errorf("loading config: %w", err)
+	}
+	for parent, added := range addedUnder {
+		existing := 0
+		for _, n := range st.AllNodes() {
+			if p, ok := n.ID.Parent(); ok && p.String() == parent {
+				existing++
+			}
+		}
+		if existing+added > cfg.MaxChildren {
+			return result, fmt.Errorf("%w: node %s would have %d children (max %d)",
+				ErrMaxChildrenExceeded, parent, existing+added, cfg.MaxChildren)
+		}
+	}
+
+	events, err := rebaseEvents(plan)
+	if err != nil {
+		return result, err
+	}
+
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
+	if _, err := s.appendBulkIfSequence(ldg, events, expectedSeq); err != nil {
+		return result, wrapSequenceMismatch(err, "Rebase")
+	}
+
+	for _, n := range plan.created {
+		result.Added[n.ID.String()] = plan.mapping[n.ID.String()]
+	}
+	return result, nil
+}
+
+// rebasePlan is the outcome of matching a clone's new nodes against the
+// proof, before anything is appended.
+type rebasePlan struct {
+	// mapping maps the clone ID of every new node that is appended or
+	// already present to its ID in the proof.
+	mapping map[string]types.NodeID
+
+	// created holds the clone's nodes to append, sorted by their ID in the
+	// proof so that parents are created before their children.
+	created []*node.Node
+
+	skipped   []types.NodeID
+	conflicts []RebaseConflict
+}
+
+// planRebase works out which of the nodes in srcSt, a clone that forked
+// from the proof at forkSt, are appended to the proof in st and under which
+// IDs, and which conflict with it.
+func (s *ProofService) planRebase(st, forkSt, srcSt *state.State) rebasePlan {
+	plan := rebasePlan{
+		mapping:   make(map[string]types.NodeID),
+		skipped:   []types.NodeID{},
+		conflicts: []RebaseConflict{},
+	}
+	conflict := func(id types.NodeID, format string, args ...interface{}) {
+		plan.conflicts = append(plan.conflicts, RebaseConflict{NodeID: id, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	// Nodes both sides had at the fork must not have been changed in the clone
+	var newNodes []*node.Node
+	for _, n := range srcSt.AllNodesSorted() {
+		base := forkSt.GetNode(n.ID)
+		if base == nil {
+			newNodes = append(newNodes, n)
+			continue
+		}
+		if n.ContentHash == base.ContentHash {
+			continue
+		}
+		if current := st.GetNode(n.ID); current == nil || current.ContentHash != n.ContentHash {
+			conflict(n.ID, "amended in the clone after the fork and differs from the proof")
+		}
+	}
+
+	// Assign each new node its ID in the proof, parents before children
+	archived := make(map[string]bool)
+	taken := make(map[string]bool)
+	takenUnder := make(map[string]int)
+	for _, n := range newNodes {
+		parentID, _ := n.ID.Parent()
+		if n.EpistemicState == schema.EpistemicArchived || archived[parentID.String()] {
+			archived[n.ID.String()] = true
+			plan.skipped = append(plan.skipped, n.ID)
+			continue
+		}
+
+		targetParent := parentID
+		if mapped, ok := plan.mapping[parentID.String()]; ok {
+			targetParent = mapped
+		}
+		if st.GetNode(targetParent) == nil && !taken[targetParent.String()] {
+			conflict(n.ID, "parent %s is not in the proof", targetParent.String())
+			continue
+		}
+
+		candidate, err := targetParent.Child(childNumber(n.ID))
+		if err != nil {
+			conflict(n.ID, "cannot place under %s: %v", targetParent.String(), err)
+			continue
+		}
+		if existing := st.GetNode(candidate); existing != nil && sameNodeContent(existing, n) {
+			plan.mapping[n.ID.String()] = candidate
+			plan.skipped = append(plan.skipped, n.ID)
+			continue
+		}
+		if st.GetNode(candidate) != nil || taken[candidate.String()] {
+			candidate, err = s.freeChildID(st, targetParent, taken, takenUnder[targetParent.String()])
+			if err != nil {
+				conflict(n.ID, "cannot renumber under %s: %v", targetParent.String(), err)
+				continue
+			}
+		}
+
+		plan.mapping[n.ID.String()] = candidate
+		taken[candidate.String()] = true
+		takenUnder[targetParent.String()]++
+		plan.created = append(plan.created, n)
+	}
+
+	// Appended nodes may only cite what the proof will have
+	for _, n := range plan.created {
+		if srcSt.GetScope(n.ID) != nil {
+			conflict(n.ID, "opens an assumption scope, which cannot be rebased")
+		}
+		for _, dep := range append(append([]types.NodeID{}, n.Dependencies...), n.ValidationDeps...) {
+			if _, ok := plan.mapping[dep.String()]; ok {
+				continue
+			}
+			if archived[dep.String()] {
+				conflict(n.ID, "depends on %s, which was archived in the clone", dep.String())
+			} else if st.GetNode(dep) == nil {
+				conflict(n.ID, "depends on %s, which is not in the proof", dep.String())
+			}
+		}
+		for _, lemmaID := range n.Lemmas {
+			if st.GetLemma(lemmaID) == nil {
+				conflict(n.ID, "cites lemma %s, which is not in the proof", lemmaID)
+			}
+		}
+		if err := lemma.ValidateExtCitations(n.Statement, st); err != nil {
+			conflict(n.ID, "%v", err)
+		}
+	}
+
+	sort.Slice(plan.created, func(i, j int) bool {
+		return plan.mapping[plan.created[i].ID.String()].Less(plan.mapping[plan.created[j].ID.String()])
+	})
+	sort.Slice(plan.skipped, func(i, j int) bool { return plan.skipped[i].Less(plan.skipped[j]) })
+	sort.SliceStable(plan.conflicts, func(i, j int) bool { return plan.conflicts[i].NodeID.Less(plan.conflicts[j].NodeID) })
+	return plan
+}
+
+// rebaseEvents returns a node_created event for each node plan appends,
+// recreated under its ID in the proof as a pending, unclaimed node with its
+// dependencies renumbered.
+func rebaseEvents(plan rebasePlan) ([]ledger.Event, error) {
+	events := make([]ledger.Event, 0, len(plan.created))
+	for _, n := range plan.created {
+		newID := plan.mapping[n.ID.String()]
+		created, err := node.NewNodeWithOptions(newID, n.Type, n.Statement, n.Inference, node.NodeOptions{
+			Latex:          n.Latex,
+			Context:        n.Context,
+			Dependencies:   renumberIDs(n.Dependencies, plan.mapping),
+			ValidationDeps: renumberIDs(n.ValidationDeps, plan.mapping),
+			Scope:          n.Scope,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to recreate node %s as %s: %w", n.ID.String(), newID.String(), err)
+		}
+		created.Lemmas = n.Lemmas
+		created.Tags = n.Tags
+		created.Notes = n.Notes
+		created.ContentHash = created.ComputeContentHash()
+		events = append(events, ledger.NewNodeCreated(*created))
+	}
+	return events, nil
+}
+
+// sameNodeContent reports whether a and b state the same step: the same
+// type, statement, LaTeX and inference.
+func sameNodeContent(a, b *node.Node) bool {
+	return a.Type == b.Type && a.Statement == b.Statement && a.Latex == b.Latex && a.Inference == b.Inference
+}
+
+// childNumber returns the last component of id, its number among its
+// siblings.
+func childNumber(id types.NodeID) int {
+	s := id.String()
+	num, _ := strconv.Atoi(s[strings.LastIndex(s, ".")+1:])
+	return num
+}
+
+// freeChildID returns the next child ID of parentID under the configured
+// child ID strategy that is not in taken, where takenCount of the IDs in
+// taken are children of parentID.
+func (s *ProofService) freeChildID(st *state.State, parentID types.NodeID, taken map[string]bool, takenCount int) (types.NodeID, error) {
+	nums, err := s.nextChildNums(st, parentID, takenCount+1)
+	if err != nil {
+		return types.NodeID{}, err
+	}
+	for _, num := range nums {
+		id, err := parentID.Child(num)
+		if err != nil {
+			return types.NodeID{}, err
+		}
+		if !taken[id.String()] {
+			return id, nil
+		}
+	}
+	return types.NodeID{}, fmt.Errorf("no free child ID under %s", parentID.String())
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newRebaseTestClone returns a proof with node 1.1 and root claimed by
+// "prover", together with a clone of it.
+func newRebaseTestClone(t *testing.T) (*ProofService, *ProofService) {
+	t.Helper()
+	svc := newRenumberTestService(t, "1.1")
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := svc.CloneProof(dest); err != nil {
+		t.Fatalf("CloneProof failed: %v", err)
+	}
+	clone, err := NewProofService(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc, clone
+}
+
+// mustCreate creates a claim node with the given statement.
+func mustCreate(t *testing.T, svc *ProofService, id, statement string) {
+	t.Helper()
+	if err := svc.CreateNode(mustParseID(t, id), schema.NodeTypeClaim, statement, schema.InferenceAssumption); err != nil {
+		t.Fatalf("CreateNode(%s) failed: %v", id, err)
+	}
+}
+
+// assertMapping checks that got maps exactly the clone IDs in want to the
+// given proof IDs.
+func assertMapping(t *testing.T, got map[string]types.NodeID, want map[string]string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("Added = %v, want %v", got, want)
+		return
+	}
+	for from, to := range want {
+		if id, ok := got[from]; !ok || id.String() != to {
+			t.Errorf("Added[%s] = %v, want %s", from, got[from], to)
+		}
+	}
+}
+
+func TestRebase_AppendsNewNodes(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+	forkSeq := ledgerCount(t, svc)
+
+	mustCreate(t, clone, "1.2", "Clone step")
+	mustCreate(t, clone, "1.2.1", "Clone substep")
+	if err := clone.RefineNodeWithDeps(mustParseID(t, "1"), "prover", mustParseID(t, "1.3"), schema.NodeTypeClaim,
+		"Uses the substep", schema.InferenceModusPonens, []types.NodeID{mustParseID(t, "1.2.1"), mustParseID(t, "1.1")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.AcceptNode(mustParseID(t, "1.2.1")); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := svc.Rebase(clone.Path())
+	if err != nil {
+		t.Fatalf("Rebase failed: %v (conflicts %+v)", err, result.Conflicts)
+	}
+	if result.ForkSeq != forkSeq {
+		t.Errorf("ForkSeq = %d, want %d", result.ForkSeq, forkSeq)
+	}
+	assertMapping(t, result.Added, map[string]string{"1.2": "1.2", "1.2.1": "1.2.1", "1.3": "1.3"})
+	if len(result.Conflicts) != 0 || len(result.Skipped) != 0 {
+		t.Errorf("unexpected conflicts %+v or skipped %v", result.Conflicts, result.Skipped)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := st.GetNode(mustParseID(t, "1.2.1"))
+	if sub == nil || sub.Statement != "Clone substep" {
+		t.Fatalf("node 1.2.1 = %+v, want the clone's substep", sub)
+	}
+	if sub.EpistemicState != schema.EpistemicPending || sub.WorkflowState != schema.WorkflowAvailable {
+		t.Errorf("rebased node is %s/%s, want pending/available", sub.EpistemicState, sub.WorkflowState)
+	}
+	uses := st.GetNode(mustParseID(t, "1.3"))
+	if uses == nil || !equalIDs(uses.Dependencies, []types.NodeID{mustParseID(t, "1.2.1"), mustParseID(t, "1.1")}) {
+		t.Errorf("node 1.3 dependencies = %v, want [1.2.1 1.1]", uses.Dependencies)
+	}
+
+	// Rebasing the same clone again adds nothing
+	again, err := svc.Rebase(clone.Path())
+	if err != nil {
+		t.Fatalf("second Rebase failed: %v (conflicts %+v)", err, again.Conflicts)
+	}
+	if len(again.Added) != 0 || len(again.Skipped) != 3 {
+		t.Errorf("second Rebase added %v and skipped %v, want nothing added and 3 skipped", again.Added, again.Skipped)
+	}
+}
+
+func TestRebase_RenumbersCollisions(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+
+	// The proof and the clone both use 1.2 for different steps
+	mustCreate(t, svc, "1.2", "Main step")
+	mustCreate(t, clone, "1.2", "Clone step")
+	mustCreate(t, clone, "1.2.1", "Clone substep")
+	if err := clone.RefineNodeWithDeps(mustParseID(t, "1"), "prover", mustParseID(t, "1.3"), schema.NodeTypeClaim,
+		"Uses the substep", schema.InferenceModusPonens, []types.NodeID{mustParseID(t, "1.2.1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := svc.Rebase(clone.Path())
+	if err != nil {
+		t.Fatalf("Rebase failed: %v (conflicts %+v)", err, result.Conflicts)
+	}
+	// 1.2 moves to the next free child, 1.3, its child follows it, and the
+	// clone's 1.3 then moves on to 1.4
+	assertMapping(t, result.Added, map[string]string{"1.2": "1.3", "1.2.1": "1.3.1", "1.3": "1.4"})
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[string]string{
+		"1.2":   "Main step",
+		"1.3":   "Clone step",
+		"1.3.1": "Clone substep",
+		"1.4":   "Uses the substep",
+	} {
+		if n := st.GetNode(mustParseID(t, id)); n == nil || n.Statement != want {
+			t.Errorf("node %s = %+v, want statement %q", id, n, want)
+		}
+	}
+	if deps := st.GetNode(mustParseID(t, "1.4")).Dependencies; !equalIDs(deps, []types.NodeID{mustParseID(t, "1.3.1")}) {
+		t.Errorf("node 1.4 dependencies = %v, want the renumbered [1.3.1]", deps)
+	}
+}
+
+func TestRebase_SameStepInBothIsSkipped(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+	mustCreate(t, svc, "1.2", "Shared step")
+	mustCreate(t, clone, "1.2", "Shared step")
+	mustCreate(t, clone, "1.2.1", "Clone substep")
+
+	result, err := svc.Rebase(clone.Path())
+	if err != nil {
+		t.Fatalf("Rebase failed: %v", err)
+	}
+	assertMapping(t, result.Added, map[string]string{"1.2.1": "1.2.1"})
+	if len(result.Skipped) != 1 || result.Skipped[0].String() != "1.2" {
+		t.Errorf("Skipped = %v, want [1.2]", result.Skipped)
+	}
+}
+
+func TestRebase_ConflictsAppendNothing(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+
+	// A node from before the fork amended in the clone
+	if err := clone.AmendNode(mustParseID(t, "1.1"), "prover", "Claim 1.1, restated"); err != nil {
+		t.Fatal(err)
+	}
+	// A new node that depends on one archived in the clone
+	mustCreate(t, clone, "1.2", "Abandoned step")
+	if err := clone.RefineNodeWithDeps(mustParseID(t, "1"), "prover", mustParseID(t, "1.3"), schema.NodeTypeClaim,
+		"Builds on the abandoned step", schema.InferenceModusPonens, []types.NodeID{mustParseID(t, "1.2")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.ArchiveNode(mustParseID(t, "1.2")); err != nil {
+		t.Fatal(err)
+	}
+	mustCreate(t, clone, "1.4", "Unrelated step")
+
+	before := ledgerCount(t, svc)
+	result, err := svc.Rebase(clone.Path())
+	if !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("Rebase error = %v, want ErrInvalidState", err)
+	}
+	if len(result.Conflicts) != 2 || result.Conflicts[0].NodeID.String() != "1.1" || result.Conflicts[1].NodeID.String() != "1.3" {
+		t.Errorf("Conflicts = %+v, want 1.1 and 1.3", result.Conflicts)
+	}
+	if len(result.Added) != 0 {
+		t.Errorf("Added = %v, want nothing", result.Added)
+	}
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("proof has %d events after a conflicting rebase, want %d", got, before)
+	}
+
+	// The unrelated new node is held back with the rest
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.GetNode(mustParseID(t, "1.4")) != nil {
+		t.Error("node 1.4 was appended despite the conflicts")
+	}
+}
+
+func TestRebase_SkipsArchivedSubtrees(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+	mustCreate(t, clone, "1.2", "Abandoned step")
+	mustCreate(t, clone, "1.2.1", "Abandoned substep")
+	if err := clone.ArchiveNode(mustParseID(t, "1.2")); err != nil {
+		t.Fatal(err)
+	}
+	mustCreate(t, clone, "1.3", "Kept step")
+
+	result, err := svc.Rebase(clone.Path())
+	if err != nil {
+		t.Fatalf("Rebase failed: %v (conflicts %+v)", err, result.Conflicts)
+	}
+	assertMapping(t, result.Added, map[string]string{"1.3": "1.3"})
+	if len(result.Skipped) != 2 {
+		t.Errorf("Skipped = %v, want 1.2 and 1.2.1", result.Skipped)
+	}
+}
+
+func TestRebase_Errors(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+
+	if _, err := svc.Rebase(" "); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Rebase(blank) error = %v, want ErrEmptyInput", err)
+	}
+
+	unrelated := t.TempDir()
+	if err := Init(unrelated, "A different conjecture", "someone-else"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Rebase(unrelated); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Rebase(unrelated) error = %v, want ErrInvalidState", err)
+	}
+
+	// A clone with nothing new is not an error
+	result, err := svc.Rebase(clone.Path())
+	if err != nil || len(result.Added) != 0 {
+		t.Errorf("Rebase(unchanged clone) = %+v, %v; want nothing added", result, err)
+	}
+}
+
+func TestRebase_DryRunWritesNothing(t *testing.T) {
+	svc, clone := newRebaseTestClone(t)
+	mustCreate(t, clone, "1.2", "Clone step")
+
+	dry, err := NewProofService(svc.Path(), WithDryRun(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := ledgerCount(t, svc)
+	result, err := dry.Rebase(clone.Path())
+	if err != nil {
+		t.Fatalf("dry-run Rebase failed: %v", err)
+	}
+	assertMapping(t, result.Added, map[string]string{"1.2": "1.2"})
+	if got := ledgerCount(t, svc); got != before {
+		t.Errorf("dry-run Rebase appended %d events", got-before)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// FindReclaimable returns the claimed nodes whose claim timeout has passed,
+// sorted by node ID. These can be freed with ReapExpiredClaims or taken over
+// with ReclaimNode.
+func (s *ProofService) FindReclaimable() ([]*node.Node, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return expiredClaims(st, types.Now()), nil
+}
+
+// WhoHolds returns the agent currently holding a claim on nodeID, when the
+// claim was made, and when it expires (claim time plus timeout). An
+// unclaimed node returns an empty owner and zero times. A claim whose
+// expiry has passed is still reported until it is reaped or reclaimed, so
+// callers can compare expires with the current time.
+//
+// Returns ErrNodeNotFound if the node doesn't exist.
+func (s *ProofService) WhoHolds(nodeID types.NodeID) (owner string, since time.Time, expires time.Time, err error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	if n.WorkflowState != schema.WorkflowClaimed || n.ClaimedBy == "" {
+		return "", time.Time{}, time.Time{}, nil
+	}
+	return n.ClaimedBy, n.ClaimedAt.Time(), n.ClaimExpiry().Time(), nil
+}
+
+// expiredClaims returns the nodes in st whose claim expired before now,
+// sorted by node ID.
+func expiredClaims(st *state.State, now types.Timestamp) []*node.Node {
+	var expired []*node.Node
+	for _, n := range st.AllNodesSorted() {
+		if n.IsClaimExpired(now) {
+			expired = append(expired, n)
+		}
+	}
+	return expired
+}
+
+// ReclaimNode takes over a node whose claim has expired, claiming it for
+// newOwner with the given timeout. The stale claim is released and the node
+// claimed in a single event, so unlike reaping and then claiming there is no
+// window in which another agent can take the node. An agent may reclaim its
+// own expired claim, which renews it.
+//
+// Returns ErrEmptyInput if newOwner is empty, ErrInvalidTimeout if timeout
+// is not positive, ErrNodeNotFound if the node doesn't exist, or
+// ErrNotClaimed if it is not claimed (claim it with ClaimNode instead).
+// Returns ErrClaimNotExpired, naming the current owner and the time left,
+// if the claim has not expired yet.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) ReclaimNode(nodeID types.NodeID, newOwner string, timeout time.Duration) error {
+	if strings.TrimSpace(newOwner) == "" {
+		return fmt.Errorf("%w: owner", ErrEmptyInput)
+	}
+	if timeout <= 0 {
+		return ErrInvalidTimeout
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	if n.WorkflowState != schema.WorkflowClaimed {
+		return fmt.Errorf("%w: node %s", ErrNotClaimed, nodeID.String())
+	}
+	now := types.Now()
+	if !n.IsClaimExpired(now) {
+		remaining := n.ClaimExpiry().Sub(now).Round(time.Second)
+		return fmt.Errorf("%w: node %s is claimed by %s for another %s", ErrClaimNotExpired, nodeID.String(), n.ClaimedBy, remaining)
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	timeoutTS := types.FromTime(time.Now().Add(timeout))
+	var event ledger.Event
+	if n.ClaimedBy == newOwner {
+		event = ledger.NewClaimRefreshed(nodeID, newOwner, timeoutTS)
+	} else {
+		event = ledger.NewNodesReassigned([]types.NodeID{nodeID}, n.ClaimedBy, newOwner, timeoutTS)
+	}
+	_, err = s.appendIfSequence(ldg, event, expectedSeq)
+	return wrapSequenceMismatch(err, "ReclaimNode")
+}
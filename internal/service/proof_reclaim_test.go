@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	aferrors "github.com/tobias/vibefeld/internal/errors"
+)
+
+// newExpiredClaimService returns a proof whose root is claimed by "stale"
+// with a claim that has already expired.
+func newExpiredClaimService(t *testing.T) *ProofService {
+	t.Helper()
+	svc := newChallengeTestService(t)
+	if err := svc.ClaimNode(mustParseID(t, "1"), "stale", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	return svc
+}
+
+func TestReclaimNode_NewOwner(t *testing.T) {
+	svc := newExpiredClaimService(t)
+
+	reclaimable, err := svc.FindReclaimable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reclaimable) != 1 || reclaimable[0].ID.String() != "1" {
+		t.Fatalf("FindReclaimable() = %v, want node 1", reclaimable)
+	}
+
+	if err := svc.ReclaimNode(mustParseID(t, "1"), "fresh", time.Hour); err != nil {
+		t.Fatalf("ReclaimNode failed: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := st.GetNode(mustParseID(t, "1"))
+	if n.ClaimedBy != "fresh" {
+		t.Errorf("ClaimedBy = %q, want fresh", n.ClaimedBy)
+	}
+	if n.IsClaimExpired(n.ClaimedAt) {
+		t.Error("reclaimed claim is already expired")
+	}
+	if reclaimable, _ := svc.FindReclaimable(); len(reclaimable) != 0 {
+		t.Errorf("FindReclaimable() after reclaim = %v, want none", reclaimable)
+	}
+}
+
+func TestReclaimNode_SameOwnerRenews(t *testing.T) {
+	svc := newExpiredClaimService(t)
+
+	if err := svc.ReclaimNode(mustParseID(t, "1"), "stale", time.Hour); err != nil {
+		t.Fatalf("ReclaimNode failed: %v", err)
+	}
+	if reclaimable, _ := svc.FindReclaimable(); len(reclaimable) != 0 {
+		t.Errorf("FindReclaimable() after renewal = %v, want none", reclaimable)
+	}
+}
+
+func TestReclaimNode_NotExpired(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if err := svc.ClaimNode(mustParseID(t, "1"), "holder", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	err := svc.ReclaimNode(mustParseID(t, "1"), "fresh", time.Hour)
+	if !errors.Is(err, ErrClaimNotExpired) {
+		t.Fatalf("ReclaimNode() error = %v, want ErrClaimNotExpired", err)
+	}
+	if !strings.Contains(err.Error(), "holder") {
+		t.Errorf("error %q does not name the current owner", err)
+	}
+}
+
+func TestErrClaimNotExpired_DistinctFromAlreadyClaimed(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if err := svc.ClaimNode(mustParseID(t, "1"), "holder", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	alreadyClaimed := aferrors.New(aferrors.ALREADY_CLAIMED, "node 1 is claimed by holder")
+	if errors.Is(alreadyClaimed, ErrClaimNotExpired) {
+		t.Error("errors.Is(ALREADY_CLAIMED error, ErrClaimNotExpired) = true, want false")
+	}
+	if err := svc.ClaimNode(mustParseID(t, "1"), "other", time.Hour); errors.Is(err, ErrClaimNotExpired) {
+		t.Errorf("ClaimNode() error = %v, want it not to match ErrClaimNotExpired", err)
+	}
+
+	err := svc.ReclaimNode(mustParseID(t, "1"), "fresh", time.Hour)
+	if !errors.Is(err, ErrClaimNotExpired) {
+		t.Fatalf("ReclaimNode() error = %v, want ErrClaimNotExpired", err)
+	}
+	if errors.Is(err, alreadyClaimed) {
+		t.Errorf("errors.Is(ReclaimNode error, ALREADY_CLAIMED error) = true, want false")
+	}
+}
+
+func TestReclaimNode_Errors(t *testing.T) {
+	svc := newChallengeTestService(t)
+
+	if err := svc.ReclaimNode(mustParseID(t, "1"), "fresh", time.Hour); !errors.Is(err, ErrNotClaimed) {
+		t.Errorf("unclaimed node: error = %v, want ErrNotClaimed", err)
+	}
+	if err := svc.ReclaimNode(mustParseID(t, "1.9"), "fresh", time.Hour); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("missing node: error = %v, want ErrNodeNotFound", err)
+	}
+	if err := svc.ReclaimNode(mustParseID(t, "1"), " ", time.Hour); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("empty owner: error = %v, want ErrEmptyInput", err)
+	}
+	if err := svc.ReclaimNode(mustParseID(t, "1"), "fresh", 0); !errors.Is(err, ErrInvalidTimeout) {
+		t.Errorf("zero timeout: error = %v, want ErrInvalidTimeout", err)
+	}
+}
+
+func TestWhoHolds(t *testing.T) {
+	svc := newChallengeTestService(t)
+	root := mustParseID(t, "1")
+
+	owner, since, expires, err := svc.WhoHolds(root)
+	if err != nil {
+		t.Fatalf("WhoHolds on free node failed: %v", err)
+	}
+	if owner != "" || !since.IsZero() || !expires.IsZero() {
+		t.Errorf("WhoHolds(free) = %q, %v, %v; want empty owner and zero times", owner, since, expires)
+	}
+
+	before := time.Now()
+	if err := svc.ClaimNode(root, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	owner, since, expires, err = svc.WhoHolds(root)
+	if err != nil {
+		t.Fatalf("WhoHolds on claimed node failed: %v", err)
+	}
+	if owner != "prover" {
+		t.Errorf("owner = %q, want prover", owner)
+	}
+	if since.Before(before.Add(-time.Second)) || since.After(time.Now()) {
+		t.Errorf("since = %v, want about %v", since, before)
+	}
+	if got := expires.Sub(since); got < time.Hour-time.Second || got > time.Hour {
+		t.Errorf("expires - since = %v, want about 1h", got)
+	}
+
+	if err := svc.ReleaseNode(root, "prover"); err != nil {
+		t.Fatal(err)
+	}
+	if owner, _, _, err := svc.WhoHolds(root); err != nil || owner != "" {
+		t.Errorf("WhoHolds after release = %q, %v; want empty owner", owner, err)
+	}
+
+	if _, _, _, err := svc.WhoHolds(mustParseID(t, "1.9")); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("WhoHolds(missing) error = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestWhoHolds_ExpiredClaim(t *testing.T) {
+	svc := newExpiredClaimService(t)
+
+	owner, _, expires, err := svc.WhoHolds(mustParseID(t, "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner != "stale" || !expires.Before(time.Now()) {
+		t.Errorf("WhoHolds(expired) = %q expiring %v, want stale with a past expiry", owner, expires)
+	}
+}
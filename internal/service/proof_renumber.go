@@ -0,0 +1,312 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// RenumberSubtree re-sequences the live (non-archived) direct children of
+// rootID so they are numbered contiguously from rootID.1, moving each
+// child's descendants along with it. Archived children keep their IDs, and
+// live children are numbered around them.
+//
+// A moved node is recreated under its new ID with its state and notes
+// intact, and its old ID is archived unless another moved node takes it
+// over. Challenges and amendment history are recorded against node IDs and
+// stay with the old ID, so a node may not take over the ID of a node that
+// has any. Dependencies
+// and validation dependencies pointing at moved nodes are rewritten: inside
+// the moved nodes directly, elsewhere through amendments recorded for owner.
+// All events are appended as one all-or-nothing batch (see
+// appendBulkIfSequence), so a failure never leaves a moved subtree live
+// under both its old and new IDs. The subtree of rootID and every node
+// citing one of its nodes are locked for the whole call (see lockNodes).
+//
+// Returns the mapping from old ID string to new ID for every moved node;
+// the map is empty if the children are already contiguous.
+//
+// Returns ErrNodeNotFound if rootID doesn't exist.
+// Returns ErrNotClaimed or ErrOwnerMismatch if rootID is not claimed by owner.
+// Returns ErrAlreadyExists if a new ID is taken by a node that is not moving.
+// Returns ErrInvalidState if a moved node has open challenges or an
+// assumption scope, if another node would take over its ID but it has
+// challenges or amendment history, or if its old ID would have to be
+// archived but is in a state that cannot be archived.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) RenumberSubtree(rootID types.NodeID, owner string) (map[string]types.NodeID, error) {
+	// Lock the nodes the renumbering may write, then capture the sequence of
+	// the state loaded under the locks for CAS
+	st, locked, err := s.lockTouchedNodes(func(st *state.State) []types.NodeID {
+		return relocationTouched(st, rootID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockNodes(locked)
+	expectedSeq := st.LatestSeq()
+
+	root := st.GetNode(rootID)
+	if root == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, rootID.String())
+	}
+	if root.WorkflowState != schema.WorkflowClaimed {
+		return nil, fmt.Errorf("%w: node %s must be claimed to renumber its children", ErrNotClaimed, rootID.String())
+	}
+	if root.ClaimedBy != owner {
+		return nil, ErrOwnerMismatch
+	}
+
+	mapping, err := renumberMapping(st, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mapping) == 0 {
+		return mapping, nil
+	}
+
+	events, err := relocationEvents(st, mapping, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	// Append all events as one all-or-nothing batch with CAS (see appendBulkIfSequence)
+	if _, err := s.appendBulkIfSequence(ldg, events, expectedSeq); err != nil {
+		return nil, wrapSequenceMismatch(err, "RenumberSubtree")
+	}
+
+	return mapping, nil
+}
+
+// relocationTouched returns the nodes that relocating nodes within the
+// subtree of rootID may write: the subtree itself and every node that cites
+// one of its nodes, whose citations are amended. Returns nil if rootID
+// doesn't exist.
+func relocationTouched(st *state.State, rootID types.NodeID) []types.NodeID {
+	subtree := subtreeIDs(st, rootID)
+	ids := append([]types.NodeID{}, subtree...)
+	for _, id := range subtree {
+		ids = append(ids, st.Dependents(id)...)
+	}
+	return ids
+}
+
+// relocationEvents returns the events that move every node in mapping from
+// its old ID to its new one: a node_created for each node under its new ID
+// with its state intact, a node_archived for each old ID that no moved node
+// takes over, and amendments recorded for owner on nodes that stay put but
+// cite a moved node.
+//
+// Challenges and amendment history stay recorded under a node's old ID.
+//
+// Returns ErrInvalidState if a moved node has open challenges or an
+// assumption scope, if another node would take over its ID but it has
+// challenges or amendment history, or if its old ID would have to be
+// archived but is in a state that cannot be archived.
+func relocationEvents(st *state.State, mapping map[string]types.NodeID, owner string) ([]ledger.Event, error) {
+	// Moved nodes sorted by new ID, so parents are created before children
+	var moved []*node.Node
+	for _, n := range st.AllNodes() {
+		if _, ok := mapping[n.ID.String()]; ok {
+			moved = append(moved, n)
+		}
+	}
+	sort.Slice(moved, func(i, j int) bool {
+		return mapping[moved[i].ID.String()].Less(mapping[moved[j].ID.String()])
+	})
+
+	// New ID → old ID of the node moving there
+	newIDs := make(map[string]string, len(mapping))
+	for oldID, newID := range mapping {
+		newIDs[newID.String()] = oldID
+	}
+
+	var archives, creates []ledger.Event
+	for _, n := range moved {
+		if hasOpenChallenge(st.GetChallengesForNode(n.ID)) {
+			return nil, fmt.Errorf("%w: node %s has open challenges; resolve or withdraw them before moving it",
+				ErrInvalidState, n.ID.String())
+		}
+		if st.GetScope(n.ID) != nil {
+			return nil, fmt.Errorf("%w: node %s opens an assumption scope and cannot be moved",
+				ErrInvalidState, n.ID.String())
+		}
+
+		// The history recorded under an ID taken over would pass to the new node
+		successor, takenOver := newIDs[n.ID.String()]
+		if takenOver && (len(st.GetChallengesForNode(n.ID)) > 0 || len(st.GetAmendmentHistory(n.ID)) > 0) {
+			return nil, fmt.Errorf("%w: node %s has challenges or amendments recorded under its ID, which node %s would take over",
+				ErrInvalidState, n.ID.String(), successor)
+		}
+
+		// An old ID that no moved node takes over is archived
+		if !takenOver && n.EpistemicState != schema.EpistemicArchived {
+			if err := schema.ValidateEpistemicTransition(n.EpistemicState, schema.EpistemicArchived); err != nil {
+				return nil, fmt.Errorf("%w: node %s is %s and cannot be archived after moving to %s",
+					ErrInvalidState, n.ID.String(), n.EpistemicState, mapping[n.ID.String()].String())
+			}
+			archives = append(archives, ledger.NewNodeArchived(n.ID))
+		}
+
+		created := *n
+		created.ID = mapping[n.ID.String()]
+		created.Dependencies = renumberIDs(n.Dependencies, mapping)
+		created.ValidationDeps = renumberIDs(n.ValidationDeps, mapping)
+		created.ContentHash = created.ComputeContentHash()
+		creates = append(creates, ledger.NewNodeCreated(created))
+	}
+
+	// Nodes that stay put but cite a moved node have their references amended
+	var amends []ledger.Event
+	for _, n := range st.AllNodes() {
+		if _, isMoved := mapping[n.ID.String()]; isMoved {
+			continue
+		}
+		next := *n
+		next.Dependencies = renumberIDs(n.Dependencies, mapping)
+		next.ValidationDeps = renumberIDs(n.ValidationDeps, mapping)
+
+		var fields []string
+		if !equalIDs(n.Dependencies, next.Dependencies) {
+			fields = append(fields, ledger.AmendFieldDependencies)
+		}
+		if !equalIDs(n.ValidationDeps, next.ValidationDeps) {
+			fields = append(fields, ledger.AmendFieldValidationDeps)
+		}
+		if len(fields) > 0 {
+			amends = append(amends, ledger.NewNodeAmendedFull(*n, next, owner, fields))
+		}
+	}
+
+	return append(append(creates, archives...), amends...), nil
+}
+
+// renumberMapping computes the old→new ID mapping that makes the live
+// children of rootID contiguous, including every descendant of a moved child.
+// Slots held by archived children are skipped rather than taken over.
+// Returns ErrAlreadyExists if a new ID is held by a node that is not moving.
+func renumberMapping(st *state.State, rootID types.NodeID) (map[string]types.NodeID, error) {
+	var children []*node.Node
+	archived := make(map[string]bool)
+	for _, n := range st.AllNodes() {
+		parent, ok := n.ID.Parent()
+		if !ok || !parent.Equal(rootID) {
+			continue
+		}
+		if n.EpistemicState == schema.EpistemicArchived {
+			archived[n.ID.String()] = true
+			continue
+		}
+		children = append(children, n)
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].ID.Less(children[j].ID) })
+
+	mapping := make(map[string]types.NodeID)
+	slot := 0
+	for _, child := range children {
+		// Archived children keep their IDs, so their slots are skipped
+		var newChildID types.NodeID
+		for {
+			slot++
+			id, err := rootID.Child(slot)
+			if err != nil {
+				return nil, err
+			}
+			if !archived[id.String()] {
+				newChildID = id
+				break
+			}
+		}
+		if newChildID.Equal(child.ID) {
+			continue
+		}
+		if err := mapSubtree(st, child.ID, newChildID, mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	// A new ID may only be taken from a node that is itself moving
+	for _, newID := range mapping {
+		if st.GetNode(newID) == nil {
+			continue
+		}
+		if _, isMoved := mapping[newID.String()]; !isMoved {
+			return nil, fmt.Errorf("%w: renumbering would collide with existing node %s", ErrAlreadyExists, newID.String())
+		}
+	}
+
+	return mapping, nil
+}
+
+// mapSubtree adds to mapping the new ID of oldRoot and each of its
+// descendants when the subtree is moved to newRoot, keeping each
+// descendant's path below the root.
+func mapSubtree(st *state.State, oldRoot, newRoot types.NodeID, mapping map[string]types.NodeID) error {
+	oldPrefix := oldRoot.String()
+	for _, n := range st.Subtree(oldRoot) {
+		newID, err := types.Parse(newRoot.String() + strings.TrimPrefix(n.ID.String(), oldPrefix))
+		if err != nil {
+			return err
+		}
+		mapping[n.ID.String()] = newID
+	}
+	return nil
+}
+
+// renumberIDs returns ids with every ID in mapping replaced by its new ID.
+// Returns ids itself if none of them moved.
+func renumberIDs(ids []types.NodeID, mapping map[string]types.NodeID) []types.NodeID {
+	var out []types.NodeID
+	for i, id := range ids {
+		newID, ok := mapping[id.String()]
+		if !ok {
+			if out != nil {
+				out = append(out, id)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]types.NodeID, 0, len(ids)), ids[:i]...)
+		}
+		out = append(out, newID)
+	}
+	if out == nil {
+		return ids
+	}
+	return out
+}
+
+// equalIDs reports whether a and b hold the same IDs in the same order.
+func equalIDs(a, b []types.NodeID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasOpenChallenge reports whether any of challenges is open.
+func hasOpenChallenge(challenges []*state.Challenge) bool {
+	for _, c := range challenges {
+		if c.Status == state.ChallengeStatusOpen {
+			return true
+		}
+	}
+	return false
+}
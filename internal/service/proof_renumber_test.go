@@ -0,0 +1,222 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newRenumberTestService creates a proof whose root is claimed by "prover"
+// and has nodes with the given IDs, created in order.
+func newRenumberTestService(t *testing.T, ids ...string) *ProofService {
+	t.Helper()
+	svc := newChallengeTestService(t)
+	for _, id := range ids {
+		if err := svc.CreateNode(mustParseID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption); err != nil {
+			t.Fatalf("CreateNode(%s) failed: %v", id, err)
+		}
+	}
+	if err := svc.ClaimNode(mustParseID(t, "1"), "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func mustParseID(t *testing.T, s string) types.NodeID {
+	t.Helper()
+	id, err := types.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestRenumberSubtree_ClosesGaps(t *testing.T) {
+	svc := newRenumberTestService(t, "1.3", "1.3.1", "1.5")
+	rootID := mustParseID(t, "1")
+
+	// 1.1 cites 1.5, which is about to move
+	if err := svc.Refine(RefineSpec{
+		ParentID:     rootID,
+		Owner:        "prover",
+		ChildID:      mustParseID(t, "1.1"),
+		NodeType:     schema.NodeTypeClaim,
+		Statement:    "Claim 1.1",
+		Inference:    schema.InferenceAssumption,
+		Dependencies: []types.NodeID{mustParseID(t, "1.5")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	mapping, err := svc.RenumberSubtree(rootID, "prover")
+	if err != nil {
+		t.Fatalf("RenumberSubtree failed: %v", err)
+	}
+	want := map[string]string{"1.3": "1.2", "1.3.1": "1.2.1", "1.5": "1.3"}
+	if len(mapping) != len(want) {
+		t.Fatalf("mapping = %v, want %v", mapping, want)
+	}
+	for oldID, newID := range want {
+		if got := mapping[oldID]; got.String() != newID {
+			t.Errorf("mapping[%s] = %s, want %s", oldID, got, newID)
+		}
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statements := map[string]string{"1.1": "Claim 1.1", "1.2": "Claim 1.3", "1.2.1": "Claim 1.3.1", "1.3": "Claim 1.5"}
+	for id, stmt := range statements {
+		n := st.GetNode(mustParseID(t, id))
+		if n == nil || n.Statement != stmt {
+			t.Errorf("node %s = %+v, want statement %q", id, n, stmt)
+			continue
+		}
+		if n.EpistemicState != schema.EpistemicPending {
+			t.Errorf("node %s EpistemicState = %s, want pending", id, n.EpistemicState)
+		}
+		if !n.VerifyContentHash() {
+			t.Errorf("node %s has a stale content hash", id)
+		}
+	}
+	for _, id := range []string{"1.3.1", "1.5"} {
+		if n := st.GetNode(mustParseID(t, id)); n == nil || n.EpistemicState != schema.EpistemicArchived {
+			t.Errorf("vacated node %s = %+v, want archived", id, n)
+		}
+	}
+
+	deps := st.GetNode(mustParseID(t, "1.1")).Dependencies
+	if len(deps) != 1 || deps[0].String() != "1.3" {
+		t.Errorf("1.1 dependencies = %v, want [1.3]", deps)
+	}
+
+	// Already contiguous: nothing to do
+	before, _ := svc.LoadState()
+	mapping, err = svc.RenumberSubtree(rootID, "prover")
+	if err != nil || len(mapping) != 0 {
+		t.Fatalf("second RenumberSubtree = %v, %v; want empty mapping", mapping, err)
+	}
+	after, _ := svc.LoadState()
+	if before.LatestSeq() != after.LatestSeq() {
+		t.Errorf("no-op renumber appended events: seq %d -> %d", before.LatestSeq(), after.LatestSeq())
+	}
+}
+
+func TestRenumberSubtree_SkipsArchivedSiblings(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2", "1.4")
+	rootID := mustParseID(t, "1")
+	if err := svc.ArchiveNode(mustParseID(t, "1.2")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1.2 keeps its ID, so 1.4 moves to 1.3
+	mapping, err := svc.RenumberSubtree(rootID, "prover")
+	if err != nil {
+		t.Fatalf("RenumberSubtree failed: %v", err)
+	}
+	if len(mapping) != 1 || mapping["1.4"].String() != "1.3" {
+		t.Errorf("mapping = %v, want 1.4 -> 1.3", mapping)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := st.GetNode(mustParseID(t, "1.2")); n == nil || n.Statement != "Claim 1.2" || n.EpistemicState != schema.EpistemicArchived {
+		t.Errorf("archived sibling 1.2 = %+v, want it untouched", n)
+	}
+	if n := st.GetNode(mustParseID(t, "1.3")); n == nil || n.Statement != "Claim 1.4" {
+		t.Errorf("node 1.3 = %+v, want statement %q", n, "Claim 1.4")
+	}
+}
+
+// TestRenumberSubtree_FailureMidBatchCommitsNothing fails the batch after
+// the new nodes are created but before the old IDs are archived, which must
+// not leave the moved subtree live under both IDs.
+func TestRenumberSubtree_FailureMidBatchCommitsNothing(t *testing.T) {
+	svc := newRenumberTestService(t, "1.3", "1.3.1", "1.5")
+	before := ledgerCount(t, svc)
+
+	// Three creates, then the archives
+	unblock := blockLedgerSeq(t, svc, before+4)
+	if _, err := svc.RenumberSubtree(mustParseID(t, "1"), "prover"); err == nil {
+		t.Fatal("RenumberSubtree succeeded with an event file name blocked")
+	}
+	unblock()
+
+	if after := ledgerCount(t, svc); after != before {
+		t.Errorf("ledger grew from %d to %d events, want nothing committed", before, after)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1.1", "1.1.1", "1.2"} {
+		if n := st.GetNode(mustParseID(t, id)); n != nil {
+			t.Errorf("node %s = %+v after failed renumber, want none", id, n)
+		}
+	}
+	for _, id := range []string{"1.3", "1.3.1", "1.5"} {
+		if n := st.GetNode(mustParseID(t, id)); n == nil || n.EpistemicState != schema.EpistemicPending {
+			t.Errorf("node %s = %+v after failed renumber, want pending", id, n)
+		}
+	}
+}
+
+func TestRenumberSubtree_Errors(t *testing.T) {
+	rootID, _ := types.Parse("1")
+
+	t.Run("not claimed", func(t *testing.T) {
+		svc := newChallengeTestService(t)
+		if _, err := svc.RenumberSubtree(rootID, "prover"); !errors.Is(err, ErrNotClaimed) {
+			t.Errorf("error = %v, want ErrNotClaimed", err)
+		}
+	})
+
+	t.Run("wrong owner", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.2")
+		if _, err := svc.RenumberSubtree(rootID, "someone-else"); !errors.Is(err, ErrOwnerMismatch) {
+			t.Errorf("error = %v, want ErrOwnerMismatch", err)
+		}
+	})
+
+	t.Run("takes over an ID with a closed challenge", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.2", "1.3")
+		challengeID, err := svc.RaiseChallenge(mustParseID(t, "1.2"), "", "Gap", "minor")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.ResolveChallenge(challengeID); err != nil {
+			t.Fatal(err)
+		}
+		// 1.3 would move to 1.2 and inherit its resolved challenge
+		if _, err := svc.RenumberSubtree(rootID, "prover"); !errors.Is(err, ErrInvalidState) || !strings.Contains(err.Error(), "node 1.3 would take over") {
+			t.Errorf("error = %v, want ErrInvalidState naming the takeover by 1.3", err)
+		}
+	})
+
+	t.Run("takes over an amended ID", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.2", "1.3")
+		if err := svc.AmendNode(mustParseID(t, "1.2"), "prover", "Claim 1.2, amended"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.RenumberSubtree(rootID, "prover"); !errors.Is(err, ErrInvalidState) || !strings.Contains(err.Error(), "node 1.3 would take over") {
+			t.Errorf("error = %v, want ErrInvalidState naming the takeover by 1.3", err)
+		}
+	})
+
+	t.Run("moved node has open challenge", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.2")
+		if _, err := svc.RaiseChallenge(mustParseID(t, "1.2"), "", "Gap", "minor"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.RenumberSubtree(rootID, "prover"); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+	})
+}
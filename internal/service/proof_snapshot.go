@@ -0,0 +1,63 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/fs"
+	"github.com/tobias/vibefeld/internal/render"
+)
+
+// ExportState writes the proof's fully derived state (nodes, definitions,
+// assumptions, externals, lemmas, and challenges) to path as one JSON
+// document, for handing the proof to someone without the CLI. Unlike the
+// ledger it records the result, not the history. The document is a
+// StateSnapshot built from the render view models and carries their
+// schema version; LoadStateFromSnapshot reads it back.
+//
+// The file is written atomically, replacing any existing file at path.
+// ExportState does not change the proof, so it also works on read-only
+// and dry-run services.
+func (s *ProofService) ExportState(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("%w: snapshot path", ErrEmptyInput)
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(render.StateToSnapshotView(st), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := fs.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadStateFromSnapshot reads a snapshot written by ExportState. It needs
+// neither the proof directory nor its ledger: the snapshot holds view
+// models, ready for rendering, and nothing in it can be written back.
+//
+// Returns an error if the file cannot be read or parsed, or if it was
+// written with a different schema version.
+func LoadStateFromSnapshot(path string) (*StateSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap StateSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if snap.SchemaVersion != render.JSONSchemaVersion {
+		return nil, fmt.Errorf("unsupported snapshot schema version %d (want %d)", snap.SchemaVersion, render.JSONSchemaVersion)
+	}
+	return &snap, nil
+}
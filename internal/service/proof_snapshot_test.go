@@ -0,0 +1,123 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/render"
+)
+
+func TestExportState_RoundTrip(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1")
+	defID, err := svc.AddDefinition("group", "A set with an operation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assumptionID, err := svc.AddAssumption("G is finite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	externalID, err := svc.AddExternal("Lagrange", "Any algebra textbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	challengeID, err := svc.RaiseChallenge(mustParseID(t, "1.1"), "", "Why?", "minor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := svc.ExportState(path); err != nil {
+		t.Fatalf("ExportState() error: %v", err)
+	}
+
+	snap, err := LoadStateFromSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadStateFromSnapshot() error: %v", err)
+	}
+	if snap.SchemaVersion != render.JSONSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", snap.SchemaVersion, render.JSONSchemaVersion)
+	}
+	if len(snap.Nodes) != 2 || snap.Nodes[0].ID != "1" || snap.Nodes[1].ID != "1.1" {
+		t.Errorf("Nodes = %+v, want 1 and 1.1", snap.Nodes)
+	}
+	if len(snap.Definitions) != 1 || snap.Definitions[0].ID != defID || snap.Definitions[0].Name != "group" {
+		t.Errorf("Definitions = %+v, want %s", snap.Definitions, defID)
+	}
+	if len(snap.Assumptions) != 1 || snap.Assumptions[0].ID != assumptionID {
+		t.Errorf("Assumptions = %+v, want %s", snap.Assumptions, assumptionID)
+	}
+	if len(snap.Externals) != 1 || snap.Externals[0].ID != externalID {
+		t.Errorf("Externals = %+v, want %s", snap.Externals, externalID)
+	}
+	if len(snap.Challenges) != 1 || snap.Challenges[0].ID != challengeID || snap.Challenges[0].Severity != "minor" {
+		t.Errorf("Challenges = %+v, want %s", snap.Challenges, challengeID)
+	}
+	if snap.Lemmas == nil || len(snap.Lemmas) != 0 {
+		t.Errorf("Lemmas = %#v, want empty", snap.Lemmas)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := render.StateToSnapshotView(st); !reflect.DeepEqual(*snap, want) {
+		t.Errorf("snapshot does not match the state it was exported from:\ngot  %+v\nwant %+v", *snap, want)
+	}
+}
+
+func TestExportState_Stable(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2")
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")
+	for _, path := range []string{first, second} {
+		if err := svc.ExportState(path); err != nil {
+			t.Fatalf("ExportState(%s) error: %v", path, err)
+		}
+	}
+
+	a, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(a) != string(b) {
+		t.Errorf("exports of the same state differ:\n%s\n---\n%s", a, b)
+	}
+}
+
+func TestLoadStateFromSnapshot_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadStateFromSnapshot(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	garbage := filepath.Join(dir, "garbage.json")
+	if err := os.WriteFile(garbage, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadStateFromSnapshot(garbage); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+
+	future := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(future, []byte(`{"schema_version": 999, "nodes": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadStateFromSnapshot(future); err == nil || !strings.Contains(err.Error(), "schema version 999") {
+		t.Errorf("expected schema version error, got %v", err)
+	}
+}
+
+func TestExportState_EmptyPath(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if err := svc.ExportState(" "); err == nil {
+		t.Error("expected error for empty path")
+	}
+}
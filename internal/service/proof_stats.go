@@ -0,0 +1,148 @@
+package service
+
+import (
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// ProofStats contains structural metrics describing the shape of a proof.
+// Unlike ProofStatus, which counts nodes by state, it measures the tree and
+// the dependency graph, for dashboards tracking a proof over time.
+type ProofStats struct {
+	TotalNodes int `json:"total_nodes"`
+
+	// MaxDepth is the depth of the deepest node; the root has depth 1.
+	MaxDepth int `json:"max_depth"`
+
+	// Leaves is the number of nodes without children.
+	Leaves int `json:"leaves"`
+
+	// AverageBranching is the mean number of children of the nodes that
+	// have at least one child, or 0 if no node has children.
+	AverageBranching float64 `json:"average_branching"`
+
+	// MaxChildren is the largest number of children of any node.
+	MaxChildren int `json:"max_children"`
+
+	// AdmittedNodes is the number of nodes admitted without proof, the
+	// proof's outstanding debt.
+	AdmittedNodes int `json:"admitted_nodes"`
+
+	// LongestDependencyChain is the number of edges on the longest path
+	// through Dependencies and ValidationDeps. Edges that would revisit a
+	// node already on the path are not followed, so cycles are counted once.
+	LongestDependencyChain int `json:"longest_dependency_chain"`
+
+	// TaintCounts counts nodes by taint state (clean, self_admitted,
+	// tainted, unresolved). Every state is present, possibly with 0.
+	TaintCounts map[string]int `json:"taint_counts"`
+}
+
+// Stats computes structural metrics for the proof.
+// Note: This method performs I/O to load state from disk.
+func (s *ProofService) Stats() (*ProofStats, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return computeProofStats(st), nil
+}
+
+// computeProofStats computes the ProofStats of st.
+func computeProofStats(st *state.State) *ProofStats {
+	nodes := st.AllNodes()
+	stats := &ProofStats{
+		TotalNodes: len(nodes),
+		TaintCounts: map[string]int{
+			string(node.TaintClean):        0,
+			string(node.TaintSelfAdmitted): 0,
+			string(node.TaintTainted):      0,
+			string(node.TaintUnresolved):   0,
+		},
+	}
+
+	children := make(map[string]int, len(nodes))
+	for _, n := range nodes {
+		if parent, ok := n.ID.Parent(); ok && st.GetNode(parent) != nil {
+			children[parent.String()]++
+		}
+	}
+
+	totalChildren := 0
+	for _, n := range nodes {
+		if depth := n.ID.Depth(); depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+		if count := children[n.ID.String()]; count == 0 {
+			stats.Leaves++
+		} else {
+			totalChildren += count
+			if count > stats.MaxChildren {
+				stats.MaxChildren = count
+			}
+		}
+		if n.EpistemicState == schema.EpistemicAdmitted {
+			stats.AdmittedNodes++
+		}
+		stats.TaintCounts[string(n.TaintState)]++
+	}
+	if internal := len(children); internal > 0 {
+		stats.AverageBranching = float64(totalChildren) / float64(internal)
+	}
+
+	stats.LongestDependencyChain = longestDependencyChain(st, nodes)
+	return stats
+}
+
+// longestDependencyChain returns the number of edges on the longest
+// dependency path in st. Each node's chain length is computed once, so the
+// traversal is linear in the size of the graph. Edges to a node still on the
+// current path close a cycle and are skipped; edges to missing nodes are
+// ignored.
+func longestDependencyChain(st *state.State, nodes []*node.Node) int {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	status := make(map[string]int, len(nodes))
+	chain := make(map[string]int, len(nodes))
+
+	var visit func(n *node.Node) int
+	visit = func(n *node.Node) int {
+		key := n.ID.String()
+		switch status[key] {
+		case onPath:
+			return -1
+		case done:
+			return chain[key]
+		}
+		status[key] = onPath
+
+		longest := 0
+		deps := append(append([]types.NodeID{}, n.Dependencies...), n.ValidationDeps...)
+		for _, depID := range deps {
+			dep := st.GetNode(depID)
+			if dep == nil {
+				continue
+			}
+			if length := visit(dep); length >= 0 && length+1 > longest {
+				longest = length + 1
+			}
+		}
+
+		status[key] = done
+		chain[key] = longest
+		return longest
+	}
+
+	longest := 0
+	for _, n := range nodes {
+		if length := visit(n); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestStats(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.1.1", "1.1.2", "1.1.3", "1.2")
+	rootID := mustParseID(t, "1")
+
+	// 1.4 -> 1.3 -> 1.2 is the longest dependency chain
+	for _, spec := range []struct {
+		child string
+		dep   string
+	}{{"1.3", "1.2"}, {"1.4", "1.3"}} {
+		if err := svc.Refine(RefineSpec{
+			ParentID:     rootID,
+			Owner:        "prover",
+			ChildID:      mustParseID(t, spec.child),
+			NodeType:     schema.NodeTypeClaim,
+			Statement:    "Claim " + spec.child,
+			Inference:    schema.InferenceAssumption,
+			Dependencies: []types.NodeID{mustParseID(t, spec.dep)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.AdmitNode(mustParseID(t, "1.2")); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := svc.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+
+	if stats.TotalNodes != 8 || stats.MaxDepth != 3 || stats.Leaves != 6 {
+		t.Errorf("nodes, depth, leaves = %d, %d, %d; want 8, 3, 6", stats.TotalNodes, stats.MaxDepth, stats.Leaves)
+	}
+	// 1 has four children and 1.1 has three
+	if stats.AverageBranching != 3.5 || stats.MaxChildren != 4 {
+		t.Errorf("AverageBranching, MaxChildren = %v, %d; want 3.5, 4", stats.AverageBranching, stats.MaxChildren)
+	}
+	if stats.AdmittedNodes != 1 {
+		t.Errorf("AdmittedNodes = %d, want 1", stats.AdmittedNodes)
+	}
+	if stats.LongestDependencyChain != 2 {
+		t.Errorf("LongestDependencyChain = %d, want 2", stats.LongestDependencyChain)
+	}
+
+	total := 0
+	for _, count := range stats.TaintCounts {
+		total += count
+	}
+	if len(stats.TaintCounts) != 4 || total != stats.TotalNodes {
+		t.Errorf("TaintCounts = %v, want all four states summing to %d", stats.TaintCounts, stats.TotalNodes)
+	}
+}
+
+func TestComputeProofStats_DependencyCycle(t *testing.T) {
+	st := state.NewState()
+	add := func(id string, deps ...string) {
+		t.Helper()
+		n, err := node.NewNode(mustParseID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, dep := range deps {
+			n.Dependencies = append(n.Dependencies, mustParseID(t, dep))
+		}
+		st.AddNode(n)
+	}
+
+	// 1.1 -> 1.2 -> 1.3 -> 1.1 is a cycle; 1.4 depends on itself
+	add("1")
+	add("1.1", "1.2")
+	add("1.2", "1.3")
+	add("1.3", "1.1")
+	add("1.4", "1.4")
+	st.GetNode(mustParseID(t, "1.4")).TaintState = node.TaintTainted
+
+	stats := computeProofStats(st)
+	if stats.LongestDependencyChain != 2 {
+		t.Errorf("LongestDependencyChain = %d, want 2", stats.LongestDependencyChain)
+	}
+	if got := stats.TaintCounts[string(node.TaintTainted)]; got != 1 {
+		t.Errorf("tainted count = %d, want 1", got)
+	}
+}
+
+func TestStats_EmptyChain(t *testing.T) {
+	svc := newChallengeTestService(t)
+
+	stats, err := svc.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.LongestDependencyChain != 0 || stats.AverageBranching != 0 {
+		t.Errorf("chain, branching = %d, %v; want 0, 0", stats.LongestDependencyChain, stats.AverageBranching)
+	}
+	if stats.Leaves != stats.TotalNodes {
+		t.Errorf("Leaves = %d, want %d", stats.Leaves, stats.TotalNodes)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// TagNode attaches a free-form tag (e.g. "needs-expert") to a node so agents
+// can coordinate around it. Tags carry no proof semantics: any agent may tag
+// any node, claimed or not. Tagging a node that already carries tag is a no-op.
+//
+// Returns ErrEmptyInput if tag is empty, or an error if it contains whitespace.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) TagNode(nodeID types.NodeID, tag string) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	if n.HasTag(tag) {
+		return nil
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appendIfSequence(ldg, ledger.NewNodeTagged(nodeID, tag), expectedSeq)
+	return wrapSequenceMismatch(err, "TagNode")
+}
+
+// UntagNode removes a tag from a node. Removing a tag the node does not
+// carry is a no-op.
+//
+// Returns ErrEmptyInput if tag is empty, or an error if it contains whitespace.
+// Returns ErrNodeNotFound if nodeID doesn't exist.
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) UntagNode(nodeID types.NodeID, tag string) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return err
+	}
+	expectedSeq := st.LatestSeq()
+
+	n := st.GetNode(nodeID)
+	if n == nil {
+		return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID.String())
+	}
+	if !n.HasTag(tag) {
+		return nil
+	}
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.appendIfSequence(ldg, ledger.NewNodeUntagged(nodeID, tag), expectedSeq)
+	return wrapSequenceMismatch(err, "UntagNode")
+}
+
+// ListNodesByTag returns the nodes carrying tag, sorted by ID.
+// Returns an empty slice if no node carries it.
+//
+// Returns ErrEmptyInput if tag is empty, or an error if it contains whitespace.
+func (s *ProofService) ListNodesByTag(tag string) ([]*node.Node, error) {
+	if err := validateTag(tag); err != nil {
+		return nil, err
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []*node.Node{}
+	for _, n := range st.AllNodes() {
+		if n.HasTag(tag) {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID.Less(nodes[j].ID) })
+	return nodes, nil
+}
+
+// validateTag checks tag with node.ValidateTag, reporting an empty tag as
+// ErrEmptyInput.
+func validateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("%w: tag", ErrEmptyInput)
+	}
+	return node.ValidateTag(tag)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTagNode_AddsAndRemovesTags(t *testing.T) {
+	svc := newRenumberTestService(t, "1.1", "1.2")
+
+	for _, tag := range []string{"needs-expert", "approach-A", "needs-expert"} {
+		if err := svc.TagNode(mustParseID(t, "1.1"), tag); err != nil {
+			t.Fatalf("TagNode(%q) failed: %v", tag, err)
+		}
+	}
+	if err := svc.TagNode(mustParseID(t, "1.2"), "approach-A"); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := st.GetNode(mustParseID(t, "1.1")).Tags
+	if len(tags) != 2 || tags[0] != "approach-A" || tags[1] != "needs-expert" {
+		t.Errorf("node 1.1 Tags = %v, want [approach-A needs-expert]", tags)
+	}
+
+	nodes, err := svc.ListNodesByTag("approach-A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0].ID.String() != "1.1" || nodes[1].ID.String() != "1.2" {
+		t.Errorf("ListNodesByTag(approach-A) returned %d nodes, want 1.1 and 1.2", len(nodes))
+	}
+
+	if err := svc.UntagNode(mustParseID(t, "1.1"), "approach-A"); err != nil {
+		t.Fatalf("UntagNode failed: %v", err)
+	}
+	// Removing a tag that is not there is a no-op
+	if err := svc.UntagNode(mustParseID(t, "1.1"), "approach-A"); err != nil {
+		t.Fatalf("second UntagNode failed: %v", err)
+	}
+
+	nodes, err = svc.ListNodesByTag("approach-A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].ID.String() != "1.2" {
+		t.Errorf("ListNodesByTag(approach-A) after untag returned %d nodes, want only 1.2", len(nodes))
+	}
+}
+
+func TestTagNode_Errors(t *testing.T) {
+	svc := newRenumberTestService(t)
+
+	if err := svc.TagNode(mustParseID(t, "1.9"), "todo"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("TagNode(unknown node) error = %v, want ErrNodeNotFound", err)
+	}
+	if err := svc.TagNode(mustParseID(t, "1"), ""); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("TagNode(empty tag) error = %v, want ErrEmptyInput", err)
+	}
+	if err := svc.TagNode(mustParseID(t, "1"), "needs expert"); err == nil {
+		t.Error("TagNode(tag with whitespace) succeeded, want error")
+	}
+	if _, err := svc.ListNodesByTag("a\tb"); err == nil {
+		t.Error("ListNodesByTag(tag with whitespace) succeeded, want error")
+	}
+}
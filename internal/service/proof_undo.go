@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Undo reverts the most recent operation by appending a compensating event.
+//
+// This is a logical undo: the ledger is append-only, so the original event
+// stays in history and the compensating event is recorded after it. Trailing
+// taint_recomputed events are derived state and are skipped when looking for
+// the operation to undo. The compensations are:
+//
+//   - nodes_claimed: the nodes are released
+//   - node_created: the node is archived
+//   - node_validated, node_admitted, node_refuted, node_archived: the node
+//     is reopened (returned to pending)
+//   - challenge_raised: the challenge is withdrawn
+//
+// Undoing an undo is not special: undoing a node_created leaves a
+// node_archived as the latest operation, and undoing that reopens the node.
+//
+// Returns the type of the event that was undone.
+// Returns ErrInvalidState if the latest operation is of any other type, or if
+// the proof has since moved on in a way the compensation can't cleanly
+// reverse (e.g. the claimed nodes were released, or the node gained children).
+// Returns ErrConcurrentModification if the proof was modified by another process
+// since state was loaded. Callers should retry after reloading state.
+func (s *ProofService) Undo() (ledger.EventType, error) {
+	// Load current state and capture sequence for CAS
+	st, err := s.LoadState()
+	if err != nil {
+		return "", err
+	}
+	expectedSeq := st.LatestSeq()
+
+	ldg, err := s.getLedger()
+	if err != nil {
+		return "", err
+	}
+
+	// Find the latest event that isn't derived taint bookkeeping
+	seq := expectedSeq
+	var base ledger.BaseEvent
+	for ; seq > 0; seq-- {
+		if err := ledger.ReadEventTyped(ldg.Dir(), seq, &base); err != nil {
+			return "", err
+		}
+		if base.EventType != ledger.EventTaintRecomputed {
+			break
+		}
+	}
+	if seq == 0 {
+		return "", fmt.Errorf("%w: nothing to undo", ErrInvalidState)
+	}
+
+	event, nodeID, err := s.compensatingEvent(st, ldg.Dir(), seq, base.EventType)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.appendIfSequence(ldg, event, expectedSeq); err != nil {
+		return "", wrapSequenceMismatch(err, "Undo")
+	}
+
+	// Epistemic changes need their taint recomputed
+	if event.Type() == ledger.EventNodeArchived || event.Type() == ledger.EventNodeReopened {
+		if err := s.emitTaintRecomputedEvents(ldg, st, nodeID); err != nil {
+			return "", err
+		}
+	}
+
+	return base.EventType, nil
+}
+
+// compensatingEvent reads event seq of the given type and returns the event
+// that reverses it, together with the node whose epistemic state it changes
+// (if any). Returns ErrInvalidState if the event can't be cleanly compensated.
+func (s *ProofService) compensatingEvent(st *state.State, dir string, seq int, eventType ledger.EventType) (ledger.Event, types.NodeID, error) {
+	var none types.NodeID
+
+	switch eventType {
+	case ledger.EventNodesClaimed:
+		var e ledger.NodesClaimed
+		if err := ledger.ReadEventTyped(dir, seq, &e); err != nil {
+			return nil, none, err
+		}
+		for _, id := range e.NodeIDs {
+			n := st.GetNode(id)
+			if n == nil || n.WorkflowState != schema.WorkflowClaimed || n.ClaimedBy != e.Owner {
+				return nil, none, fmt.Errorf("%w: cannot undo claim: node %s is no longer claimed by %s",
+					ErrInvalidState, id.String(), e.Owner)
+			}
+		}
+		return ledger.NewNodesReleased(e.NodeIDs), none, nil
+
+	case ledger.EventNodeCreated:
+		var e ledger.NodeCreated
+		if err := ledger.ReadEventTyped(dir, seq, &e); err != nil {
+			return nil, none, err
+		}
+		id := e.Node.ID
+		if id.IsRoot() {
+			return nil, none, fmt.Errorf("%w: cannot undo creation of the root node", ErrInvalidState)
+		}
+		n := st.GetNode(id)
+		if n == nil {
+			return nil, none, fmt.Errorf("%w: %s", ErrNodeNotFound, id.String())
+		}
+		if len(st.Subtree(id)) > 1 {
+			return nil, none, fmt.Errorf("%w: cannot undo creation of node %s: it has children", ErrInvalidState, id.String())
+		}
+		if err := schema.ValidateEpistemicTransition(n.EpistemicState, schema.EpistemicArchived); err != nil {
+			return nil, none, fmt.Errorf("%w: cannot undo creation of node %s: it is %s and cannot be archived",
+				ErrInvalidState, id.String(), n.EpistemicState)
+		}
+		return ledger.NewNodeArchived(id), id, nil
+
+	case ledger.EventNodeValidated, ledger.EventNodeAdmitted, ledger.EventNodeRefuted, ledger.EventNodeArchived:
+		// All four carry the node in a node_id field
+		var e struct {
+			NodeID types.NodeID `json:"node_id"`
+		}
+		if err := ledger.ReadEventTyped(dir, seq, &e); err != nil {
+			return nil, none, err
+		}
+		n := st.GetNode(e.NodeID)
+		if n == nil {
+			return nil, none, fmt.Errorf("%w: %s", ErrNodeNotFound, e.NodeID.String())
+		}
+		if !schema.CanRevertToPending(n.EpistemicState) {
+			return nil, none, fmt.Errorf("%w: cannot undo %s: node %s is %s",
+				ErrInvalidState, eventType, e.NodeID.String(), n.EpistemicState)
+		}
+		// Refuting or archiving supersedes open challenges, and reopening
+		// does not restore them
+		if eventType == ledger.EventNodeRefuted || eventType == ledger.EventNodeArchived {
+			for _, c := range st.GetChallengesForNode(e.NodeID) {
+				if c.Status == state.ChallengeStatusSuperseded {
+					return nil, none, fmt.Errorf("%w: cannot undo %s: node %s has superseded challenges that reopening would not restore",
+						ErrInvalidState, eventType, e.NodeID.String())
+				}
+			}
+		}
+		return ledger.NewNodeReopened(e.NodeID), e.NodeID, nil
+
+	case ledger.EventChallengeRaised:
+		var e ledger.ChallengeRaised
+		if err := ledger.ReadEventTyped(dir, seq, &e); err != nil {
+			return nil, none, err
+		}
+		c := st.GetChallenge(e.ChallengeID)
+		if c == nil || c.Status != state.ChallengeStatusOpen {
+			return nil, none, fmt.Errorf("%w: cannot undo challenge %s: it is no longer open", ErrInvalidState, e.ChallengeID)
+		}
+		return ledger.NewChallengeWithdrawn(e.ChallengeID), none, nil
+
+	default:
+		return nil, none, fmt.Errorf("%w: cannot undo %s events", ErrInvalidState, eventType)
+	}
+}
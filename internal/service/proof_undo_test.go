@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+// undoOnce calls Undo and checks that it reports the expected event type and
+// appended events rather than rewriting history.
+func undoOnce(t *testing.T, svc *ProofService, want ledger.EventType) {
+	t.Helper()
+	before, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := svc.Undo()
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("Undo undid %s, want %s", got, want)
+	}
+	after, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.LatestSeq() <= before.LatestSeq() {
+		t.Errorf("Undo did not append: seq %d -> %d", before.LatestSeq(), after.LatestSeq())
+	}
+}
+
+func TestUndo_Claim(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID := mustParseID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	undoOnce(t, svc, ledger.EventNodesClaimed)
+
+	st, _ := svc.LoadState()
+	if n := st.GetNode(rootID); n.WorkflowState != schema.WorkflowAvailable || n.ClaimedBy != "" {
+		t.Errorf("root after undo = %s claimed by %q, want available", n.WorkflowState, n.ClaimedBy)
+	}
+}
+
+func TestUndo_NodeCreated(t *testing.T) {
+	svc := newChallengeTestService(t)
+	id := mustParseID(t, "1.1")
+	if err := svc.CreateNode(id, schema.NodeTypeClaim, "Step", schema.InferenceAssumption); err != nil {
+		t.Fatal(err)
+	}
+
+	undoOnce(t, svc, ledger.EventNodeCreated)
+
+	st, _ := svc.LoadState()
+	if n := st.GetNode(id); n.EpistemicState != schema.EpistemicArchived {
+		t.Errorf("1.1 after undo = %s, want archived", n.EpistemicState)
+	}
+
+	// Undoing the undo brings the node back
+	undoOnce(t, svc, ledger.EventNodeArchived)
+
+	st, _ = svc.LoadState()
+	if n := st.GetNode(id); n.EpistemicState != schema.EpistemicPending {
+		t.Errorf("1.1 after second undo = %s, want pending", n.EpistemicState)
+	}
+}
+
+func TestUndo_EpistemicTransitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		apply     func(*ProofService) error
+		eventType ledger.EventType
+	}{
+		{"accept", func(s *ProofService) error { return s.AcceptNode(mustParseID(t, "1.1")) }, ledger.EventNodeValidated},
+		{"admit", func(s *ProofService) error { return s.AdmitNode(mustParseID(t, "1.1")) }, ledger.EventNodeAdmitted},
+		{"refute", func(s *ProofService) error { return s.RefuteNode(mustParseID(t, "1.1")) }, ledger.EventNodeRefuted},
+		{"archive", func(s *ProofService) error { return s.ArchiveNode(mustParseID(t, "1.1")) }, ledger.EventNodeArchived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newChallengeTestService(t)
+			id := mustParseID(t, "1.1")
+			if err := svc.CreateNode(id, schema.NodeTypeClaim, "Step", schema.InferenceAssumption); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.apply(svc); err != nil {
+				t.Fatal(err)
+			}
+
+			undoOnce(t, svc, tt.eventType)
+
+			st, _ := svc.LoadState()
+			n := st.GetNode(id)
+			if n.EpistemicState != schema.EpistemicPending {
+				t.Errorf("1.1 after undo = %s, want pending", n.EpistemicState)
+			}
+			if n.TaintState != "unresolved" {
+				t.Errorf("1.1 taint after undo = %s, want unresolved", n.TaintState)
+			}
+		})
+	}
+}
+
+func TestUndo_ChallengeRaised(t *testing.T) {
+	svc := newChallengeTestService(t)
+	challengeID, err := svc.RaiseChallenge(mustParseID(t, "1"), "", "Gap", "major")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	undoOnce(t, svc, ledger.EventChallengeRaised)
+
+	st, _ := svc.LoadState()
+	if c := st.GetChallenge(challengeID); c.Status != "withdrawn" {
+		t.Errorf("challenge after undo = %s, want withdrawn", c.Status)
+	}
+}
+
+func TestUndo_Refusals(t *testing.T) {
+	t.Run("proof initialized", func(t *testing.T) {
+		svc := newChallengeTestService(t)
+		if _, err := svc.Undo(); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+	})
+
+	t.Run("uncompensable event", func(t *testing.T) {
+		svc := newChallengeTestService(t)
+		rootID := mustParseID(t, "1")
+		if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.ReleaseNode(rootID, "prover"); err != nil {
+			t.Fatal(err)
+		}
+		before, _ := svc.LoadState()
+		if _, err := svc.Undo(); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+		after, _ := svc.LoadState()
+		if before.LatestSeq() != after.LatestSeq() {
+			t.Errorf("refused undo appended events: seq %d -> %d", before.LatestSeq(), after.LatestSeq())
+		}
+	})
+
+	t.Run("refute superseded a challenge", func(t *testing.T) {
+		svc := newChallengeTestService(t)
+		id := mustParseID(t, "1.1")
+		if err := svc.CreateNode(id, schema.NodeTypeClaim, "Step", schema.InferenceAssumption); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.RaiseChallenge(id, "", "Gap", "major"); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.RefuteNode(id); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.Undo(); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("error = %v, want ErrInvalidState", err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/state"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Context entry prefixes for assumptions and externals. A node's Context may
+// name an item by its bare ID or with the prefix.
+const (
+	contextAssumptionPrefix = "assume:"
+	contextExternalPrefix   = "ext:"
+)
+
+// ContextUsage lists the nodes whose Context references an assumption or
+// external. References from archived nodes are kept apart from live ones:
+// an item only archived nodes refer to no longer supports the proof.
+type ContextUsage struct {
+	Live     []types.NodeID `json:"live"`
+	Archived []types.NodeID `json:"archived,omitempty"`
+}
+
+// AssumptionUsage returns, for every assumption in the proof, the live
+// (non-archived) nodes that reference it in their Context, in node ID
+// order. Unused assumptions map to an empty list.
+// See AssumptionUsageDetail for references from archived nodes.
+func (s *ProofService) AssumptionUsage() (map[string][]types.NodeID, error) {
+	usage, err := s.AssumptionUsageDetail()
+	if err != nil {
+		return nil, err
+	}
+	return liveUsage(usage), nil
+}
+
+// ExternalUsage returns, for every external reference in the proof, the
+// live (non-archived) nodes that reference it in their Context, in node ID
+// order. Unused externals map to an empty list.
+// See ExternalUsageDetail for references from archived nodes.
+func (s *ProofService) ExternalUsage() (map[string][]types.NodeID, error) {
+	usage, err := s.ExternalUsageDetail()
+	if err != nil {
+		return nil, err
+	}
+	return liveUsage(usage), nil
+}
+
+// AssumptionUsageDetail is AssumptionUsage with references from archived
+// nodes reported separately.
+func (s *ProofService) AssumptionUsageDetail() (map[string]ContextUsage, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return assumptionUsage(st), nil
+}
+
+// ExternalUsageDetail is ExternalUsage with references from archived nodes
+// reported separately.
+func (s *ProofService) ExternalUsageDetail() (map[string]ContextUsage, error) {
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	return externalUsage(st), nil
+}
+
+// assumptionUsage returns the usage of every assumption in st.
+func assumptionUsage(st *state.State) map[string]ContextUsage {
+	ids := make([]string, 0)
+	for _, a := range st.AllAssumptions() {
+		ids = append(ids, a.ID)
+	}
+	return contextUsage(st, ids, contextAssumptionPrefix)
+}
+
+// externalUsage returns the usage of every external in st.
+func externalUsage(st *state.State) map[string]ContextUsage {
+	ids := make([]string, 0)
+	for _, ext := range st.AllExternals() {
+		ids = append(ids, ext.ID)
+	}
+	return contextUsage(st, ids, contextExternalPrefix)
+}
+
+// contextUsage returns the usage of each of ids by the nodes of st. A
+// Context entry refers to an item if it is the item's ID, with or without
+// prefix. Each node is listed at most once per item.
+func contextUsage(st *state.State, ids []string, prefix string) map[string]ContextUsage {
+	usage := make(map[string]ContextUsage, len(ids))
+	for _, id := range ids {
+		usage[id] = ContextUsage{Live: []types.NodeID{}}
+	}
+
+	for _, n := range st.AllNodesSorted() {
+		seen := make(map[string]bool)
+		for _, entry := range n.Context {
+			id := strings.TrimPrefix(strings.TrimSpace(entry), prefix)
+			u, ok := usage[id]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			if n.EpistemicState == schema.EpistemicArchived {
+				u.Archived = append(u.Archived, n.ID)
+			} else {
+				u.Live = append(u.Live, n.ID)
+			}
+			usage[id] = u
+		}
+	}
+	return usage
+}
+
+// liveUsage drops the archived references from usage.
+func liveUsage(usage map[string]ContextUsage) map[string][]types.NodeID {
+	live := make(map[string][]types.NodeID, len(usage))
+	for id, u := range usage {
+		live[id] = u.Live
+	}
+	return live
+}
+
+// sortedUsageIDs returns the item IDs of usage in sorted order.
+func sortedUsageIDs(usage map[string]ContextUsage) []string {
+	ids := make([]string, 0, len(usage))
+	for id := range usage {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
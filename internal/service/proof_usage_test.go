@@ -0,0 +1,124 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// appendContextNode appends a node under the root whose Context holds refs.
+func appendContextNode(t *testing.T, svc *ProofService, id string, refs ...string) {
+	t.Helper()
+	n, err := node.NewNodeWithOptions(mustParseID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption,
+		node.NodeOptions{Context: refs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldg, err := svc.getLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldg.Append(ledger.NewNodeCreated(*n)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAssumptionUsage(t *testing.T) {
+	svc := newRenumberTestService(t)
+	used, err := svc.AddAssumption("x is real")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unused, err := svc.AddAssumption("y is real")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archivedOnly, err := svc.AddAssumption("z is real")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bare and prefixed references both count, once per node
+	appendContextNode(t, svc, "1.1", used)
+	appendContextNode(t, svc, "1.2", "assume:"+used, used, "assume:"+archivedOnly)
+	if err := svc.ArchiveNode(mustParseID(t, "1.2")); err != nil {
+		t.Fatal(err)
+	}
+
+	live, err := svc.AssumptionUsage()
+	if err != nil {
+		t.Fatalf("AssumptionUsage failed: %v", err)
+	}
+	want := map[string][]types.NodeID{
+		used:         {mustParseID(t, "1.1")},
+		unused:       {},
+		archivedOnly: {},
+	}
+	if !reflect.DeepEqual(live, want) {
+		t.Errorf("AssumptionUsage() = %v, want %v", live, want)
+	}
+
+	detail, err := svc.AssumptionUsageDetail()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := detail[used].Archived; !equalIDs(got, []types.NodeID{mustParseID(t, "1.2")}) {
+		t.Errorf("archived references of %s = %v, want [1.2]", used, got)
+	}
+	if got := detail[archivedOnly]; len(got.Live) != 0 || !equalIDs(got.Archived, []types.NodeID{mustParseID(t, "1.2")}) {
+		t.Errorf("usage of %s = %+v, want only archived 1.2", archivedOnly, got)
+	}
+
+	// Only the unreferenced assumptions are linted, each once
+	warnings, err := svc.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, w := range warnings {
+		if w.Rule == LintUnusedAssumption {
+			messages = append(messages, w.Message)
+		}
+	}
+	if len(messages) != 2 {
+		t.Fatalf("unused_assumption warnings = %q, want 2", messages)
+	}
+	joined := strings.Join(messages, "\n")
+	if !strings.Contains(joined, unused+" is not referenced by any node") ||
+		!strings.Contains(joined, archivedOnly+" is referenced only by archived nodes (1.2)") {
+		t.Errorf("unused_assumption warnings = %q", messages)
+	}
+	if strings.Contains(joined, used) {
+		t.Errorf("assumption %s is used by 1.1 but was reported: %q", used, messages)
+	}
+}
+
+func TestExternalUsage(t *testing.T) {
+	svc := newRenumberTestService(t)
+	cited, err := svc.AddExternal("Fermat", "Wiles 1995")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncited, err := svc.AddExternal("Riemann", "unproven")
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendContextNode(t, svc, "1.1", "ext:"+cited)
+
+	usage, err := svc.ExternalUsage()
+	if err != nil {
+		t.Fatalf("ExternalUsage failed: %v", err)
+	}
+	want := map[string][]types.NodeID{
+		cited:   {mustParseID(t, "1.1")},
+		uncited: {},
+	}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("ExternalUsage() = %v, want %v", usage, want)
+	}
+}
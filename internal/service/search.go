@@ -0,0 +1,100 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+// SearchOptions controls which nodes SearchNodes returns.
+// The zero value matches the query case-insensitively against statements only.
+type SearchOptions struct {
+	// IncludeLatex also matches the query against each node's LaTeX.
+	IncludeLatex bool
+
+	// CaseSensitive disables the default case-insensitive matching.
+	CaseSensitive bool
+
+	// EpistemicState, if set, restricts results to nodes in this epistemic state.
+	EpistemicState schema.EpistemicState
+
+	// WorkflowState, if set, restricts results to nodes in this workflow state.
+	WorkflowState schema.WorkflowState
+
+	// Definition, if set, restricts results to nodes whose context references
+	// a definition containing this name (case-insensitive).
+	Definition string
+}
+
+// SearchNodes returns the nodes whose statement (and, with IncludeLatex, LaTeX)
+// contains query and that pass the filters in opts, sorted by node ID.
+// An empty query matches every node, so filters can be used on their own.
+func (s *ProofService) SearchNodes(query string, opts SearchOptions) ([]*node.Node, error) {
+	if opts.EpistemicState != "" {
+		if err := schema.ValidateEpistemicState(string(opts.EpistemicState)); err != nil {
+			return nil, err
+		}
+	}
+	if opts.WorkflowState != "" {
+		if err := schema.ValidateWorkflowState(string(opts.WorkflowState)); err != nil {
+			return nil, err
+		}
+	}
+
+	st, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*node.Node
+	for _, n := range st.AllNodes() {
+		if matchesSearch(n, query, opts) {
+			results = append(results, n)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].ID.Less(results[j].ID) })
+	return results, nil
+}
+
+// matchesSearch reports whether n matches query and all filters in opts.
+func matchesSearch(n *node.Node, query string, opts SearchOptions) bool {
+	if opts.EpistemicState != "" && n.EpistemicState != opts.EpistemicState {
+		return false
+	}
+	if opts.WorkflowState != "" && n.WorkflowState != opts.WorkflowState {
+		return false
+	}
+	if opts.Definition != "" && !referencesDefinition(n, opts.Definition) {
+		return false
+	}
+	if query == "" {
+		return true
+	}
+	if containsQuery(n.Statement, query, opts.CaseSensitive) {
+		return true
+	}
+	return opts.IncludeLatex && containsQuery(n.Latex, query, opts.CaseSensitive)
+}
+
+// containsQuery reports whether text contains query, optionally ignoring case.
+func containsQuery(text, query string, caseSensitive bool) bool {
+	if caseSensitive {
+		return strings.Contains(text, query)
+	}
+	return strings.Contains(strings.ToLower(text), strings.ToLower(query))
+}
+
+// referencesDefinition reports whether any context entry of n mentions name.
+// Context entries may be "def:name" or just "name".
+func referencesDefinition(n *node.Node, name string) bool {
+	lower := strings.ToLower(name)
+	for _, ctx := range n.Context {
+		if strings.Contains(strings.ToLower(ctx), lower) {
+			return true
+		}
+	}
+	return false
+}
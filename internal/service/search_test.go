@@ -0,0 +1,131 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// setupSearchTest creates a proof whose root is "Test conjecture" with children:
+//
+//	1.1 "Base case by induction"            (validated)
+//	1.2 "Inductive step"                    (refuted)
+//	1.3 "Apply INDUCTION hypothesis"        (pending, claimed)
+//	1.4 "Sum formula" with LaTeX \sum_{k}   (pending)
+func setupSearchTest(t *testing.T) *ProofService {
+	t.Helper()
+	svc, _ := setupTestProof(t)
+
+	for _, c := range []struct{ id, stmt string }{
+		{"1.1", "Base case by induction"},
+		{"1.2", "Inductive step"},
+		{"1.3", "Apply INDUCTION hypothesis"},
+	} {
+		if err := svc.CreateNode(parseNodeID(t, c.id), schema.NodeTypeClaim, c.stmt, schema.InferenceModusPonens); err != nil {
+			t.Fatalf("CreateNode(%s) failed: %v", c.id, err)
+		}
+	}
+
+	n, err := node.NewNodeWithOptions(parseNodeID(t, "1.4"), schema.NodeTypeClaim, "Sum formula",
+		schema.InferenceModusPonens, node.NodeOptions{Latex: `\sum_{k} k = n(n+1)/2`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldg, err := svc.getLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldg.Append(ledger.NewNodeCreated(*n)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.AcceptNode(parseNodeID(t, "1.1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RefuteNode(parseNodeID(t, "1.2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ClaimNode(parseNodeID(t, "1.3"), "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestSearchNodes(t *testing.T) {
+	svc := setupSearchTest(t)
+
+	tests := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+		want  []string
+	}{
+		{"case-insensitive by default", "induction", SearchOptions{}, []string{"1.1", "1.3"}},
+		{"case-sensitive", "induction", SearchOptions{CaseSensitive: true}, []string{"1.1"}},
+		{"prefix matches", "induct", SearchOptions{}, []string{"1.1", "1.2", "1.3"}},
+		{"epistemic filter", "induct", SearchOptions{EpistemicState: schema.EpistemicRefuted}, []string{"1.2"}},
+		{"workflow filter", "induct", SearchOptions{WorkflowState: schema.WorkflowClaimed}, []string{"1.3"}},
+		{"latex excluded by default", `\sum`, SearchOptions{}, nil},
+		{"latex included", `\sum`, SearchOptions{IncludeLatex: true}, []string{"1.4"}},
+		{"empty query with filter", "", SearchOptions{EpistemicState: schema.EpistemicValidated}, []string{"1.1"}},
+		{"no match", "convergence", SearchOptions{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := svc.SearchNodes(tt.query, tt.opts)
+			if err != nil {
+				t.Fatalf("SearchNodes() unexpected error: %v", err)
+			}
+			var got []string
+			for _, n := range nodes {
+				got = append(got, n.ID.String())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SearchNodes(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SearchNodes(%q) = %v, want %v", tt.query, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSearchNodes_InvalidFilter(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	if _, err := svc.SearchNodes("x", SearchOptions{EpistemicState: "bogus"}); err == nil {
+		t.Error("SearchNodes() expected error for invalid epistemic state")
+	}
+	if _, err := svc.SearchNodes("x", SearchOptions{WorkflowState: "bogus"}); err == nil {
+		t.Error("SearchNodes() expected error for invalid workflow state")
+	}
+}
+
+func TestSearchNodes_SortedByNodeID(t *testing.T) {
+	svc, _ := setupTestProof(t)
+	for _, id := range []string{"1.10", "1.2", "1.1"} {
+		if err := svc.CreateNode(parseNodeID(t, id), schema.NodeTypeClaim, "Step "+id, schema.InferenceModusPonens); err != nil {
+			t.Fatalf("CreateNode(%s) failed: %v", id, err)
+		}
+	}
+
+	nodes, err := svc.SearchNodes("step", SearchOptions{})
+	if err != nil {
+		t.Fatalf("SearchNodes() unexpected error: %v", err)
+	}
+	got := make([]types.NodeID, len(nodes))
+	for i, n := range nodes {
+		got[i] = n.ID
+	}
+	if ids := types.ToStringSlice(got); len(ids) != 3 || ids[0] != "1.1" || ids[1] != "1.2" || ids[2] != "1.10" {
+		t.Errorf("SearchNodes() order = %v, want [1.1 1.2 1.10]", ids)
+	}
+}
@@ -4,6 +4,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -517,6 +518,134 @@ func TestClaimNode_AlreadyClaimed(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// ClaimNodeBulk Tests
+// =============================================================================
+
+// setupSiblingLeaves creates children 1.1, 1.2 and 1.3 under the root.
+func setupSiblingLeaves(t *testing.T, svc *ProofService) []types.NodeID {
+	t.Helper()
+	var ids []types.NodeID
+	for _, s := range []string{"1.1", "1.2", "1.3"} {
+		id := parseNodeID(t, s)
+		if err := svc.CreateNode(id, schema.NodeTypeClaim, "Leaf "+s, schema.InferenceModusPonens); err != nil {
+			t.Fatalf("CreateNode(%s) failed: %v", s, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestClaimNodeBulk_Success(t *testing.T) {
+	svc, _ := setupTestProof(t)
+	ids := setupSiblingLeaves(t, svc)
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	seqBefore := st.LatestSeq()
+
+	if err := svc.ClaimNodeBulk(ids, "agent-001", 5*time.Minute); err != nil {
+		t.Fatalf("ClaimNodeBulk() unexpected error: %v", err)
+	}
+
+	st, err = svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	if got := st.LatestSeq(); got != seqBefore+1 {
+		t.Errorf("LatestSeq() = %d, want %d (a single event)", got, seqBefore+1)
+	}
+	for _, id := range ids {
+		n := st.GetNode(id)
+		if n.WorkflowState != schema.WorkflowClaimed || n.ClaimedBy != "agent-001" {
+			t.Errorf("node %s: WorkflowState = %q, ClaimedBy = %q; want claimed by agent-001", id, n.WorkflowState, n.ClaimedBy)
+		}
+	}
+}
+
+func TestClaimNodeBulk_AtomicFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		extra   string
+		prepare func(t *testing.T, svc *ProofService)
+		wantErr error
+	}{
+		{
+			name:    "missing node",
+			extra:   "1.9",
+			wantErr: ErrNodeNotFound,
+		},
+		{
+			name:  "already claimed node",
+			extra: "1.2",
+			prepare: func(t *testing.T, svc *ProofService) {
+				if err := svc.ClaimNode(parseNodeID(t, "1.2"), "agent-002", 5*time.Minute); err != nil {
+					t.Fatalf("ClaimNode() failed: %v", err)
+				}
+			},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "duplicate node",
+			extra:   "1.1",
+			wantErr: ErrInvalidState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupTestProof(t)
+			setupSiblingLeaves(t, svc)
+			if tt.prepare != nil {
+				tt.prepare(t, svc)
+			}
+
+			st, err := svc.LoadState()
+			if err != nil {
+				t.Fatalf("LoadState() unexpected error: %v", err)
+			}
+			seqBefore := st.LatestSeq()
+
+			ids := []types.NodeID{parseNodeID(t, "1.1"), parseNodeID(t, "1.3"), parseNodeID(t, tt.extra)}
+			err = svc.ClaimNodeBulk(ids, "agent-001", 5*time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ClaimNodeBulk() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.extra) {
+				t.Errorf("error %q should name offending node %s", err, tt.extra)
+			}
+
+			st, err = svc.LoadState()
+			if err != nil {
+				t.Fatalf("LoadState() unexpected error: %v", err)
+			}
+			if got := st.LatestSeq(); got != seqBefore {
+				t.Errorf("LatestSeq() = %d, want %d (ledger untouched)", got, seqBefore)
+			}
+			if n := st.GetNode(parseNodeID(t, "1.1")); n.WorkflowState != schema.WorkflowAvailable {
+				t.Errorf("node 1.1 WorkflowState = %q, want %q", n.WorkflowState, schema.WorkflowAvailable)
+			}
+		})
+	}
+}
+
+func TestClaimNodeBulk_InvalidInput(t *testing.T) {
+	svc, _ := setupTestProof(t)
+	rootID := parseNodeID(t, "1")
+
+	if err := svc.ClaimNodeBulk(nil, "agent-001", 5*time.Minute); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("ClaimNodeBulk(nil) error = %v, want ErrEmptyInput", err)
+	}
+	if err := svc.ClaimNodeBulk([]types.NodeID{rootID}, " ", 5*time.Minute); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("ClaimNodeBulk() with empty owner error = %v, want ErrEmptyInput", err)
+	}
+	if err := svc.ClaimNodeBulk([]types.NodeID{rootID}, "agent-001", 0); !errors.Is(err, ErrInvalidTimeout) {
+		t.Errorf("ClaimNodeBulk() with zero timeout error = %v, want ErrInvalidTimeout", err)
+	}
+}
+
 // =============================================================================
 // RefreshClaim Tests
 // =============================================================================
@@ -607,6 +736,90 @@ func TestRefreshClaim_WrongOwner(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// ReassignClaim Tests
+// =============================================================================
+
+func TestReassignClaim_Success(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	rootID := parseNodeID(t, "1")
+
+	if err := svc.ClaimNode(rootID, "agent-001", 5*time.Minute); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	seqBefore := st.LatestSeq()
+
+	if err := svc.ReassignClaim(rootID, "agent-001", "agent-002", 10*time.Minute); err != nil {
+		t.Fatalf("ReassignClaim() unexpected error: %v", err)
+	}
+
+	st, err = svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	if got := st.LatestSeq(); got != seqBefore+1 {
+		t.Errorf("LatestSeq() = %d, want %d (single event)", got, seqBefore+1)
+	}
+	n := st.GetNode(rootID)
+	if n.WorkflowState != schema.WorkflowClaimed {
+		t.Errorf("WorkflowState = %q, want %q", n.WorkflowState, schema.WorkflowClaimed)
+	}
+	if n.ClaimedBy != "agent-002" {
+		t.Errorf("ClaimedBy = %q, want %q", n.ClaimedBy, "agent-002")
+	}
+	if d := n.ClaimTimeout - 10*time.Minute; d < -time.Second || d > time.Second {
+		t.Errorf("ClaimTimeout = %v, want about %v", n.ClaimTimeout, 10*time.Minute)
+	}
+
+	// The previous owner no longer holds the node
+	if err := svc.ReleaseNode(rootID, "agent-001"); !errors.Is(err, ErrOwnerMismatch) {
+		t.Errorf("ReleaseNode() by previous owner error = %v, want ErrOwnerMismatch", err)
+	}
+}
+
+func TestReassignClaim_Errors(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	rootID := parseNodeID(t, "1")
+
+	if err := svc.ReassignClaim(rootID, "agent-001", "agent-002", 5*time.Minute); !errors.Is(err, ErrNotClaimed) {
+		t.Errorf("ReassignClaim() on unclaimed node error = %v, want ErrNotClaimed", err)
+	}
+
+	if err := svc.ClaimNode(rootID, "agent-001", 5*time.Minute); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		id      types.NodeID
+		from    string
+		to      string
+		timeout time.Duration
+		want    error
+	}{
+		{"empty from owner", rootID, " ", "agent-002", 5 * time.Minute, ErrEmptyInput},
+		{"empty to owner", rootID, "agent-001", "", 5 * time.Minute, ErrEmptyInput},
+		{"same owner", rootID, "agent-001", "agent-001", 5 * time.Minute, ErrInvalidState},
+		{"zero timeout", rootID, "agent-001", "agent-002", 0, ErrInvalidTimeout},
+		{"node not found", parseNodeID(t, "1.99"), "agent-001", "agent-002", 5 * time.Minute, ErrNodeNotFound},
+		{"wrong from owner", rootID, "agent-003", "agent-002", 5 * time.Minute, ErrOwnerMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.ReassignClaim(tt.id, tt.from, tt.to, tt.timeout); !errors.Is(err, tt.want) {
+				t.Errorf("ReassignClaim() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // ReleaseNode Tests
 // =============================================================================
@@ -1129,6 +1342,96 @@ func TestRefineNodeBulk_EmptyStatement(t *testing.T) {
 	}
 }
 
+func TestRefineNodeBulkWithDeps_Success(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	rootID := parseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "agent-001", 5*time.Minute); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+	if _, err := svc.RefineNodeBulk(rootID, "agent-001", []ChildSpec{
+		{NodeType: schema.NodeTypeClaim, Statement: "Existing step", Inference: schema.InferenceAssumption},
+	}); err != nil {
+		t.Fatalf("RefineNodeBulk() unexpected error: %v", err)
+	}
+
+	children := []ChildSpecWithDeps{
+		{ChildSpec: ChildSpec{NodeType: schema.NodeTypeClaim, Statement: "Lemma", Inference: schema.InferenceAssumption}},
+		{ChildSpec: ChildSpec{NodeType: schema.NodeTypeClaim, Statement: "By the lemma", Inference: schema.InferenceModusPonens},
+			Dependencies: []types.NodeID{parseNodeID(t, "1.1")}, BatchDeps: []int{0}},
+		{ChildSpec: ChildSpec{NodeType: schema.NodeTypeClaim, Statement: "Combining", Inference: schema.InferenceModusPonens},
+			BatchDeps: []int{0, 1}},
+	}
+
+	childIDs, err := svc.RefineNodeBulkWithDeps(rootID, "agent-001", children)
+	if err != nil {
+		t.Fatalf("RefineNodeBulkWithDeps() unexpected error: %v", err)
+	}
+	if got := types.ToStringSlice(childIDs); strings.Join(got, ",") != "1.2,1.3,1.4" {
+		t.Fatalf("RefineNodeBulkWithDeps() = %v, want [1.2 1.3 1.4]", got)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	want := map[string]string{"1.2": "", "1.3": "1.1,1.2", "1.4": "1.2,1.3"}
+	for id, deps := range want {
+		n := st.GetNode(parseNodeID(t, id))
+		if n == nil {
+			t.Fatalf("child %s not found", id)
+		}
+		if got := strings.Join(types.ToStringSlice(n.Dependencies), ","); got != deps {
+			t.Errorf("dependencies of %s = %q, want %q", id, got, deps)
+		}
+	}
+}
+
+func TestRefineNodeBulkWithDeps_InvalidDeps(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	rootID := parseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "agent-001", 5*time.Minute); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+
+	spec := func(deps []types.NodeID, batchDeps []int) ChildSpecWithDeps {
+		return ChildSpecWithDeps{
+			ChildSpec:    ChildSpec{NodeType: schema.NodeTypeClaim, Statement: "Step", Inference: schema.InferenceAssumption},
+			Dependencies: deps,
+			BatchDeps:    batchDeps,
+		}
+	}
+	tests := []struct {
+		name     string
+		children []ChildSpecWithDeps
+		want     string
+	}{
+		{"missing node", []ChildSpecWithDeps{spec([]types.NodeID{parseNodeID(t, "1.9")}, nil)}, "not found"},
+		{"self reference", []ChildSpecWithDeps{spec(nil, []int{0})}, "invalid batch dependency"},
+		{"later child", []ChildSpecWithDeps{spec(nil, []int{1}), spec(nil, nil)}, "invalid batch dependency"},
+		{"negative index", []ChildSpecWithDeps{spec(nil, nil), spec(nil, []int{-1})}, "invalid batch dependency"},
+		{"parent", []ChildSpecWithDeps{spec([]types.NodeID{rootID}, nil)}, "circular"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.RefineNodeBulkWithDeps(rootID, "agent-001", tt.children)
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), tt.want) {
+				t.Errorf("RefineNodeBulkWithDeps() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	// Nothing was written
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	if st.GetNode(parseNodeID(t, "1.1")) != nil {
+		t.Error("a failed RefineNodeBulkWithDeps() created children")
+	}
+}
+
 // =============================================================================
 // AcceptNode Tests
 // =============================================================================
@@ -1650,6 +1953,12 @@ func TestStatus_InitializedProof(t *testing.T) {
 	if status.PendingNodes != 1 {
 		t.Errorf("Status.PendingNodes = %d, want 1", status.PendingNodes)
 	}
+	if status.CompletionPercent != 0 {
+		t.Errorf("Status.CompletionPercent = %v, want 0", status.CompletionPercent)
+	}
+	if status.RootProven {
+		t.Error("Status.RootProven should be false while the root is pending")
+	}
 }
 
 func TestStatus_WithClaimedNode(t *testing.T) {
@@ -1691,6 +2000,55 @@ func TestStatus_WithValidatedNode(t *testing.T) {
 	if status.PendingNodes != 0 {
 		t.Errorf("Status.PendingNodes = %d, want 0", status.PendingNodes)
 	}
+	if status.CompletionPercent != 100 {
+		t.Errorf("Status.CompletionPercent = %v, want 100", status.CompletionPercent)
+	}
+	if !status.RootProven {
+		t.Error("Status.RootProven should be true once the root is validated")
+	}
+}
+
+func TestStatus_OpenChallenges(t *testing.T) {
+	svc, _ := setupTestProof(t)
+	rootID := parseNodeID(t, "1")
+
+	raise := func(severity string) string {
+		t.Helper()
+		id, err := svc.RaiseChallenge(rootID, "statement", "issue", severity)
+		if err != nil {
+			t.Fatalf("RaiseChallenge(%s) unexpected error: %v", severity, err)
+		}
+		return id
+	}
+	raise("critical")
+	raise("minor")
+	raise("note")
+	resolved := raise("major")
+	withdrawn := raise("critical")
+	if err := svc.ResolveChallenge(resolved); err != nil {
+		t.Fatalf("ResolveChallenge() unexpected error: %v", err)
+	}
+	if err := svc.WithdrawChallenge(withdrawn); err != nil {
+		t.Fatalf("WithdrawChallenge() unexpected error: %v", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		t.Fatalf("Status() unexpected error: %v", err)
+	}
+
+	want := map[string]int{"critical": 1, "minor": 1, "note": 1}
+	if len(status.OpenChallenges) != len(want) {
+		t.Errorf("Status.OpenChallenges = %v, want %v", status.OpenChallenges, want)
+	}
+	for severity, n := range want {
+		if status.OpenChallenges[severity] != n {
+			t.Errorf("Status.OpenChallenges[%s] = %d, want %d", severity, status.OpenChallenges[severity], n)
+		}
+	}
+	if status.BlockedNodes != 1 {
+		t.Errorf("Status.BlockedNodes = %d, want 1", status.BlockedNodes)
+	}
 }
 
 func TestStatus_UninitializedProof(t *testing.T) {
@@ -1925,7 +2283,7 @@ func TestWrapSequenceMismatch_MatchingError(t *testing.T) {
 
 func TestFormatBlockingChallengesError_EmptyList(t *testing.T) {
 	// Test with empty challenges
-	err := formatBlockingChallengesError(parseNodeID(&testing.T{}, "1"), nil)
+	err := formatBlockingChallengesError(parseNodeID(&testing.T{}, "1"), nil, nil)
 	if err != nil {
 		t.Errorf("formatBlockingChallengesError() with empty list should return nil, got %v", err)
 	}
@@ -1997,6 +2355,90 @@ func TestErrCircularDependency_IsError(t *testing.T) {
 	}
 }
 
+func TestSentinelErrors_Codes(t *testing.T) {
+	tests := []struct {
+		err      error
+		code     string
+		exitCode int
+	}{
+		{ErrConcurrentModification, "CONCURRENT_MODIFICATION", 4},
+		{ErrBlockingChallenges, "BLOCKING_CHALLENGES", 5},
+		{ErrMaxDepthExceeded, "DEPTH_EXCEEDED", 6},
+		{ErrNodeNotFound, "NODE_NOT_FOUND", 3},
+		{ErrLemmaNotFound, "LEMMA_NOT_FOUND", 3},
+		{ErrNoAvailableNodes, "NO_AVAILABLE_NODES", 4},
+		{ErrClaimNotExpired, "CLAIM_NOT_EXPIRED", 4},
+		{ErrReadOnly, "READ_ONLY", 6},
+		{ErrCheckpointNotFound, "CHECKPOINT_NOT_FOUND", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			wrapped := fmt.Errorf("%w: node 1.2", tt.err)
+			if got := ErrorCodeName(wrapped); got != tt.code {
+				t.Errorf("ErrorCodeName() = %q, want %q", got, tt.code)
+			}
+			if got := ExitCode(wrapped); got != tt.exitCode {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.exitCode)
+			}
+			if !errors.Is(wrapped, tt.err) {
+				t.Errorf("errors.Is(wrapped, sentinel) = false, want true")
+			}
+		})
+	}
+}
+
+// TestSentinelErrors_ExitCodes asserts the documented exit code of every
+// exported sentinel error, so scripts can rely on $? to tell them apart.
+func TestSentinelErrors_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+	}{
+		{"ErrNodeNotFound", ErrNodeNotFound, ExitNotFound},
+		{"ErrChallengeNotFound", ErrChallengeNotFound, ExitNotFound},
+		{"ErrParentNotFound", ErrParentNotFound, ExitNotFound},
+		{"ErrLemmaNotFound", ErrLemmaNotFound, ExitNotFound},
+		{"ErrCheckpointNotFound", ErrCheckpointNotFound, ExitNotFound},
+
+		{"ErrConcurrentModification", ErrConcurrentModification, ExitConflict},
+		{"ErrNotClaimed", ErrNotClaimed, ExitConflict},
+		{"ErrOwnerMismatch", ErrOwnerMismatch, ExitConflict},
+		{"ErrClaimNotExpired", ErrClaimNotExpired, ExitConflict},
+		{"ErrNoAvailableNodes", ErrNoAvailableNodes, ExitConflict},
+
+		{"ErrBlockingChallenges", ErrBlockingChallenges, ExitBlocked},
+		{"ErrUnvalidatedDependencies", ErrUnvalidatedDependencies, ExitBlocked},
+
+		{"ErrMaxDepthExceeded", ErrMaxDepthExceeded, ExitValidation},
+		{"ErrMaxChildrenExceeded", ErrMaxChildrenExceeded, ExitValidation},
+		{"ErrEmptyInput", ErrEmptyInput, ExitValidation},
+		{"ErrInvalidState", ErrInvalidState, ExitValidation},
+		{"ErrReadOnly", ErrReadOnly, ExitValidation},
+		{"ErrAlreadyExists", ErrAlreadyExists, ExitValidation},
+		{"ErrInvalidTimeout", ErrInvalidTimeout, ExitValidation},
+		{"ErrCircularDependency", ErrCircularDependency, ExitValidation},
+
+		{"unknown error", errors.New("unknown"), ExitGeneric},
+		{"nil", nil, ExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.exitCode {
+				t.Errorf("ExitCode(%s) = %d, want %d", tt.name, got, tt.exitCode)
+			}
+			if tt.err == nil {
+				return
+			}
+			if got := ExitCode(fmt.Errorf("context: %w", tt.err)); got != tt.exitCode {
+				t.Errorf("ExitCode(wrapped %s) = %d, want %d", tt.name, got, tt.exitCode)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // stateDependencyProvider Tests
 // =============================================================================
@@ -2643,3 +3085,85 @@ func TestRequestRefinement_InvalidState(t *testing.T) {
 		t.Error("RequestRefinement() expected error for pending node, got nil")
 	}
 }
+
+// =============================================================================
+// Snapshot Tests
+// =============================================================================
+
+func TestSnapshot_LoadStateUsesSnapshot(t *testing.T) {
+	svc, _ := setupTestProof(t)
+
+	seq, err := svc.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() unexpected error: %v", err)
+	}
+
+	// Mutate after the snapshot so the tail must be replayed
+	rootID := parseNodeID(t, "1")
+	if err := svc.ClaimNode(rootID, "prover", time.Hour); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	if st.LatestSeq() != seq+1 {
+		t.Errorf("LatestSeq() = %d, want %d", st.LatestSeq(), seq+1)
+	}
+	n := st.GetNode(rootID)
+	if n == nil {
+		t.Fatal("Node not found")
+	}
+	if n.WorkflowState != schema.WorkflowClaimed {
+		t.Errorf("WorkflowState = %q, want %q", n.WorkflowState, schema.WorkflowClaimed)
+	}
+}
+
+// =============================================================================
+// ReapExpiredClaims Tests
+// =============================================================================
+
+func TestReapExpiredClaims(t *testing.T) {
+	svc, _ := setupTestProof(t)
+	ids := setupSiblingLeaves(t, svc)
+
+	// 1.1 and 1.3 get claims that expire almost immediately; 1.2 stays claimed
+	if err := svc.ClaimNode(ids[0], "crashed-agent", time.Millisecond); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+	if err := svc.ClaimNode(ids[1], "live-agent", time.Hour); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+	if err := svc.ClaimNode(ids[2], "crashed-agent", time.Millisecond); err != nil {
+		t.Fatalf("ClaimNode() unexpected error: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	reaped, err := svc.ReapExpiredClaims()
+	if err != nil {
+		t.Fatalf("ReapExpiredClaims() unexpected error: %v", err)
+	}
+	if got := types.ToStringSlice(reaped); len(got) != 2 || got[0] != "1.1" || got[1] != "1.3" {
+		t.Errorf("ReapExpiredClaims() = %v, want [1.1 1.3]", got)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatalf("LoadState() unexpected error: %v", err)
+	}
+	for i, want := range []schema.WorkflowState{schema.WorkflowAvailable, schema.WorkflowClaimed, schema.WorkflowAvailable} {
+		if got := st.GetNode(ids[i]).WorkflowState; got != want {
+			t.Errorf("node %s WorkflowState = %q, want %q", ids[i], got, want)
+		}
+	}
+
+	// Nothing left to reap
+	reaped, err = svc.ReapExpiredClaims()
+	if err != nil {
+		t.Fatalf("ReapExpiredClaims() unexpected error: %v", err)
+	}
+	if len(reaped) != 0 {
+		t.Errorf("ReapExpiredClaims() = %v, want none", reaped)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/state"
+)
+
+// stateCache holds the ledger-derived state computed by the last LoadState,
+// so that later calls only need to replay the events appended since.
+type stateCache struct {
+	st *state.State
+
+	// boundaryHash is the hash of event st.LatestSeq() when it was cached,
+	// used to detect a ledger that was rewritten up to or past that point.
+	boundaryHash string
+}
+
+// replayState returns the current ledger-derived state, using the cache when
+// possible. The returned state is never the cached instance itself, so
+// callers are free to modify it.
+//
+// When the cache matches the ledger, it is brought up to date by replaying
+// only the events after the cached sequence. The cache is discarded and the
+// state fully replayed if the ledger has gaps, has fewer events than the
+// cached sequence (it was rewound), or the event at the cached sequence has
+// changed.
+func (s *ProofService) replayState(ldg *ledger.Ledger) (*state.State, error) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if s.cache != nil {
+		if s.refreshCache(ldg) {
+			return s.cache.st.Clone()
+		}
+		s.cache = nil
+	}
+
+	st, err := state.ReplayWithSnapshot(ldg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Caching is best-effort: if the state can't be copied or its boundary
+	// can't be read, just serve this replay uncached
+	cached, err := st.Clone()
+	if err != nil {
+		return st, nil
+	}
+	hash, err := boundaryHash(ldg, cached.LatestSeq())
+	if err != nil {
+		return st, nil
+	}
+	s.cache = &stateCache{st: cached, boundaryHash: hash}
+	return st, nil
+}
+
+// refreshCache brings the cached state up to date with the ledger.
+// Returns false if the cache no longer matches the ledger and must be
+// discarded. Must be called with cacheMu held.
+func (s *ProofService) refreshCache(ldg *ledger.Ledger) bool {
+	seq := s.cache.st.LatestSeq()
+
+	if hasGaps, err := ledger.HasGaps(ldg.Dir()); err != nil || hasGaps {
+		return false
+	}
+	count, err := ldg.Count()
+	if err != nil || count < seq {
+		return false
+	}
+	if hash, err := boundaryHash(ldg, seq); err != nil || hash != s.cache.boundaryHash {
+		return false
+	}
+	if count == seq {
+		return true
+	}
+
+	// A failed delta may leave the cached state half-updated
+	if err := state.ReplayAfter(ldg, s.cache.st); err != nil {
+		return false
+	}
+	hash, err := boundaryHash(ldg, s.cache.st.LatestSeq())
+	if err != nil {
+		return false
+	}
+	s.cache.boundaryHash = hash
+	return true
+}
+
+// invalidateStateCache discards the cached state. It is called whenever the
+// service appends to the ledger.
+func (s *ProofService) invalidateStateCache() {
+	s.cacheMu.Lock()
+	s.cache = nil
+	s.cacheMu.Unlock()
+}
+
+// boundaryHash returns the hash of event seq, or "" for an empty ledger.
+func boundaryHash(ldg *ledger.Ledger, seq int) (string, error) {
+	if seq == 0 {
+		return "", nil
+	}
+	data, err := ledger.ReadEvent(ldg.Dir(), seq)
+	if err != nil {
+		return "", err
+	}
+	return ledger.HashEvent(data), nil
+}
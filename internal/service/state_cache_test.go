@@ -0,0 +1,137 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestLoadState_CacheReturnsIndependentCopies(t *testing.T) {
+	svc := newChallengeTestService(t)
+	rootID := mustParseID(t, "1")
+
+	first, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.GetNode(rootID).Statement = "Mutated by caller"
+
+	second, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.GetNode(rootID).Statement != "Test conjecture" {
+		t.Errorf("statement = %q, caller mutation leaked into the cache", second.GetNode(rootID).Statement)
+	}
+	if svc.cache == nil {
+		t.Error("LoadState did not populate the cache")
+	}
+}
+
+func TestLoadState_CacheReplaysExternalAppends(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if _, err := svc.LoadState(); err != nil {
+		t.Fatal(err)
+	}
+	cached := svc.cache.st
+
+	// Another process appends to the ledger
+	other, err := NewProofService(svc.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.ClaimNode(mustParseID(t, "1"), "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := st.GetNode(mustParseID(t, "1")); n.WorkflowState != schema.WorkflowClaimed {
+		t.Errorf("root workflow = %s, want claimed", n.WorkflowState)
+	}
+	if svc.cache.st != cached {
+		t.Error("cache was rebuilt instead of replaying the new events")
+	}
+	if svc.cache.st.LatestSeq() != st.LatestSeq() {
+		t.Errorf("cached seq = %d, want %d", svc.cache.st.LatestSeq(), st.LatestSeq())
+	}
+}
+
+func TestLoadState_CacheDetectsRewrittenLedger(t *testing.T) {
+	t.Run("rewound", func(t *testing.T) {
+		svc := newRenumberTestService(t, "1.1")
+		before, err := svc.LoadState()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ldg, _ := svc.getLedger()
+		if err := os.Remove(filepath.Join(ldg.Dir(), ledger.GenerateFilename(before.LatestSeq()))); err != nil {
+			t.Fatal(err)
+		}
+
+		after, err := svc.LoadState()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after.LatestSeq() != before.LatestSeq()-1 {
+			t.Errorf("LatestSeq = %d, want %d", after.LatestSeq(), before.LatestSeq()-1)
+		}
+		if n := after.GetNode(mustParseID(t, "1")); n.WorkflowState == schema.WorkflowClaimed {
+			t.Error("state still reflects the removed claim event")
+		}
+	})
+
+	t.Run("last event replaced", func(t *testing.T) {
+		svc := newChallengeTestService(t)
+		before, err := svc.LoadState()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seq := before.LatestSeq()
+
+		// Replace the last event with a different one at the same sequence
+		ldg, _ := svc.getLedger()
+		if err := os.Remove(filepath.Join(ldg.Dir(), ledger.GenerateFilename(seq))); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ldg.Append(ledger.NewNodesClaimed([]types.NodeID{}, "prover", types.Now())); err != nil {
+			t.Fatal(err)
+		}
+
+		after, err := svc.LoadState()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after.GetNode(mustParseID(t, "1")) != nil {
+			t.Error("state still reflects the replaced node_created event")
+		}
+	})
+}
+
+func TestLoadState_CacheInvalidatedByAppend(t *testing.T) {
+	svc := newChallengeTestService(t)
+	if _, err := svc.LoadState(); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ClaimNode(mustParseID(t, "1"), "prover", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if svc.cache != nil {
+		t.Error("cache survived an append by the service")
+	}
+
+	st, err := svc.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := st.GetNode(mustParseID(t, "1")); n.ClaimedBy != "prover" {
+		t.Errorf("ClaimedBy = %q, want prover", n.ClaimedBy)
+	}
+}
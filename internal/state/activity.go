@@ -0,0 +1,123 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// AgentActivity summarizes what one agent has done in a proof.
+type AgentActivity struct {
+	Agent string
+
+	Claims           int // Nodes claimed, including nodes reassigned to the agent
+	Refinements      int // Nodes created under a parent the agent had claimed
+	ChallengesRaised int // Challenges the agent raised
+	Acceptances      int // Nodes validated while the agent held their claim
+
+	FirstAction types.Timestamp
+	LastAction  types.Timestamp
+}
+
+// Total returns the number of actions counted for the agent.
+func (a *AgentActivity) Total() int {
+	return a.Claims + a.Refinements + a.ChallengesRaised + a.Acceptances
+}
+
+// Activity scans the ledger and aggregates actions per agent.
+//
+// Agents are identified by claim owners and challenge authors. Refinements
+// and acceptances carry no agent of their own, so they are attributed to
+// whoever held the claim on the parent (for refinements) or on the node
+// itself (for acceptances) when the event was recorded. Events that cannot
+// be attributed to an agent are not counted.
+//
+// The result is sorted by total activity, most active first, with ties
+// broken by agent name.
+func Activity(ldg *ledger.Ledger) ([]*AgentActivity, error) {
+	if ldg == nil {
+		return nil, fmt.Errorf("cannot compute activity from nil ledger")
+	}
+
+	agents := make(map[string]*AgentActivity)
+	record := func(agent string, at types.Timestamp) *AgentActivity {
+		a, ok := agents[agent]
+		if !ok {
+			a = &AgentActivity{Agent: agent, FirstAction: at, LastAction: at}
+			agents[agent] = a
+		}
+		if at.Before(a.FirstAction) {
+			a.FirstAction = at
+		}
+		if at.After(a.LastAction) {
+			a.LastAction = at
+		}
+		return a
+	}
+
+	// Current claim owner by node ID, as of the event being scanned
+	owners := make(map[string]string)
+
+	err := ldg.Scan(func(seq int, data []byte) error {
+		event, err := parseEvent(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse event %d: %w", seq, err)
+		}
+		at := event.Timestamp()
+
+		switch e := event.(type) {
+		case ledger.NodesClaimed:
+			for _, id := range e.NodeIDs {
+				owners[id.String()] = e.Owner
+			}
+			if e.Owner != "" {
+				record(e.Owner, at).Claims += len(e.NodeIDs)
+			}
+		case ledger.NodesReassigned:
+			for _, id := range e.NodeIDs {
+				owners[id.String()] = e.ToOwner
+			}
+			if e.ToOwner != "" {
+				record(e.ToOwner, at).Claims += len(e.NodeIDs)
+			}
+		case ledger.NodesReleased:
+			for _, id := range e.NodeIDs {
+				delete(owners, id.String())
+			}
+		case ledger.LockReaped:
+			delete(owners, e.NodeID.String())
+		case ledger.NodeCreated:
+			if parent, ok := e.Node.ID.Parent(); ok {
+				if owner := owners[parent.String()]; owner != "" {
+					record(owner, at).Refinements++
+				}
+			}
+		case ledger.ChallengeRaised:
+			if e.RaisedBy != "" {
+				record(e.RaisedBy, at).ChallengesRaised++
+			}
+		case ledger.NodeValidated:
+			if owner := owners[e.NodeID.String()]; owner != "" {
+				record(owner, at).Acceptances++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*AgentActivity, 0, len(agents))
+	for _, a := range agents {
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if ti, tj := result[i].Total(), result[j].Total(); ti != tj {
+			return ti > tj
+		}
+		return result[i].Agent < result[j].Agent
+	})
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestActivity(t *testing.T) {
+	ldg, err := ledger.NewLedger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2025, 1, 11, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) types.Timestamp {
+		return types.FromTime(base.Add(time.Duration(minutes) * time.Minute))
+	}
+	newNode := func(id string) ledger.NodeCreated {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Step "+id, schema.InferenceModusPonens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ledger.NewNodeCreated(*n)
+	}
+	id1, id11 := mustParseNodeID(t, "1"), mustParseNodeID(t, "1.1")
+
+	root := newNode("1")
+	claimRoot := ledger.NewNodesClaimed([]types.NodeID{id1}, "prover", at(60))
+	claimRoot.EventTime = at(1)
+	child := newNode("1.1")
+	child.EventTime = at(2)
+	release := ledger.NewNodesReleased([]types.NodeID{id1})
+	orphan := newNode("1.2") // parent no longer claimed: not attributed
+	challenge := ledger.NewChallengeRaisedWithSeverity("ch-1", id11, "statement", "Gap", "major", "verifier")
+	challenge.EventTime = at(5)
+	claimChild := ledger.NewNodesClaimed([]types.NodeID{id11}, "verifier", at(60))
+	claimChild.EventTime = at(6)
+	validated := ledger.NewNodeValidated(id11)
+	validated.EventTime = at(9)
+	anonymous := ledger.NewChallengeRaised("ch-2", id1, "statement", "No author") // not attributed
+
+	for _, e := range []ledger.Event{
+		ledger.NewProofInitialized("Root", "author"), root, claimRoot, child, release, orphan,
+		challenge, claimChild, validated, anonymous,
+	} {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	activity, err := Activity(ldg)
+	if err != nil {
+		t.Fatalf("Activity failed: %v", err)
+	}
+	if len(activity) != 2 {
+		t.Fatalf("got %d agents, want 2: %+v", len(activity), activity)
+	}
+
+	// verifier (3 actions) sorts ahead of prover (2 actions)
+	v, p := activity[0], activity[1]
+	if v.Agent != "verifier" || v.Claims != 1 || v.ChallengesRaised != 1 || v.Acceptances != 1 || v.Refinements != 0 || v.Total() != 3 {
+		t.Errorf("verifier activity = %+v", v)
+	}
+	if !v.FirstAction.Equal(at(5)) || !v.LastAction.Equal(at(9)) {
+		t.Errorf("verifier first/last = %s/%s, want %s/%s", v.FirstAction, v.LastAction, at(5), at(9))
+	}
+	if p.Agent != "prover" || p.Claims != 1 || p.Refinements != 1 || p.Total() != 2 {
+		t.Errorf("prover activity = %+v", p)
+	}
+	if !p.FirstAction.Equal(at(1)) || !p.LastAction.Equal(at(2)) {
+		t.Errorf("prover first/last = %s/%s, want %s/%s", p.FirstAction, p.LastAction, at(1), at(2))
+	}
+}
+
+func TestActivity_NilLedger(t *testing.T) {
+	if _, err := Activity(nil); err == nil {
+		t.Error("Activity(nil) should return an error")
+	}
+}
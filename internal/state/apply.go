@@ -36,6 +36,8 @@ func Apply(s *State, event ledger.Event) error {
 		return applyNodesClaimed(s, e)
 	case ledger.ClaimRefreshed:
 		return applyClaimRefreshed(s, e)
+	case ledger.NodesReassigned:
+		return applyNodesReassigned(s, e)
 	case ledger.NodesReleased:
 		return applyNodesReleased(s, e)
 	case ledger.NodeValidated:
@@ -52,6 +54,8 @@ func Apply(s *State, event ledger.Event) error {
 		return applyDefAdded(s, e)
 	case ledger.LemmaExtracted:
 		return applyLemmaExtracted(s, e)
+	case ledger.LemmaApplied:
+		return applyLemmaApplied(s, e)
 	case ledger.ChallengeRaised:
 		return applyChallengeRaised(s, e)
 	case ledger.ChallengeResolved:
@@ -60,6 +64,8 @@ func Apply(s *State, event ledger.Event) error {
 		return applyChallengeWithdrawn(s, e)
 	case ledger.ChallengeSuperseded:
 		return applyChallengeSuperseded(s, e)
+	case ledger.ChallengeEscalated:
+		return applyChallengeEscalated(s, e)
 	case ledger.NodeAmended:
 		return applyNodeAmended(s, e)
 	case ledger.ScopeOpened:
@@ -68,6 +74,16 @@ func Apply(s *State, event ledger.Event) error {
 		return applyScopeClosed(s, e)
 	case ledger.RefinementRequested:
 		return applyRefinementRequested(s, e)
+	case ledger.NodeReopened:
+		return applyNodeReopened(s, e)
+	case ledger.NodeTagged:
+		return applyNodeTagged(s, e)
+	case ledger.NodeUntagged:
+		return applyNodeUntagged(s, e)
+	case ledger.NoteAdded:
+		return applyNoteAdded(s, e)
+	case ledger.NodeLatexSet:
+		return applyNodeLatexSet(s, e)
 	default:
 		return fmt.Errorf("unknown event type: %s", event.Type())
 	}
@@ -103,11 +119,22 @@ func applyNodesClaimed(s *State, e ledger.NodesClaimed) error {
 		}
 		n.WorkflowState = schema.WorkflowClaimed
 		n.ClaimedBy = e.Owner
-		n.ClaimedAt = e.Timeout
+		setClaimTimes(n, e.Timestamp(), e.Timeout)
 	}
 	return nil
 }
 
+// setClaimTimes records when a claim was made and how long it lasts, given
+// the claim time and the absolute expiry recorded in the ledger.
+// Events without a timestamp are treated as claimed at their expiry.
+func setClaimTimes(n *node.Node, claimedAt, expires types.Timestamp) {
+	if claimedAt.IsZero() {
+		claimedAt = expires
+	}
+	n.ClaimedAt = claimedAt
+	n.ClaimTimeout = expires.Sub(claimedAt)
+}
+
 // applyClaimRefreshed handles the ClaimRefreshed event.
 // This updates the claim timeout without changing workflow state.
 func applyClaimRefreshed(s *State, e ledger.ClaimRefreshed) error {
@@ -122,8 +149,28 @@ func applyClaimRefreshed(s *State, e ledger.ClaimRefreshed) error {
 	if n.ClaimedBy != e.Owner {
 		return fmt.Errorf("node %s is claimed by %s, not %s", e.NodeID.String(), n.ClaimedBy, e.Owner)
 	}
-	// Update the timeout
-	n.ClaimedAt = e.NewTimeout
+	// Extend the claim so it expires at the new timeout
+	setClaimTimes(n, n.ClaimedAt, e.NewTimeout)
+	return nil
+}
+
+// applyNodesReassigned handles the NodesReassigned event.
+// This transfers claims to the new owner with a fresh timeout; the nodes stay claimed.
+func applyNodesReassigned(s *State, e ledger.NodesReassigned) error {
+	for _, nodeID := range e.NodeIDs {
+		n := s.GetNode(nodeID)
+		if n == nil {
+			return fmt.Errorf("node %s not found in state", nodeID.String())
+		}
+		if n.WorkflowState != schema.WorkflowClaimed {
+			return fmt.Errorf("node %s is not claimed", nodeID.String())
+		}
+		if n.ClaimedBy != e.FromOwner {
+			return fmt.Errorf("node %s is claimed by %s, not %s", nodeID.String(), n.ClaimedBy, e.FromOwner)
+		}
+		n.ClaimedBy = e.ToOwner
+		setClaimTimes(n, e.Timestamp(), e.Timeout)
+	}
 	return nil
 }
 
@@ -142,6 +189,7 @@ func applyNodesReleased(s *State, e ledger.NodesReleased) error {
 		n.WorkflowState = schema.WorkflowAvailable
 		n.ClaimedBy = ""
 		n.ClaimedAt = types.Timestamp{}
+		n.ClaimTimeout = 0
 	}
 	return nil
 }
@@ -267,6 +315,26 @@ func applyLemmaExtracted(s *State, e ledger.LemmaExtracted) error {
 	return nil
 }
 
+// applyLemmaApplied handles the LemmaApplied event.
+// This records the lemma as a justification for the node.
+// Applying a lemma the node already cites is a no-op.
+func applyLemmaApplied(s *State, e ledger.LemmaApplied) error {
+	n := s.GetNode(e.NodeID)
+	if n == nil {
+		return fmt.Errorf("node %s not found in state", e.NodeID.String())
+	}
+	if s.GetLemma(e.LemmaID) == nil {
+		return fmt.Errorf("lemma %s not found in state", e.LemmaID)
+	}
+	for _, id := range n.Lemmas {
+		if id == e.LemmaID {
+			return nil
+		}
+	}
+	n.Lemmas = append(n.Lemmas, e.LemmaID)
+	return nil
+}
+
 // applyChallengeRaised handles the ChallengeRaised event.
 // This adds a new challenge to the state with status ChallengeStatusOpen.
 func applyChallengeRaised(s *State, e ledger.ChallengeRaised) error {
@@ -333,6 +401,24 @@ func applyChallengeSuperseded(s *State, e ledger.ChallengeSuperseded) error {
 	return nil
 }
 
+// applyChallengeEscalated handles the ChallengeEscalated event.
+// This replaces the severity of an open challenge, which may change whether
+// it blocks acceptance of its node.
+func applyChallengeEscalated(s *State, e ledger.ChallengeEscalated) error {
+	c := s.GetChallenge(e.ChallengeID)
+	if c == nil {
+		return fmt.Errorf("challenge %s not found", e.ChallengeID)
+	}
+	if c.Status != ChallengeStatusOpen {
+		return fmt.Errorf("cannot change severity of challenge %s: it is %s", e.ChallengeID, c.Status)
+	}
+	if err := schema.ValidateChallengeSeverity(e.Severity); err != nil {
+		return fmt.Errorf("invalid challenge severity: %w", err)
+	}
+	c.Severity = e.Severity
+	return nil
+}
+
 // supersedeOpenChallengesForNode marks all open challenges for a specific node
 // as superseded. This is called when a node is archived or refuted, making
 // any challenges on it moot.
@@ -390,26 +476,53 @@ func recomputeTaintForNode(s *State, n *node.Node) {
 }
 
 // applyNodeAmended handles the NodeAmended event.
-// This updates a node's statement and records the amendment in history.
+// This updates the amended fields of a node and records the amendment in history.
 func applyNodeAmended(s *State, e ledger.NodeAmended) error {
 	n := s.GetNode(e.NodeID)
 	if n == nil {
 		return fmt.Errorf("node %s not found in state", e.NodeID.String())
 	}
 
+	// Events without a field list predate full amendments and only
+	// change the statement.
+	fields := e.Fields
+	if len(fields) == 0 {
+		fields = []string{ledger.AmendFieldStatement}
+	}
+
 	// Record the amendment in history
 	amendment := Amendment{
-		Timestamp:         e.EventTime,
-		PreviousStatement: e.PreviousStatement,
-		NewStatement:      e.NewStatement,
-		Owner:             e.Owner,
+		Timestamp:              e.EventTime,
+		PreviousStatement:      e.PreviousStatement,
+		NewStatement:           e.NewStatement,
+		Owner:                  e.Owner,
+		Fields:                 fields,
+		PreviousInference:      e.PreviousInference,
+		NewInference:           e.NewInference,
+		PreviousDependencies:   e.PreviousDependencies,
+		NewDependencies:        e.NewDependencies,
+		PreviousValidationDeps: e.PreviousValidationDeps,
+		NewValidationDeps:      e.NewValidationDeps,
 	}
 	s.AddAmendment(e.NodeID, amendment)
 
-	// Update the node's statement
-	n.Statement = e.NewStatement
+	// Update the amended fields of the node
+	for _, f := range fields {
+		switch f {
+		case ledger.AmendFieldStatement:
+			n.Statement = e.NewStatement
+		case ledger.AmendFieldInference:
+			n.Inference = e.NewInference
+		case ledger.AmendFieldDependencies:
+			n.Dependencies = e.NewDependencies
+		case ledger.AmendFieldValidationDeps:
+			n.ValidationDeps = e.NewValidationDeps
+		default:
+			return fmt.Errorf("node %s: unknown amended field %q", e.NodeID.String(), f)
+		}
+	}
 
-	// Recompute content hash since statement changed
+	// Recompute content hash since the node's content changed
 	n.ContentHash = n.ComputeContentHash()
 
 	return nil
@@ -442,3 +555,74 @@ func applyRefinementRequested(s *State, e ledger.RefinementRequested) error {
 	n.EpistemicState = schema.EpistemicNeedsRefinement
 	return nil
 }
+
+// applyNodeReopened handles the NodeReopened event.
+// This returns a refuted or archived node to the pending state so it can
+// be revisited, or a validated or admitted node whose acceptance is being
+// undone. Challenges superseded when the node was closed stay superseded.
+func applyNodeReopened(s *State, e ledger.NodeReopened) error {
+	n := s.GetNode(e.NodeID)
+	if n == nil {
+		return fmt.Errorf("node %s not found in state", e.NodeID.String())
+	}
+	if !schema.CanRevertToPending(n.EpistemicState) {
+		return fmt.Errorf("invalid transition for node %s: cannot reopen node in state %q", e.NodeID.String(), n.EpistemicState)
+	}
+	n.EpistemicState = schema.EpistemicPending
+
+	// Auto-trigger taint recomputation after epistemic state change
+	recomputeTaintForNode(s, n)
+
+	return nil
+}
+
+// applyNodeTagged handles the NodeTagged event.
+// Adding a tag the node already carries is a no-op.
+func applyNodeTagged(s *State, e ledger.NodeTagged) error {
+	n := s.GetNode(e.NodeID)
+	if n == nil {
+		return fmt.Errorf("node %s not found in state", e.NodeID.String())
+	}
+	n.AddTag(e.Tag)
+	return nil
+}
+
+// applyNodeUntagged handles the NodeUntagged event.
+// Removing a tag the node does not carry is a no-op.
+func applyNodeUntagged(s *State, e ledger.NodeUntagged) error {
+	n := s.GetNode(e.NodeID)
+	if n == nil {
+		return fmt.Errorf("node %s not found in state", e.NodeID.String())
+	}
+	n.RemoveTag(e.Tag)
+	return nil
+}
+
+// applyNoteAdded handles the NoteAdded event.
+// Notes are appended in ledger order and never change the node's states.
+func applyNoteAdded(s *State, e ledger.NoteAdded) error {
+	n := s.GetNode(e.NodeID)
+	if n == nil {
+		return fmt.Errorf("node %s not found in state", e.NodeID.String())
+	}
+	n.Notes = append(n.Notes, node.Note{
+		ID:      e.NoteID,
+		Author:  e.Author,
+		Text:    e.Text,
+		Created: e.EventTime,
+	})
+	return nil
+}
+
+// applyNodeLatexSet handles the NodeLatexSet event.
+// Only the LaTeX rendering changes; the content hash is recomputed since it
+// covers the LaTeX field.
+func applyNodeLatexSet(s *State, e ledger.NodeLatexSet) error {
+	n := s.GetNode(e.NodeID)
+	if n == nil {
+		return fmt.Errorf("node %s not found in state", e.NodeID.String())
+	}
+	n.Latex = e.Latex
+	n.ContentHash = n.ComputeContentHash()
+	return nil
+}
@@ -4,6 +4,7 @@ package state
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tobias/vibefeld/internal/ledger"
 	"github.com/tobias/vibefeld/internal/node"
@@ -128,6 +129,45 @@ func TestApplyNodesClaimed(t *testing.T) {
 	}
 }
 
+// TestApplyNodesClaimedRecordsClaimTimes verifies that the claim time and
+// timeout are preserved so expiry can be computed later.
+func TestApplyNodesClaimedRecordsClaimTimes(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Test claim", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("Failed to create test node: %v", err)
+	}
+	s.AddNode(n)
+
+	event := ledger.NewNodesClaimed([]types.NodeID{nodeID}, "agent-123", types.Now())
+	event.Timeout = event.Timestamp().Add(30 * time.Minute)
+
+	if err := Apply(s, event); err != nil {
+		t.Fatalf("Apply NodesClaimed failed: %v", err)
+	}
+
+	got := s.GetNode(nodeID)
+	if !got.ClaimedAt.Equal(event.Timestamp()) {
+		t.Errorf("ClaimedAt = %v, want event time %v", got.ClaimedAt, event.Timestamp())
+	}
+	if got.ClaimTimeout != 30*time.Minute {
+		t.Errorf("ClaimTimeout = %v, want %v", got.ClaimTimeout, 30*time.Minute)
+	}
+	if !got.ClaimExpiry().Equal(event.Timeout) {
+		t.Errorf("ClaimExpiry = %v, want %v", got.ClaimExpiry(), event.Timeout)
+	}
+
+	// Releasing clears the claim times
+	if err := Apply(s, ledger.NewNodesReleased([]types.NodeID{nodeID})); err != nil {
+		t.Fatalf("Apply NodesReleased failed: %v", err)
+	}
+	if !got.ClaimedAt.IsZero() || got.ClaimTimeout != 0 {
+		t.Errorf("claim times not cleared: ClaimedAt=%v ClaimTimeout=%v", got.ClaimedAt, got.ClaimTimeout)
+	}
+}
+
 // TestApplyNodesClaimedMultiple verifies that NodesClaimed event can claim multiple nodes.
 func TestApplyNodesClaimedMultiple(t *testing.T) {
 	s := NewState()
@@ -729,6 +769,41 @@ func TestApplyChallengeWithdrawn_NotFound(t *testing.T) {
 	}
 }
 
+// TestApplyChallengeEscalated verifies that escalations replace the
+// severity of an open challenge and are rejected once it is closed.
+func TestApplyChallengeEscalated(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	if err := Apply(s, ledger.NewChallengeRaisedWithSeverity("chal-001", nodeID, "statement", "Typo", "minor", "")); err != nil {
+		t.Fatalf("Apply ChallengeRaised failed: %v", err)
+	}
+
+	if err := Apply(s, ledger.NewChallengeEscalated("chal-001", "minor", "critical")); err != nil {
+		t.Fatalf("Apply ChallengeEscalated failed: %v", err)
+	}
+	if got := s.GetChallenge("chal-001").Severity; got != "critical" {
+		t.Errorf("Challenge Severity: got %q, want %q", got, "critical")
+	}
+	if len(s.GetBlockingChallengesForNode(nodeID)) != 1 {
+		t.Error("escalated challenge should block acceptance")
+	}
+
+	if err := Apply(s, ledger.NewChallengeEscalated("chal-001", "critical", "blocker")); err == nil {
+		t.Error("Apply ChallengeEscalated should fail for an invalid severity")
+	}
+	if err := Apply(s, ledger.NewChallengeEscalated("chal-missing", "minor", "major")); err == nil {
+		t.Error("Apply ChallengeEscalated should fail for non-existent challenge")
+	}
+
+	if err := Apply(s, ledger.NewChallengeResolved("chal-001")); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(s, ledger.NewChallengeEscalated("chal-001", "critical", "note")); err == nil {
+		t.Error("Apply ChallengeEscalated should fail for a resolved challenge")
+	}
+}
+
 // TestApplyUnknownEventType verifies that unknown event type returns error.
 func TestApplyUnknownEventType(t *testing.T) {
 	s := NewState()
@@ -1659,14 +1734,15 @@ func TestApplyClaimRefreshed(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create test node: %v", err)
 	}
-	originalTimeout := types.Now()
+	claimedAt := types.Now()
 	n.WorkflowState = schema.WorkflowClaimed
 	n.ClaimedBy = "agent-123"
-	n.ClaimedAt = originalTimeout
+	n.ClaimedAt = claimedAt
+	n.ClaimTimeout = time.Minute
 	s.AddNode(n)
 
 	// Apply ClaimRefreshed event with new timeout
-	newTimeout := types.Now()
+	newTimeout := claimedAt.Add(time.Hour)
 	event := ledger.NewClaimRefreshed(nodeID, "agent-123", newTimeout)
 
 	err = Apply(s, event)
@@ -1674,10 +1750,13 @@ func TestApplyClaimRefreshed(t *testing.T) {
 		t.Fatalf("Apply ClaimRefreshed failed: %v", err)
 	}
 
-	// Verify timeout was updated
+	// Verify timeout was updated and the claim time kept
 	got := s.GetNode(nodeID)
-	if got.ClaimedAt != newTimeout {
-		t.Errorf("ClaimedAt not updated: got %v, want %v", got.ClaimedAt, newTimeout)
+	if !got.ClaimExpiry().Equal(newTimeout) {
+		t.Errorf("ClaimExpiry not updated: got %v, want %v", got.ClaimExpiry(), newTimeout)
+	}
+	if !got.ClaimedAt.Equal(claimedAt) {
+		t.Errorf("ClaimedAt changed: got %v, want %v", got.ClaimedAt, claimedAt)
 	}
 
 	// Verify workflow state remains claimed
@@ -1761,6 +1840,66 @@ func TestApplyClaimRefreshed_WrongOwner(t *testing.T) {
 	}
 }
 
+// TestApplyNodesReassigned verifies that NodesReassigned transfers the claim
+// to the new owner with fresh claim times.
+func TestApplyNodesReassigned(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Test claim", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("Failed to create test node: %v", err)
+	}
+	n.WorkflowState = schema.WorkflowClaimed
+	n.ClaimedBy = "agent-123"
+	n.ClaimedAt = types.Now()
+	n.ClaimTimeout = time.Minute
+	s.AddNode(n)
+
+	event := ledger.NewNodesReassigned([]types.NodeID{nodeID}, "agent-123", "agent-456", types.Now())
+	event.Timeout = event.Timestamp().Add(time.Hour)
+
+	if err := Apply(s, event); err != nil {
+		t.Fatalf("Apply NodesReassigned failed: %v", err)
+	}
+
+	got := s.GetNode(nodeID)
+	if got.WorkflowState != schema.WorkflowClaimed {
+		t.Errorf("WorkflowState = %q, want %q", got.WorkflowState, schema.WorkflowClaimed)
+	}
+	if got.ClaimedBy != "agent-456" {
+		t.Errorf("ClaimedBy = %q, want %q", got.ClaimedBy, "agent-456")
+	}
+	if !got.ClaimedAt.Equal(event.Timestamp()) {
+		t.Errorf("ClaimedAt = %v, want event time %v", got.ClaimedAt, event.Timestamp())
+	}
+	if !got.ClaimExpiry().Equal(event.Timeout) {
+		t.Errorf("ClaimExpiry = %v, want %v", got.ClaimExpiry(), event.Timeout)
+	}
+
+	// Replaying the same handoff again fails: agent-123 no longer holds the node
+	if err := Apply(s, event); err == nil || !strings.Contains(err.Error(), "claimed by agent-456") {
+		t.Errorf("expected ownership error on second reassignment, got %v", err)
+	}
+}
+
+// TestApplyNodesReassigned_NotClaimed verifies error when reassigning an unclaimed node.
+func TestApplyNodesReassigned_NotClaimed(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Test claim", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("Failed to create test node: %v", err)
+	}
+	s.AddNode(n)
+
+	event := ledger.NewNodesReassigned([]types.NodeID{nodeID}, "agent-123", "agent-456", types.Now())
+	if err := Apply(s, event); err == nil || !strings.Contains(err.Error(), "not claimed") {
+		t.Errorf("expected 'not claimed' error, got %v", err)
+	}
+}
+
 // TestApplyNodeAmended verifies that NodeAmended event updates node statement.
 func TestApplyNodeAmended(t *testing.T) {
 	s := NewState()
@@ -1811,6 +1950,84 @@ func TestApplyNodeAmended_NonExistentNode(t *testing.T) {
 	}
 }
 
+// TestApplyNodeAmended_Fields verifies that a full amendment updates only the
+// listed fields and records them in history.
+func TestApplyNodeAmended_Fields(t *testing.T) {
+	s := NewState()
+
+	depID := mustParseNodeID(t, "1.1")
+	dep, err := node.NewNode(depID, schema.NodeTypeClaim, "Dependency", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("Failed to create test node: %v", err)
+	}
+	s.AddNode(dep)
+
+	nodeID := mustParseNodeID(t, "1.2")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Original statement", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("Failed to create test node: %v", err)
+	}
+	s.AddNode(n)
+
+	next := *n
+	next.Inference = schema.InferenceModusPonens
+	next.Dependencies = []types.NodeID{depID}
+	next.Statement = "Ignored because statement is not listed"
+	event := ledger.NewNodeAmendedFull(*n, next, "prover-agent",
+		[]string{ledger.AmendFieldInference, ledger.AmendFieldDependencies})
+
+	if err := Apply(s, event); err != nil {
+		t.Fatalf("Apply NodeAmended failed: %v", err)
+	}
+
+	got := s.GetNode(nodeID)
+	if got.Statement != "Original statement" {
+		t.Errorf("Statement = %q, want unchanged", got.Statement)
+	}
+	if got.Inference != schema.InferenceModusPonens {
+		t.Errorf("Inference = %q, want %q", got.Inference, schema.InferenceModusPonens)
+	}
+	if len(got.Dependencies) != 1 || got.Dependencies[0].String() != "1.1" {
+		t.Errorf("Dependencies = %v, want [1.1]", got.Dependencies)
+	}
+	if got.ContentHash != got.ComputeContentHash() {
+		t.Error("ContentHash not recomputed")
+	}
+
+	history := s.GetAmendmentHistory(nodeID)
+	if len(history) != 1 {
+		t.Fatalf("expected 1 amendment, got %d", len(history))
+	}
+	if len(history[0].Fields) != 2 || history[0].Fields[0] != "inference" || history[0].Fields[1] != "dependencies" {
+		t.Errorf("Fields = %v, want [inference dependencies]", history[0].Fields)
+	}
+	if history[0].PreviousInference != schema.InferenceAssumption {
+		t.Errorf("PreviousInference = %q, want %q", history[0].PreviousInference, schema.InferenceAssumption)
+	}
+}
+
+// TestApplyNodeAmended_LegacyEventRecordsStatementField verifies that events
+// without a field list are treated as statement-only amendments.
+func TestApplyNodeAmended_LegacyEventRecordsStatementField(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Original statement", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("Failed to create test node: %v", err)
+	}
+	s.AddNode(n)
+
+	if err := Apply(s, ledger.NewNodeAmended(nodeID, "Original statement", "Amended", "owner")); err != nil {
+		t.Fatalf("Apply NodeAmended failed: %v", err)
+	}
+
+	history := s.GetAmendmentHistory(nodeID)
+	if len(history) != 1 || len(history[0].Fields) != 1 || history[0].Fields[0] != "statement" {
+		t.Errorf("history = %+v, want a single statement amendment", history)
+	}
+}
+
 // TestApplyNodeAmended_RecordsAmendmentHistory verifies that amendments are tracked.
 func TestApplyNodeAmended_RecordsAmendmentHistory(t *testing.T) {
 	s := NewState()
@@ -2076,3 +2293,101 @@ func TestGetNodesNeedingRefinement(t *testing.T) {
 		t.Error("GetNodesNeedingRefinement() should include node 1.2")
 	}
 }
+
+// TestApplyNodeReopened verifies that refuted and archived nodes can be
+// reopened, as can validated and admitted nodes when an undo reverts them.
+func TestApplyNodeReopened(t *testing.T) {
+	tests := []struct {
+		name      string
+		epistemic schema.EpistemicState
+		wantError bool
+	}{
+		{"refuted", schema.EpistemicRefuted, false},
+		{"archived", schema.EpistemicArchived, false},
+		{"pending", schema.EpistemicPending, true},
+		{"validated", schema.EpistemicValidated, false},
+		{"admitted", schema.EpistemicAdmitted, false},
+		{"needs_refinement", schema.EpistemicNeedsRefinement, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewState()
+			nodeID := mustParseNodeID(t, "1")
+			n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Test claim", schema.InferenceAssumption)
+			if err != nil {
+				t.Fatalf("Failed to create test node: %v", err)
+			}
+			n.EpistemicState = tt.epistemic
+			s.AddNode(n)
+
+			err = Apply(s, ledger.NewNodeReopened(nodeID))
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error reopening %s node", tt.epistemic)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply NodeReopened failed: %v", err)
+			}
+			if got := s.GetNode(nodeID).EpistemicState; got != schema.EpistemicPending {
+				t.Errorf("Epistemic state after reopen: got %q, want %q", got, schema.EpistemicPending)
+			}
+		})
+	}
+}
+
+func TestApplyNoteAdded(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Root", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddNode(n)
+
+	event := ledger.NewNoteAdded(nodeID, "note-1", "verifier", "Could be shorter")
+	if err := Apply(s, event); err != nil {
+		t.Fatalf("Apply NoteAdded failed: %v", err)
+	}
+	notes := s.GetNode(nodeID).Notes
+	if len(notes) != 1 || notes[0].ID != "note-1" || notes[0].Author != "verifier" || notes[0].Created.String() != event.EventTime.String() {
+		t.Errorf("Notes = %+v, want one note-1 by verifier", notes)
+	}
+	if s.GetNode(nodeID).EpistemicState != schema.EpistemicPending {
+		t.Error("NoteAdded should not change the epistemic state")
+	}
+
+	if err := Apply(s, ledger.NewNoteAdded(mustParseNodeID(t, "1.5"), "note-2", "verifier", "x")); err == nil {
+		t.Error("Apply NoteAdded should fail for non-existent node")
+	}
+}
+
+func TestApplyNodeLatexSet(t *testing.T) {
+	s := NewState()
+
+	nodeID := mustParseNodeID(t, "1")
+	n, err := node.NewNode(nodeID, schema.NodeTypeClaim, "Root", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddNode(n)
+	oldHash := n.ContentHash
+
+	if err := Apply(s, ledger.NewNodeLatexSet(nodeID, "", "$r$", "prover")); err != nil {
+		t.Fatalf("Apply NodeLatexSet failed: %v", err)
+	}
+	got := s.GetNode(nodeID)
+	if got.Latex != "$r$" || got.Statement != "Root" {
+		t.Errorf("node = latex %q, statement %q; want latex $r$ and statement unchanged", got.Latex, got.Statement)
+	}
+	if got.ContentHash == oldHash || got.ContentHash != got.ComputeContentHash() {
+		t.Error("NodeLatexSet should recompute the content hash")
+	}
+
+	if err := Apply(s, ledger.NewNodeLatexSet(mustParseNodeID(t, "1.5"), "", "$x$", "prover")); err == nil {
+		t.Error("Apply NodeLatexSet should fail for non-existent node")
+	}
+}
@@ -0,0 +1,94 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// ClaimPeriod is one stretch of time during which a node was claimed by a
+// single owner.
+type ClaimPeriod struct {
+	Owner     string
+	ClaimedAt types.Timestamp
+
+	// ReleasedAt is when the owner's claim ended, because the node was
+	// released or reassigned to another owner. Zero while the claim is held.
+	ReleasedAt types.Timestamp
+}
+
+// Held reports whether the claim had not ended when the ledger was scanned.
+func (p ClaimPeriod) Held() bool {
+	return p.ReleasedAt.IsZero()
+}
+
+// Duration returns how long the claim was held, measuring a claim that is
+// still held up to now. Periods recorded without timestamps, which
+// predate timestamped events, last 0.
+func (p ClaimPeriod) Duration(now types.Timestamp) time.Duration {
+	end := p.ReleasedAt
+	if end.IsZero() {
+		end = now
+	}
+	if p.ClaimedAt.IsZero() || end.Before(p.ClaimedAt) {
+		return 0
+	}
+	return end.Sub(p.ClaimedAt)
+}
+
+// ClaimHistory scans the ledger and returns every period each node spent
+// claimed, keyed by node ID string, in ledger order. Unlike the node's
+// ClaimedBy and ClaimedAt, which only describe the current claim, it keeps
+// claims that have since ended.
+//
+// A reassignment ends the previous owner's period and starts one for the
+// new owner. A refreshed claim stays in the same period. Nodes that were
+// never claimed are omitted.
+func ClaimHistory(ldg *ledger.Ledger) (map[string][]ClaimPeriod, error) {
+	if ldg == nil {
+		return nil, fmt.Errorf("cannot read claim history from nil ledger")
+	}
+
+	periods := make(map[string][]ClaimPeriod)
+	// end closes the node's open period, if any, at the given time
+	end := func(id string, at types.Timestamp) {
+		if ps := periods[id]; len(ps) > 0 && ps[len(ps)-1].Held() {
+			ps[len(ps)-1].ReleasedAt = at
+		}
+	}
+	start := func(id, owner string, at types.Timestamp) {
+		periods[id] = append(periods[id], ClaimPeriod{Owner: owner, ClaimedAt: at})
+	}
+
+	err := ldg.Scan(func(seq int, data []byte) error {
+		event, err := parseEvent(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse event %d: %w", seq, err)
+		}
+		at := event.Timestamp()
+
+		switch e := event.(type) {
+		case ledger.NodesClaimed:
+			for _, id := range e.NodeIDs {
+				end(id.String(), at)
+				start(id.String(), e.Owner, at)
+			}
+		case ledger.NodesReassigned:
+			for _, id := range e.NodeIDs {
+				end(id.String(), at)
+				start(id.String(), e.ToOwner, at)
+			}
+		case ledger.NodesReleased:
+			for _, id := range e.NodeIDs {
+				end(id.String(), at)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
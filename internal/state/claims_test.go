@@ -0,0 +1,80 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestClaimHistory(t *testing.T) {
+	ldg, err := ledger.NewLedger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2025, 1, 11, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) types.Timestamp {
+		return types.FromTime(base.Add(time.Duration(minutes) * time.Minute))
+	}
+	n, err := node.NewNode(mustParseNodeID(t, "1"), schema.NodeTypeClaim, "Root", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []types.NodeID{mustParseNodeID(t, "1")}
+
+	claim := ledger.NewNodesClaimed(ids, "alice", at(60))
+	claim.EventTime = at(1)
+	refresh := ledger.NewClaimRefreshed(ids[0], "alice", at(90))
+	refresh.EventTime = at(2)
+	release := ledger.NewNodesReleased(ids)
+	release.EventTime = at(4)
+	reclaim := ledger.NewNodesClaimed(ids, "bob", at(60))
+	reclaim.EventTime = at(10)
+	reassign := ledger.NewNodesReassigned(ids, "bob", "carol", at(60))
+	reassign.EventTime = at(15)
+
+	for _, e := range []ledger.Event{
+		ledger.NewProofInitialized("Root", "author"), ledger.NewNodeCreated(*n), claim, refresh, release, reclaim, reassign,
+	} {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	history, err := ClaimHistory(ldg)
+	if err != nil {
+		t.Fatalf("ClaimHistory failed: %v", err)
+	}
+	periods := history["1"]
+	if len(periods) != 3 {
+		t.Fatalf("got %d periods, want 3: %+v", len(periods), periods)
+	}
+
+	now := at(20)
+	want := []struct {
+		owner    string
+		duration time.Duration
+		held     bool
+	}{
+		{"alice", 3 * time.Minute, false},
+		{"bob", 5 * time.Minute, false},
+		{"carol", 5 * time.Minute, true},
+	}
+	for i, w := range want {
+		p := periods[i]
+		if p.Owner != w.owner || p.Duration(now) != w.duration || p.Held() != w.held {
+			t.Errorf("period %d = %s for %s (held %v), want %s for %s (held %v)",
+				i, p.Owner, p.Duration(now), p.Held(), w.owner, w.duration, w.held)
+		}
+	}
+}
+
+func TestClaimHistory_NilLedger(t *testing.T) {
+	if _, err := ClaimHistory(nil); err == nil {
+		t.Error("ClaimHistory(nil) should return an error")
+	}
+}
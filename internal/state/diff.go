@@ -0,0 +1,143 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Node fields compared by Diff, as reported in NodeChange.Field.
+const (
+	DiffFieldWorkflow  = "workflow_state"
+	DiffFieldEpistemic = "epistemic_state"
+	DiffFieldTaint     = "taint_state"
+)
+
+// NodeChange records a change to a single state field of a node.
+type NodeChange struct {
+	NodeID types.NodeID
+	Field  string // One of DiffFieldWorkflow, DiffFieldEpistemic, DiffFieldTaint
+	From   string
+	To     string
+}
+
+// StateDiff describes how the proof state changed between two ledger
+// sequence numbers.
+type StateDiff struct {
+	FromSeq int
+	ToSeq   int
+
+	// Added holds nodes created after FromSeq, as they are at ToSeq.
+	Added []*node.Node
+
+	// Removed holds nodes that existed at FromSeq and were archived by ToSeq.
+	Removed []*node.Node
+
+	// Changed holds per-field changes to nodes that existed at FromSeq.
+	// The transition to archived is reported in Removed, not here.
+	Changed []NodeChange
+
+	// ChallengesRaised holds challenges raised after FromSeq, as they are at
+	// ToSeq, sorted by node ID and then by challenge ID.
+	ChallengesRaised []*Challenge
+}
+
+// IsEmpty returns true if no node was added, removed, or changed.
+// Challenges raised in the range are not considered.
+func (d *StateDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the state after event fromSeq with the state after event toSeq.
+// A fromSeq of 0 compares against the empty state before the first event.
+//
+// Returns an error if fromSeq is negative, fromSeq > toSeq, or toSeq exceeds
+// the latest sequence number in the ledger.
+func Diff(ldg *ledger.Ledger, fromSeq, toSeq int) (*StateDiff, error) {
+	if ldg == nil {
+		return nil, fmt.Errorf("cannot diff nil ledger")
+	}
+	if fromSeq < 0 || toSeq < 0 {
+		return nil, fmt.Errorf("sequence numbers must not be negative (got %d and %d)", fromSeq, toSeq)
+	}
+	if fromSeq > toSeq {
+		return nil, fmt.Errorf("from sequence %d is after to sequence %d", fromSeq, toSeq)
+	}
+
+	after := NewState()
+	if toSeq > 0 {
+		var err error
+		after, err = ReplayUntil(ldg, toSeq)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if after.LatestSeq() < toSeq {
+		return nil, fmt.Errorf("to sequence %d exceeds latest ledger sequence %d", toSeq, after.LatestSeq())
+	}
+
+	before := NewState()
+	if fromSeq > 0 {
+		var err error
+		before, err = ReplayUntil(ldg, fromSeq)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diffStates(before, after, fromSeq, toSeq), nil
+}
+
+// diffStates computes the node differences between two states.
+func diffStates(before, after *State, fromSeq, toSeq int) *StateDiff {
+	d := &StateDiff{FromSeq: fromSeq, ToSeq: toSeq}
+
+	nodes := after.AllNodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID.Less(nodes[j].ID) })
+
+	for _, n := range nodes {
+		old := before.GetNode(n.ID)
+		if old == nil {
+			d.Added = append(d.Added, n)
+			continue
+		}
+
+		archived := n.EpistemicState == schema.EpistemicArchived && old.EpistemicState != schema.EpistemicArchived
+		if archived {
+			d.Removed = append(d.Removed, n)
+		}
+
+		d.addChange(n.ID, DiffFieldWorkflow, string(old.WorkflowState), string(n.WorkflowState))
+		if !archived {
+			d.addChange(n.ID, DiffFieldEpistemic, string(old.EpistemicState), string(n.EpistemicState))
+		}
+		d.addChange(n.ID, DiffFieldTaint, string(old.TaintState), string(n.TaintState))
+	}
+
+	for _, c := range after.AllChallenges() {
+		if before.GetChallenge(c.ID) == nil {
+			d.ChallengesRaised = append(d.ChallengesRaised, c)
+		}
+	}
+	sort.Slice(d.ChallengesRaised, func(i, j int) bool {
+		a, b := d.ChallengesRaised[i], d.ChallengesRaised[j]
+		if !a.NodeID.Equal(b.NodeID) {
+			return a.NodeID.Less(b.NodeID)
+		}
+		return a.ID < b.ID
+	})
+
+	return d
+}
+
+// addChange records a field change if the value differs.
+func (d *StateDiff) addChange(id types.NodeID, field, from, to string) {
+	if from == to {
+		return
+	}
+	d.Changed = append(d.Changed, NodeChange{NodeID: id, Field: field, From: from, To: to})
+}
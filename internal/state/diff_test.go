@@ -0,0 +1,227 @@
+package state
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newDiffTestLedger creates a ledger with the following history:
+//
+//	1: proof initialized
+//	2: node 1 created
+//	3: node 1.1 created
+//	4: node 1.2 created
+//	5: node 1.1 claimed
+//	6: node 1.1 released
+//	7: node 1.1 validated
+//	8: node 1.2 archived
+//	9: node 1.3 created
+func newDiffTestLedger(t *testing.T) *ledger.Ledger {
+	t.Helper()
+	ldg, err := ledger.NewLedger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newNode := func(id, stmt string) node.Node {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, stmt, schema.InferenceModusPonens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *n
+	}
+	id11 := mustParseNodeID(t, "1.1")
+
+	events := []ledger.Event{
+		ledger.NewProofInitialized("Root", "author"),
+		ledger.NewNodeCreated(newNode("1", "Root")),
+		ledger.NewNodeCreated(newNode("1.1", "First step")),
+		ledger.NewNodeCreated(newNode("1.2", "Dead end")),
+		ledger.NewNodesClaimed([]types.NodeID{id11}, "prover", types.Now()),
+		ledger.NewNodesReleased([]types.NodeID{id11}),
+		ledger.NewNodeValidated(id11),
+		ledger.NewNodeArchived(mustParseNodeID(t, "1.2")),
+		ledger.NewNodeCreated(newNode("1.3", "Second step")),
+	}
+	for _, e := range events {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	return ldg
+}
+
+func TestReplayUntil(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+
+	st, err := ReplayUntil(ldg, 4)
+	if err != nil {
+		t.Fatalf("ReplayUntil failed: %v", err)
+	}
+	if st.LatestSeq() != 4 {
+		t.Errorf("LatestSeq = %d, want 4", st.LatestSeq())
+	}
+	if st.GetNode(mustParseNodeID(t, "1.2")) == nil {
+		t.Error("node 1.2 should exist at seq 4")
+	}
+	if st.GetNode(mustParseNodeID(t, "1.3")) != nil {
+		t.Error("node 1.3 should not exist at seq 4")
+	}
+
+	// A bound past the end replays everything
+	st, err = ReplayUntil(ldg, 100)
+	if err != nil {
+		t.Fatalf("ReplayUntil failed: %v", err)
+	}
+	if st.LatestSeq() != 9 {
+		t.Errorf("LatestSeq = %d, want 9", st.LatestSeq())
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+
+	d, err := Diff(ldg, 4, 9)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	if d.FromSeq != 4 || d.ToSeq != 9 {
+		t.Errorf("range = %d..%d, want 4..9", d.FromSeq, d.ToSeq)
+	}
+	if len(d.Added) != 1 || d.Added[0].ID.String() != "1.3" {
+		t.Errorf("Added = %v, want [1.3]", nodeIDs(d.Added))
+	}
+	if len(d.Removed) != 1 || d.Removed[0].ID.String() != "1.2" {
+		t.Errorf("Removed = %v, want [1.2]", nodeIDs(d.Removed))
+	}
+
+	// Claim and release cancel out; only the validation shows up for 1.1
+	var got []string
+	for _, c := range d.Changed {
+		got = append(got, c.NodeID.String()+" "+c.Field+" "+c.From+"->"+c.To)
+	}
+	want := "1.1 epistemic_state pending->validated"
+	if len(got) == 0 || got[0] != want {
+		t.Errorf("Changed = %v, want first entry %q", got, want)
+	}
+	for _, c := range d.Changed {
+		if c.Field == DiffFieldEpistemic && c.To == string(schema.EpistemicArchived) {
+			t.Errorf("archival of %s should be reported in Removed, not Changed", c.NodeID)
+		}
+		if c.Field == DiffFieldWorkflow {
+			t.Errorf("unexpected workflow change for %s: %s -> %s", c.NodeID, c.From, c.To)
+		}
+	}
+}
+
+func TestDiff_ClaimInRange(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+
+	d, err := Diff(ldg, 4, 5)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(d.Changed) != 1 {
+		t.Fatalf("Changed = %v, want one workflow change", d.Changed)
+	}
+	c := d.Changed[0]
+	if c.NodeID.String() != "1.1" || c.Field != DiffFieldWorkflow ||
+		c.From != string(schema.WorkflowAvailable) || c.To != string(schema.WorkflowClaimed) {
+		t.Errorf("Changed[0] = %+v, want 1.1 workflow available -> claimed", c)
+	}
+}
+
+func TestDiff_SameSeqIsEmpty(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+
+	d, err := Diff(ldg, 6, 6)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if !d.IsEmpty() {
+		t.Errorf("Diff(6, 6) = %+v, want empty", d)
+	}
+}
+
+func TestDiff_FromZero(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+
+	d, err := Diff(ldg, 0, 3)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(d.Added) != 2 {
+		t.Errorf("Added = %v, want [1 1.1]", nodeIDs(d.Added))
+	}
+}
+
+func TestDiff_ChallengesRaised(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+	for _, e := range []ledger.Event{
+		ledger.NewChallengeRaised("ch-b", mustParseNodeID(t, "1.3"), "statement", "Unclear"), // seq 10
+		ledger.NewChallengeRaised("ch-a", mustParseNodeID(t, "1.1"), "inference", "Gap"),     // seq 11
+		ledger.NewChallengeRaised("ch-c", mustParseNodeID(t, "1.1"), "statement", "Typo"),    // seq 12
+	} {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		from int
+		want string
+	}{
+		{9, "ch-a ch-c ch-b"}, // sorted by node ID, then challenge ID
+		{10, "ch-a ch-c"},
+		{12, ""},
+	} {
+		d, err := Diff(ldg, tt.from, 12)
+		if err != nil {
+			t.Fatalf("Diff failed: %v", err)
+		}
+		var ids []string
+		for _, c := range d.ChallengesRaised {
+			ids = append(ids, c.ID)
+		}
+		if got := strings.Join(ids, " "); got != tt.want {
+			t.Errorf("Diff(%d, 12).ChallengesRaised = %q, want %q", tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestDiff_InvalidRange(t *testing.T) {
+	ldg := newDiffTestLedger(t)
+
+	tests := []struct {
+		name     string
+		from, to int
+		wantErr  string
+	}{
+		{"from after to", 5, 3, "is after"},
+		{"to past end", 2, 10, "exceeds latest ledger sequence 9"},
+		{"negative", -1, 3, "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Diff(ldg, tt.from, tt.to)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Diff(%d, %d) error = %v, want error containing %q", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// nodeIDs returns the IDs of nodes as strings for test messages.
+func nodeIDs(nodes []*node.Node) []string {
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID.String()
+	}
+	return ids
+}
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Dependency graph edge kinds.
+const (
+	// EdgeDependency is an edge from a node to one of its Dependencies.
+	EdgeDependency = "dependency"
+	// EdgeValidation is an edge from a node to one of its ValidationDeps.
+	EdgeValidation = "validation"
+)
+
+// GraphEdge is a directed edge of the dependency graph, from the dependent
+// node to the node it depends on.
+type GraphEdge struct {
+	From types.NodeID `json:"from"`
+	To   types.NodeID `json:"to"`
+	Kind string       `json:"kind"` // EdgeDependency or EdgeValidation
+}
+
+// Graph is the proof's dependency graph as raw adjacency data, for tools
+// that lay out or analyze the graph themselves.
+type Graph struct {
+	// Nodes holds every node, sorted by ID.
+	Nodes []*node.Node `json:"nodes"`
+
+	// Edges holds every dependency and validation dependency, ordered by
+	// source node, then dependencies before validation dependencies, then
+	// target node. Targets are taken as recorded and may be missing from
+	// Nodes.
+	Edges []GraphEdge `json:"edges"`
+
+	// Cycles holds the dependency cycles, each starting and ending at the
+	// same node. NewGraph leaves it empty; ProofService.DependencyGraph
+	// fills it in from its cycle check.
+	Cycles [][]types.NodeID `json:"cycles"`
+}
+
+// NewGraph returns the dependency graph of s without cycles.
+func NewGraph(s *State) *Graph {
+	g := &Graph{Nodes: s.AllNodesSorted(), Edges: []GraphEdge{}, Cycles: [][]types.NodeID{}}
+	for _, n := range g.Nodes {
+		for _, dep := range sortedNodeIDs(n.Dependencies) {
+			g.Edges = append(g.Edges, GraphEdge{From: n.ID, To: dep, Kind: EdgeDependency})
+		}
+		for _, dep := range sortedNodeIDs(n.ValidationDeps) {
+			g.Edges = append(g.Edges, GraphEdge{From: n.ID, To: dep, Kind: EdgeValidation})
+		}
+	}
+	return g
+}
+
+// sortedNodeIDs returns a sorted copy of ids.
+func sortedNodeIDs(ids []types.NodeID) []types.NodeID {
+	sorted := append([]types.NodeID(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Less(sorted[j]) })
+	return sorted
+}
@@ -0,0 +1,53 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestNewGraph(t *testing.T) {
+	s := NewState()
+	addProgressNode(t, s, "1", schema.EpistemicPending)
+	addProgressNode(t, s, "1.10", schema.EpistemicPending)
+	addProgressNode(t, s, "1.2", schema.EpistemicPending, "1.10", "1.1")
+	addProgressNode(t, s, "1.1", schema.EpistemicValidated)
+	s.GetNode(mustParseNodeID(t, "1.2")).ValidationDeps = []types.NodeID{mustParseNodeID(t, "1")}
+
+	g := NewGraph(s)
+
+	var gotNodes []string
+	for _, n := range g.Nodes {
+		gotNodes = append(gotNodes, n.ID.String())
+	}
+	if want := []string{"1", "1.1", "1.2", "1.10"}; !reflect.DeepEqual(gotNodes, want) {
+		t.Errorf("Nodes = %v, want %v", gotNodes, want)
+	}
+
+	type edge struct{ from, to, kind string }
+	var gotEdges []edge
+	for _, e := range g.Edges {
+		gotEdges = append(gotEdges, edge{e.From.String(), e.To.String(), e.Kind})
+	}
+	wantEdges := []edge{
+		{"1.2", "1.1", EdgeDependency},
+		{"1.2", "1.10", EdgeDependency},
+		{"1.2", "1", EdgeValidation},
+	}
+	if !reflect.DeepEqual(gotEdges, wantEdges) {
+		t.Errorf("Edges = %v, want %v", gotEdges, wantEdges)
+	}
+
+	if g.Cycles == nil || len(g.Cycles) != 0 {
+		t.Errorf("Cycles = %#v, want empty non-nil slice", g.Cycles)
+	}
+}
+
+func TestNewGraph_Empty(t *testing.T) {
+	g := NewGraph(NewState())
+	if len(g.Nodes) != 0 || g.Edges == nil || len(g.Edges) != 0 {
+		t.Errorf("NewGraph(empty) = %+v, want no nodes and an empty edge list", g)
+	}
+}
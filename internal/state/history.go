@@ -0,0 +1,196 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// NodeHistoryEntry is one ledger event in the timeline of a node.
+type NodeHistoryEntry struct {
+	Seq       int
+	Timestamp types.Timestamp
+	Type      ledger.EventType
+	Summary   string // Human-readable description, e.g. "claimed by alice"
+}
+
+// NodeHistory scans the ledger and returns every event that references
+// nodeID, in ledger order: its creation, claims and releases, challenges
+// raised against it and their resolution, epistemic and taint changes,
+// amendments, tags, lemmas, and assumption scopes.
+//
+// Challenge resolutions and withdrawals do not name a node; they are
+// included when they close a challenge raised against nodeID.
+func NodeHistory(ldg *ledger.Ledger, nodeID types.NodeID) ([]NodeHistoryEntry, error) {
+	if ldg == nil {
+		return nil, fmt.Errorf("cannot read node history from nil ledger")
+	}
+
+	id := nodeID.String()
+	hasID := func(ids []types.NodeID) bool {
+		for _, other := range ids {
+			if other.String() == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Challenges raised against the node, so their closing events can be matched
+	challenges := make(map[string]bool)
+
+	var entries []NodeHistoryEntry
+	err := ldg.Scan(func(seq int, data []byte) error {
+		event, err := parseEvent(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse event %d: %w", seq, err)
+		}
+
+		var summary string
+		switch e := event.(type) {
+		case ledger.NodeCreated:
+			if e.Node.ID.String() == id {
+				summary = fmt.Sprintf("created as %s: %s", e.Node.Type, e.Node.Statement)
+			}
+		case ledger.NodesClaimed:
+			if hasID(e.NodeIDs) {
+				summary = "claimed by " + e.Owner
+			}
+		case ledger.NodesReleased:
+			if hasID(e.NodeIDs) {
+				summary = "released"
+			}
+		case ledger.NodesReassigned:
+			if hasID(e.NodeIDs) {
+				summary = fmt.Sprintf("reassigned from %s to %s", e.FromOwner, e.ToOwner)
+			}
+		case ledger.ClaimRefreshed:
+			if e.NodeID.String() == id {
+				summary = "claim refreshed by " + e.Owner
+			}
+		case ledger.LockReaped:
+			if e.NodeID.String() == id {
+				summary = "stale claim reaped from " + e.Owner
+			}
+		case ledger.ChallengeRaised:
+			if e.NodeID.String() == id {
+				challenges[e.ChallengeID] = true
+				summary = fmt.Sprintf("challenge %s raised on %s: %s", e.ChallengeID, e.Target, e.Reason)
+				if e.Severity != "" {
+					summary += " (" + e.Severity + ")"
+				}
+			}
+		case ledger.ChallengeResolved:
+			if challenges[e.ChallengeID] {
+				summary = fmt.Sprintf("challenge %s resolved", e.ChallengeID)
+			}
+		case ledger.ChallengeWithdrawn:
+			if challenges[e.ChallengeID] {
+				summary = fmt.Sprintf("challenge %s withdrawn", e.ChallengeID)
+			}
+		case ledger.ChallengeSuperseded:
+			if e.NodeID.String() == id || challenges[e.ChallengeID] {
+				summary = fmt.Sprintf("challenge %s superseded", e.ChallengeID)
+			}
+		case ledger.ChallengeEscalated:
+			if challenges[e.ChallengeID] {
+				summary = fmt.Sprintf("challenge %s severity changed from %s to %s", e.ChallengeID, e.PreviousSeverity, e.Severity)
+			}
+		case ledger.NodeValidated:
+			if e.NodeID.String() == id {
+				summary = "validated"
+				if e.Note != "" {
+					summary += ": " + e.Note
+				}
+			}
+		case ledger.NodeAdmitted:
+			if e.NodeID.String() == id {
+				summary = "admitted"
+			}
+		case ledger.NodeRefuted:
+			if e.NodeID.String() == id {
+				summary = "refuted"
+			}
+		case ledger.NodeArchived:
+			if e.NodeID.String() == id {
+				summary = "archived"
+			}
+		case ledger.NodeReopened:
+			if e.NodeID.String() == id {
+				summary = "reopened"
+			}
+		case ledger.RefinementRequested:
+			if e.NodeID.String() == id {
+				summary = "refinement requested: " + e.Reason
+				if e.RequestedBy != "" {
+					summary = fmt.Sprintf("refinement requested by %s: %s", e.RequestedBy, e.Reason)
+				}
+			}
+		case ledger.TaintRecomputed:
+			if e.NodeID.String() == id {
+				summary = fmt.Sprintf("taint recomputed: %s", e.NewTaint)
+			}
+		case ledger.NodeAmended:
+			if e.NodeID.String() == id {
+				fields := e.Fields
+				if len(fields) == 0 {
+					fields = []string{ledger.AmendFieldStatement}
+				}
+				summary = fmt.Sprintf("amended by %s: %s", e.Owner, strings.Join(fields, ", "))
+			}
+		case ledger.NodeTagged:
+			if e.NodeID.String() == id {
+				summary = "tagged " + e.Tag
+			}
+		case ledger.NodeUntagged:
+			if e.NodeID.String() == id {
+				summary = "untagged " + e.Tag
+			}
+		case ledger.NoteAdded:
+			if e.NodeID.String() == id {
+				summary = fmt.Sprintf("note %s by %s: %s", e.NoteID, e.Author, e.Text)
+			}
+		case ledger.NodeLatexSet:
+			if e.NodeID.String() == id {
+				summary = "latex set by " + e.Owner
+				if e.Latex == "" {
+					summary = "latex cleared by " + e.Owner
+				}
+			}
+		case ledger.LemmaExtracted:
+			if e.Lemma.NodeID.String() == id {
+				summary = fmt.Sprintf("lemma %s extracted", e.Lemma.ID)
+			}
+		case ledger.LemmaApplied:
+			if e.NodeID.String() == id {
+				summary = fmt.Sprintf("justified by lemma %s (by %s)", e.LemmaID, e.Owner)
+			}
+		case ledger.ScopeOpened:
+			if e.NodeID.String() == id {
+				summary = "assumption scope opened: " + e.Statement
+			}
+		case ledger.ScopeClosed:
+			if e.NodeID.String() == id {
+				summary = "assumption scope closed by " + e.DischargeNodeID.String()
+			} else if e.DischargeNodeID.String() == id {
+				summary = "discharged the assumption scope of " + e.NodeID.String()
+			}
+		}
+
+		if summary != "" {
+			entries = append(entries, NodeHistoryEntry{
+				Seq:       seq,
+				Timestamp: event.Timestamp(),
+				Type:      event.Type(),
+				Summary:   summary,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+func TestNodeHistory(t *testing.T) {
+	ldg, err := ledger.NewLedger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newNode := func(id string) ledger.NodeCreated {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Step "+id, schema.InferenceModusPonens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ledger.NewNodeCreated(*n)
+	}
+	id1, id11, id12 := mustParseNodeID(t, "1"), mustParseNodeID(t, "1.1"), mustParseNodeID(t, "1.2")
+
+	for _, e := range []ledger.Event{
+		ledger.NewProofInitialized("Root", "author"),
+		newNode("1"),
+		newNode("1.1"),
+		newNode("1.2"),
+		ledger.NewChallengeRaised("ch-1", id11, "statement", "Unclear"),
+		ledger.NewChallengeRaised("ch-2", id12, "statement", "Other node"),
+		ledger.NewChallengeWithdrawn("ch-2"), // closes a challenge on 1.2: not included
+		ledger.NewChallengeWithdrawn("ch-1"),
+		ledger.NewScopeOpened(id1, "Assume x > 0"),
+		ledger.NewScopeClosed(id1, id11),
+		ledger.NewNodeTagged(id11, "approach-A"),
+		ledger.NewTaintRecomputed(id11, node.TaintTainted),
+		ledger.NewNodeTagged(id12, "approach-B"),
+	} {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	entries, err := NodeHistory(ldg, id11)
+	if err != nil {
+		t.Fatalf("NodeHistory failed: %v", err)
+	}
+
+	want := []struct {
+		seq     int
+		summary string
+	}{
+		{3, "created as claim: Step 1.1"},
+		{5, "challenge ch-1 raised on statement: Unclear (major)"},
+		{8, "challenge ch-1 withdrawn"},
+		{10, "discharged the assumption scope of 1"},
+		{11, "tagged approach-A"},
+		{12, "taint recomputed: tainted"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i].Seq != w.seq || entries[i].Summary != w.summary {
+			t.Errorf("entry %d = #%d %q, want #%d %q", i, entries[i].Seq, entries[i].Summary, w.seq, w.summary)
+		}
+	}
+}
+
+func TestNodeHistory_NilLedger(t *testing.T) {
+	if _, err := NodeHistory(nil, mustParseNodeID(t, "1")); err == nil {
+		t.Error("expected error for nil ledger")
+	}
+}
@@ -0,0 +1,120 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Invariant violation kinds.
+const (
+	// ViolationMissingDependency: a node depends on a node that doesn't exist.
+	ViolationMissingDependency = "missing_dependency"
+	// ViolationDependencyCycle: nodes depend on each other in a cycle.
+	ViolationDependencyCycle = "dependency_cycle"
+	// ViolationContentHash: a node's stored content hash doesn't match its content.
+	ViolationContentHash = "content_hash_mismatch"
+	// ViolationUnvalidatedDependency: a validated node depends on a node that
+	// is neither validated nor admitted.
+	ViolationUnvalidatedDependency = "unvalidated_dependency"
+	// ViolationLedgerGap: the ledger is missing one or more event sequences.
+	ViolationLedgerGap = "ledger_gap"
+)
+
+// InvariantViolation describes one structural problem found in a proof.
+type InvariantViolation struct {
+	Kind string
+
+	// Critical violations mean the proof is corrupt or inconsistent.
+	// Non-critical ones are suspicious but can arise through normal use
+	// (e.g. accepting bottom-up with --allow-unvalidated-deps).
+	Critical bool
+
+	NodeIDs []types.NodeID
+	Message string
+}
+
+// InvariantReport is the result of checking a proof's structural invariants.
+type InvariantReport struct {
+	EventsChecked int
+	NodesChecked  int
+	Violations    []InvariantViolation
+}
+
+// HasCritical reports whether any violation in the report is critical.
+func (r *InvariantReport) HasCritical() bool {
+	for _, v := range r.Violations {
+		if v.Critical {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckNodeInvariants checks the invariants that can be verified node by
+// node: every dependency exists, every content hash matches, and every
+// validated node depends only on validated or admitted nodes. Dependency
+// cycles and ledger gaps need the whole graph or the ledger and are not
+// checked here.
+//
+// Violations are returned in node ID order.
+func CheckNodeInvariants(s *State) []InvariantViolation {
+	nodes := s.AllNodes()
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID.Less(nodes[j].ID)
+	})
+
+	var violations []InvariantViolation
+	for _, n := range nodes {
+		if !n.VerifyContentHash() {
+			violations = append(violations, InvariantViolation{
+				Kind:     ViolationContentHash,
+				Critical: true,
+				NodeIDs:  []types.NodeID{n.ID},
+				Message:  fmt.Sprintf("node %s content hash does not match its content", n.ID.String()),
+			})
+		}
+
+		for _, depID := range nodeDependencies(n) {
+			dep := s.GetNode(depID)
+			if dep == nil {
+				violations = append(violations, InvariantViolation{
+					Kind:     ViolationMissingDependency,
+					Critical: true,
+					NodeIDs:  []types.NodeID{n.ID, depID},
+					Message:  fmt.Sprintf("node %s depends on %s, which does not exist", n.ID.String(), depID.String()),
+				})
+				continue
+			}
+			if n.EpistemicState == schema.EpistemicValidated &&
+				dep.EpistemicState != schema.EpistemicValidated && dep.EpistemicState != schema.EpistemicAdmitted {
+				violations = append(violations, InvariantViolation{
+					Kind:    ViolationUnvalidatedDependency,
+					NodeIDs: []types.NodeID{n.ID, depID},
+					Message: fmt.Sprintf("node %s is validated but depends on %s, which is %s",
+						n.ID.String(), depID.String(), dep.EpistemicState),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// nodeDependencies returns n's reference and validation dependencies, without
+// duplicates.
+func nodeDependencies(n *node.Node) []types.NodeID {
+	seen := make(map[string]bool, len(n.Dependencies)+len(n.ValidationDeps))
+	var deps []types.NodeID
+	for _, list := range [][]types.NodeID{n.Dependencies, n.ValidationDeps} {
+		for _, id := range list {
+			if !seen[id.String()] {
+				seen[id.String()] = true
+				deps = append(deps, id)
+			}
+		}
+	}
+	return deps
+}
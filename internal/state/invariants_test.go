@@ -0,0 +1,65 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+func TestCheckNodeInvariants(t *testing.T) {
+	s := NewState()
+	addNode := func(id string, opts node.NodeOptions) *node.Node {
+		n, err := node.NewNodeWithOptions(mustParseNodeID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.AddNode(n)
+		return n
+	}
+
+	addNode("1", node.NodeOptions{})
+	addNode("1.1", node.NodeOptions{Dependencies: []types.NodeID{mustParseNodeID(t, "1.9")}})
+	tampered := addNode("1.2", node.NodeOptions{})
+	tampered.Statement = "Tampered"
+	validated := addNode("1.3", node.NodeOptions{ValidationDeps: []types.NodeID{mustParseNodeID(t, "1")}})
+	validated.EpistemicState = schema.EpistemicValidated
+
+	got := CheckNodeInvariants(s)
+	want := []struct {
+		kind     string
+		critical bool
+		nodes    string
+	}{
+		{ViolationMissingDependency, true, "1.1,1.9"},
+		{ViolationContentHash, true, "1.2"},
+		{ViolationUnvalidatedDependency, false, "1.3,1"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CheckNodeInvariants returned %d violations, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		ids := ""
+		for j, id := range got[i].NodeIDs {
+			if j > 0 {
+				ids += ","
+			}
+			ids += id.String()
+		}
+		if got[i].Kind != w.kind || got[i].Critical != w.critical || ids != w.nodes {
+			t.Errorf("violation %d = {%s %v %s}, want {%s %v %s}", i, got[i].Kind, got[i].Critical, ids, w.kind, w.critical, w.nodes)
+		}
+		if got[i].Message == "" {
+			t.Errorf("violation %d has no message", i)
+		}
+	}
+
+	report := &InvariantReport{Violations: got}
+	if !report.HasCritical() {
+		t.Error("HasCritical() = false, want true")
+	}
+	if (&InvariantReport{Violations: got[2:]}).HasCritical() {
+		t.Error("HasCritical() = true for warnings only")
+	}
+}
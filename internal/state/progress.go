@@ -0,0 +1,86 @@
+package state
+
+import (
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// CompletionPercent returns the share of nodes that are validated or
+// admitted, as a percentage in the range [0, 100]. An empty state is 0%.
+func (s *State) CompletionPercent() float64 {
+	if len(s.nodes) == 0 {
+		return 0
+	}
+
+	done := 0
+	for _, n := range s.nodes {
+		if isSettled(n.EpistemicState) {
+			done++
+		}
+	}
+	return float64(done) * 100 / float64(len(s.nodes))
+}
+
+// RootProven reports whether the root node "1" is validated and every node
+// it transitively depends on (through reference or validation dependencies)
+// is validated or admitted. A dependency that does not exist in the state
+// counts as unproven.
+func (s *State) RootProven() bool {
+	rootID, err := types.Parse("1")
+	if err != nil {
+		return false
+	}
+	root := s.GetNode(rootID)
+	if root == nil || root.EpistemicState != schema.EpistemicValidated {
+		return false
+	}
+
+	visited := map[string]bool{rootID.String(): true}
+	queue := []types.NodeID{rootID}
+	for len(queue) > 0 {
+		n := s.GetNode(queue[0])
+		queue = queue[1:]
+		if n == nil || !isSettled(n.EpistemicState) {
+			return false
+		}
+		for _, deps := range [][]types.NodeID{n.Dependencies, n.ValidationDeps} {
+			for _, dep := range deps {
+				key := dep.String()
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return true
+}
+
+// isSettled reports whether an epistemic state counts toward proof completion.
+func isSettled(es schema.EpistemicState) bool {
+	return es == schema.EpistemicValidated || es == schema.EpistemicAdmitted
+}
+
+// OpenChallengesBySeverity counts open challenges by severity (critical,
+// major, minor, note). Resolved, withdrawn, and superseded challenges are
+// not counted. Severities with no open challenges are omitted.
+func (s *State) OpenChallengesBySeverity() map[string]int {
+	counts := make(map[string]int)
+	for _, c := range s.OpenChallenges() {
+		counts[c.Severity]++
+	}
+	return counts
+}
+
+// BlockedNodeCount returns the number of nodes with at least one open
+// blocking (critical or major) challenge.
+func (s *State) BlockedNodeCount() int {
+	blocked := make(map[string]bool)
+	for _, c := range s.OpenChallenges() {
+		if schema.SeverityBlocksAcceptance(schema.ChallengeSeverity(c.Severity)) {
+			blocked[c.NodeID.String()] = true
+		}
+	}
+	return len(blocked)
+}
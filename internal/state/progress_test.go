@@ -0,0 +1,165 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// addProgressNode adds a node with the given epistemic state and dependencies.
+func addProgressNode(t *testing.T, s *State, id string, es schema.EpistemicState, deps ...string) {
+	t.Helper()
+	n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+	if err != nil {
+		t.Fatalf("NewNode(%s) error: %v", id, err)
+	}
+	n.EpistemicState = es
+	for _, d := range deps {
+		n.Dependencies = append(n.Dependencies, mustParseNodeID(t, d))
+	}
+	s.AddNode(n)
+}
+
+func TestCompletionPercent(t *testing.T) {
+	s := NewState()
+	if got := s.CompletionPercent(); got != 0 {
+		t.Errorf("CompletionPercent() on empty state = %v, want 0", got)
+	}
+
+	addProgressNode(t, s, "1", schema.EpistemicPending)
+	addProgressNode(t, s, "1.1", schema.EpistemicValidated)
+	addProgressNode(t, s, "1.2", schema.EpistemicAdmitted)
+	addProgressNode(t, s, "1.3", schema.EpistemicRefuted)
+
+	if got := s.CompletionPercent(); got != 50 {
+		t.Errorf("CompletionPercent() = %v, want 50", got)
+	}
+}
+
+func TestRootProven(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, s *State)
+		want  bool
+	}{
+		{
+			name:  "empty state",
+			setup: func(t *testing.T, s *State) {},
+			want:  false,
+		},
+		{
+			name: "root pending",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicPending)
+			},
+			want: false,
+		},
+		{
+			name: "root admitted is not proven",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicAdmitted)
+			},
+			want: false,
+		},
+		{
+			name: "root validated without deps",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicValidated)
+			},
+			want: true,
+		},
+		{
+			name: "transitive deps settled",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicValidated, "1.1")
+				addProgressNode(t, s, "1.1", schema.EpistemicValidated, "1.2")
+				addProgressNode(t, s, "1.2", schema.EpistemicAdmitted)
+			},
+			want: true,
+		},
+		{
+			name: "transitive dep pending",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicValidated, "1.1")
+				addProgressNode(t, s, "1.1", schema.EpistemicValidated, "1.2")
+				addProgressNode(t, s, "1.2", schema.EpistemicPending)
+			},
+			want: false,
+		},
+		{
+			name: "validation dep pending",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicValidated)
+				addProgressNode(t, s, "1.1", schema.EpistemicPending)
+				root := s.GetNode(mustParseNodeID(t, "1"))
+				root.ValidationDeps = []types.NodeID{mustParseNodeID(t, "1.1")}
+			},
+			want: false,
+		},
+		{
+			name: "missing dep",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicValidated, "1.5")
+			},
+			want: false,
+		},
+		{
+			name: "dependency cycle terminates",
+			setup: func(t *testing.T, s *State) {
+				addProgressNode(t, s, "1", schema.EpistemicValidated, "1.1")
+				addProgressNode(t, s, "1.1", schema.EpistemicValidated, "1")
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewState()
+			tt.setup(t, s)
+			if got := s.RootProven(); got != tt.want {
+				t.Errorf("RootProven() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenChallengeCounts(t *testing.T) {
+	s := NewState()
+	addProgressNode(t, s, "1", schema.EpistemicPending)
+	addProgressNode(t, s, "1.1", schema.EpistemicPending)
+	addProgressNode(t, s, "1.2", schema.EpistemicPending)
+
+	challenges := []struct {
+		id, nodeID, severity, status string
+	}{
+		{"c1", "1", "critical", ChallengeStatusOpen},
+		{"c2", "1", "major", ChallengeStatusOpen},
+		{"c3", "1.1", "major", ChallengeStatusOpen},
+		{"c4", "1.2", "minor", ChallengeStatusOpen},
+		{"c5", "1.2", "critical", ChallengeStatusResolved},
+		{"c6", "1.2", "major", ChallengeStatusWithdrawn},
+		{"c7", "1.2", "note", ChallengeStatusSuperseded},
+	}
+	for _, c := range challenges {
+		s.AddChallenge(&Challenge{ID: c.id, NodeID: mustParseNodeID(t, c.nodeID), Target: "statement", Severity: c.severity, Status: c.status})
+	}
+
+	counts := s.OpenChallengesBySeverity()
+	want := map[string]int{"critical": 1, "major": 2, "minor": 1}
+	if len(counts) != len(want) {
+		t.Errorf("OpenChallengesBySeverity() = %v, want %v", counts, want)
+	}
+	for severity, n := range want {
+		if counts[severity] != n {
+			t.Errorf("OpenChallengesBySeverity()[%s] = %d, want %d", severity, counts[severity], n)
+		}
+	}
+
+	// 1 and 1.1 have open blocking challenges; 1.2 only has a minor one open.
+	if got := s.BlockedNodeCount(); got != 2 {
+		t.Errorf("BlockedNodeCount() = %d, want 2", got)
+	}
+}
@@ -5,37 +5,103 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/tobias/vibefeld/internal/ledger"
 )
 
 // Replay reads all events from the ledger and applies them to build the current state.
 // Returns an error if the ledger is nil, contains invalid JSON, or has unknown event types.
+// The ledger's sequence numbers are validated up front (see
+// ledger.ValidateSequences), so a gap, duplicate, or out-of-range event file
+// is reported by name before any event is applied.
 func Replay(ldg *ledger.Ledger) (*State, error) {
-	return replayInternal(ldg, false)
+	return replayInternal(ldg, false, 0, nil)
+}
+
+// ProgressFunc is called by ReplayWithProgress after each event is applied,
+// with the event's sequence number and the number of events in the ledger.
+type ProgressFunc func(seq, total int)
+
+// ReplayWithProgress is like Replay but calls progress after applying each
+// event, so callers can report on long replays. progress is called
+// synchronously, in sequence order, and does not affect the replay; a nil
+// progress behaves exactly like Replay.
+func ReplayWithProgress(ldg *ledger.Ledger, progress ProgressFunc) (*State, error) {
+	return replayInternal(ldg, false, 0, progress)
+}
+
+// ReplayUntil is like Replay but stops after applying the event with sequence
+// number maxSeq, returning the state as it was at that point in history.
+// A maxSeq of 0 or less replays the whole ledger. If the ledger ends before
+// maxSeq, the returned state reflects all events and LatestSeq reports where
+// it stopped.
+func ReplayUntil(ldg *ledger.Ledger, maxSeq int) (*State, error) {
+	return replayInternal(ldg, false, maxSeq, nil)
 }
 
 // ReplayWithVerify reads all events from the ledger, applies them to build state,
-// and verifies content hashes on all nodes. Returns an error if any node's
-// content hash does not match its computed hash.
+// and verifies both the prev_hash chain linking events and the content hashes
+// on all nodes. Returns an error naming the first event whose prev_hash does
+// not match its predecessor, or if any node's content hash does not match its
+// computed hash.
 func ReplayWithVerify(ldg *ledger.Ledger) (*State, error) {
-	return replayInternal(ldg, true)
+	return replayInternal(ldg, true, 0, nil)
 }
 
-// replayInternal is the shared implementation for Replay and ReplayWithVerify.
-func replayInternal(ldg *ledger.Ledger, verifyHashes bool) (*State, error) {
+// ReplayWithVerifyProgress is ReplayWithVerify with progress reporting as in
+// ReplayWithProgress.
+func ReplayWithVerifyProgress(ldg *ledger.Ledger, progress ProgressFunc) (*State, error) {
+	return replayInternal(ldg, true, 0, progress)
+}
+
+// replayInternal is the shared implementation for the Replay variants.
+// Events after maxSeq are not applied; maxSeq <= 0 means no upper bound.
+// progress, if non-nil, is called after each event has been applied and
+// verified.
+func replayInternal(ldg *ledger.Ledger, verifyHashes bool, maxSeq int, progress ProgressFunc) (*State, error) {
 	if ldg == nil {
 		return nil, fmt.Errorf("cannot replay from nil ledger")
 	}
 
+	// The total is only needed for progress reporting
+	var total int
+	if progress != nil {
+		count, err := ldg.Count()
+		if err != nil {
+			return nil, fmt.Errorf("failed to count events: %w", err)
+		}
+		total = count
+	}
+
+	// Reject malformed sequences before applying anything
+	if err := ldg.ValidateSequences(); err != nil {
+		return nil, err
+	}
+
 	state := NewState()
 
 	// Track expected sequence number for validation (starts at 1)
 	expectedSeq := 1
 
+	var chain *ledger.ChainVerifier
+	if verifyHashes {
+		chainStart, err := ldg.ChainStart()
+		if err != nil {
+			return nil, err
+		}
+		chain = ledger.NewChainVerifierFrom(chainStart)
+	}
+
 	// Scan through all events and apply them, tracking sequence numbers
 	err := ldg.Scan(func(seq int, data []byte) error {
-		// Validate sequence numbers are consecutive starting from 1
+		// Stop early once the upper bound has been reached
+		if maxSeq > 0 && seq > maxSeq {
+			return ledger.ErrStopScan
+		}
+
+		// Validate sequence numbers are consecutive starting from 1; this
+		// catches events written after the up-front check
 		if seq != expectedSeq {
 			if seq < expectedSeq {
 				return fmt.Errorf("duplicate sequence number detected: got %d, expected %d", seq, expectedSeq)
@@ -44,6 +110,13 @@ func replayInternal(ldg *ledger.Ledger, verifyHashes bool) (*State, error) {
 		}
 		expectedSeq++
 
+		// Verify the event links to its predecessor before trusting its contents
+		if chain != nil {
+			if err := chain.Verify(seq, data); err != nil {
+				return err
+			}
+		}
+
 		// Parse the event type first
 		event, err := parseEvent(data)
 		if err != nil {
@@ -69,6 +142,9 @@ func replayInternal(ldg *ledger.Ledger, verifyHashes bool) (*State, error) {
 			}
 		}
 
+		if progress != nil {
+			progress(seq, total)
+		}
 		return nil
 	})
 
@@ -149,11 +225,39 @@ var eventFactories = map[ledger.EventType]eventFactory{
 	ledger.EventTaintRecomputed:     func() ledger.Event { return &ledger.TaintRecomputed{} },
 	ledger.EventDefAdded:            func() ledger.Event { return &ledger.DefAdded{} },
 	ledger.EventLemmaExtracted:      func() ledger.Event { return &ledger.LemmaExtracted{} },
+	ledger.EventLemmaApplied:        func() ledger.Event { return &ledger.LemmaApplied{} },
 	ledger.EventLockReaped:          func() ledger.Event { return &ledger.LockReaped{} },
 	ledger.EventClaimRefreshed:      func() ledger.Event { return &ledger.ClaimRefreshed{} },
+	ledger.EventNodesReassigned:     func() ledger.Event { return &ledger.NodesReassigned{} },
 	ledger.EventScopeOpened:          func() ledger.Event { return &ledger.ScopeOpened{} },
 	ledger.EventScopeClosed:          func() ledger.Event { return &ledger.ScopeClosed{} },
 	ledger.EventRefinementRequested:  func() ledger.Event { return &ledger.RefinementRequested{} },
+	ledger.EventNodeReopened:         func() ledger.Event { return &ledger.NodeReopened{} },
+	ledger.EventNodeTagged:           func() ledger.Event { return &ledger.NodeTagged{} },
+	ledger.EventNodeUntagged:         func() ledger.Event { return &ledger.NodeUntagged{} },
+	ledger.EventChallengeEscalated:   func() ledger.Event { return &ledger.ChallengeEscalated{} },
+	ledger.EventNoteAdded:            func() ledger.Event { return &ledger.NoteAdded{} },
+	ledger.EventNodeLatexSet:         func() ledger.Event { return &ledger.NodeLatexSet{} },
+}
+
+// EventTypes returns every event type that replay understands, sorted.
+func EventTypes() []ledger.EventType {
+	types := make([]ledger.EventType, 0, len(eventFactories))
+	for t := range eventFactories {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// NewEvent returns a new zero value of the event struct for eventType, as a
+// pointer, or false if the type is unknown.
+func NewEvent(eventType ledger.EventType) (ledger.Event, bool) {
+	factory, ok := eventFactories[eventType]
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
 }
 
 // parseEvent parses raw JSON bytes into a typed Event.
@@ -219,16 +323,32 @@ func derefEvent(eventPtr ledger.Event) ledger.Event {
 		return *e
 	case *ledger.LemmaExtracted:
 		return *e
+	case *ledger.LemmaApplied:
+		return *e
 	case *ledger.LockReaped:
 		return *e
 	case *ledger.ClaimRefreshed:
 		return *e
+	case *ledger.NodesReassigned:
+		return *e
 	case *ledger.ScopeOpened:
 		return *e
 	case *ledger.ScopeClosed:
 		return *e
 	case *ledger.RefinementRequested:
 		return *e
+	case *ledger.NodeReopened:
+		return *e
+	case *ledger.NodeTagged:
+		return *e
+	case *ledger.NodeUntagged:
+		return *e
+	case *ledger.ChallengeEscalated:
+		return *e
+	case *ledger.NoteAdded:
+		return *e
+	case *ledger.NodeLatexSet:
+		return *e
 	default:
 		// Should never happen since factory already validated the type
 		return eventPtr
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
 )
 
 // -----------------------------------------------------------------------------
@@ -66,6 +68,7 @@ func TestExtractEventType_AllKnownTypes(t *testing.T) {
 		{"scope_opened", `{"type":"scope_opened"}`, ledger.EventScopeOpened},
 		{"scope_closed", `{"type":"scope_closed"}`, ledger.EventScopeClosed},
 		{"claim_refreshed", `{"type":"claim_refreshed"}`, ledger.EventClaimRefreshed},
+		{"node_reopened", `{"type":"node_reopened"}`, ledger.EventNodeReopened},
 	}
 
 	for _, tt := range tests {
@@ -377,3 +380,58 @@ func TestParseEvent_AllEventTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestReplayWithProgress verifies the progress callback sees every event in
+// order with the ledger total, and that the result matches Replay.
+func TestReplayWithProgress(t *testing.T) {
+	ldg, err := ledger.NewLedger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []ledger.Event{ledger.NewProofInitialized("Root", "author")}
+	for _, id := range []string{"1", "1.1", "1.2"} {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Step "+id, schema.InferenceModusPonens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, ledger.NewNodeCreated(*n))
+	}
+	for _, e := range events {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	var seqs []int
+	st, err := ReplayWithProgress(ldg, func(seq, total int) {
+		if total != len(events) {
+			t.Errorf("progress total = %d, want %d", total, len(events))
+		}
+		seqs = append(seqs, seq)
+	})
+	if err != nil {
+		t.Fatalf("ReplayWithProgress failed: %v", err)
+	}
+	for i, seq := range seqs {
+		if seq != i+1 {
+			t.Fatalf("progress sequence numbers = %v, want 1..%d", seqs, len(events))
+		}
+	}
+	if len(seqs) != len(events) {
+		t.Errorf("progress called %d times, want %d", len(seqs), len(events))
+	}
+
+	plain, err := Replay(ldg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.LatestSeq() != plain.LatestSeq() || len(st.AllNodes()) != len(plain.AllNodes()) {
+		t.Errorf("ReplayWithProgress state (seq %d, %d nodes) differs from Replay (seq %d, %d nodes)",
+			st.LatestSeq(), len(st.AllNodes()), plain.LatestSeq(), len(plain.AllNodes()))
+	}
+
+	// A nil callback behaves like Replay
+	if _, err := ReplayWithProgress(ldg, nil); err != nil {
+		t.Errorf("ReplayWithProgress with nil callback failed: %v", err)
+	}
+}
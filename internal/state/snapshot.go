@@ -0,0 +1,286 @@
+// Package state provides derived state from replaying ledger events.
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/scope"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// stateSnapshot is the serialized form of the ledger-derived parts of State.
+// Assumptions and externals are loaded from the filesystem, not the ledger,
+// so they are not part of a snapshot.
+type stateSnapshot struct {
+	Nodes       []*node.Node           `json:"nodes"`
+	Definitions []*node.Definition     `json:"definitions,omitempty"`
+	Lemmas      []*node.Lemma          `json:"lemmas,omitempty"`
+	Challenges  []*Challenge           `json:"challenges,omitempty"`
+	Amendments  map[string][]Amendment `json:"amendments,omitempty"`
+	Scopes      []*scope.Entry         `json:"scopes,omitempty"`
+}
+
+// WriteSnapshot writes a snapshot of s to the ledger, tied to the event at
+// s.LatestSeq(). Subsequent calls to ReplayWithSnapshot only need to replay
+// events appended after that point.
+func WriteSnapshot(ldg *ledger.Ledger, s *State) error {
+	if ldg == nil {
+		return fmt.Errorf("cannot snapshot to nil ledger")
+	}
+	if s == nil {
+		return fmt.Errorf("cannot snapshot nil state")
+	}
+	return ldg.Snapshot(s.toSnapshot(), s.LatestSeq())
+}
+
+// ReplayWithSnapshot builds the current state like Replay, but starts from the
+// ledger's snapshot (if any) and only replays events after the snapshot boundary.
+//
+// If there is no snapshot, the snapshot is stale (the ledger was truncated or the
+// boundary event changed), the snapshot cannot be decoded, or the ledger's
+// sequence numbers are malformed, this falls back to a full Replay. Tail events are subject to the
+// same gap, duplicate, and parse checks as a full replay.
+func ReplayWithSnapshot(ldg *ledger.Ledger) (*State, error) {
+	if ldg == nil {
+		return nil, fmt.Errorf("cannot replay from nil ledger")
+	}
+
+	snap, err := ldg.LoadSnapshot()
+	if err != nil {
+		// The snapshot is only a cache: if it is missing, stale (ledger truncated
+		// or rewound), or unreadable, rebuild from the ledger
+		return Replay(ldg)
+	}
+
+	// A gap or malformed sequence anywhere in the ledger must be reported;
+	// full replay does that
+	if err := ldg.ValidateSequences(); err != nil {
+		return Replay(ldg)
+	}
+
+	var data stateSnapshot
+	if err := json.Unmarshal(snap.State, &data); err != nil {
+		return Replay(ldg)
+	}
+	state, err := fromSnapshot(data)
+	if err != nil {
+		return Replay(ldg)
+	}
+	state.SetLatestSeq(snap.Seq)
+
+	if err := replayTail(ldg, state, snap.Seq); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ReplayAfter brings s up to date by applying the events appended after
+// s.LatestSeq(), with the same sequence validation as a full replay. If it
+// returns an error, s may have been partially updated and should be discarded.
+func ReplayAfter(ldg *ledger.Ledger, s *State) error {
+	if ldg == nil {
+		return fmt.Errorf("cannot replay from nil ledger")
+	}
+	if s == nil {
+		return fmt.Errorf("cannot replay into nil state")
+	}
+	return replayTail(ldg, s, s.LatestSeq())
+}
+
+// Clone returns a deep copy of the ledger-derived parts of s, i.e. everything
+// a snapshot captures, along with its latest sequence number. Like snapshots,
+// the copy has no assumptions or externals.
+func (s *State) Clone() (*State, error) {
+	raw, err := json.Marshal(s.toSnapshot())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	var data stateSnapshot
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+	clone, err := fromSnapshot(data)
+	if err != nil {
+		return nil, err
+	}
+	clone.SetLatestSeq(s.LatestSeq())
+	return clone, nil
+}
+
+// CloneWithout returns a deep copy of s like Clone, minus the nodes for
+// which drop returns true and the challenges, amendments, and scopes
+// attached to them.
+func (s *State) CloneWithout(drop func(types.NodeID) bool) (*State, error) {
+	data := s.toSnapshot()
+
+	dropped := make(map[string]bool)
+	nodes := data.Nodes[:0:0]
+	for _, n := range data.Nodes {
+		if drop(n.ID) {
+			dropped[n.ID.String()] = true
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	data.Nodes = nodes
+
+	challenges := data.Challenges[:0:0]
+	for _, c := range data.Challenges {
+		if !dropped[c.NodeID.String()] {
+			challenges = append(challenges, c)
+		}
+	}
+	data.Challenges = challenges
+
+	amendments := make(map[string][]Amendment, len(data.Amendments))
+	for key, list := range data.Amendments {
+		if !dropped[key] {
+			amendments[key] = list
+		}
+	}
+	data.Amendments = amendments
+
+	scopes := data.Scopes[:0:0]
+	for _, entry := range data.Scopes {
+		if !dropped[entry.NodeID.String()] {
+			scopes = append(scopes, entry)
+		}
+	}
+	data.Scopes = scopes
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	var copied stateSnapshot
+	if err := json.Unmarshal(raw, &copied); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+	clone, err := fromSnapshot(copied)
+	if err != nil {
+		return nil, err
+	}
+	clone.SetLatestSeq(s.LatestSeq())
+	return clone, nil
+}
+
+// Equivalent reports whether a and b hold the same ledger-derived content,
+// i.e. everything a snapshot captures. Latest sequence numbers are not
+// compared, so a rewritten ledger can be checked against its source. Scope
+// timestamps come from the clock at apply time rather than from the ledger,
+// so they are ignored too.
+func Equivalent(a, b *State) (bool, error) {
+	rawA, err := comparableSnapshot(a)
+	if err != nil {
+		return false, err
+	}
+	rawB, err := comparableSnapshot(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(rawA, rawB), nil
+}
+
+// comparableSnapshot encodes the snapshot of s with scope timestamps cleared.
+func comparableSnapshot(s *State) ([]byte, error) {
+	data := s.toSnapshot()
+	scopes := make([]*scope.Entry, len(data.Scopes))
+	for i, entry := range data.Scopes {
+		copied := *entry
+		copied.Introduced = types.Timestamp{}
+		if copied.Discharged != nil {
+			copied.Discharged = &types.Timestamp{}
+		}
+		scopes[i] = &copied
+	}
+	data.Scopes = scopes
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	return raw, nil
+}
+
+// replayTail applies events after seq to state, with the same sequence
+// validation as a full replay.
+func replayTail(ldg *ledger.Ledger, state *State, after int) error {
+	expectedSeq := after + 1
+
+	return ldg.ScanFrom(after, func(seq int, data []byte) error {
+		// Validate sequence numbers are consecutive after the snapshot boundary
+		if seq != expectedSeq {
+			if seq < expectedSeq {
+				return fmt.Errorf("duplicate sequence number detected: got %d, expected %d", seq, expectedSeq)
+			}
+			return fmt.Errorf("sequence gap detected: got %d, expected %d", seq, expectedSeq)
+		}
+		expectedSeq++
+
+		event, err := parseEvent(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse event %d: %w", seq, err)
+		}
+
+		if err := Apply(state, event); err != nil {
+			return fmt.Errorf("failed to apply event %d (%s): %w", seq, event.Type(), err)
+		}
+
+		state.SetLatestSeq(seq)
+		return nil
+	})
+}
+
+// toSnapshot captures the ledger-derived parts of s, sorted for stable output.
+func (s *State) toSnapshot() stateSnapshot {
+	data := stateSnapshot{
+		Nodes:       s.AllNodes(),
+		Definitions: make([]*node.Definition, 0, len(s.definitions)),
+		Lemmas:      s.AllLemmas(),
+		Challenges:  s.AllChallenges(),
+		Amendments:  s.amendments,
+		Scopes:      s.scopeTracker.AllScopes(),
+	}
+	for _, d := range s.definitions {
+		data.Definitions = append(data.Definitions, d)
+	}
+
+	sort.Slice(data.Nodes, func(i, j int) bool { return data.Nodes[i].ID.Less(data.Nodes[j].ID) })
+	sort.Slice(data.Definitions, func(i, j int) bool { return data.Definitions[i].ID < data.Definitions[j].ID })
+	sort.Slice(data.Lemmas, func(i, j int) bool { return data.Lemmas[i].ID < data.Lemmas[j].ID })
+	sort.Slice(data.Challenges, func(i, j int) bool { return data.Challenges[i].ID < data.Challenges[j].ID })
+	sort.Slice(data.Scopes, func(i, j int) bool { return data.Scopes[i].NodeID.Less(data.Scopes[j].NodeID) })
+
+	return data
+}
+
+// fromSnapshot rebuilds a State from its serialized form.
+func fromSnapshot(data stateSnapshot) (*State, error) {
+	s := NewState()
+	for _, n := range data.Nodes {
+		s.AddNode(n)
+	}
+	for _, d := range data.Definitions {
+		s.AddDefinition(d)
+	}
+	for _, l := range data.Lemmas {
+		s.AddLemma(l)
+	}
+	for _, c := range data.Challenges {
+		s.AddChallenge(c)
+	}
+	for key, amendments := range data.Amendments {
+		s.amendments[key] = amendments
+	}
+	for _, entry := range data.Scopes {
+		if err := s.scopeTracker.RestoreScope(entry); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,328 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// newSnapshotTestLedger creates a ledger exercising every part of State that
+// a snapshot has to capture.
+func newSnapshotTestLedger(t *testing.T) *ledger.Ledger {
+	t.Helper()
+	ldg, err := ledger.NewLedger(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootID := mustParseNodeID(t, "1")
+	childID := mustParseNodeID(t, "1.1")
+	root, err := node.NewNode(rootID, schema.NodeTypeClaim, "Root", schema.InferenceAssumption)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := node.NewNode(childID, schema.NodeTypeClaim, "Child", schema.InferenceModusPonens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []ledger.Event{
+		ledger.NewProofInitialized("Root", "author"),
+		ledger.NewNodeCreated(*root),
+		ledger.NewNodeCreated(*child),
+		ledger.NewNodesClaimed([]types.NodeID{childID}, "agent", types.Now()),
+		ledger.NewDefAdded(ledger.Definition{ID: "def-1", Name: "group", Definition: "A set with...", Created: types.Now()}),
+		ledger.NewLemmaExtracted(ledger.Lemma{ID: "lem-1", Statement: "Lemma", NodeID: childID, Created: types.Now()}),
+		ledger.NewChallengeRaisedWithSeverity("ch-1", childID, "statement", "Unclear", "minor", "verifier"),
+		ledger.NewNodeAmended(childID, "Child", "Amended child", "agent"),
+		ledger.NewScopeOpened(childID, "Assume x > 0"),
+	}
+	for _, e := range events {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+	return ldg
+}
+
+// snapshotJSON returns a canonical encoding of the ledger-derived state.
+// Scope timestamps are taken from the clock at apply time, so they are
+// cleared before comparing.
+func snapshotJSON(t *testing.T, s *State) string {
+	t.Helper()
+	snap := s.toSnapshot()
+	for i, entry := range snap.Scopes {
+		copied := *entry
+		copied.Introduced = types.Timestamp{}
+		if copied.Discharged != nil {
+			copied.Discharged = &types.Timestamp{}
+		}
+		snap.Scopes[i] = &copied
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestReplayWithSnapshot_MatchesFullReplay(t *testing.T) {
+	ldg := newSnapshotTestLedger(t)
+
+	st, err := Replay(ldg)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if err := WriteSnapshot(ldg, st); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	// Append tail events after the snapshot boundary
+	childID := mustParseNodeID(t, "1.1")
+	for _, e := range []ledger.Event{
+		ledger.NewChallengeResolved("ch-1"),
+		ledger.NewScopeClosed(childID, childID),
+		ledger.NewNodesReleased([]types.NodeID{childID}),
+	} {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	full, err := Replay(ldg)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	fromSnap, err := ReplayWithSnapshot(ldg)
+	if err != nil {
+		t.Fatalf("ReplayWithSnapshot failed: %v", err)
+	}
+
+	if fromSnap.LatestSeq() != full.LatestSeq() {
+		t.Errorf("LatestSeq = %d, want %d", fromSnap.LatestSeq(), full.LatestSeq())
+	}
+	if got, want := snapshotJSON(t, fromSnap), snapshotJSON(t, full); got != want {
+		t.Errorf("snapshot replay differs from full replay:\ngot:  %s\nwant: %s", got, want)
+	}
+	if got := fromSnap.GetAmendmentHistory(childID); len(got) != 1 {
+		t.Errorf("amendment history length = %d, want 1", len(got))
+	}
+}
+
+func TestReplayAfter_MatchesFullReplay(t *testing.T) {
+	ldg := newSnapshotTestLedger(t)
+
+	st, err := Replay(ldg)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	// Nothing new: a no-op
+	seq := st.LatestSeq()
+	if err := ReplayAfter(ldg, st); err != nil || st.LatestSeq() != seq {
+		t.Fatalf("ReplayAfter with no new events = seq %d, %v; want seq %d", st.LatestSeq(), err, seq)
+	}
+
+	childID := mustParseNodeID(t, "1.1")
+	for _, e := range []ledger.Event{
+		ledger.NewChallengeResolved("ch-1"),
+		ledger.NewNodesReleased([]types.NodeID{childID}),
+	} {
+		if _, err := ldg.Append(e); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	if err := ReplayAfter(ldg, st); err != nil {
+		t.Fatalf("ReplayAfter failed: %v", err)
+	}
+	full, err := Replay(ldg)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if st.LatestSeq() != full.LatestSeq() {
+		t.Errorf("LatestSeq = %d, want %d", st.LatestSeq(), full.LatestSeq())
+	}
+	if got, want := snapshotJSON(t, st), snapshotJSON(t, full); got != want {
+		t.Errorf("incremental replay differs from full replay:\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestClone_IsDeepCopy(t *testing.T) {
+	st, err := Replay(newSnapshotTestLedger(t))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	clone, err := st.Clone()
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if clone.LatestSeq() != st.LatestSeq() {
+		t.Errorf("LatestSeq = %d, want %d", clone.LatestSeq(), st.LatestSeq())
+	}
+	if got, want := snapshotJSON(t, clone), snapshotJSON(t, st); got != want {
+		t.Errorf("clone differs from original:\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// Mutating the clone must not affect the original
+	childID := mustParseNodeID(t, "1.1")
+	clone.GetNode(childID).Statement = "Changed"
+	clone.GetChallenge("ch-1").Status = ChallengeStatusResolved
+	if st.GetNode(childID).Statement == "Changed" {
+		t.Error("node mutation leaked into the original state")
+	}
+	if st.GetChallenge("ch-1").Status != ChallengeStatusOpen {
+		t.Error("challenge mutation leaked into the original state")
+	}
+}
+
+func TestCloneWithout(t *testing.T) {
+	st, err := Replay(newSnapshotTestLedger(t))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	childID := mustParseNodeID(t, "1.1")
+	clone, err := st.CloneWithout(func(id types.NodeID) bool { return id.String() == childID.String() })
+	if err != nil {
+		t.Fatalf("CloneWithout failed: %v", err)
+	}
+
+	if clone.GetNode(childID) != nil || clone.GetNode(mustParseNodeID(t, "1")) == nil {
+		t.Error("CloneWithout should drop 1.1 and keep 1")
+	}
+	if clone.GetChallenge("ch-1") != nil {
+		t.Error("challenge on the dropped node was kept")
+	}
+	if len(clone.GetAmendmentHistory(childID)) != 0 {
+		t.Error("amendments of the dropped node were kept")
+	}
+	if len(clone.GetAllScopes()) != 0 {
+		t.Error("scope of the dropped node was kept")
+	}
+	if clone.GetDefinition("def-1") == nil || clone.GetLemma("lem-1") == nil {
+		t.Error("definitions and lemmas should be kept")
+	}
+	if st.GetNode(childID) == nil {
+		t.Error("CloneWithout modified the original state")
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	ldg := newSnapshotTestLedger(t)
+	a, err := Replay(ldg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Replay(ldg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Scope timestamps differ between the replays but are ignored
+	if same, err := Equivalent(a, b); err != nil || !same {
+		t.Errorf("Equivalent = %v (err %v), want true for two replays of one ledger", same, err)
+	}
+
+	b.GetNode(mustParseNodeID(t, "1.1")).Statement = "Changed"
+	if same, err := Equivalent(a, b); err != nil || same {
+		t.Errorf("Equivalent = %v (err %v), want false after a change", same, err)
+	}
+}
+
+func TestReplayWithSnapshot_TruncatedLedgerFallsBack(t *testing.T) {
+	ldg := newSnapshotTestLedger(t)
+
+	st, err := Replay(ldg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSnapshot(ldg, st); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	// Rewind the ledger past the snapshot boundary
+	last := st.LatestSeq()
+	if err := os.Remove(filepath.Join(ldg.Dir(), ledger.GenerateFilename(last))); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReplayWithSnapshot(ldg)
+	if err != nil {
+		t.Fatalf("ReplayWithSnapshot failed: %v", err)
+	}
+	if got.LatestSeq() != last-1 {
+		t.Errorf("LatestSeq = %d, want %d (full replay of truncated ledger)", got.LatestSeq(), last-1)
+	}
+	if len(got.GetActiveScopes()) != 0 {
+		t.Error("expected scope from truncated event to be absent")
+	}
+}
+
+func TestReplayWithSnapshot_DetectsCorruption(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, dir string, boundary int)
+		wantErr string
+	}{
+		{
+			name: "gap before boundary",
+			corrupt: func(t *testing.T, dir string, boundary int) {
+				if err := os.Remove(filepath.Join(dir, ledger.GenerateFilename(2))); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: "sequence gap",
+		},
+		{
+			name: "gap in tail",
+			corrupt: func(t *testing.T, dir string, boundary int) {
+				writeRawEvent(t, dir, boundary+2, `{"type":"challenge_resolved","challenge_id":"ch-1"}`)
+			},
+			wantErr: "sequence gap",
+		},
+		{
+			name: "invalid tail event",
+			corrupt: func(t *testing.T, dir string, boundary int) {
+				writeRawEvent(t, dir, boundary+1, `{"type":"no_such_event"}`)
+			},
+			wantErr: "unknown event type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldg := newSnapshotTestLedger(t)
+			st, err := Replay(ldg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := WriteSnapshot(ldg, st); err != nil {
+				t.Fatalf("WriteSnapshot failed: %v", err)
+			}
+
+			tt.corrupt(t, ldg.Dir(), st.LatestSeq())
+
+			_, err = ReplayWithSnapshot(ldg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ReplayWithSnapshot error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// writeRawEvent writes raw event bytes at a specific sequence number.
+func writeRawEvent(t *testing.T, dir string, seq int, data string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ledger.GenerateFilename(seq)), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
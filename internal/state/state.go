@@ -2,6 +2,7 @@
 package state
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/tobias/vibefeld/internal/node"
@@ -33,12 +34,22 @@ type Challenge struct {
 	RaisedBy   string          // Agent ID who raised the challenge
 }
 
-// Amendment represents a single amendment to a node's statement.
+// Amendment represents a single amendment to a node.
+// Fields names what changed; the Previous/New pairs for inference and
+// dependencies are only meaningful when the corresponding field is listed.
 type Amendment struct {
 	Timestamp         types.Timestamp // When the amendment occurred
 	PreviousStatement string          // The statement before this amendment
 	NewStatement      string          // The statement after this amendment
 	Owner             string          // Who made the amendment
+
+	Fields                 []string             // Amended fields, e.g. "statement", "inference"
+	PreviousInference      schema.InferenceType // The inference before this amendment
+	NewInference           schema.InferenceType // The inference after this amendment
+	PreviousDependencies   []types.NodeID       // Reference dependencies before this amendment
+	NewDependencies        []types.NodeID       // Reference dependencies after this amendment
+	PreviousValidationDeps []types.NodeID       // Validation dependencies before this amendment
+	NewValidationDeps      []types.NodeID       // Validation dependencies after this amendment
 }
 
 // State represents the current derived state of a proof.
@@ -130,6 +141,16 @@ func (s *State) GetDefinitionByName(name string) *node.Definition {
 	return nil
 }
 
+// AllDefinitions returns a slice of all definitions in the state.
+// The order of definitions is not guaranteed.
+func (s *State) AllDefinitions() []*node.Definition {
+	defs := make([]*node.Definition, 0, len(s.definitions))
+	for _, d := range s.definitions {
+		defs = append(defs, d)
+	}
+	return defs
+}
+
 // AddAssumption adds an assumption to the state.
 // If an assumption with the same ID already exists, it is overwritten.
 func (s *State) AddAssumption(a *node.Assumption) {
@@ -141,6 +162,16 @@ func (s *State) GetAssumption(id string) *node.Assumption {
 	return s.assumptions[id]
 }
 
+// AllAssumptions returns a slice of all assumptions in the state.
+// The order of assumptions is not guaranteed.
+func (s *State) AllAssumptions() []*node.Assumption {
+	assumptions := make([]*node.Assumption, 0, len(s.assumptions))
+	for _, a := range s.assumptions {
+		assumptions = append(assumptions, a)
+	}
+	return assumptions
+}
+
 // AddExternal adds an external reference to the state.
 // If an external with the same ID already exists, it is overwritten.
 func (s *State) AddExternal(e *node.External) {
@@ -163,6 +194,16 @@ func (s *State) GetExternalByName(name string) *node.External {
 	return nil
 }
 
+// AllExternals returns a slice of all external references in the state.
+// The order of externals is not guaranteed.
+func (s *State) AllExternals() []*node.External {
+	externals := make([]*node.External, 0, len(s.externals))
+	for _, e := range s.externals {
+		externals = append(externals, e)
+	}
+	return externals
+}
+
 // AddLemma adds a lemma to the state.
 // If a lemma with the same ID already exists, it is overwritten.
 func (s *State) AddLemma(l *node.Lemma) {
@@ -321,6 +362,22 @@ func (s *State) GetBlockingChallengesForNode(nodeID types.NodeID) []*Challenge {
 	return blocking
 }
 
+// GetBlockingChallengesForNodeWithSeverities returns the open challenges on
+// the specified node whose severity is one of the given blocking severities
+// (see schema.SeverityBlocksAcceptanceWith).
+func (s *State) GetBlockingChallengesForNodeWithSeverities(nodeID types.NodeID, severities []string) []*Challenge {
+	var blocking []*Challenge
+	for _, c := range s.GetChallengesForNode(nodeID) {
+		if c.Status != ChallengeStatusOpen {
+			continue
+		}
+		if schema.SeverityBlocksAcceptanceWith(schema.ChallengeSeverity(c.Severity), severities) {
+			blocking = append(blocking, c)
+		}
+	}
+	return blocking
+}
+
 // HasBlockingChallenges returns true if the node has any open challenges
 // with Critical or Major severity that block acceptance.
 func (s *State) HasBlockingChallenges(nodeID types.NodeID) bool {
@@ -344,7 +401,8 @@ func (s *State) VerifierRaisedChallengeForNode(nodeID types.NodeID, agentID stri
 }
 
 // AllNodes returns a slice of all nodes in the state.
-// The order of nodes is not guaranteed.
+// The order of nodes is not guaranteed; use AllNodesSorted for output that
+// must be deterministic.
 func (s *State) AllNodes() []*node.Node {
 	nodes := make([]*node.Node, 0, len(s.nodes))
 	for _, n := range s.nodes {
@@ -353,6 +411,105 @@ func (s *State) AllNodes() []*node.Node {
 	return nodes
 }
 
+// AllNodesSorted returns all nodes in the state sorted by NodeID, comparing
+// IDs component by component so that 1.2 sorts before 1.10 and a parent
+// before its children: 1, 1.1, 1.1.1, 1.2, 1.10, 2.
+func (s *State) AllNodesSorted() []*node.Node {
+	nodes := s.AllNodes()
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID.Less(nodes[j].ID)
+	})
+	return nodes
+}
+
+// Subtree returns the node with rootID followed by all of its descendants,
+// sorted by NodeID. Descendants are matched on whole ID components, so 1.10
+// is not part of the subtree of 1.1. Returns nil if rootID doesn't exist.
+func (s *State) Subtree(rootID types.NodeID) []*node.Node {
+	root := s.GetNode(rootID)
+	if root == nil {
+		return nil
+	}
+
+	subtree := []*node.Node{root}
+	for _, n := range s.nodes {
+		if n.ID.IsDescendantOf(rootID) {
+			subtree = append(subtree, n)
+		}
+	}
+	sort.Slice(subtree, func(i, j int) bool {
+		return subtree[i].ID.Less(subtree[j].ID)
+	})
+	return subtree
+}
+
+// Dependents returns the IDs of the nodes that list id in their
+// Dependencies or ValidationDeps, sorted by NodeID. Each dependent is
+// listed once, even if it cites id in both.
+func (s *State) Dependents(id types.NodeID) []types.NodeID {
+	var dependents []types.NodeID
+	for _, n := range s.nodes {
+		if containsID(n.Dependencies, id) || containsID(n.ValidationDeps, id) {
+			dependents = append(dependents, n.ID)
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		return dependents[i].Less(dependents[j])
+	})
+	return dependents
+}
+
+// TransitiveDependencies returns every node id rests on through
+// Dependencies, directly or via other dependencies, sorted by NodeID and
+// listed once each. Missing dependencies are included but not followed.
+// ValidationDeps are not followed.
+//
+// The walk terminates on cyclic graphs; the second result reports whether
+// a cycle was reachable from id. id itself is never listed, even when it
+// rests on itself through a cycle.
+func (s *State) TransitiveDependencies(id types.NodeID) ([]types.NodeID, bool) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	marks := make(map[string]int)
+	var deps []types.NodeID
+	cyclic := false
+
+	var visit func(types.NodeID)
+	visit = func(cur types.NodeID) {
+		marks[cur.String()] = visiting
+		if n := s.GetNode(cur); n != nil {
+			for _, dep := range n.Dependencies {
+				switch marks[dep.String()] {
+				case visiting:
+					cyclic = true
+				case 0:
+					deps = append(deps, dep)
+					visit(dep)
+				}
+			}
+		}
+		marks[cur.String()] = done
+	}
+	visit(id)
+
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].Less(deps[j])
+	})
+	return deps, cyclic
+}
+
+// containsID reports whether ids contains id.
+func containsID(ids []types.NodeID, id types.NodeID) bool {
+	for _, other := range ids {
+		if other.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
 // LatestSeq returns the sequence number of the last event applied to this state.
 // Returns 0 if no events have been applied yet.
 // This is used for optimistic concurrency control when appending new events.
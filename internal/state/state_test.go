@@ -4,6 +4,7 @@ package state
 import (
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 
@@ -71,6 +72,123 @@ func TestAddAndGetNode(t *testing.T) {
 	}
 }
 
+// TestAllNodesSorted verifies that nodes are ordered component by component,
+// so 1.2 sorts before 1.10 and parents sort before their children.
+func TestAllNodesSorted(t *testing.T) {
+	s := NewState()
+	for _, id := range []string{"1.10", "1.10.1", "1.2.1", "1", "1.1.10", "1.2", "1.9", "1.1.2", "1.1"} {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatalf("Failed to create node %s: %v", id, err)
+		}
+		s.AddNode(n)
+	}
+
+	want := []string{"1", "1.1", "1.1.2", "1.1.10", "1.2", "1.2.1", "1.9", "1.10", "1.10.1"}
+	got := s.AllNodesSorted()
+	if len(got) != len(want) {
+		t.Fatalf("AllNodesSorted returned %d nodes, want %d", len(got), len(want))
+	}
+	for i, n := range got {
+		if n.ID.String() != want[i] {
+			t.Errorf("AllNodesSorted()[%d] = %s, want %s", i, n.ID, want[i])
+		}
+	}
+
+	if got := NewState().AllNodesSorted(); len(got) != 0 {
+		t.Errorf("AllNodesSorted on empty state returned %d nodes", len(got))
+	}
+}
+
+// TestAllDefinitionsAssumptionsExternals verifies that the All accessors
+// return every added entry.
+func TestAllDefinitionsAssumptionsExternals(t *testing.T) {
+	s := NewState()
+	if len(s.AllDefinitions()) != 0 || len(s.AllAssumptions()) != 0 || len(s.AllExternals()) != 0 {
+		t.Fatal("empty state returned entries")
+	}
+
+	for _, name := range []string{"group", "ring"} {
+		def, err := node.NewDefinition(name, "Definition of "+name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.AddDefinition(def)
+	}
+	asm, _ := node.NewAssumption("Test assumption statement")
+	s.AddAssumption(asm)
+	ext, _ := node.NewExternal("ZFC", "Zermelo-Fraenkel set theory")
+	s.AddExternal(ext)
+
+	if got := len(s.AllDefinitions()); got != 2 {
+		t.Errorf("AllDefinitions returned %d definitions, want 2", got)
+	}
+	if got := s.AllAssumptions(); len(got) != 1 || got[0].ID != asm.ID {
+		t.Errorf("AllAssumptions = %v, want [%s]", got, asm.ID)
+	}
+	if got := s.AllExternals(); len(got) != 1 || got[0].ID != ext.ID {
+		t.Errorf("AllExternals = %v, want [%s]", got, ext.ID)
+	}
+}
+
+func TestTransitiveDependencies(t *testing.T) {
+	s := NewState()
+	// 1.1 -> 1.2, 1.3; 1.2 -> 1.4; 1.3 -> 1.4, 1.9 (missing); 1.4 -> 1.2 (cycle)
+	deps := map[string][]string{
+		"1.1": {"1.2", "1.3"},
+		"1.2": {"1.4"},
+		"1.3": {"1.4", "1.9"},
+		"1.4": {"1.2"},
+		"1.5": nil,
+	}
+	for id, depIDs := range deps {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatalf("Failed to create node %s: %v", id, err)
+		}
+		for _, dep := range depIDs {
+			n.Dependencies = append(n.Dependencies, mustParseNodeID(t, dep))
+		}
+		s.AddNode(n)
+	}
+
+	tests := []struct {
+		id     string
+		want   []string
+		cyclic bool
+	}{
+		{"1.1", []string{"1.2", "1.3", "1.4", "1.9"}, true},
+		{"1.4", []string{"1.2"}, true},
+		{"1.5", nil, false},
+	}
+	for _, tt := range tests {
+		got, cyclic := s.TransitiveDependencies(mustParseNodeID(t, tt.id))
+		if strings.Join(types.ToStringSlice(got), ",") != strings.Join(tt.want, ",") || cyclic != tt.cyclic {
+			t.Errorf("TransitiveDependencies(%s) = %v, %v; want %v, %v", tt.id, got, cyclic, tt.want, tt.cyclic)
+		}
+	}
+
+	// A diamond without a cycle: 1.2 -> 1.3, 1.4; both -> 1.5
+	s = NewState()
+	for _, id := range []string{"1.2", "1.3", "1.4", "1.5"} {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch id {
+		case "1.2":
+			n.Dependencies = []types.NodeID{mustParseNodeID(t, "1.3"), mustParseNodeID(t, "1.4")}
+		case "1.3", "1.4":
+			n.Dependencies = []types.NodeID{mustParseNodeID(t, "1.5")}
+		}
+		s.AddNode(n)
+	}
+	got, cyclic := s.TransitiveDependencies(mustParseNodeID(t, "1.2"))
+	if strings.Join(types.ToStringSlice(got), ",") != "1.3,1.4,1.5" || cyclic {
+		t.Errorf("TransitiveDependencies(1.2) = %v, %v; want [1.3 1.4 1.5], false", got, cyclic)
+	}
+}
+
 // TestAddAndGetDefinition verifies adding and retrieving definitions by ID.
 func TestAddAndGetDefinition(t *testing.T) {
 	s := NewState()
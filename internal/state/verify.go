@@ -0,0 +1,136 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/types"
+)
+
+// Ledger verification issue kinds.
+const (
+	// LedgerIssueSequenceGap: one or more event sequence numbers are missing.
+	LedgerIssueSequenceGap = "sequence_gap"
+	// LedgerIssueChainBroken: an event's prev_hash does not match the hash of
+	// the event before it.
+	LedgerIssueChainBroken = "chain_broken"
+	// LedgerIssueContentHash: a created node's content hash doesn't match its content.
+	LedgerIssueContentHash = "content_hash_mismatch"
+	// LedgerIssueInvalidEvent: an event cannot be parsed or applied to the
+	// state built from the events before it.
+	LedgerIssueInvalidEvent = "invalid_event"
+)
+
+// LedgerIssue describes one problem found while verifying a ledger.
+type LedgerIssue struct {
+	Kind string
+
+	// Seq is the sequence number of the offending event. For a sequence gap
+	// it is the first missing sequence number.
+	Seq int
+
+	// NodeID is the node the issue concerns, or the zero NodeID if none.
+	NodeID types.NodeID
+
+	Message string
+}
+
+// LedgerVerification is the result of verifying a ledger with VerifyLedger.
+type LedgerVerification struct {
+	EventsChecked int
+
+	// Chained is true if the ledger carries a prev_hash chain. Ledgers
+	// written before hash chaining was introduced have none.
+	Chained bool
+
+	// Issues are in sequence order.
+	Issues []LedgerIssue
+}
+
+// OK reports whether the ledger verified without issues.
+func (v *LedgerVerification) OK() bool {
+	return len(v.Issues) == 0
+}
+
+// VerifyLedger runs the checks of ReplayWithVerify over every event in the
+// ledger: consecutive sequence numbers, the prev_hash chain, the content
+// hash of every created node, and that each event applies cleanly to the
+// state built so far. Unlike ReplayWithVerify it does not stop at the first
+// problem, so every issue is reported.
+//
+// The returned error is reserved for failures to read the ledger; problems
+// with its contents are reported as issues.
+func VerifyLedger(ldg *ledger.Ledger) (*LedgerVerification, error) {
+	if ldg == nil {
+		return nil, fmt.Errorf("cannot verify nil ledger")
+	}
+
+	chainStart, err := ldg.ChainStart()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LedgerVerification{}
+	st := NewState()
+	chain := ledger.NewChainVerifierFrom(chainStart)
+	expectedSeq := 1
+
+	err = ldg.Scan(func(seq int, data []byte) error {
+		report.EventsChecked++
+
+		if seq > expectedSeq {
+			missing := fmt.Sprintf("%d", expectedSeq)
+			if seq-1 > expectedSeq {
+				missing = fmt.Sprintf("%d-%d", expectedSeq, seq-1)
+			}
+			report.Issues = append(report.Issues, LedgerIssue{
+				Kind:    LedgerIssueSequenceGap,
+				Seq:     expectedSeq,
+				Message: fmt.Sprintf("ledger is missing event(s) %s", missing),
+			})
+		}
+		expectedSeq = seq + 1
+
+		if err := chain.Verify(seq, data); err != nil {
+			report.Issues = append(report.Issues, LedgerIssue{
+				Kind:    LedgerIssueChainBroken,
+				Seq:     seq,
+				Message: err.Error(),
+			})
+		}
+
+		event, err := parseEvent(data)
+		if err != nil {
+			report.Issues = append(report.Issues, LedgerIssue{
+				Kind:    LedgerIssueInvalidEvent,
+				Seq:     seq,
+				Message: err.Error(),
+			})
+			return nil
+		}
+
+		if created, ok := event.(ledger.NodeCreated); ok && !created.Node.VerifyContentHash() {
+			report.Issues = append(report.Issues, LedgerIssue{
+				Kind:    LedgerIssueContentHash,
+				Seq:     seq,
+				NodeID:  created.Node.ID,
+				Message: fmt.Sprintf("node %s content hash does not match its content", created.Node.ID.String()),
+			})
+		}
+
+		if err := Apply(st, event); err != nil {
+			report.Issues = append(report.Issues, LedgerIssue{
+				Kind:    LedgerIssueInvalidEvent,
+				Seq:     seq,
+				Message: fmt.Sprintf("cannot apply %s: %v", event.Type(), err),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.Chained = chain.Chained()
+	return report, nil
+}
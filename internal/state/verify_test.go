@@ -0,0 +1,169 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/tobias/vibefeld/internal/ledger"
+	"github.com/tobias/vibefeld/internal/node"
+	"github.com/tobias/vibefeld/internal/schema"
+)
+
+// newVerifyTestLedger appends a proof initialization and nodes 1 and 1.1
+// to a fresh ledger and returns its directory.
+func newVerifyTestLedger(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	if _, err := ledger.Append(dir, ledger.NewProofInitialized("Conjecture", "agent")); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "1.1"} {
+		n, err := node.NewNode(mustParseNodeID(t, id), schema.NodeTypeClaim, "Claim "+id, schema.InferenceAssumption)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ledger.Append(dir, ledger.NewNodeCreated(*n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func verifyTestLedger(t *testing.T, dir string) *LedgerVerification {
+	t.Helper()
+	ldg, err := ledger.NewLedger(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := VerifyLedger(ldg)
+	if err != nil {
+		t.Fatalf("VerifyLedger failed: %v", err)
+	}
+	return report
+}
+
+func TestVerifyLedger_Clean(t *testing.T) {
+	report := verifyTestLedger(t, newVerifyTestLedger(t))
+
+	if !report.OK() {
+		t.Errorf("Issues = %+v, want none", report.Issues)
+	}
+	if report.EventsChecked != 3 {
+		t.Errorf("EventsChecked = %d, want 3", report.EventsChecked)
+	}
+	if !report.Chained {
+		t.Error("Chained = false, want true")
+	}
+}
+
+func TestVerifyLedger_ReportsEveryIssue(t *testing.T) {
+	dir := newVerifyTestLedger(t)
+
+	// Corrupt the content hash of node 1.1 (event 3) and rewrite the
+	// conjecture of event 1, which breaks the chain at event 2
+	path := filepath.Join(dir, ledger.GenerateFilename(3))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "Claim 1.1", "Claim 1.1 (edited)", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path = filepath.Join(dir, ledger.GenerateFilename(1))
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "Conjecture", "Edited", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyTestLedger(t, dir)
+
+	if report.EventsChecked != 3 {
+		t.Errorf("EventsChecked = %d, want 3", report.EventsChecked)
+	}
+	if len(report.Issues) != 2 {
+		t.Fatalf("Issues = %+v, want 2", report.Issues)
+	}
+	if got := report.Issues[0]; got.Kind != LedgerIssueChainBroken || got.Seq != 2 {
+		t.Errorf("Issues[0] = %+v, want chain_broken at seq 2", got)
+	}
+	if got := report.Issues[1]; got.Kind != LedgerIssueContentHash || got.Seq != 3 || got.NodeID.String() != "1.1" {
+		t.Errorf("Issues[1] = %+v, want content_hash_mismatch at seq 3 for node 1.1", got)
+	}
+}
+
+func TestVerifyLedger_SequenceGap(t *testing.T) {
+	dir := newVerifyTestLedger(t)
+	if err := os.Remove(filepath.Join(dir, ledger.GenerateFilename(2))); err != nil {
+		t.Fatal(err)
+	}
+
+	report := verifyTestLedger(t, dir)
+
+	if report.EventsChecked != 2 {
+		t.Errorf("EventsChecked = %d, want 2", report.EventsChecked)
+	}
+	kinds := make(map[string]int)
+	for _, issue := range report.Issues {
+		kinds[issue.Kind] = issue.Seq
+	}
+	if seq, ok := kinds[LedgerIssueSequenceGap]; !ok || seq != 2 {
+		t.Errorf("Issues = %+v, want a sequence_gap at seq 2", report.Issues)
+	}
+	// The event after the gap no longer links to its predecessor
+	if seq, ok := kinds[LedgerIssueChainBroken]; !ok || seq != 3 {
+		t.Errorf("Issues = %+v, want a chain_broken at seq 3", report.Issues)
+	}
+}
+
+// TestVerifyLedger_StrippedChain edits an event and strips the prev_hash of
+// every event, which would make a chained ledger look like one written
+// before hash chaining. The recorded chain start still catches it.
+func TestVerifyLedger_StrippedChain(t *testing.T) {
+	dir := newVerifyTestLedger(t)
+
+	prevHash := regexp.MustCompile(`,"prev_hash":"[0-9a-f]{64}"`)
+	for seq := 1; seq <= 3; seq++ {
+		path := filepath.Join(dir, ledger.GenerateFilename(seq))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stripped := prevHash.ReplaceAll(data, nil)
+		if seq == 1 {
+			stripped = []byte(strings.Replace(string(stripped), "Conjecture", "Edited", 1))
+		}
+		if err := os.WriteFile(path, stripped, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report := verifyTestLedger(t, dir)
+
+	if len(report.Issues) != 3 {
+		t.Fatalf("Issues = %+v, want a chain_broken for each of the 3 events", report.Issues)
+	}
+	for i, issue := range report.Issues {
+		if issue.Kind != LedgerIssueChainBroken || issue.Seq != i+1 {
+			t.Errorf("Issues[%d] = %+v, want chain_broken at seq %d", i, issue, i+1)
+		}
+	}
+	if !report.Chained {
+		t.Error("Chained = false, want true")
+	}
+
+	ldg, err := ledger.NewLedger(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReplayWithVerify(ldg); !errors.Is(err, ledger.ErrChainBroken) {
+		t.Errorf("ReplayWithVerify error = %v, want ErrChainBroken", err)
+	}
+}
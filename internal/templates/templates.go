@@ -3,6 +3,8 @@
 package templates
 
 import (
+	"sort"
+
 	"github.com/tobias/vibefeld/internal/schema"
 )
 
@@ -35,7 +37,8 @@ type Template struct {
 	Children []ChildSpec
 }
 
-// registry contains all available templates
+// registry contains all available templates, keyed by name. Templates are
+// pure data: a new template needs only an entry here.
 var registry = map[string]Template{
 	"contradiction": {
 		Name:        "contradiction",
@@ -94,17 +97,23 @@ func Get(name string) (Template, bool) {
 	return tmpl, ok
 }
 
-// List returns all available templates in a consistent order.
+// List returns all available templates sorted by name.
 func List() []Template {
-	// Return in alphabetical order for consistency
-	return []Template{
-		registry["cases"],
-		registry["contradiction"],
-		registry["induction"],
+	names := Names()
+	list := make([]Template, len(names))
+	for i, name := range names {
+		list[i] = registry[name]
 	}
+	return list
 }
 
-// Names returns all available template names in a consistent order.
+// Names returns all available template names in alphabetical order.
+// Adding a template to the registry is enough for it to be listed here.
 func Names() []string {
-	return []string{"cases", "contradiction", "induction"}
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
@@ -129,6 +129,32 @@ func TestTemplateDescription(t *testing.T) {
 	}
 }
 
+func TestRegistry_WellFormed(t *testing.T) {
+	names := Names()
+	if len(names) != len(registry) {
+		t.Fatalf("Names() returned %d names, registry has %d templates", len(names), len(registry))
+	}
+	for i, tmpl := range List() {
+		if tmpl.Name != names[i] {
+			t.Errorf("List()[%d] = %q, want %q (sorted by name)", i, tmpl.Name, names[i])
+		}
+		if len(tmpl.Children) == 0 {
+			t.Errorf("template %q has no children", tmpl.Name)
+		}
+		for j, child := range tmpl.Children {
+			if err := schema.ValidateNodeType(string(child.NodeType)); err != nil {
+				t.Errorf("template %q child %d: %v", tmpl.Name, j+1, err)
+			}
+			if err := schema.ValidateInference(string(child.Inference)); err != nil {
+				t.Errorf("template %q child %d: %v", tmpl.Name, j+1, err)
+			}
+			if child.StatementTemplate == "" {
+				t.Errorf("template %q child %d has no statement", tmpl.Name, j+1)
+			}
+		}
+	}
+}
+
 // contains checks if s contains substr (case-insensitive)
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || containsLower(toLower(s), toLower(substr)))
@@ -159,6 +159,12 @@ func (n NodeID) IsAncestorOf(other NodeID) bool {
 	return true
 }
 
+// IsDescendantOf returns true if this NodeID is a descendant of other.
+// A node is not considered a descendant of itself.
+func (n NodeID) IsDescendantOf(other NodeID) bool {
+	return other.IsAncestorOf(n)
+}
+
 // CommonAncestor returns the lowest common ancestor of this NodeID and other.
 // If one node is an ancestor of the other, returns that ancestor.
 // If the nodes are the same, returns that node.
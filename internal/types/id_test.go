@@ -296,6 +296,9 @@ func TestIsAncestorOf(t *testing.T) {
 		{"different branch", "1.1", "1.2.1", false},
 		{"different branch reverse", "1.2.1", "1.1", false},
 		{"uncle is not ancestor of nephew", "1.1", "1.2.1.1", false},
+		{"1.1 is not ancestor of 1.10", "1.1", "1.10", false},
+		{"1.1 is not ancestor of 1.10.1", "1.1", "1.10.1", false},
+		{"1.1 is not ancestor of 1.11.1", "1.1", "1.11.1", false},
 	}
 
 	for _, tt := range tests {
@@ -319,6 +322,53 @@ func TestIsAncestorOf(t *testing.T) {
 	}
 }
 
+// TestIsDescendantOf verifies descendant detection, including prefix-like
+// siblings that share leading digits.
+func TestIsDescendantOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		descendant string
+		ancestor   string
+		want       bool
+	}{
+		{"child of root", "1.1", "1", true},
+		{"deep descendant of root", "1.2.3.4.5", "1", true},
+		{"grandchild of child", "1.1.1", "1.1", true},
+		{"deep descendant of intermediate", "1.2.3.4.5", "1.2.3", true},
+		{"tenth child subtree of tenth child", "1.10.2", "1.10", true},
+
+		{"root is not descendant of itself", "1", "1", false},
+		{"node is not descendant of itself", "1.2.3", "1.2.3", false},
+		{"root is not descendant of child", "1", "1.1", false},
+		{"parent is not descendant of child", "1.1", "1.1.1", false},
+		{"sibling is not descendant", "1.2", "1.1", false},
+		{"1.10 is not descendant of 1.1", "1.10", "1.1", false},
+		{"1.10.1 is not descendant of 1.1", "1.10.1", "1.1", false},
+		{"1.1.10 is not descendant of 1.1.1", "1.1.10", "1.1.1", false},
+		{"cousin is not descendant", "1.2.1", "1.1.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			descendant, err := Parse(tt.descendant)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.descendant, err)
+			}
+
+			ancestor, err := Parse(tt.ancestor)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.ancestor, err)
+			}
+
+			got := descendant.IsDescendantOf(ancestor)
+			if got != tt.want {
+				t.Errorf("Parse(%q).IsDescendantOf(Parse(%q)) = %v, want %v",
+					tt.descendant, tt.ancestor, got, tt.want)
+			}
+		})
+	}
+}
+
 // TestCommonAncestor verifies finding common ancestors
 func TestCommonAncestor(t *testing.T) {
 	tests := []struct {
@@ -59,6 +59,21 @@ func (ts Timestamp) Equal(other Timestamp) bool {
 	return ts.t.Equal(other.t)
 }
 
+// Add returns the timestamp ts+d.
+func (ts Timestamp) Add(d time.Duration) Timestamp {
+	return Timestamp{t: ts.t.Add(d)}
+}
+
+// Sub returns the duration ts-other.
+func (ts Timestamp) Sub(other Timestamp) time.Duration {
+	return ts.t.Sub(other.t)
+}
+
+// Time returns ts as a time.Time in UTC.
+func (ts Timestamp) Time() time.Time {
+	return ts.t
+}
+
 // IsZero returns true if ts is the zero value.
 func (ts Timestamp) IsZero() bool {
 	return ts.t.IsZero()
@@ -655,3 +655,35 @@ func TestStringParseRoundtrip_NanosecondPrecision(t *testing.T) {
 		})
 	}
 }
+
+func TestAdd_Sub(t *testing.T) {
+	ts, err := ParseTimestamp("2025-01-11T10:05:00Z")
+	if err != nil {
+		t.Fatalf("ParseTimestamp error: %v", err)
+	}
+
+	later := ts.Add(90 * time.Minute)
+	if got := later.String(); got != "2025-01-11T11:35:00Z" {
+		t.Errorf("Add(90m) = %q, want %q", got, "2025-01-11T11:35:00Z")
+	}
+	if got := later.Sub(ts); got != 90*time.Minute {
+		t.Errorf("Sub() = %v, want %v", got, 90*time.Minute)
+	}
+	if got := ts.Sub(later); got != -90*time.Minute {
+		t.Errorf("Sub() = %v, want %v", got, -90*time.Minute)
+	}
+}
+
+func TestTime_FromTimeRoundtrip(t *testing.T) {
+	want := time.Date(2025, 1, 11, 10, 5, 0, 123, time.FixedZone("CET", 3600))
+	got := FromTime(want).Time()
+	if !got.Equal(want) {
+		t.Errorf("FromTime(t).Time() = %v, want %v", got, want)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("Time() location = %v, want UTC", got.Location())
+	}
+	if !(Timestamp{}).Time().IsZero() {
+		t.Error("zero Timestamp.Time() should be the zero time")
+	}
+}